@@ -0,0 +1,137 @@
+// entity-fields generates for each DB entity of a package (structs implementing Table()) a variable which lists the names
+// of its fields. Queries refer to these names instead of string literals: renamed or removed fields break the
+// build instead of failing at runtime when the column handler can't resolve the field.
+//
+// The generated names are combined with the conditions of the query builder in pkg/db (Where, WhereCompare and
+// WhereInValues), which resolve the columns and placeholders. Entity-specific predicates aren't generated: statements
+// which the query builder can't express (aggregations, sub-queries, DB specific interval arithmetic like the cluster
+// status filters, batched updates of the key rotation) stay hand-written and resolve their columns with the
+// column handler.
+//
+// Usage (within the package of the entities):
+//
+//	//go:generate go run ../../cmd/generators/entity-fields -output fields_gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+type entity struct {
+	name   string
+	fields []string
+}
+
+func main() {
+	output := flag.String("output", "fields_gen.go", "Generated file")
+	flag.Parse()
+
+	pkgName, entities, err := parseEntities(".", *output)
+	if err != nil {
+		log.Fatalf("Failed to parse entities: %s", err)
+	}
+	src, err := render(pkgName, entities)
+	if err != nil {
+		log.Fatalf("Failed to render entity fields: %s", err)
+	}
+	if err := os.WriteFile(*output, src, 0600); err != nil {
+		log.Fatalf("Failed to write '%s': %s", *output, err)
+	}
+}
+
+func parseEntities(dir, output string) (string, []*entity, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go") && info.Name() != output
+	}, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(pkgs) != 1 {
+		return "", nil, fmt.Errorf("expected one package in directory '%s' but found %d", dir, len(pkgs))
+	}
+
+	var pkgName string
+	structs := make(map[string]*ast.StructType)
+	tables := make(map[string]bool)
+	for name, pkg := range pkgs {
+		pkgName = name
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				switch decl := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+							if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+								structs[typeSpec.Name.Name] = structType
+							}
+						}
+					}
+				case *ast.FuncDecl:
+					if decl.Name.Name == "Table" && decl.Recv != nil && len(decl.Recv.List) == 1 {
+						tables[receiverType(decl.Recv.List[0].Type)] = true
+					}
+				}
+			}
+		}
+	}
+
+	var entities []*entity
+	for name := range tables {
+		structType, ok := structs[name]
+		if !ok {
+			continue
+		}
+		entity := &entity{name: name}
+		for _, field := range structType.Fields.List {
+			for _, ident := range field.Names {
+				if ident.IsExported() {
+					entity.fields = append(entity.fields, ident.Name)
+				}
+			}
+		}
+		entities = append(entities, entity)
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].name < entities[j].name
+	})
+	return pkgName, entities, nil
+}
+
+func receiverType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func render(pkgName string, entities []*entity) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by entity-fields. DO NOT EDIT.\n\npackage %s\n", pkgName)
+	for _, entity := range entities {
+		fmt.Fprintf(&buf, "\n// %sFields lists the fields of %s which are mapped to DB columns\n", entity.name, entity.name)
+		fmt.Fprintf(&buf, "var %sFields = struct {\n", entity.name)
+		for _, field := range entity.fields {
+			fmt.Fprintf(&buf, "%s string\n", field)
+		}
+		buf.WriteString("}{\n")
+		for _, field := range entity.fields {
+			fmt.Fprintf(&buf, "%s: %q,\n", field, field)
+		}
+		buf.WriteString("}\n")
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	freezeCmd "github.com/kyma-incubator/reconciler/cmd/mothership/cluster/freeze"
+	intervalCmd "github.com/kyma-incubator/reconciler/cmd/mothership/cluster/interval"
+	scheduleCmd "github.com/kyma-incubator/reconciler/cmd/mothership/cluster/schedule"
+	simulateCmd "github.com/kyma-incubator/reconciler/cmd/mothership/cluster/simulate"
+	statusCmd "github.com/kyma-incubator/reconciler/cmd/mothership/cluster/status"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Inspect clusters managed by the mothership",
+		Long:  "Administrative CLI tool to inspect clusters using the API of a running mothership",
+	}
+
+	cmd.AddCommand(statusCmd.NewCmd(statusCmd.NewOptions(o)))
+	cmd.AddCommand(scheduleCmd.NewCmd(scheduleCmd.NewOptions(o)))
+	cmd.AddCommand(intervalCmd.NewCmd(intervalCmd.NewOptions(o)))
+	cmd.AddCommand(simulateCmd.NewCmd(simulateCmd.NewOptions(o)))
+	cmd.AddCommand(freezeCmd.NewCmd(freezeCmd.NewOptions(o)))
+
+	return cmd
+}
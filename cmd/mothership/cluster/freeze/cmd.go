@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+const allScope = "*"
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "freeze",
+		Short: "Stop and resume the scheduling of new operations.",
+		Long: `Emergency brake for incidents of the control plane: a freeze stops the scheduling of new operations
+of a component or of all components. Operations in progress are finished (use the stop API of an operation to
+cancel it). Who engaged and released a freeze is recorded.`,
+	}
+	cmd.PersistentFlags().StringVar(&o.MothershipURL, "mothership-url", "http://localhost:8080", "URL of the mothership API")
+	cmd.PersistentFlags().StringVar(&o.Token, "token", "", "Bearer token used to authenticate at the mothership")
+	cmd.PersistentFlags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(cli.SupportedOutputFormats, "', '")))
+
+	cmd.AddCommand(newEngageCmd(o), newListCmd(o), newReleaseCmd(o))
+	return cmd
+}
+
+func newEngageCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "engage",
+		Short: "Stop the scheduling of new operations.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunEngage(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	addScopeFlags(cmd, o)
+	cmd.Flags().StringVar(&o.Reason, "reason", "", "Reason of the freeze (e.g. an incident ID)")
+	return cmd
+}
+
+func newListCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the engaged freezes.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunList(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	cmd.Flags().BoolVar(&o.Released, "released", false, "Include released freezes")
+	return cmd
+}
+
+func newReleaseCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Resume the scheduling of new operations.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunRelease(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	addScopeFlags(cmd, o)
+	return cmd
+}
+
+func addScopeFlags(cmd *cobra.Command, o *Options) {
+	cmd.Flags().StringVar(&o.Component, "component", "", "Component the freeze applies to")
+	cmd.Flags().BoolVar(&o.All, "all", false, "Freeze applies to all components (fleet-wide emergency stop)")
+}
+
+func RunEngage(ctx context.Context, o *Options, out io.Writer) error {
+	if err := o.validateScope(); err != nil {
+		return err
+	}
+	if strings.TrimSpace(o.Reason) == "" {
+		return fmt.Errorf("reason of the freeze is undefined: use --reason")
+	}
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	freeze, err := mothership.EngageFreeze(ctx, o.Component, o.Reason)
+	if err != nil {
+		return err
+	}
+	return renderFreezes(o, keb.HTTPFreezes{*freeze}, out)
+}
+
+func RunList(ctx context.Context, o *Options, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	freezes, err := mothership.GetFreezes(ctx, o.Released)
+	if err != nil {
+		return err
+	}
+	return renderFreezes(o, freezes, out)
+}
+
+func RunRelease(ctx context.Context, o *Options, out io.Writer) error {
+	if err := o.validateScope(); err != nil {
+		return err
+	}
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	freeze, err := mothership.ReleaseFreeze(ctx, o.Component)
+	if err != nil {
+		return err
+	}
+	return renderFreezes(o, keb.HTTPFreezes{*freeze}, out)
+}
+
+func renderFreezes(o *Options, freezes keb.HTTPFreezes, out io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Component", "Reason", "Engaged by", "Engaged", "Released by", "Released"); err != nil {
+		return err
+	}
+	for _, freeze := range freezes {
+		var releasedBy, releasedAt string
+		if freeze.Released {
+			releasedBy = value(freeze.ReleasedBy)
+			if freeze.ReleasedAt != nil {
+				releasedAt = freeze.ReleasedAt.Format(time.RFC3339)
+			}
+		}
+		component := value(freeze.Component)
+		if component == "" {
+			component = allScope
+		}
+		if err := formatter.AddRow(component, freeze.Reason, freeze.EngagedBy, freeze.Created.Format(time.RFC3339),
+			releasedBy, releasedAt); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(out)
+}
+
+func value(ptr *string) string {
+	if ptr == nil {
+		return ""
+	}
+	return *ptr
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreezeCmd(t *testing.T) {
+	component := "istio"
+	reason := "INC-4711"
+	releasedBy := "ops"
+	created := time.Now().UTC()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/freezes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body := &keb.FreezeUpdate{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+			require.Nil(t, body.Component)
+			require.Equal(t, reason, body.Reason)
+			require.NoError(t, json.NewEncoder(w).Encode(&keb.Freeze{
+				Id: 1, Reason: body.Reason, EngagedBy: "ops", Created: created}))
+		case http.MethodGet:
+			freezes := keb.HTTPFreezes{{Id: 1, Reason: reason, EngagedBy: "ops", Created: created}}
+			if r.URL.Query().Get("released") == "true" {
+				freezes = append(freezes, keb.Freeze{Id: 2, Component: &component, Reason: "maintenance",
+					EngagedBy: "ops", Released: true, ReleasedBy: &releasedBy, ReleasedAt: &created, Created: created})
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(freezes))
+		case http.MethodDelete:
+			if r.URL.Query().Get("component") != component {
+				w.WriteHeader(http.StatusNotFound)
+				require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPErrorResponse{Error: "not found"}))
+				return
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(&keb.Freeze{Id: 2, Component: &component, Reason: reason,
+				EngagedBy: "ops", Released: true, ReleasedBy: &releasedBy, ReleasedAt: &created, Created: created}))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	newOptions := func() *Options {
+		o := NewOptions(&cli.Options{OutputFormat: "table"})
+		o.MothershipURL = srv.URL
+		return o
+	}
+
+	t.Run("Engage fleet-wide freeze", func(t *testing.T) {
+		o := newOptions()
+		o.All = true
+		o.Reason = reason
+		out := &bytes.Buffer{}
+		require.NoError(t, RunEngage(context.Background(), o, out))
+		require.Contains(t, out.String(), allScope)
+		require.Contains(t, out.String(), reason)
+	})
+
+	t.Run("Engage freeze without reason", func(t *testing.T) {
+		o := newOptions()
+		o.All = true
+		require.Error(t, RunEngage(context.Background(), o, &bytes.Buffer{}))
+	})
+
+	t.Run("Engage freeze without scope", func(t *testing.T) {
+		o := newOptions()
+		o.Reason = reason
+		require.Error(t, RunEngage(context.Background(), o, &bytes.Buffer{}))
+
+		o.All = true
+		o.Component = component
+		require.Error(t, RunEngage(context.Background(), o, &bytes.Buffer{}))
+	})
+
+	t.Run("List freezes", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, RunList(context.Background(), newOptions(), out))
+		require.Contains(t, out.String(), reason)
+		require.NotContains(t, out.String(), "maintenance")
+
+		o := newOptions()
+		o.Released = true
+		out = &bytes.Buffer{}
+		require.NoError(t, RunList(context.Background(), o, out))
+		require.Contains(t, out.String(), "maintenance")
+	})
+
+	t.Run("Release freeze", func(t *testing.T) {
+		o := newOptions()
+		o.Component = component
+		out := &bytes.Buffer{}
+		require.NoError(t, RunRelease(context.Background(), o, out))
+		require.Contains(t, out.String(), component)
+		require.Contains(t, out.String(), "ops")
+	})
+
+	t.Run("Release unknown freeze", func(t *testing.T) {
+		o := newOptions()
+		o.Component = "serverless"
+		require.Error(t, RunRelease(context.Background(), o, &bytes.Buffer{}))
+	})
+}
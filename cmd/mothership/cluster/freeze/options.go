@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+)
+
+type Options struct {
+	*cli.Options
+	MothershipURL string
+	Token         string
+	Component     string
+	All           bool
+	Reason        string
+	Released      bool
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, "", "", "", false, "", false}
+}
+
+func (o *Options) Validate() error {
+	if o.MothershipURL == "" {
+		return fmt.Errorf("mothership URL is undefined")
+	}
+	return nil
+}
+
+// validateScope ensures that a freeze of all components is requested explicitly
+func (o *Options) validateScope() error {
+	if o.All == (o.Component != "") {
+		return fmt.Errorf("either a component (--component) or all components (--all) have to be defined")
+	}
+	return nil
+}
+
+func (o *Options) client() (*client.MothershipClient, error) {
+	var opts []client.Option
+	if o.HTTPClient != nil {
+		opts = append(opts, client.WithHTTPClient(httpclient.New(o.HTTPClient)))
+	}
+	if o.Token != "" {
+		opts = append(opts, client.WithBearerToken(o.Token))
+	}
+	return client.NewMothershipClient(o.MothershipURL, opts...)
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+const allScope = "*"
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "interval",
+		Short: "Manage the periodic reconcile interval of clusters and components.",
+		Long: `Override the global periodic reconcile interval for a cluster, for a component (on all clusters)
+or for a component of a particular cluster (e.g. reconcile a monitoring integration hourly but istio daily).`,
+	}
+	cmd.PersistentFlags().StringVar(&o.MothershipURL, "mothership-url", "http://localhost:8080", "URL of the mothership API")
+	cmd.PersistentFlags().StringVar(&o.Token, "token", "", "Bearer token used to authenticate at the mothership")
+	cmd.PersistentFlags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(cli.SupportedOutputFormats, "', '")))
+
+	cmd.AddCommand(newSetCmd(o), newListCmd(o), newRemoveCmd(o))
+	return cmd
+}
+
+func newSetCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Override the reconcile interval.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunSet(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	addScopeFlags(cmd, o)
+	cmd.Flags().DurationVar(&o.Interval, "interval", 0, "Reconcile interval (e.g. 1h or 24h)")
+	return cmd
+}
+
+func newListCmd(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the reconcile interval overrides.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunList(cli.NewContext(), o, os.Stdout)
+		},
+	}
+}
+
+func newRemoveCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a reconcile interval override.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunRemove(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	addScopeFlags(cmd, o)
+	return cmd
+}
+
+func addScopeFlags(cmd *cobra.Command, o *Options) {
+	cmd.Flags().StringVar(&o.RuntimeID, "runtime-id", "", "Cluster the interval applies to (default: all clusters)")
+	cmd.Flags().StringVar(&o.Component, "component", "", "Component the interval applies to (default: all components)")
+}
+
+func RunSet(ctx context.Context, o *Options, out io.Writer) error {
+	if o.Interval < time.Second {
+		return fmt.Errorf("reconcile interval has to be >= 1s: use --interval")
+	}
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	override, err := mothership.SetReconcileInterval(ctx, o.RuntimeID, o.Component, o.Interval)
+	if err != nil {
+		return err
+	}
+	return renderIntervals(o, keb.HTTPReconcileIntervals{*override}, out)
+}
+
+func RunList(ctx context.Context, o *Options, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	overrides, err := mothership.GetReconcileIntervals(ctx)
+	if err != nil {
+		return err
+	}
+	return renderIntervals(o, overrides, out)
+}
+
+func RunRemove(ctx context.Context, o *Options, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	if err := mothership.RemoveReconcileInterval(ctx, o.RuntimeID, o.Component); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Reconcile interval of cluster '%s' and component '%s' removed\n",
+		scope(&o.RuntimeID), scope(&o.Component))
+	return err
+}
+
+func renderIntervals(o *Options, overrides keb.HTTPReconcileIntervals, out io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Cluster", "Component", "Interval"); err != nil {
+		return err
+	}
+	for _, override := range overrides {
+		interval := time.Duration(override.IntervalSeconds) * time.Second
+		if err := formatter.AddRow(scope(override.RuntimeID), scope(override.Component), interval.String()); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(out)
+}
+
+// scope renders an undefined cluster or component as wildcard
+func scope(value *string) string {
+	if value == nil || *value == "" {
+		return allScope
+	}
+	return *value
+}
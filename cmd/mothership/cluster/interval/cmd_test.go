@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalCmd(t *testing.T) {
+	runtimeID := "runtime1"
+	component := "istio"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/intervals", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			body := &keb.ReconcileIntervalUpdate{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+			require.Nil(t, body.RuntimeID)
+			require.Equal(t, component, *body.Component)
+			require.Equal(t, int64(86400), body.IntervalSeconds)
+			require.NoError(t, json.NewEncoder(w).Encode(&keb.ReconcileInterval{
+				Component: body.Component, IntervalSeconds: body.IntervalSeconds}))
+		case http.MethodGet:
+			require.NoError(t, json.NewEncoder(w).Encode(keb.HTTPReconcileIntervals{
+				{Component: &component, IntervalSeconds: 86400},
+				{RuntimeID: &runtimeID, IntervalSeconds: 3600},
+			}))
+		case http.MethodDelete:
+			if r.URL.Query().Get("runtimeID") != runtimeID {
+				w.WriteHeader(http.StatusNotFound)
+				require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPErrorResponse{Error: "not found"}))
+			}
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	newOptions := func() *Options {
+		o := NewOptions(&cli.Options{OutputFormat: "table"})
+		o.MothershipURL = srv.URL
+		return o
+	}
+
+	t.Run("Set reconcile interval", func(t *testing.T) {
+		o := newOptions()
+		o.Component = component
+		o.Interval = 24 * time.Hour
+		out := &bytes.Buffer{}
+		require.NoError(t, RunSet(context.Background(), o, out))
+		require.Contains(t, out.String(), component)
+		require.Contains(t, out.String(), "24h0m0s")
+	})
+
+	t.Run("Set reconcile interval without interval", func(t *testing.T) {
+		o := newOptions()
+		o.Component = component
+		require.Error(t, RunSet(context.Background(), o, &bytes.Buffer{}))
+	})
+
+	t.Run("List reconcile intervals", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, RunList(context.Background(), newOptions(), out))
+		require.Contains(t, out.String(), component)
+		require.Contains(t, out.String(), runtimeID)
+		require.Contains(t, out.String(), "1h0m0s")
+	})
+
+	t.Run("Remove reconcile interval", func(t *testing.T) {
+		o := newOptions()
+		o.RuntimeID = runtimeID
+		out := &bytes.Buffer{}
+		require.NoError(t, RunRemove(context.Background(), o, out))
+		require.Contains(t, out.String(), runtimeID)
+
+		require.Error(t, RunRemove(context.Background(), newOptions(), &bytes.Buffer{}))
+	})
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+)
+
+type Options struct {
+	*cli.Options
+	MothershipURL string
+	Token         string
+	RuntimeID     string
+	Component     string
+	Interval      time.Duration
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, "", "", "", "", 0}
+}
+
+func (o *Options) Validate() error {
+	if o.MothershipURL == "" {
+		return fmt.Errorf("mothership URL is undefined")
+	}
+	return nil
+}
+
+func (o *Options) client() (*client.MothershipClient, error) {
+	var opts []client.Option
+	if o.HTTPClient != nil {
+		opts = append(opts, client.WithHTTPClient(httpclient.New(o.HTTPClient)))
+	}
+	if o.Token != "" {
+		opts = append(opts, client.WithBearerToken(o.Token))
+	}
+	return client.NewMothershipClient(o.MothershipURL, opts...)
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage scheduled one-off reconciliations of clusters.",
+		Long: `Queue a reconciliation of a cluster for a later point in time (e.g. a quiet hour),
+list the pending scheduled reconciliations or cancel them.`,
+	}
+	cmd.PersistentFlags().StringVar(&o.MothershipURL, "mothership-url", "http://localhost:8080", "URL of the mothership API")
+	cmd.PersistentFlags().StringVar(&o.Token, "token", "", "Bearer token used to authenticate at the mothership")
+	cmd.PersistentFlags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(cli.SupportedOutputFormats, "', '")))
+
+	cmd.AddCommand(newCreateCmd(o), newListCmd(o), newCancelCmd(o))
+	return cmd
+}
+
+func newCreateCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create RUNTIME_ID",
+		Short: "Schedule a reconciliation of a cluster.",
+		Long: `Schedule a one-off reconciliation of a cluster which is triggered not before the given point in time.
+If the cluster is still reconciled at this point in time, the scheduled reconciliation starts afterwards.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunCreate(cli.NewContext(), o, args[0], os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.At, "at", "", "Point in time of the reconciliation (RFC3339, e.g. 2022-03-01T02:00:00Z)")
+	cmd.Flags().DurationVar(&o.In, "in", 0, "Delay of the reconciliation relative to now (e.g. 6h)")
+	return cmd
+}
+
+func newListCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the pending scheduled reconciliations.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunList(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.RuntimeID, "runtime-id", "", "Only list the scheduled reconciliations of this cluster")
+	return cmd
+}
+
+func newCancelCmd(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel SCHEDULE_ID",
+		Short: "Cancel a pending scheduled reconciliation.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return RunCancel(cli.NewContext(), o, args[0], os.Stdout)
+		},
+	}
+}
+
+func RunCreate(ctx context.Context, o *Options, runtimeID string, out io.Writer) error {
+	notBefore, err := o.notBefore(time.Now())
+	if err != nil {
+		return err
+	}
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	schedule, err := mothership.ScheduleReconciliation(ctx, runtimeID, notBefore)
+	if err != nil {
+		return err
+	}
+	return renderSchedules(o, keb.HTTPScheduledReconciliations{*schedule}, out)
+}
+
+func RunList(ctx context.Context, o *Options, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	schedules, err := mothership.GetScheduledReconciliations(ctx, o.RuntimeID)
+	if err != nil {
+		return err
+	}
+	return renderSchedules(o, schedules, out)
+}
+
+func RunCancel(ctx context.Context, o *Options, scheduleID string, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	if err := mothership.CancelScheduledReconciliation(ctx, scheduleID); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "Scheduled reconciliation '%s' cancelled\n", scheduleID)
+	return err
+}
+
+func renderSchedules(o *Options, schedules keb.HTTPScheduledReconciliations, out io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("ID", "Cluster", "Not before"); err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if err := formatter.AddRow(schedule.Id, schedule.RuntimeID,
+			schedule.NotBefore.UTC().Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(out)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleCmd(t *testing.T) {
+	notBefore := time.Date(2022, 3, 1, 2, 0, 0, 0, time.UTC)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/schedules", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			body := &keb.ScheduledReconciliationCreate{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+			require.Equal(t, "runtime1", body.RuntimeID)
+			require.True(t, notBefore.Equal(body.NotBefore))
+			require.NoError(t, json.NewEncoder(w).Encode(&keb.ScheduledReconciliation{
+				Id: "schedule1", RuntimeID: body.RuntimeID, NotBefore: body.NotBefore}))
+		case http.MethodGet:
+			require.Equal(t, "runtime1", r.URL.Query().Get("runtimeID"))
+			require.NoError(t, json.NewEncoder(w).Encode(keb.HTTPScheduledReconciliations{
+				{Id: "schedule1", RuntimeID: "runtime1", NotBefore: notBefore},
+				{Id: "schedule2", RuntimeID: "runtime1", NotBefore: notBefore.Add(time.Hour)},
+			}))
+		}
+	})
+	mux.HandleFunc("/v1/schedules/schedule1", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+	})
+	mux.HandleFunc("/v1/schedules/unknown", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPErrorResponse{Error: "not found"}))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	newOptions := func() *Options {
+		o := NewOptions(&cli.Options{OutputFormat: "table"})
+		o.MothershipURL = srv.URL
+		return o
+	}
+
+	t.Run("Schedule reconciliation", func(t *testing.T) {
+		o := newOptions()
+		o.At = notBefore.Format(time.RFC3339)
+		out := &bytes.Buffer{}
+		require.NoError(t, RunCreate(context.Background(), o, "runtime1", out))
+		require.Contains(t, out.String(), "schedule1")
+		require.Contains(t, out.String(), "2022-03-01T02:00:00Z")
+	})
+
+	t.Run("Schedule reconciliation without point in time", func(t *testing.T) {
+		err := RunCreate(context.Background(), newOptions(), "runtime1", &bytes.Buffer{})
+		require.Error(t, err)
+	})
+
+	t.Run("Schedule reconciliation with ambiguous point in time", func(t *testing.T) {
+		o := newOptions()
+		o.At = notBefore.Format(time.RFC3339)
+		o.In = time.Hour
+		require.Error(t, RunCreate(context.Background(), o, "runtime1", &bytes.Buffer{}))
+	})
+
+	t.Run("List scheduled reconciliations", func(t *testing.T) {
+		o := newOptions()
+		o.RuntimeID = "runtime1"
+		out := &bytes.Buffer{}
+		require.NoError(t, RunList(context.Background(), o, out))
+		require.Contains(t, out.String(), "schedule1")
+		require.Contains(t, out.String(), "schedule2")
+	})
+
+	t.Run("Cancel scheduled reconciliation", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, RunCancel(context.Background(), newOptions(), "schedule1", out))
+		require.Contains(t, out.String(), "schedule1")
+
+		require.Error(t, RunCancel(context.Background(), newOptions(), "unknown", &bytes.Buffer{}))
+	})
+}
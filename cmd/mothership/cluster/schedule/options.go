@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+)
+
+type Options struct {
+	*cli.Options
+	MothershipURL string
+	Token         string
+	At            string
+	In            time.Duration
+	RuntimeID     string
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, "", "", "", 0, ""}
+}
+
+func (o *Options) Validate() error {
+	if o.MothershipURL == "" {
+		return fmt.Errorf("mothership URL is undefined")
+	}
+	return nil
+}
+
+// notBefore returns the point in time the reconciliation is scheduled for: either an absolute timestamp (--at)
+// or a delay relative to now (--in)
+func (o *Options) notBefore(now time.Time) (time.Time, error) {
+	switch {
+	case o.At != "" && o.In != 0:
+		return time.Time{}, fmt.Errorf("point in time has to be defined either by --at or by --in but not both")
+	case o.At != "":
+		notBefore, err := time.Parse(time.RFC3339, o.At)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse point in time: %w", err)
+		}
+		return notBefore, nil
+	case o.In > 0:
+		return now.Add(o.In), nil
+	default:
+		return time.Time{}, fmt.Errorf("point in time is undefined: use --at or --in")
+	}
+}
+
+func (o *Options) client() (*client.MothershipClient, error) {
+	var opts []client.Option
+	if o.HTTPClient != nil {
+		opts = append(opts, client.WithHTTPClient(httpclient.New(o.HTTPClient)))
+	}
+	if o.Token != "" {
+		opts = append(opts, client.WithBearerToken(o.Token))
+	}
+	return client.NewMothershipClient(o.MothershipURL, opts...)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate [RUNTIME_ID...]",
+		Short: "Simulate the operations caused by a new Kyma version.",
+		Long: `Simulate the operation plan of a new Kyma version for the given clusters (or for all clusters)
+without executing anything. The plan shows the order of the operations per cluster, the batches of clusters
+which fit into the worker pool of the mothership and durations estimated from historical operations.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return Run(cli.NewContext(), o, args, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.MothershipURL, "mothership-url", "http://localhost:8080", "URL of the mothership API")
+	cmd.Flags().StringVar(&o.Token, "token", "", "Bearer token used to authenticate at the mothership")
+	cmd.Flags().StringVar(&o.KymaVersion, "kyma-version", "", "Proposed Kyma version")
+	cmd.Flags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(supportedOutputFormats, "', '")))
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options, runtimeIDs []string, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	plan, err := mothership.SimulateChange(ctx, o.KymaVersion, runtimeIDs)
+	if err != nil {
+		return err
+	}
+
+	switch o.OutputFormat {
+	case "json":
+		return json.NewEncoder(out).Encode(plan)
+	case "json_pretty":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	default:
+		return renderPlan(plan, out)
+	}
+}
+
+func renderPlan(plan *keb.HTTPSimulationPlan, out io.Writer) error {
+	batchOfCluster := make(map[string]int)
+	for idx, batch := range plan.Batches {
+		for _, runtimeID := range batch.RuntimeIDs {
+			batchOfCluster[runtimeID] = idx + 1
+		}
+	}
+
+	operations, err := cli.NewOutputFormatter("table")
+	if err != nil {
+		return err
+	}
+	if err := operations.Header("Batch", "Cluster", "Priority", "Component", "Current version", "Version",
+		"Estimated duration"); err != nil {
+		return err
+	}
+	var rejected []keb.SimulatedCluster
+	for _, cluster := range plan.Clusters {
+		if cluster.Error != nil {
+			rejected = append(rejected, cluster)
+			continue
+		}
+		for _, group := range cluster.Groups {
+			for _, op := range group.Operations {
+				currentVersion := "-"
+				if op.CurrentVersion != nil {
+					currentVersion = *op.CurrentVersion
+				}
+				if err := operations.AddRow(batchOfCluster[cluster.RuntimeID], cluster.RuntimeID, group.Priority,
+					op.Component, currentVersion, op.Version, estimate(op.EstimatedDuration, op.Historical)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := operations.Output(out); err != nil {
+		return err
+	}
+
+	if len(rejected) > 0 {
+		_, _ = fmt.Fprintln(out)
+		rejections, err := cli.NewOutputFormatter("table")
+		if err != nil {
+			return err
+		}
+		if err := rejections.Header("Rejected cluster", "Reason"); err != nil {
+			return err
+		}
+		for _, cluster := range rejected {
+			if err := rejections.AddRow(cluster.RuntimeID, *cluster.Error); err != nil {
+				return err
+			}
+		}
+		if err := rejections.Output(out); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(out, "\n%d operations on %d clusters in %d batches (estimated duration: %s)\n",
+		plan.Operations, len(plan.Clusters)-len(rejected), len(plan.Batches), milliseconds(plan.EstimatedDuration))
+	return err
+}
+
+// estimate renders an estimated duration and marks estimations which aren't based on historical operations
+func estimate(ms int64, historical bool) string {
+	if historical {
+		return milliseconds(ms).String()
+	}
+	return fmt.Sprintf("%s (default)", milliseconds(ms))
+}
+
+func milliseconds(ms int64) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateCmd(t *testing.T) {
+	currentVersion := "1.0.0"
+	reason := "pin violated"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/simulations", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+		body := &keb.SimulationRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(body))
+		require.Equal(t, "2.0.0", body.KymaVersion)
+		require.Equal(t, []string{"runtime1", "runtime2"}, *body.RuntimeIDs)
+		require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPSimulationPlan{
+			KymaVersion:       body.KymaVersion,
+			Operations:        1,
+			EstimatedDuration: 120000,
+			Clusters: []keb.SimulatedCluster{
+				{
+					RuntimeID:         "runtime1",
+					CurrentVersion:    currentVersion,
+					Operations:        1,
+					EstimatedDuration: 120000,
+					Groups: []keb.SimulatedOperationGroup{
+						{Priority: 1, EstimatedDuration: 120000, Operations: []keb.SimulatedOperation{
+							{Component: "istio", Version: "2.0.0", CurrentVersion: &currentVersion, EstimatedDuration: 120000, Historical: true},
+						}},
+					},
+				},
+				{RuntimeID: "runtime2", CurrentVersion: currentVersion, Error: &reason},
+			},
+			Batches: []keb.SimulatedBatch{{RuntimeIDs: []string{"runtime1"}, Operations: 1, EstimatedDuration: 120000}},
+		}))
+	}))
+	defer srv.Close()
+
+	newOptions := func(format string) *Options {
+		o := NewOptions(&cli.Options{OutputFormat: format})
+		o.MothershipURL = srv.URL
+		o.KymaVersion = "2.0.0"
+		return o
+	}
+
+	t.Run("Render plan as table", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, Run(context.Background(), newOptions("table"), []string{"runtime1", "runtime2"}, out))
+		require.Contains(t, out.String(), "istio")
+		require.Contains(t, out.String(), "2m0s")
+		require.Contains(t, out.String(), reason)
+		require.Contains(t, out.String(), "1 operations on 1 clusters in 1 batches (estimated duration: 2m0s)")
+	})
+
+	t.Run("Render plan as JSON", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		require.NoError(t, Run(context.Background(), newOptions("json"), []string{"runtime1", "runtime2"}, out))
+		plan := &keb.HTTPSimulationPlan{}
+		require.NoError(t, json.NewDecoder(out).Decode(plan))
+		require.Len(t, plan.Clusters, 2)
+	})
+
+	t.Run("Kyma version is required", func(t *testing.T) {
+		o := newOptions("table")
+		o.KymaVersion = ""
+		require.Error(t, o.Validate())
+	})
+}
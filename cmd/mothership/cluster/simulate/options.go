@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+)
+
+var supportedOutputFormats = []string{"table", "json", "json_pretty"}
+
+type Options struct {
+	*cli.Options
+	MothershipURL string
+	Token         string
+	KymaVersion   string
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, "", "", ""}
+}
+
+func (o *Options) Validate() error {
+	if o.MothershipURL == "" {
+		return fmt.Errorf("mothership URL is undefined")
+	}
+	if o.KymaVersion == "" {
+		return fmt.Errorf("Kyma version is undefined: use --kyma-version")
+	}
+	for _, format := range supportedOutputFormats {
+		if format == o.OutputFormat {
+			return nil
+		}
+	}
+	return fmt.Errorf("output format '%s' not supported: choose one of '%s'",
+		o.OutputFormat, strings.Join(supportedOutputFormats, "', '"))
+}
+
+func (o *Options) client() (*client.MothershipClient, error) {
+	var opts []client.Option
+	if o.HTTPClient != nil {
+		opts = append(opts, client.WithHTTPClient(httpclient.New(o.HTTPClient)))
+	}
+	if o.Token != "" {
+		opts = append(opts, client.WithBearerToken(o.Token))
+	}
+	return client.NewMothershipClient(o.MothershipURL, opts...)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status RUNTIME_ID",
+		Short: "Show the status of a cluster.",
+		Long: `Show the status of the latest configuration of a cluster.
+With --watch, the status is polled and each transition is printed with a timestamp until the cluster
+reached a terminal status. The command fails if the terminal status is an error.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return Run(cli.NewContext(), o, args[0], os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.MothershipURL, "mothership-url", "http://localhost:8080", "URL of the mothership API")
+	cmd.Flags().StringVar(&o.Token, "token", "", "Bearer token used to authenticate at the mothership")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", false, "Watch the status until the cluster reached a terminal status")
+	cmd.Flags().DurationVar(&o.PollInterval, "poll-interval", o.PollInterval, "Interval used to poll the status in watch mode")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", o.Timeout, "Maximum time to watch the status (0 = unlimited)")
+	cmd.Flags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(cli.SupportedOutputFormats, "', '")))
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options, runtimeID string, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+
+	if !o.Watch {
+		status, err := mothership.GetClusterStatus(ctx, runtimeID)
+		if err != nil {
+			return err
+		}
+		return renderStatus(o, status, out)
+	}
+
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+	status, err := mothership.WatchCluster(ctx, runtimeID, func(status *keb.HTTPClusterResponse) {
+		printTransition(status, out)
+	})
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("cluster '%s' didn't reach a terminal status within %v", runtimeID, o.Timeout)
+		}
+		return err
+	}
+	if status.Status != keb.StatusReady && status.Status != keb.StatusDeleted {
+		return fmt.Errorf("cluster '%s' reached status '%s'", runtimeID, status.Status)
+	}
+	return nil
+}
+
+func renderStatus(o *Options, status *keb.HTTPClusterResponse, out io.Writer) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Cluster", "Cluster version", "Configuration version", "Status", "Failures"); err != nil {
+		return err
+	}
+	if err := formatter.AddRow(status.Cluster, status.ClusterVersion, status.ConfigurationVersion,
+		status.Status, failures(status)); err != nil {
+		return err
+	}
+	return formatter.Output(out)
+}
+
+func printTransition(status *keb.HTTPClusterResponse, out io.Writer) {
+	_, _ = fmt.Fprintf(out, "%s\t%s\t%s (configuration version %d)\n",
+		time.Now().UTC().Format(time.RFC3339), status.Cluster, status.Status, status.ConfigurationVersion)
+	for _, failure := range failures(status) {
+		_, _ = fmt.Fprintf(out, "\t\t%s\n", failure)
+	}
+}
+
+func failures(status *keb.HTTPClusterResponse) []string {
+	var result []string
+	if status.Failures != nil {
+		for _, failure := range *status.Failures {
+			result = append(result, fmt.Sprintf("%s: %s", failure.Component, failure.Reason))
+		}
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCmd(t *testing.T) {
+	newServer := func(statuses ...keb.Status) *httptest.Server {
+		var calls int32
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/clusters/runtime1/status", r.URL.Path)
+			idx := int(atomic.AddInt32(&calls, 1)) - 1
+			if idx >= len(statuses) {
+				idx = len(statuses) - 1
+			}
+			resp := &keb.HTTPClusterResponse{Cluster: "runtime1", ConfigurationVersion: 1, Status: statuses[idx]}
+			if statuses[idx] == keb.StatusError {
+				resp.Failures = &[]keb.Failure{{Component: "comp1", Reason: "failed"}}
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(resp))
+		}))
+	}
+	newOptions := func(url string, watch bool) *Options {
+		o := NewOptions(&cli.Options{OutputFormat: "json"})
+		o.MothershipURL = url
+		o.Watch = watch
+		o.PollInterval = time.Millisecond
+		return o
+	}
+
+	t.Run("Show status", func(t *testing.T) {
+		srv := newServer(keb.StatusReconciling)
+		defer srv.Close()
+
+		out := &bytes.Buffer{}
+		require.NoError(t, Run(context.Background(), newOptions(srv.URL, false), "runtime1", out))
+		require.Contains(t, out.String(), string(keb.StatusReconciling))
+	})
+
+	t.Run("Watch until ready", func(t *testing.T) {
+		srv := newServer(keb.StatusReconcilePending, keb.StatusReconciling, keb.StatusReconciling, keb.StatusReady)
+		defer srv.Close()
+
+		out := &bytes.Buffer{}
+		require.NoError(t, Run(context.Background(), newOptions(srv.URL, true), "runtime1", out))
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 3) //one line per transition
+		require.Contains(t, lines[0], string(keb.StatusReconcilePending))
+		require.Contains(t, lines[1], string(keb.StatusReconciling))
+		require.Contains(t, lines[2], string(keb.StatusReady))
+	})
+
+	t.Run("Watch fails on error status", func(t *testing.T) {
+		srv := newServer(keb.StatusReconciling, keb.StatusError)
+		defer srv.Close()
+
+		out := &bytes.Buffer{}
+		err := Run(context.Background(), newOptions(srv.URL, true), "runtime1", out)
+		require.Error(t, err)
+		require.Contains(t, out.String(), "comp1: failed")
+	})
+
+	t.Run("Watch times out", func(t *testing.T) {
+		srv := newServer(keb.StatusReconciling)
+		defer srv.Close()
+
+		o := newOptions(srv.URL, true)
+		o.Timeout = 20 * time.Millisecond
+		err := Run(context.Background(), o, "runtime1", &bytes.Buffer{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "didn't reach a terminal status")
+	})
+}
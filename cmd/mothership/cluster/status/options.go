@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+)
+
+type Options struct {
+	*cli.Options
+	MothershipURL string
+	Token         string
+	Watch         bool
+	PollInterval  time.Duration
+	Timeout       time.Duration
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, "", "", false, 5 * time.Second, 0}
+}
+
+func (o *Options) Validate() error {
+	if o.MothershipURL == "" {
+		return fmt.Errorf("mothership URL is undefined")
+	}
+	if o.PollInterval <= 0 {
+		return fmt.Errorf("poll interval has to be > 0 but was %v", o.PollInterval)
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("timeout cannot be < 0 but was %v", o.Timeout)
+	}
+	return nil
+}
+
+func (o *Options) client() (*client.MothershipClient, error) {
+	opts := []client.Option{client.WithPollInterval(o.PollInterval)}
+	if o.HTTPClient != nil {
+		opts = append(opts, client.WithHTTPClient(httpclient.New(o.HTTPClient)))
+	}
+	if o.Token != "" {
+		opts = append(opts, client.WithBearerToken(o.Token))
+	}
+	return client.NewMothershipClient(o.MothershipURL, opts...)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/cleanup"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Remove orphaned entities from the database.",
+		Long: `Find and remove entities which aren't needed anymore but weren't removed by the regular cleanup:
+stale cluster statuses, outdated cluster configurations which aren't referenced anymore and operations
+whose reconciliation doesn't exist. The orphans are deleted in batches, use --dry-run to report them only.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.InitApplicationRegistry(true); err != nil {
+				return err
+			}
+			return Run(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", o.DryRun, "Report the orphaned entities without deleting them")
+	cmd.Flags().IntVar(&o.BatchSize, "batch-size", o.BatchSize, "Amount of entities deleted within one transaction")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options, out io.Writer) error {
+	cleaner, err := cleanup.NewOrphanCleaner(o.Registry.Connection(), o.BatchSize, o.Verbose)
+	if err != nil {
+		return err
+	}
+	results, cleanupErr := cleaner.Cleanup(ctx, o.DryRun)
+
+	formatter, err := cli.NewOutputFormatter("table")
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Orphans", "Found", "Deleted"); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := formatter.AddRow(string(result.Category), result.Found, result.Deleted); err != nil {
+			return err
+		}
+	}
+	if err := formatter.Output(out); err != nil {
+		return err
+	}
+	return cleanupErr
+}
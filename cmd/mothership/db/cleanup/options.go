@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	DryRun    bool
+	BatchSize int
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, false, 100}
+}
+
+func (o *Options) Validate() error {
+	if o.BatchSize <= 0 {
+		return fmt.Errorf("batch size has to be > 0")
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	cleanupCmd "github.com/kyma-incubator/reconciler/cmd/mothership/db/cleanup"
+	rotateKeyCmd "github.com/kyma-incubator/reconciler/cmd/mothership/db/rotatekey"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Maintain the database of the mothership",
+		Long:  "Administrative CLI tool to maintain the database of the mothership reconciler",
+	}
+
+	cmd.AddCommand(rotateKeyCmd.NewCmd(rotateKeyCmd.NewOptions(o)))
+	cmd.AddCommand(cleanupCmd.NewCmd(cleanupCmd.NewOptions(o)))
+
+	return cmd
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate-key",
+		Short: "Re-encrypt all encrypted columns with the current encryption key.",
+		Long: `Re-encrypt the kubeconfigs, cluster configurations, change histories and debug bundles which are still
+encrypted with a previous key by using the current encryption key. Rotating the key works online:
+
+  1. Create a new key with 'mothership install' (the current key file is kept as backup).
+  2. Add the backup to 'db.encryption.previousKeyFiles' of the configuration and restart all
+     mothership instances: new data gets encrypted with the new key, existing data stays readable.
+  3. Run this command: the values are re-encrypted in batches. An interrupted rotation is resumed by
+     running the command again.
+  4. Remove the previous key from the configuration.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.InitApplicationRegistry(true); err != nil {
+				return err
+			}
+			return Run(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	cmd.Flags().IntVar(&o.BatchSize, "batch-size", o.BatchSize, "Amount of values re-encrypted within one transaction")
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options, out io.Writer) error {
+	rotator, err := db.NewKeyRotator(o.Registry.Connection(), o.BatchSize, o.Logger())
+	if err != nil {
+		return err
+	}
+	results, rotateErr := rotator.Rotate(ctx, model.EncryptedColumns())
+
+	formatter, err := cli.NewOutputFormatter("table")
+	if err != nil {
+		return err
+	}
+	if err := formatter.Header("Column", "Re-encrypted", "Modified concurrently"); err != nil {
+		return err
+	}
+	for _, result := range results {
+		if err := formatter.AddRow(result.Column, result.Rotated, result.Skipped); err != nil {
+			return err
+		}
+	}
+	if err := formatter.Output(out); err != nil {
+		return err
+	}
+	return rotateErr
+}
@@ -6,9 +6,12 @@ import (
 	"path/filepath"
 	"strings"
 
+	clusterCmd "github.com/kyma-incubator/reconciler/cmd/mothership/cluster"
 	cfgCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config"
+	dbCmd "github.com/kyma-incubator/reconciler/cmd/mothership/db"
 	localCmd "github.com/kyma-incubator/reconciler/cmd/mothership/local"
 	msCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership"
+	reportCmd "github.com/kyma-incubator/reconciler/cmd/mothership/report"
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	file "github.com/kyma-incubator/reconciler/pkg/files"
 	"github.com/spf13/cobra"
@@ -30,7 +33,10 @@ func main() {
 		"Command line tool to administrate the Kyma reconciler system")
 
 	cmd.AddCommand(cfgCmd.NewCmd(o))
+	cmd.AddCommand(clusterCmd.NewCmd(o))
 	cmd.AddCommand(msCmd.NewCmd(o))
+	cmd.AddCommand(reportCmd.NewCmd(o))
+	cmd.AddCommand(dbCmd.NewCmd(o))
 	cmd.AddCommand(localCmd.NewCmd(localCmd.NewOptions(o)))
 
 	if err := cmd.Execute(); err != nil {
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/reconciler/pkg/anomaly"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+)
+
+const anomalyLogScope = "anomaly"
+
+func startAnomalyAnalyzer(ctx context.Context, o *Options) error {
+	cfg := anomaly.DefaultConfig()
+	cfg.Interval = o.AnomalyDetectionInterval
+
+	var notifiers []anomaly.Notifier
+	if o.AnomalyWebhookURL != "" {
+		notifiers = append(notifiers, &anomaly.WebhookNotifier{URL: o.AnomalyWebhookURL})
+	}
+	return anomaly.NewAnalyzer(o.Registry.ReconciliationRepository(), o.Registry.Inventory(), cfg,
+		logger.NewScopedLogger(anomalyLogScope, o.Verbose), notifiers...).Run(ctx)
+}
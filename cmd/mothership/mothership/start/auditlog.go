@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
@@ -20,7 +21,7 @@ import (
 )
 
 const (
-	XJWTHeaderName = "X-Jwt"
+	XJWTHeaderName = server.HeaderJWT
 	redacted       = "REDACTED"
 	DataMessageKey = "data"
 )
@@ -29,8 +30,26 @@ var (
 	sanitizedFields = []string{
 		"kubeconfig",
 	}
+	//audit loggers per file: a file has to be written by a single logger to rotate it safely
+	auditLoggers   = map[string]*zap.Logger{}
+	auditLoggersMu sync.Mutex
 )
 
+// auditLoggerFor returns the logger writing the audit log file (created on first use)
+func auditLoggerFor(logFile string) (*zap.Logger, error) {
+	auditLoggersMu.Lock()
+	defer auditLoggersMu.Unlock()
+	if auditLogger, ok := auditLoggers[logFile]; ok {
+		return auditLogger, nil
+	}
+	auditLogger, err := NewLoggerWithFile(logFile)
+	if err != nil {
+		return nil, err
+	}
+	auditLoggers[logFile] = auditLogger
+	return auditLogger, nil
+}
+
 func NewLoggerWithFile(logFile string) (*zap.Logger, error) {
 	cfg := zap.Config{
 		Encoding:         "json",
@@ -180,6 +199,35 @@ func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *O
 	logger.Info(string(data))
 }
 
+type configReloadData struct {
+	Event   string   `json:"event"`
+	File    string   `json:"file"`
+	Changes []string `json:"changes"`
+	User    string   `json:"user"`
+	Tenant  string   `json:"tenant"`
+}
+
+// auditLogConfigReload records the settings which were changed by editing the configuration file
+func auditLogConfigReload(l *zap.Logger, o *Options, file string, changes []string) error {
+	logData := configReloadData{
+		Event:   "configurationReloaded",
+		File:    file,
+		Changes: changes,
+		User:    "SYSTEM",
+		Tenant:  o.AuditLogTenantID,
+	}
+	data, err := json.Marshal(logData)
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal auditlog JSON payload")
+	}
+	l.With(zap.String("uuid", uuid.New().String())).
+		With(zap.String("user", logData.User)).
+		With(zap.String("tenant", o.AuditLogTenantID)).
+		With(zap.String("category", "audit.security-events")). // comply with required log backend format
+		Info(string(data))
+	return nil
+}
+
 func getJWTPayload(r *http.Request) (string, error) {
 	// The jwtHeader here is not a full JWT token. Instead, it's only the
 	// encoded payload part of the token. It's passed by Istio as a header
@@ -204,3 +252,12 @@ func getJWTPayloadSub(payload string) (string, error) {
 	err := json.Unmarshal([]byte(payload), &s)
 	return s.Sub, err
 }
+
+// requestActor returns the user who sent the request (empty if the request wasn't authenticated by a JWT)
+func requestActor(r *http.Request) (string, error) {
+	payload, err := getJWTPayload(r)
+	if err != nil {
+		return "", err
+	}
+	return getJWTPayloadSub(payload)
+}
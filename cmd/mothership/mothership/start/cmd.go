@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/events"
 
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/spf13/cobra"
@@ -48,6 +49,11 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().DurationVarP(&o.OrphanOperationTimeout, "orphan-timeout", "", 10*time.Minute, "Timeout until a processed operation which hasn't received status updates from its worker will be restarted")
 	cmd.Flags().DurationVarP(&o.WatchInterval, "watch-interval", "", 1*time.Minute, "Size of the reconciler worker pool")
 	cmd.Flags().DurationVarP(&o.ClusterReconcileInterval, "reconcile-interval", "", 5*time.Minute, "Defines the time when a cluster will to be reconciled since his last successful reconciliation")
+	cmd.Flags().Float64Var(&o.ClusterReconcileJitter, "reconcile-jitter", 0.1, "Fraction of the reconcile interval (0..1) which is added per cluster to spread the reconciliations over time")
+	cmd.Flags().IntVar(&o.MaxOperationsPerMinute, "max-operations-per-minute", 0, "Maximal amount of operations scheduled per minute by periodic reconciliations (0 disables the throttle)")
+	cmd.Flags().BoolVar(&o.AllowUnconfirmedDeletion, "allow-unconfirmed-deletion", false, "Delete clusters without a confirmation token for old clients (new clients can still request it with the header 'Deletion-Confirmation: required')")
+	cmd.Flags().DurationVar(&o.DeletionConfirmationTTL, "deletion-confirmation-ttl", 10*time.Minute, "Time until the token which confirms a cluster deletion expires")
+	cmd.Flags().DurationVar(&o.DeletionGracePeriod, "deletion-grace-period", 0, "Time a deleted cluster is retained and can be undeleted before its teardown starts (0 tears down immediately)")
 	cmd.Flags().DurationVar(&o.PurgeEntitiesOlderThan, "purge-older-than", 14*24*time.Hour, "[Deprecated] Defines the minimum age of entities like Reconciliations and Operations that will be removed")
 	cmd.Flags().IntVar(&o.ReconciliationsKeepLatestCount, "reconciliations-keep-n-latest", 0, "Defines the count of the most recent reconciliation records the cleaner keeps") //It's set to zero to disable it by default. Change to a proper value once this mechanism is enabled in the environments.
 	cmd.Flags().IntVar(&o.ReconciliationsMaxAgeDays, "recon-max-age-days", 0, "Defines the number of days for which the cleaner keeps reconciliations before removal")         //It's set to zero to disable it by default. Change to a proper value once this mechanism is enabled in the environments.
@@ -60,6 +66,28 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().StringVar(&o.AuditLogFile, "audit-log-file", "/var/log/auditlog/mothership-audit.log", "Path for mothership audit log file")
 	cmd.Flags().StringVar(&o.AuditLogTenantID, "audit-log-tenant-id", "", "tenant id for audit logging")
 	cmd.Flags().BoolVar(&o.StopAfterMigration, "stop-after-migrate", false, "Stop mothership after database migration to the latest release")
+	o.AddHTTPClientFlags(cmd.Flags())
+	o.AddHTTPServerFlags(cmd.Flags())
+	cmd.Flags().IntVar(&o.DiagnosticsPort, "diagnostics-port", 0, "Port exposing pprof and expvar diagnostics endpoints (0 = disabled)")
+	cmd.Flags().StringVar(&o.LogLevelFile, "log-level-file", "", "Path to a file defining log levels ('<level>' or '<scope>=<level>' per line) which is watched for changes at runtime")
+	cmd.Flags().DurationVar(&o.HealthCheckTimeout, "health-timeout", 5*time.Second, "Maximal time the dependency checks of the health endpoints are allowed to take")
+	cmd.Flags().StringVar(&o.TenantClaim, "tenant-claim", "", "JWT claim containing the global account a token is scoped to: scoped tokens can only access the clusters of their global account (empty disables the scoping)")
+	cmd.Flags().IntVar(&o.TenantMaxParallelOperations, "tenant-max-parallel", 0, "Default of maximal parallel operations per tenant (global account), 0 means unlimited. Can be overridden per tenant by quotas")
+	cmd.Flags().IntVar(&o.TenantMaxOperationsPerMinute, "tenant-max-operations-per-minute", 0, "Default of maximal operations per minute assigned to workers for a tenant (global account), 0 means unlimited. Can be overridden per tenant by quotas")
+	cmd.Flags().DurationVar(&o.AnomalyDetectionInterval, "anomaly-detection-interval", 0, "Interval of the analyzer which alerts anomalous operation durations and failure rates of components (0 disables the analyzer)")
+	cmd.Flags().StringVar(&o.AnomalyWebhookURL, "anomaly-webhook-url", "", "URL of a webhook which receives the alerts of the anomaly analyzer as JSON (alerts are always logged)")
+	cmd.Flags().BoolVar(&o.RequireIfMatch, "require-if-match", false, "Reject updates of existing clusters which don't pass the generation of the updated configuration in an If-Match header")
+	cmd.Flags().BoolVar(&o.ReadOnly, "read-only", false, "Serve only read requests and never schedule operations (e.g. as standby or for analytics connected to a database replica)")
+	cmd.Flags().StringVar(&o.EventsWebhookURL, "events-webhook-url", "", "URL of a webhook (e.g. a Knative broker) which receives the status changes of clusters as CloudEvents (empty disables the events)")
+	cmd.Flags().StringVar(&o.EventsSource, "events-source", events.DefaultSource, "Source attribute of the emitted CloudEvents")
+	cmd.Flags().DurationVar(&o.ConfigReloadInterval, "config-reload-interval", 10*time.Second, "Interval to check the configuration file for changes of the reloadable settings (0 disables the reload)")
+	cmd.Flags().DurationVar(&o.HealthProbeInterval, "health-probe-interval", 0, "Interval of the probe which verifies the health checks of the components on the clusters and reports the results in the cluster status (0 disables the probe)")
+	cmd.Flags().BoolVar(&o.HealthRepair, "health-repair", false, "Trigger a reconciliation of clusters as soon as the health probe detects unhealthy components instead of waiting for the reconcile interval")
+	cmd.Flags().DurationVar(&o.HealthRepairCooldown, "health-repair-cooldown", 30*time.Minute, "Minimal time between two repair reconciliations of a cluster triggered by the health probe")
+	cmd.Flags().DurationVar(&o.FreshnessObjective, "freshness-objective", 0, "Maximal age of the latest successful reconciliation of a cluster which is tracked as freshness SLO by the metrics and the default of the freshness report (0 disables the metrics)")
+	cmd.Flags().Float64Var(&o.ReconcileBackoffFactor, "reconcile-backoff-factor", 0, "Factor which widens the reconcile interval of ready clusters after each reconciliation which didn't change anything (values <= 1 disable the backoff)")
+	cmd.Flags().DurationVar(&o.ReconcileBackoffMaxInterval, "reconcile-backoff-max", 2*time.Hour, "Maximal reconcile interval of ready clusters widened by the backoff")
+	cmd.Flags().StringSliceVar(&o.DisabledHealthChecks, "health-disabled-checks", []string{}, "Health checks which are excluded from the health endpoints (supported: database)")
 	return cmd
 }
 
@@ -70,12 +98,39 @@ func Run(ctx context.Context, o *Options) error {
 	}
 	//passing config value to be used by metrics collectors and trackers
 	o.Config = schedulerCfg
+	if err := o.ConfigureHTTPClient(); err != nil {
+		return err
+	}
+	if err := o.WatchLogLevels(ctx); err != nil {
+		return err
+	}
+	if err := o.StartDiagnostics(ctx); err != nil {
+		return err
+	}
+	if o.HealthProbeInterval > 0 {
+		//the probe only reads from the clusters and is therefore also started in read-only mode
+		startHealthProbe(ctx, o)
+	}
+	if o.ReadOnly {
+		o.Logger().Info("Mothership is running in read-only mode: scheduler is not started and modifying requests are rejected")
+		return startWebserver(ctx, o)
+	}
+	if o.EventsWebhookURL != "" {
+		startEventPublisher(ctx, o)
+	}
 	go func(ctx context.Context, o *Options) {
 		err := startScheduler(ctx, o)
 		if err != nil {
 			panic(err)
 		}
 	}(ctx, o)
+	if o.AnomalyDetectionInterval > 0 {
+		go func(ctx context.Context, o *Options) {
+			if err := startAnomalyAnalyzer(ctx, o); err != nil {
+				o.Logger().Errorf("Anomaly analyzer returned an error: %s", err)
+			}
+		}(ctx, o)
+	}
 
 	return startWebserver(ctx, o)
 }
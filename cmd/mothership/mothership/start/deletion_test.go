@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type deletionRecorder struct {
+	*cluster.MockInventory
+	deletions int
+}
+
+func (i *deletionRecorder) MarkForDeletion(runtimeID string) (*cluster.State, error) {
+	i.deletions++
+	return i.MockInventory.MarkForDeletion(runtimeID)
+}
+
+func (i *deletionRecorder) ScheduleDeletion(runtimeID string, teardownAfter time.Time) (*model.ScheduledDeletionEntity, error) {
+	i.deletions++
+	return i.MockInventory.ScheduleDeletion(runtimeID, teardownAfter)
+}
+
+func TestDeleteCluster(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		allowUnconfirmedDeletion bool
+		header                   string
+		query                    string
+		confirmationErr          error
+		expectedCode             int
+		expectedToken            string
+	}{
+		{
+			name:          "Bare deletion request returns a token",
+			expectedCode:  http.StatusAccepted,
+			expectedToken: "token",
+		},
+		{
+			name:                     "Deletion request with header returns a token if unconfirmed deletions are allowed",
+			allowUnconfirmedDeletion: true,
+			header:                   deletionConfirmationRequired,
+			expectedCode:             http.StatusAccepted,
+			expectedToken:            "token",
+		},
+		{
+			name:            "Deletion request with invalid token is rejected",
+			query:           "?token=invalid",
+			confirmationErr: &cluster.DeletionConfirmationError{RuntimeID: "runtime1"},
+			expectedCode:    http.StatusConflict,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			inventory := &deletionRecorder{
+				MockInventory: &cluster.MockInventory{
+					GetLatestResult: &cluster.State{
+						Cluster: &model.ClusterEntity{RuntimeID: "runtime1"},
+					},
+					ConfirmDeletionResult: tc.confirmationErr,
+				},
+			}
+			o := NewOptions(&cli.Options{})
+			o.AllowUnconfirmedDeletion = tc.allowUnconfirmedDeletion
+			o.DeletionConfirmationTTL = 10 * time.Minute
+
+			router := mux.NewRouter()
+			router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}", paramContractVersion, paramRuntimeID),
+				func(w http.ResponseWriter, r *http.Request) {
+					deleteClusterFromInventory(o, inventory, w, r)
+				}).Methods(http.MethodDelete)
+
+			req := httptest.NewRequest(http.MethodDelete, "/v1/clusters/runtime1"+tc.query, nil)
+			if tc.header != "" {
+				req.Header.Set(headerDeletionConfirmation, tc.header)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+			require.Zero(t, inventory.deletions)
+			if tc.expectedToken != "" {
+				confirmation := &keb.DeletionConfirmation{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), confirmation))
+				require.Equal(t, tc.expectedToken, confirmation.Token)
+				require.Equal(t, "runtime1", confirmation.RuntimeID)
+			}
+		})
+	}
+}
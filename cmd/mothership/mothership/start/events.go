@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/reconciler/pkg/events"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+)
+
+const eventsLogScope = "events"
+
+func startEventPublisher(ctx context.Context, o *Options) {
+	publisher := events.NewWebhookPublisher(o.EventsWebhookURL, o.EventsSource,
+		logger.NewScopedLogger(eventsLogScope, o.Verbose))
+	o.Registry.AddClusterStateObserver(publisher)
+	go publisher.Run(ctx)
+	o.Logger().Infof("Publishing cluster status changes as CloudEvents to '%s'", o.EventsWebhookURL)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/probe"
+)
+
+const (
+	healthProbeLogScope = "healthprobe"
+	healthProbeWorkers  = 10
+	healthProbeTimeout  = 10 * time.Second
+)
+
+func startHealthProbe(ctx context.Context, o *Options) {
+	probeLogger := logger.NewScopedLogger(healthProbeLogScope, o.Verbose)
+	var listeners []probe.Listener
+	if o.HealthRepair {
+		listeners = append(listeners, probe.NewWatchdog(o.Registry.Inventory(), o.HealthRepairCooldown, probeLogger))
+	}
+	o.HealthProber = probe.NewProber(o.Registry.Inventory(), &probe.Config{
+		Interval: o.HealthProbeInterval,
+		Timeout:  healthProbeTimeout,
+		Workers:  healthProbeWorkers,
+		Checks:   o.Config.Health,
+	}, probeLogger, listeners...)
+	go func() {
+		if err := o.HealthProber.Run(ctx); err != nil {
+			o.Logger().Errorf("Component health probe returned an error: %s", err)
+		}
+	}()
+}
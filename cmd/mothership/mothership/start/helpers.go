@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 )
@@ -27,3 +31,26 @@ func validateStatuses(statuses []string) error {
 	}
 	return nil
 }
+
+// generationETag returns the ETag of a cluster configuration with the given generation
+func generationETag(generation int64) string {
+	return strconv.Quote(strconv.FormatInt(generation, 10))
+}
+
+// parseIfMatch returns the configuration generation expected by an If-Match header. The second return value is
+// false if the header is missing or matches any generation ('*').
+func parseIfMatch(header string) (int64, bool, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, false, nil
+	}
+	etag := strings.TrimPrefix(header, "W/")
+	if unquoted, err := strconv.Unquote(etag); err == nil {
+		etag = unquoted
+	}
+	generation, err := strconv.ParseInt(etag, 10, 64)
+	if err != nil || generation < 0 {
+		return 0, false, fmt.Errorf("If-Match header '%s' is not a generation of a cluster configuration", header)
+	}
+	return generation, true, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_components(t *testing.T) {
@@ -95,3 +96,22 @@ func Test_validateStatuses(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseIfMatch(t *testing.T) {
+	for header, expected := range map[string]int64{`"5"`: 5, `W/"7"`: 7, "3": 3, `"0"`: 0} {
+		generation, ok, err := parseIfMatch(header)
+		require.NoError(t, err, header)
+		require.True(t, ok, header)
+		require.Equal(t, expected, generation, header)
+	}
+	for _, header := range []string{"", "*", " "} {
+		_, ok, err := parseIfMatch(header)
+		require.NoError(t, err, header)
+		require.False(t, ok, header)
+	}
+	for _, header := range []string{`"abc"`, "-1", `"1", "2"`} {
+		_, _, err := parseIfMatch(header)
+		require.Error(t, err, header)
+	}
+	require.Equal(t, `"4"`, generationETag(4))
+}
@@ -7,23 +7,30 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/features"
+	"github.com/kyma-incubator/reconciler/pkg/health"
 
 	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/openapi"
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/estimation"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/kubernetes"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/report"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
 	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/simulation"
 	"github.com/pkg/errors"
 
 	"github.com/gorilla/mux"
@@ -38,6 +45,7 @@ const (
 	paramOffset          = "offset"
 	paramSchedulingID    = "schedulingID"
 	paramCorrelationID   = "correlationID"
+	paramComponent       = "component"
 
 	paramStatus     = "status"
 	paramRuntimeIDs = "runtimeID"
@@ -46,9 +54,38 @@ const (
 	paramLast       = "last"
 	paramTimeFormat = time.RFC3339
 	paramPoolID     = "poolID"
-
-	// Limit Request Bodies to 100KB
+	paramVersion    = "version"
+	paramTop        = "top"
+	paramScheduleID = "scheduleID"
+	paramFormat     = "format"
+	paramToken      = "token"
+	paramTenant     = "globalAccountID"
+	paramLimit      = "limit"
+	paramFlag       = "name"
+	paramObjective  = "objective"
+	paramViolations = "violations"
+	paramReleased   = "released"
+
+	// Clients opt in to the two-step cluster deletion by passing this header
+	headerDeletionConfirmation   = "Deletion-Confirmation"
+	deletionConfirmationRequired = "required"
+
+	formatCSV           = "csv"
+	defaultReportWindow = 24 * time.Hour
+	// Successful operations per component (and cluster size) used to estimate the ETA of operations
+	etaHistorySamples = 50
+
+	defaultConfigurationChangesLimit = 50
+	// Status badges are polled by dashboards: allow caching them for a short time
+	statusBadgeMaxAge = 30 * time.Second
+
+	// Limit Request Bodies to 100KB (cluster payloads are limited only by the request size limit of the webserver
+	// as kubeconfigs and configurations of big clusters can have several MB)
 	bodyRequestLimitBytes = 100000
+	// Limit uploaded debug bundles to 20MB
+	debugBundleLimitBytes = 20 * 1024 * 1024
+	// Limit uploaded failure captures to 5MB (they include the configuration and the resolved values of a component)
+	failureCaptureLimitBytes = 5 * 1024 * 1024
 )
 
 // AuditRegistry contains mappings from path-prefixes to array of methods that are registered with the AuditLogMiddleware
@@ -62,6 +99,35 @@ var (
 			http.MethodPatch,
 			http.MethodDelete,
 		},
+		fmt.Sprintf("/v{%s}/clusters/{%s}/protection", paramContractVersion, paramRuntimeID): {
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		fmt.Sprintf("/v{%s}/clusters/{%s}/deletion", paramContractVersion, paramRuntimeID): {
+			http.MethodDelete,
+		},
+		fmt.Sprintf("/v{%s}/clusters/{%s}/badge/token", paramContractVersion, paramRuntimeID): {
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		fmt.Sprintf("/v{%s}/schedules", paramContractVersion): {
+			http.MethodPost,
+		},
+		fmt.Sprintf("/v{%s}/schedules/{%s}", paramContractVersion, paramScheduleID): {
+			http.MethodDelete,
+		},
+		fmt.Sprintf("/v{%s}/intervals", paramContractVersion): {
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		fmt.Sprintf("/v{%s}/quotas", paramContractVersion): {
+			http.MethodPut,
+			http.MethodDelete,
+		},
+		fmt.Sprintf("/v{%s}/featureflags/{%s}", paramContractVersion, paramFlag): {
+			http.MethodPut,
+			http.MethodDelete,
+		},
 	}
 )
 
@@ -88,7 +154,7 @@ func startWebserver(ctx context.Context, o *Options) error {
 			o.Logger().Infof("Auditing %s for methods [%s]", auditedPath, strings.Join(auditedMethods, ","))
 		}
 
-		auditLogger, err := NewLoggerWithFile(o.AuditLogFile)
+		auditLogger, err := auditLoggerFor(o.AuditLogFile)
 		if err != nil {
 			return err
 		}
@@ -108,6 +174,15 @@ func startWebserver(ctx context.Context, o *Options) error {
 		})
 	}
 
+	if o.TenantClaim != "" {
+		o.Logger().Infof("Scoping API access by tenant: tenant is read from token claim '%s'", o.TenantClaim)
+		apiRouter.Use(newTenantAuthorizer(o).middleware)
+	}
+
+	if o.ReadOnly {
+		apiRouter.Use(readOnlyMiddleware)
+	}
+
 	metricsRouter := mainRouter.Path("/metrics").Subrouter()
 	healthRouter := mainRouter.PathPrefix("/health").Subrouter()
 	mainRouter.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux)
@@ -132,9 +207,24 @@ func startWebserver(ctx context.Context, o *Options) error {
 		callHandler(o, deleteCluster)).
 		Methods(http.MethodDelete)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/protection", paramContractVersion, paramRuntimeID),
+		callHandler(o, protectCluster)).
+		Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/protection", paramContractVersion, paramRuntimeID),
+		callHandler(o, unprotectCluster)).
+		Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/deletion", paramContractVersion, paramRuntimeID),
+		callHandler(o, undeleteCluster)).
+		Methods(http.MethodDelete)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%v}/clusters/state", paramContractVersion),
-		callHandler(o, getClustersState)).
+		server.CompressResponse(callHandler(o, getClustersState))).
 		Methods(http.MethodGet)
 
 	apiRouter.HandleFunc(
@@ -154,9 +244,29 @@ func startWebserver(ctx context.Context, o *Options) error {
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID), //supports offset-param
-		callHandler(o, statusChanges)).
+		server.CompressResponse(callHandler(o, statusChanges))).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/changes", paramContractVersion, paramRuntimeID), //supports limit-param
+		server.CompressResponse(callHandler(o, configurationChanges))).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/badge", paramContractVersion, paramRuntimeID), //requires token-param
+		callHandler(o, getStatusBadge)).
 		Methods(http.MethodGet)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/badge/token", paramContractVersion, paramRuntimeID),
+		callHandler(o, issueStatusBadgeToken)).
+		Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/badge/token", paramContractVersion, paramRuntimeID),
+		callHandler(o, revokeStatusBadgeToken)).
+		Methods(http.MethodDelete)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/operations/{%s}/callback/{%s}", paramContractVersion, paramSchedulingID, paramCorrelationID),
 		callHandler(o, operationCallback)).
@@ -164,7 +274,7 @@ func startWebserver(ctx context.Context, o *Options) error {
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations", paramContractVersion),
-		callHandler(o, getReconciliations)).
+		server.CompressResponse(callHandler(o, getReconciliations))).
 		Methods(http.MethodGet)
 
 	apiRouter.HandleFunc(
@@ -177,6 +287,46 @@ func startWebserver(ctx context.Context, o *Options) error {
 		callHandler(o, enableOperationDebugLogging)).
 		Methods(http.MethodPut)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/debug/bundle", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, uploadOperationDebugBundle)).
+		Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/debug/bundle", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, downloadOperationDebugBundle)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/failure", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, uploadOperationFailureCapture)).
+		Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/failure", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationFailureCapture)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/timeline", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationTimeline)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/smoketests", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationSmokeTests)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/resources", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationResources)).
+		Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/payload", paramContractVersion, paramSchedulingID, paramCorrelationID),
+		callHandler(o, getOperationPayload)).
+		Methods(http.MethodGet)
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations/{%s}/debug", paramContractVersion, paramSchedulingID),
 		callHandler(o, enableReconciliationDebugLogging)).
@@ -184,7 +334,99 @@ func startWebserver(ctx context.Context, o *Options) error {
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/config/{%s}", paramContractVersion, paramRuntimeID, paramConfigVersion),
-		callHandler(o, getKymaConfig)).Methods(http.MethodGet)
+		server.CompressResponse(callHandler(o, getKymaConfig))).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/components/{%s}/clusters", paramContractVersion, paramComponent),
+		server.CompressResponse(callHandler(o, getComponentClusters))).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/schedules", paramContractVersion),
+		server.CompressResponse(callHandler(o, getScheduledReconciliations))).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/schedules", paramContractVersion),
+		callHandler(o, scheduleReconciliation)).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/schedules/{%s}", paramContractVersion, paramScheduleID),
+		callHandler(o, cancelScheduledReconciliation)).Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/intervals", paramContractVersion),
+		callHandler(o, getReconcileIntervals)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/intervals", paramContractVersion),
+		callHandler(o, setReconcileInterval)).Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/intervals", paramContractVersion),
+		callHandler(o, removeReconcileInterval)).Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/quotas", paramContractVersion),
+		callHandler(o, getTenantQuotas)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/quotas", paramContractVersion),
+		callHandler(o, setTenantQuota)).Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/quotas", paramContractVersion),
+		callHandler(o, removeTenantQuota)).Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/freezes", paramContractVersion),
+		callHandler(o, getFreezes)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/freezes", paramContractVersion),
+		callHandler(o, engageFreeze)).Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/freezes", paramContractVersion),
+		callHandler(o, releaseFreeze)).Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/featureflags", paramContractVersion),
+		callHandler(o, getFeatureFlags)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/featureflags/{%s}", paramContractVersion, paramFlag),
+		callHandler(o, saveFeatureFlag)).Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/featureflags/{%s}", paramContractVersion, paramFlag),
+		callHandler(o, removeFeatureFlag)).Methods(http.MethodDelete)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/featureflags", paramContractVersion, paramRuntimeID),
+		callHandler(o, getClusterFeatureFlags)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reports/fleet", paramContractVersion),
+		callHandler(o, getFleetReport)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/reports/freshness", paramContractVersion),
+		callHandler(o, getFreshnessReport)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/simulations", paramContractVersion),
+		callHandler(o, simulateChange)).Methods(http.MethodPost)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/pins", paramContractVersion, paramRuntimeID),
+		callHandler(o, getComponentPins)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/pins/{%s}", paramContractVersion, paramRuntimeID, paramComponent),
+		callHandler(o, pinComponent)).Methods(http.MethodPut)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/pins/{%s}", paramContractVersion, paramRuntimeID, paramComponent),
+		callHandler(o, unpinComponent)).Methods(http.MethodDelete)
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/occupancy/{%s}", paramContractVersion, paramPoolID),
@@ -194,6 +436,10 @@ func startWebserver(ctx context.Context, o *Options) error {
 		fmt.Sprintf("/v{%s}/occupancy/{%s}", paramContractVersion, paramPoolID),
 		callHandler(o, createOrUpdateComponentWorkerPoolOccupancy)).Methods(http.MethodPost)
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/loglevel", paramContractVersion),
+		server.LogLevelHandler).Methods(http.MethodGet, http.MethodPut, http.MethodDelete)
+
 	//metrics endpoint
 	metricErr := metrics.RegisterOccupancy(o.Registry.OccupancyRepository(), o.Config.Scheduler.Reconcilers, o.Logger())
 	if metricErr != nil {
@@ -211,12 +457,33 @@ func startWebserver(ctx context.Context, o *Options) error {
 	if metricErr != nil {
 		return metricErr
 	}
+	metricErr = metrics.RegisterDbQueries(o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	metricErr = metrics.RegisterOperationStates(o.Registry.ReconciliationRepository(), o.Logger())
+	if metricErr != nil {
+		return metricErr
+	}
+	if o.FreshnessObjective > 0 {
+		metricErr = metrics.RegisterFreshnessSLO(o.Registry.Inventory(), o.Registry.ReconciliationRepository(),
+			o.FreshnessObjective, o.Logger())
+		if metricErr != nil {
+			return metricErr
+		}
+	}
 
 	metricsRouter.Handle("", promhttp.Handler())
 
 	//liveness and readiness checks
-	healthRouter.HandleFunc("/live", live)
-	healthRouter.HandleFunc("/ready", ready(o))
+	checker := newHealthChecker(o)
+	mainRouter.HandleFunc("/healthz", checker.LiveHandler())
+	mainRouter.HandleFunc("/readyz", checker.ReadyHandler())
+	healthRouter.HandleFunc("/live", checker.LiveHandler())
+	healthRouter.HandleFunc("/ready", checker.ReadyHandler())
+
+	//API documentation
+	server.AddOpenAPIRoutes(mainRouter, "Reconciler mothership API", openapi.ExternalAPI, openapi.InternalAPI)
 
 	//start server process
 	srv := &server.Webserver{
@@ -225,6 +492,8 @@ func startWebserver(ctx context.Context, o *Options) error {
 		SSLCrtFile: o.SSLCrt,
 		SSLKeyFile: o.SSLKey,
 		Router:     mainRouter,
+		Security:   o.HTTPServer,
+		AccessLog:  o.HTTPAccessLog,
 	}
 	return srv.Start(ctx) //blocking call
 }
@@ -281,652 +550,2028 @@ func enableOperationDebugLogging(o *Options, w http.ResponseWriter, r *http.Requ
 
 }
 
-func live(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusOK)
-}
-
-func ready(o *Options) http.HandlerFunc {
-	return func(w http.ResponseWriter, _ *http.Request) {
-		if o.Registry.Connection().Ping() != nil {
-			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
-			return
-		}
-		w.WriteHeader(http.StatusOK)
+func uploadOperationDebugBundle(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
 	}
-}
-
-func callHandler(o *Options, handler func(o *Options, w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
-	return func(w http.ResponseWriter, r *http.Request) {
-		handler(o, w, r)
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if op == nil {
+		server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("operation with schedulingID '%s' and correlationID '%s' not found", schedulingID, correlationID),
+		})
+		return
 	}
+	if !op.Debug {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("operation with schedulingID '%s' and correlationID '%s' is not flagged as debug", schedulingID, correlationID),
+		})
+		return
+	}
+	bundle, err := io.ReadAll(http.MaxBytesReader(w, r.Body, debugBundleLimitBytes))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read debug bundle").Error(),
+		})
+		return
+	}
+	if err := o.Registry.ReconciliationRepository().StoreDebugBundle(schedulingID, correlationID, bundle); err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
 }
 
-func deleteReconciliationsByCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+func downloadOperationDebugBundle(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	if _, err := params.Int64(paramContractVersion); err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Contract version undefined").Error(),
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
 		})
 		return
 	}
-	runtimeID, err := params.String(paramRuntimeID)
+	correlationID, err := params.String(paramCorrelationID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
-	err = o.Registry.ReconciliationRepository().RemoveReconciliationByRuntimeID(runtimeID)
-	if err == nil {
-		w.WriteHeader(http.StatusOK)
+	bundleEntity, err := o.Registry.ReconciliationRepository().GetDebugBundle(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
-	if repository.IsNotFoundError(err) {
-		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
-			Error: err.Error(),
-		})
-	} else {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
+	bundle, err := bundleEntity.Data()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to decode debug bundle").Error(),
 		})
+		return
+	}
+	w.Header().Set("content-type", "application/gzip")
+	w.Header().Set("content-disposition",
+		fmt.Sprintf("attachment; filename=\"debug-%s-%s.tar.gz\"", schedulingID, correlationID))
+	if _, err := w.Write(bundle); err != nil {
+		o.Logger().Warnf("Failed to send debug bundle of operation (schedulingID:%s/correlationID:%s): %s",
+			schedulingID, correlationID, err)
 	}
 }
 
-func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+func uploadOperationFailureCapture(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	contractV, err := params.Int64(paramContractVersion)
+	schedulingID, err := params.String(paramSchedulingID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Contract version undefined").Error(),
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
 		})
 		return
 	}
-	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
-	clusterModel, err := keb.NewModelFactory(contractV).Cluster(bodyLimited)
+	correlationID, err := params.String(paramCorrelationID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
 		})
 		return
 	}
-	if _, err := kubernetes.NewClientBuilder().WithLogger(o.Logger()).WithString(clusterModel.Kubeconfig).Build(r.Context(), true); err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "kubeconfig not accepted").Error(),
+	capture, err := io.ReadAll(http.MaxBytesReader(w, r.Body, failureCaptureLimitBytes))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read failure capture").Error(),
 		})
 		return
 	}
-
-	clusterStateOld, err := o.Registry.Inventory().GetLatest(clusterModel.RuntimeID)
-	if err != nil && !repository.IsNotFoundError(err) {
-		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to get latest status").Error(),
+	var failureCapture reconciler.FailureCapture
+	if err := json.Unmarshal(capture, &failureCapture); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal failure capture").Error(),
 		})
 		return
 	}
-
-	clusterStateNew, err := o.Registry.Inventory().CreateOrUpdate(contractV, clusterModel)
+	if failureCapture.Error == "" || len(failureCapture.Task) == 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: "error or task not provided in failure capture",
+		})
+		return
+	}
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to create or update cluster entity").Error(),
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if op == nil {
+		server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("operation with schedulingID '%s' and correlationID '%s' not found", schedulingID, correlationID),
 		})
 		return
 	}
-
-	if clusterStateOld != nil && clusterStateOld.Status.Status.IsDisabled() {
-		if clusterStateNew, err = o.Registry.Inventory().UpdateStatus(clusterStateNew, model.ClusterStatusReconcileDisabled); err != nil {
-			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-				Error: errors.Wrap(err, "Failed to disable cluster after an update").Error(),
-			})
-			return
-		}
+	if err := o.Registry.ReconciliationRepository().StoreFailureCapture(schedulingID, correlationID, capture); err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
 	}
-
-	//respond status URL
-	sendResponse(w, r, clusterStateNew, o)
+	w.WriteHeader(http.StatusOK)
 }
 
-func getClustersState(o *Options, w http.ResponseWriter, r *http.Request) {
+func getOperationFailureCapture(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-
-	if runtimeID, err := params.String(paramRuntimeID); err == nil && runtimeID != "" {
-		state, err := o.Registry.Inventory().GetLatest(runtimeID)
-		if err != nil {
-			server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	captureEntity, err := o.Registry.ReconciliationRepository().GetFailureCapture(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	result, err := converters.ConvertOperationFailureCapture(captureEntity)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode failure capture response"))
+	}
+}
+
+func getOperationTimeline(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if op == nil {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.NotFoundResponse{
+			Error: fmt.Sprintf("operation (schedulingID:%s/correlationID:%s) not found", schedulingID, correlationID),
+		})
+		return
+	}
+	phases, err := o.Registry.ReconciliationRepository().GetOperationPhases(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertOperationTimeline(op, phases)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode operation timeline response"))
+	}
+}
+
+func getOperationSmokeTests(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	smokeTests, err := o.Registry.ReconciliationRepository().GetOperationSmokeTests(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertOperationSmokeTests(smokeTests)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode operation smoke tests response"))
+	}
+}
+
+func getOperationResources(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	resources, err := o.Registry.ReconciliationRepository().GetOperationResources(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertOperationResources(resources)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode operation resources response"))
+	}
+}
+
+func getOperationPayload(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	actor, err := requestActor(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to determine the user of the request").Error(),
+		})
+		return
+	}
+	payload, err := o.Registry.ReconciliationRepository().GetOperationPayload(schedulingID, correlationID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	//the payload includes the kubeconfig of the cluster: keep track of who read it
+	o.Logger().Warnf("Payload of operation (schedulingID:%s/correlationID:%s) was requested by '%s'",
+		schedulingID, correlationID, actor)
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertOperationPayload(payload)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode operation payload response"))
+	}
+}
+
+func newHealthChecker(o *Options) *health.Checker {
+	checker := health.NewChecker(&health.Config{
+		Timeout:        o.HealthCheckTimeout,
+		DisabledChecks: o.DisabledHealthChecks,
+	}, o.Logger())
+	checker.WithReadinessCheck(health.CheckDatabase, health.DatabaseCheck(o.Registry.Connection()))
+	o.Logger().Infof("Health endpoints are verifying the checks: %s", strings.Join(checker.Names(), ", "))
+	return checker
+}
+
+func callHandler(o *Options, handler func(o *Options, w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handler(o, w, r)
+	}
+}
+
+func deleteReconciliationsByCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	if _, err := params.Int64(paramContractVersion); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Contract version undefined").Error(),
+		})
+		return
+	}
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	err = o.Registry.ReconciliationRepository().RemoveReconciliationByRuntimeID(runtimeID)
+	if err == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if repository.IsNotFoundError(err) {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+	} else {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+	}
+}
+
+func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	contractV, err := params.Int64(paramContractVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Contract version undefined").Error(),
+		})
+		return
+	}
+	clusterModel, err := keb.NewModelFactory(contractV).Cluster(r.Body)
+	if tooLarge := server.RequestTooLargeError(err); tooLarge != nil {
+		server.SendHTTPError(w, http.StatusRequestEntityTooLarge, &keb.HTTPErrorResponse{
+			Error: tooLarge.Error(),
+		})
+		return
+	}
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if err := chart.ValidateProfile(clusterModel.KymaConfig.Profile); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "profile not accepted").Error(),
+		})
+		return
+	}
+	kubeconfig, err := kubernetes.NormalizeKubeconfig(clusterModel.Kubeconfig)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "kubeconfig not accepted").Error(),
+		})
+		return
+	}
+	clusterModel.Kubeconfig = kubeconfig
+	expectedGeneration, ifMatch, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if _, err := kubernetes.NewClientBuilder().WithLogger(o.Logger()).WithString(clusterModel.Kubeconfig).Build(r.Context(), true); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "kubeconfig not accepted").Error(),
+		})
+		return
+	}
+
+	clusterStateOld, err := o.Registry.Inventory().GetLatest(clusterModel.RuntimeID)
+	if err != nil && !repository.IsNotFoundError(err) {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to get latest status").Error(),
+		})
+		return
+	}
+	if clusterStateOld != nil && o.RequireIfMatch && !ifMatch {
+		server.SendHTTPError(w, http.StatusPreconditionRequired, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("Update of cluster '%s' requires the generation of its configuration "+
+				"in an If-Match header", clusterModel.RuntimeID),
+		})
+		return
+	}
+	if clusterStateOld != nil && clusterStateOld.Configuration != nil {
+		if err := validateUpgradePath(o, clusterStateOld.Configuration.KymaVersion, clusterModel.KymaConfig.Version); err != nil {
+			sendUpgradePathError(w, err)
+			return
+		}
+	}
+
+	actor, err := requestActor(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to determine the user of the request").Error(),
+		})
+		return
+	}
+	inventory := o.Registry.Inventory().WithActor(actor)
+	var clusterStateNew *cluster.State
+	if ifMatch {
+		clusterStateNew, err = inventory.UpdateIfMatch(contractV, clusterModel, expectedGeneration)
+	} else {
+		clusterStateNew, err = inventory.CreateOrUpdate(contractV, clusterModel)
+	}
+	if err != nil {
+		var conflictErr *cluster.ConfigurationConflictError
+		if errors.As(err, &conflictErr) {
+			w.Header().Set("ETag", generationETag(conflictErr.Current))
+			server.SendHTTPError(w, http.StatusConflict, &keb.HTTPConfigurationConflictResponse{
+				Error:             conflictErr.Error(),
+				CurrentGeneration: conflictErr.Current,
+			})
+			return
+		}
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to create or update cluster entity").Error(),
+		})
+		return
+	}
+
+	if clusterStateOld != nil && clusterStateOld.Status.Status.IsDisabled() {
+		if clusterStateNew, err = o.Registry.Inventory().UpdateStatus(clusterStateNew, model.ClusterStatusReconcileDisabled); err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to disable cluster after an update").Error(),
+			})
+			return
+		}
+	}
+
+	//respond status URL
+	sendResponse(w, r, clusterStateNew, o)
+}
+
+func validateUpgradePath(o *Options, from, to string) error {
+	upgradePath, err := cluster.NewUpgradePath(o.Config.Scheduler.UpgradePath)
+	if err != nil {
+		return err
+	}
+	return upgradePath.Validate(from, to)
+}
+
+func sendUpgradePathError(w http.ResponseWriter, err error) {
+	var upgradePathErr *cluster.UpgradePathError
+	if !errors.As(err, &upgradePathErr) {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	server.SendHTTPError(w, http.StatusConflict, &keb.UpgradeRejected{
+		Error:            upgradePathErr.Error(),
+		RequiredVersions: upgradePathErr.RequiredVersions,
+	})
+}
+
+func getClustersState(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	if runtimeID, err := params.String(paramRuntimeID); err == nil && runtimeID != "" {
+		state, err := o.Registry.Inventory().GetLatest(runtimeID)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
 				Error: errors.Wrap(err, "Failed to get cluster state based on runtimeID").Error(),
 			})
 			return
 		}
 
-		sendClusterStateResponse(w, state)
+		sendClusterStateResponse(w, state)
+		return
+	}
+
+	filters := []operation.Filter{}
+	if schedulingID, err := params.String(paramSchedulingID); err == nil && schedulingID != "" {
+		filters = append(filters, &operation.WithSchedulingID{
+			SchedulingID: schedulingID,
+		})
+	}
+
+	if correlationID, err := params.String(paramCorrelationID); err == nil && correlationID != "" {
+		filters = append(filters, &operation.WithCorrelationID{
+			CorrelationID: correlationID,
+		})
+	}
+
+	operations, err := o.Registry.ReconciliationRepository().GetOperations(&operation.FilterMixer{
+		Filters: append(filters, &operation.Limit{Count: 1}),
+	})
+	if err != nil {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	} else if len(operations) < 1 {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: "Failed to get operations correlated to cluster state based on given parameters",
+		})
+		return
+	}
+
+	runtimeID := operations[0].RuntimeID
+	clusterConfig := operations[0].ClusterConfig
+	state, err := o.Registry.Inventory().Get(runtimeID, clusterConfig)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	sendClusterStateResponse(w, state)
+}
+
+func getCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	configVersion, err := params.Int64(paramConfigVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	clusterState, err := o.Registry.Inventory().Get(runtimeID, configVersion)
+	if err != nil {
+		var httpCode int
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		} else {
+			httpCode = http.StatusInternalServerError
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
+		})
+		return
+	}
+	sendResponse(w, r, clusterState, o)
+}
+
+func updateLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	clusterName, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	contractV, err := params.Int64(paramContractVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Contract version undefined").Error(),
+		})
+		return
+	}
+	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
+	status, err := keb.NewModelFactory(contractV).Status(bodyLimited)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+
+	clusterState, err := o.Registry.Inventory().GetLatest(clusterName)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not update cluster state").Error(),
+		})
+		return
+	}
+
+	actor, err := requestActor(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to determine the user of the request").Error(),
+		})
+		return
+	}
+	clusterState, err = o.Registry.Inventory().WithActor(actor).UpdateStatus(clusterState, model.Status(status.Status))
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not update cluster state").Error(),
+		})
+		return
+	}
+
+	sendResponse(w, r, clusterState, o)
+}
+
+func getReconciliations(o *Options, w http.ResponseWriter, r *http.Request) {
+	// define variables
+	var filters []reconciliation.Filter
+
+	params := server.NewParams(r)
+
+	runtimeIDs, err := params.StrSlice(paramRuntimeIDs)
+	runtimeIDsDefined := err == nil
+	if tenant := tenantOf(r); tenant != "" {
+		//requests scoped to a tenant are restricted to the clusters of the tenant
+		tenantRuntimeIDs, err := o.Registry.Inventory().TenantClusters(tenant)
+		if err != nil {
+			server.SendHTTPErrorMap(w, err)
+			return
+		}
+		if runtimeIDsDefined {
+			tenantRuntimeIDs = intersect(runtimeIDs, tenantRuntimeIDs)
+		}
+		if len(tenantRuntimeIDs) == 0 {
+			w.Header().Set("content-type", "application/json")
+			if err := json.NewEncoder(w).Encode(keb.ReconcilationsOKResponse{}); err != nil {
+				server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode cluster list response"))
+			}
+			return
+		}
+		runtimeIDs, runtimeIDsDefined = tenantRuntimeIDs, true
+	}
+	if runtimeIDsDefined {
+		filters = append(filters, &reconciliation.WithRuntimeIDs{RuntimeIDs: runtimeIDs})
+	}
+
+	if statuses, err := params.StrSlice(paramStatus); err == nil {
+		if err := validateStatuses(statuses); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+		filters = append(filters, &reconciliation.WithStatuses{Statuses: statuses})
+	}
+
+	if after, err := params.String(paramAfter); err == nil && after != "" {
+		t, err := time.Parse(paramTimeFormat, after)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+		filters = append(filters, &reconciliation.WithCreationDateAfter{Time: t})
+	}
+
+	if before, err := params.String(paramBefore); err == nil && before != "" {
+		t, err := time.Parse(paramTimeFormat, before)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+		filters = append(filters, &reconciliation.WithCreationDateBefore{Time: t})
+	}
+
+	if limit, err := params.Int(paramLast); err == nil {
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+		filters = append(filters, &reconciliation.Limit{Count: limit})
+	}
+
+	// Fetch all reconciliation entities
+	reconciles, err := o.Registry.
+		ReconciliationRepository().
+		GetReconciliations(
+			&reconciliation.FilterMixer{Filters: filters},
+		)
+
+	if err != nil {
+		server.SendHTTPError(
+			w,
+			http.StatusInternalServerError,
+			&keb.InternalError{Error: err.Error()},
+		)
+		return
+	}
+
+	var results []keb.Reconciliation
+
+	for _, reconcile := range reconciles {
+		results = append(results, keb.Reconciliation{
+			Created:      reconcile.Created,
+			Lock:         reconcile.Lock,
+			RuntimeID:    reconcile.RuntimeID,
+			SchedulingID: reconcile.SchedulingID,
+			Status:       keb.Status(reconcile.Status),
+			Updated:      reconcile.Updated,
+			Finished:     reconcile.Finished,
+			Initiator:    keb.Initiator(reconcile.Initiator),
+			Reason:       reconcile.Reason,
+		})
+	}
+
+	//respond
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.ReconcilationsOKResponse(results)); err != nil {
+		server.SendHTTPError(
+			w,
+			http.StatusInternalServerError,
+			&reconciler.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to encode cluster list response").Error(),
+			})
+		return
+	}
+}
+
+func getReconciliationInfo(o *Options, w http.ResponseWriter, r *http.Request) {
+	// find arguments
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	reconciliationEntity, err := o.Registry.ReconciliationRepository().GetReconciliation(schedulingID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	operations, err := o.Registry.ReconciliationRepository().GetOperations(&operation.WithSchedulingID{
+		SchedulingID: schedulingID,
+	})
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	result, err := converters.ConvertReconciliation(reconciliationEntity, operations)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	//ETAs are optional: a failed estimation doesn't fail the request
+	estimator := estimation.NewEstimator(o.Registry.ReconciliationRepository(), etaHistorySamples, 0)
+	now := time.Now()
+	for i, op := range operations {
+		eta, err := estimator.ETA(op, now)
+		if err != nil {
+			o.Logger().Warnf("Failed to estimate operation '%s' of reconciliation '%s': %s",
+				op.CorrelationID, schedulingID, err)
+			break
+		}
+		result.Operations[i].Eta = converters.ConvertOperationETA(eta)
+	}
+
+	//respond
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.ReconciliationInfoOKResponse(result)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode cluster list response"))
+	}
+}
+
+func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
+		})
+		return
+	}
+	sendResponse(w, r, clusterState, o)
+}
+
+func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	offset, err := params.String(paramOffset)
+	if err != nil {
+		offset = fmt.Sprintf("%dh", 24*7) //default offset is 1 week
+	}
+
+	o.Logger().Debugf("Using an offset of '%s' for cluster status updates", offset)
+
+	duration, err := time.ParseDuration(offset)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	statusChanges, err := o.Registry.Inventory().StatusChanges(runtimeID, duration)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster statusChanges").Error(),
+		})
+		return
+	}
+
+	resp := keb.HTTPClusterStatusResponse{}
+	for _, statusChange := range statusChanges {
+		kebClusterStatus, err := statusChange.Status.GetKEBClusterStatus()
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to map reconciler internal cluster status to KEB cluster status").Error(),
+			})
+			return
+		}
+		resp.StatusChanges = append(resp.StatusChanges, keb.StatusChange{
+			Started:  statusChange.Status.Created,
+			Duration: int64(statusChange.Duration),
+			Status:   kebClusterStatus,
+		})
+	}
+
+	//respond
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster statusChanges response").Error(),
+		})
+		return
+	}
+}
+
+func configurationChanges(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	limit := defaultConfigurationChangesLimit
+	if _, err := params.String(paramLimit); err == nil {
+		if limit, err = params.Int(paramLimit); err != nil || limit <= 0 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("parameter '%s' has to be a positive number", paramLimit),
+			})
+			return
+		}
+	}
+
+	changes, err := o.Registry.Inventory().ConfigurationChanges(runtimeID, limit)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve cluster configuration changes").Error(),
+		})
+		return
+	}
+	if len(changes) == 0 {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("no configuration changes found for cluster '%s'", runtimeID),
+		})
+		return
+	}
+
+	resp := keb.HTTPClusterChanges{RuntimeID: runtimeID}
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, keb.ClusterChange{
+			Actor:         change.Actor,
+			ConfigVersion: change.ConfigVersion,
+			Generation:    change.Generation,
+			Created:       change.Created,
+			Diff:          *change.Diff,
+		})
+	}
+
+	//respond
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster configuration changes response").Error(),
+		})
+		return
+	}
+}
+
+func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	deleteClusterFromInventory(o, o.Registry.Inventory(), w, r)
+}
+
+func deleteClusterFromInventory(o *Options, inventory cluster.Inventory, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	clusterState, err := inventory.GetLatest(runtimeID)
+	if repository.IsNotFoundError(err) {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Deletion impossible: Cluster '%s' not found", runtimeID)).Error(),
+		})
+		return
+	}
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	protected, err := inventory.IsProtectedFromDeletion(runtimeID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if protected {
+		server.SendHTTPError(w, http.StatusForbidden, &keb.DeletionProtected{
+			Error: fmt.Sprintf("Deletion impossible: Cluster '%s' is protected from deletion", runtimeID),
+		})
+		return
+	}
+
+	//two-step deletion: issue a token which has to be passed to the second deletion request (old clients
+	//can only skip it if the mothership allows unconfirmed deletions)
+	token, _ := params.String(paramToken) //optional
+	if token == "" && (!o.AllowUnconfirmedDeletion || r.Header.Get(headerDeletionConfirmation) == deletionConfirmationRequired) {
+		confirmation, err := inventory.RequestDeletion(runtimeID, o.DeletionConfirmationTTL)
+		if err != nil {
+			server.SendHTTPErrorMap(w, err)
+			return
+		}
+		w.Header().Set("content-type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(converters.ConvertDeletionConfirmation(confirmation)); err != nil {
+			o.Logger().Warnf("Failed to encode deletion confirmation response: %s", err)
+		}
+		return
+	}
+
+	if o.DeletionGracePeriod <= 0 {
+		actor, err := requestActor(r)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to determine the user of the request").Error(),
+			})
+			return
+		}
+		inventory = inventory.WithActor(actor)
+	}
+	state := clusterState
+	deletion := func(iTx cluster.Inventory) error {
+		//soft-delete the cluster: the teardown is triggered by the inventory watcher after the grace period
+		if o.DeletionGracePeriod > 0 {
+			_, err := iTx.ScheduleDeletion(runtimeID, time.Now().Add(o.DeletionGracePeriod))
+			return err
+		}
+		var err error
+		state, err = iTx.MarkForDeletion(runtimeID)
+		return err
+	}
+
+	//the token is consumed within the transaction which deletes the cluster
+	if token == "" {
+		err = deletion(inventory)
+	} else {
+		err = inventory.ConfirmDeletion(runtimeID, token, deletion)
+	}
+	var confirmationErr *cluster.DeletionConfirmationError
+	if errors.As(err, &confirmationErr) {
+		server.SendHTTPError(w, http.StatusConflict, &keb.DeletionNotConfirmed{
+			Error: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to delete cluster '%s'", runtimeID)).Error(),
+		})
+		return
+	}
+	sendResponse(w, r, state, o)
+}
+
+func undeleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	if err := o.Registry.Inventory().CancelScheduledDeletion(runtimeID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	state, err := o.Registry.Inventory().GetLatest(runtimeID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	sendResponse(w, r, state, o)
+}
+
+func protectCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	//only known clusters can be protected
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	protection, err := o.Registry.Inventory().ProtectFromDeletion(runtimeID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertDeletionProtection(protection)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode deletion protection response"))
+	}
+}
+
+func unprotectCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	if err := o.Registry.Inventory().UnprotectFromDeletion(runtimeID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+	}
+}
+
+func getStatusBadge(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	token, err := params.String(paramToken)
+	if err != nil || token == "" { //don't reveal whether the cluster exists
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("no status badge found for cluster '%s'", runtimeID),
+		})
+		return
+	}
+
+	badge, err := o.Registry.Inventory().StatusBadge(runtimeID, token)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("cache-control", fmt.Sprintf("max-age=%d", int(statusBadgeMaxAge.Seconds())))
+	if err := json.NewEncoder(w).Encode(converters.ConvertStatusBadge(badge)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode status badge response"))
+	}
+}
+
+func issueStatusBadgeToken(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	//only known clusters get a status badge
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	token, err := o.Registry.Inventory().IssueStatusBadgeToken(runtimeID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.StatusBadgeToken{RuntimeID: runtimeID, Token: token}); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode status badge token response"))
+	}
+}
+
+func revokeStatusBadgeToken(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	if err := o.Registry.Inventory().RevokeStatusBadgeToken(runtimeID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+	}
+}
+
+func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var stopOperation keb.OperationStop
+	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
+	reqBody, err := io.ReadAll(bodyLimited)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+
+	err = json.Unmarshal(reqBody, &stopOperation)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+
+	op, err := getOperationStatus(o, schedulingID, correlationID)
+	if err != nil {
+		if repository.IsNotFoundError(err) {
+			server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
+				Error: "Couldn't find operation",
+			})
+			return
+		}
+
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to get operation").Error(),
+		})
+		return
+	}
+
+	if op.State != model.OperationStateNew {
+		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
+			Error: fmt.Sprintf("Operation is in status: %s. Should be in: %s in order to stop it.", op.State, model.OperationStateNew),
+		})
+		return
+	}
+
+	err = updateOperationState(o, schedulingID, correlationID, model.OperationStateDone, stopOperation.Reason)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "while updating operation status").Error(),
+		})
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	schedulingID, err := params.String(paramSchedulingID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	correlationID, err := params.String(paramCorrelationID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	var body reconciler.CallbackMessage
+	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
+	reqBody, err := io.ReadAll(bodyLimited)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+
+	err = json.Unmarshal(reqBody, &body)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+
+	if body.Manifest != nil {
+		logger.NewLogger(true).Debugf("Dry run (correlationID: %s)\n, %s", *body.Manifest)
+	}
+
+	if body.Status == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+			Error: fmt.Errorf("status not provided in payload").Error(),
+		})
+		return
+	}
+
+	if body.Phases != nil {
+		updateOperationPhases(o, schedulingID, correlationID, *body.Phases)
+	}
+	if body.SmokeTests != nil {
+		updateOperationSmokeTests(o, schedulingID, correlationID, *body.SmokeTests)
+	}
+	if body.ResourceResults != nil {
+		updateOperationResources(o, schedulingID, correlationID, *body.ResourceResults)
+	}
+
+	switch body.Status {
+	case reconciler.StatusNotstarted, reconciler.StatusRunning:
+		//warnings about stalled resources become the reason of the running operation until they're resolved
+		var warnings []string
+		if body.Warnings != nil {
+			warnings = *body.Warnings
+			o.Logger().Warnf("Operation (schedulingID:%s/correlationID:%s) reported progress warnings: %s",
+				schedulingID, correlationID, strings.Join(warnings, ", "))
+		}
+		err = updateOperationStateAndRetryID(o, schedulingID, correlationID, body.RetryID, model.OperationStateInProgress, warnings...)
+	case reconciler.StatusFailed:
+		err = updateOperationStateAndRetryID(o, schedulingID, correlationID, body.RetryID, model.OperationStateFailed, body.Error)
+	case reconciler.StatusSuccess:
+		err = updateOperationStateAndRetryIDAndProcessingDuration(o, schedulingID, correlationID, body.RetryID, model.OperationStateDone, body.ProcessingDuration)
+	case reconciler.StatusError:
+		err = updateOperationStateAndRetryIDAndProcessingDuration(o, schedulingID, correlationID, body.RetryID, model.OperationStateError, body.ProcessingDuration, body.Error)
+	case reconciler.StatusVerificationFailed:
+		err = updateOperationStateAndRetryIDAndProcessingDuration(o, schedulingID, correlationID, body.RetryID, model.OperationStateVerificationFailed, body.ProcessingDuration, body.Error)
+	}
+	if err != nil {
+		httpCode := http.StatusBadRequest
+		if repository.IsNotFoundError(err) {
+			httpCode = http.StatusNotFound
+		}
+		server.SendHTTPError(w, httpCode, &reconciler.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+}
+
+func getKymaConfig(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+
+	configVersion, err := params.Int64(paramConfigVersion)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+
+	state, err := o.Registry.Inventory().Get(runtimeID, configVersion)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if state.Configuration == nil {
+		server.SendHTTPErrorMap(w, errors.New("state configuration is nil"))
+		return
+	}
+	response := converters.ConvertConfig(*state.Configuration)
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
+
+func getComponentClusters(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	component, err := params.String(paramComponent)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+	version, _ := params.String(paramVersion) //optional filter
+
+	states, err := o.Registry.Inventory().GetAll()
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	installations := cluster.ClustersRunningComponent(states, component, version)
+	if err := json.NewEncoder(w).Encode(converters.ConvertComponentClusters(installations)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode component clusters response"))
+	}
+}
+
+func getScheduledReconciliations(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, _ := params.String(paramRuntimeID) //optional filter
+
+	schedules, err := o.Registry.Inventory().ScheduledReconciliations(runtimeID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if tenant := tenantOf(r); tenant != "" {
+		//requests scoped to a tenant only see the schedules of the tenant's clusters
+		tenantRuntimeIDs, err := o.Registry.Inventory().TenantClusters(tenant)
+		if err != nil {
+			server.SendHTTPErrorMap(w, err)
+			return
+		}
+		var tenantSchedules []*model.ScheduledReconciliationEntity
+		for _, schedule := range schedules {
+			if contains(tenantRuntimeIDs, schedule.RuntimeID) {
+				tenantSchedules = append(tenantSchedules, schedule)
+			}
+		}
+		schedules = tenantSchedules
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertScheduledReconciliations(schedules)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode scheduled reconciliations response"))
+	}
+}
+
+func scheduleReconciliation(o *Options, w http.ResponseWriter, r *http.Request) {
+	var body keb.ScheduledReconciliationCreate
+	reqBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	if err := json.Unmarshal(reqBody, &body); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if body.RuntimeID == "" || body.NotBefore.IsZero() {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: "runtimeID or notBefore not provided in payload",
+		})
+		return
+	}
+
+	//reconciliations can only be scheduled for known clusters (of the tenant if the request is scoped to a tenant)
+	clusterState, err := o.Registry.Inventory().GetLatest(body.RuntimeID)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	if tenant := tenantOf(r); tenant != "" && clusterState.Cluster.GlobalAccountID != tenant {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("cluster '%s' not found", body.RuntimeID),
+		})
+		return
+	}
+	schedule, err := o.Registry.Inventory().ScheduleReconciliation(body.RuntimeID, body.NotBefore)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertScheduledReconciliation(schedule)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode scheduled reconciliation response"))
+	}
+}
+
+func cancelScheduledReconciliation(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	scheduleID, err := params.String(paramScheduleID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	if err := o.Registry.Inventory().CancelScheduledReconciliation(scheduleID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+	}
+}
+
+func getReconcileIntervals(o *Options, w http.ResponseWriter, _ *http.Request) {
+	overrides, err := o.Registry.Inventory().ReconcileIntervals()
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
 
-	filters := []operation.Filter{}
-	if schedulingID, err := params.String(paramSchedulingID); err == nil && schedulingID != "" {
-		filters = append(filters, &operation.WithSchedulingID{
-			SchedulingID: schedulingID,
-		})
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertReconcileIntervals(overrides)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode reconcile intervals response"))
 	}
+}
 
-	if correlationID, err := params.String(paramCorrelationID); err == nil && correlationID != "" {
-		filters = append(filters, &operation.WithCorrelationID{
-			CorrelationID: correlationID,
+func setReconcileInterval(o *Options, w http.ResponseWriter, r *http.Request) {
+	var body keb.ReconcileIntervalUpdate
+	reqBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
 		})
+		return
 	}
-
-	operations, err := o.Registry.ReconciliationRepository().GetOperations(&operation.FilterMixer{
-		Filters: append(filters, &operation.Limit{Count: 1}),
-	})
-	if err != nil {
-		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
-			Error: err.Error(),
+	if err := json.Unmarshal(reqBody, &body); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
 		})
 		return
-	} else if len(operations) < 1 {
-		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
-			Error: "Failed to get operations correlated to cluster state based on given parameters",
+	}
+	if body.IntervalSeconds <= 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: "intervalSeconds not provided in payload or not positive",
 		})
 		return
 	}
 
-	runtimeID := operations[0].RuntimeID
-	clusterConfig := operations[0].ClusterConfig
-	state, err := o.Registry.Inventory().Get(runtimeID, clusterConfig)
+	var runtimeID, component string
+	if body.RuntimeID != nil {
+		runtimeID = *body.RuntimeID
+	}
+	if body.Component != nil {
+		component = *body.Component
+	}
+
+	//cluster specific intervals are only accepted for known clusters
+	if runtimeID != "" {
+		if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+			server.SendHTTPErrorMap(w, err)
+			return
+		}
+	}
+	override, err := o.Registry.Inventory().SetReconcileInterval(runtimeID, component,
+		time.Duration(body.IntervalSeconds)*time.Second)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
 
-	sendClusterStateResponse(w, state)
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertReconcileInterval(override)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode reconcile interval response"))
+	}
 }
 
-func getCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+func removeReconcileInterval(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	runtimeID, err := params.String(paramRuntimeID)
+	runtimeID, _ := params.String(paramRuntimeID) //optional
+	component, _ := params.String(paramComponent) //optional
+
+	if err := o.Registry.Inventory().RemoveReconcileInterval(runtimeID, component); err != nil {
+		server.SendHTTPErrorMap(w, err)
+	}
+}
+
+func simulateChange(o *Options, w http.ResponseWriter, r *http.Request) {
+	var body keb.SimulationRequest
+	reqBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
 		})
 		return
 	}
-	configVersion, err := params.Int64(paramConfigVersion)
-	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
+	if err := json.Unmarshal(reqBody, &body); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
 		})
 		return
 	}
-	clusterState, err := o.Registry.Inventory().Get(runtimeID, configVersion)
-	if err != nil {
-		var httpCode int
-		if repository.IsNotFoundError(err) {
-			httpCode = http.StatusNotFound
-		} else {
-			httpCode = http.StatusInternalServerError
-		}
-		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
+	if body.KymaVersion == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: "kymaVersion not provided in payload",
 		})
 		return
 	}
-	sendResponse(w, r, clusterState, o)
+
+	change := &simulation.Change{KymaVersion: body.KymaVersion}
+	if body.RuntimeIDs != nil {
+		change.RuntimeIDs = *body.RuntimeIDs
+	}
+	plan, err := simulation.NewSimulator(o.Registry.Inventory(), o.Registry.ReconciliationRepository(), &simulation.Config{
+		PreComponents:         o.Config.Scheduler.PreComponents,
+		BootstrapComponents:   o.Config.Scheduler.BootstrapComponents,
+		MaxParallelOperations: o.MaxParallelOperations,
+		Workers:               o.Workers,
+	}).Simulate(change)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertSimulationPlan(plan)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode simulation response"))
+	}
 }
 
-func updateLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
-	params := server.NewParams(r)
-	clusterName, err := params.String(paramRuntimeID)
+func getTenantQuotas(o *Options, w http.ResponseWriter, _ *http.Request) {
+	quotas, err := o.Registry.Inventory().TenantQuotas()
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
-	contractV, err := params.Int64(paramContractVersion)
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertTenantQuotas(quotas)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode tenant quotas response"))
+	}
+}
+
+func setTenantQuota(o *Options, w http.ResponseWriter, r *http.Request) {
+	var body keb.TenantQuotaUpdate
+	reqBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Contract version undefined").Error(),
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
 		})
 		return
 	}
-	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
-	status, err := keb.NewModelFactory(contractV).Status(bodyLimited)
-	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+	if err := json.Unmarshal(reqBody, &body); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
 			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
 		})
 		return
 	}
-
-	clusterState, err := o.Registry.Inventory().GetLatest(clusterName)
-	if err != nil {
-		httpCode := http.StatusInternalServerError
-		if repository.IsNotFoundError(err) {
-			httpCode = http.StatusNotFound
-		}
-		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Could not update cluster state").Error(),
+	if body.GlobalAccountID == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: "globalAccountID not provided in payload",
+		})
+		return
+	}
+	if body.MaxParallelOperations < 0 || body.MaxOperationsPerMinute < 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: "maxParallelOperations and maxOperationsPerMinute cannot be negative",
 		})
 		return
 	}
 
-	clusterState, err = o.Registry.Inventory().UpdateStatus(clusterState, model.Status(status.Status))
+	quota, err := o.Registry.Inventory().SetTenantQuota(body.GlobalAccountID,
+		body.MaxParallelOperations, body.MaxOperationsPerMinute)
 	if err != nil {
-		httpCode := http.StatusInternalServerError
-		if repository.IsNotFoundError(err) {
-			httpCode = http.StatusNotFound
-		}
-		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Could not update cluster state").Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
 
-	sendResponse(w, r, clusterState, o)
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertTenantQuota(quota)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode tenant quota response"))
+	}
 }
 
-func getReconciliations(o *Options, w http.ResponseWriter, r *http.Request) {
-	// define variables
-	var filters []reconciliation.Filter
-
+func removeTenantQuota(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-
-	if runtimeIDs, err := params.StrSlice(paramRuntimeIDs); err == nil {
-		filters = append(filters, &reconciliation.WithRuntimeIDs{RuntimeIDs: runtimeIDs})
+	tenant, err := params.String(paramTenant)
+	if err != nil || tenant == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: fmt.Sprintf("query parameter '%s' is required", paramTenant),
+		})
+		return
 	}
 
-	if statuses, err := params.StrSlice(paramStatus); err == nil {
-		if err := validateStatuses(statuses); err != nil {
-			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
-			return
-		}
-		filters = append(filters, &reconciliation.WithStatuses{Statuses: statuses})
+	if err := o.Registry.Inventory().RemoveTenantQuota(tenant); err != nil {
+		server.SendHTTPErrorMap(w, err)
 	}
+}
 
-	if after, err := params.String(paramAfter); err == nil && after != "" {
-		t, err := time.Parse(paramTimeFormat, after)
-		if err != nil {
-			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+func getFreezes(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	includeReleased := false
+	if value, err := params.String(paramReleased); err == nil && value != "" {
+		if includeReleased, err = strconv.ParseBool(value); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+				Error: fmt.Sprintf("parameter '%s' has to be a boolean but was '%s'", paramReleased, value),
+			})
 			return
 		}
-		filters = append(filters, &reconciliation.WithCreationDateAfter{Time: t})
 	}
 
-	if before, err := params.String(paramBefore); err == nil && before != "" {
-		t, err := time.Parse(paramTimeFormat, before)
-		if err != nil {
-			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
-			return
-		}
-		filters = append(filters, &reconciliation.WithCreationDateBefore{Time: t})
+	freezes, err := o.Registry.Inventory().Freezes(includeReleased)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
 	}
 
-	if limit, err := params.Int(paramLast); err == nil {
-		if err != nil {
-			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
-			return
-		}
-		filters = append(filters, &reconciliation.Limit{Count: limit})
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertFreezes(freezes)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode freezes response"))
 	}
+}
 
-	// Fetch all reconciliation entities
-	reconciles, err := o.Registry.
-		ReconciliationRepository().
-		GetReconciliations(
-			&reconciliation.FilterMixer{Filters: filters},
-		)
+func engageFreeze(o *Options, w http.ResponseWriter, r *http.Request) {
+	var body keb.FreezeUpdate
+	reqBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	if err := json.Unmarshal(reqBody, &body); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
+		return
+	}
+	if strings.TrimSpace(body.Reason) == "" {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: "reason not provided in payload",
+		})
+		return
+	}
+	var component string
+	if body.Component != nil {
+		component = *body.Component
+	}
 
+	actor, err := requestActor(r)
 	if err != nil {
-		server.SendHTTPError(
-			w,
-			http.StatusInternalServerError,
-			&keb.InternalError{Error: err.Error()},
-		)
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to determine the user of the request").Error(),
+		})
 		return
 	}
+	freeze, err := o.Registry.Inventory().WithActor(actor).Freeze(component, body.Reason)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
+	}
+	o.Logger().Warnf("Scheduling of component '%s' is frozen by '%s': %s",
+		freezeComponent(freeze.Component), freeze.EngagedBy, freeze.Reason)
 
-	var results []keb.Reconciliation
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertFreeze(freeze)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode freeze response"))
+	}
+}
 
-	for _, reconcile := range reconciles {
-		results = append(results, keb.Reconciliation{
-			Created:      reconcile.Created,
-			Lock:         reconcile.Lock,
-			RuntimeID:    reconcile.RuntimeID,
-			SchedulingID: reconcile.SchedulingID,
-			Status:       keb.Status(reconcile.Status),
-			Updated:      reconcile.Updated,
-			Finished:     reconcile.Finished,
+func releaseFreeze(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	component, _ := params.String(paramComponent) //optional
+
+	actor, err := requestActor(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to determine the user of the request").Error(),
 		})
+		return
+	}
+	freeze, err := o.Registry.Inventory().WithActor(actor).ReleaseFreeze(component)
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
 	}
+	o.Logger().Warnf("Freeze of component '%s' is released by '%s'", freezeComponent(freeze.Component),
+		freeze.ReleasedBy)
 
-	//respond
 	w.Header().Set("content-type", "application/json")
-	if err := json.NewEncoder(w).Encode(keb.ReconcilationsOKResponse(results)); err != nil {
-		server.SendHTTPError(
-			w,
-			http.StatusInternalServerError,
-			&reconciler.HTTPErrorResponse{
-				Error: errors.Wrap(err, "Failed to encode cluster list response").Error(),
-			})
+	if err := json.NewEncoder(w).Encode(converters.ConvertFreeze(freeze)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode freeze response"))
+	}
+}
+
+// freezeComponent renders the empty component of a fleet-wide freeze
+func freezeComponent(component string) string {
+	if component == "" {
+		return "*"
+	}
+	return component
+}
+
+func getFeatureFlags(o *Options, w http.ResponseWriter, _ *http.Request) {
+	flags, err := o.Registry.Inventory().FeatureFlags()
+	if err != nil {
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertFeatureFlags(flags)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode feature flags response"))
+	}
 }
 
-func getReconciliationInfo(o *Options, w http.ResponseWriter, r *http.Request) {
-	// find arguments
+func saveFeatureFlag(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	schedulingID, err := params.String(paramSchedulingID)
+	name, err := params.String(paramFlag)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
 		return
 	}
-	reconciliationEntity, err := o.Registry.ReconciliationRepository().GetReconciliation(schedulingID)
+
+	var body keb.FeatureFlagUpdate
+	reqBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
 	if err != nil {
-		server.SendHTTPErrorMap(w, err)
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.InternalError{
+			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+		})
+		return
+	}
+	if err := json.Unmarshal(reqBody, &body); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+		})
 		return
 	}
 
-	operations, err := o.Registry.ReconciliationRepository().GetOperations(&operation.WithSchedulingID{
-		SchedulingID: schedulingID,
-	})
-	if err != nil {
-		server.SendHTTPErrorMap(w, err)
+	flag := &model.FeatureFlagEntity{
+		Name:              name,
+		RolloutPercentage: body.RolloutPercentage,
+	}
+	if body.Description != nil {
+		flag.Description = *body.Description
+	}
+	if body.EnabledClusters != nil {
+		flag.EnabledClusters = *body.EnabledClusters
+	}
+	if body.DisabledClusters != nil {
+		flag.DisabledClusters = *body.DisabledClusters
+	}
+	if err := cluster.ValidateFeatureFlag(flag); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
 		return
 	}
 
-	result, err := converters.ConvertReconciliation(reconciliationEntity, operations)
+	flag, err = o.Registry.Inventory().SaveFeatureFlag(flag)
 	if err != nil {
 		server.SendHTTPErrorMap(w, err)
 		return
 	}
 
-	//respond
 	w.Header().Set("content-type", "application/json")
-	if err := json.NewEncoder(w).Encode(keb.ReconciliationInfoOKResponse(result)); err != nil {
-		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode cluster list response"))
+	if err := json.NewEncoder(w).Encode(converters.ConvertFeatureFlag(flag)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode feature flag response"))
 	}
 }
 
-func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+func removeFeatureFlag(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	runtimeID, err := params.String(paramRuntimeID)
+	name, err := params.String(paramFlag)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
 		return
 	}
-	clusterState, err := o.Registry.Inventory().GetLatest(runtimeID)
-	if err != nil {
-		httpCode := http.StatusInternalServerError
-		if repository.IsNotFoundError(err) {
-			httpCode = http.StatusNotFound
-		}
-		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Could not retrieve cluster state").Error(),
-		})
+
+	if err := o.Registry.Inventory().RemoveFeatureFlag(name); err != nil {
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
-	sendResponse(w, r, clusterState, o)
+	w.WriteHeader(http.StatusOK)
 }
 
-func statusChanges(o *Options, w http.ResponseWriter, r *http.Request) {
+func getClusterFeatureFlags(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-
 	runtimeID, err := params.String(paramRuntimeID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
 		return
 	}
 
-	offset, err := params.String(paramOffset)
-	if err != nil {
-		offset = fmt.Sprintf("%dh", 24*7) //default offset is 1 week
+	//flags are only evaluated for known clusters
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
 	}
-
-	o.Logger().Debugf("Using an offset of '%s' for cluster status updates", offset)
-
-	duration, err := time.ParseDuration(offset)
+	enabled, err := o.Registry.Inventory().EnabledFeatureFlags(runtimeID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
 
-	statusChanges, err := o.Registry.Inventory().StatusChanges(runtimeID, duration)
-	if err != nil {
-		httpCode := http.StatusInternalServerError
-		if repository.IsNotFoundError(err) {
-			httpCode = http.StatusNotFound
-		}
-		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Could not retrieve cluster statusChanges").Error(),
-		})
-		return
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertClusterFeatureFlags(runtimeID, enabled)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode cluster feature flags response"))
 	}
+}
 
-	resp := keb.HTTPClusterStatusResponse{}
-	for _, statusChange := range statusChanges {
-		kebClusterStatus, err := statusChange.Status.GetKEBClusterStatus()
-		if err != nil {
-			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-				Error: errors.Wrap(err, "Failed to map reconciler internal cluster status to KEB cluster status").Error(),
+func getFleetReport(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+
+	before := time.Now()
+	if value, err := params.String(paramBefore); err == nil && value != "" {
+		if before, err = time.Parse(paramTimeFormat, value); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+	}
+	after := before.Add(-defaultReportWindow)
+	if value, err := params.String(paramAfter); err == nil && value != "" {
+		if after, err = time.Parse(paramTimeFormat, value); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+	}
+	top := report.DefaultTopFailures
+	if value, err := params.String(paramTop); err == nil && value != "" {
+		if top, err = strconv.Atoi(value); err != nil || top < 0 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+				Error: fmt.Sprintf("parameter '%s' has to be a non-negative number but was '%s'", paramTop, value),
 			})
 			return
 		}
-		resp.StatusChanges = append(resp.StatusChanges, keb.StatusChange{
-			Started:  statusChange.Status.Created,
-			Duration: int64(statusChange.Duration),
-			Status:   kebClusterStatus,
-		})
 	}
-
-	//respond
-	w.Header().Set("content-type", "application/json")
-	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to encode cluster statusChanges response").Error(),
+	if !after.Before(before) {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: fmt.Sprintf("parameter '%s' has to be before parameter '%s'", paramAfter, paramBefore),
 		})
 		return
 	}
-}
 
-func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
-	params := server.NewParams(r)
-	runtimeID, err := params.String(paramRuntimeID)
+	fleetReport, err := report.NewGenerator(o.Registry.ReconciliationRepository()).Fleet(after, before, top)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
-	if _, err := o.Registry.Inventory().GetLatest(runtimeID); repository.IsNotFoundError(err) {
-		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, fmt.Sprintf("Deletion impossible: Cluster '%s' not found", runtimeID)).Error(),
-		})
+	result := keb.HTTPFleetReport(converters.ConvertFleetReport(fleetReport))
+
+	if format, _ := params.String(paramFormat); format == formatCSV {
+		w.Header().Set("content-type", "text/csv")
+		if err := report.WriteCSV(w, &result); err != nil {
+			server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to write fleet report as CSV"))
+		}
 		return
 	}
-	state, err := o.Registry.Inventory().MarkForDeletion(runtimeID)
-	if err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, fmt.Sprintf("Failed to delete cluster '%s'", runtimeID)).Error(),
-		})
-		return
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode fleet report response"))
 	}
-	sendResponse(w, r, state, o)
 }
 
-func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
+func getFreshnessReport(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	schedulingID, err := params.String(paramSchedulingID)
-	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: err.Error(),
-		})
-		return
+
+	objective := o.FreshnessObjective
+	if value, err := params.String(paramObjective); err == nil && value != "" {
+		if objective, err = time.ParseDuration(value); err != nil || objective <= 0 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+				Error: fmt.Sprintf("parameter '%s' has to be a positive duration but was '%s'", paramObjective, value),
+			})
+			return
+		}
 	}
-	correlationID, err := params.String(paramCorrelationID)
-	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: err.Error(),
+	if objective <= 0 {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+			Error: fmt.Sprintf("no freshness objective is configured: parameter '%s' is required", paramObjective),
 		})
 		return
 	}
+	violationsOnly := false
+	if value, err := params.String(paramViolations); err == nil && value != "" {
+		if violationsOnly, err = strconv.ParseBool(value); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{
+				Error: fmt.Sprintf("parameter '%s' has to be a boolean but was '%s'", paramViolations, value),
+			})
+			return
+		}
+	}
 
-	var stopOperation keb.OperationStop
-	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
-	reqBody, err := io.ReadAll(bodyLimited)
+	states, err := o.Registry.Inventory().GetAll()
 	if err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
-
-	err = json.Unmarshal(reqBody, &stopOperation)
+	freshnessReport, err := report.NewGenerator(o.Registry.ReconciliationRepository()).Freshness(states, objective)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
 
-	op, err := getOperationStatus(o, schedulingID, correlationID)
-	if err != nil {
-		if repository.IsNotFoundError(err) {
-			server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
-				Error: "Couldn't find operation",
-			})
-			return
-		}
-
-		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to get operation").Error(),
-		})
-		return
+	w.Header().Set("content-type", "application/json")
+	result := keb.HTTPFreshnessReport(converters.ConvertFreshnessReport(freshnessReport, violationsOnly))
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode freshness report response"))
 	}
+}
 
-	if op.State != model.OperationStateNew {
-		server.SendHTTPError(w, http.StatusForbidden, &reconciler.HTTPErrorResponse{
-			Error: fmt.Sprintf("Operation is in status: %s. Should be in: %s in order to stop it.", op.State, model.OperationStateNew),
-		})
+func getComponentPins(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
 		return
 	}
 
-	err = updateOperationState(o, schedulingID, correlationID, model.OperationStateDone, stopOperation.Reason)
+	pins, err := o.Registry.Inventory().ComponentPins(runtimeID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
-			Error: errors.Wrap(err, "while updating operation status").Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertComponentPins(pins)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode component pins response"))
+	}
 }
 
-func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
+func pinComponent(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
-	schedulingID, err := params.String(paramSchedulingID)
+	runtimeID, err := params.String(paramRuntimeID)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
 		return
 	}
-	correlationID, err := params.String(paramCorrelationID)
+	component, err := params.String(paramComponent)
 	if err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
 		return
 	}
 
-	var body reconciler.CallbackMessage
-	bodyLimited := http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes)
-	reqBody, err := io.ReadAll(bodyLimited)
+	var body keb.ComponentPinUpdate
+	reqBody, err := io.ReadAll(http.MaxBytesReader(w, r.Body, bodyRequestLimitBytes))
 	if err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
 			Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
 		})
 		return
 	}
-
-	err = json.Unmarshal(reqBody, &body)
-	if err != nil {
+	if err := json.Unmarshal(reqBody, &body); err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
 			Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
 		})
 		return
 	}
-
-	if body.Manifest != nil {
-		logger.NewLogger(true).Debugf("Dry run (correlationID: %s)\n, %s", *body.Manifest)
-	}
-
-	if body.Status == "" {
+	if body.Version == "" {
 		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: fmt.Errorf("status not provided in payload").Error(),
+			Error: "version not provided in payload",
 		})
 		return
 	}
 
-	switch body.Status {
-	case reconciler.StatusNotstarted, reconciler.StatusRunning:
-		err = updateOperationStateAndRetryID(o, schedulingID, correlationID, body.RetryID, model.OperationStateInProgress)
-	case reconciler.StatusFailed:
-		err = updateOperationStateAndRetryID(o, schedulingID, correlationID, body.RetryID, model.OperationStateFailed, body.Error)
-	case reconciler.StatusSuccess:
-		err = updateOperationStateAndRetryIDAndProcessingDuration(o, schedulingID, correlationID, body.RetryID, model.OperationStateDone, body.ProcessingDuration)
-	case reconciler.StatusError:
-		err = updateOperationStateAndRetryIDAndProcessingDuration(o, schedulingID, correlationID, body.RetryID, model.OperationStateError, body.ProcessingDuration, body.Error)
+	//pins are only accepted for known clusters
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); err != nil {
+		server.SendHTTPErrorMap(w, err)
+		return
 	}
+	pin, err := o.Registry.Inventory().PinComponent(runtimeID, component, body.Version)
 	if err != nil {
-		httpCode := http.StatusBadRequest
-		if repository.IsNotFoundError(err) {
-			httpCode = http.StatusNotFound
-		}
-		server.SendHTTPError(w, httpCode, &reconciler.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+		server.SendHTTPErrorMap(w, err)
 		return
 	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(converters.ConvertComponentPin(pin)); err != nil {
+		server.SendHTTPErrorMap(w, errors.Wrap(err, "Failed to encode component pin response"))
+	}
 }
 
-func getKymaConfig(o *Options, w http.ResponseWriter, r *http.Request) {
+func unpinComponent(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	runtimeID, err := params.String(paramRuntimeID)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
 		return
 	}
-
-	configVersion, err := params.Int64(paramConfigVersion)
+	component, err := params.String(paramComponent)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
 		return
 	}
 
-	state, err := o.Registry.Inventory().Get(runtimeID, configVersion)
-	if err != nil {
+	if err := o.Registry.Inventory().UnpinComponent(runtimeID, component); err != nil {
 		server.SendHTTPErrorMap(w, err)
 		return
 	}
-	if state.Configuration == nil {
-		server.SendHTTPErrorMap(w, errors.New("state configuration is nil"))
-		return
-	}
-	response := converters.ConvertConfig(*state.Configuration)
-
-	w.Header().Set("content-type", "application/json")
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
-			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
-		})
-	}
+	w.WriteHeader(http.StatusOK)
 }
 
 func createOrUpdateComponentWorkerPoolOccupancy(o *Options, w http.ResponseWriter, r *http.Request) {
@@ -1043,6 +2688,38 @@ func updateOperationStateAndRetryIDAndProcessingDuration(o *Options, schedulingI
 	return db.Transaction(o.Registry.Connection(), dbOps, o.Logger())
 }
 
+func updateOperationPhases(o *Options, schedulingID, correlationID string, phases []reconciler.OperationPhase) {
+	operationPhases, err := reconciliation.NewOperationPhases(phases)
+	if err == nil {
+		err = o.Registry.ReconciliationRepository().UpdateOperationPhases(schedulingID, correlationID, operationPhases)
+	}
+	if err != nil {
+		//phases are only informative: don't reject the callback
+		o.Logger().Warnf("REST endpoint failed to update phases of operation (schedulingID:%s/correlationID:%s): %s",
+			schedulingID, correlationID, err)
+	}
+}
+
+func updateOperationSmokeTests(o *Options, schedulingID, correlationID string, smokeTests []reconciler.SmokeTestResult) {
+	err := o.Registry.ReconciliationRepository().UpdateOperationSmokeTests(schedulingID, correlationID,
+		reconciliation.NewOperationSmokeTests(smokeTests))
+	if err != nil {
+		//results are only informative: the status reflects whether the smoke tests passed
+		o.Logger().Warnf("REST endpoint failed to update smoke test results of operation (schedulingID:%s/correlationID:%s): %s",
+			schedulingID, correlationID, err)
+	}
+}
+
+func updateOperationResources(o *Options, schedulingID, correlationID string, resources []reconciler.ResourceResult) {
+	err := o.Registry.ReconciliationRepository().UpdateOperationResources(schedulingID, correlationID,
+		reconciliation.NewOperationResources(resources))
+	if err != nil {
+		//outcomes are only informative: don't fail the callback
+		o.Logger().Warnf("REST endpoint failed to update resource outcomes of operation (schedulingID:%s/correlationID:%s): %s",
+			schedulingID, correlationID, err)
+	}
+}
+
 func getOperationStatus(o *Options, schedulingID, correlationID string) (*model.OperationEntity, error) {
 	op, err := o.Registry.ReconciliationRepository().GetOperation(schedulingID, correlationID)
 	if err != nil {
@@ -1061,6 +2738,7 @@ func sendResponse(w http.ResponseWriter, r *http.Request, clusterState *cluster.
 	}
 
 	w.Header().Set("content-type", "application/json")
+	w.Header().Set("ETag", generationETag(clusterState.Configuration.Generation))
 	if err := json.NewEncoder(w).Encode(respModel); err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
 			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
@@ -1085,6 +2763,32 @@ func sendClusterStateResponse(w http.ResponseWriter, state *cluster.State) {
 	}
 }
 
+// componentHealth returns the results of the latest health probe of the cluster (nil if the probe is disabled or
+// didn't check the cluster yet)
+func componentHealth(o *Options, runtimeID string) *[]keb.ComponentHealth {
+	if o.HealthProber == nil {
+		return nil
+	}
+	probed := o.HealthProber.Health(runtimeID)
+	if len(probed) == 0 {
+		return nil
+	}
+	result := make([]keb.ComponentHealth, 0, len(probed))
+	for _, health := range probed {
+		entry := keb.ComponentHealth{
+			Component: health.Component,
+			Status:    keb.ComponentHealthStatus(health.Status),
+			Probed:    health.Probed,
+		}
+		if health.Message != "" {
+			message := health.Message
+			entry.Message = &message
+		}
+		result = append(result, entry)
+	}
+	return &result
+}
+
 func newClusterResponse(r *http.Request, clusterState *cluster.State, o *Options) (*keb.HTTPClusterResponse, error) {
 	reconciliationRepository := o.Registry.ReconciliationRepository()
 	kebStatus, err := clusterState.Status.GetKEBClusterStatus()
@@ -1118,8 +2822,19 @@ func newClusterResponse(r *http.Request, clusterState *cluster.State, o *Options
 		}
 	}
 
+	var deletionScheduled *time.Time
+	deletions, err := o.Registry.Inventory().ScheduledDeletions(clusterState.Cluster.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(deletions) > 0 {
+		deletionScheduled = &deletions[0].TeardownAfter
+	}
+
 	return &keb.HTTPClusterResponse{
 		Cluster:              clusterState.Cluster.RuntimeID,
+		ComponentHealth:      componentHealth(o, clusterState.Cluster.RuntimeID),
+		DeletionScheduled:    deletionScheduled,
 		ClusterVersion:       clusterState.Cluster.Version,
 		ConfigurationVersion: clusterState.Configuration.Version,
 		Status:               kebStatus,
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/probe"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 
 	"github.com/pkg/errors"
@@ -21,6 +22,11 @@ type Options struct {
 	WatchInterval                  time.Duration
 	OrphanOperationTimeout         time.Duration
 	ClusterReconcileInterval       time.Duration
+	ClusterReconcileJitter         float64
+	MaxOperationsPerMinute         int
+	AllowUnconfirmedDeletion       bool
+	DeletionConfirmationTTL        time.Duration
+	DeletionGracePeriod            time.Duration
 	PurgeEntitiesOlderThan         time.Duration
 	CleanerInterval                time.Duration
 	BookkeeperWatchInterval        time.Duration
@@ -34,7 +40,26 @@ type Options struct {
 	AuditLogFile                   string
 	AuditLogTenantID               string
 	StopAfterMigration             bool
+	HealthCheckTimeout             time.Duration
+	DisabledHealthChecks           []string
+	TenantClaim                    string
+	TenantMaxParallelOperations    int
+	TenantMaxOperationsPerMinute   int
+	AnomalyDetectionInterval       time.Duration
+	AnomalyWebhookURL              string
+	RequireIfMatch                 bool
+	ReadOnly                       bool
+	EventsWebhookURL               string
+	EventsSource                   string
+	ConfigReloadInterval           time.Duration
 	Config                         *config.Config
+	HealthProbeInterval            time.Duration
+	HealthProber                   *probe.Prober
+	HealthRepair                   bool
+	HealthRepairCooldown           time.Duration
+	FreshnessObjective             time.Duration
+	ReconcileBackoffFactor         float64
+	ReconcileBackoffMaxInterval    time.Duration
 }
 
 func NewOptions(o *cli.Options) *Options {
@@ -46,6 +71,11 @@ func NewOptions(o *cli.Options) *Options {
 		0 * time.Second,  //WatchInterval
 		0 * time.Minute,  //Orphan timeout
 		0 * time.Second,  //ClusterReconcileInterval
+		0,                //ClusterReconcileJitter
+		0,                //MaxOperationsPerMinute
+		false,            //AllowUnconfirmedDeletion
+		0 * time.Minute,  //DeletionConfirmationTTL
+		0 * time.Minute,  //DeletionGracePeriod
 		0 * time.Minute,  //PurgeEntitiesOlderThan
 		0 * time.Minute,  //CleanerInterval
 		45 * time.Second, //BookkeeperWatchInterval
@@ -59,7 +89,26 @@ func NewOptions(o *cli.Options) *Options {
 		"",               //AuditLogFile
 		"",               //AuditLogTenant
 		false,            //StopAfterMigration
+		0 * time.Second,  //HealthCheckTimeout
+		[]string{},       //DisabledHealthChecks
+		"",               //TenantClaim
+		0,                //TenantMaxParallelOperations
+		0,                //TenantMaxOperationsPerMinute
+		0 * time.Minute,  //AnomalyDetectionInterval
+		"",               //AnomalyWebhookURL
+		false,            //RequireIfMatch
+		false,            //ReadOnly
+		"",               //EventsWebhookURL
+		"",               //EventsSource
+		0 * time.Second,  //ConfigReloadInterval
 		&config.Config{}, //Config
+		0 * time.Second,  //HealthProbeInterval
+		nil,              //HealthProber
+		false,            //HealthRepair
+		0 * time.Minute,  //HealthRepairCooldown
+		0 * time.Hour,    //FreshnessObjective
+		0,                //ReconcileBackoffFactor
+		0 * time.Hour,    //ReconcileBackoffMaxInterval
 	}
 }
 
@@ -67,6 +116,9 @@ func (o *Options) Validate() error {
 	if o.Port <= 0 || o.Port > 65535 {
 		return fmt.Errorf("port %d is out of range 1-65535", o.Port)
 	}
+	if err := o.HTTPServer.Validate(); err != nil {
+		return err
+	}
 	if o.Workers <= 0 {
 		return errors.New("amount of workers cannot be <= 0")
 	}
@@ -79,6 +131,18 @@ func (o *Options) Validate() error {
 	if o.ClusterReconcileInterval <= 0 {
 		return errors.New("cluster reconciliation interval cannot be <= 0")
 	}
+	if o.ClusterReconcileJitter < 0 || o.ClusterReconcileJitter > 1 {
+		return errors.New("cluster reconciliation jitter has to be within 0 and 1")
+	}
+	if o.MaxOperationsPerMinute < 0 {
+		return errors.New("maximal operations per minute cannot be < 0")
+	}
+	if o.DeletionConfirmationTTL <= 0 {
+		return errors.New("TTL of cluster deletion confirmations cannot be <= 0")
+	}
+	if o.DeletionGracePeriod < 0 {
+		return errors.New("grace period of cluster deletions cannot be < 0")
+	}
 	if o.ReconciliationsKeepLatestCount < 0 {
 		return errors.New("cleaner count of latest entities to keep cannot be < 0")
 	}
@@ -94,6 +158,47 @@ func (o *Options) Validate() error {
 	if o.MaxParallelOperations < 0 {
 		return errors.New("maximal parallel reconciled components per cluster cannot be < 0")
 	}
+	if o.TenantMaxParallelOperations < 0 {
+		return errors.New("maximal parallel operations per tenant cannot be < 0")
+	}
+	if o.TenantMaxOperationsPerMinute < 0 {
+		return errors.New("maximal operations per minute of a tenant cannot be < 0")
+	}
+	if o.AnomalyDetectionInterval < 0 {
+		return errors.New("interval of the anomaly detection cannot be < 0")
+	}
+	if o.AnomalyWebhookURL != "" && o.AnomalyDetectionInterval == 0 {
+		return errors.New("anomaly webhook requires an anomaly detection interval")
+	}
+	if o.ConfigReloadInterval < 0 {
+		return errors.New("interval to reload the configuration file cannot be < 0")
+	}
+	if o.HealthProbeInterval < 0 {
+		return errors.New("interval of the component health probe cannot be < 0")
+	}
+	if o.HealthRepair {
+		if o.HealthProbeInterval == 0 {
+			return errors.New("repair of unhealthy components requires a health probe interval")
+		}
+		if o.ReadOnly {
+			return errors.New("repair of unhealthy components is not possible in read-only mode")
+		}
+		if o.HealthRepairCooldown < 0 {
+			return errors.New("cooldown between repairs of a cluster cannot be < 0")
+		}
+	}
+	if o.FreshnessObjective < 0 {
+		return errors.New("freshness objective cannot be < 0")
+	}
+	if o.ReconcileBackoffFactor < 0 {
+		return errors.New("reconcile interval backoff factor cannot be < 0")
+	}
+	if o.ReconcileBackoffFactor > 1 && o.ReconcileBackoffMaxInterval <= o.ClusterReconcileInterval {
+		return errors.New("maximal reconcile interval of the backoff has to be greater than the cluster reconciliation interval")
+	}
+	if o.ReadOnly && (o.Migrate || o.StopAfterMigration) {
+		return errors.New("database migration is not possible in read-only mode")
+	}
 	if o.AuditLog {
 		if o.AuditLogFile == "" {
 			return errors.New("audit log file must be set if audit logging is enable")
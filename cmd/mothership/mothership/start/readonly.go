@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+)
+
+// readOnlyRoutes contains the routes which are served in read-only mode although they use modifying methods:
+// they neither write to the database nor schedule operations.
+var readOnlyRoutes = map[string][]string{
+	fmt.Sprintf("/v{%s}/simulations", paramContractVersion): {http.MethodPost},
+	fmt.Sprintf("/v{%s}/loglevel", paramContractVersion):    {http.MethodPut, http.MethodDelete},
+}
+
+func isReadOnlyRoute(path, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	for _, allowedMethod := range readOnlyRoutes[path] {
+		if allowedMethod == method {
+			return true
+		}
+	}
+	return false
+}
+
+// readOnlyMiddleware rejects all requests which would modify the state of the mothership. It's used by mothership
+// instances running as standby or for analytics, which are typically connected to a replica of the database.
+func readOnlyMiddleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, err := mux.CurrentRoute(r).GetPathTemplate()
+		if err != nil || !isReadOnlyRoute(path, r.Method) {
+			server.SendHTTPError(w, http.StatusServiceUnavailable, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("%s %s is not permitted: mothership is running in read-only mode",
+					r.Method, r.URL.Path),
+			})
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(readOnlyMiddleware)
+	var handled bool
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		handled = true
+	}
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters", paramContractVersion), handler).
+		Methods(http.MethodPost, http.MethodPut)
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID), handler).
+		Methods(http.MethodGet)
+	router.HandleFunc(fmt.Sprintf("/v{%s}/simulations", paramContractVersion), handler).
+		Methods(http.MethodPost)
+
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+	}{
+		{
+			name:         "Read requests are served",
+			method:       http.MethodGet,
+			path:         "/v1/clusters/runtime1/status",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Modifying requests are rejected",
+			method:       http.MethodPut,
+			path:         "/v1/clusters",
+			expectedCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "Modifying requests which don't write are served",
+			method:       http.MethodPost,
+			path:         "/v1/simulations",
+			expectedCode: http.StatusOK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			handled = false
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+
+			require.Equal(t, tc.expectedCode, w.Code)
+			require.Equal(t, tc.expectedCode == http.StatusOK, handled)
+		})
+	}
+}
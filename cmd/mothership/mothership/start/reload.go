@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// configReloader applies the reloadable settings of the configuration file to the running scheduler. Settings
+// which are removed from the file fall back to the values configured at startup.
+type configReloader struct {
+	o               *Options
+	file            string
+	workerConfig    *worker.Config
+	schedulerConfig *service.SchedulerConfig
+	defaults        *config.ReloadableConfig //values configured at startup
+	current         *config.ReloadableConfig
+}
+
+func newConfigReloader(o *Options, file string, workerConfig *worker.Config, schedulerConfig *service.SchedulerConfig) *configReloader {
+	return &configReloader{
+		o:               o,
+		file:            file,
+		workerConfig:    workerConfig,
+		schedulerConfig: schedulerConfig,
+		defaults: &config.ReloadableConfig{
+			LogLevel:               logger.Levels()[logger.GlobalScope],
+			ReconcileInterval:      o.ClusterReconcileInterval,
+			ReconcileJitter:        &o.ClusterReconcileJitter,
+			MaxOperationsPerMinute: &o.MaxOperationsPerMinute,
+			Workers:                o.Workers,
+			InvokerMaxRetries:      workerConfig.InvokerMaxRetries,
+			InvokerRetryDelay:      workerConfig.InvokerRetryDelay,
+		},
+	}
+}
+
+// Run applies the settings and re-applies them whenever the configuration file changes. Invalid settings are
+// rejected at startup but only logged afterwards: the previously applied settings stay active.
+func (r *configReloader) Run(ctx context.Context, cfg *config.ReloadableConfig, interval time.Duration) error {
+	if err := r.apply(cfg); err != nil {
+		return err
+	}
+	lastMod := fileModTime(r.file)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mod := fileModTime(r.file)
+				if mod.Equal(lastMod) {
+					continue
+				}
+				lastMod = mod
+				cfg, err := loadReloadableConfig(r.file)
+				if err == nil {
+					err = r.apply(cfg)
+				}
+				if err != nil {
+					r.o.Logger().Warnf("Failed to reload configuration file '%s' (keeping previous settings): %s",
+						r.file, err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *configReloader) apply(cfg *config.ReloadableConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return errors.Wrap(err, "invalid reloadable settings")
+	}
+	effective := r.withDefaults(cfg)
+
+	if err := r.schedulerConfig.Reload(effective.ReconcileInterval, effective.ReconcileJitter,
+		effective.MaxOperationsPerMinute); err != nil {
+		return err
+	}
+	if err := r.workerConfig.Reload(effective.Workers, effective.InvokerMaxRetries,
+		effective.InvokerRetryDelay); err != nil {
+		return err
+	}
+
+	previous := r.current
+	if previous == nil {
+		previous = &config.ReloadableConfig{}
+	}
+	//the log level is only touched if it was changed in the file: changes via the loglevel endpoint are kept otherwise
+	if cfg.LogLevel != previous.LogLevel {
+		if r.o.LogLevelFile != "" {
+			r.o.Logger().Warnf("Log level of configuration file is ignored: log levels are defined by file '%s'",
+				r.o.LogLevelFile)
+		} else if err := logger.SetLevel(logger.GlobalScope, effective.LogLevel); err != nil {
+			return err
+		}
+	}
+
+	initial := r.current == nil
+	r.current = cfg
+	if initial {
+		return nil
+	}
+	changes := cfg.Changes(previous)
+	if len(changes) == 0 {
+		return nil
+	}
+	r.o.Logger().Infof("Reloaded configuration file '%s': %s", r.file, strings.Join(changes, ", "))
+	if err := r.auditLog(changes); err != nil {
+		r.o.Logger().Errorf("Failed to write audit log entry for reloaded configuration file '%s': %s", r.file, err)
+	}
+	return nil
+}
+
+// withDefaults replaces undefined settings by the values configured at startup
+func (r *configReloader) withDefaults(cfg *config.ReloadableConfig) *config.ReloadableConfig {
+	effective := *cfg
+	if effective.LogLevel == "" {
+		effective.LogLevel = r.defaults.LogLevel
+	}
+	if effective.ReconcileInterval == 0 {
+		effective.ReconcileInterval = r.defaults.ReconcileInterval
+	}
+	if effective.ReconcileJitter == nil {
+		effective.ReconcileJitter = r.defaults.ReconcileJitter
+	}
+	if effective.MaxOperationsPerMinute == nil {
+		effective.MaxOperationsPerMinute = r.defaults.MaxOperationsPerMinute
+	}
+	if effective.Workers == 0 {
+		effective.Workers = r.defaults.Workers
+	}
+	if effective.InvokerMaxRetries == 0 {
+		effective.InvokerMaxRetries = r.defaults.InvokerMaxRetries
+	}
+	if effective.InvokerRetryDelay == 0 {
+		effective.InvokerRetryDelay = r.defaults.InvokerRetryDelay
+	}
+	return &effective
+}
+
+func (r *configReloader) auditLog(changes []string) error {
+	if !r.o.AuditLog || r.o.AuditLogFile == "" || r.o.AuditLogTenantID == "" {
+		return nil
+	}
+	auditLogger, err := auditLoggerFor(r.o.AuditLogFile)
+	if err != nil {
+		return err
+	}
+	return auditLogConfigReload(auditLogger, r.o, r.file, changes)
+}
+
+func loadReloadableConfig(file string) (*config.ReloadableConfig, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	cfg := &config.ReloadableConfig{}
+	return cfg, v.UnmarshalKey("mothership.reloadable", cfg)
+}
+
+func fileModTime(file string) time.Time {
+	fileInfo, err := os.Stat(file)
+	if err != nil {
+		return time.Time{}
+	}
+	return fileInfo.ModTime()
+}
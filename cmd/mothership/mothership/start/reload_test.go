@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigReloader(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "reconciler.yaml")
+	writeConfig := func(reloadable string) {
+		require.NoError(t, os.WriteFile(configFile, []byte("mothership:\n  reloadable:\n"+reloadable), 0600))
+	}
+
+	o := NewOptions(&cli.Options{})
+	o.LogLevelFile = "levels.txt" //log levels are not changed by the test
+	o.Workers = 50
+	o.ClusterReconcileInterval = 5 * time.Minute
+	o.ClusterReconcileJitter = 0.1
+	workerConfig := &worker.Config{PoolSize: o.Workers, InvokerMaxRetries: 2, InvokerRetryDelay: 10 * time.Second}
+	schedulerConfig := &service.SchedulerConfig{
+		ClusterReconcileInterval: o.ClusterReconcileInterval,
+		ClusterReconcileJitter:   o.ClusterReconcileJitter,
+	}
+	reloader := newConfigReloader(o, configFile, workerConfig, schedulerConfig)
+
+	t.Run("Apply reloadable settings", func(t *testing.T) {
+		writeConfig("    reconcileInterval: 10m\n    reconcileJitter: 0\n    workers: 20\n    invokerRetryDelay: 5s\n")
+		cfg, err := loadReloadableConfig(configFile)
+		require.NoError(t, err)
+		require.NoError(t, reloader.apply(cfg))
+
+		require.Equal(t, 10*time.Minute, schedulerConfig.ClusterReconcileInterval)
+		require.Equal(t, float64(0), schedulerConfig.ClusterReconcileJitter)
+		require.Equal(t, 20, workerConfig.PoolSize)
+		require.Equal(t, 2, workerConfig.InvokerMaxRetries)
+		require.Equal(t, 5*time.Second, workerConfig.InvokerRetryDelay)
+	})
+
+	t.Run("Removed settings fall back to startup values", func(t *testing.T) {
+		writeConfig("    workers: 30\n")
+		cfg, err := loadReloadableConfig(configFile)
+		require.NoError(t, err)
+		require.NoError(t, reloader.apply(cfg))
+
+		require.Equal(t, 5*time.Minute, schedulerConfig.ClusterReconcileInterval)
+		require.Equal(t, 0.1, schedulerConfig.ClusterReconcileJitter)
+		require.Equal(t, 30, workerConfig.PoolSize)
+		require.Equal(t, 10*time.Second, workerConfig.InvokerRetryDelay)
+	})
+
+	t.Run("Invalid settings are rejected", func(t *testing.T) {
+		writeConfig("    workers: -1\n")
+		cfg, err := loadReloadableConfig(configFile)
+		require.NoError(t, err)
+		require.Error(t, reloader.apply(cfg))
+		require.Equal(t, 30, workerConfig.PoolSize)
+	})
+}
@@ -6,40 +6,66 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/metrics"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
 	"github.com/spf13/viper"
 )
 
+const schedulerLogScope = "scheduler"
+
 func startScheduler(ctx context.Context, o *Options) error {
 
-	runtimeBuilder := service.NewRuntimeBuilder(o.Registry.ReconciliationRepository(), logger.NewLogger(o.Verbose))
+	runtimeBuilder := service.NewRuntimeBuilder(o.Registry.ReconciliationRepository(), logger.NewScopedLogger(schedulerLogScope, o.Verbose))
 	ds, err := service.NewDeleteStrategy(o.Config.Scheduler.DeleteStrategy)
 	if err != nil {
 		return err
 	}
 
+	schedulerMetrics := metrics.NewSchedulerCollector()
+	if err := metrics.RegisterScheduler(schedulerMetrics, o.Logger()); err != nil {
+		return err
+	}
+
+	workerConfig := &worker.Config{
+		MaxParallelOperations: o.MaxParallelOperations,
+		PoolSize:              o.Workers,
+		//check-interval should be greater than "max-retires * retry-delay" to avoid queuing
+		//of workers in case that component-reconciler isn't reachable
+		OperationCheckInterval:       30 * time.Second,
+		InvokerMaxRetries:            2,
+		InvokerRetryDelay:            10 * time.Second,
+		TenantMaxParallelOperations:  o.TenantMaxParallelOperations,
+		TenantMaxOperationsPerMinute: o.TenantMaxOperationsPerMinute,
+	}
+	schedulerConfig := &service.SchedulerConfig{
+		InventoryWatchInterval:      o.WatchInterval,
+		ClusterReconcileInterval:    o.ClusterReconcileInterval,
+		ClusterReconcileJitter:      o.ClusterReconcileJitter,
+		MaxOperationsPerMinute:      o.MaxOperationsPerMinute,
+		ReconcileBackoffFactor:      o.ReconcileBackoffFactor,
+		ReconcileBackoffMaxInterval: o.ReconcileBackoffMaxInterval,
+		ClusterQueueSize:            10,
+		DeleteStrategy:              ds,
+		PreComponents:               o.Config.Scheduler.PreComponents,
+		BootstrapComponents:         o.Config.Scheduler.BootstrapComponents,
+		ComponentCRDs:               o.Config.Scheduler.ComponentCRDs,
+	}
+	if configFile := viper.ConfigFileUsed(); configFile != "" && o.ConfigReloadInterval > 0 {
+		err := newConfigReloader(o, configFile, workerConfig, schedulerConfig).
+			Run(ctx, &o.Config.Reloadable, o.ConfigReloadInterval)
+		if err != nil {
+			return err
+		}
+		o.Logger().Infof("Watching configuration file '%s' for changes of the reloadable settings", configFile)
+	}
+
 	return runtimeBuilder.
 		RunRemote(o.Registry.Connection(), o.Registry.Inventory(), o.Registry.OccupancyRepository(), o.Config).
-		WithWorkerPoolConfig(&worker.Config{
-			MaxParallelOperations: o.MaxParallelOperations,
-			PoolSize:              o.Workers,
-			//check-interval should be greater than "max-retires * retry-delay" to avoid queuing
-			//of workers in case that component-reconciler isn't reachable
-			OperationCheckInterval: 30 * time.Second,
-			InvokerMaxRetries:      2,
-			InvokerRetryDelay:      10 * time.Second,
-		}).
-		WithSchedulerConfig(
-			&service.SchedulerConfig{
-				InventoryWatchInterval:   o.WatchInterval,
-				ClusterReconcileInterval: o.ClusterReconcileInterval,
-				ClusterQueueSize:         10,
-				DeleteStrategy:           ds,
-				PreComponents:            o.Config.Scheduler.PreComponents,
-				ComponentCRDs:            o.Config.Scheduler.ComponentCRDs,
-			}).
+		WithMetricsCollector(schedulerMetrics).
+		WithWorkerPoolConfig(workerConfig).
+		WithSchedulerConfig(schedulerConfig).
 		WithBookkeeperConfig(&service.BookkeeperConfig{
 			OperationsWatchInterval: o.BookkeeperWatchInterval,
 			OrphanOperationTimeout:  o.OrphanOperationTimeout,
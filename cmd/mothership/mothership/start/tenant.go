@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+type tenantContextKey struct{}
+
+// tenantRoutes contains the routes which are accessible with tokens scoped to a tenant (global account).
+// All other routes require a token which isn't scoped to a tenant.
+var tenantRoutes = map[string][]string{
+	fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID):                                   {http.MethodGet},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/configs/{%s}/status", paramContractVersion, paramRuntimeID, paramConfigVersion):  {http.MethodGet},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID):                            {http.MethodGet},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/changes", paramContractVersion, paramRuntimeID):                                  {http.MethodGet},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/badge", paramContractVersion, paramRuntimeID):                                    {http.MethodGet},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/badge/token", paramContractVersion, paramRuntimeID):                              {http.MethodPut, http.MethodDelete},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/config/{%s}", paramContractVersion, paramRuntimeID, paramConfigVersion):          {http.MethodGet},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/pins", paramContractVersion, paramRuntimeID):                                     {http.MethodGet},
+	fmt.Sprintf("/v{%s}/clusters/{%s}/featureflags", paramContractVersion, paramRuntimeID):                             {http.MethodGet},
+	fmt.Sprintf("/v{%s}/reconciliations", paramContractVersion):                                                        {http.MethodGet},
+	fmt.Sprintf("/v{%s}/reconciliations/{%s}/info", paramContractVersion, paramSchedulingID):                           {http.MethodGet},
+	fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/timeline", paramContractVersion, paramSchedulingID, paramCorrelationID):   {http.MethodGet},
+	fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/smoketests", paramContractVersion, paramSchedulingID, paramCorrelationID): {http.MethodGet},
+	fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/resources", paramContractVersion, paramSchedulingID, paramCorrelationID):  {http.MethodGet},
+	fmt.Sprintf("/v{%s}/schedules", paramContractVersion):                                                              {http.MethodGet, http.MethodPost},
+}
+
+func isTenantRoute(path, method string) bool {
+	for _, allowedMethod := range tenantRoutes[path] {
+		if allowedMethod == method {
+			return true
+		}
+	}
+	return false
+}
+
+// tenantAuthorizer isolates the tenants: a request with a token scoped to a tenant can only access the
+// clusters of this tenant. The tenant is read from a claim of the JWT payload which is passed by Istio.
+type tenantAuthorizer struct {
+	claim     string
+	inventory cluster.Inventory
+	reconRepo reconciliation.Repository
+}
+
+func newTenantAuthorizer(o *Options) *tenantAuthorizer {
+	return &tenantAuthorizer{
+		claim:     o.TenantClaim,
+		inventory: o.Registry.Inventory(),
+		reconRepo: o.Registry.ReconciliationRepository(),
+	}
+}
+
+func (a *tenantAuthorizer) middleware(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant, err := a.tenant(r)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+			return
+		}
+		if tenant == "" { //token isn't scoped to a tenant
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		path, err := mux.CurrentRoute(r).GetPathTemplate()
+		if err != nil || !isTenantRoute(path, r.Method) {
+			server.SendHTTPError(w, http.StatusForbidden, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("access to %s %s is not permitted for tenant '%s'", r.Method, r.URL.Path, tenant),
+			})
+			return
+		}
+
+		vars := mux.Vars(r)
+		runtimeID := vars[paramRuntimeID]
+		if schedulingID, ok := vars[paramSchedulingID]; ok {
+			reconEntity, err := a.reconRepo.GetReconciliation(schedulingID)
+			if err != nil {
+				server.SendHTTPErrorMap(w, err)
+				return
+			}
+			runtimeID = reconEntity.RuntimeID
+		}
+		if runtimeID != "" && !a.authorized(w, tenant, runtimeID) {
+			return
+		}
+
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantContextKey{}, tenant)))
+	})
+}
+
+// tenant returns the tenant of the token or an empty string if the token isn't scoped to a tenant
+func (a *tenantAuthorizer) tenant(r *http.Request) (string, error) {
+	payload, err := getJWTPayload(r)
+	if err != nil || payload == "" {
+		return "", err
+	}
+	claims := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(payload), &claims); err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to parse %s header content", XJWTHeaderName))
+	}
+	switch tenant := claims[a.claim].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return tenant, nil
+	default:
+		return "", fmt.Errorf("claim '%s' of the token is not a string", a.claim)
+	}
+}
+
+// authorized verifies that the cluster belongs to the tenant. Otherwise, a not-found error is sent to
+// avoid revealing the existence of clusters of other tenants.
+func (a *tenantAuthorizer) authorized(w http.ResponseWriter, tenant, runtimeID string) bool {
+	state, err := a.inventory.GetLatest(runtimeID)
+	if err != nil && !repository.IsNotFoundError(err) {
+		server.SendHTTPErrorMap(w, err)
+		return false
+	}
+	if err != nil || state.Cluster.GlobalAccountID != tenant {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("cluster '%s' not found", runtimeID),
+		})
+		return false
+	}
+	return true
+}
+
+// tenantOf returns the tenant the request is scoped to or an empty string if the request isn't scoped
+func tenantOf(r *http.Request) string {
+	tenant, _ := r.Context().Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+func intersect(runtimeIDs, tenantRuntimeIDs []string) []string {
+	var result []string
+	for _, runtimeID := range runtimeIDs {
+		if contains(tenantRuntimeIDs, runtimeID) {
+			result = append(result, runtimeID)
+		}
+	}
+	return result
+}
+
+func contains(runtimeIDs []string, runtimeID string) bool {
+	for _, candidate := range runtimeIDs {
+		if candidate == runtimeID {
+			return true
+		}
+	}
+	return false
+}
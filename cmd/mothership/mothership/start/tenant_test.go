@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantAuthorizer(t *testing.T) {
+	inventory := &cluster.MockInventory{
+		GetLatestResult: &cluster.State{
+			Cluster: &model.ClusterEntity{RuntimeID: "runtime1", GlobalAccountID: "tenant1"},
+		},
+	}
+	authorizer := &tenantAuthorizer{claim: "globalAccountID", inventory: inventory}
+
+	router := mux.NewRouter()
+	router.Use(authorizer.middleware)
+	var handledTenant string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		handledTenant = tenantOf(r)
+	}
+	router.HandleFunc(fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID), handler).
+		Methods(http.MethodGet, http.MethodPut)
+	router.HandleFunc(fmt.Sprintf("/v{%s}/intervals", paramContractVersion), handler).
+		Methods(http.MethodGet)
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		jwtPayload     string
+		expectedCode   int
+		expectedTenant string
+	}{
+		{
+			name:         "Unscoped token can access all routes",
+			method:       http.MethodGet,
+			path:         "/v1/intervals",
+			jwtPayload:   `{"sub":"operator"}`,
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Request without token is not scoped",
+			method:       http.MethodPut,
+			path:         "/v1/clusters/runtime1/status",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:           "Scoped token can access clusters of its tenant",
+			method:         http.MethodGet,
+			path:           "/v1/clusters/runtime1/status",
+			jwtPayload:     `{"sub":"customer","globalAccountID":"tenant1"}`,
+			expectedCode:   http.StatusOK,
+			expectedTenant: "tenant1",
+		},
+		{
+			name:         "Scoped token cannot access clusters of other tenants",
+			method:       http.MethodGet,
+			path:         "/v1/clusters/runtime1/status",
+			jwtPayload:   `{"sub":"customer","globalAccountID":"tenant2"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "Scoped token cannot access routes which are not permitted for tenants",
+			method:       http.MethodPut,
+			path:         "/v1/clusters/runtime1/status",
+			jwtPayload:   `{"sub":"customer","globalAccountID":"tenant1"}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Scoped token cannot access global routes",
+			method:       http.MethodGet,
+			path:         "/v1/intervals",
+			jwtPayload:   `{"sub":"customer","globalAccountID":"tenant1"}`,
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "Invalid tenant claim is rejected",
+			method:       http.MethodGet,
+			path:         "/v1/clusters/runtime1/status",
+			jwtPayload:   `{"sub":"customer","globalAccountID":123}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			handledTenant = ""
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.jwtPayload != "" {
+				req.Header.Add(XJWTHeaderName, base64.RawURLEncoding.EncodeToString([]byte(tc.jwtPayload)))
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.expectedCode, w.Code)
+			require.Equal(t, tc.expectedTenant, handledTenant)
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	require.Equal(t, []string{"b"}, intersect([]string{"a", "b"}, []string{"b", "c"}))
+	require.Empty(t, intersect([]string{"a"}, []string{"b"}))
+	require.Empty(t, intersect(nil, []string{"b"}))
+}
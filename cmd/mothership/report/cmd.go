@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	fleetCmd "github.com/kyma-incubator/reconciler/cmd/mothership/report/fleet"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Create reports about the reconciliations of the mothership",
+		Long:  "Administrative CLI tool to create reports using the API of a running mothership",
+	}
+
+	cmd.AddCommand(fleetCmd.NewCmd(fleetCmd.NewOptions(o)))
+
+	return cmd
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/report"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Show fleet-wide KPIs of the reconciliations.",
+		Long: `Show the success rate, the mean time to reconcile and the components with most failures
+of all reconciliations created within a time window. The window covers the duration defined by --last
+and ends now, unless --after or --before define its boundaries explicitly.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return Run(cli.NewContext(), o, os.Stdout)
+		},
+	}
+	cmd.Flags().StringVar(&o.MothershipURL, "mothership-url", "http://localhost:8080", "URL of the mothership API")
+	cmd.Flags().StringVar(&o.Token, "token", "", "Bearer token used to authenticate at the mothership")
+	cmd.Flags().DurationVar(&o.Last, "last", o.Last, "Duration of the time window covered by the report")
+	cmd.Flags().StringVar(&o.After, "after", "", "Begin of the time window (RFC3339)")
+	cmd.Flags().StringVar(&o.Before, "before", "", "End of the time window (RFC3339)")
+	cmd.Flags().IntVar(&o.Top, "top", o.Top, "Maximal amount of components listed in the failure top-list")
+	cmd.Flags().StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(supportedOutputFormats, "', '")))
+	return cmd
+}
+
+func Run(ctx context.Context, o *Options, out io.Writer) error {
+	after, before, err := o.window(time.Now())
+	if err != nil {
+		return err
+	}
+
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	fleetReport, err := mothership.GetFleetReport(ctx, after, before, o.Top)
+	if err != nil {
+		return err
+	}
+
+	switch o.OutputFormat {
+	case "csv":
+		return report.WriteCSV(out, fleetReport)
+	case "json":
+		return json.NewEncoder(out).Encode(fleetReport)
+	case "json_pretty":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(fleetReport)
+	default:
+		return renderTables(fleetReport, out)
+	}
+}
+
+func renderTables(fleetReport *keb.HTTPFleetReport, out io.Writer) error {
+	kpis, err := cli.NewOutputFormatter("table")
+	if err != nil {
+		return err
+	}
+	if err := kpis.Header("From", "To", "Reconciliations", "Succeeded", "Failed", "In progress",
+		"Success rate", "Mean time to reconcile"); err != nil {
+		return err
+	}
+	if err := kpis.AddRow(fleetReport.From.Format(time.RFC3339), fleetReport.To.Format(time.RFC3339),
+		fleetReport.Reconciliations, fleetReport.Succeeded, fleetReport.Failed, fleetReport.InProgress,
+		fmt.Sprintf("%.2f%%", fleetReport.SuccessRate),
+		(time.Duration(fleetReport.MeanTimeToReconcile) * time.Millisecond).String()); err != nil {
+		return err
+	}
+	if err := kpis.Output(out); err != nil {
+		return err
+	}
+
+	if len(fleetReport.TopFailures) == 0 {
+		return nil
+	}
+	_, _ = fmt.Fprintln(out)
+	failures, err := cli.NewOutputFormatter("table")
+	if err != nil {
+		return err
+	}
+	if err := failures.Header("Component", "Failures", "Clusters", "Latest reason"); err != nil {
+		return err
+	}
+	for _, failure := range fleetReport.TopFailures {
+		if err := failures.AddRow(failure.Component, failure.Failures, failure.Clusters, failure.LatestReason); err != nil {
+			return err
+		}
+	}
+	return failures.Output(out)
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFleetCmd(t *testing.T) {
+	after := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := after.Add(24 * time.Hour)
+
+	newServer := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/reports/fleet", r.URL.Path)
+			require.Equal(t, after.Format(time.RFC3339), r.URL.Query().Get("after"))
+			require.Equal(t, before.Format(time.RFC3339), r.URL.Query().Get("before"))
+			require.Equal(t, "5", r.URL.Query().Get("top"))
+			require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPFleetReport{
+				From:                after,
+				To:                  before,
+				Reconciliations:     3,
+				Succeeded:           2,
+				Failed:              1,
+				SuccessRate:         66.67,
+				MeanTimeToReconcile: 90000,
+				TopFailures: []keb.ComponentFailures{
+					{Component: "istio", Failures: 2, Clusters: 1, LatestReason: "timeout"},
+				},
+			}))
+		}))
+	}
+	newOptions := func(url, format string) *Options {
+		o := NewOptions(&cli.Options{OutputFormat: format})
+		o.MothershipURL = url
+		o.Before = before.Format(time.RFC3339)
+		o.Top = 5
+		return o
+	}
+
+	t.Run("Show report as table", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+
+		out := &bytes.Buffer{}
+		require.NoError(t, Run(context.Background(), newOptions(srv.URL, "table"), out))
+		require.Contains(t, out.String(), "66.67%")
+		require.Contains(t, out.String(), "1m30s")
+		require.Contains(t, out.String(), "istio")
+	})
+
+	t.Run("Export report as CSV", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+
+		out := &bytes.Buffer{}
+		require.NoError(t, Run(context.Background(), newOptions(srv.URL, "csv"), out))
+		require.Contains(t, out.String(), "successRate,66.67\n")
+		require.Contains(t, out.String(), "istio,2,1,timeout\n")
+	})
+
+	t.Run("Export report as JSON", func(t *testing.T) {
+		srv := newServer()
+		defer srv.Close()
+
+		out := &bytes.Buffer{}
+		require.NoError(t, Run(context.Background(), newOptions(srv.URL, "json"), out))
+		result := &keb.HTTPFleetReport{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), result))
+		require.Equal(t, 3, result.Reconciliations)
+	})
+
+	t.Run("Reject invalid time window", func(t *testing.T) {
+		o := newOptions("http://localhost", "table")
+		o.After = before.Add(time.Hour).Format(time.RFC3339)
+		err := Run(context.Background(), o, &bytes.Buffer{})
+		require.Error(t, err)
+	})
+
+	t.Run("Reject unsupported output format", func(t *testing.T) {
+		require.Error(t, newOptions("http://localhost", "yaml").Validate())
+	})
+}
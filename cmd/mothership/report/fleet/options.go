@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/kyma-incubator/reconciler/pkg/report"
+)
+
+var supportedOutputFormats = []string{"table", "json", "json_pretty", "csv"}
+
+type Options struct {
+	*cli.Options
+	MothershipURL string
+	Token         string
+	Last          time.Duration
+	After         string
+	Before        string
+	Top           int
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, "", "", 24 * time.Hour, "", "", report.DefaultTopFailures}
+}
+
+func (o *Options) Validate() error {
+	if o.MothershipURL == "" {
+		return fmt.Errorf("mothership URL is undefined")
+	}
+	if o.Last <= 0 {
+		return fmt.Errorf("time window has to be > 0 but was %v", o.Last)
+	}
+	if o.Top < 0 {
+		return fmt.Errorf("size of failure top-list cannot be < 0 but was %d", o.Top)
+	}
+	for _, format := range supportedOutputFormats {
+		if format == o.OutputFormat {
+			return nil
+		}
+	}
+	return fmt.Errorf("output format '%s' not supported: choose one of '%s'",
+		o.OutputFormat, strings.Join(supportedOutputFormats, "', '"))
+}
+
+// window returns the begin and end of the report: explicitly defined boundaries win over the --last duration
+func (o *Options) window(now time.Time) (time.Time, time.Time, error) {
+	before := now
+	if o.Before != "" {
+		var err error
+		if before, err = time.Parse(time.RFC3339, o.Before); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("failed to parse end of time window: %w", err)
+		}
+	}
+	after := before.Add(-o.Last)
+	if o.After != "" {
+		var err error
+		if after, err = time.Parse(time.RFC3339, o.After); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("failed to parse begin of time window: %w", err)
+		}
+	}
+	if !after.Before(before) {
+		return time.Time{}, time.Time{}, fmt.Errorf("begin of time window (%s) has to be before its end (%s)",
+			after.Format(time.RFC3339), before.Format(time.RFC3339))
+	}
+	return after, before, nil
+}
+
+func (o *Options) client() (*client.MothershipClient, error) {
+	var opts []client.Option
+	if o.HTTPClient != nil {
+		opts = append(opts, client.WithHTTPClient(httpclient.New(o.HTTPClient)))
+	}
+	if o.Token != "" {
+		opts = append(opts, client.WithBearerToken(o.Token))
+	}
+	return client.NewMothershipClient(o.MothershipURL, opts...)
+}
@@ -4,12 +4,16 @@ import (
 	"os"
 	"time"
 
+	operationCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/operation"
+	replayCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/operation/replay"
 	startCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/start"
 	startSvcCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/start/service"
 	testCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/test"
+	callbackMockCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/test/callbackmock"
 	testSvcCmd "github.com/kyma-incubator/reconciler/cmd/reconciler/test/service"
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/sandbox"
 	reconcilerRegistry "github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 	"github.com/spf13/cobra"
 
@@ -35,12 +39,36 @@ func newCmd(o *cli.Options) *cobra.Command {
 	}
 
 	reconcilerOpts := reconciler.NewOptions(o) //decorate options with reconciler-specific options
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if len(reconcilerOpts.Components) == 0 {
+			return nil
+		}
+		return reconcilerRegistry.RestrictReconcilers(reconcilerOpts.Components)
+	}
+
+	//component reconcilers included in this deployment
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.Components, "components", []string{},
+		"Component reconcilers which can be started by this binary (e.g. 'base,rma', default: all compiled-in reconcilers)")
 
 	//worker pool configuration
 	cmd.PersistentFlags().IntVar(&reconcilerOpts.WorkerConfig.Workers, "worker-count", 50,
 		"Number of in parallel running reconciliation workers")
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.WorkerConfig.Timeout, "worker-timeout", defaultTimeout,
 		"Maximal time a worker will run before a reconciliation will be stopped")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.WorkerConfig.StuckFactor, "worker-stuck-factor", 2,
+		"Workers running longer than worker-timeout × factor are reported as stuck and their capacity is recycled (0 = disabled)")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.WorkerConfig.MemoryMiB, "worker-memory-budget", 0,
+		"Approximate memory in MiB which running reconciliations may use: new reconciliations are delayed until enough memory is released (0 = unlimited)")
+
+	//queue configuration
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.QueueConfig.DBConfigFile, "queue-db-config", "",
+		"Path to a config file defining the database or Redis server used to queue tasks: replicas of the component reconciler share the queue and queued tasks survive restarts (empty = tasks are directly assigned to workers)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.QueueConfig.PollInterval, "queue-poll-interval", 2*time.Second,
+		"Interval to check the queue for tasks if workers are free")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.QueueConfig.LeaseDuration, "queue-lease-duration", 1*time.Minute,
+		"Time a replica holds a queued task without renewing its lease: tasks of stopped replicas are picked up after the lease expired")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.QueueConfig.MaxAttempts, "queue-max-attempts", 3,
+		"Queued tasks which were started more often without being completed are dropped (0 = unlimited)")
 
 	//REST API configuration
 	cmd.PersistentFlags().IntVar(&reconcilerOpts.ServerConfig.Port, "server-port", 8080,
@@ -49,12 +77,18 @@ func newCmd(o *cli.Options) *cobra.Command {
 		"Path to SSL certificate file used for secure REST API communication")
 	cmd.PersistentFlags().StringVar(&reconcilerOpts.ServerConfig.SSLKeyFile, "server-key", "",
 		"Path to SSL key file used for secure REST API communication")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ServerConfig.IdempotencyRetention, "server-idempotency-retention", 10*time.Minute,
+		"Time the response of a run request with Idempotency-Key header is replayed for duplicates of the request reaching the same replica (0 = duplicates are processed again)")
 
 	//retry configuration
 	cmd.PersistentFlags().IntVar(&reconcilerOpts.RetryConfig.MaxRetries, "retries-max", 5,
 		"Number of retries until the reconciler will report a reconciliation as consistently failing")
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.RetryConfig.RetryDelay, "retries-delay", 30*time.Second,
-		"Delay between each reconciliation retry")
+		"Initial delay between reconciliation retries (grows exponentially with each retry, transient errors are retried faster)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.RetryConfig.MaxRetryDelay, "retries-max-delay", 5*time.Minute,
+		"Upper limit of the delay between reconciliation retries")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.RetryConfig.RetryBudget, "retries-budget", 0,
+		"Overall time the retries of a reconciliation are allowed to take (0 = limited only by the worker timeout)")
 
 	//heartbeat-sender configuration
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.HeartbeatSenderConfig.Interval, "status-interval", 30*time.Second,
@@ -65,11 +99,61 @@ func newCmd(o *cli.Options) *cobra.Command {
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ProgressTrackerConfig.Interval, "progress-interval", 15*time.Second,
 		"Interval to verify the installation progress of a deployed Kubernetes resource")
 	reconcilerOpts.ProgressTrackerConfig.Timeout = reconcilerOpts.WorkerConfig.Timeout //coupled to reconcile-timeout
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.ReadyThreshold, "progress-ready-threshold", "",
+		"Replicas of Deployments and DaemonSets which have to be ready, e.g. '90%', 'maxUnavailable' or '90%,maxUnavailable' "+
+			"(components can override it with the configuration key '"+reconcilerRegistry.ReadyThresholdKey+"')")
+	cmd.PersistentFlags().IntSliceVar(&reconcilerOpts.StallWarnings, "progress-stall-warnings", []int{50, 80},
+		"Percentages of the reconcile-timeout after which resources which are not ready yet are reported to the mothership "+
+			"(empty = no warnings)")
+	cmd.PersistentFlags().BoolVar(&reconcilerOpts.TrackAllResources, "progress-track-all", false,
+		"Await the readiness of all resources of a component and not only of the ones which were created or updated")
+	cmd.PersistentFlags().BoolVar(&reconcilerOpts.InformerCache, "progress-informer-cache", false,
+		"Read Deployments, DaemonSets, StatefulSets, Pods and Jobs of a cluster from informers which are shared by "+
+			"all operations running on the cluster instead of polling the API server")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.StreamedComponents, "stream-components", nil,
+		"Components whose manifests are applied in batches of resources if they exceed the stream threshold: "+
+			"interceptors see only the resources of one batch")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.StreamThresholdMiB, "stream-threshold", 8,
+		"Size in MiB of the manifests which are applied in batches by the components listed in stream-components")
+
+	//kube client configuration
+	cmd.PersistentFlags().Float64Var(&reconcilerOpts.KubeClientConfig.QPS, "kube-client-qps", 0,
+		"Maximal requests per second sent to a cluster (0 = client-go default)")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.KubeClientConfig.Burst, "kube-client-burst", 0,
+		"Maximal burst of requests sent to a cluster (0 = client-go default)")
+	cmd.PersistentFlags().BoolVar(&reconcilerOpts.KubeClientConfig.Adaptive, "kube-client-adaptive-throttling", false,
+		"Reduce the request rate temporarily when the API server of a cluster answers with '429 Too Many Requests'")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.KubeClientConfig.SizeClasses, "kube-client-size-classes", nil,
+		"Limits of clusters with many components in the format '<min components>:<qps>/<burst>', e.g. '30:50/100'")
+
+	//sandbox configuration for external processes started by actions
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.SandboxConfig.Dir, "sandbox-dir", "",
+		"Directory in which the working directories of external processes are created (empty = temp directory)")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.SandboxConfig.EnvAllowlist, "sandbox-env-allowlist", sandbox.DefaultEnvAllowlist,
+		"Env vars of the reconciler which are passed to external processes")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.SandboxConfig.Timeout, "sandbox-timeout", 5*time.Minute,
+		"Maximal runtime of an external process if the action doesn't define a timeout")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.SandboxConfig.OutputLimit, "sandbox-output-limit", 1024*1024,
+		"Maximal bytes of stdout and of stderr which are captured per external process")
+
+	//health check configuration
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.HealthConfig.Timeout, "health-timeout", 5*time.Second,
+		"Maximal time the dependency checks of the health endpoints are allowed to take")
+	cmd.PersistentFlags().StringSliceVar(&reconcilerOpts.HealthConfig.DisabledChecks, "health-disabled-checks", []string{},
+		"Health checks which are excluded from the health endpoints (supported: workerpool, workspace, mothership)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.HealthConfig.MothershipURL, "health-mothership-url", "",
+		"Health endpoint of the mothership reconciler (e.g. http://mothership:8080/healthz) used to verify its reachability")
 
 	//file cache for Kyma sources
 	cmd.PersistentFlags().StringVar(&reconcilerOpts.Workspace, "workspace", ".",
 		"Workspace directory used to cache Kyma sources")
 
+	reconcilerOpts.AddHTTPClientFlags(cmd.PersistentFlags())
+	reconcilerOpts.AddHTTPServerFlags(cmd.PersistentFlags())
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.DiagnosticsPort, "diagnostics-port", 0,
+		"Port exposing pprof and expvar diagnostics endpoints (0 = disabled)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.LogLevelFile, "log-level-file", "",
+		"Path to a file defining log levels ('<level>' or '<component>=<level>' per line) which is watched for changes at runtime")
 	cmd.PersistentFlags().BoolVarP(&reconcilerOpts.Verbose, "verbose", "v", false, "Show detailed information about the executed command actions")
 	cmd.PersistentFlags().BoolVar(&reconcilerOpts.NonInteractive, "non-interactive", false, "Enables the non-interactive shell mode")
 
@@ -82,10 +166,15 @@ func newCmd(o *cli.Options) *cobra.Command {
 
 	testCommand := testCmd.NewCmd()
 	cmd.AddCommand(testCommand)
+	testCommand.AddCommand(callbackMockCmd.NewCmd(callbackMockCmd.NewOptions(reconcilerOpts)))
 	//register component reconcilers in start command:
 	for _, reconcilerName := range reconcilerRegistry.RegisteredReconcilers() {
 		testCommand.AddCommand(testSvcCmd.NewCmd(testSvcCmd.NewOptions(reconcilerOpts), reconcilerName))
 	}
 
+	operationCommand := operationCmd.NewCmd()
+	cmd.AddCommand(operationCommand)
+	operationCommand.AddCommand(replayCmd.NewCmd(replayCmd.NewOptions(reconcilerOpts)))
+
 	return cmd
 }
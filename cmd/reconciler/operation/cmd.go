@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "operation",
+		Short: "Inspect operations processed by component reconcilers",
+		Long:  "CLI tool to inspect and reproduce operations which were processed by component reconcilers",
+	}
+
+	return cmd
+}
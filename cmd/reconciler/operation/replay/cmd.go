@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/test/callbackmock"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <schedulingID>/<correlationID>",
+		Short: "Replay an operation with its stored payload",
+		Long: "Re-submit the payload which the mothership sent to a component reconciler for a past operation to " +
+			"reproduce its outcome. The callbacks of the replay are received by a local callback receiver and don't " +
+			"change the state of the original operation.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			if err := o.parseOperationID(args[0]); err != nil {
+				return err
+			}
+			return RunReplay(cli.NewContext(), o, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.MothershipURL, "mothership-url", "http://localhost:8080", "URL of the mothership API")
+	cmd.Flags().StringVar(&o.Token, "token", "", "Bearer token used to authenticate at the mothership")
+	cmd.Flags().StringVar(&o.ReconcilerURL, "reconciler-url", "",
+		"Base URL of the component reconciler which receives the replay, e.g. http://localhost:8080 (empty = reconciler which processed the operation)")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false,
+		"Render the manifests without applying them (the rendered manifest is printed)")
+	cmd.Flags().IntVar(&o.CallbackPort, "callback-port", 11111, "Port of the local callback receiver")
+	cmd.Flags().StringVar(&o.CallbackURL, "callback-url", "",
+		"Base URL under which the component reconciler reaches the local callback receiver (empty = http://localhost:<callback-port>)")
+	cmd.Flags().DurationVar(&o.Timeout, "timeout", 10*time.Minute,
+		"Maximal time to wait for the final callback of the replay (0 = don't wait)")
+
+	return cmd
+}
+
+func RunReplay(ctx context.Context, o *Options, out io.Writer) error {
+	mothership, err := o.client()
+	if err != nil {
+		return err
+	}
+	stored, err := mothership.GetOperationPayload(ctx, o.schedulingID, o.correlationID)
+	if err != nil {
+		return err
+	}
+
+	contractVersion := stored.ContractVersion
+	if o.DryRun {
+		contractVersion = 2 //dry-runs are only supported by the v2 contract
+	}
+	runURL, err := replayURL(stored, o.ReconcilerURL, contractVersion)
+	if err != nil {
+		return err
+	}
+	payload, err := replayPayload(stored, callbackmock.URL(o.callbackBaseURL(), o.correlationID), o.DryRun)
+	if err != nil {
+		return err
+	}
+
+	//the receiver has to listen before the payload is submitted as the component reconciler starts immediately
+	var receiver *callbackmock.Server
+	if o.Timeout > 0 {
+		if receiver, err = callbackmock.NewServer(callbackmock.Config{}, o.Logger()); err != nil {
+			return err
+		}
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", o.CallbackPort))
+		if err != nil {
+			return fmt.Errorf("failed to start callback receiver: %w", err)
+		}
+		srv := &http.Server{Handler: receiver.Router(), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+				o.Logger().Warnf("Callback receiver stopped: %s", err)
+			}
+		}()
+		defer func() {
+			if err := srv.Close(); err != nil {
+				o.Logger().Warnf("Failed to stop callback receiver: %s", err)
+			}
+		}()
+	}
+
+	if err := submit(o, runURL, payload); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Replayed operation %s/%s (component reconciler: %s, dry-run: %t)\n",
+		o.schedulingID, o.correlationID, runURL, o.DryRun)
+
+	if receiver == nil {
+		return nil
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	final, err := receiver.WaitForFinalCallback(waitCtx, o.correlationID)
+	for _, msg := range receiver.Callbacks(o.correlationID) {
+		renderCallback(out, msg)
+	}
+	if err != nil {
+		return err
+	}
+	if final.Manifest != nil {
+		fmt.Fprintf(out, "Rendered manifest:\n%s\n", *final.Manifest)
+	}
+	return nil
+}
+
+// replayURL returns the run endpoint of the contract version. The base URL of the component reconciler can be
+// overridden, otherwise the endpoint which received the stored payload is used.
+func replayURL(stored *keb.HTTPOperationPayload, reconcilerURL string, contractVersion int) (string, error) {
+	if reconcilerURL != "" {
+		return fmt.Sprintf("%s/v%d/run", strings.TrimSuffix(reconcilerURL, "/"), contractVersion), nil
+	}
+	if contractVersion == stored.ContractVersion {
+		return stored.Url, nil
+	}
+	storedEndpoint := fmt.Sprintf("/v%d/run", stored.ContractVersion)
+	if !strings.HasSuffix(stored.Url, storedEndpoint) {
+		return "", fmt.Errorf("cannot derive the contract version v%d endpoint from URL '%s': "+
+			"define the component reconciler with --reconciler-url", contractVersion, stored.Url)
+	}
+	return fmt.Sprintf("%s/v%d/run", strings.TrimSuffix(stored.Url, storedEndpoint), contractVersion), nil
+}
+
+// replayPayload returns the stored payload with the callback URL of the replay. A dry-run converts the payload to
+// the v2 contract if required. All other fields are kept unchanged.
+func replayPayload(stored *keb.HTTPOperationPayload, callbackURL string, dryRun bool) ([]byte, error) {
+	decoder := json.NewDecoder(strings.NewReader(stored.Payload))
+	decoder.UseNumber() //keep numbers of the configuration as they are
+	var payload map[string]interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("stored payload cannot be parsed: %w", err)
+	}
+	payload["callbackURL"] = callbackURL
+
+	if dryRun {
+		if stored.ContractVersion < 2 {
+			//configuration keys in dot-notation are accepted as values by the v2 contract
+			payload["values"] = payload["configuration"]
+			delete(payload, "configuration")
+		}
+		options, ok := payload["options"].(map[string]interface{})
+		if !ok {
+			options = make(map[string]interface{})
+		}
+		options["dryRun"] = true
+		payload["options"] = options
+	}
+	return json.Marshal(payload)
+}
+
+func submit(o *Options, runURL string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, runURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(server.HeaderIdempotencyKey, uuid.NewString())
+	resp, err := o.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to submit payload to component reconciler '%s': %w", runURL, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			o.Logger().Warnf("Failed to close response body: %s", err)
+		}
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("component reconciler '%s' rejected the payload (HTTP code: %d): %s",
+			runURL, resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+func renderCallback(out io.Writer, msg *reconciler.CallbackMessage) {
+	line := fmt.Sprintf("Callback: %s", msg.Status)
+	if msg.Error != "" {
+		line = fmt.Sprintf("%s (%s)", line, msg.Error)
+	}
+	fmt.Fprintln(out, line)
+	if msg.Warnings != nil {
+		for _, warning := range *msg.Warnings {
+			fmt.Fprintf(out, "  Warning: %s\n", warning)
+		}
+	}
+}
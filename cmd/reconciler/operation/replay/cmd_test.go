@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	schedulingID  = "scheduling1"
+	correlationID = "correlation1"
+)
+
+func TestReplayCmd(t *testing.T) {
+	received := make(chan map[string]interface{}, 1)
+	componentReconciler := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := make(map[string]interface{})
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payload["path"] = r.URL.Path
+		received <- payload
+
+		//report the outcome like a component reconciler (if the callback receiver of the test is used)
+		callbackURL := payload["callbackURL"].(string)
+		go func() {
+			if !strings.HasPrefix(callbackURL, "http://localhost") {
+				return
+			}
+			manifest := "kind: ConfigMap"
+			msg, err := json.Marshal(&reconciler.CallbackMessage{Status: reconciler.StatusSuccess, Manifest: &manifest})
+			require.NoError(t, err)
+			resp, err := http.Post(callbackURL, "application/json", bytes.NewReader(msg))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+		}()
+		w.WriteHeader(http.StatusOK)
+		require.NoError(t, json.NewEncoder(w).Encode(&reconciler.HTTPReconciliationResponse{}))
+	}))
+	defer componentReconciler.Close()
+
+	mothership := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/operations/"+schedulingID+"/"+correlationID+"/payload" {
+			w.WriteHeader(http.StatusNotFound)
+			require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPErrorResponse{Error: "not found"}))
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(&keb.HTTPOperationPayload{
+			SchedulingID:    schedulingID,
+			CorrelationID:   correlationID,
+			Url:             componentReconciler.URL + "/v1/run",
+			ContractVersion: 1,
+			Payload: `{"component":"istio","correlationID":"` + correlationID + `",` +
+				`"callbackURL":"https://mothership/v1/operations/x/callback/y","configuration":{"a.b":12345678901234567890}}`,
+			Created: time.Now().UTC(),
+		}))
+	}))
+	defer mothership.Close()
+
+	newOptions := func(operationID string) *Options {
+		o := NewOptions(reconCli.NewOptions(&cli.Options{}))
+		o.MothershipURL = mothership.URL
+		o.CallbackPort = freePort(t)
+		o.Timeout = 30 * time.Second
+		require.NoError(t, o.parseOperationID(operationID))
+		return o
+	}
+
+	t.Run("Replay operation to the original component reconciler", func(t *testing.T) {
+		o := newOptions(schedulingID + "/" + correlationID)
+		out := &bytes.Buffer{}
+		require.NoError(t, RunReplay(context.Background(), o, out))
+
+		payload := <-received
+		require.Equal(t, "/v1/run", payload["path"])
+		require.Equal(t, "istio", payload["component"])
+		require.Equal(t, map[string]interface{}{"a.b": 12345678901234567890.0}, payload["configuration"])
+		require.False(t, strings.HasPrefix(payload["callbackURL"].(string), "https://mothership"),
+			"callbacks of the replay must not reach the mothership")
+		require.Contains(t, out.String(), "Callback: success")
+	})
+
+	t.Run("Replay operation as dry-run", func(t *testing.T) {
+		o := newOptions(schedulingID + "/" + correlationID)
+		o.DryRun = true
+		out := &bytes.Buffer{}
+		require.NoError(t, RunReplay(context.Background(), o, out))
+
+		payload := <-received
+		require.Equal(t, "/v2/run", payload["path"])
+		require.Nil(t, payload["configuration"])
+		require.NotNil(t, payload["values"])
+		require.Equal(t, true, payload["options"].(map[string]interface{})["dryRun"])
+		require.Contains(t, out.String(), "kind: ConfigMap")
+	})
+
+	t.Run("Replay operation to another component reconciler without awaiting callbacks", func(t *testing.T) {
+		o := newOptions(schedulingID + "/" + correlationID)
+		o.ReconcilerURL = componentReconciler.URL + "/"
+		o.CallbackURL = "http://debug-receiver:8080"
+		o.Timeout = 0
+		out := &bytes.Buffer{}
+		require.NoError(t, RunReplay(context.Background(), o, out))
+
+		payload := <-received
+		require.Equal(t, "/v1/run", payload["path"])
+		require.Equal(t, "http://debug-receiver:8080/callback/"+correlationID, payload["callbackURL"])
+	})
+
+	t.Run("Replay unknown operation", func(t *testing.T) {
+		o := newOptions(schedulingID + "/unknown")
+		require.Error(t, RunReplay(context.Background(), o, &bytes.Buffer{}))
+	})
+
+	t.Run("Invalid operation ID", func(t *testing.T) {
+		o := NewOptions(reconCli.NewOptions(&cli.Options{}))
+		require.Error(t, o.parseOperationID(correlationID))
+		require.Error(t, o.parseOperationID(schedulingID+"/"))
+	})
+}
+
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, listener.Close())
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/client"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+)
+
+type Options struct {
+	*reconCli.Options
+	MothershipURL string
+	Token         string
+	ReconcilerURL string //base URL of the component reconciler receiving the replay (empty = original reconciler)
+	DryRun        bool
+	CallbackPort  int
+	CallbackURL   string //base URL of the callback receiver reachable by the component reconciler
+	Timeout       time.Duration
+	schedulingID  string
+	correlationID string
+}
+
+func NewOptions(o *reconCli.Options) *Options {
+	return &Options{
+		Options: o,
+	}
+}
+
+func (o *Options) Validate() error {
+	if o.MothershipURL == "" {
+		return fmt.Errorf("mothership URL is undefined")
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("timeout cannot be < 0 but was %s", o.Timeout)
+	}
+	if o.Timeout > 0 && o.CallbackPort <= 0 {
+		return fmt.Errorf("callback port is undefined")
+	}
+	return nil
+}
+
+// parseOperationID expects an operation ID in the format '<schedulingID>/<correlationID>'
+func (o *Options) parseOperationID(operationID string) error {
+	ids := strings.Split(operationID, "/")
+	if len(ids) != 2 || strings.TrimSpace(ids[0]) == "" || strings.TrimSpace(ids[1]) == "" {
+		return fmt.Errorf("operation ID '%s' is invalid: expected format is '<schedulingID>/<correlationID>'", operationID)
+	}
+	o.schedulingID = strings.TrimSpace(ids[0])
+	o.correlationID = strings.TrimSpace(ids[1])
+	return nil
+}
+
+func (o *Options) callbackBaseURL() string {
+	if o.CallbackURL != "" {
+		return strings.TrimSuffix(o.CallbackURL, "/")
+	}
+	return fmt.Sprintf("http://localhost:%d", o.CallbackPort)
+}
+
+func (o *Options) httpClient() *http.Client {
+	if o.HTTPClient != nil {
+		return httpclient.New(o.HTTPClient)
+	}
+	return httpclient.Default()
+}
+
+func (o *Options) client() (*client.MothershipClient, error) {
+	opts := []client.Option{client.WithHTTPClient(o.httpClient())}
+	if o.Token != "" {
+		opts = append(opts, client.WithBearerToken(o.Token))
+	}
+	return client.NewMothershipClient(o.MothershipURL, opts...)
+}
@@ -24,9 +24,22 @@ func NewCmd(o *reconCli.Options, reconcilerName string) *cobra.Command {
 
 func Run(o *reconCli.Options, reconcilerName string) error {
 	ctx := cli.NewContext()
+	if err := o.ConfigureHTTPClient(); err != nil {
+		return err
+	}
+	if err := o.WatchLogLevels(ctx); err != nil {
+		return err
+	}
+	if err := o.StartDiagnostics(ctx); err != nil {
+		return err
+	}
 	workerPool, tracker, err := StartComponentReconciler(ctx, o, reconcilerName)
 	if err != nil {
 		return err
 	}
-	return StartWebserver(ctx, o, workerPool, tracker)
+	dispatcher, err := StartQueueDispatcher(ctx, o, reconcilerName, workerPool)
+	if err != nil {
+		return err
+	}
+	return StartWebserver(ctx, o, workerPool, tracker, dispatcher)
 }
@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/gorilla/mux"
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	cliRecon "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
 	cliTest "github.com/kyma-incubator/reconciler/internal/cli/test"
@@ -14,12 +13,13 @@ import (
 	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
 	"github.com/kyma-incubator/reconciler/pkg/test"
+	"github.com/kyma-incubator/reconciler/pkg/test/callbackmock"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/zap"
-	"io"
 	clientgo "k8s.io/client-go/kubernetes"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -47,8 +47,7 @@ type reconcilerIntegrationTestSuite struct {
 	kubeClientConfig *k8s.Config
 	chartFactory     chart.Factory
 
-	callbackOnNil    string
-	callbackMockPort int
+	callbackMockURL string
 
 	workerConfig *cliRecon.WorkerConfig
 
@@ -99,9 +98,6 @@ func TestIntegrationSuite(t *testing.T) {
 		testDirectory:  "test",
 		testLogger:     logger.NewTestLogger(t),
 
-		callbackOnNil:    "https://httpbin.org/post",
-		callbackMockPort: 11111,
-
 		workerConfig: &cliRecon.WorkerConfig{
 			Timeout: 35 * time.Second,
 			Workers: 2,
@@ -203,7 +199,7 @@ func (s *reconcilerIntegrationTestSuite) startAndWaitForComponentReconciler(sett
 	go func() {
 		// This is necessary in case the next test starts faster than Prometheus can garbage collect the Registration
 		s.T().Cleanup(func() { prometheus.Unregister(recon.Collector()) })
-		s.NoError(StartWebserver(componentReconcilerServerContext, s.options, workerPool, tracker))
+		s.NoError(StartWebserver(componentReconcilerServerContext, s.options, workerPool, tracker, nil))
 	}()
 
 	cliTest.WaitForTCPSocket(s.T(), s.reconcilerHost, s.reconcilerPort, 5*time.Second)
@@ -215,10 +211,8 @@ func (s *reconcilerIntegrationTestSuite) reconcilerURL() string {
 	return fmt.Sprintf("http://%s:%v/v1/run", s.reconcilerHost, s.reconcilerPort)
 }
 
-//goland:noinspection HttpUrlsUsage
-func (s *reconcilerIntegrationTestSuite) callbackURL() string {
-	//nolint:gosec //in test cases is a dynamic URL acceptable
-	return fmt.Sprintf("http://%s:%v/callback", s.reconcilerHost, s.callbackMockPort)
+func (s *reconcilerIntegrationTestSuite) callbackURL(correlationID string) string {
+	return callbackmock.URL(s.callbackMockURL, correlationID)
 }
 
 func (s *reconcilerIntegrationTestSuite) post(testCase reconcilerIntegrationTestCase) interface{} {
@@ -253,56 +247,23 @@ func (s *reconcilerIntegrationTestSuite) post(testCase reconcilerIntegrationTest
 	return allResults
 }
 
-func (s *reconcilerIntegrationTestSuite) newCallbackMockServer() (*http.Server, chan *reconciler.CallbackMessage) {
-	callbackC := make(chan *reconciler.CallbackMessage, 100) //don't block
-
-	router := mux.NewRouter()
-	router.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-		body, err := io.ReadAll(r.Body)
-		defer s.NoError(r.Body.Close())
-		s.NoError(err, "Failed to read HTTP callback message")
-
-		s.testLogger.Infof("Callback mock server received following callback request: %s", string(body))
-
-		callbackData := &reconciler.CallbackMessage{}
-		s.NoError(json.Unmarshal(body, &callbackData))
-
-		callbackC <- callbackData
-	})
-
-	srv := &http.Server{
-		Addr:              fmt.Sprintf(":%d", s.callbackMockPort),
-		Handler:           router,
-		ReadHeaderTimeout: 5 * time.Second,
-	}
-	go func() {
-		s.testLogger.Infof("Starting callback mock server on port %d", s.callbackMockPort)
-		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
-			s.testLogger.Infof("Failed to start callback mock server: %s", err)
-		}
-		s.testLogger.Infof("Shutting down callback mock server")
-	}()
-	cliTest.WaitForTCPSocket(s.T(), "localhost", s.callbackMockPort, 3*time.Second)
-
-	return srv, callbackC
+// newCallbackMock starts a callback receiver which is stopped when the test finishes
+func (s *reconcilerIntegrationTestSuite) newCallbackMock() *callbackmock.Server {
+	mock, err := callbackmock.NewServer(callbackmock.Config{}, s.testLogger)
+	s.NoError(err)
+	srv := httptest.NewServer(mock.Router())
+	s.T().Cleanup(srv.Close)
+	s.callbackMockURL = srv.URL
+	return mock
 }
 
-func (s *reconcilerIntegrationTestSuite) receiveCallbacks(callbackC chan *reconciler.CallbackMessage) []*reconciler.CallbackMessage {
-	var received []*reconciler.CallbackMessage
-Loop:
-	for {
-		select {
-		case callback := <-callbackC:
-			received = append(received, callback)
-			if callback.Status == reconciler.StatusError || callback.Status == reconciler.StatusSuccess {
-				break Loop
-			}
-		case <-time.NewTimer(s.workerConfig.Timeout).C:
-			s.testLogger.Infof("Timeout reached for retrieving callbacks")
-			break Loop
-		}
+func (s *reconcilerIntegrationTestSuite) receiveCallbacks(mock *callbackmock.Server, correlationID string) []*reconciler.CallbackMessage {
+	ctx, cancel := context.WithTimeout(s.testContext, s.workerConfig.Timeout)
+	defer cancel()
+	if _, err := mock.WaitForFinalCallback(ctx, correlationID); err != nil {
+		s.testLogger.Infof("Timeout reached for retrieving callbacks")
 	}
-	return received
+	return mock.Callbacks(correlationID)
 }
 
 func (s *reconcilerIntegrationTestSuite) newProgressTracker(clientSet clientgo.Interface) *progress.Tracker {
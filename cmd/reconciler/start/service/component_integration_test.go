@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -357,23 +356,10 @@ func (s *reconcilerIntegrationTestSuite) TestRun() {
 			s.setDefaultValuesFromTestCase(&testCase)
 			s.startAndWaitForComponentReconciler(testCase.settings)
 
-			var callbackC chan *reconciler.CallbackMessage
-
+			//catch callback events with a mock server
+			callbackMock := s.newCallbackMock()
 			if testCase.model.CallbackURL == "" { // set fallback callback URL
-				if testCase.callbackVerification == nil { // check if validation of callback events has to happen
-					testCase.model.CallbackURL = s.callbackOnNil
-				} else {
-					//goland:noinspection HttpUrlsUsage
-					testCase.model.CallbackURL = s.callbackURL()
-
-					// start mock server to catch callback events
-					var server *http.Server
-					server, callbackC = s.newCallbackMockServer()
-					s.T().Cleanup(func() {
-						s.NoError(server.Shutdown(context.Background()))
-						time.Sleep(1 * time.Second) // give the server some time for graceful shutdown
-					})
-				}
+				testCase.model.CallbackURL = s.callbackURL(testCase.model.CorrelationID)
 			}
 
 			respModel := s.post(testCase)
@@ -383,7 +369,7 @@ func (s *reconcilerIntegrationTestSuite) TestRun() {
 			}
 
 			if testCase.callbackVerification != nil {
-				received := s.receiveCallbacks(callbackC)
+				received := s.receiveCallbacks(callbackMock, testCase.model.CorrelationID)
 				testCase.callbackVerification(received)
 			}
 		})
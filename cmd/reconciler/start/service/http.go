@@ -4,14 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/openapi"
+	"github.com/kyma-incubator/reconciler/pkg/health"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 	"github.com/kyma-incubator/reconciler/pkg/server"
 	"github.com/pkg/errors"
@@ -21,47 +27,80 @@ const (
 	paramContractVersion = "version"
 )
 
-func StartWebserver(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) error {
+func StartWebserver(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker, dispatcher *service.QueueDispatcher) error {
 	srv := server.Webserver{
 		Logger:     o.Logger(),
 		Port:       o.ServerConfig.Port,
 		SSLCrtFile: o.ServerConfig.SSLCrtFile,
 		SSLKeyFile: o.ServerConfig.SSLKeyFile,
-		Router:     newRouter(ctx, o, workerPool, tracker),
+		Router:     newRouter(ctx, o, workerPool, tracker, dispatcher),
+		Security:   o.HTTPServer,
+		AccessLog:  o.HTTPAccessLog,
 	}
 	return srv.Start(ctx) //blocking until ctx gets closed
 }
 
-func newRouter(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) *mux.Router {
+func newRouter(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker, dispatcher *service.QueueDispatcher) *mux.Router {
 	router := mux.NewRouter()
+	//network retries of the mothership mustn't start an operation twice
+	idempotencyCache := server.NewIdempotencyCache(o.ServerConfig.IdempotencyRetention)
 	router.HandleFunc(
 		fmt.Sprintf("/v{%s}/run", paramContractVersion),
-		func(w http.ResponseWriter, r *http.Request) { //just an adapter for the reconcile-fct call
-			reconcile(ctx, w, r, o, workerPool, tracker)
-		},
+		idempotencyCache.Handler(func(w http.ResponseWriter, r *http.Request) { //just an adapter for the reconcile-fct call
+			reconcile(ctx, w, r, o, workerPool, tracker, dispatcher)
+		}),
 	).Methods("PUT", "POST")
+	if dispatcher != nil {
+		router.HandleFunc(
+			fmt.Sprintf("/v{%s}/queue", paramContractVersion),
+			func(w http.ResponseWriter, r *http.Request) {
+				queuedTasks(w, dispatcher)
+			},
+		).Methods(http.MethodGet)
+	}
+	router.HandleFunc(
+		fmt.Sprintf("/v{%s}/loglevel", paramContractVersion),
+		server.LogLevelHandler,
+	).Methods(http.MethodGet, http.MethodPut, http.MethodDelete)
+	router.HandleFunc(
+		fmt.Sprintf("/v{%s}/capabilities", paramContractVersion),
+		capabilities,
+	).Methods(http.MethodGet)
 	metricsRouter := router.Path("/metrics").Subrouter()
-	metricsRouter.Handle("", promhttp.Handler())
+	//OpenMetrics format is required to expose exemplars
+	metricsRouter.Handle("", promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})))
 
 	//liveness and readiness checks
-	router.HandleFunc("/health/live", live)
-	router.HandleFunc("/health/ready", ready(workerPool))
+	checker := newHealthChecker(o, workerPool)
+	router.HandleFunc("/healthz", checker.LiveHandler())
+	router.HandleFunc("/readyz", checker.ReadyHandler())
+	router.HandleFunc("/health/live", checker.LiveHandler())
+	router.HandleFunc("/health/ready", checker.ReadyHandler())
 
-	return router
-}
+	//API documentation
+	server.AddOpenAPIRoutes(router, "Component reconciler API", openapi.ReconcilerAPI)
 
-func live(w http.ResponseWriter, _ *http.Request) {
-	w.WriteHeader(http.StatusOK)
+	return router
 }
 
-func ready(workerPool *service.WorkerPool) http.HandlerFunc {
-	return func(w http.ResponseWriter, _ *http.Request) {
+func newHealthChecker(o *reconCli.Options, workerPool *service.WorkerPool) *health.Checker {
+	checker := health.NewChecker(&health.Config{
+		Timeout:        o.HealthConfig.Timeout,
+		DisabledChecks: o.HealthConfig.DisabledChecks,
+	}, o.Logger())
+	checker.WithReadinessCheck(health.CheckWorkerPool, func(_ context.Context) error {
 		if workerPool.IsClosed() {
-			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
-			return
+			return errors.New("worker pool is closed")
 		}
-		w.WriteHeader(http.StatusOK)
+		return nil
+	})
+	checker.WithReadinessCheck(health.CheckWorkspace, health.WritableDirCheck(o.Workspace))
+	if o.HealthConfig.MothershipURL != "" {
+		checker.WithReadinessCheck(health.CheckMothership, health.HTTPCheck(o.HealthConfig.MothershipURL, nil))
 	}
+	o.Logger().Infof("Health endpoints are verifying the checks: %s", strings.Join(checker.Names(), ", "))
+	return checker
 }
 
 func newModel(req *http.Request) (*reconciler.Task, error) {
@@ -76,11 +115,7 @@ func newModel(req *http.Request) (*reconciler.Task, error) {
 		return nil, err
 	}
 
-	model, err := modelForVersion(contractVersion)
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(b, model)
+	model, err := modelForVersion(contractVersion, b)
 	if err != nil {
 		return nil, err
 	}
@@ -92,20 +127,44 @@ func newModel(req *http.Request) (*reconciler.Task, error) {
 	return model, err
 }
 
-func modelForVersion(contractVersion string) (*reconciler.Task, error) {
-	if contractVersion == "" {
+func modelForVersion(contractVersion string, payload []byte) (*reconciler.Task, error) {
+	switch contractVersion {
+	case "":
 		return nil, fmt.Errorf("contract version cannot be empty")
+	case "1":
+		model := &reconciler.Task{}
+		if err := json.Unmarshal(payload, model); err != nil {
+			return nil, err
+		}
+		return model, nil
+	case "2":
+		model := &reconciler.TaskV2{}
+		if err := json.Unmarshal(payload, model); err != nil {
+			return nil, err
+		}
+		return model.Task()
+	default:
+		return nil, fmt.Errorf("contract version '%s' is not supported", contractVersion)
 	}
-	return &reconciler.Task{}, nil //change this function if multiple contract versions have to be supported
 }
 
 var reconcileSubmissionMutex = sync.Mutex{}
 
-func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker) {
+func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o *reconCli.Options, workerPool *service.WorkerPool, tracker *service.OccupancyTracker, dispatcher *service.QueueDispatcher) {
 	o.Logger().Debug("Start processing reconciliation request")
 
 	//marshal model
 	model, err := newModel(req)
+	if tooLarge := server.RequestTooLargeError(err); tooLarge != nil {
+		server.SendHTTPError(w, http.StatusRequestEntityTooLarge, &reconciler.HTTPErrorResponse{
+			Error: tooLarge.Error(),
+		})
+		return
+	}
+	if validationErr, ok := reconciler.AsValidationError(err); ok {
+		sendValidationError(w, validationErr)
+		return
+	}
 	if err != nil {
 		o.Logger().Warnf("Unmarshalling of model failed: %s", err)
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
@@ -114,12 +173,31 @@ func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o
 		return
 	}
 	o.Logger().Debugf("Reconciliation model unmarshalled: %s", model)
+	model.TraceID = server.TraceID(req)
 
 	//validate model
-	if err := model.Validate(); err != nil {
-		server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
-			Error: err.Error(),
-		})
+	if err := validateModel(model); err != nil {
+		validationErr, ok := reconciler.AsValidationError(err)
+		if !ok {
+			server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		sendValidationError(w, validationErr)
+		return
+	}
+
+	if dispatcher != nil {
+		//queued tasks are processed by the next free worker of any replica: the capacity isn't checked
+		tracker.AssignCallbackURL(model.CallbackURL)
+		if err := dispatcher.Enqueue(model); err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to queue task").Error(),
+			})
+			return
+		}
+		sendResponse(w)
 		return
 	}
 
@@ -135,6 +213,12 @@ func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o
 		})
 		return
 	}
+	if model.Priority == reconciler.PriorityLow && workerPool.FreeWorkers() <= workerPool.ReservedWorkers() {
+		server.SendHTTPError(w, http.StatusTooManyRequests, &reconciler.HTTPErrorResponse{
+			Error: errors.Errorf("worker pool for %s has no capacity left for low priority tasks", model.Component).Error(),
+		})
+		return
+	}
 
 	o.Logger().Debugf("Assigning reconciliation worker to model '%s'", model)
 	//setting callback URL for occupancy tracking
@@ -150,6 +234,78 @@ func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o
 	sendResponse(w)
 }
 
+// validateModel extends the validation of the task by the checks which depend on the component reconciler
+func validateModel(model *reconciler.Task) error {
+	validationErr := &reconciler.ValidationError{}
+	if err := model.Validate(); err != nil {
+		var ok bool
+		if validationErr, ok = reconciler.AsValidationError(err); !ok {
+			return err
+		}
+	}
+	if err := chart.ValidateProfile(model.Profile); err != nil {
+		validationErr.Add("profile", "%s", err)
+	}
+	return validationErr.ErrOrNil()
+}
+
+func sendValidationError(w http.ResponseWriter, validationErr *reconciler.ValidationError) {
+	server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPValidationErrorResponse{
+		Error:  validationErr.Error(),
+		Fields: validationErr.Fields,
+	})
+}
+
+// capabilities announces the features of this reconciler: the mothership uses them to decide which payload
+// and options it can send (replicas of different versions can coexist during a rolling upgrade)
+func capabilities(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(&reconciler.HTTPCapabilitiesResponse{
+		Version:      service.ReconcilerVersion,
+		Capabilities: reconciler.Capabilities(),
+	}); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode capabilities to JSON").Error(),
+		})
+	}
+}
+
+// queuedTasks lists the queued tasks of the component reconciler (shared by all replicas)
+func queuedTasks(w http.ResponseWriter, dispatcher *service.QueueDispatcher) {
+	tasks, err := dispatcher.Tasks()
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to retrieve queued tasks").Error(),
+		})
+		return
+	}
+	now := time.Now().UTC()
+	resp := &reconciler.HTTPQueueResponse{Tasks: []*reconciler.HTTPQueuedTask{}}
+	for _, task := range tasks {
+		queuedTask := &reconciler.HTTPQueuedTask{
+			ID:            task.ID,
+			Component:     task.Component,
+			CorrelationID: task.CorrelationID,
+			RuntimeID:     task.RuntimeID,
+			Priority:      task.Priority,
+			Attempts:      task.Attempts,
+			Created:       task.Created,
+		}
+		if task.Leased(now) {
+			queuedTask.LeaseOwner = task.LeaseOwner
+			leaseExpiry := task.LeaseExpiry
+			queuedTask.LeaseExpiry = &leaseExpiry
+		}
+		resp.Tasks = append(resp.Tasks, queuedTask)
+	}
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode queued tasks to JSON").Error(),
+		})
+	}
+}
+
 func sendResponse(w http.ResponseWriter) {
 	w.Header().Set("content-type", "application/json")
 	if err := json.NewEncoder(w).Encode(&reconciler.HTTPReconciliationResponse{}); err != nil {
@@ -6,7 +6,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/queue"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/kyma-incubator/reconciler/pkg/redis"
 )
 
 func StartComponentReconciler(ctx context.Context, o *reconCli.Options, reconcilerName string) (*service.WorkerPool, *service.OccupancyTracker, error) {
@@ -19,12 +22,118 @@ func StartComponentReconciler(ctx context.Context, o *reconCli.Options, reconcil
 	if err != nil {
 		return nil, nil, err
 	}
-	reconcilerMetricsSet := metrics.NewReconcilerMetricsSet(durationMetric)
+	operationsMetric := metrics.NewComponentOperationsMetric(o.Logger())
+	err = prometheus.Register(operationsMetric)
+	if err != nil {
+		return nil, nil, err
+	}
+	stuckWorkersMetric := metrics.NewStuckWorkersMetric()
+	err = prometheus.Register(stuckWorkersMetric)
+	if err != nil {
+		return nil, nil, err
+	}
+	reconcilerMetricsSet := metrics.NewReconcilerMetricsSet(durationMetric, operationsMetric, stuckWorkersMetric)
 	recon, err := reconCli.NewComponentReconciler(o, reconcilerName, reconcilerMetricsSet)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	if err := enableRenderCache(ctx, o, recon); err != nil {
+		return nil, nil, err
+	}
+
 	o.Logger().Infof("Starting component reconciler '%s'", reconcilerName)
 	return recon.StartRemote(ctx, reconcilerName)
 }
+
+// StartQueueDispatcher processes the tasks of the queue shared by all replicas of the component reconciler
+// (returns nil if no queue is configured: tasks are directly assigned to workers)
+func StartQueueDispatcher(ctx context.Context, o *reconCli.Options, reconcilerName string, workerPool *service.WorkerPool) (*service.QueueDispatcher, error) {
+	if !o.QueueConfig.Enabled() {
+		return nil, nil
+	}
+	redisConfig, err := redis.NewConfig(o.QueueConfig.DBConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	var taskQueue queue.Queue
+	if redisConfig.Enabled() && redisConfig.Queue {
+		client, encryptor, err := newRedisClient(ctx, o, redisConfig)
+		if err != nil {
+			return nil, err
+		}
+		if taskQueue, err = queue.NewRedisQueue(client, encryptor); err != nil {
+			return nil, err
+		}
+		o.Logger().Infof("Queueing tasks in redis '%s'", redisConfig.Address)
+	} else {
+		connFactory, err := db.NewConnectionFactory(o.QueueConfig.DBConfigFile, false, o.Verbose)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := connFactory.NewConnection()
+		if err != nil {
+			return nil, err
+		}
+		if taskQueue, err = queue.NewPersistentQueue(conn, o.Verbose); err != nil {
+			return nil, err
+		}
+		go func() {
+			<-ctx.Done()
+			if err := conn.Close(); err != nil {
+				o.Logger().Warnf("Failed to close DB connection of queue: %s", err)
+			}
+		}()
+	}
+	dispatcher, err := service.NewQueueDispatcher(taskQueue, workerPool, reconcilerName, service.QueueDispatcherConfig{
+		PollInterval:  o.QueueConfig.PollInterval,
+		LeaseDuration: o.QueueConfig.LeaseDuration,
+		MaxAttempts:   o.QueueConfig.MaxAttempts,
+	}, o.Logger())
+	if err != nil {
+		return nil, err
+	}
+	go dispatcher.Run(ctx)
+	return dispatcher, nil
+}
+
+// enableRenderCache shares rendered manifests between the replicas if the render cache is enabled in the redis
+// section of the queue configuration file
+func enableRenderCache(ctx context.Context, o *reconCli.Options, recon *service.ComponentReconciler) error {
+	if !o.QueueConfig.Enabled() {
+		return nil
+	}
+	redisConfig, err := redis.NewConfig(o.QueueConfig.DBConfigFile)
+	if err != nil {
+		return err
+	}
+	if !redisConfig.Enabled() || !redisConfig.RenderCache {
+		return nil
+	}
+	client, encryptor, err := newRedisClient(ctx, o, redisConfig)
+	if err != nil {
+		return err
+	}
+	recon.WithRenderCache(service.NewRedisRenderCache(client, encryptor, o.Logger()))
+	o.Logger().Infof("Caching rendered manifests in redis '%s' (TTL: %s)", redisConfig.Address, redisConfig.RenderCacheTTL)
+	return nil
+}
+
+// newRedisClient returns a client which is closed with the context and the encryptor of the stored data
+func newRedisClient(ctx context.Context, o *reconCli.Options, redisConfig *redis.Config) (*redis.Client, *db.Encryptor, error) {
+	encryptor, err := db.NewEncryptorFromConfig(o.QueueConfig.DBConfigFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := redis.NewClient(redisConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		if err := client.Close(); err != nil {
+			o.Logger().Warnf("Failed to close redis client: %s", err)
+		}
+	}()
+	return client, encryptor, nil
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/test/callbackmock"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "callback-mock",
+		Short: "Start a callback receiver",
+		Long: "Start a mock of the mothership which receives the callbacks of component reconcilers (at /callback/{id}) " +
+			"and exposes them for assertions (at /callbacks/{id})",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+
+	cmd.Flags().IntVar(&o.Port, "port", 11111, "Port of the callback receiver")
+	cmd.Flags().DurationVar(&o.Latency, "latency", 0, "Delay of each response")
+	cmd.Flags().Float64Var(&o.FailureRate, "failure-rate", 0, "Rate (between 0 and 1) of callbacks which are rejected")
+	cmd.Flags().IntVar(&o.FailureStatusCode, "failure-status", 500, "HTTP status code returned for rejected callbacks")
+
+	return cmd
+}
+
+func Run(o *Options) error {
+	srv, err := callbackmock.NewServer(callbackmock.Config{
+		Latency:           o.Latency,
+		FailureRate:       o.FailureRate,
+		FailureStatusCode: o.FailureStatusCode,
+	}, o.Logger())
+	if err != nil {
+		return err
+	}
+	o.Logger().Infof("Callback mock is receiving callbacks at http://localhost:%d/callback/{id}", o.Port)
+	return srv.Start(cli.NewContext(), o.Port)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+)
+
+type Options struct {
+	*reconCli.Options
+	Port              int
+	Latency           time.Duration
+	FailureRate       float64
+	FailureStatusCode int
+}
+
+func NewOptions(o *reconCli.Options) *Options {
+	return &Options{
+		Options: o,
+	}
+}
+
+func (o *Options) Validate() error {
+	if o.Port <= 0 {
+		return fmt.Errorf("port is undefined")
+	}
+	if o.FailureRate < 0 || o.FailureRate > 1 {
+		return fmt.Errorf("failure rate has to be between 0 and 1")
+	}
+	return nil
+}
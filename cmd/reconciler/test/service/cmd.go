@@ -49,7 +49,11 @@ func Run(o *Options, reconcilerName string) error {
 	if err != nil {
 		return err
 	}
-	return startSvcCmd.StartWebserver(ctx, o.Options, workerPool, tracker)
+	dispatcher, err := startSvcCmd.StartQueueDispatcher(ctx, o.Options, reconcilerName, workerPool)
+	if err != nil {
+		return err
+	}
+	return startSvcCmd.StartWebserver(ctx, o.Options, workerPool, tracker, dispatcher)
 }
 
 func showCurl(o *Options) error {
@@ -67,7 +71,7 @@ func showCurl(o *Options) error {
 		Profile:         o.Profile,
 		Configuration:   nil,
 		Kubeconfig:      kubeConfig,
-		CallbackURL:     "https://httpbin.org/post",
+		CallbackURL:     "http://localhost:11111/callback", //start 'reconciler test callback-mock' to receive the callbacks
 		CorrelationID:   "1-2-3-4-5",
 	}
 
@@ -77,7 +81,8 @@ func showCurl(o *Options) error {
 	}
 	fmt.Printf(`
 
-Execute this command to trigger the component reconciler:
+Execute this command to trigger the component reconciler
+(run 'reconciler test callback-mock' to receive its callbacks):
 
 curl --location \
 --request PUT 'http://localhost:%d/v1/run' \
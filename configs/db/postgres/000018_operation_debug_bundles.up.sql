@@ -0,0 +1,11 @@
+--DDL for debug bundles captured by component reconcilers for operations flagged as "debug"
+CREATE TABLE IF NOT EXISTS scheduler_operation_debug_bundles
+(
+    "scheduling_id"  varchar(255) NOT NULL,
+    "correlation_id" varchar(255) NOT NULL,
+    "bundle"         text         NOT NULL,
+    "created"        TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_operation_debug_bundles_pk PRIMARY KEY ("scheduling_id", "correlation_id"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id")
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,11 @@
+--DDL for the timestamps when operations reached a particular processing phase
+CREATE TABLE IF NOT EXISTS scheduler_operation_phases
+(
+    "scheduling_id"  varchar(255) NOT NULL,
+    "correlation_id" varchar(255) NOT NULL,
+    "phase"          varchar(255) NOT NULL,
+    "reached"        TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    CONSTRAINT scheduler_operation_phases_pk PRIMARY KEY ("scheduling_id", "correlation_id", "phase"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id")
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+--DDL for the versions or version ranges components of a cluster are pinned to
+CREATE TABLE IF NOT EXISTS inventory_component_pins
+(
+    "runtime_id" varchar(255) NOT NULL,
+    "component"  varchar(255) NOT NULL,
+    "version"    varchar(255) NOT NULL,
+    "created"    TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_component_pins_pk PRIMARY KEY ("runtime_id", "component")
+);
@@ -0,0 +1,13 @@
+--DDL for the results of the smoke tests which verified the component of an operation
+CREATE TABLE IF NOT EXISTS scheduler_operation_smoke_tests
+(
+    "scheduling_id"  varchar(255) NOT NULL,
+    "correlation_id" varchar(255) NOT NULL,
+    "name"           varchar(255) NOT NULL,
+    "passed"         boolean      NOT NULL,
+    "error"          text,
+    "duration"       bigint       NOT NULL,
+    CONSTRAINT scheduler_operation_smoke_tests_pk PRIMARY KEY ("scheduling_id", "correlation_id", "name"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id")
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
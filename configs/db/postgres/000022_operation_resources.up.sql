@@ -0,0 +1,15 @@
+--DDL for the outcome of each resource handled by the reconciliation of an operation
+CREATE TABLE IF NOT EXISTS scheduler_operation_resources
+(
+    "scheduling_id"  varchar(255) NOT NULL,
+    "correlation_id" varchar(255) NOT NULL,
+    "api_version"    varchar(255) NOT NULL,
+    "kind"           varchar(255) NOT NULL,
+    "namespace"      varchar(255) NOT NULL DEFAULT '',
+    "name"           varchar(255) NOT NULL,
+    "outcome"        varchar(255) NOT NULL,
+    "error"          text,
+    CONSTRAINT scheduler_operation_resources_pk PRIMARY KEY ("scheduling_id", "correlation_id", "api_version", "kind", "namespace", "name"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id")
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
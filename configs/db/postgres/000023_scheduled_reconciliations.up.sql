@@ -0,0 +1,10 @@
+--DDL for one-off reconciliations which are triggered not before a given point in time
+CREATE TABLE IF NOT EXISTS inventory_scheduled_reconciliations
+(
+    "id"         varchar(255) NOT NULL,
+    "runtime_id" varchar(255) NOT NULL,
+    "not_before" TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    "created"    TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_scheduled_reconciliations_pk PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS inventory_scheduled_reconciliations__idx_not_before ON "inventory_scheduled_reconciliations" ("not_before");
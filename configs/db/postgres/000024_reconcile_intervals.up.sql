@@ -0,0 +1,9 @@
+--DDL for reconcile intervals overriding the global interval per cluster and/or per component (empty value = any)
+CREATE TABLE IF NOT EXISTS inventory_reconcile_intervals
+(
+    "runtime_id" varchar(255) NOT NULL DEFAULT '',
+    "component"  varchar(255) NOT NULL DEFAULT '',
+    "seconds"    bigint NOT NULL,
+    "created"    TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_reconcile_intervals_pk PRIMARY KEY ("runtime_id", "component")
+);
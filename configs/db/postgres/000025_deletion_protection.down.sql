@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inventory_deletion_confirmations;
+DROP TABLE IF EXISTS inventory_deletion_protections;
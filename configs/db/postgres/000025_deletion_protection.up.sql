@@ -0,0 +1,17 @@
+--DDL for clusters which are protected against deletion
+CREATE TABLE IF NOT EXISTS inventory_deletion_protections
+(
+    "runtime_id" varchar(255) NOT NULL,
+    "created"    TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_deletion_protections_pk PRIMARY KEY ("runtime_id")
+);
+--DDL for single-use tokens which confirm the deletion of a cluster
+CREATE TABLE IF NOT EXISTS inventory_deletion_confirmations
+(
+    "token"      varchar(255) NOT NULL,
+    "runtime_id" varchar(255) NOT NULL,
+    "expires"    TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    "created"    TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_deletion_confirmations_pk PRIMARY KEY ("token")
+);
+CREATE INDEX IF NOT EXISTS inventory_deletion_confirmations__idx_runtime_id ON "inventory_deletion_confirmations" ("runtime_id");
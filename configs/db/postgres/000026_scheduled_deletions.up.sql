@@ -0,0 +1,9 @@
+--DDL for soft-deleted clusters which are torn down not before a given point in time
+CREATE TABLE IF NOT EXISTS inventory_scheduled_deletions
+(
+    "runtime_id"     varchar(255) NOT NULL,
+    "teardown_after" TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    "created"        TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_scheduled_deletions_pk PRIMARY KEY ("runtime_id")
+);
+CREATE INDEX IF NOT EXISTS inventory_scheduled_deletions__idx_teardown_after ON "inventory_scheduled_deletions" ("teardown_after");
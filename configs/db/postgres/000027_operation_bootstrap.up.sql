@@ -0,0 +1,2 @@
+ALTER TABLE scheduler_operations
+    ADD COLUMN "bootstrap" boolean NOT NULL DEFAULT FALSE;
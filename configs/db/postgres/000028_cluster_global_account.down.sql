@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS inventory_cluster__idx_global_account_id;
+ALTER TABLE inventory_clusters DROP COLUMN "global_account_id";
@@ -0,0 +1,5 @@
+--tenant (global account) of a cluster which is used to scope the API access to the clusters of a tenant
+ALTER TABLE inventory_clusters
+    ADD COLUMN "global_account_id" varchar(255);
+UPDATE inventory_clusters SET "global_account_id" = (metadata::json ->> 'globalAccountID');
+CREATE INDEX IF NOT EXISTS inventory_cluster__idx_global_account_id ON "inventory_clusters" ("global_account_id");
@@ -0,0 +1,9 @@
+--DDL for tenant (global account) specific limits of the operations processed in parallel and per minute (0 = unlimited)
+CREATE TABLE IF NOT EXISTS inventory_tenant_quotas
+(
+    "global_account_id"         varchar(255) NOT NULL,
+    "max_parallel_operations"   bigint NOT NULL,
+    "max_operations_per_minute" bigint NOT NULL,
+    "created"                   TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_tenant_quotas_pk PRIMARY KEY ("global_account_id")
+);
@@ -0,0 +1,2 @@
+ALTER TABLE scheduler_operations
+    ADD COLUMN "cluster_size" bigint NOT NULL DEFAULT 0;
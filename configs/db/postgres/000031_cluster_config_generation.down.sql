@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS inventory_cluster_configs__idx_runtime_id_generation;
+ALTER TABLE inventory_cluster_configs DROP COLUMN "generation";
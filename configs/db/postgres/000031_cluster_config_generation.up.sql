@@ -0,0 +1,12 @@
+--generation of a cluster configuration: incremented with each change of the configuration of a cluster and used
+--as version (ETag) to detect concurrent updates
+ALTER TABLE inventory_cluster_configs
+    ADD COLUMN "generation" bigint NOT NULL DEFAULT 0;
+
+UPDATE inventory_cluster_configs icc
+SET generation = generations.generation
+FROM (SELECT version, ROW_NUMBER() OVER (PARTITION BY runtime_id ORDER BY version) AS generation
+      FROM inventory_cluster_configs) generations
+WHERE icc.version = generations.version;
+
+CREATE UNIQUE INDEX IF NOT EXISTS inventory_cluster_configs__idx_runtime_id_generation ON "inventory_cluster_configs" ("runtime_id", "generation");
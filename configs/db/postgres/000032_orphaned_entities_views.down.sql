@@ -0,0 +1,2 @@
+DROP VIEW IF EXISTS v_inventory_orphaned_configs;
+DROP VIEW IF EXISTS v_scheduler_orphaned_operations;
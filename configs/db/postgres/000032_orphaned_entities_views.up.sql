@@ -0,0 +1,17 @@
+----------------------view orphaned operations
+CREATE OR REPLACE VIEW v_scheduler_orphaned_operations AS
+-- operations whose reconciliation doesn't exist anymore
+SELECT so.scheduling_id, so.correlation_id, so.runtime_id, so.created
+FROM scheduler_operations so
+WHERE NOT EXISTS(SELECT 1 FROM scheduler_reconciliations sr WHERE sr.scheduling_id = so.scheduling_id);
+
+----------------------view orphaned cluster configs
+CREATE OR REPLACE VIEW v_inventory_orphaned_configs AS
+-- outdated cluster configurations which are neither referenced by a status, a reconciliation nor an operation
+SELECT icc.version AS config_id, icc.runtime_id, icc.cluster_version AS cluster_id, icc.created
+FROM inventory_cluster_configs icc
+WHERE NOT EXISTS(SELECT 1 FROM inventory_cluster_config_statuses icss WHERE icss.config_version = icc.version)
+  AND NOT EXISTS(SELECT 1 FROM scheduler_reconciliations sr WHERE sr.cluster_config = icc.version)
+  AND NOT EXISTS(SELECT 1 FROM scheduler_operations so WHERE so.cluster_config = icc.version)
+  -- the latest configuration of a cluster is always kept
+  AND icc.version < (SELECT MAX(latest.version) FROM inventory_cluster_configs latest WHERE latest.runtime_id = icc.runtime_id);
@@ -0,0 +1,13 @@
+--DDL for the history of the changes of the desired configuration of clusters (diff is encrypted)
+CREATE TABLE IF NOT EXISTS inventory_cluster_changes
+(
+    "id"             SERIAL PRIMARY KEY,
+    "runtime_id"     varchar(255) NOT NULL,
+    "config_version" bigint NOT NULL,
+    "generation"     bigint NOT NULL,
+    "actor"          varchar(255),
+    "diff"           text NOT NULL,
+    "created"        TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+CREATE INDEX IF NOT EXISTS inventory_cluster_changes__idx_runtime_id ON "inventory_cluster_changes" ("runtime_id");
@@ -0,0 +1,8 @@
+--DDL for the tokens granting access to the status badges of clusters (only the hash of a token is stored)
+CREATE TABLE IF NOT EXISTS inventory_status_badges
+(
+    "runtime_id" varchar(255) NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "created"    TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_status_badges_pk PRIMARY KEY ("runtime_id")
+);
@@ -0,0 +1,12 @@
+--DDL for feature flags which gate reconciler behaviors per cluster (explicit targeting or percentage rollout)
+CREATE TABLE IF NOT EXISTS inventory_feature_flags
+(
+    "name"               varchar(255) NOT NULL,
+    "description"        text         NOT NULL DEFAULT '',
+    "rollout_percentage" integer      NOT NULL DEFAULT 0,
+    "enabled_clusters"   text         NOT NULL DEFAULT '[]',
+    "disabled_clusters"  text         NOT NULL DEFAULT '[]',
+    "created"            TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT inventory_feature_flags_pk PRIMARY KEY ("name"),
+    CONSTRAINT inventory_feature_flags_percentage CHECK ("rollout_percentage" BETWEEN 0 AND 100)
+);
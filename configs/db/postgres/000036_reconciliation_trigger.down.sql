@@ -0,0 +1,2 @@
+ALTER TABLE scheduler_reconciliations DROP COLUMN "initiator", DROP COLUMN "reason";
+ALTER TABLE inventory_cluster_config_statuses DROP COLUMN "initiator", DROP COLUMN "reason";
@@ -0,0 +1,9 @@
+--initiator (scheduler, keb or user) and reason of the status changes which request a reconciliation
+ALTER TABLE inventory_cluster_config_statuses
+    ADD COLUMN "initiator" varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN "reason" varchar(255) NOT NULL DEFAULT '';
+
+--initiator and reason of a reconciliation
+ALTER TABLE scheduler_reconciliations
+    ADD COLUMN "initiator" varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN "reason" varchar(255) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reconciler_task_queue;
@@ -0,0 +1,18 @@
+--DDL for the queue of pending operations of the component reconcilers: replicas of a component reconciler share
+--the queue and lease the operations they process (the lease of a crashed replica expires)
+CREATE TABLE IF NOT EXISTS reconciler_task_queue
+(
+    "id"             varchar(64)  NOT NULL,
+    "reconciler"     varchar(255) NOT NULL,
+    "component"      varchar(255) NOT NULL,
+    "correlation_id" varchar(255) NOT NULL,
+    "runtime_id"     varchar(255) NOT NULL DEFAULT '',
+    "priority"       varchar(32)  NOT NULL DEFAULT 'normal',
+    "task"           text         NOT NULL,
+    "lease_owner"    varchar(255) NOT NULL DEFAULT '',
+    "lease_expiry"   TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (NOW() AT TIME ZONE 'utc'),
+    "attempts"       integer      NOT NULL DEFAULT 0,
+    "created"        TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT reconciler_task_queue_pk PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS reconciler_task_queue_idx_reconciler ON reconciler_task_queue ("reconciler", "created");
@@ -0,0 +1,15 @@
+--DDL for freezes which stop the scheduling of new operations of a component or of all components (empty component)
+CREATE TABLE IF NOT EXISTS inventory_scheduling_freezes
+(
+    "id"          SERIAL PRIMARY KEY,
+    "component"   varchar(255) NOT NULL DEFAULT '',
+    "reason"      text NOT NULL,
+    "engaged_by"  varchar(255) NOT NULL,
+    "released"    boolean NOT NULL DEFAULT false,
+    "released_by" varchar(255) NOT NULL DEFAULT '',
+    "updated"     TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    "created"     TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc')
+);
+
+--a component can only have one engaged freeze: released freezes are kept as audit trail
+CREATE UNIQUE INDEX IF NOT EXISTS inventory_scheduling_freezes__idx_engaged ON "inventory_scheduling_freezes" ("component") WHERE "released" = false;
@@ -0,0 +1,13 @@
+--DDL for payloads sent to component reconcilers (used to replay operations)
+CREATE TABLE IF NOT EXISTS scheduler_operation_payloads
+(
+    "scheduling_id"    varchar(255) NOT NULL,
+    "correlation_id"   varchar(255) NOT NULL,
+    "url"              text         NOT NULL,
+    "contract_version" integer      NOT NULL,
+    "payload"          text         NOT NULL,
+    "created"          TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_operation_payloads_pk PRIMARY KEY ("scheduling_id", "correlation_id"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id")
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,11 @@
+--DDL for captures of failed operations (payload and resolved values with redacted secrets)
+CREATE TABLE IF NOT EXISTS scheduler_operation_failure_captures
+(
+    "scheduling_id"  varchar(255) NOT NULL,
+    "correlation_id" varchar(255) NOT NULL,
+    "capture"        text         NOT NULL,
+    "created"        TIMESTAMP WITHOUT TIME ZONE DEFAULT (NOW() AT TIME ZONE 'utc'),
+    CONSTRAINT scheduler_operation_failure_captures_pk PRIMARY KEY ("scheduling_id", "correlation_id"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id")
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
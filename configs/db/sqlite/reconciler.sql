@@ -59,6 +59,7 @@ CREATE TABLE IF NOT EXISTS inventory_clusters (
 	"runtime_id" text NOT NULL,
 	"runtime" text NOT NULL,
 	"metadata" text NOT NULL,
+	"global_account_id" text,
 	"kubeconfig" text NOT NULL,
 	"contract" int NOT NULL,
 	"deleted" boolean DEFAULT FALSE,
@@ -75,9 +76,11 @@ CREATE TABLE IF NOT EXISTS inventory_cluster_configs (
 	"components" text,
 	"administrators" text,
 	"contract" int NOT NULL,
+	"generation" int NOT NULL DEFAULT 0,
 	"deleted" boolean DEFAULT FALSE,
 	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	CONSTRAINT inventory_cluster_configs_pk UNIQUE ("runtime_id", "cluster_version", "version"),
+	CONSTRAINT inventory_cluster_configs_generation UNIQUE ("runtime_id", "generation"),
 	FOREIGN KEY("runtime_id", "cluster_version") REFERENCES inventory_clusters("runtime_id", "version") ON UPDATE CASCADE ON DELETE CASCADE
 );
 
@@ -88,6 +91,8 @@ CREATE TABLE IF NOT EXISTS inventory_cluster_config_statuses (
 	"config_version" int NOT NULL,
 	"status" text NOT NULL,
 	"deleted" boolean DEFAULT FALSE,
+	"initiator" text NOT NULL DEFAULT '',
+	"reason" text NOT NULL DEFAULT '',
 	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	FOREIGN KEY("runtime_id", "cluster_version", "config_version") REFERENCES inventory_cluster_configs("runtime_id", "cluster_version", "version") ON UPDATE CASCADE ON DELETE CASCADE
 );
@@ -100,6 +105,8 @@ CREATE TABLE IF NOT EXISTS scheduler_reconciliations (
     "status" text NOT NULL,
     "cluster_config_status" int,
     "finished" boolean DEFAULT FALSE,
+    "initiator" text NOT NULL DEFAULT '',
+    "reason" text NOT NULL DEFAULT '',
     "created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY("lock") REFERENCES inventory_clusters("runtime_id"),
@@ -125,6 +132,8 @@ CREATE TABLE IF NOT EXISTS scheduler_operations(
     "updated" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     "picked_up" TIMESTAMP,
     "processing_duration" int,
+    "bootstrap" boolean NOT NULL DEFAULT FALSE,
+    "cluster_size" int NOT NULL DEFAULT 0,
     CONSTRAINT scheduler_operations_pk UNIQUE ("scheduling_id", "correlation_id"),
     FOREIGN KEY("scheduling_id") REFERENCES scheduler_reconciliations("scheduling_id") ON UPDATE CASCADE ON DELETE CASCADE,
     FOREIGN KEY("runtime_id") REFERENCES inventory_clusters("runtime_id") ON UPDATE CASCADE,
@@ -138,4 +147,197 @@ CREATE TABLE IF NOT EXISTS worker_pool_occupancy
     "running_workers"      int  NOT NULL,
     "worker_pool_capacity" int,
     "created"              TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-);
\ No newline at end of file
+);
+CREATE TABLE IF NOT EXISTS scheduler_operation_debug_bundles
+(
+    "scheduling_id"  text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "bundle"         text NOT NULL,
+    "created"        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduler_operation_debug_bundles_pk UNIQUE ("scheduling_id", "correlation_id"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS scheduler_operation_phases
+(
+    "scheduling_id"  text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "phase"          text NOT NULL,
+    "reached"        TIMESTAMP NOT NULL,
+    CONSTRAINT scheduler_operation_phases_pk UNIQUE ("scheduling_id", "correlation_id", "phase"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS scheduler_operation_smoke_tests
+(
+    "scheduling_id"  text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "name"           text NOT NULL,
+    "passed"         boolean NOT NULL,
+    "error"          text,
+    "duration"       integer NOT NULL,
+    CONSTRAINT scheduler_operation_smoke_tests_pk UNIQUE ("scheduling_id", "correlation_id", "name"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS scheduler_operation_resources
+(
+    "scheduling_id"  text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "api_version"    text NOT NULL,
+    "kind"           text NOT NULL,
+    "namespace"      text NOT NULL DEFAULT '',
+    "name"           text NOT NULL,
+    "outcome"        text NOT NULL,
+    "error"          text,
+    CONSTRAINT scheduler_operation_resources_pk UNIQUE ("scheduling_id", "correlation_id", "api_version", "kind", "namespace", "name"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS scheduler_operation_payloads
+(
+    "scheduling_id"    text    NOT NULL,
+    "correlation_id"   text    NOT NULL,
+    "url"              text    NOT NULL,
+    "contract_version" integer NOT NULL,
+    "payload"          text    NOT NULL,
+    "created"          TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduler_operation_payloads_pk UNIQUE ("scheduling_id", "correlation_id"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS scheduler_operation_failure_captures
+(
+    "scheduling_id"  text NOT NULL,
+    "correlation_id" text NOT NULL,
+    "capture"        text NOT NULL,
+    "created"        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT scheduler_operation_failure_captures_pk UNIQUE ("scheduling_id", "correlation_id"),
+    FOREIGN KEY ("scheduling_id", "correlation_id") REFERENCES scheduler_operations ("scheduling_id", "correlation_id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE TABLE IF NOT EXISTS inventory_component_pins
+(
+    "runtime_id" text NOT NULL,
+    "component"  text NOT NULL,
+    "version"    text NOT NULL,
+    "created"    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_component_pins_pk UNIQUE ("runtime_id", "component")
+);
+CREATE TABLE IF NOT EXISTS inventory_scheduled_reconciliations
+(
+    "id"         text NOT NULL,
+    "runtime_id" text NOT NULL,
+    "not_before" TIMESTAMP NOT NULL,
+    "created"    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_scheduled_reconciliations_pk UNIQUE ("id")
+);
+CREATE TABLE IF NOT EXISTS inventory_reconcile_intervals
+(
+    "runtime_id" text NOT NULL DEFAULT '',
+    "component"  text NOT NULL DEFAULT '',
+    "seconds"    integer NOT NULL,
+    "created"    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_reconcile_intervals_pk UNIQUE ("runtime_id", "component")
+);
+CREATE TABLE IF NOT EXISTS inventory_deletion_protections
+(
+    "runtime_id" text NOT NULL,
+    "created"    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_deletion_protections_pk UNIQUE ("runtime_id")
+);
+CREATE TABLE IF NOT EXISTS inventory_deletion_confirmations
+(
+    "token"      text NOT NULL,
+    "runtime_id" text NOT NULL,
+    "expires"    TIMESTAMP NOT NULL,
+    "created"    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_deletion_confirmations_pk UNIQUE ("token")
+);
+CREATE TABLE IF NOT EXISTS inventory_scheduled_deletions
+(
+    "runtime_id"     text NOT NULL,
+    "teardown_after" TIMESTAMP NOT NULL,
+    "created"        TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_scheduled_deletions_pk UNIQUE ("runtime_id")
+);
+CREATE TABLE IF NOT EXISTS inventory_tenant_quotas
+(
+    "global_account_id"         text NOT NULL,
+    "max_parallel_operations"   integer NOT NULL,
+    "max_operations_per_minute" integer NOT NULL,
+    "created"                   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_tenant_quotas_pk UNIQUE ("global_account_id")
+);
+CREATE TABLE IF NOT EXISTS inventory_cluster_changes
+(
+    "id"             integer PRIMARY KEY AUTOINCREMENT,
+    "runtime_id"     text NOT NULL,
+    "config_version" integer NOT NULL,
+    "generation"     integer NOT NULL,
+    "actor"          text,
+    "diff"           text NOT NULL,
+    "created"        TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE IF NOT EXISTS inventory_status_badges
+(
+    "runtime_id" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "created"    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_status_badges_pk UNIQUE ("runtime_id")
+);
+CREATE TABLE IF NOT EXISTS inventory_feature_flags
+(
+    "name"               text NOT NULL,
+    "description"        text NOT NULL DEFAULT '',
+    "rollout_percentage" integer NOT NULL DEFAULT 0,
+    "enabled_clusters"   text NOT NULL DEFAULT '[]',
+    "disabled_clusters"  text NOT NULL DEFAULT '[]',
+    "created"            TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT inventory_feature_flags_pk UNIQUE ("name")
+);
+CREATE TABLE IF NOT EXISTS inventory_scheduling_freezes
+(
+    "id"          integer PRIMARY KEY AUTOINCREMENT,
+    "component"   text NOT NULL DEFAULT '',
+    "reason"      text NOT NULL,
+    "engaged_by"  text NOT NULL,
+    "released"    boolean NOT NULL DEFAULT false,
+    "released_by" text NOT NULL DEFAULT '',
+    "updated"     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "created"     TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS inventory_scheduling_freezes_idx_engaged ON inventory_scheduling_freezes ("component") WHERE "released" = false;
+CREATE TABLE IF NOT EXISTS reconciler_task_queue
+(
+    "id"             text    NOT NULL PRIMARY KEY,
+    "reconciler"     text    NOT NULL,
+    "component"      text    NOT NULL,
+    "correlation_id" text    NOT NULL,
+    "runtime_id"     text    NOT NULL DEFAULT '',
+    "priority"       text    NOT NULL DEFAULT 'normal',
+    "task"           text    NOT NULL,
+    "lease_owner"    text    NOT NULL DEFAULT '',
+    "lease_expiry"   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    "attempts"       integer NOT NULL DEFAULT 0,
+    "created"        TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS reconciler_task_queue_idx_reconciler ON reconciler_task_queue ("reconciler", "created");
+CREATE VIEW IF NOT EXISTS v_inventory_status_cleanup AS
+WITH t_active_status AS (
+    SELECT icss.config_version AS cluster_config_id, MAX(icss.id) AS status_id
+    FROM inventory_cluster_config_statuses icss
+             JOIN inventory_cluster_configs icc ON icss.config_version = icc.version
+    WHERE icss.deleted = false AND icc.deleted = false
+    GROUP BY icss.config_version
+)
+SELECT status.id AS status_id, status.runtime_id, status.cluster_version AS cluster_id, status.config_version AS config_id, status.status, status.created
+FROM inventory_cluster_config_statuses status
+         LEFT OUTER JOIN scheduler_reconciliations sr ON status.id = sr.cluster_config_status
+WHERE sr.cluster_config_status IS NULL
+  AND status.id NOT IN (SELECT status_id FROM t_active_status);
+CREATE VIEW IF NOT EXISTS v_scheduler_orphaned_operations AS
+SELECT so.scheduling_id, so.correlation_id, so.runtime_id, so.created
+FROM scheduler_operations so
+WHERE NOT EXISTS(SELECT 1 FROM scheduler_reconciliations sr WHERE sr.scheduling_id = so.scheduling_id);
+CREATE VIEW IF NOT EXISTS v_inventory_orphaned_configs AS
+SELECT icc.version AS config_id, icc.runtime_id, icc.cluster_version AS cluster_id, icc.created
+FROM inventory_cluster_configs icc
+WHERE NOT EXISTS(SELECT 1 FROM inventory_cluster_config_statuses icss WHERE icss.config_version = icc.version)
+  AND NOT EXISTS(SELECT 1 FROM scheduler_reconciliations sr WHERE sr.cluster_config = icc.version)
+  AND NOT EXISTS(SELECT 1 FROM scheduler_operations so WHERE so.cluster_config = icc.version)
+  AND icc.version < (SELECT MAX(latest.version) FROM inventory_cluster_configs latest WHERE latest.runtime_id = icc.runtime_id);
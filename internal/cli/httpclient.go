@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/spf13/pflag"
+)
+
+// AddHTTPClientFlags registers the flags configuring the HTTP clients used for outgoing requests
+func (o *Options) AddHTTPClientFlags(flags *pflag.FlagSet) {
+	o.HTTPClient = httpclient.DefaultConfig()
+	flags.DurationVar(&o.HTTPClient.Timeout, "http-timeout", o.HTTPClient.Timeout,
+		"Overall time an outgoing HTTP request is allowed to take")
+	flags.DurationVar(&o.HTTPClient.DialTimeout, "http-dial-timeout", o.HTTPClient.DialTimeout,
+		"Maximal time to establish a connection for an outgoing HTTP request")
+	flags.DurationVar(&o.HTTPClient.TLSHandshakeTimeout, "http-tls-handshake-timeout", o.HTTPClient.TLSHandshakeTimeout,
+		"Maximal time of the TLS handshake for an outgoing HTTP request")
+	flags.DurationVar(&o.HTTPClient.ResponseHeaderTimeout, "http-response-header-timeout", o.HTTPClient.ResponseHeaderTimeout,
+		"Maximal time to wait for the response headers of an outgoing HTTP request")
+	flags.IntVar(&o.HTTPClient.MaxIdleConnsPerHost, "http-max-idle-conns-per-host", o.HTTPClient.MaxIdleConnsPerHost,
+		"Maximal number of idle connections kept per host")
+	flags.IntVar(&o.HTTPClient.MaxConnsPerHost, "http-max-conns-per-host", o.HTTPClient.MaxConnsPerHost,
+		"Maximal number of connections per host (0 = unlimited)")
+	flags.IntVar(&o.HTTPClient.MaxRetries, "http-retries", o.HTTPClient.MaxRetries,
+		"Retries of outgoing HTTP requests which failed for transient reasons")
+	flags.DurationVar(&o.HTTPClient.RetryDelay, "http-retry-delay", o.HTTPClient.RetryDelay,
+		"Initial delay between retries of outgoing HTTP requests (grows exponentially)")
+	flags.StringSliceVar(&o.HTTPClient.EgressAllowlist, "http-egress-allowlist", nil,
+		"Hosts which outgoing HTTP requests (e.g. chart downloads and callbacks) are allowed to contact: host names, "+
+			"wildcard domains ('*.example.com'), IPs or CIDRs (empty = all hosts)")
+}
+
+// ConfigureHTTPClient applies the HTTP client flags to all HTTP clients created by the httpclient package
+func (o *Options) ConfigureHTTPClient() error {
+	if o.HTTPClient == nil {
+		return nil
+	}
+	if _, err := httpclient.ParseEgressAllowlist(o.HTTPClient.EgressAllowlist); err != nil {
+		return err
+	}
+	httpclient.SetDefaults(o.HTTPClient)
+	return nil
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/spf13/pflag"
+)
+
+// AddHTTPServerFlags registers the flags configuring the CORS policy, request limits and access log of the webservers
+func (o *Options) AddHTTPServerFlags(flags *pflag.FlagSet) {
+	o.HTTPServer = server.DefaultSecurityConfig()
+	flags.StringSliceVar(&o.HTTPServer.AllowedOrigins, "server-cors-allowed-origins", o.HTTPServer.AllowedOrigins,
+		"Origins of browser-based clients which are allowed to call the API (e.g. 'https://console.example.com', '*' = any origin, empty = CORS disabled)")
+	flags.StringSliceVar(&o.HTTPServer.AllowedMethods, "server-cors-allowed-methods", o.HTTPServer.AllowedMethods,
+		"HTTP methods which browser-based clients are allowed to use")
+	flags.StringSliceVar(&o.HTTPServer.AllowedHeaders, "server-cors-allowed-headers", o.HTTPServer.AllowedHeaders,
+		"Request headers which browser-based clients are allowed to send")
+	flags.DurationVar(&o.HTTPServer.CORSMaxAge, "server-cors-max-age", o.HTTPServer.CORSMaxAge,
+		"Time browsers cache the CORS policy")
+	flags.Int64Var(&o.HTTPServer.MaxRequestBytes, "server-max-request-bytes", o.HTTPServer.MaxRequestBytes,
+		"Requests with a larger body are rejected with 413, also if the decompressed body is larger (0 = unlimited)")
+
+	o.HTTPAccessLog = &server.AccessLogConfig{}
+	flags.BoolVar(&o.HTTPAccessLog.Enabled, "server-access-log", false,
+		"Log method, path, caller, latency and response code of each request")
+	flags.BoolVar(&o.HTTPAccessLog.Bodies, "server-access-log-bodies", false,
+		"Log request bodies with redacted kubeconfigs and secrets in addition (requires debug log level)")
+}
@@ -1,25 +1,36 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"github.com/kyma-incubator/reconciler/internal/persistency"
 	"strings"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/spf13/viper"
 
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/server"
 	"go.uber.org/zap"
 )
 
+const logLevelWatchInterval = 10 * time.Second
+
 type Options struct {
-	Migrate        bool
-	Verbose        bool
-	InitRegistry   bool
-	NonInteractive bool
-	OutputFormat   string
-	logger         *zap.SugaredLogger
-	Registry       *persistency.Registry //will be initialized during CLI bootstrap in main.go
+	Migrate         bool
+	Verbose         bool
+	InitRegistry    bool
+	NonInteractive  bool
+	OutputFormat    string
+	LogLevelFile    string
+	DiagnosticsPort int
+	HTTPClient      *httpclient.Config
+	HTTPServer      *server.SecurityConfig //applied to all webservers (nil = only security headers)
+	HTTPAccessLog   *server.AccessLogConfig
+	logger          *zap.SugaredLogger
+	Registry        *persistency.Registry //will be initialized during CLI bootstrap in main.go
 }
 
 func (o *Options) String() string {
@@ -43,6 +54,39 @@ func (o *Options) Logger() *zap.SugaredLogger {
 	return o.logger
 }
 
+// WatchLogLevels applies the log levels defined in the log level file and watches the file for changes
+func (o *Options) WatchLogLevels(ctx context.Context) error {
+	if o.LogLevelFile == "" {
+		return nil
+	}
+	o.Logger().Infof("Watching log level file '%s' for changes", o.LogLevelFile)
+	return logger.WatchLevelFile(ctx, o.LogLevelFile, logLevelWatchInterval)
+}
+
+// StartDiagnostics serves runtime profiles and exported variables on the diagnostics port (if enabled)
+func (o *Options) StartDiagnostics(ctx context.Context) error {
+	if o.DiagnosticsPort == 0 {
+		return nil
+	}
+	if o.DiagnosticsPort < 0 || o.DiagnosticsPort > 65535 {
+		return fmt.Errorf("diagnostics port %d is out of range 1-65535", o.DiagnosticsPort)
+	}
+	o.Logger().Infof("Exposing diagnostics endpoints on port %d", o.DiagnosticsPort)
+	go func() {
+		srv := server.Webserver{
+			Logger:    o.Logger(),
+			Port:      o.DiagnosticsPort,
+			Router:    server.NewDiagnosticsRouter(),
+			Security:  o.HTTPServer,
+			AccessLog: o.HTTPAccessLog,
+		}
+		if err := srv.Start(ctx); err != nil {
+			o.Logger().Warnf("Diagnostics webserver returned an error: %s", err)
+		}
+	}()
+	return nil
+}
+
 func (o *Options) InitApplicationRegistry(forceInitialization bool) error {
 	if forceInitialization || o.InitRegistry {
 		dbConnFact, err := db.NewConnectionFactory(viper.ConfigFileUsed(), o.Migrate, o.Verbose)
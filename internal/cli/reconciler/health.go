@@ -0,0 +1,25 @@
+package reconciler
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type HealthConfig struct {
+	Timeout        time.Duration
+	DisabledChecks []string
+	MothershipURL  string
+}
+
+func (c *HealthConfig) validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("health check timeout cannot be < 0")
+	}
+	if c.MothershipURL != "" {
+		if _, err := url.ParseRequestURI(c.MothershipURL); err != nil {
+			return fmt.Errorf("mothership URL '%s' used for health checks is invalid: %s", c.MothershipURL, err)
+		}
+	}
+	return nil
+}
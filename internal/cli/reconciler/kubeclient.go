@@ -0,0 +1,33 @@
+package reconciler
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+)
+
+type KubeClientConfig struct {
+	QPS         float64  //0 = client-go default
+	Burst       int      //0 = client-go default
+	Adaptive    bool     //reduce the rate temporarily when the API server throttles requests
+	SizeClasses []string //limits of clusters with many components ('<min components>:<qps>/<burst>')
+}
+
+func (c *KubeClientConfig) validate() error {
+	if c.QPS < 0 {
+		return fmt.Errorf("kube-client-qps cannot be < 0")
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("kube-client-burst cannot be < 0")
+	}
+	_, err := kubernetes.ParseSizeClassLimits(c.SizeClasses)
+	return err
+}
+
+func (c *KubeClientConfig) limits() (kubernetes.ClientLimits, []kubernetes.SizeClassLimits, error) {
+	sizeClasses, err := kubernetes.ParseSizeClassLimits(c.SizeClasses)
+	if err != nil {
+		return kubernetes.ClientLimits{}, nil, err
+	}
+	return kubernetes.ClientLimits{QPS: float32(c.QPS), Burst: c.Burst, Adaptive: c.Adaptive}, sizeClasses, nil
+}
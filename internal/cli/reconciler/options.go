@@ -1,7 +1,10 @@
 package reconciler
 
 import (
+	"fmt"
+
 	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
 )
 
 type Options struct {
@@ -12,7 +15,18 @@ type Options struct {
 	RetryConfig           *RetryConfig
 	HeartbeatSenderConfig *RecurringTaskConfig
 	ProgressTrackerConfig *RecurringTaskConfig
+	HealthConfig          *HealthConfig
+	QueueConfig           *QueueConfig
 	DryRun                bool
+	Components            []string //component reconcilers which can be started (all registered if empty)
+	ReadyThreshold        string   //replicas of Deployments and DaemonSets which have to be ready (e.g. '90%')
+	StallWarnings         []int    //percentages of the progress timeout after which pending resources are reported
+	TrackAllResources     bool     //await also resources which a deployment didn't change
+	InformerCache         bool     //read watched resources from informers shared by the operations on a cluster
+	StreamedComponents    []string //components whose large manifests are applied in batches of resources
+	StreamThresholdMiB    int      //size of the manifests which are applied in batches
+	KubeClientConfig      *KubeClientConfig
+	SandboxConfig         *SandboxConfig
 }
 
 func NewOptions(o *cli.Options) *Options {
@@ -24,7 +38,18 @@ func NewOptions(o *cli.Options) *Options {
 		&RetryConfig{},
 		&RecurringTaskConfig{},
 		&RecurringTaskConfig{},
+		&HealthConfig{},
+		&QueueConfig{},
+		false,
+		nil,
+		"",
+		nil,
 		false,
+		false,
+		nil,
+		0,
+		&KubeClientConfig{},
+		&SandboxConfig{},
 	}
 }
 
@@ -35,6 +60,9 @@ func (o *Options) Validate() error {
 	if err := o.ServerConfig.validate(); err != nil {
 		return err
 	}
+	if err := o.HTTPServer.Validate(); err != nil {
+		return err
+	}
 	if err := o.WorkerConfig.validate(); err != nil {
 		return err
 	}
@@ -44,5 +72,30 @@ func (o *Options) Validate() error {
 	if err := o.HeartbeatSenderConfig.validate(); err != nil {
 		return err
 	}
+	if err := o.HealthConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.QueueConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.KubeClientConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.SandboxConfig.validate(); err != nil {
+		return err
+	}
+	if o.ReadyThreshold != "" {
+		if _, err := progress.ParseReadyThreshold(o.ReadyThreshold); err != nil {
+			return err
+		}
+	}
+	if len(o.StreamedComponents) > 0 && o.StreamThresholdMiB <= 0 {
+		return fmt.Errorf("stream threshold has to be > 0 MiB (got %d)", o.StreamThresholdMiB)
+	}
+	for _, percentage := range o.StallWarnings {
+		if percentage <= 0 || percentage >= 100 {
+			return fmt.Errorf("progress stall warnings have to be between 1 and 99 percent (got %d)", percentage)
+		}
+	}
 	return o.ProgressTrackerConfig.validate()
 }
@@ -0,0 +1,36 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+)
+
+type QueueConfig struct {
+	DBConfigFile  string //tasks are queued in the database configured in this file (empty = tasks aren't queued)
+	PollInterval  time.Duration
+	LeaseDuration time.Duration
+	MaxAttempts   int
+}
+
+func (c *QueueConfig) Enabled() bool {
+	return c.DBConfigFile != ""
+}
+
+func (c *QueueConfig) validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.PollInterval <= 0 {
+		return fmt.Errorf("queue poll interval cannot be <= 0")
+	}
+	if c.LeaseDuration <= 0 {
+		return fmt.Errorf("queue lease duration cannot be <= 0")
+	}
+	if c.LeaseDuration < 3*c.PollInterval {
+		return fmt.Errorf("queue lease duration has to be at least 3 × poll interval")
+	}
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts of queued tasks cannot be set to < 0")
+	}
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 )
 
 type RetryConfig struct {
-	MaxRetries int
-	RetryDelay time.Duration
+	MaxRetries    int
+	RetryDelay    time.Duration //initial delay which grows exponentially with each retry
+	MaxRetryDelay time.Duration //0 = default of the component reconciler
+	RetryBudget   time.Duration //overall time retries of a reconciliation are allowed to take (0 = unlimited)
 }
 
 func (c *RetryConfig) validate() error {
@@ -17,5 +19,14 @@ func (c *RetryConfig) validate() error {
 	if c.RetryDelay <= 0 {
 		return fmt.Errorf("retry-delay cannot be <= 0")
 	}
+	if c.MaxRetryDelay < 0 {
+		return fmt.Errorf("max-retry-delay cannot be < 0")
+	}
+	if c.MaxRetryDelay > 0 && c.MaxRetryDelay < c.RetryDelay {
+		return fmt.Errorf("max-retry-delay cannot be < retry-delay")
+	}
+	if c.RetryBudget < 0 {
+		return fmt.Errorf("retry-budget cannot be < 0")
+	}
 	return nil
 }
@@ -0,0 +1,34 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/sandbox"
+)
+
+type SandboxConfig struct {
+	Dir          string        //directory for the working directories of the processes (empty = temp dir)
+	EnvAllowlist []string      //env vars of the reconciler passed to the processes
+	Timeout      time.Duration //maximal runtime of a process
+	OutputLimit  int           //maximal bytes of stdout and stderr captured per process
+}
+
+func (c *SandboxConfig) validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("sandbox-timeout cannot be < 0")
+	}
+	if c.OutputLimit < 0 {
+		return fmt.Errorf("sandbox-output-limit cannot be < 0")
+	}
+	return nil
+}
+
+func (c *SandboxConfig) config() *sandbox.Config {
+	return &sandbox.Config{
+		BaseDir:      c.Dir,
+		EnvAllowlist: c.EnvAllowlist,
+		Timeout:      c.Timeout,
+		OutputLimit:  c.OutputLimit,
+	}
+}
@@ -3,17 +3,22 @@ package reconciler
 import (
 	"fmt"
 	"github.com/kyma-incubator/reconciler/pkg/ssl"
+	"time"
 )
 
 type ServerConfig struct {
-	Port       int
-	SSLCrtFile string
-	SSLKeyFile string
+	Port                 int
+	SSLCrtFile           string
+	SSLKeyFile           string
+	IdempotencyRetention time.Duration //responses of requests with idempotency key are replayed for duplicates
 }
 
 func (c *ServerConfig) validate() error {
 	if c.Port <= 0 || c.Port > 65535 {
 		return fmt.Errorf("port %d is out of range 1-65535", c.Port)
 	}
+	if c.IdempotencyRetention < 0 {
+		return fmt.Errorf("idempotency retention cannot be < 0")
+	}
 	return ssl.VerifyKeyPair(c.SSLCrtFile, c.SSLKeyFile)
 }
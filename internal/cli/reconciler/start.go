@@ -2,6 +2,7 @@ package reconciler
 
 import (
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 )
 
@@ -18,12 +19,40 @@ func NewComponentReconciler(o *Options, reconcilerName string, reconcilerMetrics
 	recon.WithWorkspace(o.Workspace).
 		//configure reconciliation worker pool + retry-behaviour
 		WithWorkers(o.WorkerConfig.Workers, o.WorkerConfig.Timeout).
+		WithStuckWorkerFactor(o.WorkerConfig.StuckFactor).
+		WithMemoryBudget(int64(o.WorkerConfig.MemoryMiB)<<20).
 		WithRetryDelay(o.RetryConfig.RetryDelay).
+		WithRetryBackoff(o.RetryConfig.MaxRetryDelay).
+		WithRetryBudget(o.RetryConfig.RetryBudget).
 		//configure status updates send to mothership reconciler
 		WithHeartbeatSenderConfig(o.HeartbeatSenderConfig.Interval, o.HeartbeatSenderConfig.Timeout).
 		//configure reconciliation progress-checks applied on target K8s cluster
 		WithProgressTrackerConfig(o.ProgressTrackerConfig.Interval, o.ProgressTrackerConfig.Timeout).
+		WithProgressStallWarnings(o.StallWarnings...).
+		WithProgressTrackingOfUnchangedResources(o.TrackAllResources).
+		WithInformerCache(o.InformerCache).
+		WithSandbox(o.SandboxConfig.config()).
 		WithReconcilerMetricsSet(reconcilerMetricsSet)
 
+	clientLimits, sizeClasses, err := o.KubeClientConfig.limits()
+	if err != nil {
+		return nil, err
+	}
+	recon.WithKubeClientLimits(clientLimits, sizeClasses...)
+
+	for _, component := range o.StreamedComponents {
+		if component == reconcilerName {
+			recon.WithStreamedDeployment(o.StreamThresholdMiB << 20)
+		}
+	}
+
+	if o.ReadyThreshold != "" {
+		readyThreshold, err := progress.ParseReadyThreshold(o.ReadyThreshold)
+		if err != nil {
+			return nil, err
+		}
+		recon.WithReadyThreshold(readyThreshold)
+	}
+
 	return recon, nil
 }
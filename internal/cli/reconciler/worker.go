@@ -6,8 +6,10 @@ import (
 )
 
 type WorkerConfig struct {
-	Workers int
-	Timeout time.Duration
+	Workers     int
+	Timeout     time.Duration
+	StuckFactor int
+	MemoryMiB   int //memory budget of all running operations (0 = unlimited)
 }
 
 func (c *WorkerConfig) validate() error {
@@ -17,5 +19,11 @@ func (c *WorkerConfig) validate() error {
 	if c.Timeout <= 0 {
 		return fmt.Errorf("timeout for workers cannot be set to < 0")
 	}
+	if c.StuckFactor < 0 {
+		return fmt.Errorf("stuck factor for workers cannot be set to < 0")
+	}
+	if c.MemoryMiB < 0 {
+		return fmt.Errorf("memory budget for workers cannot be set to < 0")
+	}
 	return nil
 }
@@ -0,0 +1,23 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+func ConvertComponentClusters(installations []*cluster.ComponentInstallation) keb.ComponentClustersOKResponse {
+	result := keb.ComponentClustersOKResponse{}
+	for _, installation := range installations {
+		componentCluster := keb.ComponentCluster{
+			RuntimeID:     installation.State.Configuration.RuntimeID,
+			ConfigVersion: installation.State.Configuration.Version,
+			KymaVersion:   installation.State.Configuration.KymaVersion,
+			Version:       installation.Version,
+		}
+		if installation.State.Status != nil {
+			componentCluster.Status = string(installation.State.Status.Status)
+		}
+		result = append(result, componentCluster)
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+package converters_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertComponentClusters(t *testing.T) {
+	t.Run("Installations are converted", func(t *testing.T) {
+		output := converters.ConvertComponentClusters([]*cluster.ComponentInstallation{
+			{
+				State: &cluster.State{
+					Configuration: &model.ClusterConfigurationEntity{RuntimeID: "runtime1", Version: 3, KymaVersion: "2.4.0"},
+					Status:        &model.ClusterStatusEntity{Status: model.ClusterStatusReady},
+				},
+				Version: "1.9.2",
+			},
+		})
+		require.Equal(t, keb.ComponentClustersOKResponse{
+			{RuntimeID: "runtime1", ConfigVersion: 3, KymaVersion: "2.4.0", Version: "1.9.2", Status: string(model.ClusterStatusReady)},
+		}, output)
+	})
+
+	t.Run("No installations lead to an empty list", func(t *testing.T) {
+		output := converters.ConvertComponentClusters(nil)
+		require.NotNil(t, output)
+		require.Empty(t, output)
+	})
+}
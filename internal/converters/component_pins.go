@@ -0,0 +1,22 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertComponentPin(pin *model.ComponentPinEntity) keb.ComponentPin {
+	return keb.ComponentPin{
+		Component: pin.Component,
+		Version:   pin.Version,
+		Created:   pin.Created,
+	}
+}
+
+func ConvertComponentPins(pins []*model.ComponentPinEntity) keb.HTTPComponentPins {
+	result := keb.HTTPComponentPins{}
+	for _, pin := range pins {
+		result = append(result, ConvertComponentPin(pin))
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertComponentPins(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+
+	t.Run("Pins are converted", func(t *testing.T) {
+		output := converters.ConvertComponentPins([]*model.ComponentPinEntity{
+			{RuntimeID: "abc", Component: "istio", Version: "1.2.3", Created: created},
+			{RuntimeID: "abc", Component: "serverless", Version: "~2.0", Created: created},
+		})
+		require.Equal(t, keb.HTTPComponentPins{
+			{Component: "istio", Version: "1.2.3", Created: created},
+			{Component: "serverless", Version: "~2.0", Created: created},
+		}, output)
+	})
+
+	t.Run("No pins result in an empty list", func(t *testing.T) {
+		output := converters.ConvertComponentPins(nil)
+		require.NotNil(t, output)
+		require.Empty(t, output)
+	})
+}
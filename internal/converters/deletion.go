@@ -0,0 +1,21 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertDeletionConfirmation(confirmation *model.DeletionConfirmationEntity) keb.DeletionConfirmation {
+	return keb.DeletionConfirmation{
+		RuntimeID: confirmation.RuntimeID,
+		Token:     confirmation.Token,
+		Expires:   confirmation.Expires,
+	}
+}
+
+func ConvertDeletionProtection(protection *model.DeletionProtectionEntity) keb.DeletionProtection {
+	return keb.DeletionProtection{
+		RuntimeID: protection.RuntimeID,
+		Created:   protection.Created,
+	}
+}
@@ -0,0 +1,39 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertFeatureFlag(flag *model.FeatureFlagEntity) keb.FeatureFlag {
+	return keb.FeatureFlag{
+		Name:              flag.Name,
+		Description:       flag.Description,
+		RolloutPercentage: flag.RolloutPercentage,
+		EnabledClusters:   nonNilStrings(flag.EnabledClusters),
+		DisabledClusters:  nonNilStrings(flag.DisabledClusters),
+		Created:           flag.Created,
+	}
+}
+
+func ConvertFeatureFlags(flags []*model.FeatureFlagEntity) keb.HTTPFeatureFlags {
+	result := keb.HTTPFeatureFlags{}
+	for _, flag := range flags {
+		result = append(result, ConvertFeatureFlag(flag))
+	}
+	return result
+}
+
+func ConvertClusterFeatureFlags(runtimeID string, enabled []string) keb.HTTPClusterFeatureFlags {
+	return keb.HTTPClusterFeatureFlags{
+		RuntimeID:    runtimeID,
+		FeatureFlags: nonNilStrings(enabled),
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,44 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFeatureFlags(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+
+	t.Run("Feature flags are converted", func(t *testing.T) {
+		output := converters.ConvertFeatureFlags([]*model.FeatureFlagEntity{
+			{Name: "flag1", Description: "first", RolloutPercentage: 10, Created: created},
+			{Name: "flag2", EnabledClusters: []string{"runtime1"}, DisabledClusters: []string{"runtime2"}, Created: created},
+		})
+		require.Equal(t, keb.HTTPFeatureFlags{
+			{
+				Name:              "flag1",
+				Description:       "first",
+				RolloutPercentage: 10,
+				EnabledClusters:   []string{},
+				DisabledClusters:  []string{},
+				Created:           created,
+			},
+			{
+				Name:             "flag2",
+				EnabledClusters:  []string{"runtime1"},
+				DisabledClusters: []string{"runtime2"},
+				Created:          created,
+			},
+		}, output)
+	})
+
+	t.Run("No feature flags result in an empty list", func(t *testing.T) {
+		require.Equal(t, keb.HTTPFeatureFlags{}, converters.ConvertFeatureFlags(nil))
+		require.Equal(t, keb.HTTPClusterFeatureFlags{RuntimeID: "runtime1", FeatureFlags: []string{}},
+			converters.ConvertClusterFeatureFlags("runtime1", nil))
+	})
+}
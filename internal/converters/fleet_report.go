@@ -0,0 +1,29 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/report"
+)
+
+func ConvertFleetReport(fleetReport *report.FleetReport) keb.FleetReportOKResponse {
+	result := keb.FleetReportOKResponse{
+		From:                fleetReport.From,
+		To:                  fleetReport.To,
+		Reconciliations:     fleetReport.Reconciliations,
+		Succeeded:           fleetReport.Succeeded,
+		Failed:              fleetReport.Failed,
+		InProgress:          fleetReport.InProgress,
+		SuccessRate:         fleetReport.SuccessRate,
+		MeanTimeToReconcile: fleetReport.MeanTimeToReconcile.Milliseconds(),
+		TopFailures:         []keb.ComponentFailures{},
+	}
+	for _, failures := range fleetReport.TopFailures {
+		result.TopFailures = append(result.TopFailures, keb.ComponentFailures{
+			Component:    failures.Component,
+			Failures:     failures.Failures,
+			Clusters:     failures.Clusters,
+			LatestReason: failures.LatestReason,
+		})
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/report"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFleetReport(t *testing.T) {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("Report is converted", func(t *testing.T) {
+		output := converters.ConvertFleetReport(&report.FleetReport{
+			From:                from,
+			To:                  to,
+			Reconciliations:     5,
+			Succeeded:           3,
+			Failed:              1,
+			InProgress:          1,
+			SuccessRate:         75,
+			MeanTimeToReconcile: 90 * time.Second,
+			TopFailures: []*report.ComponentFailures{
+				{Component: "istio", Failures: 4, Clusters: 2, LatestReason: "timeout"},
+			},
+		})
+		require.Equal(t, keb.FleetReportOKResponse{
+			From:                from,
+			To:                  to,
+			Reconciliations:     5,
+			Succeeded:           3,
+			Failed:              1,
+			InProgress:          1,
+			SuccessRate:         75,
+			MeanTimeToReconcile: 90000,
+			TopFailures: []keb.ComponentFailures{
+				{Component: "istio", Failures: 4, Clusters: 2, LatestReason: "timeout"},
+			},
+		}, output)
+	})
+
+	t.Run("Report without failures has an empty top-list", func(t *testing.T) {
+		output := converters.ConvertFleetReport(&report.FleetReport{From: from, To: to})
+		require.NotNil(t, output.TopFailures)
+		require.Empty(t, output.TopFailures)
+	})
+}
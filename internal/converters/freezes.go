@@ -0,0 +1,35 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertFreeze(freeze *model.SchedulingFreezeEntity) keb.Freeze {
+	result := keb.Freeze{
+		Id:        freeze.ID,
+		Reason:    freeze.Reason,
+		EngagedBy: freeze.EngagedBy,
+		Released:  freeze.Released,
+		Created:   freeze.Created,
+	}
+	if freeze.Component != "" {
+		component := freeze.Component
+		result.Component = &component
+	}
+	if freeze.Released {
+		releasedBy := freeze.ReleasedBy
+		releasedAt := freeze.Updated
+		result.ReleasedBy = &releasedBy
+		result.ReleasedAt = &releasedAt
+	}
+	return result
+}
+
+func ConvertFreezes(freezes []*model.SchedulingFreezeEntity) keb.HTTPFreezes {
+	result := keb.HTTPFreezes{}
+	for _, freeze := range freezes {
+		result = append(result, ConvertFreeze(freeze))
+	}
+	return result
+}
@@ -0,0 +1,37 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFreezes(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+	released := time.Unix(2000, 0).UTC()
+	component := "istio"
+	releasedBy := "bob"
+
+	t.Run("Freezes are converted", func(t *testing.T) {
+		output := converters.ConvertFreezes([]*model.SchedulingFreezeEntity{
+			{ID: 2, Reason: "incident", EngagedBy: "alice", Updated: created, Created: created},
+			{ID: 1, Component: component, Reason: "broken release", EngagedBy: "alice", Released: true,
+				ReleasedBy: releasedBy, Updated: released, Created: created},
+		})
+		require.Equal(t, keb.HTTPFreezes{
+			{Id: 2, Reason: "incident", EngagedBy: "alice", Created: created},
+			{Id: 1, Component: &component, Reason: "broken release", EngagedBy: "alice", Released: true,
+				ReleasedBy: &releasedBy, ReleasedAt: &released, Created: created},
+		}, output)
+	})
+
+	t.Run("No freezes result in an empty list", func(t *testing.T) {
+		output := converters.ConvertFreezes(nil)
+		require.NotNil(t, output)
+		require.Empty(t, output)
+	})
+}
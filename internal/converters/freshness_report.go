@@ -0,0 +1,39 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/report"
+)
+
+// ConvertFreshnessReport converts the report of the freshness SLO (optionally limited to the violating clusters)
+func ConvertFreshnessReport(freshnessReport *report.FreshnessReport, violationsOnly bool) keb.FreshnessReportOKResponse {
+	result := keb.FreshnessReportOKResponse{
+		Objective:  freshnessReport.Objective.Milliseconds(),
+		Evaluated:  freshnessReport.Evaluated,
+		Clusters:   freshnessReport.Clusters,
+		Compliant:  freshnessReport.Compliant,
+		Compliance: freshnessReport.Compliance,
+		Excluded:   freshnessReport.Excluded,
+		Freshness:  []keb.ClusterFreshness{},
+	}
+	for _, freshness := range freshnessReport.Freshness {
+		if violationsOnly && freshness.Compliant {
+			continue
+		}
+		entry := keb.ClusterFreshness{
+			Cluster:   freshness.RuntimeID,
+			Status:    keb.Status(freshness.Status),
+			Compliant: freshness.Compliant,
+		}
+		if !freshness.LastSuccess.IsZero() {
+			lastSuccess := freshness.LastSuccess
+			entry.LastSuccess = &lastSuccess
+		}
+		if freshness.Reason != "" {
+			reason := freshness.Reason
+			entry.Reason = &reason
+		}
+		result.Freshness = append(result.Freshness, entry)
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/report"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertFreshnessReport(t *testing.T) {
+	evaluated := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastSuccess := evaluated.Add(-time.Hour)
+	reason := "no successful reconciliation within 6h0m0s: reconciliation is running since 7h0m0s"
+	freshnessReport := &report.FreshnessReport{
+		Objective:  6 * time.Hour,
+		Evaluated:  evaluated,
+		Clusters:   2,
+		Compliant:  1,
+		Compliance: 50,
+		Excluded:   1,
+		Freshness: []*report.ClusterFreshness{
+			{RuntimeID: "fresh", Status: model.ClusterStatusReady, LastSuccess: lastSuccess, Compliant: true},
+			{RuntimeID: "stale", Status: model.ClusterStatusReconciling, Reason: reason},
+		},
+	}
+
+	t.Run("Report is converted", func(t *testing.T) {
+		output := converters.ConvertFreshnessReport(freshnessReport, false)
+		require.Equal(t, keb.FreshnessReportOKResponse{
+			Objective:  (6 * time.Hour).Milliseconds(),
+			Evaluated:  evaluated,
+			Clusters:   2,
+			Compliant:  1,
+			Compliance: 50,
+			Excluded:   1,
+			Freshness: []keb.ClusterFreshness{
+				{Cluster: "fresh", Status: keb.StatusReady, LastSuccess: &lastSuccess, Compliant: true},
+				{Cluster: "stale", Status: keb.StatusReconciling, Reason: &reason},
+			},
+		}, output)
+	})
+
+	t.Run("Only violations are listed", func(t *testing.T) {
+		output := converters.ConvertFreshnessReport(freshnessReport, true)
+		require.Len(t, output.Freshness, 1)
+		require.Equal(t, "stale", output.Freshness[0].Cluster)
+		require.Equal(t, 1, output.Compliant)
+	})
+}
@@ -0,0 +1,25 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/estimation"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+func ConvertOperationETA(eta *estimation.ETA) *keb.OperationETA {
+	if eta == nil {
+		return nil
+	}
+	result := &keb.OperationETA{
+		SizeBucket:     keb.OperationETASizeBucket(eta.Bucket),
+		Samples:        eta.Samples,
+		MedianDuration: eta.Median.Milliseconds(),
+		P90Duration:    eta.P90.Milliseconds(),
+		Remaining:      eta.Remaining.Milliseconds(),
+		Overdue:        eta.Overdue,
+	}
+	if !eta.Completion.IsZero() {
+		completion := eta.Completion
+		result.Completion = &completion
+	}
+	return result
+}
@@ -0,0 +1,40 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/estimation"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationETA(t *testing.T) {
+	require.Nil(t, converters.ConvertOperationETA(nil))
+
+	distribution := estimation.Distribution{
+		Bucket:  estimation.SizeLarge,
+		Samples: 10,
+		Median:  12 * time.Minute,
+		P90:     20 * time.Minute,
+		Max:     25 * time.Minute,
+	}
+	require.Equal(t, &keb.OperationETA{
+		SizeBucket:     keb.OperationETASizeBucketLarge,
+		Samples:        10,
+		MedianDuration: 720000,
+		P90Duration:    1200000,
+		Remaining:      720000,
+	}, converters.ConvertOperationETA(&estimation.ETA{Distribution: distribution, Remaining: 12 * time.Minute}))
+
+	completion := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := converters.ConvertOperationETA(&estimation.ETA{
+		Distribution: distribution,
+		Completion:   completion,
+		Overdue:      true,
+	})
+	require.Equal(t, &completion, result.Completion)
+	require.Zero(t, result.Remaining)
+	require.True(t, result.Overdue)
+}
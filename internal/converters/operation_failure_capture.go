@@ -0,0 +1,26 @@
+package converters
+
+import (
+	"encoding/json"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
+)
+
+func ConvertOperationFailureCapture(capture *model.OperationFailureCaptureEntity) (keb.OperationFailureCaptureOKResponse, error) {
+	var failureCapture reconciler.FailureCapture
+	if err := json.Unmarshal([]byte(capture.Capture), &failureCapture); err != nil {
+		return keb.OperationFailureCaptureOKResponse{}, errors.Wrap(err, "failed to unmarshal failure capture")
+	}
+	return keb.OperationFailureCaptureOKResponse{
+		SchedulingID:  capture.SchedulingID,
+		CorrelationID: capture.CorrelationID,
+		Error:         failureCapture.Error,
+		Task:          failureCapture.Task,
+		Values:        failureCapture.Values,
+		ValuesError:   failureCapture.ValuesError,
+		Created:       failureCapture.Created,
+	}, nil
+}
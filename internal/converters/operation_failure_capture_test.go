@@ -0,0 +1,38 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationFailureCapture(t *testing.T) {
+	t.Run("Convert capture", func(t *testing.T) {
+		output, err := converters.ConvertOperationFailureCapture(&model.OperationFailureCaptureEntity{
+			SchedulingID:  "scheduling1",
+			CorrelationID: "correlation1",
+			Capture: `{"error":"deployment failed","created":"1970-01-01T00:16:40Z",` +
+				`"task":{"component":"istio","kubeconfig":"<redacted>"},"values":{"global":{"domainName":"example.com"}}}`,
+		})
+		require.NoError(t, err)
+		require.Equal(t, keb.OperationFailureCaptureOKResponse{
+			SchedulingID:  "scheduling1",
+			CorrelationID: "correlation1",
+			Error:         "deployment failed",
+			Task:          map[string]interface{}{"component": "istio", "kubeconfig": "<redacted>"},
+			Values: &map[string]interface{}{
+				"global": map[string]interface{}{"domainName": "example.com"},
+			},
+			Created: time.Unix(1000, 0).UTC(),
+		}, output)
+	})
+
+	t.Run("Invalid capture", func(t *testing.T) {
+		_, err := converters.ConvertOperationFailureCapture(&model.OperationFailureCaptureEntity{Capture: "not json"})
+		require.Error(t, err)
+	})
+}
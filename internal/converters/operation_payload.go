@@ -0,0 +1,17 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertOperationPayload(payload *model.OperationPayloadEntity) keb.OperationPayloadOKResponse {
+	return keb.OperationPayloadOKResponse{
+		SchedulingID:    payload.SchedulingID,
+		CorrelationID:   payload.CorrelationID,
+		Url:             payload.URL,
+		ContractVersion: int(payload.ContractVersion),
+		Payload:         payload.Payload,
+		Created:         payload.Created,
+	}
+}
@@ -0,0 +1,32 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationPayload(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+
+	output := converters.ConvertOperationPayload(&model.OperationPayloadEntity{
+		SchedulingID:    "scheduling1",
+		CorrelationID:   "correlation1",
+		URL:             "http://istio-reconciler/v2/run",
+		ContractVersion: 2,
+		Payload:         `{"component":"istio"}`,
+		Created:         created,
+	})
+	require.Equal(t, keb.OperationPayloadOKResponse{
+		SchedulingID:    "scheduling1",
+		CorrelationID:   "correlation1",
+		Url:             "http://istio-reconciler/v2/run",
+		ContractVersion: 2,
+		Payload:         `{"component":"istio"}`,
+		Created:         created,
+	}, output)
+}
@@ -0,0 +1,40 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertOperationResources(resources []*model.OperationResourceEntity) keb.OperationResourcesOKResponse {
+	result := keb.OperationResourcesOKResponse{
+		Resources: []keb.OperationResource{},
+	}
+	for _, resource := range resources {
+		converted := keb.OperationResource{
+			ApiVersion: resource.APIVersion,
+			Kind:       resource.Kind,
+			Namespace:  resource.Namespace,
+			Name:       resource.Name,
+			Outcome:    keb.OperationResourceOutcome(resource.Outcome),
+		}
+		if resource.Error != "" {
+			resourceErr := resource.Error
+			converted.Error = &resourceErr
+		}
+		result.Resources = append(result.Resources, converted)
+
+		switch converted.Outcome {
+		case keb.OperationResourceOutcomeCreated:
+			result.Summary.Created++
+		case keb.OperationResourceOutcomeUpdated:
+			result.Summary.Updated++
+		case keb.OperationResourceOutcomeUnchanged:
+			result.Summary.Unchanged++
+		case keb.OperationResourceOutcomeDeleted:
+			result.Summary.Deleted++
+		case keb.OperationResourceOutcomeFailed:
+			result.Summary.Failed++
+		}
+	}
+	return result
+}
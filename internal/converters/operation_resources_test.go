@@ -0,0 +1,39 @@
+package converters_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationResources(t *testing.T) {
+	t.Run("Resources are converted and summarized", func(t *testing.T) {
+		output := converters.ConvertOperationResources([]*model.OperationResourceEntity{
+			{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "kyma-system", Name: "app", Outcome: "updated"},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kyma-system", Name: "cm1", Outcome: "created"},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kyma-system", Name: "cm2", Outcome: "created"},
+			{APIVersion: "v1", Kind: "Secret", Namespace: "kyma-system", Name: "secret", Outcome: "failed", Error: "forbidden"},
+		})
+		forbidden := "forbidden"
+		require.Equal(t, keb.OperationResourceSummary{Created: 2, Updated: 1, Failed: 1}, output.Summary)
+		require.Len(t, output.Resources, 4)
+		require.Equal(t, keb.OperationResource{
+			ApiVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  "kyma-system",
+			Name:       "secret",
+			Outcome:    keb.OperationResourceOutcomeFailed,
+			Error:      &forbidden,
+		}, output.Resources[3])
+	})
+
+	t.Run("No resources lead to an empty list", func(t *testing.T) {
+		output := converters.ConvertOperationResources(nil)
+		require.NotNil(t, output.Resources)
+		require.Empty(t, output.Resources)
+		require.Equal(t, keb.OperationResourceSummary{}, output.Summary)
+	})
+}
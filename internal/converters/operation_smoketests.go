@@ -0,0 +1,23 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertOperationSmokeTests(smokeTests []*model.OperationSmokeTestEntity) keb.OperationSmokeTestsOKResponse {
+	result := keb.OperationSmokeTestsOKResponse{}
+	for _, smokeTest := range smokeTests {
+		converted := keb.SmokeTestResult{
+			Name:     smokeTest.Name,
+			Passed:   smokeTest.Passed,
+			Duration: smokeTest.Duration,
+		}
+		if smokeTest.Error != "" {
+			smokeTestErr := smokeTest.Error
+			converted.Error = &smokeTestErr
+		}
+		result = append(result, converted)
+	}
+	return result
+}
@@ -0,0 +1,30 @@
+package converters_test
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationSmokeTests(t *testing.T) {
+	t.Run("Results are converted", func(t *testing.T) {
+		output := converters.ConvertOperationSmokeTests([]*model.OperationSmokeTestEntity{
+			{Name: "job", Passed: true, Duration: 1500},
+			{Name: "probe", Passed: false, Error: "unexpected status code 503", Duration: 20},
+		})
+		probeErr := "unexpected status code 503"
+		require.Equal(t, keb.OperationSmokeTestsOKResponse{
+			{Name: "job", Passed: true, Duration: 1500},
+			{Name: "probe", Passed: false, Error: &probeErr, Duration: 20},
+		}, output)
+	})
+
+	t.Run("No results lead to an empty list", func(t *testing.T) {
+		output := converters.ConvertOperationSmokeTests(nil)
+		require.NotNil(t, output)
+		require.Empty(t, output)
+	})
+}
@@ -0,0 +1,48 @@
+package converters
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+// ConvertOperationTimeline returns the reached phases of an operation in processing order. The duration of a phase
+// is the time between the previous phase and the phase itself (phases which weren't reached are omitted).
+func ConvertOperationTimeline(operation *model.OperationEntity, phases []*model.OperationPhaseEntity) keb.OperationTimelineOKResponse {
+	reached := map[model.OperationPhase]time.Time{
+		model.OperationPhaseQueued:   operation.Created,
+		model.OperationPhasePickedUp: operation.PickedUp,
+	}
+	for _, phase := range phases {
+		reached[phase.Phase] = phase.Reached
+	}
+
+	resultPhases := []keb.OperationPhase{}
+	var previous time.Time
+	for _, phase := range model.OperationPhases() {
+		phaseReached, ok := reached[phase]
+		if !ok || phaseReached.IsZero() {
+			continue
+		}
+		var duration time.Duration
+		//timestamps are reported by different hosts: clock skews could lead to negative durations
+		if !previous.IsZero() && phaseReached.After(previous) {
+			duration = phaseReached.Sub(previous)
+		}
+		resultPhases = append(resultPhases, keb.OperationPhase{
+			Phase:    keb.OperationPhasePhase(phase),
+			Reached:  phaseReached,
+			Duration: duration.Milliseconds(),
+		})
+		previous = phaseReached
+	}
+
+	return keb.OperationTimelineOKResponse{
+		Component:     operation.Component,
+		CorrelationID: operation.CorrelationID,
+		SchedulingID:  operation.SchedulingID,
+		State:         string(operation.State),
+		Phases:        resultPhases,
+	}
+}
@@ -0,0 +1,55 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertOperationTimeline(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+	opEntInput := &model.OperationEntity{
+		SchedulingID:  "abcd",
+		CorrelationID: "zxcv",
+		Component:     "testComponent",
+		State:         model.OperationStateDone,
+		Created:       created,
+		PickedUp:      created.Add(2 * time.Second),
+	}
+
+	t.Run("Phases are ordered and contain durations", func(t *testing.T) {
+		//WHEN
+		output := converters.ConvertOperationTimeline(opEntInput, []*model.OperationPhaseEntity{
+			{Phase: model.OperationPhaseApplied, Reached: created.Add(10 * time.Second)},
+			{Phase: model.OperationPhaseRendered, Reached: created.Add(5 * time.Second)},
+			{Phase: model.OperationPhaseCallbackSent, Reached: created.Add(9 * time.Second)}, //clock skew
+		})
+
+		//THEN
+		require.Equal(t, opEntInput.SchedulingID, output.SchedulingID)
+		require.Equal(t, opEntInput.CorrelationID, output.CorrelationID)
+		require.Equal(t, opEntInput.Component, output.Component)
+		require.Equal(t, string(opEntInput.State), output.State)
+		require.Equal(t, []keb.OperationPhase{
+			{Phase: keb.OperationPhasePhaseQueued, Reached: created, Duration: 0},
+			{Phase: keb.OperationPhasePhasePickedUp, Reached: created.Add(2 * time.Second), Duration: 2000},
+			{Phase: keb.OperationPhasePhaseRendered, Reached: created.Add(5 * time.Second), Duration: 3000},
+			{Phase: keb.OperationPhasePhaseApplied, Reached: created.Add(10 * time.Second), Duration: 5000},
+			{Phase: keb.OperationPhasePhaseCallbackSent, Reached: created.Add(9 * time.Second), Duration: 0},
+		}, output.Phases)
+	})
+
+	t.Run("Operation which wasn't picked up yet", func(t *testing.T) {
+		//WHEN
+		output := converters.ConvertOperationTimeline(&model.OperationEntity{Created: created}, nil)
+
+		//THEN
+		require.Equal(t, []keb.OperationPhase{
+			{Phase: keb.OperationPhasePhaseQueued, Reached: created, Duration: 0},
+		}, output.Phases)
+	})
+}
@@ -0,0 +1,30 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertReconcileInterval(override *model.ReconcileIntervalEntity) keb.ReconcileInterval {
+	result := keb.ReconcileInterval{
+		IntervalSeconds: override.Seconds,
+		Created:         override.Created,
+	}
+	if override.RuntimeID != "" {
+		runtimeID := override.RuntimeID
+		result.RuntimeID = &runtimeID
+	}
+	if override.Component != "" {
+		component := override.Component
+		result.Component = &component
+	}
+	return result
+}
+
+func ConvertReconcileIntervals(overrides []*model.ReconcileIntervalEntity) keb.HTTPReconcileIntervals {
+	result := keb.HTTPReconcileIntervals{}
+	for _, override := range overrides {
+		result = append(result, ConvertReconcileInterval(override))
+	}
+	return result
+}
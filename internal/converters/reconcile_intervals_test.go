@@ -0,0 +1,36 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertReconcileIntervals(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+	runtimeID := "abc"
+	component := "istio"
+
+	t.Run("Reconcile intervals are converted", func(t *testing.T) {
+		output := converters.ConvertReconcileIntervals([]*model.ReconcileIntervalEntity{
+			{Component: component, Seconds: 86400, Created: created},
+			{RuntimeID: runtimeID, Seconds: 600, Created: created},
+			{RuntimeID: runtimeID, Component: component, Seconds: 3600, Created: created},
+		})
+		require.Equal(t, keb.HTTPReconcileIntervals{
+			{Component: &component, IntervalSeconds: 86400, Created: created},
+			{RuntimeID: &runtimeID, IntervalSeconds: 600, Created: created},
+			{RuntimeID: &runtimeID, Component: &component, IntervalSeconds: 3600, Created: created},
+		}, output)
+	})
+
+	t.Run("No reconcile intervals result in an empty list", func(t *testing.T) {
+		output := converters.ConvertReconcileIntervals(nil)
+		require.NotNil(t, output)
+		require.Empty(t, output)
+	})
+}
@@ -26,6 +26,9 @@ func ConvertReconciliation(reconciliation *model.ReconciliationEntity, operation
 		Updated:       reconciliation.Updated,
 		ConfigVersion: reconciliation.ClusterConfig,
 		Status:        resultStatus,
+		Initiator:     keb.Initiator(reconciliation.Initiator),
+		Reason:        reconciliation.Reason,
+		Phase:         keb.HTTPReconciliationInfoPhase(model.NewReconciliationPhase(operations)),
 		Operations:    resultOperations,
 	}
 
@@ -46,5 +49,6 @@ func ConvertOperation(operation *model.OperationEntity) keb.Operation {
 		State:         string(operation.State),
 		Updated:       operation.Updated,
 		Type:          string(operation.Type),
+		Bootstrap:     operation.Bootstrap,
 	}
 }
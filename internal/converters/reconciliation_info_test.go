@@ -22,6 +22,8 @@ func TestConvertReconciliationStatus(t *testing.T) {
 		Created:             time.Unix(0, 10),
 		Updated:             time.Unix(10, 100),
 		Status:              model.ClusterStatusReconcileDisabled,
+		Initiator:           model.InitiatorKEB,
+		Reason:              model.ReasonVersionRollout,
 	}
 
 	opEntInput := &model.OperationEntity{
@@ -70,6 +72,59 @@ func TestConvertReconciliationStatus(t *testing.T) {
 
 }
 
+func TestConvertReconciliationPhase(t *testing.T) {
+	reconEntInput := &model.ReconciliationEntity{
+		RuntimeID:    "runtime",
+		SchedulingID: "1234",
+		Status:       model.ClusterStatusReconciling,
+	}
+	newOp := func(component string, bootstrap bool, state model.OperationState) *model.OperationEntity {
+		return &model.OperationEntity{Component: component, Bootstrap: bootstrap, State: state}
+	}
+
+	testCases := map[string]struct {
+		opEntInput []*model.OperationEntity
+		expected   keb.HTTPReconciliationInfoPhase
+	}{
+		"no bootstrap components": {
+			opEntInput: []*model.OperationEntity{newOp("comp", false, model.OperationStateNew)},
+			expected:   keb.HTTPReconciliationInfoPhaseComponents,
+		},
+		"bootstrap components in progress": {
+			opEntInput: []*model.OperationEntity{
+				newOp("crds", true, model.OperationStateDone),
+				newOp("cert-manager", true, model.OperationStateInProgress),
+				newOp("comp", false, model.OperationStateNew),
+			},
+			expected: keb.HTTPReconciliationInfoPhaseBootstrap,
+		},
+		"bootstrap component failed": {
+			opEntInput: []*model.OperationEntity{
+				newOp("crds", true, model.OperationStateInProgress),
+				newOp("cert-manager", true, model.OperationStateError),
+				newOp("comp", false, model.OperationStateNew),
+			},
+			expected: keb.HTTPReconciliationInfoPhaseBootstrapFailed,
+		},
+		"bootstrap components done": {
+			opEntInput: []*model.OperationEntity{
+				newOp("cert-manager", true, model.OperationStateDone),
+				newOp("comp", false, model.OperationStateError),
+			},
+			expected: keb.HTTPReconciliationInfoPhaseComponents,
+		},
+	}
+
+	for name, testCase := range testCases {
+		tc := testCase
+		t.Run(name, func(t *testing.T) {
+			output, err := converters.ConvertReconciliation(reconEntInput, tc.opEntInput)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, output.Phase)
+		})
+	}
+}
+
 func assertReconciliation(t *testing.T, input *model.ReconciliationEntity, output keb.ReconciliationInfoOKResponse) {
 	assert.Equal(t, input.RuntimeID, output.RuntimeID)
 	assert.Equal(t, input.ClusterConfig, output.ConfigVersion)
@@ -77,6 +132,8 @@ func assertReconciliation(t *testing.T, input *model.ReconciliationEntity, outpu
 	assert.Equal(t, input.SchedulingID, output.SchedulingID)
 	assert.Equal(t, input.Created, output.Created)
 	assert.Equal(t, input.Updated, output.Updated)
+	assert.Equal(t, string(input.Initiator), string(output.Initiator))
+	assert.Equal(t, input.Reason, output.Reason)
 }
 
 func assertOperation(t *testing.T, input *model.OperationEntity, output keb.Operation) {
@@ -88,4 +145,5 @@ func assertOperation(t *testing.T, input *model.OperationEntity, output keb.Oper
 	assert.Equal(t, input.SchedulingID, output.SchedulingID)
 	assert.Equal(t, string(input.State), output.State)
 	assert.Equal(t, input.Updated, output.Updated)
+	assert.Equal(t, input.Bootstrap, output.Bootstrap)
 }
@@ -0,0 +1,23 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertScheduledReconciliation(schedule *model.ScheduledReconciliationEntity) keb.ScheduledReconciliation {
+	return keb.ScheduledReconciliation{
+		Id:        schedule.ID,
+		RuntimeID: schedule.RuntimeID,
+		NotBefore: schedule.NotBefore,
+		Created:   schedule.Created,
+	}
+}
+
+func ConvertScheduledReconciliations(schedules []*model.ScheduledReconciliationEntity) keb.HTTPScheduledReconciliations {
+	result := keb.HTTPScheduledReconciliations{}
+	for _, schedule := range schedules {
+		result = append(result, ConvertScheduledReconciliation(schedule))
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertScheduledReconciliations(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+	notBefore := created.Add(2 * time.Hour)
+
+	t.Run("Scheduled reconciliations are converted", func(t *testing.T) {
+		output := converters.ConvertScheduledReconciliations([]*model.ScheduledReconciliationEntity{
+			{ID: "id1", RuntimeID: "abc", NotBefore: notBefore, Created: created},
+		})
+		require.Equal(t, keb.HTTPScheduledReconciliations{
+			{Id: "id1", RuntimeID: "abc", NotBefore: notBefore, Created: created},
+		}, output)
+	})
+
+	t.Run("No scheduled reconciliations result in an empty list", func(t *testing.T) {
+		output := converters.ConvertScheduledReconciliations(nil)
+		require.NotNil(t, output)
+		require.Empty(t, output)
+	})
+}
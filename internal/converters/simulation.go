@@ -0,0 +1,65 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/simulation"
+)
+
+func ConvertSimulationPlan(plan *simulation.Plan) keb.HTTPSimulationPlan {
+	result := keb.HTTPSimulationPlan{
+		KymaVersion:       plan.KymaVersion,
+		Operations:        plan.Operations,
+		EstimatedDuration: plan.EstimatedDuration.Milliseconds(),
+		Clusters:          []keb.SimulatedCluster{},
+		Batches:           []keb.SimulatedBatch{},
+	}
+	for _, clusterPlan := range plan.Clusters {
+		result.Clusters = append(result.Clusters, convertSimulatedCluster(clusterPlan))
+	}
+	for _, batch := range plan.Batches {
+		result.Batches = append(result.Batches, keb.SimulatedBatch{
+			RuntimeIDs:        batch.RuntimeIDs,
+			Operations:        batch.Operations,
+			Start:             batch.Start.Milliseconds(),
+			EstimatedDuration: batch.EstimatedDuration.Milliseconds(),
+		})
+	}
+	return result
+}
+
+func convertSimulatedCluster(clusterPlan *simulation.ClusterPlan) keb.SimulatedCluster {
+	result := keb.SimulatedCluster{
+		RuntimeID:         clusterPlan.RuntimeID,
+		CurrentVersion:    clusterPlan.CurrentVersion,
+		Operations:        clusterPlan.Operations,
+		EstimatedDuration: clusterPlan.EstimatedDuration.Milliseconds(),
+		Groups:            []keb.SimulatedOperationGroup{},
+	}
+	if clusterPlan.Error != "" {
+		reason := clusterPlan.Error
+		result.Error = &reason
+	}
+	for _, group := range clusterPlan.Groups {
+		simulatedGroup := keb.SimulatedOperationGroup{
+			Priority:          group.Priority,
+			Bootstrap:         group.Bootstrap,
+			EstimatedDuration: group.EstimatedDuration.Milliseconds(),
+			Operations:        []keb.SimulatedOperation{},
+		}
+		for _, op := range group.Operations {
+			simulatedOp := keb.SimulatedOperation{
+				Component:         op.Component,
+				Version:           op.Version,
+				EstimatedDuration: op.EstimatedDuration.Milliseconds(),
+				Historical:        op.Historical,
+			}
+			if op.CurrentVersion != "" {
+				currentVersion := op.CurrentVersion
+				simulatedOp.CurrentVersion = &currentVersion
+			}
+			simulatedGroup.Operations = append(simulatedGroup.Operations, simulatedOp)
+		}
+		result.Groups = append(result.Groups, simulatedGroup)
+	}
+	return result
+}
@@ -0,0 +1,79 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/simulation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSimulationPlan(t *testing.T) {
+	currentVersion := "1.0.0"
+	reason := "pin violated"
+
+	output := converters.ConvertSimulationPlan(&simulation.Plan{
+		KymaVersion: "2.0.0",
+		Clusters: []*simulation.ClusterPlan{
+			{
+				RuntimeID:      "runtime1",
+				CurrentVersion: currentVersion,
+				Groups: []*simulation.Group{
+					{
+						Priority:  1,
+						Bootstrap: true,
+						Operations: []*simulation.Operation{
+							{Component: "istio", Version: "2.0.0", CurrentVersion: currentVersion, EstimatedDuration: time.Minute, Historical: true},
+							{Component: "CRDs", Version: "2.0.0", EstimatedDuration: time.Second},
+						},
+						EstimatedDuration: time.Minute,
+					},
+				},
+				Operations:        2,
+				EstimatedDuration: time.Minute,
+			},
+			{RuntimeID: "runtime2", CurrentVersion: currentVersion, Error: reason},
+		},
+		Batches: []*simulation.Batch{
+			{RuntimeIDs: []string{"runtime1"}, Operations: 2, EstimatedDuration: time.Minute},
+		},
+		Operations:        2,
+		EstimatedDuration: time.Minute,
+	})
+
+	require.Equal(t, keb.HTTPSimulationPlan{
+		KymaVersion:       "2.0.0",
+		Operations:        2,
+		EstimatedDuration: 60000,
+		Clusters: []keb.SimulatedCluster{
+			{
+				RuntimeID:         "runtime1",
+				CurrentVersion:    currentVersion,
+				Operations:        2,
+				EstimatedDuration: 60000,
+				Groups: []keb.SimulatedOperationGroup{
+					{
+						Priority:          1,
+						Bootstrap:         true,
+						EstimatedDuration: 60000,
+						Operations: []keb.SimulatedOperation{
+							{Component: "istio", Version: "2.0.0", CurrentVersion: &currentVersion, EstimatedDuration: 60000, Historical: true},
+							{Component: "CRDs", Version: "2.0.0", EstimatedDuration: 1000},
+						},
+					},
+				},
+			},
+			{
+				RuntimeID:      "runtime2",
+				CurrentVersion: currentVersion,
+				Error:          &reason,
+				Groups:         []keb.SimulatedOperationGroup{},
+			},
+		},
+		Batches: []keb.SimulatedBatch{
+			{RuntimeIDs: []string{"runtime1"}, Operations: 2, EstimatedDuration: 60000},
+		},
+	}, output)
+}
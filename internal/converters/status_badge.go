@@ -0,0 +1,14 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+func ConvertStatusBadge(badge *cluster.StatusBadge) keb.HTTPStatusBadge {
+	return keb.HTTPStatusBadge{
+		RuntimeID:               badge.RuntimeID,
+		Status:                  keb.BadgeStatus(badge.Status),
+		LastSuccessfulReconcile: badge.LastSuccessfulReconcile,
+	}
+}
@@ -0,0 +1,36 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertStatusBadge(t *testing.T) {
+	t.Run("Status badge is converted", func(t *testing.T) {
+		lastReady := time.Unix(1000, 0).UTC()
+		output := converters.ConvertStatusBadge(&cluster.StatusBadge{
+			RuntimeID:               "runtime1",
+			Status:                  cluster.BadgeStatusGreen,
+			LastSuccessfulReconcile: &lastReady,
+		})
+		require.Equal(t, keb.HTTPStatusBadge{
+			RuntimeID:               "runtime1",
+			Status:                  keb.BadgeStatusGreen,
+			LastSuccessfulReconcile: &lastReady,
+		}, output)
+	})
+
+	t.Run("Never reconciled cluster has no last successful reconciliation", func(t *testing.T) {
+		output := converters.ConvertStatusBadge(&cluster.StatusBadge{
+			RuntimeID: "runtime1",
+			Status:    cluster.BadgeStatusYellow,
+		})
+		require.Nil(t, output.LastSuccessfulReconcile)
+		require.Equal(t, keb.BadgeStatusYellow, output.Status)
+	})
+}
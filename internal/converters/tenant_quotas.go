@@ -0,0 +1,23 @@
+package converters
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+func ConvertTenantQuota(quota *model.TenantQuotaEntity) keb.TenantQuota {
+	return keb.TenantQuota{
+		GlobalAccountID:        quota.GlobalAccountID,
+		MaxParallelOperations:  quota.MaxParallelOperations,
+		MaxOperationsPerMinute: quota.MaxOperationsPerMinute,
+		Created:                quota.Created,
+	}
+}
+
+func ConvertTenantQuotas(quotas []*model.TenantQuotaEntity) keb.HTTPTenantQuotas {
+	result := keb.HTTPTenantQuotas{}
+	for _, quota := range quotas {
+		result = append(result, ConvertTenantQuota(quota))
+	}
+	return result
+}
@@ -0,0 +1,32 @@
+package converters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertTenantQuotas(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+
+	t.Run("Tenant quotas are converted", func(t *testing.T) {
+		output := converters.ConvertTenantQuotas([]*model.TenantQuotaEntity{
+			{GlobalAccountID: "tenant1", MaxParallelOperations: 10, Created: created},
+			{GlobalAccountID: "tenant2", MaxParallelOperations: 5, MaxOperationsPerMinute: 20, Created: created},
+		})
+		require.Equal(t, keb.HTTPTenantQuotas{
+			{GlobalAccountID: "tenant1", MaxParallelOperations: 10, Created: created},
+			{GlobalAccountID: "tenant2", MaxParallelOperations: 5, MaxOperationsPerMinute: 20, Created: created},
+		}, output)
+	})
+
+	t.Run("No tenant quotas result in an empty list", func(t *testing.T) {
+		output := converters.ConvertTenantQuotas(nil)
+		require.NotNil(t, output)
+		require.Empty(t, output)
+	})
+}
@@ -1,25 +1,59 @@
 package persistency
 
 import (
+	"io"
+	"sync"
+
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/features"
 	"github.com/kyma-incubator/reconciler/pkg/kv"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/redis"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/occupancy"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"go.uber.org/zap"
 )
 
+// ClusterStateObserver is notified about each state update of a cluster in the inventory
+type ClusterStateObserver interface {
+	OnClusterStateUpdate(state *cluster.State) error
+}
+
+// clusterStateObservers forwards the state updates of clusters to all registered observers
+type clusterStateObservers struct {
+	sync.RWMutex
+	observers []ClusterStateObserver
+}
+
+func (o *clusterStateObservers) add(observer ClusterStateObserver) {
+	o.Lock()
+	defer o.Unlock()
+	o.observers = append(o.observers, observer)
+}
+
+func (o *clusterStateObservers) OnClusterStateUpdate(state *cluster.State) error {
+	o.RLock()
+	defer o.RUnlock()
+	for _, observer := range o.observers {
+		if err := observer.OnClusterStateUpdate(state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type Registry struct {
 	debug           bool
 	logger          *zap.SugaredLogger
 	connection      db.Connection
 	inventory       cluster.Inventory
+	stateObservers  *clusterStateObservers
 	kvRepository    *kv.Repository
 	reconRepository reconciliation.Repository
 	occupancyRepo   occupancy.Repository
+	closers         []io.Closer
 	initialized     bool
 }
 
@@ -64,6 +98,11 @@ func (or *Registry) Close() error {
 	if !or.initialized {
 		return nil
 	}
+	for _, closer := range or.closers {
+		if err := closer.Close(); err != nil {
+			or.logger.Warnf("Failed to close client: %s", err)
+		}
+	}
 	return or.connection.Close()
 }
 
@@ -75,6 +114,11 @@ func (or *Registry) Inventory() cluster.Inventory {
 	return or.inventory
 }
 
+// AddClusterStateObserver registers an observer which is notified about state updates of clusters by the inventory
+func (or *Registry) AddClusterStateObserver(observer ClusterStateObserver) {
+	or.stateObservers.add(observer)
+}
+
 func (or *Registry) KVRepository() *kv.Repository {
 	return or.kvRepository
 }
@@ -96,8 +140,9 @@ func (or *Registry) initRepository() (*kv.Repository, error) {
 }
 
 func (or *Registry) initInventory() (cluster.Inventory, error) {
-	collector := metrics.NewReconciliationStatusCollector(or.logger)
-	inventory, err := cluster.NewInventory(or.connection, or.debug, collector)
+	or.stateObservers = &clusterStateObservers{}
+	or.stateObservers.add(metrics.NewReconciliationStatusCollector(or.logger))
+	inventory, err := cluster.NewInventory(or.connection, or.debug, or.stateObservers)
 	if err != nil {
 		or.logger.Errorf("Failed to create cluster inventory: %s", err)
 	}
@@ -116,6 +161,20 @@ func (or *Registry) initOccupancyRepository() (occupancy.Repository, error) {
 	if !features.Enabled(features.WorkerpoolOccupancyTracking) {
 		return occupancy.CreateMockRepository(), nil
 	}
+	redisConfig, err := redis.NewConfig("") //configuration file was already read by the connection factory
+	if err != nil {
+		return nil, err
+	}
+	if redisConfig.Enabled() && redisConfig.Occupancy {
+		or.logger.Infof("Tracking worker pool occupancies in redis '%s'", redisConfig.Address)
+		redisClient, err := redis.NewClient(redisConfig)
+		if err != nil {
+			or.logger.Errorf("Failed to connect to redis: %s", err)
+			return nil, err
+		}
+		or.closers = append(or.closers, redisClient)
+		return occupancy.NewRedisOccupancyRepository(redisClient, or.debug)
+	}
 	occupancyRepo, err := occupancy.NewPersistentOccupancyRepository(or.connection, or.debug)
 	if err != nil {
 		or.logger.Errorf("Failed to create occupancy repository: %s", err)
@@ -0,0 +1,15 @@
+// Package openapi embeds the OpenAPI specs of the HTTP APIs so that the servers can publish them.
+package openapi
+
+import _ "embed"
+
+var (
+	//go:embed external_api.yaml
+	ExternalAPI []byte
+
+	//go:embed internal_api.yaml
+	InternalAPI []byte
+
+	//go:embed reconciler_api.yaml
+	ReconcilerAPI []byte
+)
@@ -0,0 +1,321 @@
+package anomaly
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"go.uber.org/zap"
+)
+
+type Type string
+
+const (
+	TypeDuration    Type = "duration"
+	TypeFailureRate Type = "failure_rate"
+)
+
+// Alert reports a component which behaves anomalous fleet-wide compared to its baseline
+type Alert struct {
+	Type      Type      `json:"type"`
+	Component string    `json:"component"`
+	Detected  time.Time `json:"detected"`
+	Message   string    `json:"message"`
+	//median duration in seconds (duration alerts) or failure rate between 0 and 1 (failure rate alerts)
+	Baseline         float64  `json:"baseline"`
+	Observed         float64  `json:"observed"`
+	Operations       int      `json:"operations"` //finished operations within the detection window
+	SuspectedVersion string   `json:"suspectedVersion,omitempty"`
+	AffectedClusters []string `json:"affectedClusters"`
+}
+
+type Config struct {
+	Interval       time.Duration
+	Window         time.Duration //operations created within the window are checked for anomalies
+	BaselineWindow time.Duration //operations created before the window define the normal behaviour
+	//median duration within the window has to exceed the baseline by this factor
+	DurationFactor float64
+	//failure rate within the window has to exceed the baseline by this increase (0..1)
+	FailureRateIncrease float64
+	MinOperations       int           //finished operations required within the window and the baseline
+	Cooldown            time.Duration //an alert isn't repeated for a component within the cooldown
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Interval:            15 * time.Minute,
+		Window:              1 * time.Hour,
+		BaselineWindow:      7 * 24 * time.Hour,
+		DurationFactor:      3,
+		FailureRateIncrease: 0.2,
+		MinOperations:       5,
+		Cooldown:            6 * time.Hour,
+	}
+}
+
+func (c *Config) validate() error {
+	if c.Interval <= 0 || c.Window <= 0 || c.BaselineWindow <= 0 {
+		return fmt.Errorf("interval, window and baseline window of the anomaly detection have to be > 0")
+	}
+	if c.DurationFactor <= 1 {
+		return fmt.Errorf("duration factor of the anomaly detection has to be > 1")
+	}
+	if c.FailureRateIncrease <= 0 || c.FailureRateIncrease > 1 {
+		return fmt.Errorf("failure rate increase of the anomaly detection has to be within 0 and 1")
+	}
+	return nil
+}
+
+// Notifier emits the alerts of the analyzer
+type Notifier interface {
+	Notify(alert *Alert) error
+}
+
+// Analyzer compares periodically the operations of each component within a recent window with their baseline and
+// alerts components whose operations suddenly take much longer or fail more often. The versions of the affected
+// operations are correlated to point at a rollout which likely caused the anomaly.
+type Analyzer struct {
+	reconRepo reconciliation.Repository
+	inventory cluster.Inventory
+	config    *Config
+	notifiers []Notifier
+	logger    *zap.SugaredLogger
+	alerted   map[string]time.Time //last alert per alert type and component
+}
+
+func NewAnalyzer(reconRepo reconciliation.Repository, inventory cluster.Inventory, config *Config,
+	logger *zap.SugaredLogger, notifiers ...Notifier) *Analyzer {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &Analyzer{
+		reconRepo: reconRepo,
+		inventory: inventory,
+		config:    config,
+		notifiers: append([]Notifier{&LogNotifier{Logger: logger}}, notifiers...),
+		logger:    logger,
+		alerted:   make(map[string]time.Time),
+	}
+}
+
+func (a *Analyzer) Run(ctx context.Context) error {
+	if err := a.config.validate(); err != nil {
+		return err
+	}
+	a.logger.Infof("Starting anomaly analyzer: checking operations of the last %s every %s",
+		a.config.Window, a.config.Interval)
+
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := a.Analyze(time.Now()); err != nil {
+				a.logger.Warnf("Anomaly analyzer failed to analyze operations: %s", err)
+			}
+		case <-ctx.Done():
+			a.logger.Info("Stopping anomaly analyzer because parent context got closed")
+			return nil
+		}
+	}
+}
+
+// Analyze detects anomalies and notifies all alerts which aren't within their cooldown
+func (a *Analyzer) Analyze(now time.Time) ([]*Alert, error) {
+	alerts, err := a.detect(now)
+	if err != nil {
+		return nil, err
+	}
+	var notified []*Alert
+	for _, alert := range alerts {
+		key := fmt.Sprintf("%s/%s", alert.Type, alert.Component)
+		if last, ok := a.alerted[key]; ok && now.Sub(last) < a.config.Cooldown {
+			continue
+		}
+		a.alerted[key] = now
+		for _, notifier := range a.notifiers {
+			if err := notifier.Notify(alert); err != nil {
+				a.logger.Warnf("Failed to notify %s alert of component '%s': %s", alert.Type, alert.Component, err)
+			}
+		}
+		notified = append(notified, alert)
+	}
+	return notified, nil
+}
+
+func (a *Analyzer) detect(now time.Time) ([]*Alert, error) {
+	windowStart := now.Add(-a.config.Window)
+	ops, err := a.reconRepo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithCreationDateAfter{Time: windowStart.Add(-a.config.BaselineWindow)},
+		&operation.WithStates{States: append([]model.OperationState{model.OperationStateDone}, failedStates...)},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	recent := make(map[string][]*model.OperationEntity)
+	baseline := make(map[string][]*model.OperationEntity)
+	for _, op := range ops {
+		if op.Type != model.OperationTypeReconcile {
+			continue
+		}
+		if op.Created.Before(windowStart) {
+			baseline[op.Component] = append(baseline[op.Component], op)
+		} else {
+			recent[op.Component] = append(recent[op.Component], op)
+		}
+	}
+
+	components := make([]string, 0, len(recent))
+	for component := range recent {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	versions := newVersionResolver(a.inventory)
+	var alerts []*Alert
+	for _, component := range components {
+		for _, alert := range []*Alert{
+			a.durationAnomaly(component, recent[component], baseline[component]),
+			a.failureRateAnomaly(component, recent[component], baseline[component]),
+		} {
+			if alert == nil {
+				continue
+			}
+			alert.Detected = now
+			if alert.SuspectedVersion, err = versions.suspect(alert, recent[component], baseline[component]); err != nil {
+				a.logger.Warnf("Failed to correlate the %s anomaly of component '%s' with a version: %s",
+					alert.Type, component, err)
+			}
+			if alert.SuspectedVersion != "" {
+				alert.Message = fmt.Sprintf("%s: suspected cause is version '%s'", alert.Message, alert.SuspectedVersion)
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts, nil
+}
+
+func (a *Analyzer) durationAnomaly(component string, recent, baseline []*model.OperationEntity) *Alert {
+	recentDurations := durations(recent)
+	baselineDurations := durations(baseline)
+	if len(recentDurations) < a.config.MinOperations || len(baselineDurations) < a.config.MinOperations {
+		return nil
+	}
+	baselineMedian := median(baselineDurations)
+	observedMedian := median(recentDurations)
+	threshold := time.Duration(float64(baselineMedian) * a.config.DurationFactor)
+	if baselineMedian <= 0 || observedMedian < threshold {
+		return nil
+	}
+	alert := &Alert{
+		Type:       TypeDuration,
+		Component:  component,
+		Baseline:   baselineMedian.Seconds(),
+		Observed:   observedMedian.Seconds(),
+		Operations: len(recentDurations),
+		Message: fmt.Sprintf("Operations of component '%s' take %.1fx longer than usual (median %s instead of %s)",
+			component, float64(observedMedian)/float64(baselineMedian), observedMedian, baselineMedian),
+	}
+	for _, op := range recent {
+		if op.State == model.OperationStateDone && processingTime(op) >= threshold {
+			alert.AffectedClusters = appendUnique(alert.AffectedClusters, op.RuntimeID)
+		}
+	}
+	return alert
+}
+
+func (a *Analyzer) failureRateAnomaly(component string, recent, baseline []*model.OperationEntity) *Alert {
+	if len(recent) < a.config.MinOperations || len(baseline) < a.config.MinOperations {
+		return nil
+	}
+	baselineRate := failureRate(baseline)
+	observedRate := failureRate(recent)
+	if observedRate-baselineRate < a.config.FailureRateIncrease {
+		return nil
+	}
+	alert := &Alert{
+		Type:       TypeFailureRate,
+		Component:  component,
+		Baseline:   baselineRate,
+		Observed:   observedRate,
+		Operations: len(recent),
+		Message: fmt.Sprintf("Failure rate of component '%s' jumped to %.0f%% (usually %.0f%%)",
+			component, observedRate*100, baselineRate*100),
+	}
+	for _, op := range recent {
+		if isFailed(op) {
+			alert.AffectedClusters = appendUnique(alert.AffectedClusters, op.RuntimeID)
+		}
+	}
+	return alert
+}
+
+var failedStates = []model.OperationState{
+	model.OperationStateError,
+	model.OperationStateFailed,
+	model.OperationStateVerificationFailed,
+}
+
+func isFailed(op *model.OperationEntity) bool {
+	for _, state := range failedStates {
+		if op.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+func failureRate(ops []*model.OperationEntity) float64 {
+	var failed int
+	for _, op := range ops {
+		if isFailed(op) {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(ops))
+}
+
+// processingTime returns the duration of an operation since it was picked up by a worker
+func processingTime(op *model.OperationEntity) time.Duration {
+	started := op.PickedUp
+	if started.IsZero() {
+		started = op.Created
+	}
+	return op.Updated.Sub(started)
+}
+
+func durations(ops []*model.OperationEntity) []time.Duration {
+	var result []time.Duration
+	for _, op := range ops {
+		if op.State != model.OperationStateDone {
+			continue
+		}
+		if duration := processingTime(op); duration > 0 {
+			result = append(result, duration)
+		}
+	}
+	return result
+}
+
+func median(durations []time.Duration) time.Duration {
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return sorted[len(sorted)/2].Truncate(time.Second)
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
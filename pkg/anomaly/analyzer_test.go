@@ -0,0 +1,127 @@
+package anomaly
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedInventory returns per config version a configuration with the given istio version
+type versionedInventory struct {
+	cluster.MockInventory
+	istioVersions map[int64]string
+}
+
+func (i *versionedInventory) Get(runtimeID string, configVersion int64) (*cluster.State, error) {
+	return &cluster.State{Configuration: &model.ClusterConfigurationEntity{
+		RuntimeID:   runtimeID,
+		Version:     configVersion,
+		KymaVersion: "2.0.0",
+		Components:  []*keb.Component{{Component: "istio", Version: i.istioVersions[configVersion]}},
+	}}, nil
+}
+
+func newOp(component string, runtime int, configVersion int64, state model.OperationState, created time.Time, duration time.Duration) *model.OperationEntity {
+	return &model.OperationEntity{
+		RuntimeID:     fmt.Sprintf("runtime%d", runtime),
+		ClusterConfig: configVersion,
+		Component:     component,
+		Type:          model.OperationTypeReconcile,
+		State:         state,
+		Created:       created,
+		PickedUp:      created,
+		Updated:       created.Add(duration),
+	}
+}
+
+func TestAnalyzer(t *testing.T) {
+	now := time.Now()
+	var ops []*model.OperationEntity
+	for i := 0; i < 5; i++ {
+		//baseline: istio 1.0 takes 2 minutes, logging never fails
+		ops = append(ops,
+			newOp("istio", i, 1, model.OperationStateDone, now.Add(-48*time.Hour), 2*time.Minute),
+			newOp("logging", i, 1, model.OperationStateDone, now.Add(-48*time.Hour), time.Minute))
+		//window: istio 1.1 takes 10 minutes, logging fails on 3 of 5 clusters
+		ops = append(ops, newOp("istio", i, 2, model.OperationStateDone, now.Add(-30*time.Minute), 10*time.Minute))
+		loggingState := model.OperationStateDone
+		if i < 3 {
+			loggingState = model.OperationStateError
+		}
+		ops = append(ops, newOp("logging", i, 2, loggingState, now.Add(-30*time.Minute), time.Minute))
+	}
+	inventory := &versionedInventory{istioVersions: map[int64]string{1: "1.0", 2: "1.1"}}
+
+	newAnalyzer := func(notifiers ...Notifier) *Analyzer {
+		return NewAnalyzer(&reconciliation.MockRepository{GetOperationsResult: ops}, inventory, DefaultConfig(),
+			logger.NewLogger(true), notifiers...)
+	}
+
+	t.Run("Anomalies are detected and correlated with versions", func(t *testing.T) {
+		alerts, err := newAnalyzer().Analyze(now)
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+
+		require.Equal(t, TypeDuration, alerts[0].Type)
+		require.Equal(t, "istio", alerts[0].Component)
+		require.Equal(t, 120.0, alerts[0].Baseline)
+		require.Equal(t, 600.0, alerts[0].Observed)
+		require.Equal(t, "1.1", alerts[0].SuspectedVersion)
+		require.Len(t, alerts[0].AffectedClusters, 5)
+
+		require.Equal(t, TypeFailureRate, alerts[1].Type)
+		require.Equal(t, "logging", alerts[1].Component)
+		require.Equal(t, 0.6, alerts[1].Observed)
+		require.Empty(t, alerts[1].SuspectedVersion) //Kyma version didn't change
+		require.Equal(t, []string{"runtime0", "runtime1", "runtime2"}, alerts[1].AffectedClusters)
+	})
+
+	t.Run("Alerts are not repeated within their cooldown", func(t *testing.T) {
+		analyzer := newAnalyzer()
+		alerts, err := analyzer.Analyze(now)
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+
+		alerts, err = analyzer.Analyze(now)
+		require.NoError(t, err)
+		require.Empty(t, alerts)
+
+		analyzer.config.Cooldown = 0
+		alerts, err = analyzer.Analyze(now)
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+	})
+
+	t.Run("Alerts are posted to webhooks", func(t *testing.T) {
+		var received []*Alert
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			alert := &Alert{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(alert))
+			received = append(received, alert)
+		}))
+		defer srv.Close()
+
+		_, err := newAnalyzer(&WebhookNotifier{URL: srv.URL, Client: srv.Client()}).Analyze(now)
+		require.NoError(t, err)
+		require.Len(t, received, 2)
+		require.Equal(t, "istio", received[0].Component)
+	})
+
+	t.Run("Too few operations aren't analyzed", func(t *testing.T) {
+		analyzer := NewAnalyzer(&reconciliation.MockRepository{GetOperationsResult: ops[:8]}, inventory,
+			DefaultConfig(), logger.NewLogger(true))
+		alerts, err := analyzer.Analyze(now)
+		require.NoError(t, err)
+		require.Empty(t, alerts)
+	})
+}
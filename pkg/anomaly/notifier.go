@@ -0,0 +1,54 @@
+package anomaly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"go.uber.org/zap"
+)
+
+// LogNotifier emits alerts as warnings of the log
+type LogNotifier struct {
+	Logger *zap.SugaredLogger
+}
+
+func (n *LogNotifier) Notify(alert *Alert) error {
+	n.Logger.With(
+		"alertType", alert.Type,
+		"component", alert.Component,
+		"suspectedVersion", alert.SuspectedVersion,
+		"affectedClusters", len(alert.AffectedClusters),
+	).Warnf("Anomaly detected: %s", alert.Message)
+	return nil
+}
+
+// WebhookNotifier posts alerts as JSON to a webhook
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client //httpclient.Default is used if undefined
+}
+
+func (n *WebhookNotifier) Notify(alert *Alert) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	client := n.Client
+	if client == nil {
+		client = httpclient.Default()
+	}
+	resp, err := client.Post(n.URL, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook '%s' responded with status code %d", n.URL, resp.StatusCode)
+	}
+	return nil
+}
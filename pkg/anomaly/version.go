@@ -0,0 +1,90 @@
+package anomaly
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+// versionResolver resolves the component version an operation was processed with by the cluster configuration
+// of the operation
+type versionResolver struct {
+	inventory cluster.Inventory
+	cache     map[string]string
+}
+
+func newVersionResolver(inventory cluster.Inventory) *versionResolver {
+	return &versionResolver{
+		inventory: inventory,
+		cache:     make(map[string]string),
+	}
+}
+
+func (r *versionResolver) version(op *model.OperationEntity) (string, error) {
+	key := fmt.Sprintf("%s/%d/%s", op.RuntimeID, op.ClusterConfig, op.Component)
+	if version, ok := r.cache[key]; ok {
+		return version, nil
+	}
+	state, err := r.inventory.Get(op.RuntimeID, op.ClusterConfig)
+	if err != nil {
+		return "", err
+	}
+	version := state.Configuration.KymaVersion
+	if component := state.Configuration.GetComponent(op.Component); component != nil && component.Version != "" {
+		version = component.Version
+	}
+	r.cache[key] = version
+	return version, nil
+}
+
+// suspect returns the version most of the affected operations were processed with if no operation of the baseline
+// used this version (the anomaly started with the rollout of the version). An empty string is returned if the
+// anomaly doesn't correlate with a new version.
+func (r *versionResolver) suspect(alert *Alert, recent, baseline []*model.OperationEntity) (string, error) {
+	counts := make(map[string]int)
+	for _, op := range recent {
+		if !isAffected(alert, op) {
+			continue
+		}
+		version, err := r.version(op)
+		if err != nil {
+			return "", err
+		}
+		counts[version]++
+	}
+	var suspected string
+	for version, count := range counts {
+		if count > counts[suspected] || (count == counts[suspected] && version < suspected) {
+			suspected = version
+		}
+	}
+	if suspected == "" {
+		return "", nil
+	}
+	for _, op := range baseline {
+		version, err := r.version(op)
+		if err != nil {
+			return "", err
+		}
+		if version == suspected {
+			return "", nil
+		}
+	}
+	return suspected, nil
+}
+
+func isAffected(alert *Alert, op *model.OperationEntity) bool {
+	if alert.Type == TypeFailureRate {
+		return isFailed(op)
+	}
+	if op.State != model.OperationStateDone {
+		return false
+	}
+	for _, runtimeID := range alert.AffectedClusters {
+		if runtimeID == op.RuntimeID {
+			return true
+		}
+	}
+	return false
+}
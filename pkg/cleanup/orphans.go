@@ -0,0 +1,177 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+)
+
+type Category string
+
+const (
+	//cluster statuses which are neither the current status of a configuration nor referenced by a reconciliation
+	CategoryClusterStatuses Category = "cluster_statuses"
+	//outdated cluster configurations which aren't referenced by any status, reconciliation or operation
+	CategoryClusterConfigs Category = "cluster_configs"
+	//operations whose reconciliation doesn't exist anymore
+	CategoryOperations Category = "operations"
+)
+
+// Result reports the orphans of a category: deleted orphans are always zero within a dry-run
+type Result struct {
+	Category Category
+	Found    int
+	Deleted  int
+}
+
+// orphans describes how orphans of a category are found (by a view) and which entities are deleted for them
+type orphans struct {
+	category  Category
+	view      db.DatabaseEntity
+	viewField string
+	key       func(orphan db.DatabaseEntity) interface{}
+	entity    db.DatabaseEntity
+	field     string
+}
+
+// OrphanCleaner removes DB entities which are left over by the regular cleanup (e.g. because of missing foreign
+// keys in older schema versions) and slow down queries.
+type OrphanCleaner struct {
+	*repository.Repository
+	batchSize int
+}
+
+func NewOrphanCleaner(conn db.Connection, batchSize int, debug bool) (*OrphanCleaner, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size of the orphan cleanup has to be > 0 (was %d)", batchSize)
+	}
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &OrphanCleaner{repo, batchSize}, nil
+}
+
+// Cleanup finds the orphans of all categories and deletes them in batches (unless it's a dry-run). Categories are
+// processed in order: deleting stale statuses can orphan the configurations they referenced.
+func (c *OrphanCleaner) Cleanup(ctx context.Context, dryRun bool) ([]*Result, error) {
+	var results []*Result
+	for _, orphans := range []*orphans{
+		{
+			category:  CategoryClusterStatuses,
+			view:      &model.StatusCleanupEntity{},
+			viewField: model.StatusCleanupEntityFields.StatusID,
+			key: func(orphan db.DatabaseEntity) interface{} {
+				return orphan.(*model.StatusCleanupEntity).StatusID
+			},
+			entity: &model.ClusterStatusEntity{},
+			field:  model.ClusterStatusEntityFields.ID,
+		},
+		{
+			category:  CategoryClusterConfigs,
+			view:      &model.OrphanedConfigEntity{},
+			viewField: model.OrphanedConfigEntityFields.ConfigID,
+			key: func(orphan db.DatabaseEntity) interface{} {
+				return orphan.(*model.OrphanedConfigEntity).ConfigID
+			},
+			entity: &model.ClusterConfigurationEntity{},
+			field:  model.ClusterConfigurationEntityFields.Version,
+		},
+		{
+			category:  CategoryOperations,
+			view:      &model.OrphanedOperationEntity{},
+			viewField: model.OrphanedOperationEntityFields.SchedulingID,
+			key: func(orphan db.DatabaseEntity) interface{} {
+				return orphan.(*model.OrphanedOperationEntity).SchedulingID
+			},
+			entity: &model.OperationEntity{},
+			field:  model.OperationEntityFields.SchedulingID,
+		},
+	} {
+		result, err := c.cleanup(ctx, orphans, dryRun)
+		if err != nil {
+			return results, err
+		}
+		c.Logger.Infof("Found %d orphaned %s (deleted: %d)", result.Found, result.Category, result.Deleted)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (c *OrphanCleaner) cleanup(ctx context.Context, orphans *orphans, dryRun bool) (*Result, error) {
+	result := &Result{Category: orphans.category}
+
+	q, err := db.NewQuery(c.Conn, orphans.view, c.Logger)
+	if err != nil {
+		return result, err
+	}
+	entities, err := q.Select().GetMany()
+	if err != nil {
+		return result, err
+	}
+	result.Found = len(entities)
+	if dryRun {
+		return result, nil
+	}
+
+	//an entity can be referenced by multiple orphans (e.g. all operations of a reconciliation)
+	var keys []interface{}
+	knownKeys := make(map[interface{}]bool, len(entities))
+	for _, entity := range entities {
+		if key := orphans.key(entity); !knownKeys[key] {
+			knownKeys[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	for _, batch := range repository.SplitSliceByBlockSize(keys, c.batchSize) {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		default:
+		}
+		deleted, err := c.deleteBatch(orphans, batch)
+		if err != nil {
+			return result, err
+		}
+		result.Deleted += deleted
+	}
+	return result, nil
+}
+
+func (c *OrphanCleaner) deleteBatch(orphans *orphans, keys []interface{}) (int, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		viewColHandler, err := db.NewColumnHandler(orphans.view, tx, c.Logger)
+		if err != nil {
+			return 0, err
+		}
+		viewColName, err := viewColHandler.ColumnName(orphans.viewField)
+		if err != nil {
+			return 0, err
+		}
+		placeholders := make([]string, 0, len(keys))
+		for idx := range keys {
+			placeholders = append(placeholders, fmt.Sprintf("$%d", idx+1))
+		}
+
+		q, err := db.NewQuery(tx, orphans.entity, c.Logger)
+		if err != nil {
+			return 0, err
+		}
+		//delete only entities which are still orphaned
+		deleted, err := q.Delete().
+			WhereIn(orphans.field, fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s)",
+				viewColName, orphans.view.Table(), viewColName, strings.Join(placeholders, ",")), keys...).
+			Exec()
+		return int(deleted), err
+	}
+	deleted, err := db.TransactionResult(c.Conn, dbOps, c.Logger)
+	if err != nil {
+		return 0, err
+	}
+	return deleted.(int), nil
+}
@@ -0,0 +1,77 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type cleanupTestSuite struct {
+	suite.Suite
+	containerSuite *db.ContainerTestSuite
+}
+
+func TestIntegrationSuite(t *testing.T) {
+	cs := db.IsolatedContainerTestSuite(t, true, *db.DefaultSharedContainerSettings, false)
+	cs.SetT(t)
+	suite.Run(t, &cleanupTestSuite{containerSuite: cs})
+}
+
+func (s *cleanupTestSuite) SetupSuite() {
+	s.containerSuite.SetupSuite()
+}
+
+func (s *cleanupTestSuite) TearDownSuite() {
+	s.containerSuite.TearDownSuite()
+}
+
+func (s *cleanupTestSuite) TestOrphanCleaner() {
+	t := s.T()
+	conn, err := s.containerSuite.NewConnection()
+	require.NoError(t, err)
+	inventory, err := cluster.NewInventory(conn, true, cluster.MetricsCollectorMock{})
+	require.NoError(t, err)
+
+	//each status update creates a new status: the replaced statuses are stale
+	state, err := inventory.CreateOrUpdate(1, test.NewCluster(t, "orphans", 1, false, test.Production))
+	require.NoError(t, err)
+	_, err = inventory.UpdateStatus(state, model.ClusterStatusReconciling)
+	require.NoError(t, err)
+	_, err = inventory.UpdateStatus(state, model.ClusterStatusReady)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, inventory.Delete(state.Cluster.RuntimeID))
+		require.NoError(t, conn.Close())
+	}()
+
+	_, err = NewOrphanCleaner(conn, 0, true)
+	require.Error(t, err)
+	cleaner, err := NewOrphanCleaner(conn, 1, true)
+	require.NoError(t, err)
+
+	results, err := cleaner.Cleanup(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, CategoryClusterStatuses, results[0].Category)
+	require.GreaterOrEqual(t, results[0].Found, 2)
+	require.Zero(t, results[0].Deleted)
+
+	results, err = cleaner.Cleanup(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, results[0].Found, results[0].Deleted)
+
+	results, err = cleaner.Cleanup(context.Background(), true)
+	require.NoError(t, err)
+	require.Zero(t, results[0].Found)
+
+	//the current status of the cluster is kept
+	state, err = inventory.GetLatest(state.Cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Equal(t, model.ClusterStatusReady, state.Status.Status)
+}
@@ -0,0 +1,190 @@
+// Package client provides typed Go clients for the HTTP APIs of the mothership and the component reconcilers.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/pkg/errors"
+)
+
+const (
+	contractVersion     = "v1"
+	defaultPollInterval = 5 * time.Second
+)
+
+// TokenSource returns the bearer token used to authenticate a request
+type TokenSource func(ctx context.Context) (string, error)
+
+type Option func(*options)
+
+type options struct {
+	httpClient   *http.Client
+	tokenSource  TokenSource
+	pollInterval time.Duration
+}
+
+// WithHTTPClient replaces the default HTTP client (see httpclient.Default) which retries requests
+// failing for transient reasons
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithBearerToken authenticates all requests with a static bearer token
+func WithBearerToken(token string) Option {
+	return WithTokenSource(func(_ context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithTokenSource authenticates all requests with a bearer token retrieved by the token source (e.g. to refresh expiring tokens)
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(o *options) {
+		o.tokenSource = tokenSource
+	}
+}
+
+// WithPollInterval defines how often watched resources are polled
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+// Error is returned if an API responded with an unexpected status code
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true if the requested resource doesn't exist
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsBusy returns true if a component reconciler had no free worker for a task
+func IsBusy(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+type baseClient struct {
+	url *url.URL
+	options
+}
+
+func newBaseClient(serverURL string, opts []Option) (*baseClient, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid server URL '%s'", serverURL)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("server URL '%s' has to use the scheme http or https", serverURL)
+	}
+	client := &baseClient{
+		url: u,
+		options: options{
+			pollInterval: defaultPollInterval,
+		},
+	}
+	for _, opt := range opts {
+		opt(&client.options)
+	}
+	if client.httpClient == nil {
+		client.httpClient = httpclient.Default()
+	}
+	if client.pollInterval <= 0 {
+		return nil, fmt.Errorf("poll interval has to be > 0 but was %v", client.pollInterval)
+	}
+	return client, nil
+}
+
+// do sends the payload as JSON and decodes the response into the result (both are optional)
+func (c *baseClient) do(ctx context.Context, method, path string, query url.Values, payload, result interface{}) error {
+	return c.doWithHeader(ctx, method, path, query, nil, payload, result)
+}
+
+// doWithHeader behaves like do but adds the given header to the request
+func (c *baseClient) doWithHeader(ctx context.Context, method, path string, query url.Values, header http.Header,
+	payload, result interface{}) error {
+	reqURL := *c.url
+	reqURL.Path = strings.TrimSuffix(reqURL.Path, "/") + path
+	reqURL.RawQuery = query.Encode()
+
+	var body io.Reader
+	var data []byte
+	if payload != nil {
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return errors.Wrap(err, "failed to encode request payload to JSON")
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), body)
+	if err != nil {
+		return err
+	}
+	for name, values := range header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if payload != nil {
+		req.Header.Set("content-type", "application/json")
+	}
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to retrieve bearer token")
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%s request to '%s' failed", method, reqURL.Redacted())
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newError(resp)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrapf(err, "failed to decode response of %s request to '%s'", method, reqURL.Redacted())
+	}
+	return nil
+}
+
+func newError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return &Error{StatusCode: resp.StatusCode, Message: err.Error()}
+	}
+	errResp := struct {
+		Error string `json:"error"`
+	}{}
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+		return &Error{StatusCode: resp.StatusCode, Message: errResp.Error}
+	}
+	return &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+}
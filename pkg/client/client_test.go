@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMothershipClient(t *testing.T) {
+	var timelineCalls int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/clusters", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		cluster := &keb.Cluster{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(cluster))
+		writeJSON(w, http.StatusOK, &keb.HTTPClusterResponse{Cluster: cluster.RuntimeID, Status: keb.StatusReconcilePending})
+	})
+	mux.HandleFunc("/api/v1/clusters/state", func(w http.ResponseWriter, r *http.Request) {
+		runtimeID := r.URL.Query().Get("runtimeID")
+		if runtimeID != "runtime1" {
+			writeJSON(w, http.StatusNotFound, &keb.HTTPErrorResponse{Error: "cluster not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, &keb.HTTPClusterStateResponse{Cluster: keb.ClusterState{RuntimeID: &runtimeID}})
+	})
+	mux.HandleFunc("/api/v1/clusters/runtime1/status", func(w http.ResponseWriter, r *http.Request) {
+		status := &keb.StatusUpdate{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(status))
+		writeJSON(w, http.StatusOK, &keb.HTTPClusterResponse{Cluster: "runtime1", Status: status.Status})
+	})
+	mux.HandleFunc("/api/v1/operations/scheduling1/correlation1/timeline", func(w http.ResponseWriter, r *http.Request) {
+		state := "new"
+		switch atomic.AddInt32(&timelineCalls, 1) {
+		case 1:
+		case 2, 3:
+			state = "in_progress"
+		default:
+			state = "done"
+		}
+		writeJSON(w, http.StatusOK, &keb.HTTPOperationTimeline{State: state})
+	})
+	mux.HandleFunc("/api/v1/clusters/runtime1", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		if r.URL.Query().Get("token") == "" && r.Header.Get("Deletion-Confirmation") == "required" {
+			writeJSON(w, http.StatusAccepted, &keb.DeletionConfirmation{RuntimeID: "runtime1", Token: "token1"})
+			return
+		}
+		if token := r.URL.Query().Get("token"); token != "" && token != "token1" {
+			writeJSON(w, http.StatusConflict, &keb.DeletionNotConfirmed{Error: "invalid token"})
+			return
+		}
+		writeJSON(w, http.StatusOK, &keb.HTTPClusterResponse{Cluster: "runtime1", Status: keb.StatusDeletePending})
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			writeJSON(w, http.StatusUnauthorized, &keb.HTTPErrorResponse{Error: "not authorized"})
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	client, err := NewMothershipClient(srv.URL+"/api", WithBearerToken("secret"), WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("Register cluster", func(t *testing.T) {
+		resp, err := client.RegisterCluster(ctx, &keb.Cluster{RuntimeID: "runtime1"})
+		require.NoError(t, err)
+		require.Equal(t, "runtime1", resp.Cluster)
+	})
+
+	t.Run("Get cluster state", func(t *testing.T) {
+		state, err := client.GetClusterState(ctx, "runtime1")
+		require.NoError(t, err)
+		require.Equal(t, "runtime1", *state.Cluster.RuntimeID)
+
+		_, err = client.GetClusterState(ctx, "runtime2")
+		require.True(t, IsNotFound(err))
+		require.Contains(t, err.Error(), "cluster not found")
+	})
+
+	t.Run("Delete cluster", func(t *testing.T) {
+		resp, err := client.DeleteCluster(ctx, "runtime1")
+		require.NoError(t, err)
+		require.Equal(t, keb.StatusDeletePending, resp.Status)
+	})
+
+	t.Run("Delete cluster with confirmation", func(t *testing.T) {
+		confirmation, err := client.RequestClusterDeletion(ctx, "runtime1")
+		require.NoError(t, err)
+		require.Equal(t, "token1", confirmation.Token)
+
+		_, err = client.ConfirmClusterDeletion(ctx, "runtime1", "token2")
+		require.Error(t, err)
+
+		resp, err := client.ConfirmClusterDeletion(ctx, "runtime1", confirmation.Token)
+		require.NoError(t, err)
+		require.Equal(t, keb.StatusDeletePending, resp.Status)
+	})
+
+	t.Run("Trigger reconcile", func(t *testing.T) {
+		resp, err := client.TriggerReconcile(ctx, "runtime1")
+		require.NoError(t, err)
+		require.Equal(t, keb.StatusReconcilePending, resp.Status)
+	})
+
+	t.Run("Watch operation", func(t *testing.T) {
+		var states []string
+		timeline, err := client.WatchOperation(ctx, "scheduling1", "correlation1", func(timeline *keb.HTTPOperationTimeline) {
+			states = append(states, timeline.State)
+		})
+		require.NoError(t, err)
+		require.Equal(t, "done", timeline.State)
+		require.Equal(t, []string{"new", "in_progress", "done"}, states)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		unauthorized, err := NewMothershipClient(srv.URL + "/api")
+		require.NoError(t, err)
+		_, err = unauthorized.GetClusterState(ctx, "runtime1")
+		apiErr, ok := err.(*Error)
+		require.True(t, ok)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	})
+
+	t.Run("Invalid URL", func(t *testing.T) {
+		_, err := NewMothershipClient("ftp://mothership")
+		require.Error(t, err)
+	})
+}
+
+func TestReconcilerClient(t *testing.T) {
+	var busy int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/run", r.URL.Path)
+		task := &reconciler.TaskV2{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(task))
+		require.Equal(t, "comp", task.Component)
+		if atomic.LoadInt32(&busy) == 1 {
+			writeJSON(w, http.StatusTooManyRequests, &reconciler.HTTPErrorResponse{Error: "no free worker"})
+			return
+		}
+		writeJSON(w, http.StatusOK, &reconciler.HTTPReconciliationResponse{})
+	}))
+	defer srv.Close()
+
+	client, err := NewReconcilerClient(srv.URL)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Run(context.Background(), &reconciler.TaskV2{Component: "comp"}))
+
+	atomic.StoreInt32(&busy, 1)
+	err = client.Run(context.Background(), &reconciler.TaskV2{Component: "comp"})
+	require.True(t, IsBusy(err))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,380 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+// MothershipClient is a client of the mothership API
+type MothershipClient struct {
+	*baseClient
+}
+
+func NewMothershipClient(mothershipURL string, opts ...Option) (*MothershipClient, error) {
+	base, err := newBaseClient(mothershipURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &MothershipClient{base}, nil
+}
+
+// RegisterCluster creates the cluster or updates its configuration. Each new configuration triggers a reconciliation.
+func (c *MothershipClient) RegisterCluster(ctx context.Context, cluster *keb.Cluster) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	//PUT is equivalent to POST but idempotent: the request is retried on transient failures
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/%s/clusters", contractVersion), nil, cluster, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetClusterState returns the latest state of the cluster including its configuration
+func (c *MothershipClient) GetClusterState(ctx context.Context, runtimeID string) (*keb.HTTPClusterStateResponse, error) {
+	result := &keb.HTTPClusterStateResponse{}
+	query := url.Values{"runtimeID": []string{runtimeID}}
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%s/clusters/state", contractVersion), query, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteCluster deletes the cluster with a single request. This works only if the mothership allows unconfirmed
+// deletions, otherwise only a token is issued: use RequestClusterDeletion and ConfirmClusterDeletion in this case.
+func (c *MothershipClient) DeleteCluster(ctx context.Context, runtimeID string) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	err := c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/%s/clusters/%s", contractVersion, url.PathEscape(runtimeID)), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Cluster == "" { //response contains a deletion confirmation instead of the cluster
+		return nil, fmt.Errorf("deletion of cluster '%s' has to be confirmed", runtimeID)
+	}
+	return result, nil
+}
+
+// RequestClusterDeletion returns a token which has to be passed to ConfirmClusterDeletion before it expires
+func (c *MothershipClient) RequestClusterDeletion(ctx context.Context, runtimeID string) (*keb.DeletionConfirmation, error) {
+	result := &keb.DeletionConfirmation{}
+	header := http.Header{}
+	header.Set("Deletion-Confirmation", "required")
+	err := c.doWithHeader(ctx, http.MethodDelete,
+		fmt.Sprintf("/%s/clusters/%s", contractVersion, url.PathEscape(runtimeID)), nil, header, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ConfirmClusterDeletion deletes the cluster using the token issued by RequestClusterDeletion
+func (c *MothershipClient) ConfirmClusterDeletion(ctx context.Context, runtimeID, token string) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	query := url.Values{"token": []string{token}}
+	err := c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/%s/clusters/%s", contractVersion, url.PathEscape(runtimeID)), query, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UndeleteCluster reverts the deletion of a soft-deleted cluster whose grace period is not over yet
+func (c *MothershipClient) UndeleteCluster(ctx context.Context, runtimeID string) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	err := c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/%s/clusters/%s/deletion", contractVersion, url.PathEscape(runtimeID)), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ProtectCluster rejects all deletion requests of the cluster until UnprotectCluster is called
+func (c *MothershipClient) ProtectCluster(ctx context.Context, runtimeID string) (*keb.DeletionProtection, error) {
+	result := &keb.DeletionProtection{}
+	err := c.do(ctx, http.MethodPut,
+		fmt.Sprintf("/%s/clusters/%s/protection", contractVersion, url.PathEscape(runtimeID)), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnprotectCluster removes the deletion protection of the cluster
+func (c *MothershipClient) UnprotectCluster(ctx context.Context, runtimeID string) error {
+	return c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/%s/clusters/%s/protection", contractVersion, url.PathEscape(runtimeID)), nil, nil, nil)
+}
+
+// GetClusterStatus returns the status of the latest configuration of the cluster including the failures
+// of a running or failed reconciliation
+func (c *MothershipClient) GetClusterStatus(ctx context.Context, runtimeID string) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	err := c.do(ctx, http.MethodGet,
+		fmt.Sprintf("/%s/clusters/%s/status", contractVersion, url.PathEscape(runtimeID)), nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetFleetReport returns the fleet KPIs of the reconciliations created between after and before. The failure
+// top-list is limited to top entries.
+func (c *MothershipClient) GetFleetReport(ctx context.Context, after, before time.Time, top int) (*keb.HTTPFleetReport, error) {
+	result := &keb.HTTPFleetReport{}
+	query := url.Values{
+		"after":  []string{after.Format(time.RFC3339)},
+		"before": []string{before.Format(time.RFC3339)},
+		"top":    []string{strconv.Itoa(top)},
+	}
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%s/reports/fleet", contractVersion), query, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WatchCluster polls the status of the cluster until it reached a terminal status and returns it. The optional
+// callback is called whenever the status of the cluster changed.
+func (c *MothershipClient) WatchCluster(ctx context.Context, runtimeID string,
+	onChange func(*keb.HTTPClusterResponse)) (*keb.HTTPClusterResponse, error) {
+	var last *keb.HTTPClusterResponse
+	for {
+		status, err := c.GetClusterStatus(ctx, runtimeID)
+		if err != nil {
+			return nil, err
+		}
+		if last == nil || status.Status != last.Status || status.ConfigurationVersion != last.ConfigurationVersion {
+			last = status
+			if onChange != nil {
+				onChange(status)
+			}
+		}
+		if IsTerminalClusterStatus(status.Status) {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+// IsTerminalClusterStatus returns true if the mothership won't change the status of the cluster without
+// further user interaction
+func IsTerminalClusterStatus(status keb.Status) bool {
+	switch status {
+	case keb.StatusReady, keb.StatusError, keb.StatusDeleted, keb.StatusDeleteError, keb.StatusReconcileDisabled:
+		return true
+	}
+	return false
+}
+
+// TriggerReconcile schedules a reconciliation of the latest configuration of the cluster
+func (c *MothershipClient) TriggerReconcile(ctx context.Context, runtimeID string) (*keb.HTTPClusterResponse, error) {
+	result := &keb.HTTPClusterResponse{}
+	payload := &keb.StatusUpdate{Status: keb.StatusReconcilePending}
+	err := c.do(ctx, http.MethodPut,
+		fmt.Sprintf("/%s/clusters/%s/status", contractVersion, url.PathEscape(runtimeID)), nil, payload, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SimulateChange returns the operations which a new Kyma version would cause on the clusters (on all clusters if
+// no runtimeID is given). Nothing is executed by the mothership.
+func (c *MothershipClient) SimulateChange(ctx context.Context, kymaVersion string, runtimeIDs []string) (*keb.HTTPSimulationPlan, error) {
+	result := &keb.HTTPSimulationPlan{}
+	payload := &keb.SimulationRequest{KymaVersion: kymaVersion}
+	if len(runtimeIDs) > 0 {
+		payload.RuntimeIDs = &runtimeIDs
+	}
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%s/simulations", contractVersion), nil, payload, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ScheduleReconciliation queues a one-off reconciliation of the cluster which is triggered not before the
+// given point in time
+func (c *MothershipClient) ScheduleReconciliation(ctx context.Context, runtimeID string, notBefore time.Time) (*keb.ScheduledReconciliation, error) {
+	result := &keb.ScheduledReconciliation{}
+	payload := &keb.ScheduledReconciliationCreate{RuntimeID: runtimeID, NotBefore: notBefore}
+	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/%s/schedules", contractVersion), nil, payload, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetScheduledReconciliations returns the pending scheduled reconciliations of the cluster (or of all
+// clusters if the runtimeID is empty)
+func (c *MothershipClient) GetScheduledReconciliations(ctx context.Context, runtimeID string) (keb.HTTPScheduledReconciliations, error) {
+	var result keb.HTTPScheduledReconciliations
+	var query url.Values
+	if runtimeID != "" {
+		query = url.Values{"runtimeID": []string{runtimeID}}
+	}
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%s/schedules", contractVersion), query, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CancelScheduledReconciliation removes a pending scheduled reconciliation
+func (c *MothershipClient) CancelScheduledReconciliation(ctx context.Context, scheduleID string) error {
+	return c.do(ctx, http.MethodDelete,
+		fmt.Sprintf("/%s/schedules/%s", contractVersion, url.PathEscape(scheduleID)), nil, nil, nil)
+}
+
+// SetReconcileInterval overrides the periodic reconcile interval. An empty runtimeID applies the override to all
+// clusters, an empty component to all components.
+func (c *MothershipClient) SetReconcileInterval(ctx context.Context, runtimeID, component string, interval time.Duration) (*keb.ReconcileInterval, error) {
+	result := &keb.ReconcileInterval{}
+	payload := &keb.ReconcileIntervalUpdate{IntervalSeconds: int64(interval.Seconds())}
+	if runtimeID != "" {
+		payload.RuntimeID = &runtimeID
+	}
+	if component != "" {
+		payload.Component = &component
+	}
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/%s/intervals", contractVersion), nil, payload, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetReconcileIntervals returns all reconcile interval overrides
+func (c *MothershipClient) GetReconcileIntervals(ctx context.Context) (keb.HTTPReconcileIntervals, error) {
+	var result keb.HTTPReconcileIntervals
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%s/intervals", contractVersion), nil, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RemoveReconcileInterval drops a reconcile interval override
+func (c *MothershipClient) RemoveReconcileInterval(ctx context.Context, runtimeID, component string) error {
+	query := url.Values{}
+	if runtimeID != "" {
+		query.Set("runtimeID", runtimeID)
+	}
+	if component != "" {
+		query.Set("component", component)
+	}
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/%s/intervals", contractVersion), query, nil, nil)
+}
+
+// EngageFreeze stops the scheduling of new operations of the component. An empty component freezes all components.
+func (c *MothershipClient) EngageFreeze(ctx context.Context, component, reason string) (*keb.Freeze, error) {
+	result := &keb.Freeze{}
+	payload := &keb.FreezeUpdate{Reason: reason}
+	if component != "" {
+		payload.Component = &component
+	}
+	err := c.do(ctx, http.MethodPut, fmt.Sprintf("/%s/freezes", contractVersion), nil, payload, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetFreezes returns the engaged freezes. Released freezes are included on request.
+func (c *MothershipClient) GetFreezes(ctx context.Context, includeReleased bool) (keb.HTTPFreezes, error) {
+	var result keb.HTTPFreezes
+	query := url.Values{"released": []string{strconv.FormatBool(includeReleased)}}
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/%s/freezes", contractVersion), query, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ReleaseFreeze resumes the scheduling of the component. An empty component releases the fleet-wide freeze.
+func (c *MothershipClient) ReleaseFreeze(ctx context.Context, component string) (*keb.Freeze, error) {
+	result := &keb.Freeze{}
+	query := url.Values{}
+	if component != "" {
+		query.Set("component", component)
+	}
+	err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/%s/freezes", contractVersion), query, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetOperationPayload returns the payload which was sent to the component reconciler to process the operation.
+// The payload includes the kubeconfig of the cluster.
+func (c *MothershipClient) GetOperationPayload(ctx context.Context, schedulingID, correlationID string) (*keb.HTTPOperationPayload, error) {
+	result := &keb.HTTPOperationPayload{}
+	path := fmt.Sprintf("/%s/operations/%s/%s/payload",
+		contractVersion, url.PathEscape(schedulingID), url.PathEscape(correlationID))
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetOperationFailureCapture returns the payload and the resolved values which the component reconciler captured
+// when the operation failed (kubeconfig and secrets are redacted).
+func (c *MothershipClient) GetOperationFailureCapture(ctx context.Context, schedulingID, correlationID string) (*keb.HTTPOperationFailureCapture, error) {
+	result := &keb.HTTPOperationFailureCapture{}
+	path := fmt.Sprintf("/%s/operations/%s/%s/failure",
+		contractVersion, url.PathEscape(schedulingID), url.PathEscape(correlationID))
+	if err := c.do(ctx, http.MethodGet, path, nil, nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WatchOperation polls the operation until it reached a final state and returns its timeline. The optional
+// callback is called whenever the state of the operation changed.
+func (c *MothershipClient) WatchOperation(ctx context.Context, schedulingID, correlationID string,
+	onChange func(*keb.HTTPOperationTimeline)) (*keb.HTTPOperationTimeline, error) {
+	path := fmt.Sprintf("/%s/operations/%s/%s/timeline",
+		contractVersion, url.PathEscape(schedulingID), url.PathEscape(correlationID))
+
+	var lastState string
+	for {
+		timeline := &keb.HTTPOperationTimeline{}
+		if err := c.do(ctx, http.MethodGet, path, nil, nil, timeline); err != nil {
+			return nil, err
+		}
+		if timeline.State != lastState {
+			lastState = timeline.State
+			if onChange != nil {
+				onChange(timeline)
+			}
+		}
+		if isFinalOperationState(timeline.State) {
+			return timeline, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+// isFinalOperationState mirrors model.OperationState.IsFinal
+func isFinalOperationState(state string) bool {
+	return state == "done" || state == "error"
+}
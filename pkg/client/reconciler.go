@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+)
+
+// ReconcilerClient is a client of the API of a component reconciler
+type ReconcilerClient struct {
+	*baseClient
+}
+
+func NewReconcilerClient(reconcilerURL string, opts ...Option) (*ReconcilerClient, error) {
+	base, err := newBaseClient(reconcilerURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcilerClient{base}, nil
+}
+
+// Run assigns the task to a worker of the component reconciler. The reconciler reports the progress
+// to the callback URL of the task.
+func (c *ReconcilerClient) Run(ctx context.Context, task *reconciler.TaskV2) error {
+	return c.do(ctx, http.MethodPost, "/v2/run", nil, task, nil)
+}
@@ -0,0 +1,141 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+type BadgeStatus string
+
+const (
+	BadgeStatusGreen  BadgeStatus = "green"
+	BadgeStatusYellow BadgeStatus = "yellow"
+	BadgeStatusRed    BadgeStatus = "red"
+)
+
+// StatusBadge is the minimal status of a cluster which can be shared with everyone knowing its badge token
+type StatusBadge struct {
+	RuntimeID string
+	Status    BadgeStatus
+	//nil if the cluster was never reconciled successfully (or its statuses were already cleaned up)
+	LastSuccessfulReconcile *time.Time
+}
+
+// NewBadgeStatus maps the status of a cluster to a traffic light: clusters which are in progress or will be
+// retried are yellow, clusters which require an intervention are red.
+func NewBadgeStatus(status model.Status) BadgeStatus {
+	switch status {
+	case model.ClusterStatusReady:
+		return BadgeStatusGreen
+	case model.ClusterStatusReconcileError, model.ClusterStatusDeleteError, model.ClusterStatusDeleted:
+		return BadgeStatusRed
+	default:
+		return BadgeStatusYellow
+	}
+}
+
+// IssueStatusBadgeToken creates a new token for the status badge of the cluster: a previously issued token
+// becomes invalid. The token is returned only once, just its hash is stored.
+func (i *DefaultInventory) IssueStatusBadgeToken(runtimeID string) (string, error) {
+	token := uuid.NewString()
+	badge := &model.StatusBadgeEntity{
+		RuntimeID: runtimeID,
+		TokenHash: hashBadgeToken(token),
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, badge, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().
+			Where(map[string]interface{}{model.StatusBadgeEntityFields.RuntimeID: runtimeID}).
+			Exec(); err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to issue status badge token for cluster '%s'", runtimeID))
+	}
+	return token, nil
+}
+
+// RevokeStatusBadgeToken invalidates the token of the status badge of the cluster
+func (i *DefaultInventory) RevokeStatusBadgeToken(runtimeID string) error {
+	q, err := db.NewQuery(i.Conn, &model.StatusBadgeEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	whereCond := map[string]interface{}{model.StatusBadgeEntityFields.RuntimeID: runtimeID}
+	deleted, err := q.Delete().Where(whereCond).Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return i.NewNotFoundError(fmt.Errorf("no status badge token issued for cluster '%s'", runtimeID),
+			&model.StatusBadgeEntity{}, whereCond)
+	}
+	return nil
+}
+
+// StatusBadge returns the status badge of the cluster if the token is valid. Otherwise, a not-found error is
+// returned to avoid revealing the existence of the cluster.
+func (i *DefaultInventory) StatusBadge(runtimeID, token string) (*StatusBadge, error) {
+	whereCond := map[string]interface{}{model.StatusBadgeEntityFields.RuntimeID: runtimeID}
+	q, err := db.NewQuery(i.Conn, &model.StatusBadgeEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	badges, err := q.Select().Where(whereCond).GetMany()
+	if err != nil {
+		return nil, err
+	}
+	if len(badges) == 0 || subtle.ConstantTimeCompare(
+		[]byte(badges[0].(*model.StatusBadgeEntity).TokenHash), []byte(hashBadgeToken(token))) != 1 {
+		return nil, i.NewNotFoundError(fmt.Errorf("no status badge found for cluster '%s'", runtimeID),
+			&model.StatusBadgeEntity{}, whereCond)
+	}
+
+	state, err := i.GetLatest(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	badge := &StatusBadge{
+		RuntimeID: runtimeID,
+		Status:    NewBadgeStatus(state.Status.Status),
+	}
+
+	statusQ, err := db.NewQuery(i.Conn, &model.ClusterStatusEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	readyStatuses, err := statusQ.Select().
+		Where(map[string]interface{}{
+			model.ClusterStatusEntityFields.RuntimeID: runtimeID,
+			model.ClusterStatusEntityFields.Status:    string(model.ClusterStatusReady),
+		}).
+		OrderBy(map[string]string{model.ClusterStatusEntityFields.ID: "desc"}).
+		Limit(1).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	if len(readyStatuses) > 0 {
+		lastReady := readyStatuses[0].(*model.ClusterStatusEntity).Created
+		badge.LastSuccessfulReconcile = &lastReady
+	}
+	return badge, nil
+}
+
+func hashBadgeToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
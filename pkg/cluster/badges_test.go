@@ -0,0 +1,76 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb/test"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBadgeStatus(t *testing.T) {
+	require.Equal(t, BadgeStatusGreen, NewBadgeStatus(model.ClusterStatusReady))
+	require.Equal(t, BadgeStatusYellow, NewBadgeStatus(model.ClusterStatusReconciling))
+	require.Equal(t, BadgeStatusYellow, NewBadgeStatus(model.ClusterStatusReconcileErrorRetryable))
+	require.Equal(t, BadgeStatusRed, NewBadgeStatus(model.ClusterStatusReconcileError))
+	require.Equal(t, BadgeStatusRed, NewBadgeStatus(model.ClusterStatusDeleteError))
+}
+
+func (s *clusterTestSuite) TestStatusBadge() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+	newCluster := test.NewCluster(t, "1", 1, false, test.Production)
+	defer func() {
+		require.NoError(t, inventory.Delete(newCluster.RuntimeID))
+		require.NoError(t, conn.Close())
+	}()
+
+	clusterState, err := inventory.CreateOrUpdate(1, newCluster)
+	require.NoError(t, err)
+
+	t.Run("Status badge requires a valid token", func(t *testing.T) {
+		_, err := inventory.StatusBadge(newCluster.RuntimeID, "unknown")
+		require.True(t, repository.IsNotFoundError(err))
+
+		token, err := inventory.IssueStatusBadgeToken(newCluster.RuntimeID)
+		require.NoError(t, err)
+		badge, err := inventory.StatusBadge(newCluster.RuntimeID, token)
+		require.NoError(t, err)
+		require.Equal(t, BadgeStatusYellow, badge.Status)
+		require.Nil(t, badge.LastSuccessfulReconcile)
+
+		_, err = inventory.StatusBadge(newCluster.RuntimeID, "unknown")
+		require.True(t, repository.IsNotFoundError(err))
+	})
+
+	t.Run("Status badge reports last successful reconciliation", func(t *testing.T) {
+		token, err := inventory.IssueStatusBadgeToken(newCluster.RuntimeID)
+		require.NoError(t, err)
+		clusterState, err = inventory.UpdateStatus(clusterState, model.ClusterStatusReady)
+		require.NoError(t, err)
+		clusterState, err = inventory.UpdateStatus(clusterState, model.ClusterStatusReconcileError)
+		require.NoError(t, err)
+
+		badge, err := inventory.StatusBadge(newCluster.RuntimeID, token)
+		require.NoError(t, err)
+		require.Equal(t, BadgeStatusRed, badge.Status)
+		require.NotNil(t, badge.LastSuccessfulReconcile)
+	})
+
+	t.Run("Reissued or revoked tokens are invalid", func(t *testing.T) {
+		oldToken, err := inventory.IssueStatusBadgeToken(newCluster.RuntimeID)
+		require.NoError(t, err)
+		newToken, err := inventory.IssueStatusBadgeToken(newCluster.RuntimeID)
+		require.NoError(t, err)
+		_, err = inventory.StatusBadge(newCluster.RuntimeID, oldToken)
+		require.True(t, repository.IsNotFoundError(err))
+
+		require.NoError(t, inventory.RevokeStatusBadgeToken(newCluster.RuntimeID))
+		_, err = inventory.StatusBadge(newCluster.RuntimeID, newToken)
+		require.True(t, repository.IsNotFoundError(err))
+		require.True(t, repository.IsNotFoundError(inventory.RevokeStatusBadgeToken(newCluster.RuntimeID)))
+	})
+}
@@ -0,0 +1,171 @@
+package cluster
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+// ConfigurationDiff returns the changes of the desired state between two configurations of a cluster (previous is nil
+// for new clusters). Values of secret configurations are never part of the diff.
+func ConfigurationDiff(previous, current *model.ClusterConfigurationEntity) *keb.ConfigurationDiff {
+	if previous == nil {
+		previous = &model.ClusterConfigurationEntity{}
+	}
+	diff := &keb.ConfigurationDiff{
+		KymaVersion:    valueChange(previous.KymaVersion, current.KymaVersion),
+		KymaProfile:    valueChange(previous.KymaProfile, current.KymaProfile),
+		Administrators: valueChange(sortedCopy(previous.Administrators), sortedCopy(current.Administrators)),
+	}
+
+	previousComps := make(map[string]*keb.Component, len(previous.Components))
+	for _, comp := range previous.Components {
+		previousComps[comp.Component] = comp
+	}
+	currentComps := make(map[string]bool, len(current.Components))
+	for _, comp := range current.Components {
+		currentComps[comp.Component] = true
+		if change := componentChange(previousComps[comp.Component], comp); change != nil {
+			diff.Components = append(diff.Components, *change)
+		}
+	}
+	for _, comp := range previous.Components {
+		if !currentComps[comp.Component] {
+			diff.Components = append(diff.Components, *componentChange(comp, nil))
+		}
+	}
+	return diff
+}
+
+func componentChange(previous, current *keb.Component) *keb.ComponentChange {
+	change := &keb.ComponentChange{}
+	switch {
+	case previous == nil:
+		change.Component = current.Component
+		change.Change = keb.ChangeTypeAdded
+		previous = &keb.Component{}
+	case current == nil:
+		change.Component = previous.Component
+		change.Change = keb.ChangeTypeRemoved
+		current = &keb.Component{}
+	default:
+		change.Component = current.Component
+		change.Change = keb.ChangeTypeModified
+	}
+	change.Version = valueChange(previous.Version, current.Version)
+	change.Namespace = valueChange(previous.Namespace, current.Namespace)
+	change.URL = valueChange(previous.URL, current.URL)
+	change.Configuration = configurationChanges(previous.Configuration, current.Configuration)
+
+	if change.Change == keb.ChangeTypeModified && change.Version == nil && change.Namespace == nil &&
+		change.URL == nil && len(change.Configuration) == 0 {
+		return nil
+	}
+	return change
+}
+
+func configurationChanges(previous, current []keb.Configuration) []keb.ConfigurationChange {
+	previousValues := make(map[string]keb.Configuration, len(previous))
+	for _, config := range previous {
+		previousValues[config.Key] = config
+	}
+	currentValues := make(map[string]keb.Configuration, len(current))
+	for _, config := range current {
+		currentValues[config.Key] = config
+	}
+
+	var changes []keb.ConfigurationChange
+	for key, config := range currentValues {
+		previousConfig, ok := previousValues[key]
+		switch {
+		case !ok:
+			changes = append(changes, keb.ConfigurationChange{
+				Key: key, Change: keb.ChangeTypeAdded, New: config.Value, Secret: config.Secret,
+			})
+		case !reflect.DeepEqual(previousConfig.Value, config.Value) || previousConfig.Secret != config.Secret:
+			changes = append(changes, keb.ConfigurationChange{
+				Key: key, Change: keb.ChangeTypeModified, Old: previousConfig.Value, New: config.Value,
+				Secret: previousConfig.Secret || config.Secret,
+			})
+		}
+	}
+	for key, config := range previousValues {
+		if _, ok := currentValues[key]; !ok {
+			changes = append(changes, keb.ConfigurationChange{
+				Key: key, Change: keb.ChangeTypeRemoved, Old: config.Value, Secret: config.Secret,
+			})
+		}
+	}
+
+	for idx := range changes {
+		if changes[idx].Secret { //only the key of a secret is revealed
+			changes[idx].Old = nil
+			changes[idx].New = nil
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// valueChange returns nil if both values are equal
+func valueChange(previous, current interface{}) *keb.ValueChange {
+	if reflect.DeepEqual(previous, current) {
+		return nil
+	}
+	return &keb.ValueChange{Old: previous, New: current}
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := append([]string{}, values...)
+	sort.Strings(result)
+	return result
+}
+
+// recordChange stores the diff between the previous and the new configuration of a cluster in its change history
+func (i *DefaultInventory) recordChange(previous, current *model.ClusterConfigurationEntity) error {
+	changeEntity := &model.ClusterChangeEntity{
+		RuntimeID:     current.RuntimeID,
+		ConfigVersion: current.Version,
+		Generation:    current.Generation,
+		Actor:         i.actor,
+		Diff:          ConfigurationDiff(previous, current),
+	}
+	q, err := db.NewQuery(i.Conn, changeEntity, i.Logger)
+	if err != nil {
+		return err
+	}
+	return q.Insert().Exec()
+}
+
+// ConfigurationChanges returns the latest changes of the desired configuration of a cluster (newest first)
+func (i *DefaultInventory) ConfigurationChanges(runtimeID string, limit int) ([]*model.ClusterChangeEntity, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit of configuration changes has to be > 0 (was %d)", limit)
+	}
+	q, err := db.NewQuery(i.Conn, &model.ClusterChangeEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{model.ClusterChangeEntityFields.RuntimeID: runtimeID}).
+		OrderBy(map[string]string{model.ClusterChangeEntityFields.ID: "desc"}).
+		Limit(limit).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.ClusterChangeEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.ClusterChangeEntity))
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigurationDiff(t *testing.T) {
+	previous := &model.ClusterConfigurationEntity{
+		KymaVersion:    "2.0.0",
+		KymaProfile:    "evaluation",
+		Administrators: []string{"b@example.com", "a@example.com"},
+		Components: []*keb.Component{
+			{Component: "istio", Namespace: "istio-system", Version: "1.0", Configuration: []keb.Configuration{
+				{Key: "replicas", Value: 1},
+				{Key: "password", Value: "old", Secret: true},
+				{Key: "obsolete", Value: true},
+			}},
+			{Component: "logging", Namespace: "kyma-system"},
+			{Component: "monitoring", Namespace: "kyma-system"},
+		},
+	}
+
+	t.Run("New cluster", func(t *testing.T) {
+		diff := ConfigurationDiff(nil, previous)
+		require.Equal(t, &keb.ValueChange{Old: "", New: "2.0.0"}, diff.KymaVersion)
+		require.Equal(t, &keb.ValueChange{Old: []string(nil), New: []string{"a@example.com", "b@example.com"}},
+			diff.Administrators)
+		require.Len(t, diff.Components, 3)
+		require.Equal(t, keb.ChangeTypeAdded, diff.Components[0].Change)
+		require.Equal(t, []keb.ConfigurationChange{
+			{Key: "obsolete", Change: keb.ChangeTypeAdded, New: true},
+			{Key: "password", Change: keb.ChangeTypeAdded, Secret: true},
+			{Key: "replicas", Change: keb.ChangeTypeAdded, New: 1},
+		}, diff.Components[0].Configuration)
+	})
+
+	t.Run("Unchanged cluster", func(t *testing.T) {
+		current := *previous
+		current.Administrators = []string{"a@example.com", "b@example.com"} //order is irrelevant
+		require.Equal(t, &keb.ConfigurationDiff{}, ConfigurationDiff(previous, &current))
+	})
+
+	t.Run("Modified cluster", func(t *testing.T) {
+		current := &model.ClusterConfigurationEntity{
+			KymaVersion:    "2.1.0",
+			KymaProfile:    "evaluation",
+			Administrators: []string{"a@example.com", "b@example.com"},
+			Components: []*keb.Component{
+				{Component: "istio", Namespace: "istio-system", Version: "1.1", Configuration: []keb.Configuration{
+					{Key: "replicas", Value: 3},
+					{Key: "password", Value: "new", Secret: true},
+				}},
+				{Component: "logging", Namespace: "kyma-system"},
+				{Component: "eventing", Namespace: "kyma-system"},
+			},
+		}
+		diff := ConfigurationDiff(previous, current)
+		require.Equal(t, &keb.ValueChange{Old: "2.0.0", New: "2.1.0"}, diff.KymaVersion)
+		require.Nil(t, diff.KymaProfile)
+		require.Nil(t, diff.Administrators)
+		require.Equal(t, []keb.ComponentChange{
+			{
+				Component: "istio",
+				Change:    keb.ChangeTypeModified,
+				Version:   &keb.ValueChange{Old: "1.0", New: "1.1"},
+				Configuration: []keb.ConfigurationChange{
+					{Key: "obsolete", Change: keb.ChangeTypeRemoved, Old: true},
+					{Key: "password", Change: keb.ChangeTypeModified, Secret: true}, //secret values are never revealed
+					{Key: "replicas", Change: keb.ChangeTypeModified, Old: 1, New: 3},
+				},
+			},
+			{
+				Component: "eventing",
+				Change:    keb.ChangeTypeAdded,
+				Namespace: &keb.ValueChange{Old: "", New: "kyma-system"},
+			},
+			{
+				Component: "monitoring",
+				Change:    keb.ChangeTypeRemoved,
+				Namespace: &keb.ValueChange{Old: "kyma-system", New: ""},
+			},
+		}, diff.Components)
+	})
+}
@@ -0,0 +1,50 @@
+package cluster
+
+import (
+	"github.com/Masterminds/semver/v3"
+)
+
+// ComponentInstallation is a cluster which runs a component in a particular version
+type ComponentInstallation struct {
+	State   *State
+	Version string
+}
+
+// ClustersRunningComponent returns the clusters whose latest configuration includes the component. The version
+// filter is optional and can be an exact version (e.g. '2.4.1' or 'main') or a semantic version range (e.g. '< 2.0.0').
+func ClustersRunningComponent(states []*State, component, version string) []*ComponentInstallation {
+	matches := newVersionMatcher(version)
+	var result []*ComponentInstallation
+	for _, state := range states {
+		comp := state.Configuration.GetComponent(component)
+		if comp == nil {
+			continue
+		}
+		installedVersion := componentVersion(state.Configuration, comp.Version)
+		if !matches(installedVersion) {
+			continue
+		}
+		result = append(result, &ComponentInstallation{
+			State:   state,
+			Version: installedVersion,
+		})
+	}
+	return result
+}
+
+func newVersionMatcher(version string) func(installed string) bool {
+	if version == "" {
+		return func(string) bool { return true }
+	}
+	if _, err := semver.NewVersion(version); err == nil {
+		return func(installed string) bool { return installed == version }
+	}
+	constraint, err := semver.NewConstraint(version)
+	if err != nil { //not a range: non-semantic versions are matched exactly
+		return func(installed string) bool { return installed == version }
+	}
+	return func(installed string) bool {
+		installedVersion, err := semver.NewVersion(installed)
+		return err == nil && constraint.Check(installedVersion)
+	}
+}
@@ -0,0 +1,55 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClustersRunningComponent(t *testing.T) {
+	newState := func(runtimeID, kymaVersion string, components ...*keb.Component) *State {
+		return &State{
+			Cluster: &model.ClusterEntity{RuntimeID: runtimeID},
+			Configuration: &model.ClusterConfigurationEntity{
+				RuntimeID:   runtimeID,
+				KymaVersion: kymaVersion,
+				Components:  components,
+			},
+		}
+	}
+	states := []*State{
+		newState("runtime1", "2.4.0", &keb.Component{Component: "istio"}),
+		newState("runtime2", "2.4.0", &keb.Component{Component: "istio", Version: "1.9.2"}),
+		newState("runtime3", "main", &keb.Component{Component: "istio"}),
+		newState("runtime4", "2.4.0", &keb.Component{Component: "serverless"}),
+	}
+	runtimeIDs := func(installations []*ComponentInstallation) []string {
+		var result []string
+		for _, installation := range installations {
+			result = append(result, installation.State.Cluster.RuntimeID)
+		}
+		return result
+	}
+
+	t.Run("All versions", func(t *testing.T) {
+		installations := ClustersRunningComponent(states, "istio", "")
+		require.Equal(t, []string{"runtime1", "runtime2", "runtime3"}, runtimeIDs(installations))
+		require.Equal(t, "2.4.0", installations[0].Version) //falls back to Kyma version
+		require.Equal(t, "1.9.2", installations[1].Version)
+	})
+
+	t.Run("Exact version", func(t *testing.T) {
+		require.Equal(t, []string{"runtime2"}, runtimeIDs(ClustersRunningComponent(states, "istio", "1.9.2")))
+		require.Equal(t, []string{"runtime3"}, runtimeIDs(ClustersRunningComponent(states, "istio", "main")))
+	})
+
+	t.Run("Version range", func(t *testing.T) {
+		require.Equal(t, []string{"runtime2"}, runtimeIDs(ClustersRunningComponent(states, "istio", "< 2.0.0")))
+	})
+
+	t.Run("Unknown component", func(t *testing.T) {
+		require.Empty(t, ClustersRunningComponent(states, "unknown", ""))
+	})
+}
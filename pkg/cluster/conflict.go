@@ -0,0 +1,18 @@
+package cluster
+
+import (
+	"fmt"
+)
+
+// ConfigurationConflictError is returned if a cluster configuration was updated with an outdated generation:
+// the configuration was changed by somebody else in between.
+type ConfigurationConflictError struct {
+	RuntimeID string
+	Expected  int64
+	Current   int64
+}
+
+func (e *ConfigurationConflictError) Error() string {
+	return fmt.Sprintf("configuration of cluster '%s' was modified concurrently: expected generation %d "+
+		"but current generation is %d", e.RuntimeID, e.Expected, e.Current)
+}
@@ -0,0 +1,252 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+// DeletionConfirmationError is returned if the token passed to a cluster deletion is unknown,
+// expired or was issued for another cluster.
+type DeletionConfirmationError struct {
+	RuntimeID string
+}
+
+func (e *DeletionConfirmationError) Error() string {
+	return fmt.Sprintf("deletion of cluster '%s' is not confirmed: the confirmation token is invalid or expired",
+		e.RuntimeID)
+}
+
+func (i *DefaultInventory) ProtectFromDeletion(runtimeID string) (*model.DeletionProtectionEntity, error) {
+	protection := &model.DeletionProtectionEntity{
+		RuntimeID: runtimeID,
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, protection, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec(); err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to protect cluster '%s' from deletion", runtimeID))
+	}
+	return protection, nil
+}
+
+func (i *DefaultInventory) UnprotectFromDeletion(runtimeID string) error {
+	q, err := db.NewQuery(i.Conn, &model.DeletionProtectionEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	whereCond := map[string]interface{}{"RuntimeID": runtimeID}
+	deleted, err := q.Delete().Where(whereCond).Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return i.NewNotFoundError(fmt.Errorf("cluster '%s' is not protected from deletion", runtimeID),
+			&model.DeletionProtectionEntity{}, whereCond)
+	}
+	return nil
+}
+
+func (i *DefaultInventory) IsProtectedFromDeletion(runtimeID string) (bool, error) {
+	q, err := db.NewQuery(i.Conn, &model.DeletionProtectionEntity{}, i.Logger)
+	if err != nil {
+		return false, err
+	}
+	protections, err := q.Select().Where(map[string]interface{}{"RuntimeID": runtimeID}).GetMany()
+	if err != nil {
+		return false, err
+	}
+	return len(protections) > 0, nil
+}
+
+// RequestDeletion issues a token which confirms the deletion of the cluster until the TTL is exceeded.
+// Expired tokens of the cluster are dropped.
+func (i *DefaultInventory) RequestDeletion(runtimeID string, ttl time.Duration) (*model.DeletionConfirmationEntity, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("TTL of deletion confirmation for cluster '%s' has to be > 0", runtimeID)
+	}
+	now := time.Now().UTC()
+	confirmation := &model.DeletionConfirmationEntity{
+		Token:     uuid.NewString(),
+		RuntimeID: runtimeID,
+		Expires:   now.Add(ttl),
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		if err := i.deleteExpiredDeletionConfirmations(tx, runtimeID, now); err != nil {
+			return err
+		}
+		q, err := db.NewQuery(tx, confirmation, i.Logger)
+		if err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to request deletion of cluster '%s'", runtimeID))
+	}
+	return confirmation, nil
+}
+
+// ConfirmDeletion consumes the deletion token of the cluster and executes the deletion within the same
+// transaction: the token stays valid if the deletion fails. A DeletionConfirmationError is returned
+// if the token is unknown, expired or belongs to another cluster.
+func (i *DefaultInventory) ConfirmDeletion(runtimeID, token string, deletion func(iTx Inventory) error) error {
+	dbOps := func(tx *db.TxConnection) error {
+		now := time.Now().UTC()
+		if err := i.deleteExpiredDeletionConfirmations(tx, runtimeID, now); err != nil {
+			return err
+		}
+		q, err := db.NewQuery(tx, &model.DeletionConfirmationEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		deleted, err := q.Delete().Where(map[string]interface{}{
+			"Token":     token,
+			"RuntimeID": runtimeID,
+		}).Exec()
+		if err != nil {
+			return err
+		}
+		if deleted == 0 {
+			return &DeletionConfirmationError{RuntimeID: runtimeID}
+		}
+		iTx, err := i.WithTx(tx)
+		if err != nil {
+			return err
+		}
+		return deletion(iTx)
+	}
+	return db.Transaction(i.Conn, dbOps, i.Logger)
+}
+
+func (i *DefaultInventory) deleteExpiredDeletionConfirmations(tx *db.TxConnection, runtimeID string, now time.Time) error {
+	q, err := db.NewQuery(tx, &model.DeletionConfirmationEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	_, err = q.Delete().
+		Where(map[string]interface{}{model.DeletionConfirmationEntityFields.RuntimeID: runtimeID}).
+		WhereCompare(model.DeletionConfirmationEntityFields.Expires, db.LessOrEqual, now).
+		Exec()
+	return err
+}
+
+// ScheduleDeletion soft-deletes the cluster: its teardown is triggered not before the given point in time.
+// If the cluster is already soft-deleted, the existing scheduled deletion is returned unchanged.
+func (i *DefaultInventory) ScheduleDeletion(runtimeID string, teardownAfter time.Time) (*model.ScheduledDeletionEntity, error) {
+	if teardownAfter.IsZero() {
+		return nil, fmt.Errorf("point in time of scheduled deletion for cluster '%s' is undefined", runtimeID)
+	}
+	var deletion *model.ScheduledDeletionEntity
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, &model.ScheduledDeletionEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		entities, err := q.Select().Where(map[string]interface{}{"RuntimeID": runtimeID}).GetMany()
+		if err != nil {
+			return err
+		}
+		if len(entities) > 0 {
+			deletion = entities[0].(*model.ScheduledDeletionEntity)
+			return nil
+		}
+		deletion = &model.ScheduledDeletionEntity{
+			RuntimeID:     runtimeID,
+			TeardownAfter: teardownAfter.UTC(),
+		}
+		insertQ, err := db.NewQuery(tx, deletion, i.Logger)
+		if err != nil {
+			return err
+		}
+		return insertQ.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to schedule deletion of cluster '%s'", runtimeID))
+	}
+	return deletion, nil
+}
+
+// CancelScheduledDeletion reverts the soft-deletion of a cluster
+func (i *DefaultInventory) CancelScheduledDeletion(runtimeID string) error {
+	q, err := db.NewQuery(i.Conn, &model.ScheduledDeletionEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	whereCond := map[string]interface{}{"RuntimeID": runtimeID}
+	deleted, err := q.Delete().Where(whereCond).Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return i.NewNotFoundError(fmt.Errorf("no scheduled deletion of cluster '%s' found", runtimeID),
+			&model.ScheduledDeletionEntity{}, whereCond)
+	}
+	return nil
+}
+
+// TriggerScheduledDeletion marks a soft-deleted cluster as delete-pending and removes its scheduled deletion
+// within one transaction
+func (i *DefaultInventory) TriggerScheduledDeletion(state *State) (*State, error) {
+	return i.updateStatusAndRun(state, model.ClusterStatusDeletePending, model.InitiatorScheduler, model.ReasonDeletion, func(iTx *DefaultInventory) error {
+		err := iTx.CancelScheduledDeletion(state.Cluster.RuntimeID)
+		if err != nil && !repository.IsNotFoundError(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+// ScheduledDeletions returns the soft-deleted clusters (or only the given cluster if the runtimeID is not empty)
+// ordered by their teardown time
+func (i *DefaultInventory) ScheduledDeletions(runtimeID string) ([]*model.ScheduledDeletionEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ScheduledDeletionEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	selectQ := q.Select()
+	if runtimeID != "" {
+		selectQ.Where(map[string]interface{}{"RuntimeID": runtimeID})
+	}
+	entities, err := selectQ.OrderBy(map[string]string{"TeardownAfter": "asc"}).GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return toScheduledDeletions(entities), nil
+}
+
+// DueScheduledDeletions returns the soft-deleted clusters whose grace period is over
+func (i *DefaultInventory) DueScheduledDeletions(now time.Time) ([]*model.ScheduledDeletionEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ScheduledDeletionEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		WhereCompare(model.ScheduledDeletionEntityFields.TeardownAfter, db.LessOrEqual, now).
+		OrderBy(map[string]string{model.ScheduledDeletionEntityFields.TeardownAfter: "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return toScheduledDeletions(entities), nil
+}
+
+func toScheduledDeletions(entities []db.DatabaseEntity) []*model.ScheduledDeletionEntity {
+	deletions := make([]*model.ScheduledDeletionEntity, 0, len(entities))
+	for _, entity := range entities {
+		deletions = append(deletions, entity.(*model.ScheduledDeletionEntity))
+	}
+	return deletions
+}
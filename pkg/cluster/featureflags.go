@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+var featureFlagNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$`)
+
+// FeatureFlagEnabled returns true if the feature flag is enabled for the cluster. Clusters are assigned to a stable
+// bucket (0-99) per flag: increasing the rollout percentage keeps the flag enabled for all previously targeted
+// clusters.
+func FeatureFlagEnabled(flag *model.FeatureFlagEntity, runtimeID string) bool {
+	for _, disabled := range flag.DisabledClusters {
+		if disabled == runtimeID {
+			return false
+		}
+	}
+	for _, enabled := range flag.EnabledClusters {
+		if enabled == runtimeID {
+			return true
+		}
+	}
+	return int64(featureFlagBucket(flag.Name, runtimeID)) < flag.RolloutPercentage
+}
+
+func featureFlagBucket(name, runtimeID string) uint32 {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name + "/" + runtimeID))
+	return hash.Sum32() % 100
+}
+
+// ValidateFeatureFlag checks the name and the rollout percentage of the feature flag
+func ValidateFeatureFlag(flag *model.FeatureFlagEntity) error {
+	if !featureFlagNamePattern.MatchString(flag.Name) {
+		return fmt.Errorf("feature flag name '%s' is invalid: use lowercase alphanumeric characters, '.' or '-'",
+			flag.Name)
+	}
+	if flag.RolloutPercentage < 0 || flag.RolloutPercentage > 100 {
+		return fmt.Errorf("rollout percentage of feature flag '%s' has to be within 0 and 100 (was %d)",
+			flag.Name, flag.RolloutPercentage)
+	}
+	return nil
+}
+
+// SaveFeatureFlag creates the feature flag or replaces its targeting
+func (i *DefaultInventory) SaveFeatureFlag(flag *model.FeatureFlagEntity) (*model.FeatureFlagEntity, error) {
+	if err := ValidateFeatureFlag(flag); err != nil {
+		return nil, err
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, flag, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().
+			Where(map[string]interface{}{model.FeatureFlagEntityFields.Name: flag.Name}).
+			Exec(); err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to save feature flag '%s'", flag.Name))
+	}
+	return flag, nil
+}
+
+// RemoveFeatureFlag disables the feature flag for all clusters
+func (i *DefaultInventory) RemoveFeatureFlag(name string) error {
+	q, err := db.NewQuery(i.Conn, &model.FeatureFlagEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	whereCond := map[string]interface{}{model.FeatureFlagEntityFields.Name: name}
+	deleted, err := q.Delete().Where(whereCond).Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return i.NewNotFoundError(fmt.Errorf("feature flag '%s' not found", name),
+			&model.FeatureFlagEntity{}, whereCond)
+	}
+	return nil
+}
+
+func (i *DefaultInventory) FeatureFlags() ([]*model.FeatureFlagEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.FeatureFlagEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{model.FeatureFlagEntityFields.Name: "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	flags := make([]*model.FeatureFlagEntity, 0, len(entities))
+	for _, entity := range entities {
+		flags = append(flags, entity.(*model.FeatureFlagEntity))
+	}
+	return flags, nil
+}
+
+// EnabledFeatureFlags returns the sorted names of the feature flags which are enabled for the cluster
+func (i *DefaultInventory) EnabledFeatureFlags(runtimeID string) ([]string, error) {
+	flags, err := i.FeatureFlags()
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, flag := range flags {
+		if FeatureFlagEnabled(flag, runtimeID) {
+			result = append(result, flag.Name)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
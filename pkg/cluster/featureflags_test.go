@@ -0,0 +1,104 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlagEnabled(t *testing.T) {
+	t.Run("Explicit targeting overrules the rollout percentage", func(t *testing.T) {
+		flag := &model.FeatureFlagEntity{
+			Name:              "flag",
+			RolloutPercentage: 100,
+			EnabledClusters:   []string{"runtime2"},
+			DisabledClusters:  []string{"runtime1", "runtime2"},
+		}
+		require.False(t, FeatureFlagEnabled(flag, "runtime1"))
+		require.False(t, FeatureFlagEnabled(flag, "runtime2")) //disabled wins
+		require.True(t, FeatureFlagEnabled(flag, "runtime3"))
+
+		flag.RolloutPercentage = 0
+		flag.DisabledClusters = nil
+		require.True(t, FeatureFlagEnabled(flag, "runtime2"))
+		require.False(t, FeatureFlagEnabled(flag, "runtime3"))
+	})
+
+	t.Run("Increasing the rollout percentage keeps enabled clusters", func(t *testing.T) {
+		flag := &model.FeatureFlagEntity{Name: "flag", RolloutPercentage: 20}
+		var enabled []string
+		for i := 0; i < 200; i++ {
+			runtimeID := fmt.Sprintf("runtime%d", i)
+			if FeatureFlagEnabled(flag, runtimeID) {
+				enabled = append(enabled, runtimeID)
+			}
+		}
+		require.NotEmpty(t, enabled)
+		require.Less(t, len(enabled), 100)
+
+		flag.RolloutPercentage = 50
+		for _, runtimeID := range enabled {
+			require.True(t, FeatureFlagEnabled(flag, runtimeID))
+		}
+	})
+}
+
+func (s *clusterTestSuite) TestFeatureFlags() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+
+	t.Run("Invalid feature flags are rejected", func(t *testing.T) {
+		_, err := inventory.SaveFeatureFlag(&model.FeatureFlagEntity{Name: "Invalid Name"})
+		require.Error(t, err)
+		_, err = inventory.SaveFeatureFlag(&model.FeatureFlagEntity{Name: "flag", RolloutPercentage: 101})
+		require.Error(t, err)
+	})
+
+	t.Run("Save, evaluate and remove feature flags", func(t *testing.T) {
+		_, err := inventory.SaveFeatureFlag(&model.FeatureFlagEntity{
+			Name:            "test.flag-b",
+			EnabledClusters: []string{"runtime1"},
+		})
+		require.NoError(t, err)
+		_, err = inventory.SaveFeatureFlag(&model.FeatureFlagEntity{
+			Name:              "test.flag-a",
+			RolloutPercentage: 100,
+			DisabledClusters:  []string{"runtime2"},
+		})
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, inventory.RemoveFeatureFlag("test.flag-a"))
+		}()
+
+		flags, err := inventory.FeatureFlags()
+		require.NoError(t, err)
+		require.Len(t, flags, 2)
+		require.Equal(t, "test.flag-a", flags[0].Name)
+		require.Equal(t, []string{"runtime2"}, flags[0].DisabledClusters)
+
+		enabled, err := inventory.EnabledFeatureFlags("runtime1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"test.flag-a", "test.flag-b"}, enabled)
+		enabled, err = inventory.EnabledFeatureFlags("runtime2")
+		require.NoError(t, err)
+		require.Empty(t, enabled)
+
+		//saving an existing flag replaces its targeting
+		_, err = inventory.SaveFeatureFlag(&model.FeatureFlagEntity{Name: "test.flag-b"})
+		require.NoError(t, err)
+		enabled, err = inventory.EnabledFeatureFlags("runtime1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"test.flag-a"}, enabled)
+
+		require.NoError(t, inventory.RemoveFeatureFlag("test.flag-b"))
+		require.True(t, repository.IsNotFoundError(inventory.RemoveFeatureFlag("test.flag-b")))
+	})
+}
@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+// Freeze stops the scheduling of new operations of the component (an empty component freezes all components). The
+// actor of the inventory is recorded as the user who engaged the freeze. If the component is already frozen, the
+// engaged freeze is returned.
+func (i *DefaultInventory) Freeze(component, reason string) (*model.SchedulingFreezeEntity, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("freeze of component '%s' requires a reason", freezeScope(component))
+	}
+	freeze := &model.SchedulingFreezeEntity{
+		Component: component,
+		Reason:    reason,
+		EngagedBy: i.actor,
+		Updated:   time.Now().UTC(),
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		engaged, err := i.engagedFreeze(tx, component)
+		if err != nil {
+			return err
+		}
+		if engaged != nil {
+			freeze = engaged
+			return nil
+		}
+		q, err := db.NewQuery(tx, freeze, i.Logger)
+		if err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to freeze component '%s'", freezeScope(component)))
+	}
+	return freeze, nil
+}
+
+// ReleaseFreeze resumes the scheduling of the component. The released freeze is kept and records the actor of the
+// inventory as the user who released it.
+func (i *DefaultInventory) ReleaseFreeze(component string) (*model.SchedulingFreezeEntity, error) {
+	var freeze *model.SchedulingFreezeEntity
+	dbOps := func(tx *db.TxConnection) error {
+		engaged, err := i.engagedFreeze(tx, component)
+		if err != nil {
+			return err
+		}
+		if engaged == nil {
+			return i.NewNotFoundError(fmt.Errorf("component '%s' is not frozen", freezeScope(component)),
+				&model.SchedulingFreezeEntity{}, map[string]interface{}{"Component": component, "Released": false})
+		}
+		engaged.Released = true
+		engaged.ReleasedBy = i.actor
+		engaged.Updated = time.Now().UTC()
+		q, err := db.NewQuery(tx, engaged, i.Logger)
+		if err != nil {
+			return err
+		}
+		if err := q.Update().Where(map[string]interface{}{"ID": engaged.ID}).Exec(); err != nil {
+			return err
+		}
+		freeze = engaged
+		return nil
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, err
+	}
+	return freeze, nil
+}
+
+// Freezes returns the engaged freezes. Released freezes are included on request (latest freeze first).
+func (i *DefaultInventory) Freezes(includeReleased bool) ([]*model.SchedulingFreezeEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.SchedulingFreezeEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	query := q.Select()
+	if !includeReleased {
+		query = query.Where(map[string]interface{}{"Released": false})
+	}
+	entities, err := query.OrderBy(map[string]string{"ID": "desc"}).GetMany()
+	if err != nil {
+		return nil, err
+	}
+	freezes := make([]*model.SchedulingFreezeEntity, 0, len(entities))
+	for _, entity := range entities {
+		freezes = append(freezes, entity.(*model.SchedulingFreezeEntity))
+	}
+	return freezes, nil
+}
+
+func (i *DefaultInventory) engagedFreeze(tx *db.TxConnection, component string) (*model.SchedulingFreezeEntity, error) {
+	q, err := db.NewQuery(tx, &model.SchedulingFreezeEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"Component": component, "Released": false}).
+		GetMany()
+	if err != nil || len(entities) == 0 {
+		return nil, err
+	}
+	return entities[0].(*model.SchedulingFreezeEntity), nil
+}
+
+// freezeScope renders the empty component of a fleet-wide freeze
+func freezeScope(component string) string {
+	if component == "" {
+		return "*"
+	}
+	return component
+}
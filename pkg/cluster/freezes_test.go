@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *clusterTestSuite) TestFreezes() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+
+	t.Run("Freeze requires a reason", func(t *testing.T) {
+		_, err := inventory.WithActor("alice").Freeze("istio", " ")
+		require.Error(t, err)
+	})
+
+	t.Run("Engage and release freezes", func(t *testing.T) {
+		fleetFreeze, err := inventory.WithActor("alice").Freeze("", "incident 42")
+		require.NoError(t, err)
+		require.True(t, fleetFreeze.IsFleetWide())
+		require.Equal(t, "alice", fleetFreeze.EngagedBy)
+		componentFreeze, err := inventory.WithActor("bob").Freeze("istio", "broken release")
+		require.NoError(t, err)
+
+		//engaging an engaged freeze keeps it unchanged
+		again, err := inventory.WithActor("bob").Freeze("", "incident 43")
+		require.NoError(t, err)
+		require.Equal(t, fleetFreeze.ID, again.ID)
+		require.Equal(t, "alice", again.EngagedBy)
+
+		freezes, err := inventory.Freezes(false)
+		require.NoError(t, err)
+		require.Len(t, freezes, 2)
+		require.Equal(t, componentFreeze.ID, freezes[0].ID)
+
+		released, err := inventory.WithActor("carol").ReleaseFreeze("")
+		require.NoError(t, err)
+		require.True(t, released.Released)
+		require.Equal(t, "carol", released.ReleasedBy)
+		_, err = inventory.ReleaseFreeze("")
+		require.True(t, repository.IsNotFoundError(err))
+		_, err = inventory.WithActor("carol").ReleaseFreeze("istio")
+		require.NoError(t, err)
+
+		freezes, err = inventory.Freezes(false)
+		require.NoError(t, err)
+		require.Empty(t, freezes)
+
+		//released freezes are kept as audit trail
+		freezes, err = inventory.Freezes(true)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(freezes), 2)
+		require.Equal(t, "alice", freezes[1].EngagedBy)
+		require.Equal(t, "carol", freezes[1].ReleasedBy)
+	})
+}
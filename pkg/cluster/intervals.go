@@ -0,0 +1,167 @@
+package cluster
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+)
+
+// ReconcileIntervalPolicy resolves the reconcile interval of clusters and their components. Overrides are
+// resolved by their specificity: cluster and component > component > cluster > global interval.
+type ReconcileIntervalPolicy struct {
+	global    time.Duration
+	jitter    float64
+	overrides map[reconcileIntervalKey]time.Duration
+}
+
+type reconcileIntervalKey struct {
+	runtimeID string
+	component string
+}
+
+// NewReconcileIntervalPolicy creates a policy for the given overrides. The jitter is a fraction of the interval
+// (0..1) which is added to the interval of each cluster to avoid that many clusters get reconciled at once.
+func NewReconcileIntervalPolicy(global time.Duration, jitter float64, overrides []*model.ReconcileIntervalEntity) *ReconcileIntervalPolicy {
+	policy := &ReconcileIntervalPolicy{
+		global:    global,
+		jitter:    jitter,
+		overrides: make(map[reconcileIntervalKey]time.Duration, len(overrides)),
+	}
+	for _, override := range overrides {
+		policy.overrides[reconcileIntervalKey{override.RuntimeID, override.Component}] = override.Duration()
+	}
+	return policy
+}
+
+// MinInterval returns the shortest configured interval (jitter excluded)
+func (p *ReconcileIntervalPolicy) MinInterval() time.Duration {
+	result := p.global
+	for _, interval := range p.overrides {
+		if interval < result {
+			result = interval
+		}
+	}
+	return result
+}
+
+// ClusterInterval returns the interval of a cluster including its jitter
+func (p *ReconcileIntervalPolicy) ClusterInterval(runtimeID string) time.Duration {
+	interval, ok := p.overrides[reconcileIntervalKey{runtimeID: runtimeID}]
+	if !ok {
+		interval = p.global
+	}
+	return p.withJitter(runtimeID, interval)
+}
+
+// ComponentInterval returns the interval of a component of a cluster including the jitter of the cluster
+func (p *ReconcileIntervalPolicy) ComponentInterval(runtimeID, component string) time.Duration {
+	for _, key := range []reconcileIntervalKey{{runtimeID, component}, {"", component}} {
+		if interval, ok := p.overrides[key]; ok {
+			return p.withJitter(runtimeID, interval)
+		}
+	}
+	return p.ClusterInterval(runtimeID)
+}
+
+// DueInterval returns the interval after which the cluster has to be reconciled: the shortest interval of its components
+func (p *ReconcileIntervalPolicy) DueInterval(state *State) time.Duration {
+	result := p.ClusterInterval(state.Cluster.RuntimeID)
+	if state.Configuration == nil {
+		return result
+	}
+	for _, component := range state.Configuration.Components {
+		if interval := p.ComponentInterval(state.Cluster.RuntimeID, component.Component); interval < result {
+			result = interval
+		}
+	}
+	return result
+}
+
+// IsDue returns true if the interval of the cluster elapsed since its latest status change
+func (p *ReconcileIntervalPolicy) IsDue(state *State, now time.Time) bool {
+	return !state.Status.Created.Add(p.DueInterval(state)).After(now)
+}
+
+// withJitter extends the interval by a fraction which is derived from the runtimeID: the jitter of a cluster
+// is stable and the clusters are spread evenly over the jitter range
+func (p *ReconcileIntervalPolicy) withJitter(runtimeID string, interval time.Duration) time.Duration {
+	if p.jitter <= 0 || interval <= 0 {
+		return interval
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(runtimeID))
+	spread := float64(hash.Sum32()%1000) / 1000
+	return interval + time.Duration(float64(interval)*p.jitter*spread)
+}
+
+func (i *DefaultInventory) SetReconcileInterval(runtimeID, component string, interval time.Duration) (*model.ReconcileIntervalEntity, error) {
+	if interval < time.Second {
+		return nil, fmt.Errorf("reconcile interval has to be >= 1s but was %v", interval)
+	}
+	override := &model.ReconcileIntervalEntity{
+		RuntimeID: runtimeID,
+		Component: component,
+		Seconds:   int64(interval.Seconds()),
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, override, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().Where(map[string]interface{}{
+			"RuntimeID": runtimeID,
+			"Component": component,
+		}).Exec(); err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to set reconcile interval (cluster: '%s', component: '%s')",
+			runtimeID, component))
+	}
+	return override, nil
+}
+
+func (i *DefaultInventory) RemoveReconcileInterval(runtimeID, component string) error {
+	q, err := db.NewQuery(i.Conn, &model.ReconcileIntervalEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	whereCond := map[string]interface{}{
+		"RuntimeID": runtimeID,
+		"Component": component,
+	}
+	deleted, err := q.Delete().Where(whereCond).Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return i.NewNotFoundError(fmt.Errorf("no reconcile interval defined (cluster: '%s', component: '%s')",
+			runtimeID, component), &model.ReconcileIntervalEntity{}, whereCond)
+	}
+	return nil
+}
+
+// ReconcileIntervals returns all reconcile interval overrides
+func (i *DefaultInventory) ReconcileIntervals() ([]*model.ReconcileIntervalEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ReconcileIntervalEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{"RuntimeID": "asc", "Component": "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	overrides := make([]*model.ReconcileIntervalEntity, 0, len(entities))
+	for _, entity := range entities {
+		overrides = append(overrides, entity.(*model.ReconcileIntervalEntity))
+	}
+	return overrides, nil
+}
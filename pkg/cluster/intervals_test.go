@@ -0,0 +1,68 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileIntervalPolicy(t *testing.T) {
+	override := func(runtimeID, component string, interval time.Duration) *model.ReconcileIntervalEntity {
+		return &model.ReconcileIntervalEntity{RuntimeID: runtimeID, Component: component, Seconds: int64(interval.Seconds())}
+	}
+	newState := func(runtimeID string, lastChange time.Time, components ...string) *State {
+		state := &State{
+			Cluster:       &model.ClusterEntity{RuntimeID: runtimeID},
+			Configuration: &model.ClusterConfigurationEntity{RuntimeID: runtimeID},
+			Status:        &model.ClusterStatusEntity{RuntimeID: runtimeID, Created: lastChange},
+		}
+		for _, component := range components {
+			state.Configuration.Components = append(state.Configuration.Components, &keb.Component{Component: component})
+		}
+		return state
+	}
+
+	policy := NewReconcileIntervalPolicy(24*time.Hour, 0, []*model.ReconcileIntervalEntity{
+		override("", "monitoring", time.Hour),
+		override("", "istio", 48*time.Hour),
+		override("cluster1", "", 12*time.Hour),
+		override("cluster1", "istio", 6*time.Hour),
+	})
+
+	t.Run("Resolve intervals by specificity", func(t *testing.T) {
+		require.Equal(t, 24*time.Hour, policy.ClusterInterval("cluster2"))
+		require.Equal(t, 12*time.Hour, policy.ClusterInterval("cluster1"))
+		require.Equal(t, 6*time.Hour, policy.ComponentInterval("cluster1", "istio"))
+		require.Equal(t, 48*time.Hour, policy.ComponentInterval("cluster2", "istio"))
+		require.Equal(t, time.Hour, policy.ComponentInterval("cluster1", "monitoring"))
+		require.Equal(t, 12*time.Hour, policy.ComponentInterval("cluster1", "serverless"))
+		require.Equal(t, 24*time.Hour, policy.ComponentInterval("cluster2", "serverless"))
+		require.Equal(t, time.Hour, policy.MinInterval())
+	})
+
+	t.Run("Cluster is due after the shortest interval of its components", func(t *testing.T) {
+		now := time.Now()
+		require.Equal(t, time.Hour, policy.DueInterval(newState("cluster2", now, "istio", "monitoring")))
+		require.True(t, policy.IsDue(newState("cluster2", now.Add(-2*time.Hour), "istio", "monitoring"), now))
+		require.False(t, policy.IsDue(newState("cluster2", now.Add(-2*time.Hour), "istio", "serverless"), now))
+		require.True(t, policy.IsDue(newState("cluster2", now.Add(-25*time.Hour), "istio", "serverless"), now))
+	})
+
+	t.Run("Jitter extends intervals by a stable fraction", func(t *testing.T) {
+		jittered := NewReconcileIntervalPolicy(time.Hour, 0.5, nil)
+		interval := jittered.ClusterInterval("cluster1")
+		require.GreaterOrEqual(t, interval, time.Hour)
+		require.Less(t, interval, 90*time.Minute)
+		require.Equal(t, interval, jittered.ClusterInterval("cluster1"))
+
+		//clusters are spread over the jitter range
+		intervals := make(map[time.Duration]bool)
+		for _, runtimeID := range []string{"cluster1", "cluster2", "cluster3", "cluster4"} {
+			intervals[jittered.ClusterInterval(runtimeID)] = true
+		}
+		require.Greater(t, len(intervals), 1)
+	})
+}
@@ -18,6 +18,7 @@ import (
 
 type Inventory interface {
 	CreateOrUpdate(contractVersion int64, cluster *keb.Cluster) (*State, error)
+	UpdateIfMatch(contractVersion int64, cluster *keb.Cluster, generation int64) (*State, error)
 	UpdateStatus(State *State, status model.Status) (*State, error)
 	MarkForDeletion(runtimeID string) (*State, error)
 	Delete(runtimeID string) error
@@ -31,12 +32,50 @@ type Inventory interface {
 	WithTx(tx *db.TxConnection) (Inventory, error)
 	RemoveStatusesWithoutReconciliations(timeout time.Duration, statusCleanupBatchSize int) (int, error)
 	RemoveDeletedClustersOlderThan(deadline time.Time) (int, error)
+	PinComponent(runtimeID, component, version string) (*model.ComponentPinEntity, error)
+	UnpinComponent(runtimeID, component string) error
+	ComponentPins(runtimeID string) ([]*model.ComponentPinEntity, error)
+	ScheduleReconciliation(runtimeID string, notBefore time.Time) (*model.ScheduledReconciliationEntity, error)
+	CancelScheduledReconciliation(id string) error
+	TriggerScheduledReconciliation(state *State, scheduleID string) (*State, error)
+	ScheduledReconciliations(runtimeID string) ([]*model.ScheduledReconciliationEntity, error)
+	DueScheduledReconciliations(now time.Time) ([]*model.ScheduledReconciliationEntity, error)
+	SetReconcileInterval(runtimeID, component string, interval time.Duration) (*model.ReconcileIntervalEntity, error)
+	RemoveReconcileInterval(runtimeID, component string) error
+	ReconcileIntervals() ([]*model.ReconcileIntervalEntity, error)
+	ProtectFromDeletion(runtimeID string) (*model.DeletionProtectionEntity, error)
+	UnprotectFromDeletion(runtimeID string) error
+	IsProtectedFromDeletion(runtimeID string) (bool, error)
+	RequestDeletion(runtimeID string, ttl time.Duration) (*model.DeletionConfirmationEntity, error)
+	ConfirmDeletion(runtimeID, token string, deletion func(iTx Inventory) error) error
+	ScheduleDeletion(runtimeID string, teardownAfter time.Time) (*model.ScheduledDeletionEntity, error)
+	CancelScheduledDeletion(runtimeID string) error
+	TriggerScheduledDeletion(state *State) (*State, error)
+	ScheduledDeletions(runtimeID string) ([]*model.ScheduledDeletionEntity, error)
+	DueScheduledDeletions(now time.Time) ([]*model.ScheduledDeletionEntity, error)
+	TenantClusters(globalAccountID string) ([]string, error)
+	SetTenantQuota(globalAccountID string, maxParallelOperations, maxOperationsPerMinute int64) (*model.TenantQuotaEntity, error)
+	RemoveTenantQuota(globalAccountID string) error
+	TenantQuotas() ([]*model.TenantQuotaEntity, error)
+	ConfigurationChanges(runtimeID string, limit int) ([]*model.ClusterChangeEntity, error)
+	IssueStatusBadgeToken(runtimeID string) (string, error)
+	RevokeStatusBadgeToken(runtimeID string) error
+	StatusBadge(runtimeID, token string) (*StatusBadge, error)
+	SaveFeatureFlag(flag *model.FeatureFlagEntity) (*model.FeatureFlagEntity, error)
+	RemoveFeatureFlag(name string) error
+	FeatureFlags() ([]*model.FeatureFlagEntity, error)
+	EnabledFeatureFlags(runtimeID string) ([]string, error)
+	Freeze(component, reason string) (*model.SchedulingFreezeEntity, error)
+	ReleaseFreeze(component string) (*model.SchedulingFreezeEntity, error)
+	Freezes(includeReleased bool) ([]*model.SchedulingFreezeEntity, error)
+	WithActor(actor string) Inventory
 }
 
 type DefaultInventory struct {
 	*repository.Repository
 	metricsCollector
 	clientSet *kubernetes.Clientset
+	actor     string //recorded in the change history of the configurations
 }
 
 type metricsCollector interface {
@@ -67,11 +106,22 @@ func NewInventory(conn db.Connection, debug bool, collector metricsCollector) (I
 		}
 	}
 
-	return &DefaultInventory{repo, collector, clientSet}, nil
+	return &DefaultInventory{Repository: repo, metricsCollector: collector, clientSet: clientSet}, nil
 }
 
 func (i *DefaultInventory) WithTx(tx *db.TxConnection) (Inventory, error) {
-	return NewInventory(tx, i.Debug, i.metricsCollector)
+	inventory, err := NewInventory(tx, i.Debug, i.metricsCollector)
+	if err != nil {
+		return nil, err
+	}
+	return inventory.WithActor(i.actor), nil
+}
+
+// WithActor returns an inventory which records the given actor as author of configuration changes
+func (i *DefaultInventory) WithActor(actor string) Inventory {
+	inventory := *i
+	inventory.actor = actor
+	return &inventory
 }
 
 func (i *DefaultInventory) CountRetries(runtimeID string, configVersion int64, maxRetries int,
@@ -117,6 +167,31 @@ func statusInSlice(status model.Status, statusList []model.Status) bool {
 }
 
 func (i *DefaultInventory) CreateOrUpdate(contractVersion int64, cluster *keb.Cluster) (*State, error) {
+	return i.createOrUpdate(contractVersion, cluster, nil)
+}
+
+// UpdateIfMatch creates or updates a cluster only if the generation of its latest configuration matches the
+// given generation (zero is expected for clusters which don't exist yet). Otherwise, or if the configuration is
+// changed concurrently, a ConfigurationConflictError is returned.
+func (i *DefaultInventory) UpdateIfMatch(contractVersion int64, cluster *keb.Cluster, generation int64) (*State, error) {
+	return i.createOrUpdate(contractVersion, cluster, &generation)
+}
+
+func (i *DefaultInventory) createOrUpdate(contractVersion int64, cluster *keb.Cluster, expectedGeneration *int64) (*State, error) {
+	if expectedGeneration != nil { //reject outdated updates early, concurrent updates are detected by the DB
+		generation, err := i.latestGeneration(cluster.RuntimeID)
+		if err != nil {
+			return nil, err
+		}
+		if generation != *expectedGeneration {
+			return nil, &ConfigurationConflictError{
+				RuntimeID: cluster.RuntimeID,
+				Expected:  *expectedGeneration,
+				Current:   generation,
+			}
+		}
+	}
+
 	dbOps := func(tx *db.TxConnection) (interface{}, error) {
 		var iTx *DefaultInventory
 		tmpiTx, err := i.WithTx(tx)
@@ -124,15 +199,20 @@ func (i *DefaultInventory) CreateOrUpdate(contractVersion int64, cluster *keb.Cl
 			return nil, err
 		}
 		iTx = tmpiTx.(*DefaultInventory)
+		previousConfigEntity, err := iTx.latestRuntimeConfig(cluster.RuntimeID)
+		if err != nil {
+			return nil, err
+		}
 		clusterEntity, err := iTx.createCluster(contractVersion, cluster)
 		if err != nil {
 			return nil, err
 		}
-		clusterConfigurationEntity, err := iTx.createConfiguration(contractVersion, cluster, clusterEntity)
+		clusterConfigurationEntity, err := iTx.createConfiguration(contractVersion, cluster, clusterEntity, expectedGeneration)
 		if err != nil {
 			return nil, err
 		}
-		clusterStatusEntity, err := iTx.createStatus(clusterConfigurationEntity, model.ClusterStatusReconcilePending)
+		clusterStatusEntity, err := iTx.createTriggeredStatus(clusterConfigurationEntity, model.ClusterStatusReconcilePending,
+			iTx.initiator(), configurationChangeReason(previousConfigEntity, clusterConfigurationEntity))
 		if err != nil {
 			return nil, err
 		}
@@ -145,6 +225,16 @@ func (i *DefaultInventory) CreateOrUpdate(contractVersion int64, cluster *keb.Cl
 
 	state, err := db.TransactionResult(i.Conn, dbOps, i.Logger)
 	if err != nil {
+		var conflictErr *ConfigurationConflictError
+		if errors.As(err, &conflictErr) {
+			if conflictErr.Current == 0 { //conflict detected by the DB: the generation is only known after the rollback
+				if conflictErr.Current, err = i.latestGeneration(cluster.RuntimeID); err != nil {
+					return nil, err
+				}
+			}
+			i.Logger.Warnf("Inventory rejected update of cluster with runtimeID '%s': %s", cluster.RuntimeID, conflictErr)
+			return nil, conflictErr
+		}
 		i.Logger.Errorf("Inventory failed to create/update cluster with runtimeID '%s': %s", cluster.RuntimeID, err)
 		return nil, err
 	}
@@ -192,11 +282,12 @@ func (i *DefaultInventory) overwriteKubeconfig(cluster *model.ClusterEntity) {
 func (i *DefaultInventory) getOrCreateCluster(contractVersion int64, cluster *keb.Cluster) (*model.ClusterEntity,
 	error) {
 	newClusterEntity := &model.ClusterEntity{
-		RuntimeID:  cluster.RuntimeID,
-		Runtime:    &cluster.RuntimeInput,
-		Metadata:   &cluster.Metadata,
-		Kubeconfig: cluster.Kubeconfig,
-		Contract:   contractVersion,
+		RuntimeID:       cluster.RuntimeID,
+		Runtime:         &cluster.RuntimeInput,
+		Metadata:        &cluster.Metadata,
+		GlobalAccountID: cluster.Metadata.GlobalAccountID,
+		Kubeconfig:      cluster.Kubeconfig,
+		Contract:        contractVersion,
 	}
 
 	// check if a new version is required
@@ -226,8 +317,10 @@ func (i *DefaultInventory) getOrCreateCluster(contractVersion int64, cluster *ke
 	return newClusterEntity, nil
 }
 
+// createConfiguration stores the configuration of the cluster if it was changed. The new configuration succeeds
+// the expected generation or, if no generation is expected, the latest generation.
 func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *keb.Cluster,
-	clusterEntity *model.ClusterEntity) (*model.ClusterConfigurationEntity, error) {
+	clusterEntity *model.ClusterEntity, expectedGeneration *int64) (*model.ClusterConfigurationEntity, error) {
 	newConfigEntity := &model.ClusterConfigurationEntity{
 		RuntimeID:      clusterEntity.RuntimeID,
 		ClusterVersion: clusterEntity.Version,
@@ -243,6 +336,9 @@ func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *k
 		Administrators: cluster.KymaConfig.Administrators,
 		Contract:       contractVersion,
 	}
+	if err := i.pinComponents(newConfigEntity); err != nil {
+		return nil, err
+	}
 
 	// check if a new version is required
 	oldConfigEntity, err := i.latestConfig(clusterEntity.Version)
@@ -257,26 +353,117 @@ func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *k
 		return nil, err
 	}
 
+	previousConfigEntity, err := i.latestRuntimeConfig(cluster.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
 	// create new version
+	var generation int64
+	if expectedGeneration == nil {
+		if previousConfigEntity != nil {
+			generation = previousConfigEntity.Generation
+		}
+	} else {
+		generation = *expectedGeneration
+	}
+	newConfigEntity.Generation = generation + 1
 	q, err := db.NewQuery(i.Conn, newConfigEntity, i.Logger)
 	if err != nil {
 		return nil, err
 	}
 	err = q.Insert().Exec()
+	if db.IsUniqueViolation(err) { //a concurrent update created the same generation
+		return nil, &ConfigurationConflictError{RuntimeID: cluster.RuntimeID, Expected: generation}
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if err := i.recordChange(previousConfigEntity, newConfigEntity); err != nil {
+		return nil, err
+	}
 	return newConfigEntity, nil
 }
 
+// latestGeneration returns the generation of the latest configuration of a cluster (zero if the cluster has
+// no configuration yet)
+func (i *DefaultInventory) latestGeneration(runtimeID string) (int64, error) {
+	configEntity, err := i.latestRuntimeConfig(runtimeID)
+	if err != nil || configEntity == nil {
+		return 0, err
+	}
+	return configEntity.Generation, nil
+}
+
+// latestRuntimeConfig returns the latest configuration of a cluster across all cluster versions (nil if the
+// cluster has no configuration yet)
+func (i *DefaultInventory) latestRuntimeConfig(runtimeID string) (*model.ClusterConfigurationEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterConfigurationEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{
+		model.ClusterConfigurationEntityFields.RuntimeID: runtimeID,
+	}
+	configEntity, err := q.Select().
+		Where(whereCond).
+		OrderBy(map[string]string{model.ClusterConfigurationEntityFields.Version: "desc"}).
+		GetOne()
+	if err != nil {
+		if err = i.MapError(err, configEntity, whereCond); repository.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return configEntity.(*model.ClusterConfigurationEntity), nil
+}
+
+// configurationChangeReason returns the reason of the reconciliation requested by storing a cluster configuration
+func configurationChangeReason(previous, current *model.ClusterConfigurationEntity) string {
+	switch {
+	case previous == nil:
+		return model.ReasonProvisioning
+	case previous.Version == current.Version: //configuration is unchanged
+		return model.ReasonManualRetry
+	case previous.KymaVersion != current.KymaVersion:
+		return model.ReasonVersionRollout
+	default:
+		return model.ReasonConfigChange
+	}
+}
+
+// initiator returns who requested the changes done by this inventory
+func (i *DefaultInventory) initiator() model.Initiator {
+	if i.actor == "" {
+		return model.InitiatorKEB
+	}
+	return model.InitiatorUser
+}
+
 func (i *DefaultInventory) createStatus(configEntity *model.ClusterConfigurationEntity,
 	status model.Status) (*model.ClusterStatusEntity, error) {
+	var initiator model.Initiator
+	var reason string
+	switch status { //pending statuses set without further context are requested by KEB or a user
+	case model.ClusterStatusReconcilePending:
+		initiator, reason = i.initiator(), model.ReasonManualRetry
+	case model.ClusterStatusDeletePending:
+		initiator, reason = i.initiator(), model.ReasonDeletion
+	}
+	return i.createTriggeredStatus(configEntity, status, initiator, reason)
+}
+
+// createTriggeredStatus creates a status and records who requested it for which reason
+func (i *DefaultInventory) createTriggeredStatus(configEntity *model.ClusterConfigurationEntity,
+	status model.Status, initiator model.Initiator, reason string) (*model.ClusterStatusEntity, error) {
 	newStatusEntity := &model.ClusterStatusEntity{
 		RuntimeID:      configEntity.RuntimeID,
 		ClusterVersion: configEntity.ClusterVersion,
 		ConfigVersion:  configEntity.Version,
 		Status:         status,
+		Initiator:      initiator,
+		Reason:         reason,
 	}
 
 	// check if a new version is required
@@ -318,6 +505,33 @@ func (i *DefaultInventory) UpdateStatus(state *State, status model.Status) (*Sta
 	return state, nil
 }
 
+// updateStatusAndRun updates the status of a cluster and runs further DB operations within the same transaction:
+// if one of them fails, the cluster keeps its previous status.
+func (i *DefaultInventory) updateStatusAndRun(state *State, status model.Status, initiator model.Initiator, reason string,
+	dbOps func(iTx *DefaultInventory) error) (*State, error) {
+	txOps := func(tx *db.TxConnection) (interface{}, error) {
+		tmpiTx, err := i.WithTx(tx)
+		if err != nil {
+			return nil, err
+		}
+		iTx := tmpiTx.(*DefaultInventory)
+		newStatus, err := iTx.createTriggeredStatus(state.Configuration, status, initiator, reason)
+		if err != nil {
+			return nil, err
+		}
+		return newStatus, dbOps(iTx)
+	}
+	newStatus, err := db.TransactionResult(i.Conn, txOps, i.Logger)
+	if err != nil {
+		return state, err
+	}
+	state.Status = newStatus.(*model.ClusterStatusEntity)
+	if err := i.metricsCollector.OnClusterStateUpdate(state); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
 func (i *DefaultInventory) MarkForDeletion(runtimeID string) (*State, error) {
 	clusterState, err := i.GetLatest(runtimeID)
 	if err != nil {
@@ -391,6 +605,46 @@ func (i *DefaultInventory) Delete(runtimeID string) error {
 			return err
 		}
 
+		// keep the configuration change history of the deleted cluster apart from clusters re-using its runtimeID
+		changeEntity := &model.ClusterChangeEntity{}
+		changeColHandler, err := db.NewColumnHandler(changeEntity, i.Conn, i.Logger)
+		if err != nil {
+			return err
+		}
+		changeClusterColName, err := changeColHandler.ColumnName(model.ClusterChangeEntityFields.RuntimeID)
+		if err != nil {
+			return err
+		}
+		changeUpdateSQL := fmt.Sprintf("UPDATE %s SET %s=$1 WHERE %s=$2",
+			changeEntity.Table(), changeClusterColName, changeClusterColName)
+		if _, err := tx.Exec(changeUpdateSQL, newClusterName, runtimeID); err != nil {
+			return err
+		}
+
+		// remove the component pins of the cluster
+		pinQ, err := db.NewQuery(tx, &model.ComponentPinEntity{}, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := pinQ.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec(); err != nil {
+			return err
+		}
+
+		// remove the deletion protection, the pending deletion confirmations, the scheduled deletion and the status
+		// badge of the cluster
+		for _, entity := range []db.DatabaseEntity{
+			&model.DeletionProtectionEntity{}, &model.DeletionConfirmationEntity{}, &model.ScheduledDeletionEntity{},
+			&model.StatusBadgeEntity{},
+		} {
+			delQ, err := db.NewQuery(tx, entity, i.Logger)
+			if err != nil {
+				return err
+			}
+			if _, err := delQ.Delete().Where(map[string]interface{}{"RuntimeID": runtimeID}).Exec(); err != nil {
+				return err
+			}
+		}
+
 		// done
 		return nil
 	}
@@ -822,24 +1076,13 @@ func (i *DefaultInventory) RemoveStatusesWithoutReconciliations(timeout time.Dur
 	totalDeleteCount := 0
 	for _, statusIDsBlock := range statusIDsBlocks {
 		dbOps := func(tx *db.TxConnection) (interface{}, error) {
-			var args []interface{}
-			var buffer bytes.Buffer
-
-			for i, statusID := range statusIDsBlock {
-				if buffer.Len() > 0 {
-					buffer.WriteRune(',')
-				}
-				buffer.WriteString(fmt.Sprintf("$%d", i+1))
-				args = append(args, statusID.(int64))
-			}
-
 			deleteQuery, err := db.NewQuery(tx, &model.ClusterStatusEntity{}, i.Logger)
 			if err != nil {
 				return 0, err
 			}
 			deletedRows, err := deleteQuery.
 				Delete().
-				WhereIn("ID", buffer.String(), args...).
+				WhereInValues(model.ClusterStatusEntityFields.ID, statusIDsBlock...).
 				Exec()
 			return int(deletedRows), err
 		}
@@ -860,32 +1103,22 @@ func (i *DefaultInventory) RemoveDeletedClustersOlderThan(deadline time.Time) (i
 		if err != nil {
 			return 0, err
 		}
-		columnHandler, err := db.NewColumnHandler(&model.ClusterEntity{}, tx, i.Logger)
-		if err != nil {
-			return 0, err
-		}
-		createdColumn, err := columnHandler.ColumnName("Created")
-		if err != nil {
-			return 0, err
-		}
-		runtimeIDSelectQuery, err := selectQuery.SelectColumn("RuntimeID")
+		runtimeIDSelectQuery, err := selectQuery.SelectColumn(model.ClusterEntityFields.RuntimeID)
 		if err != nil {
 			return 0, err
 		}
-		runtimeIDSelectQuery.
-			WhereRaw(fmt.Sprintf("%s<$%d", createdColumn, runtimeIDSelectQuery.NextPlaceholderCount()),
-				deadline.Format("2006-01-02 15:04:05.000"))
+		runtimeIDSelectQuery.WhereCompare(model.ClusterEntityFields.Created, db.Less, deadline)
 
 		deleteQuery, err := db.NewQuery(tx, &model.ClusterEntity{}, i.Logger)
 		if err != nil {
 			return 0, err
 		}
 		whereCond := map[string]interface{}{
-			"Deleted": true,
+			model.ClusterEntityFields.Deleted: true,
 		}
 		deletedRows, err := deleteQuery.
 			Delete().
-			WhereIn("RuntimeID", runtimeIDSelectQuery.String(), runtimeIDSelectQuery.GetArgs()...).
+			WhereIn(model.ClusterEntityFields.RuntimeID, runtimeIDSelectQuery.String(), runtimeIDSelectQuery.GetArgs()...).
 			Where(whereCond).
 			Exec()
 		return int(deletedRows), err
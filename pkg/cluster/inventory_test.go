@@ -226,6 +226,44 @@ func (s *clusterTestSuite) Test_StatusChange() {
 	})
 }
 
+func (s *clusterTestSuite) Test_ConfigurationChanges() {
+	t := s.T()
+	t.Run("Get configuration changes", func(t *testing.T) {
+		conn, err := s.NewConnection()
+		require.NoError(t, err)
+		inventory := s.newInventory(conn).WithActor("admin@example.com")
+		newCluster := test.NewCluster(t, "1", 1, false, test.Production)
+		defer func() {
+			//cleanup
+			require.NoError(t, inventory.Delete(newCluster.RuntimeID))
+			require.NoError(t, conn.Close())
+		}()
+
+		_, err = inventory.CreateOrUpdate(1, newCluster)
+		require.NoError(t, err)
+		_, err = inventory.CreateOrUpdate(1, newCluster) //unchanged configuration isn't recorded
+		require.NoError(t, err)
+		updatedCluster := test.NewClusterFromExisting(*newCluster, 2, false)
+		clusterState, err := inventory.CreateOrUpdate(1, updatedCluster)
+		require.NoError(t, err)
+
+		changes, err := inventory.ConfigurationChanges(newCluster.RuntimeID, 10)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		require.Equal(t, clusterState.Configuration.Version, changes[0].ConfigVersion)
+		require.Equal(t, int64(2), changes[0].Generation)
+		require.Equal(t, "admin@example.com", changes[0].Actor)
+		require.Equal(t, &keb.ValueChange{Old: newCluster.KymaConfig.Version, New: updatedCluster.KymaConfig.Version},
+			changes[0].Diff.KymaVersion)
+		require.Equal(t, int64(1), changes[1].Generation)
+		require.Len(t, changes[1].Diff.Components, len(newCluster.KymaConfig.Components))
+
+		changes, err = inventory.ConfigurationChanges(newCluster.RuntimeID, 1)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+	})
+}
+
 func (s *clusterTestSuite) TestInventoryForReconcile() {
 	t := s.T()
 	t.Run("Get clusters to reconcile", func(t *testing.T) {
@@ -505,6 +543,415 @@ func (s *clusterTestSuite) TestRemoveDeletedClustersOlderThan() {
 	require.Equal(t, 1, len(clusterStates))
 }
 
+func (s *clusterTestSuite) TestComponentPins() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	cluster := test.NewCluster(t, "pinned", 1, false, test.Production)
+	cluster.KymaConfig.Version = "1.2.0"
+	require.NotEmpty(t, cluster.KymaConfig.Components)
+	component := cluster.KymaConfig.Components[0].Component
+	_, err := inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+
+	//pin component to a range: version bump is not applied to the component
+	_, err = inventory.PinComponent(cluster.RuntimeID, component, "~1.2")
+	require.NoError(t, err)
+	bumpedCluster := test.NewClusterFromExisting(*cluster, 1, false)
+	bumpedCluster.KymaConfig.Version = "1.3.0"
+	state, err := inventory.CreateOrUpdate(1, bumpedCluster)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", state.Configuration.KymaVersion)
+	require.Equal(t, "1.2.0", state.Configuration.GetComponent(component).Version)
+
+	//pin component to a version
+	_, err = inventory.PinComponent(cluster.RuntimeID, component, "1.1.0")
+	require.NoError(t, err)
+	pins, err := inventory.ComponentPins(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Len(t, pins, 1)
+	require.Equal(t, "1.1.0", pins[0].Version)
+	bumpedCluster.KymaConfig.Version = "1.4.0"
+	state, err = inventory.CreateOrUpdate(1, bumpedCluster)
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", state.Configuration.GetComponent(component).Version)
+
+	//unpin component
+	require.NoError(t, inventory.UnpinComponent(cluster.RuntimeID, component))
+	pins, err = inventory.ComponentPins(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Empty(t, pins)
+	bumpedCluster.KymaConfig.Version = "1.5.0"
+	state, err = inventory.CreateOrUpdate(1, bumpedCluster)
+	require.NoError(t, err)
+	require.Equal(t, cluster.KymaConfig.Components[0].Version, state.Configuration.GetComponent(component).Version)
+
+	require.NoError(t, inventory.Delete(cluster.RuntimeID))
+}
+
+func (s *clusterTestSuite) TestStatusTrigger() {
+	t := s.T()
+	conn, err := s.NewConnection()
+	require.NoError(t, err)
+	inventory := s.newInventory(conn)
+	newCluster := test.NewCluster(t, "1", 1, false, test.Production)
+	defer func() {
+		require.NoError(t, inventory.Delete(newCluster.RuntimeID))
+		require.NoError(t, conn.Close())
+	}()
+
+	requireTrigger := func(state *State, initiator model.Initiator, reason string) {
+		actualInitiator, actualReason := state.Status.Trigger()
+		require.Equal(t, initiator, actualInitiator)
+		require.Equal(t, reason, actualReason)
+
+		//trigger is persisted
+		latest, err := inventory.GetLatest(state.Cluster.RuntimeID)
+		require.NoError(t, err)
+		actualInitiator, actualReason = latest.Status.Trigger()
+		require.Equal(t, initiator, actualInitiator)
+		require.Equal(t, reason, actualReason)
+	}
+
+	state, err := inventory.CreateOrUpdate(1, newCluster)
+	require.NoError(t, err)
+	requireTrigger(state, model.InitiatorKEB, model.ReasonProvisioning)
+
+	state, err = inventory.UpdateStatus(state, model.ClusterStatusReady)
+	require.NoError(t, err)
+	requireTrigger(state, model.InitiatorScheduler, model.ReasonPeriodic)
+
+	state, err = inventory.WithActor("admin@example.com").CreateOrUpdate(1, newCluster)
+	require.NoError(t, err)
+	requireTrigger(state, model.InitiatorUser, model.ReasonManualRetry)
+
+	state, err = inventory.UpdateStatus(state, model.ClusterStatusReconcileErrorRetryable)
+	require.NoError(t, err)
+	requireTrigger(state, model.InitiatorScheduler, model.ReasonRetry)
+
+	state, err = inventory.CreateOrUpdate(1, test.NewClusterFromExisting(*newCluster, 2, false))
+	require.NoError(t, err)
+	requireTrigger(state, model.InitiatorKEB, model.ReasonVersionRollout)
+
+	state, err = inventory.WithActor("admin@example.com").MarkForDeletion(newCluster.RuntimeID)
+	require.NoError(t, err)
+	requireTrigger(state, model.InitiatorUser, model.ReasonDeletion)
+}
+
+func (s *clusterTestSuite) TestScheduledReconciliations() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	now := time.Now().UTC()
+	due, err := inventory.ScheduleReconciliation("scheduled1", now.Add(-time.Minute))
+	require.NoError(t, err)
+	pending, err := inventory.ScheduleReconciliation("scheduled2", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	//list pending scheduled reconciliations
+	schedules, err := inventory.ScheduledReconciliations("")
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	require.Equal(t, due.ID, schedules[0].ID)
+	schedules, err = inventory.ScheduledReconciliations("scheduled2")
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	require.Equal(t, pending.ID, schedules[0].ID)
+
+	//only reached schedules are due
+	schedules, err = inventory.DueScheduledReconciliations(now)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	require.Equal(t, due.ID, schedules[0].ID)
+
+	//cancel scheduled reconciliations
+	require.NoError(t, inventory.CancelScheduledReconciliation(due.ID))
+	require.NoError(t, inventory.CancelScheduledReconciliation(pending.ID))
+	require.True(t, repository.IsNotFoundError(inventory.CancelScheduledReconciliation(pending.ID)))
+	schedules, err = inventory.ScheduledReconciliations("")
+	require.NoError(t, err)
+	require.Empty(t, schedules)
+
+	//triggering a schedule updates the cluster status and removes the schedule at once
+	state, err := inventory.CreateOrUpdate(1, test.NewCluster(t, "scheduled3", 1, false, test.Production))
+	require.NoError(t, err)
+	state, err = inventory.UpdateStatus(state, model.ClusterStatusReady)
+	require.NoError(t, err)
+	triggered, err := inventory.ScheduleReconciliation(state.Cluster.RuntimeID, now.Add(-time.Minute))
+	require.NoError(t, err)
+	state, err = inventory.TriggerScheduledReconciliation(state, triggered.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.ClusterStatusReconcilePending, state.Status.Status)
+	initiator, reason := state.Status.Trigger()
+	require.Equal(t, model.InitiatorScheduler, initiator)
+	require.Equal(t, model.ReasonScheduled, reason)
+	schedules, err = inventory.ScheduledReconciliations(state.Cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Empty(t, schedules)
+	require.NoError(t, inventory.Delete(state.Cluster.RuntimeID))
+}
+
+func (s *clusterTestSuite) TestUpdateIfMatch() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	cluster := test.NewCluster(t, "optimisticLocking", 1, false, test.Production)
+	state, err := inventory.UpdateIfMatch(1, cluster, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), state.Configuration.Generation)
+
+	//update based on the latest generation is accepted
+	cluster.KymaConfig.Version = "1.1.0"
+	state, err = inventory.UpdateIfMatch(1, cluster, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), state.Configuration.Generation)
+
+	//update based on an outdated generation is rejected
+	cluster.KymaConfig.Version = "1.2.0"
+	_, err = inventory.UpdateIfMatch(1, cluster, 1)
+	var conflictErr *ConfigurationConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	require.Equal(t, int64(1), conflictErr.Expected)
+	require.Equal(t, int64(2), conflictErr.Current)
+	state, err = inventory.GetLatest(cluster.RuntimeID)
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", state.Configuration.KymaVersion)
+
+	//unconditional updates increase the generation as well
+	state, err = inventory.CreateOrUpdate(1, cluster)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), state.Configuration.Generation)
+
+	require.NoError(t, inventory.Delete(cluster.RuntimeID))
+}
+
+func (s *clusterTestSuite) TestTenantClusters() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	tenantCluster := test.NewCluster(t, "tenant", 1, false, test.Production)
+	tenantCluster.Metadata.GlobalAccountID = "tenant1"
+	tenantClusterState, err := inventory.CreateOrUpdate(1, tenantCluster)
+	require.NoError(t, err)
+	require.Equal(t, "tenant1", tenantClusterState.Cluster.GlobalAccountID)
+
+	movedCluster := test.NewCluster(t, "moved", 1, false, test.Production)
+	movedCluster.Metadata.GlobalAccountID = "tenant1"
+	_, err = inventory.CreateOrUpdate(1, movedCluster)
+	require.NoError(t, err)
+	movedCluster.Metadata.GlobalAccountID = "tenant2" //cluster was assigned to another tenant
+	_, err = inventory.CreateOrUpdate(1, movedCluster)
+	require.NoError(t, err)
+
+	runtimeIDs, err := inventory.TenantClusters("tenant1")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{tenantCluster.RuntimeID}, runtimeIDs)
+	runtimeIDs, err = inventory.TenantClusters("tenant2")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{movedCluster.RuntimeID}, runtimeIDs)
+
+	//deleted clusters don't belong to a tenant anymore
+	require.NoError(t, inventory.Delete(tenantCluster.RuntimeID))
+	runtimeIDs, err = inventory.TenantClusters("tenant1")
+	require.NoError(t, err)
+	require.Empty(t, runtimeIDs)
+}
+
+func (s *clusterTestSuite) TestTenantQuotas() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	_, err := inventory.SetTenantQuota("quotaTenant1", 10, 0)
+	require.NoError(t, err)
+	_, err = inventory.SetTenantQuota("quotaTenant2", 5, 20)
+	require.NoError(t, err)
+	_, err = inventory.SetTenantQuota("quotaTenant2", 2, 20) //overwrites previous quota
+	require.NoError(t, err)
+	_, err = inventory.SetTenantQuota("quotaTenant3", -1, 0)
+	require.Error(t, err)
+	_, err = inventory.SetTenantQuota("", 1, 1)
+	require.Error(t, err)
+
+	quotas, err := inventory.TenantQuotas()
+	require.NoError(t, err)
+	require.Len(t, quotas, 2)
+	require.Equal(t, "quotaTenant1", quotas[0].GlobalAccountID)
+	require.Equal(t, int64(10), quotas[0].MaxParallelOperations)
+	require.Equal(t, int64(0), quotas[0].MaxOperationsPerMinute)
+	require.Equal(t, "quotaTenant2", quotas[1].GlobalAccountID)
+	require.Equal(t, int64(2), quotas[1].MaxParallelOperations)
+	require.Equal(t, int64(20), quotas[1].MaxOperationsPerMinute)
+
+	require.NoError(t, inventory.RemoveTenantQuota("quotaTenant1"))
+	require.NoError(t, inventory.RemoveTenantQuota("quotaTenant2"))
+	require.True(t, repository.IsNotFoundError(inventory.RemoveTenantQuota("quotaTenant2")))
+	quotas, err = inventory.TenantQuotas()
+	require.NoError(t, err)
+	require.Empty(t, quotas)
+}
+
+func (s *clusterTestSuite) TestDeletionProtection() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	protected, err := inventory.IsProtectedFromDeletion("protectedCluster")
+	require.NoError(t, err)
+	require.False(t, protected)
+
+	_, err = inventory.ProtectFromDeletion("protectedCluster")
+	require.NoError(t, err)
+	_, err = inventory.ProtectFromDeletion("protectedCluster") //protecting twice is idempotent
+	require.NoError(t, err)
+	protected, err = inventory.IsProtectedFromDeletion("protectedCluster")
+	require.NoError(t, err)
+	require.True(t, protected)
+
+	require.NoError(t, inventory.UnprotectFromDeletion("protectedCluster"))
+	require.True(t, repository.IsNotFoundError(inventory.UnprotectFromDeletion("protectedCluster")))
+	protected, err = inventory.IsProtectedFromDeletion("protectedCluster")
+	require.NoError(t, err)
+	require.False(t, protected)
+}
+
+func (s *clusterTestSuite) TestDeletionConfirmation() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	_, err := inventory.RequestDeletion("deletedCluster", 0)
+	require.Error(t, err)
+
+	confirmation, err := inventory.RequestDeletion("deletedCluster", time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, confirmation.Token)
+	require.True(t, confirmation.Expires.After(time.Now().UTC()))
+
+	var deletions int
+	deletion := func(iTx Inventory) error {
+		deletions++
+		return nil
+	}
+
+	//token is bound to the cluster
+	var confirmationErr *DeletionConfirmationError
+	require.ErrorAs(t, inventory.ConfirmDeletion("otherCluster", confirmation.Token, deletion), &confirmationErr)
+	require.Zero(t, deletions)
+
+	//token can be used only once
+	require.NoError(t, inventory.ConfirmDeletion("deletedCluster", confirmation.Token, deletion))
+	require.Equal(t, 1, deletions)
+	require.ErrorAs(t, inventory.ConfirmDeletion("deletedCluster", confirmation.Token, deletion), &confirmationErr)
+	require.Equal(t, 1, deletions)
+
+	//expired tokens are rejected
+	expired, err := inventory.RequestDeletion("deletedCluster", time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	require.ErrorAs(t, inventory.ConfirmDeletion("deletedCluster", expired.Token, deletion), &confirmationErr)
+	require.Equal(t, 1, deletions)
+
+	t.Run("Token remains valid if deletion fails", func(t *testing.T) {
+		//new db connection: the failed deletion rolls back the transaction
+		dbConn, err := s.NewConnection()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, dbConn.Close())
+		}()
+		inventory, err := NewInventory(dbConn, true, MetricsCollectorMock{})
+		require.NoError(t, err)
+
+		confirmation, err := inventory.RequestDeletion("failedDeletionCluster", time.Minute)
+		require.NoError(t, err)
+		require.Error(t, inventory.ConfirmDeletion("failedDeletionCluster", confirmation.Token, func(iTx Inventory) error {
+			return errors.New("Faking an error")
+		}))
+		require.NoError(t, inventory.ConfirmDeletion("failedDeletionCluster", confirmation.Token, func(iTx Inventory) error {
+			return nil
+		}))
+	})
+}
+
+func (s *clusterTestSuite) TestScheduledDeletions() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	now := time.Now().UTC()
+	due, err := inventory.ScheduleDeletion("softDeleted1", now.Add(-time.Minute))
+	require.NoError(t, err)
+	pending, err := inventory.ScheduleDeletion("softDeleted2", now.Add(time.Hour))
+	require.NoError(t, err)
+
+	//a repeated soft-deletion keeps the original teardown time
+	repeated, err := inventory.ScheduleDeletion("softDeleted2", now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.WithinDuration(t, pending.TeardownAfter, repeated.TeardownAfter, time.Second)
+
+	deletions, err := inventory.ScheduledDeletions("")
+	require.NoError(t, err)
+	require.Len(t, deletions, 2)
+	require.Equal(t, due.RuntimeID, deletions[0].RuntimeID)
+
+	//only deletions whose grace period is over are due
+	deletions, err = inventory.DueScheduledDeletions(now)
+	require.NoError(t, err)
+	require.Len(t, deletions, 1)
+	require.Equal(t, due.RuntimeID, deletions[0].RuntimeID)
+
+	//undelete clusters
+	require.NoError(t, inventory.CancelScheduledDeletion("softDeleted1"))
+	require.NoError(t, inventory.CancelScheduledDeletion("softDeleted2"))
+	require.True(t, repository.IsNotFoundError(inventory.CancelScheduledDeletion("softDeleted2")))
+	deletions, err = inventory.ScheduledDeletions("softDeleted2")
+	require.NoError(t, err)
+	require.Empty(t, deletions)
+}
+
+func (s *clusterTestSuite) TestReconcileIntervals() {
+	t := s.T()
+
+	//create inventory
+	inventory := s.newInventory(s.TxConnection())
+
+	_, err := inventory.SetReconcileInterval("", "monitoring", time.Hour)
+	require.NoError(t, err)
+	_, err = inventory.SetReconcileInterval("intervalCluster", "", 2*time.Hour)
+	require.NoError(t, err)
+	_, err = inventory.SetReconcileInterval("intervalCluster", "", 3*time.Hour) //overwrites previous interval
+	require.NoError(t, err)
+	_, err = inventory.SetReconcileInterval("intervalCluster", "istio", 0)
+	require.Error(t, err)
+
+	overrides, err := inventory.ReconcileIntervals()
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+	require.Equal(t, "intervalCluster", overrides[0].RuntimeID)
+	require.Equal(t, 3*time.Hour, overrides[0].Duration())
+	require.Equal(t, "monitoring", overrides[1].Component)
+	require.Equal(t, time.Hour, overrides[1].Duration())
+
+	require.NoError(t, inventory.RemoveReconcileInterval("", "monitoring"))
+	require.NoError(t, inventory.RemoveReconcileInterval("intervalCluster", ""))
+	require.True(t, repository.IsNotFoundError(inventory.RemoveReconcileInterval("intervalCluster", "")))
+	overrides, err = inventory.ReconcileIntervals()
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+}
+
 func (s *clusterTestSuite) TestDefaultInventory_RemoveStatusesWithoutReconciliations() {
 	t := s.T()
 	//create inventory
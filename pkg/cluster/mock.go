@@ -27,16 +27,51 @@ type MockInventory struct {
 	DeletedStatusesWoReconciliationResult int
 	DeletedStatusesOlderThanResult        int
 	DeletedClustersOlderThanResult        int
+	ComponentPinsResult                   []*model.ComponentPinEntity
+	ScheduledReconciliationsResult        []*model.ScheduledReconciliationEntity
+	DueScheduledReconciliationsResult     []*model.ScheduledReconciliationEntity
+	CancelScheduledReconciliationResult   error
+	TriggerScheduledReconciliationResult  *State
+	ReconcileIntervalsResult              []*model.ReconcileIntervalEntity
+	RemoveReconcileIntervalResult         error
+	UnprotectFromDeletionResult           error
+	IsProtectedFromDeletionResult         bool
+	ConfirmDeletionResult                 error
+	CancelScheduledDeletionResult         error
+	TriggerScheduledDeletionResult        *State
+	ScheduledDeletionsResult              []*model.ScheduledDeletionEntity
+	DueScheduledDeletionsResult           []*model.ScheduledDeletionEntity
+	TenantClustersResult                  []string
+	TenantQuotasResult                    []*model.TenantQuotaEntity
+	RemoveTenantQuotaResult               error
+	ConfigurationChangesResult            []*model.ClusterChangeEntity
+	IssueStatusBadgeTokenResult           string
+	RevokeStatusBadgeTokenResult          error
+	StatusBadgeResult                     *StatusBadge
+	SaveFeatureFlagResult                 *model.FeatureFlagEntity
+	RemoveFeatureFlagResult               error
+	FeatureFlagsResult                    []*model.FeatureFlagEntity
+	EnabledFeatureFlagsResult             []string
+	FreezesResult                         []*model.SchedulingFreezeEntity
+	ReleaseFreezeResult                   error
 }
 
 func (i *MockInventory) WithTx(_ *db.TxConnection) (Inventory, error) {
 	return i, nil
 }
 
+func (i *MockInventory) WithActor(_ string) Inventory {
+	return i
+}
+
 func (i *MockInventory) CreateOrUpdate(_ int64, _ *keb.Cluster) (*State, error) {
 	return i.CreateOrUpdateResult, nil
 }
 
+func (i *MockInventory) UpdateIfMatch(_ int64, _ *keb.Cluster, _ int64) (*State, error) {
+	return i.CreateOrUpdateResult, nil
+}
+
 func (i *MockInventory) UpdateStatus(_ *State, _ model.Status) (*State, error) {
 	return i.UpdateStatusResult, nil
 }
@@ -73,6 +108,93 @@ func (i *MockInventory) StatusChanges(_ string, _ time.Duration) ([]*StatusChang
 	return i.ChangesResult, nil
 }
 
+func (i *MockInventory) PinComponent(runtimeID, component, version string) (*model.ComponentPinEntity, error) {
+	return &model.ComponentPinEntity{RuntimeID: runtimeID, Component: component, Version: version}, nil
+}
+
+func (i *MockInventory) UnpinComponent(_, _ string) error {
+	return nil
+}
+
+func (i *MockInventory) ComponentPins(_ string) ([]*model.ComponentPinEntity, error) {
+	return i.ComponentPinsResult, nil
+}
+
+func (i *MockInventory) ScheduleReconciliation(runtimeID string, notBefore time.Time) (*model.ScheduledReconciliationEntity, error) {
+	return &model.ScheduledReconciliationEntity{ID: "schedule", RuntimeID: runtimeID, NotBefore: notBefore.UTC()}, nil
+}
+
+func (i *MockInventory) CancelScheduledReconciliation(_ string) error {
+	return i.CancelScheduledReconciliationResult
+}
+
+func (i *MockInventory) TriggerScheduledReconciliation(_ *State, _ string) (*State, error) {
+	return i.TriggerScheduledReconciliationResult, nil
+}
+
+func (i *MockInventory) ScheduledReconciliations(_ string) ([]*model.ScheduledReconciliationEntity, error) {
+	return i.ScheduledReconciliationsResult, nil
+}
+
+func (i *MockInventory) DueScheduledReconciliations(_ time.Time) ([]*model.ScheduledReconciliationEntity, error) {
+	return i.DueScheduledReconciliationsResult, nil
+}
+
+func (i *MockInventory) SetReconcileInterval(runtimeID, component string, interval time.Duration) (*model.ReconcileIntervalEntity, error) {
+	return &model.ReconcileIntervalEntity{RuntimeID: runtimeID, Component: component, Seconds: int64(interval.Seconds())}, nil
+}
+
+func (i *MockInventory) RemoveReconcileInterval(_, _ string) error {
+	return i.RemoveReconcileIntervalResult
+}
+
+func (i *MockInventory) ReconcileIntervals() ([]*model.ReconcileIntervalEntity, error) {
+	return i.ReconcileIntervalsResult, nil
+}
+
+func (i *MockInventory) ProtectFromDeletion(runtimeID string) (*model.DeletionProtectionEntity, error) {
+	return &model.DeletionProtectionEntity{RuntimeID: runtimeID}, nil
+}
+
+func (i *MockInventory) UnprotectFromDeletion(_ string) error {
+	return i.UnprotectFromDeletionResult
+}
+
+func (i *MockInventory) IsProtectedFromDeletion(_ string) (bool, error) {
+	return i.IsProtectedFromDeletionResult, nil
+}
+
+func (i *MockInventory) RequestDeletion(runtimeID string, ttl time.Duration) (*model.DeletionConfirmationEntity, error) {
+	return &model.DeletionConfirmationEntity{Token: "token", RuntimeID: runtimeID, Expires: time.Now().UTC().Add(ttl)}, nil
+}
+
+func (i *MockInventory) ConfirmDeletion(_, _ string, deletion func(iTx Inventory) error) error {
+	if i.ConfirmDeletionResult != nil {
+		return i.ConfirmDeletionResult
+	}
+	return deletion(i)
+}
+
+func (i *MockInventory) ScheduleDeletion(runtimeID string, teardownAfter time.Time) (*model.ScheduledDeletionEntity, error) {
+	return &model.ScheduledDeletionEntity{RuntimeID: runtimeID, TeardownAfter: teardownAfter.UTC()}, nil
+}
+
+func (i *MockInventory) CancelScheduledDeletion(_ string) error {
+	return i.CancelScheduledDeletionResult
+}
+
+func (i *MockInventory) TriggerScheduledDeletion(_ *State) (*State, error) {
+	return i.TriggerScheduledDeletionResult, nil
+}
+
+func (i *MockInventory) ScheduledDeletions(_ string) ([]*model.ScheduledDeletionEntity, error) {
+	return i.ScheduledDeletionsResult, nil
+}
+
+func (i *MockInventory) DueScheduledDeletions(_ time.Time) ([]*model.ScheduledDeletionEntity, error) {
+	return i.DueScheduledDeletionsResult, nil
+}
+
 type MockKubeconfigProvider struct {
 	KubeconfigResult string
 }
@@ -99,3 +221,73 @@ func (i *MockInventory) RemoveStatusesWithoutReconciliations(timeout time.Durati
 func (i *MockInventory) RemoveDeletedClustersOlderThan(deadline time.Time) (int, error) {
 	return i.DeletedClustersOlderThanResult, nil
 }
+
+func (i *MockInventory) TenantClusters(_ string) ([]string, error) {
+	return i.TenantClustersResult, nil
+}
+
+func (i *MockInventory) SetTenantQuota(globalAccountID string, maxParallelOperations, maxOperationsPerMinute int64) (*model.TenantQuotaEntity, error) {
+	return &model.TenantQuotaEntity{
+		GlobalAccountID:        globalAccountID,
+		MaxParallelOperations:  maxParallelOperations,
+		MaxOperationsPerMinute: maxOperationsPerMinute,
+	}, nil
+}
+
+func (i *MockInventory) RemoveTenantQuota(_ string) error {
+	return i.RemoveTenantQuotaResult
+}
+
+func (i *MockInventory) TenantQuotas() ([]*model.TenantQuotaEntity, error) {
+	return i.TenantQuotasResult, nil
+}
+
+func (i *MockInventory) ConfigurationChanges(_ string, _ int) ([]*model.ClusterChangeEntity, error) {
+	return i.ConfigurationChangesResult, nil
+}
+
+func (i *MockInventory) IssueStatusBadgeToken(_ string) (string, error) {
+	return i.IssueStatusBadgeTokenResult, nil
+}
+
+func (i *MockInventory) RevokeStatusBadgeToken(_ string) error {
+	return i.RevokeStatusBadgeTokenResult
+}
+
+func (i *MockInventory) StatusBadge(_, _ string) (*StatusBadge, error) {
+	return i.StatusBadgeResult, nil
+}
+
+func (i *MockInventory) SaveFeatureFlag(_ *model.FeatureFlagEntity) (*model.FeatureFlagEntity, error) {
+	return i.SaveFeatureFlagResult, nil
+}
+
+func (i *MockInventory) RemoveFeatureFlag(_ string) error {
+	return i.RemoveFeatureFlagResult
+}
+
+func (i *MockInventory) FeatureFlags() ([]*model.FeatureFlagEntity, error) {
+	return i.FeatureFlagsResult, nil
+}
+
+func (i *MockInventory) EnabledFeatureFlags(_ string) ([]string, error) {
+	return i.EnabledFeatureFlagsResult, nil
+}
+
+func (i *MockInventory) Freeze(component, reason string) (*model.SchedulingFreezeEntity, error) {
+	return &model.SchedulingFreezeEntity{
+		Component: component,
+		Reason:    reason,
+	}, nil
+}
+
+func (i *MockInventory) ReleaseFreeze(component string) (*model.SchedulingFreezeEntity, error) {
+	return &model.SchedulingFreezeEntity{
+		Component: component,
+		Released:  true,
+	}, i.ReleaseFreezeResult
+}
+
+func (i *MockInventory) Freezes(_ bool) ([]*model.SchedulingFreezeEntity, error) {
+	return i.FreezesResult, nil
+}
@@ -0,0 +1,160 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+// pinnedVersion returns the version of the component which honors the pin. If the desired version violates a
+// version range, the previously applied version is kept as long as it satisfies the range.
+func pinnedVersion(pin *model.ComponentPinEntity, desired, previous string) (string, error) {
+	if _, err := semver.NewVersion(pin.Version); err == nil {
+		return pin.Version, nil
+	}
+	constraint, err := semver.NewConstraint(pin.Version)
+	if err != nil {
+		return pin.Version, nil //not a range: pinned to a non-semver version
+	}
+	for _, candidate := range []string{desired, previous} {
+		if candidate == "" {
+			continue
+		}
+		if version, err := semver.NewVersion(candidate); err == nil && constraint.Check(version) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("version '%s' of component '%s' violates the pinned range '%s' "+
+		"and no previously applied version satisfies it", desired, pin.Component, pin.Version)
+}
+
+// ApplyComponentPins changes the versions of the pinned components in the new configuration
+func ApplyComponentPins(newConfig, previousConfig *model.ClusterConfigurationEntity, pins []*model.ComponentPinEntity) error {
+	for _, pin := range pins {
+		for idx, comp := range newConfig.Components {
+			if comp.Component != pin.Component {
+				continue
+			}
+			var previous string
+			if previousConfig != nil {
+				if previousComp := previousConfig.GetComponent(pin.Component); previousComp != nil {
+					previous = componentVersion(previousConfig, previousComp.Version)
+				}
+			}
+			desired := componentVersion(newConfig, comp.Version)
+			version, err := pinnedVersion(pin, desired, previous)
+			if err != nil {
+				return err
+			}
+			if version == desired {
+				continue
+			}
+			pinnedComp := *comp //don't modify the component of the request model
+			pinnedComp.Version = version
+			newConfig.Components[idx] = &pinnedComp
+		}
+	}
+	return nil
+}
+
+// componentVersion returns the version a component gets installed with (the Kyma version if no version is defined)
+func componentVersion(config *model.ClusterConfigurationEntity, version string) string {
+	if version != "" {
+		return version
+	}
+	return config.KymaVersion
+}
+
+// pinComponents applies the pins of the cluster to the new configuration
+func (i *DefaultInventory) pinComponents(newConfig *model.ClusterConfigurationEntity) error {
+	pins, err := i.ComponentPins(newConfig.RuntimeID)
+	if err != nil || len(pins) == 0 {
+		return err
+	}
+	previousConfig, err := i.latestConfigOfRuntime(newConfig.RuntimeID)
+	if err != nil && !repository.IsNotFoundError(err) {
+		return err
+	}
+	return ApplyComponentPins(newConfig, previousConfig, pins)
+}
+
+func (i *DefaultInventory) latestConfigOfRuntime(runtimeID string) (*model.ClusterConfigurationEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterConfigurationEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{
+		"RuntimeID": runtimeID,
+	}
+	configEntity, err := q.Select().
+		Where(whereCond).
+		OrderBy(map[string]string{"Version": "desc"}).
+		GetOne()
+	if err != nil {
+		return nil, i.MapError(err, configEntity, whereCond)
+	}
+	return configEntity.(*model.ClusterConfigurationEntity), nil
+}
+
+func (i *DefaultInventory) PinComponent(runtimeID, component, version string) (*model.ComponentPinEntity, error) {
+	if version == "" { //any string which isn't a valid range is handled as version (e.g. 'main' or 'PR-123')
+		return nil, fmt.Errorf("version of pin for component '%s' is undefined", component)
+	}
+	pin := &model.ComponentPinEntity{
+		RuntimeID: runtimeID,
+		Component: component,
+		Version:   version,
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, pin, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().Where(map[string]interface{}{
+			"RuntimeID": runtimeID,
+			"Component": component,
+		}).Exec(); err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to pin component '%s' of cluster '%s'", component, runtimeID))
+	}
+	return pin, nil
+}
+
+func (i *DefaultInventory) UnpinComponent(runtimeID, component string) error {
+	q, err := db.NewQuery(i.Conn, &model.ComponentPinEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	_, err = q.Delete().Where(map[string]interface{}{
+		"RuntimeID": runtimeID,
+		"Component": component,
+	}).Exec()
+	return err
+}
+
+func (i *DefaultInventory) ComponentPins(runtimeID string) ([]*model.ComponentPinEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ComponentPinEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{"RuntimeID": runtimeID}).
+		OrderBy(map[string]string{"Component": "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	pins := make([]*model.ComponentPinEntity, 0, len(entities))
+	for _, entity := range entities {
+		pins = append(pins, entity.(*model.ComponentPinEntity))
+	}
+	return pins, nil
+}
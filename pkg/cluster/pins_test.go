@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyComponentPins(t *testing.T) {
+	newConfig := func(kymaVersion string, components ...*keb.Component) *model.ClusterConfigurationEntity {
+		return &model.ClusterConfigurationEntity{KymaVersion: kymaVersion, Components: components}
+	}
+	pin := func(component, version string) *model.ComponentPinEntity {
+		return &model.ComponentPinEntity{Component: component, Version: version}
+	}
+
+	testCases := []struct {
+		name            string
+		config          *model.ClusterConfigurationEntity
+		previousConfig  *model.ClusterConfigurationEntity
+		pins            []*model.ComponentPinEntity
+		expectedVersion string
+		expectErr       bool
+	}{
+		{
+			name:            "Pinned to version",
+			config:          newConfig("2.0.0", &keb.Component{Component: "comp"}),
+			pins:            []*model.ComponentPinEntity{pin("comp", "1.2.3")},
+			expectedVersion: "1.2.3",
+		},
+		{
+			name:            "Pinned to non-semver version",
+			config:          newConfig("2.0.0", &keb.Component{Component: "comp"}),
+			pins:            []*model.ComponentPinEntity{pin("comp", "main")},
+			expectedVersion: "main",
+		},
+		{
+			name:            "Desired version satisfies range",
+			config:          newConfig("1.2.5", &keb.Component{Component: "comp"}),
+			pins:            []*model.ComponentPinEntity{pin("comp", "~1.2")},
+			expectedVersion: "",
+		},
+		{
+			name:            "Desired component version satisfies range",
+			config:          newConfig("2.0.0", &keb.Component{Component: "comp", Version: "1.2.7"}),
+			pins:            []*model.ComponentPinEntity{pin("comp", "~1.2")},
+			expectedVersion: "1.2.7",
+		},
+		{
+			name:            "Keep previous version if desired version violates range",
+			config:          newConfig("2.0.0", &keb.Component{Component: "comp"}),
+			previousConfig:  newConfig("1.2.4", &keb.Component{Component: "comp"}),
+			pins:            []*model.ComponentPinEntity{pin("comp", ">=1.2.0, <2.0.0")},
+			expectedVersion: "1.2.4",
+		},
+		{
+			name:           "Fail if no version satisfies range",
+			config:         newConfig("2.0.0", &keb.Component{Component: "comp"}),
+			previousConfig: newConfig("1.1.0", &keb.Component{Component: "comp"}),
+			pins:           []*model.ComponentPinEntity{pin("comp", "~1.2")},
+			expectErr:      true,
+		},
+		{
+			name:            "Ignore pins of other components",
+			config:          newConfig("2.0.0", &keb.Component{Component: "comp"}),
+			pins:            []*model.ComponentPinEntity{pin("other", "1.0.0")},
+			expectedVersion: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			original := testCase.config.Components[0]
+			err := ApplyComponentPins(testCase.config, testCase.previousConfig, testCase.pins)
+			if testCase.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedVersion, testCase.config.Components[0].Version)
+			if testCase.expectedVersion != original.Version {
+				require.NotSame(t, original, testCase.config.Components[0]) //request model isn't modified
+			}
+		})
+	}
+}
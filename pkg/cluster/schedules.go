@@ -0,0 +1,101 @@
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+func (i *DefaultInventory) ScheduleReconciliation(runtimeID string, notBefore time.Time) (*model.ScheduledReconciliationEntity, error) {
+	if notBefore.IsZero() {
+		return nil, fmt.Errorf("point in time of scheduled reconciliation for cluster '%s' is undefined", runtimeID)
+	}
+	schedule := &model.ScheduledReconciliationEntity{
+		ID:        uuid.NewString(),
+		RuntimeID: runtimeID,
+		NotBefore: notBefore.UTC(),
+	}
+	q, err := db.NewQuery(i.Conn, schedule, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.Insert().Exec(); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to schedule reconciliation of cluster '%s'", runtimeID))
+	}
+	return schedule, nil
+}
+
+func (i *DefaultInventory) CancelScheduledReconciliation(id string) error {
+	q, err := db.NewQuery(i.Conn, &model.ScheduledReconciliationEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	whereCond := map[string]interface{}{"ID": id}
+	deleted, err := q.Delete().Where(whereCond).Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return i.NewNotFoundError(fmt.Errorf("no pending scheduled reconciliation with ID '%s' found", id),
+			&model.ScheduledReconciliationEntity{}, whereCond)
+	}
+	return nil
+}
+
+// TriggerScheduledReconciliation marks the cluster as reconcile-pending and removes the processed schedule
+// within one transaction
+func (i *DefaultInventory) TriggerScheduledReconciliation(state *State, scheduleID string) (*State, error) {
+	return i.updateStatusAndRun(state, model.ClusterStatusReconcilePending, model.InitiatorScheduler, model.ReasonScheduled, func(iTx *DefaultInventory) error {
+		if err := iTx.CancelScheduledReconciliation(scheduleID); err != nil && !repository.IsNotFoundError(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+// ScheduledReconciliations returns the pending scheduled reconciliations of a cluster
+// (or of all clusters if the runtimeID is empty) ordered by their point in time
+func (i *DefaultInventory) ScheduledReconciliations(runtimeID string) ([]*model.ScheduledReconciliationEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ScheduledReconciliationEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	selectQ := q.Select()
+	if runtimeID != "" {
+		selectQ.Where(map[string]interface{}{"RuntimeID": runtimeID})
+	}
+	entities, err := selectQ.OrderBy(map[string]string{"NotBefore": "asc"}).GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return toScheduledReconciliations(entities), nil
+}
+
+// DueScheduledReconciliations returns the scheduled reconciliations whose point in time was reached
+func (i *DefaultInventory) DueScheduledReconciliations(now time.Time) ([]*model.ScheduledReconciliationEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.ScheduledReconciliationEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		WhereCompare(model.ScheduledReconciliationEntityFields.NotBefore, db.LessOrEqual, now).
+		OrderBy(map[string]string{model.ScheduledReconciliationEntityFields.NotBefore: "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	return toScheduledReconciliations(entities), nil
+}
+
+func toScheduledReconciliations(entities []db.DatabaseEntity) []*model.ScheduledReconciliationEntity {
+	schedules := make([]*model.ScheduledReconciliationEntity, 0, len(entities))
+	for _, entity := range entities {
+		schedules = append(schedules, entity.(*model.ScheduledReconciliationEntity))
+	}
+	return schedules
+}
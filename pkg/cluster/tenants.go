@@ -0,0 +1,117 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+// TenantClusters returns the runtimeIDs of the clusters which belong to the tenant (global account)
+func (i *DefaultInventory) TenantClusters(globalAccountID string) ([]string, error) {
+	q, err := db.NewQuery(i.Conn, &model.ClusterEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		Where(map[string]interface{}{
+			"GlobalAccountID": globalAccountID,
+			"Deleted":         false,
+		}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[string]bool, len(entities))
+	for _, entity := range entities {
+		candidates[entity.(*model.ClusterEntity).RuntimeID] = true
+	}
+
+	//older versions of a cluster can belong to another tenant: only the latest version is relevant
+	result := make([]string, 0, len(candidates))
+	for runtimeID := range candidates {
+		latest, err := i.latestCluster(runtimeID)
+		if repository.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if latest.GlobalAccountID == globalAccountID {
+			result = append(result, runtimeID)
+		}
+	}
+	return result, nil
+}
+
+func (i *DefaultInventory) SetTenantQuota(globalAccountID string, maxParallelOperations, maxOperationsPerMinute int64) (*model.TenantQuotaEntity, error) {
+	if globalAccountID == "" {
+		return nil, fmt.Errorf("tenant quota requires a global account ID")
+	}
+	if maxParallelOperations < 0 || maxOperationsPerMinute < 0 {
+		return nil, fmt.Errorf("limits of tenant quota cannot be < 0 (parallel operations: %d, operations per minute: %d)",
+			maxParallelOperations, maxOperationsPerMinute)
+	}
+	quota := &model.TenantQuotaEntity{
+		GlobalAccountID:        globalAccountID,
+		MaxParallelOperations:  maxParallelOperations,
+		MaxOperationsPerMinute: maxOperationsPerMinute,
+	}
+	dbOps := func(tx *db.TxConnection) error {
+		q, err := db.NewQuery(tx, quota, i.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := q.Delete().Where(map[string]interface{}{
+			"GlobalAccountID": globalAccountID,
+		}).Exec(); err != nil {
+			return err
+		}
+		return q.Insert().Exec()
+	}
+	if err := db.Transaction(i.Conn, dbOps, i.Logger); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to set quota of tenant '%s'", globalAccountID))
+	}
+	return quota, nil
+}
+
+func (i *DefaultInventory) RemoveTenantQuota(globalAccountID string) error {
+	q, err := db.NewQuery(i.Conn, &model.TenantQuotaEntity{}, i.Logger)
+	if err != nil {
+		return err
+	}
+	whereCond := map[string]interface{}{
+		"GlobalAccountID": globalAccountID,
+	}
+	deleted, err := q.Delete().Where(whereCond).Exec()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return i.NewNotFoundError(fmt.Errorf("no quota defined for tenant '%s'", globalAccountID),
+			&model.TenantQuotaEntity{}, whereCond)
+	}
+	return nil
+}
+
+// TenantQuotas returns the quotas of all tenants which override the default limits
+func (i *DefaultInventory) TenantQuotas() ([]*model.TenantQuotaEntity, error) {
+	q, err := db.NewQuery(i.Conn, &model.TenantQuotaEntity{}, i.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := q.Select().
+		OrderBy(map[string]string{"GlobalAccountID": "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	quotas := make([]*model.TenantQuotaEntity, 0, len(entities))
+	for _, entity := range entities {
+		quotas = append(quotas, entity.(*model.TenantQuotaEntity))
+	}
+	return quotas, nil
+}
@@ -0,0 +1,97 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/pkg/errors"
+)
+
+// UpgradePathError is returned if an upgrade violates the upgrade path rules. It lists the versions
+// which have to be installed before the target version is accepted.
+type UpgradePathError struct {
+	From             string
+	To               string
+	RequiredVersions []string
+}
+
+func (e *UpgradePathError) Error() string {
+	return fmt.Sprintf("upgrade from Kyma version '%s' to '%s' is not supported: upgrade to the intermediate "+
+		"versions '%s' first", e.From, e.To, strings.Join(e.RequiredVersions, "', '"))
+}
+
+type UpgradePath struct {
+	allowMajorSkip   bool
+	requiredVersions []*semver.Version
+}
+
+func NewUpgradePath(cfg config.UpgradePathConfig) (*UpgradePath, error) {
+	up := &UpgradePath{
+		allowMajorSkip: cfg.AllowMajorSkip,
+	}
+	for _, version := range cfg.RequiredVersions {
+		requiredVersion, err := semver.NewVersion(version)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("required upgrade version '%s' is not a semantic version", version))
+		}
+		up.requiredVersions = append(up.requiredVersions, requiredVersion)
+	}
+	sort.Sort(semver.Collection(up.requiredVersions))
+	return up, nil
+}
+
+// Validate returns an UpgradePathError if the upgrade skips a major version or a required version.
+// Downgrades and versions which aren't semantic versions (e.g. 'main' or 'PR-123') are not validated.
+func (up *UpgradePath) Validate(from, to string) error {
+	fromVersion, err := semver.NewVersion(from)
+	if err != nil {
+		return nil
+	}
+	toVersion, err := semver.NewVersion(to)
+	if err != nil || !toVersion.GreaterThan(fromVersion) {
+		return nil
+	}
+
+	var required []string
+	coveredMajors := map[uint64]bool{}
+	for _, requiredVersion := range up.requiredVersions {
+		if requiredVersion.GreaterThan(fromVersion) && requiredVersion.LessThan(toVersion) {
+			required = append(required, requiredVersion.Original())
+			coveredMajors[requiredVersion.Major()] = true
+		}
+	}
+	if !up.allowMajorSkip {
+		var skippedMajors []string
+		for major := fromVersion.Major() + 1; major < toVersion.Major(); major++ {
+			if !coveredMajors[major] {
+				skippedMajors = append(skippedMajors, fmt.Sprintf("%d.x", major))
+			}
+		}
+		required = mergeRequiredVersions(required, skippedMajors)
+	}
+
+	if len(required) == 0 {
+		return nil
+	}
+	return &UpgradePathError{
+		From:             from,
+		To:               to,
+		RequiredVersions: required,
+	}
+}
+
+// mergeRequiredVersions returns the required versions and the skipped major versions ('<major>.x') in upgrade order
+func mergeRequiredVersions(required, skippedMajors []string) []string {
+	result := append(required, skippedMajors...)
+	sort.SliceStable(result, func(i, j int) bool {
+		return upgradeOrder(result[i]).LessThan(upgradeOrder(result[j]))
+	})
+	return result
+}
+
+func upgradeOrder(version string) *semver.Version {
+	return semver.MustParse(strings.TrimSuffix(version, ".x"))
+}
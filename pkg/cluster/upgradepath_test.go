@@ -0,0 +1,94 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradePath(t *testing.T) {
+	testCases := []struct {
+		name             string
+		cfg              config.UpgradePathConfig
+		from             string
+		to               string
+		expectedRequired []string
+	}{
+		{
+			name: "Minor upgrade",
+			from: "1.24.0",
+			to:   "1.26.3",
+		},
+		{
+			name: "Upgrade to next major version",
+			from: "1.24.0",
+			to:   "2.0.0",
+		},
+		{
+			name:             "Upgrade skipping major versions",
+			from:             "1.24.0",
+			to:               "4.1.0",
+			expectedRequired: []string{"2.x", "3.x"},
+		},
+		{
+			name: "Skipping major versions is allowed",
+			cfg:  config.UpgradePathConfig{AllowMajorSkip: true},
+			from: "1.24.0",
+			to:   "3.0.0",
+		},
+		{
+			name:             "Upgrade skipping required versions",
+			cfg:              config.UpgradePathConfig{RequiredVersions: []string{"2.4.0", "2.0.0", "3.0.0"}},
+			from:             "1.24.0",
+			to:               "2.6.0",
+			expectedRequired: []string{"2.0.0", "2.4.0"},
+		},
+		{
+			name: "Upgrade to required version",
+			cfg:  config.UpgradePathConfig{RequiredVersions: []string{"2.0.0"}},
+			from: "1.24.0",
+			to:   "2.0.0",
+		},
+		{
+			name:             "Required versions cover skipped major versions",
+			cfg:              config.UpgradePathConfig{RequiredVersions: []string{"3.2.0"}},
+			from:             "1.24.0",
+			to:               "5.0.0",
+			expectedRequired: []string{"2.x", "3.2.0", "4.x"},
+		},
+		{
+			name: "Downgrade is not validated",
+			cfg:  config.UpgradePathConfig{RequiredVersions: []string{"2.0.0"}},
+			from: "3.0.0",
+			to:   "1.0.0",
+		},
+		{
+			name: "Non-semantic versions are not validated",
+			from: "1.24.0",
+			to:   "main",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			upgradePath, err := NewUpgradePath(testCase.cfg)
+			require.NoError(t, err)
+
+			err = upgradePath.Validate(testCase.from, testCase.to)
+			if len(testCase.expectedRequired) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var upgradePathErr *UpgradePathError
+			require.True(t, errors.As(err, &upgradePathErr))
+			require.Equal(t, testCase.expectedRequired, upgradePathErr.RequiredVersions)
+		})
+	}
+
+	t.Run("Invalid required version", func(t *testing.T) {
+		_, err := NewUpgradePath(config.UpgradePathConfig{RequiredVersions: []string{"abc"}})
+		require.Error(t, err)
+	})
+}
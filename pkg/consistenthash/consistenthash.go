@@ -0,0 +1,29 @@
+// Package consistenthash assigns keys to a number of buckets. The assignment is stable: a key is always assigned
+// to the same bucket and changing the number of buckets moves only the keys which have to move
+// (e.g. growing from n to n+1 buckets moves about 1/(n+1) of the keys, all of them into the new bucket).
+package consistenthash
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Bucket returns the bucket of the key in the range [0, buckets). It returns 0 if buckets is less than 1.
+func Bucket(key string, buckets int) int {
+	if buckets < 1 {
+		return 0
+	}
+	sum := sha256.Sum256([]byte(key))
+	return jump(binary.LittleEndian.Uint64(sum[0:8]), buckets)
+}
+
+// jump implements the jump consistent hash algorithm (Lamping and Veach, 2014)
+func jump(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
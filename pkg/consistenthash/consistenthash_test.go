@@ -0,0 +1,44 @@
+package consistenthash
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket(t *testing.T) {
+	t.Run("Assignment is stable", func(t *testing.T) {
+		require.Equal(t, 3, Bucket("testInstance", 6))
+		require.Equal(t, Bucket("testInstance", 6), Bucket("testInstance", 6))
+	})
+
+	t.Run("Undefined buckets", func(t *testing.T) {
+		require.Equal(t, 0, Bucket("testInstance", 0))
+		require.Equal(t, 0, Bucket("testInstance", -1))
+		require.Equal(t, 0, Bucket("testInstance", 1))
+	})
+
+	t.Run("Keys are distributed evenly", func(t *testing.T) {
+		counts := make([]int, 6)
+		for i := 0; i < 6000; i++ {
+			counts[Bucket(fmt.Sprintf("instance-%d", i), 6)]++
+		}
+		for bucket, count := range counts {
+			require.InDelta(t, 1000, count, 100, "bucket %d", bucket)
+		}
+	})
+
+	t.Run("Adding a bucket moves keys only into the new bucket", func(t *testing.T) {
+		moved := 0
+		for i := 0; i < 10000; i++ {
+			key := fmt.Sprintf("instance-%d", i)
+			before, after := Bucket(key, 6), Bucket(key, 7)
+			if before != after {
+				require.Equal(t, 6, after)
+				moved++
+			}
+		}
+		require.InDelta(t, 10000/7, moved, 200)
+	})
+}
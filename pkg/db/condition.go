@@ -0,0 +1,118 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimestampFormat is the format of timestamps passed as arguments of queries
+const TimestampFormat = "2006-01-02 15:04:05.000"
+
+// Operator compares a column with a value
+type Operator string
+
+const (
+	Equal          Operator = "="
+	NotEqual       Operator = "<>"
+	Less           Operator = "<"
+	LessOrEqual    Operator = "<="
+	Greater        Operator = ">"
+	GreaterOrEqual Operator = ">="
+)
+
+func (o Operator) valid() bool {
+	switch o {
+	case Equal, NotEqual, Less, LessOrEqual, Greater, GreaterOrEqual:
+		return true
+	}
+	return false
+}
+
+// addCompareCondition renders the comparison of the column of a field with the placeholder and returns the value
+// to bind
+func (q *Query) addCompareCondition(field string, op Operator, value interface{}, placeholder int) (interface{}, error) {
+	if !op.valid() {
+		return nil, fmt.Errorf("operator '%s' is not supported", op)
+	}
+	col, err := q.columnHandler.ColumnName(field)
+	if err != nil {
+		return nil, err
+	}
+	q.addWhere()
+	q.buffer.WriteString(fmt.Sprintf(" %s%s$%d", col, op, placeholder))
+	return queryArg(value), nil
+}
+
+// addInValuesCondition renders the check whether the column of a field matches one of the values and returns the
+// values to bind
+func (q *Query) addInValuesCondition(field string, values []interface{}, placeholder int) ([]interface{}, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no values defined for IN condition of field '%s'", field)
+	}
+	col, err := q.columnHandler.ColumnName(field)
+	if err != nil {
+		return nil, err
+	}
+	placeholders := make([]string, 0, len(values))
+	args := make([]interface{}, 0, len(values))
+	for i, value := range values {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", placeholder+i))
+		args = append(args, queryArg(value))
+	}
+	q.addWhere()
+	q.buffer.WriteString(fmt.Sprintf(" %s IN (%s)", col, strings.Join(placeholders, ",")))
+	return args, nil
+}
+
+// queryArg converts timestamps to UTC as all timestamps are stored in UTC
+func queryArg(value interface{}) interface{} {
+	if timestamp, ok := value.(time.Time); ok {
+		return timestamp.UTC().Format(TimestampFormat)
+	}
+	return value
+}
+
+// WhereCompare adds a condition which compares a field with a value
+func (s *Select) WhereCompare(field string, op Operator, value interface{}) *Select {
+	arg, err := s.addCompareCondition(field, op, value, s.NextPlaceholderCount())
+	if err != nil {
+		s.setErr(err)
+		return s
+	}
+	s.args = append(s.args, arg)
+	return s
+}
+
+// WhereCompare adds a condition which compares a field with a value
+func (d *Delete) WhereCompare(field string, op Operator, value interface{}) *Delete {
+	arg, err := d.addCompareCondition(field, op, value, d.NextPlaceholderCount())
+	if err != nil {
+		d.setErr(err)
+		return d
+	}
+	d.args = append(d.args, arg)
+	return d
+}
+
+// WhereInValues adds a condition which checks whether a field matches one of the values
+func (s *Select) WhereInValues(field string, values ...interface{}) *Select {
+	args, err := s.addInValuesCondition(field, values, s.NextPlaceholderCount())
+	if err != nil {
+		s.setErr(err)
+		return s
+	}
+	s.args = append(s.args, args...)
+	return s
+}
+
+// WhereInValues adds a condition which checks whether a field matches one of the values
+func (d *Delete) WhereInValues(field string, values ...interface{}) *Delete {
+	args, err := d.addInValuesCondition(field, values, d.NextPlaceholderCount())
+	if err != nil {
+		d.setErr(err)
+		return d
+	}
+	d.args = append(d.args, args...)
+	return d
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 
 	file "github.com/kyma-incubator/reconciler/pkg/files"
@@ -21,9 +22,11 @@ const KeyLength = 32
 type Encryptor struct {
 	keyID [16]byte
 	aead  cipher.AEAD
+	//previous keys are only used for decrypting data which wasn't re-encrypted yet after a key rotation
+	previous map[string]cipher.AEAD
 }
 
-func NewEncryptor(key string) (*Encryptor, error) {
+func NewEncryptor(key string, previousKeys ...string) (*Encryptor, error) {
 	if len(key) == 0 {
 		return nil, fmt.Errorf("cannot create new encryptor instance because encryption key was an empty string")
 	}
@@ -33,10 +36,21 @@ func NewEncryptor(key string) (*Encryptor, error) {
 		return nil, err
 	}
 
-	return &Encryptor{
-		aead:  aead,
-		keyID: md5.Sum([]byte(key)), //nolint: gosec //using MD5 just for generating a checksum of the key
-	}, nil
+	encryptor := &Encryptor{
+		aead:     aead,
+		keyID:    md5.Sum([]byte(key)), //nolint: gosec //using MD5 just for generating a checksum of the key
+		previous: make(map[string]cipher.AEAD, len(previousKeys)),
+	}
+	for _, previousKey := range previousKeys {
+		previousAEAD, err := newAEAD(previousKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "previous encryption key is invalid")
+		}
+		if previousKeyID := keyID(previousKey); previousKeyID != encryptor.KeyID() {
+			encryptor.previous[previousKeyID] = previousAEAD
+		}
+	}
+	return encryptor, nil
 }
 
 // NewEncryptionKey generates a random 32 byte key for AES-256
@@ -63,6 +77,20 @@ func (e *Encryptor) KeyID() string {
 	return fmt.Sprintf("%x", e.keyID)[:keyIDLength]
 }
 
+// PreviousKeyIDs returns the IDs of the previous keys which are still accepted for decrypting data
+func (e *Encryptor) PreviousKeyIDs() []string {
+	keyIDs := make([]string, 0, len(e.previous))
+	for previousKeyID := range e.previous {
+		keyIDs = append(keyIDs, previousKeyID)
+	}
+	sort.Strings(keyIDs)
+	return keyIDs
+}
+
+func keyID(key string) string {
+	return fmt.Sprintf("%x", md5.Sum([]byte(key)))[:keyIDLength] //nolint: gosec //using MD5 just for generating a checksum of the key
+}
+
 func (e *Encryptor) Encrypt(data string) (string, error) {
 	nonce := make([]byte, e.aead.NonceSize())
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
@@ -73,19 +101,23 @@ func (e *Encryptor) Encrypt(data string) (string, error) {
 }
 
 func (e *Encryptor) Decrypt(encData string) (string, error) {
-	if !e.Decryptable(encData) {
+	aead, ok := e.aeadOf(encData)
+	if !ok {
 		return "", fmt.Errorf("data cannot be decrypted because encryption key does not match")
 	}
 
-	enc, err := hex.DecodeString(strings.TrimPrefix(encData, e.KeyID())) //remove keyID from encrypted data
+	enc, err := hex.DecodeString(encData[keyIDLength:]) //remove keyID from encrypted data
 	if err != nil {
 		return "", fmt.Errorf("failed to decode HEX string to bytes")
 	}
 
-	nonceSize := e.aead.NonceSize()
+	nonceSize := aead.NonceSize()
+	if len(enc) < nonceSize {
+		return "", fmt.Errorf("encrypted data is too short")
+	}
 	nonce, cipherText := enc[:nonceSize], enc[nonceSize:]
 
-	data, err := e.aead.Open(nil, nonce, cipherText, nil)
+	data, err := aead.Open(nil, nonce, cipherText, nil)
 	if err != nil {
 		return "", err
 	}
@@ -95,7 +127,20 @@ func (e *Encryptor) Decrypt(encData string) (string, error) {
 
 // Decryptable verifies whether the encrypted data can be decrypted by this Encryptor instance
 func (e *Encryptor) Decryptable(encData string) bool {
-	return strings.HasPrefix(encData, e.KeyID()) //KeyID prefix of encrypted data has to match with current KeyID
+	_, ok := e.aeadOf(encData)
+	return ok
+}
+
+// aeadOf returns the cipher of the key whose ID prefixes the encrypted data
+func (e *Encryptor) aeadOf(encData string) (cipher.AEAD, bool) {
+	if strings.HasPrefix(encData, e.KeyID()) { //KeyID prefix of encrypted data has to match with current KeyID
+		return e.aead, true
+	}
+	if len(encData) < keyIDLength {
+		return nil, false
+	}
+	aead, ok := e.previous[encData[:keyIDLength]]
+	return aead, ok
 }
 
 func readKeyFile(encKeyFile string) (string, error) {
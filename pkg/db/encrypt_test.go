@@ -3,6 +3,7 @@ package db
 import (
 	"github.com/stretchr/testify/require"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -34,6 +35,34 @@ func TestEncryptor(t *testing.T) {
 		require.True(t, enc2.Decryptable(encData2))
 	})
 
+	t.Run("Decrypt with previous keys", func(t *testing.T) {
+		oldEnc := newEncryptor(t)
+		oldData, err := oldEnc.Encrypt(data)
+		require.NoError(t, err)
+
+		oldKey, err := NewEncryptionKey()
+		require.NoError(t, err)
+		newKey, err := NewEncryptionKey()
+		require.NoError(t, err)
+		rotatedEnc, err := NewEncryptor(newKey, oldKey)
+		require.NoError(t, err)
+		require.False(t, rotatedEnc.Decryptable(oldData)) //encrypted with an unknown key
+
+		oldEnc, err = NewEncryptor(oldKey)
+		require.NoError(t, err)
+		oldData, err = oldEnc.Encrypt(data)
+		require.NoError(t, err)
+		decData, err := rotatedEnc.Decrypt(oldData)
+		require.NoError(t, err)
+		require.Equal(t, data, decData)
+
+		newData, err := rotatedEnc.Encrypt(data)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(newData, rotatedEnc.KeyID()))
+		require.False(t, oldEnc.Decryptable(newData)) //new data is always encrypted with the current key
+		require.Equal(t, []string{oldEnc.KeyID()}, rotatedEnc.PreviousKeyIDs())
+	})
+
 	t.Run("Encrypt and decrypt", func(t *testing.T) {
 		enc := newEncryptor(t)
 
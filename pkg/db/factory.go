@@ -20,6 +20,10 @@ func NewConnectionFactory(configFile string, migrate bool, debug bool) (Connecti
 	if err != nil {
 		return nil, err
 	}
+	previousEncKeys, err := readPreviousEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
 
 	dbToUse := viper.GetString("db.driver")
 	blockQueries := viper.GetBool("db.blockQueries")
@@ -27,11 +31,11 @@ func NewConnectionFactory(configFile string, migrate bool, debug bool) (Connecti
 
 	switch dbToUse {
 	case "postgres":
-		connFact := createPostgresConnectionFactory(encKey, debug, blockQueries, logQueries)
+		connFact := createPostgresConnectionFactory(encKey, previousEncKeys, debug, blockQueries, logQueries)
 		return connFact, connFact.Init(migrate)
 
 	case "sqlite":
-		connFact, err := createSqliteConnectionFactory(encKey, debug, blockQueries, logQueries)
+		connFact, err := createSqliteConnectionFactory(encKey, previousEncKeys, debug, blockQueries, logQueries)
 		if err != nil {
 			return nil, errors.Wrap(err, "error creating sqliteConnectionFactory")
 		}
@@ -47,7 +51,25 @@ func MigrateDatabase(configFile string, debug bool) error {
 	return err
 }
 
-func createSqliteConnectionFactory(encKey string, debug bool, blockQueries, logQueries bool) (*sqliteConnectionFactory, error) {
+// NewEncryptorFromConfig returns the encryptor using the encryption keys of the configuration file: data which
+// is stored outside of the database (e.g. in Redis) is encrypted with the same keys
+func NewEncryptorFromConfig(configFile string) (*Encryptor, error) {
+	viper.SetConfigFile(configFile)
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	encKey, err := readEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	previousEncKeys, err := readPreviousEncryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+	return NewEncryptor(encKey, previousEncKeys...)
+}
+
+func createSqliteConnectionFactory(encKey string, previousEncKeys []string, debug bool, blockQueries, logQueries bool) (*sqliteConnectionFactory, error) {
 	dbFile := viper.GetString("db.sqlite.file")
 	//ensure directory structure of db-file exists
 	dbFileDir := filepath.Dir(dbFile)
@@ -57,12 +79,13 @@ func createSqliteConnectionFactory(encKey string, debug bool, blockQueries, logQ
 		}
 	}
 	connFact := &sqliteConnectionFactory{
-		file:          dbFile,
-		debug:         debug,
-		reset:         viper.GetBool("db.sqlite.resetDatabase"),
-		encryptionKey: encKey,
-		blockQueries:  blockQueries,
-		logQueries:    logQueries,
+		file:                   dbFile,
+		debug:                  debug,
+		reset:                  viper.GetBool("db.sqlite.resetDatabase"),
+		encryptionKey:          encKey,
+		previousEncryptionKeys: previousEncKeys,
+		blockQueries:           blockQueries,
+		logQueries:             logQueries,
 	}
 	if viper.GetBool("db.sqlite.deploySchema") {
 		connFact.schemaFile = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), "db", "sqlite", "reconciler.sql")
@@ -169,7 +192,24 @@ func readEncryptionKey() (string, error) {
 	return readKeyFile(encKeyFile)
 }
 
-func createPostgresConnectionFactory(encKey string, debug bool, blockQueries, logQueries bool) *postgresConnectionFactory {
+// readPreviousEncryptionKeys returns the keys which were replaced by a key rotation: they are still used for
+// decrypting data until all encrypted columns were re-encrypted with the current key
+func readPreviousEncryptionKeys() ([]string, error) {
+	var encKeys []string
+	for _, encKeyFile := range viper.GetStringSlice("db.encryption.previousKeyFiles") {
+		if !filepath.IsAbs(encKeyFile) {
+			encKeyFile = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), encKeyFile)
+		}
+		encKey, err := readKeyFile(encKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read previous encryption key")
+		}
+		encKeys = append(encKeys, encKey)
+	}
+	return encKeys, nil
+}
+
+func createPostgresConnectionFactory(encKey string, previousEncKeys []string, debug bool, blockQueries, logQueries bool) *postgresConnectionFactory {
 
 	env := getPostgresEnvironment()
 
@@ -182,6 +222,7 @@ func createPostgresConnectionFactory(encKey string, debug bool, blockQueries, lo
 		sslMode:         env.sslMode,
 		sslRootCert:     env.sslRootCert,
 		encryptionKey:   encKey,
+		previousEncKeys: previousEncKeys,
 		migrationsDir:   env.migrationsDir,
 		blockQueries:    blockQueries,
 		logQueries:      logQueries,
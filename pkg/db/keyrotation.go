@@ -0,0 +1,195 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// EncryptedColumn identifies a column whose values are encrypted: the key fields have to identify a row uniquely
+type EncryptedColumn struct {
+	Entity    DatabaseEntity
+	Field     string
+	KeyFields []string
+}
+
+func (c *EncryptedColumn) String() string {
+	return fmt.Sprintf("%s.%s", c.Entity.Table(), c.Field)
+}
+
+// KeyRotationResult summarizes the re-encryption of a column
+type KeyRotationResult struct {
+	Column  string
+	Rotated int
+	//values which were modified concurrently while they got re-encrypted (they are retried by the next run)
+	Skipped int
+}
+
+// KeyRotator re-encrypts the values of encrypted columns, which were encrypted with a previous key, with the current
+// key of the connection. The values are processed in batches using short transactions, which allows to rotate the
+// key while the mothership is running (all mothership instances have to be configured with the current key and the
+// previous keys beforehand). Values are only re-encrypted if they weren't modified concurrently, and already
+// re-encrypted values are never selected again: an interrupted rotation can be resumed by starting it again.
+type KeyRotator struct {
+	conn      Connection
+	batchSize int
+	logger    *zap.SugaredLogger
+}
+
+func NewKeyRotator(conn Connection, batchSize int, logger *zap.SugaredLogger) (*KeyRotator, error) {
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("batch size of the key rotation has to be > 0 (was %d)", batchSize)
+	}
+	return &KeyRotator{
+		conn:      conn,
+		batchSize: batchSize,
+		logger:    logger,
+	}, nil
+}
+
+func (r *KeyRotator) Rotate(ctx context.Context, columns []*EncryptedColumn) ([]*KeyRotationResult, error) {
+	previousKeyIDs := r.conn.Encryptor().PreviousKeyIDs()
+	if len(previousKeyIDs) == 0 {
+		return nil, fmt.Errorf("no previous encryption keys are configured: nothing to rotate")
+	}
+
+	var results []*KeyRotationResult
+	for _, column := range columns {
+		result := &KeyRotationResult{Column: column.String()}
+		for _, keyID := range previousKeyIDs {
+			if err := r.rotateColumn(ctx, column, keyID, result); err != nil {
+				return results, errors.Wrapf(err, "failed to rotate encryption key of column '%s'", column)
+			}
+		}
+		r.logger.Infof("Re-encrypted %d values of column '%s' (%d values were modified concurrently)",
+			result.Rotated, column, result.Skipped)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (r *KeyRotator) rotateColumn(ctx context.Context, column *EncryptedColumn, keyID string,
+	result *KeyRotationResult) error {
+	colHdlr, err := NewColumnHandler(column.Entity, r.conn, r.logger)
+	if err != nil {
+		return err
+	}
+	colName, err := colHdlr.ColumnName(column.Field)
+	if err != nil {
+		return err
+	}
+	keyColNames := make([]string, 0, len(column.KeyFields))
+	for _, keyField := range column.KeyFields {
+		keyColName, err := colHdlr.ColumnName(keyField)
+		if err != nil {
+			return err
+		}
+		keyColNames = append(keyColNames, keyColName)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		var rotated, skipped int
+		if err := Transaction(r.conn, func(tx *TxConnection) error {
+			var txErr error
+			rotated, skipped, txErr = r.rotateBatch(tx, column.Entity.Table(), colName, keyColNames, keyID)
+			return txErr
+		}, r.logger); err != nil {
+			return err
+		}
+		result.Rotated += rotated
+		result.Skipped += skipped
+		if rotated == 0 { //all values of the previous key were re-encrypted (or are modified concurrently)
+			return nil
+		}
+		r.logger.Debugf("Re-encrypted batch of %d values of column '%s'", rotated, column)
+	}
+}
+
+func (r *KeyRotator) rotateBatch(tx *TxConnection, table, colName string, keyColNames []string,
+	keyID string) (int, int, error) {
+	//values encrypted with the previous key are prefixed with its ID: select them by their range
+	rows, err := tx.Query(fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s>=$1 AND %s<$2 LIMIT %d",
+		strings.Join(keyColNames, ", "), colName, table, colName, colName, r.batchSize),
+		keyID, nextKeyID(keyID))
+	if err != nil {
+		return 0, 0, err
+	}
+	type encryptedRow struct {
+		keys  []interface{}
+		value string
+	}
+	var batch []*encryptedRow
+	for rows.Next() {
+		row := &encryptedRow{keys: make([]interface{}, len(keyColNames))}
+		dest := make([]interface{}, 0, len(keyColNames)+1)
+		for idx := range row.keys {
+			dest = append(dest, &row.keys[idx])
+		}
+		if err := rows.Scan(append(dest, &row.value)...); err != nil {
+			return 0, 0, err
+		}
+		for idx, key := range row.keys {
+			if bytes, ok := key.([]byte); ok { //some drivers return text columns as byte slices
+				row.keys[idx] = string(bytes)
+			}
+		}
+		batch = append(batch, row)
+	}
+
+	var rotated, skipped int
+	for _, row := range batch {
+		value, err := tx.Encryptor().Decrypt(row.value)
+		if err != nil {
+			return rotated, skipped, err
+		}
+		encValue, err := tx.Encryptor().Encrypt(value)
+		if err != nil {
+			return rotated, skipped, err
+		}
+
+		//update the value only if it wasn't modified in the meantime
+		args := []interface{}{encValue}
+		var conditions []string
+		for idx, keyColName := range keyColNames {
+			args = append(args, row.keys[idx])
+			conditions = append(conditions, fmt.Sprintf("%s=$%d", keyColName, len(args)))
+		}
+		args = append(args, row.value)
+		conditions = append(conditions, fmt.Sprintf("%s=$%d", colName, len(args)))
+		res, err := tx.Exec(fmt.Sprintf("UPDATE %s SET %s=$1 WHERE %s",
+			table, colName, strings.Join(conditions, " AND ")), args...)
+		if err != nil {
+			return rotated, skipped, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return rotated, skipped, err
+		}
+		if affected == 0 {
+			skipped++
+		} else {
+			rotated++
+		}
+	}
+	return rotated, skipped, nil
+}
+
+// nextKeyID returns the smallest key ID which is greater than all values prefixed with the given key ID
+func nextKeyID(keyID string) string {
+	last := strings.IndexByte(hexDigits, keyID[len(keyID)-1])
+	if last < 0 || last == len(hexDigits)-1 {
+		return keyID[:len(keyID)-1] + "g" //'g' follows the highest HEX digit
+	}
+	return keyID[:len(keyID)-1] + string(hexDigits[last+1])
+}
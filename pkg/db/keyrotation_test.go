@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type secretEntity struct {
+	ID     int64  `db:"notNull"`
+	Secret string `db:"notNull,encrypt"`
+}
+
+func (e *secretEntity) String() string {
+	return fmt.Sprintf("secretEntity [ID=%d]", e.ID)
+}
+
+func (e *secretEntity) New() DatabaseEntity {
+	return &secretEntity{}
+}
+
+func (e *secretEntity) Marshaller() *EntityMarshaller {
+	return NewEntityMarshaller(&e)
+}
+
+func (e *secretEntity) Table() string {
+	return "secrets"
+}
+
+func (e *secretEntity) Equal(other DatabaseEntity) bool {
+	otherEntity, ok := other.(*secretEntity)
+	return ok && e.ID == otherEntity.ID
+}
+
+func TestNextKeyID(t *testing.T) {
+	require.Equal(t, "abc1", nextKeyID("abc0"))
+	require.Equal(t, "abca", nextKeyID("abc9"))
+	require.Equal(t, "abcg", nextKeyID("abcf"))
+}
+
+func TestKeyRotator(t *testing.T) {
+	oldKey, err := NewEncryptionKey()
+	require.NoError(t, err)
+	newKey, err := NewEncryptionKey()
+	require.NoError(t, err)
+
+	sqlDB, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1) //each connection would get its own in-memory database
+	defer func() {
+		require.NoError(t, sqlDB.Close())
+	}()
+
+	//store secrets encrypted with the old key
+	oldConn, err := newSqliteConnection(sqlDB, oldKey, nil, false, true)
+	require.NoError(t, err)
+	_, err = oldConn.Exec("CREATE TABLE secrets (id integer PRIMARY KEY, secret text NOT NULL)")
+	require.NoError(t, err)
+	for id := 1; id <= 5; id++ {
+		encSecret, err := oldConn.Encryptor().Encrypt(fmt.Sprintf("secret%d", id))
+		require.NoError(t, err)
+		_, err = oldConn.Exec("INSERT INTO secrets (id, secret) VALUES ($1, $2) RETURNING id", id, encSecret)
+		require.NoError(t, err)
+	}
+
+	column := &EncryptedColumn{Entity: &secretEntity{}, Field: "Secret", KeyFields: []string{"ID"}}
+	log := logger.NewLogger(true)
+
+	t.Run("Rotation requires previous keys", func(t *testing.T) {
+		rotator, err := NewKeyRotator(oldConn, 2, log)
+		require.NoError(t, err)
+		_, err = rotator.Rotate(context.Background(), []*EncryptedColumn{column})
+		require.Error(t, err)
+	})
+
+	t.Run("Values are re-encrypted with the new key", func(t *testing.T) {
+		newConn, err := newSqliteConnection(sqlDB, newKey, []string{oldKey}, false, true)
+		require.NoError(t, err)
+		rotator, err := NewKeyRotator(newConn, 2, log)
+		require.NoError(t, err)
+
+		results, err := rotator.Rotate(context.Background(), []*EncryptedColumn{column})
+		require.NoError(t, err)
+		require.Equal(t, []*KeyRotationResult{{Column: "secrets.Secret", Rotated: 5}}, results)
+
+		//values are readable with the new key only
+		newOnlyEncryptor, err := NewEncryptor(newKey)
+		require.NoError(t, err)
+		rows, err := newConn.Query("SELECT id, secret FROM secrets WHERE id>$1 ORDER BY id", 0)
+		require.NoError(t, err)
+		var id int
+		for rows.Next() {
+			var encSecret string
+			require.NoError(t, rows.Scan(&id, &encSecret))
+			secret, err := newOnlyEncryptor.Decrypt(encSecret)
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("secret%d", id), secret)
+		}
+		require.Equal(t, 5, id)
+
+		//a repeated rotation has nothing to do
+		results, err = rotator.Rotate(context.Background(), []*EncryptedColumn{column})
+		require.NoError(t, err)
+		require.Equal(t, []*KeyRotationResult{{Column: "secrets.Secret"}}, results)
+	})
+}
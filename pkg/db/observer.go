@@ -0,0 +1,97 @@
+package db
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const unknownTable = "unknown"
+
+// tableRegex extracts the table a statement is operating on
+var tableRegex = regexp.MustCompile(`(?i)\b(?:from|into|update)\s+"?([a-z0-9_]+)`)
+
+// QueryObserver is notified about each executed statement (e.g. to expose metrics)
+type QueryObserver interface {
+	ObserveQuery(statement, table string, duration time.Duration, err error)
+}
+
+var (
+	queryObserver   QueryObserver
+	queryObserverMu sync.RWMutex
+)
+
+// SetQueryObserver registers the observer which gets notified about all statements executed by any connection
+// (a nil value removes the observer)
+func SetQueryObserver(observer QueryObserver) {
+	queryObserverMu.Lock()
+	defer queryObserverMu.Unlock()
+	queryObserver = observer
+}
+
+func observeQuery(query string, started time.Time, err error) {
+	queryObserverMu.RLock()
+	observer := queryObserver
+	queryObserverMu.RUnlock()
+	if observer == nil {
+		return
+	}
+	statement, table := parseQuery(query)
+	observer.ObserveQuery(statement, table, time.Since(started), err)
+}
+
+// parseQuery returns the type of the statement (e.g. select, insert) and the name of the affected table
+func parseQuery(query string) (string, string) {
+	statement := "other"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		switch keyword := strings.ToLower(fields[0]); keyword {
+		case "select", "insert", "update", "delete":
+			statement = keyword
+		}
+	}
+	table := unknownTable
+	if match := tableRegex.FindStringSubmatch(query); len(match) > 1 {
+		table = strings.ToLower(match[1])
+	}
+	return statement, table
+}
+
+// TransactionObserver is notified about transactions and statements which collided with concurrent transactions
+type TransactionObserver interface {
+	// ObserveTransactionRetry is called before a collided operation is repeated
+	ObserveTransactionRetry(reason string)
+	// ObserveTransactionAbort is called if a collided operation is given up after all retries failed
+	ObserveTransactionAbort(reason string)
+}
+
+var (
+	txObserver   TransactionObserver
+	txObserverMu sync.RWMutex
+)
+
+// SetTransactionObserver registers the observer which gets notified about retried or aborted transactions
+// (a nil value removes the observer)
+func SetTransactionObserver(observer TransactionObserver) {
+	txObserverMu.Lock()
+	defer txObserverMu.Unlock()
+	txObserver = observer
+}
+
+func currentTransactionObserver() TransactionObserver {
+	txObserverMu.RLock()
+	defer txObserverMu.RUnlock()
+	return txObserver
+}
+
+func observeTransactionRetry(reason string) {
+	if observer := currentTransactionObserver(); observer != nil {
+		observer.ObserveTransactionRetry(reason)
+	}
+}
+
+func observeTransactionAbort(reason string) {
+	if observer := currentTransactionObserver(); observer != nil {
+		observer.ObserveTransactionAbort(reason)
+	}
+}
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testQueryObserver struct {
+	statements []string
+	errs       []error
+}
+
+func (o *testQueryObserver) ObserveQuery(statement, table string, _ time.Duration, err error) {
+	o.statements = append(o.statements, statement+":"+table)
+	o.errs = append(o.errs, err)
+}
+
+func TestQueryObserver(t *testing.T) {
+	t.Run("Parse query", func(t *testing.T) {
+		testCases := []struct {
+			query     string
+			statement string
+			table     string
+		}{
+			{"SELECT * FROM scheduler_operations WHERE scheduling_id=$1", "select", "scheduler_operations"},
+			{"  insert INTO \"inventory_clusters\" (runtime_id) VALUES ($1)", "insert", "inventory_clusters"},
+			{"UPDATE scheduler_operations SET state=$1", "update", "scheduler_operations"},
+			{"DELETE FROM scheduler_reconciliations", "delete", "scheduler_reconciliations"},
+			{"SHOW TRANSACTION ISOLATION LEVEL", "other", unknownTable},
+			{"", "other", unknownTable},
+		}
+		for _, testCase := range testCases {
+			statement, table := parseQuery(testCase.query)
+			require.Equal(t, testCase.statement, statement, testCase.query)
+			require.Equal(t, testCase.table, table, testCase.query)
+		}
+	})
+
+	t.Run("Notify observer", func(t *testing.T) {
+		observer := &testQueryObserver{}
+		SetQueryObserver(observer)
+		defer SetQueryObserver(nil)
+
+		queryErr := errors.New("query failed")
+		observeQuery("SELECT * FROM scheduler_operations", time.Now(), nil)
+		observeQuery("DELETE FROM scheduler_operations", time.Now(), queryErr)
+		require.Equal(t, []string{"select:scheduler_operations", "delete:scheduler_operations"}, observer.statements)
+		require.Equal(t, []error{nil, queryErr}, observer.errs)
+
+		SetQueryObserver(nil)
+		observeQuery("SELECT * FROM scheduler_operations", time.Now(), nil)
+		require.Len(t, observer.statements, 2)
+	})
+}
@@ -55,10 +55,10 @@ type postgresConnection struct {
 	logger    *zap.SugaredLogger
 }
 
-func newPostgresConnection(db *sql.DB, encryptionKey string, debug bool, blockQueries bool) (*postgresConnection, error) {
+func newPostgresConnection(db *sql.DB, encryptionKey string, previousEncKeys []string, debug bool, blockQueries bool) (*postgresConnection, error) {
 	logger := log.NewLogger(debug)
 
-	encryptor, err := NewEncryptor(encryptionKey)
+	encryptor, err := NewEncryptor(encryptionKey, previousEncKeys...)
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +96,7 @@ func (pc *postgresConnection) QueryRow(query string, args ...interface{}) (DataR
 	if err := pc.validator.Validate(query); err != nil {
 		return nil, err
 	}
+	defer observeQuery(query, time.Now(), nil) //errors of single row queries are reported when the row is scanned
 	return pc.db.QueryRow(query, args...), nil
 }
 
@@ -104,7 +105,9 @@ func (pc *postgresConnection) Query(query string, args ...interface{}) (DataRows
 	if err := pc.validator.Validate(query); err != nil {
 		return nil, err
 	}
+	started := time.Now()
 	rows, err := pc.db.Query(query, args...)
+	observeQuery(query, started, err)
 	if err != nil {
 		pc.logger.Errorf("Postgres Query() error: %s", err)
 	}
@@ -116,7 +119,14 @@ func (pc *postgresConnection) Exec(query string, args ...interface{}) (sql.Resul
 	if err := pc.validator.Validate(query); err != nil {
 		return nil, err
 	}
-	result, err := pc.db.Exec(query, args...)
+	var result sql.Result
+	err := execWithRetry(func() error { //statements colliding with concurrent transactions are repeated
+		var execErr error
+		started := time.Now()
+		result, execErr = pc.db.Exec(query, args...)
+		observeQuery(query, started, execErr)
+		return execErr
+	})
 	if err != nil {
 		pc.logger.Errorf("Postgres Exec() error: %s", err)
 	}
@@ -155,10 +165,12 @@ type postgresConnectionFactory struct {
 	sslMode       string
 	sslRootCert   string
 	encryptionKey string
-	migrationsDir string
-	debug         bool
-	blockQueries  bool
-	logQueries    bool
+	//keys replaced by a key rotation which are still used for decryption
+	previousEncKeys []string
+	migrationsDir   string
+	debug           bool
+	blockQueries    bool
+	logQueries      bool
 
 	maxOpenConns    int
 	maxIdleConns    int
@@ -216,7 +228,7 @@ func (pcf *postgresConnectionFactory) NewConnection() (Connection, error) {
 		return nil, err
 	}
 
-	return newPostgresConnection(db, pcf.encryptionKey, pcf.logQueries, pcf.blockQueries)
+	return newPostgresConnection(db, pcf.encryptionKey, pcf.previousEncKeys, pcf.logQueries, pcf.blockQueries)
 }
 
 func (pcf *postgresConnectionFactory) checkPostgresIsolationLevel() error {
@@ -184,6 +184,8 @@ func (s *Select) GetArgs() []interface{} {
 	return dst
 }
 
+// WhereRaw adds a hand-written condition: it's only intended for conditions which can't be expressed by
+// Where, WhereCompare, WhereInValues or WhereIn (e.g. DB specific functions or OR-combined filters)
 func (s *Select) WhereRaw(stmt string, args ...interface{}) *Select {
 	s.addWhere()
 	s.buffer.WriteString(fmt.Sprintf(" (%s)", stmt))
@@ -194,16 +196,23 @@ func (s *Select) WhereRaw(stmt string, args ...interface{}) *Select {
 func (s *Select) Where(conds map[string]interface{}) *Select {
 	args, err := s.addWhereCondition(conds, len(s.args))
 	s.args = append(s.args, args...)
-	s.err = err
+	s.setErr(err)
 	return s
 }
 
 func (s *Select) WhereIn(field, subQuery string, args ...interface{}) *Select {
-	s.err = s.addWhereInCondition(field, subQuery)
+	s.setErr(s.addWhereInCondition(field, subQuery))
 	s.args = append(s.args, args...)
 	return s
 }
 
+// setErr keeps the first error which occurred while the statement was built
+func (s *Select) setErr(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
 func (s *Select) GroupBy(args []string) *Select {
 	if len(args) == 0 {
 		return s
@@ -214,7 +223,7 @@ func (s *Select) GroupBy(args []string) *Select {
 	for _, field := range args {
 		col, err := s.columnHandler.ColumnName(field)
 		if err != nil {
-			s.err = err
+			s.setErr(err)
 			return s
 		}
 		grouping = append(grouping, fmt.Sprintf(" %s", col))
@@ -243,7 +252,7 @@ func (s *Select) OrderBy(args map[string]string) *Select {
 	for _, field := range fields {
 		col, err := s.columnHandler.ColumnName(field)
 		if err != nil {
-			s.err = err
+			s.setErr(err)
 			return s
 		}
 		ordering = append(ordering, fmt.Sprintf(" %s %s", col, args[field]))
@@ -339,10 +348,17 @@ type Delete struct {
 func (d *Delete) Where(conditions map[string]interface{}) *Delete {
 	args, err := d.addWhereCondition(conditions, len(d.args))
 	d.args = append(d.args, args...)
-	d.err = err
+	d.setErr(err)
 	return d
 }
 
+// setErr keeps the first error which occurred while the statement was built
+func (d *Delete) setErr(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
 func (d *Delete) Exec() (int64, error) {
 	if d.err != nil {
 		return 0, d.err
@@ -356,11 +372,13 @@ func (d *Delete) Exec() (int64, error) {
 }
 
 func (d *Delete) WhereIn(field, subQuery string, args ...interface{}) *Delete {
-	d.err = d.addWhereInCondition(field, subQuery)
-	d.args = args
+	d.setErr(d.addWhereInCondition(field, subQuery))
+	d.args = append(d.args, args...)
 	return d
 }
 
+// WhereRaw adds a hand-written condition: it's only intended for conditions which can't be expressed by
+// Where, WhereCompare, WhereInValues or WhereIn
 func (d *Delete) WhereRaw(stmt string, args ...interface{}) *Delete {
 	d.addWhere()
 	d.buffer.WriteString(fmt.Sprintf(" (%s)", stmt))
@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -50,6 +51,69 @@ func TestQuery(t *testing.T) {
 		require.Equal(t, fmt.Sprintf("SELECT col_1, col_2, col_3 FROM mockTable WHERE col_1 IN (%s) AND (col_2=$1 OR col_2=$2) AND col_1=$3", subQ), conn.query)
 	})
 
+	t.Run("Select Compare", func(t *testing.T) {
+		timestamp := time.Date(2022, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+		_, err := q.Select().
+			Where(map[string]interface{}{"Col1": "col1Value"}).
+			WhereCompare("Col3", GreaterOrEqual, 3).
+			WhereCompare("Col2", NotEqual, timestamp).
+			GetOne()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT col_1, col_2, col_3 FROM mockTable WHERE col_1=$1 AND col_3>=$2 AND col_2<>$3", conn.query)
+		require.Equal(t, []interface{}{"col1Value", 3, "2022-03-04 04:06:07.000"}, conn.args)
+	})
+
+	t.Run("Select keeps first error", func(t *testing.T) {
+		_, err := q.Select().
+			WhereCompare("Unknown", Less, 1).
+			Where(map[string]interface{}{"Col1": "col1Value"}).
+			GetOne()
+		require.Error(t, err)
+		q.reset()
+
+		_, err = q.Select().WhereCompare("Col1", Operator("LIKE"), "x").GetOne()
+		require.Error(t, err)
+		q.reset()
+	})
+
+	t.Run("Select In values", func(t *testing.T) {
+		timestamp := time.Date(2022, 3, 4, 5, 6, 7, 0, time.FixedZone("CET", 3600))
+		_, err := q.Select().
+			Where(map[string]interface{}{"Col1": "col1Value"}).
+			WhereInValues("Col3", 1, 2).
+			WhereInValues("Col2", timestamp).
+			GetOne()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT col_1, col_2, col_3 FROM mockTable WHERE col_1=$1 AND col_3 IN ($2,$3) AND col_2 IN ($4)", conn.query)
+		require.Equal(t, []interface{}{"col1Value", 1, 2, "2022-03-04 04:06:07.000"}, conn.args)
+
+		_, err = q.Select().WhereInValues("Col3").GetOne()
+		require.Error(t, err)
+		q.reset()
+	})
+
+	t.Run("Delete In values", func(t *testing.T) {
+		affected, err := q.Delete().
+			WhereCompare("Col3", Less, 5).
+			WhereInValues("Col1", "abc", "def").
+			Exec()
+		require.NoError(t, err)
+		require.Equal(t, MockRowsAffected, affected)
+		require.Equal(t, "DELETE FROM mockTable WHERE col_3<$1 AND col_1 IN ($2,$3)", conn.query)
+		require.Equal(t, []interface{}{5, "abc", "def"}, conn.args)
+	})
+
+	t.Run("Delete Compare", func(t *testing.T) {
+		affected, err := q.Delete().
+			Where(map[string]interface{}{"Col1": "col1Value"}).
+			WhereCompare("Col3", Less, 5).
+			Exec()
+		require.NoError(t, err)
+		require.Equal(t, MockRowsAffected, affected)
+		require.Equal(t, "DELETE FROM mockTable WHERE col_1=$1 AND col_3<$2", conn.query)
+		require.Equal(t, []interface{}{"col1Value", 5}, conn.args)
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		affected, err := q.Delete().
 			Where(map[string]interface{}{"Col1": "col1Value", "Col2": true}).
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// Reasons why a DB operation was aborted by the database and can safely be retried
+const (
+	RetryReasonSerializationFailure = "serialization_failure"
+	RetryReasonDeadlock             = "deadlock"
+	RetryReasonLocked               = "locked"
+)
+
+const (
+	maxRetries      = 5
+	minRetryBackoff = 25 * time.Millisecond
+	maxRetryBackoff = 1 * time.Second
+)
+
+// retryReason returns whether the error was caused by a conflict with a concurrent transaction. Such operations were
+// rolled back by the database and can be repeated without side effects.
+func retryReason(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "40001":
+			return RetryReasonSerializationFailure, true
+		case "40P01":
+			return RetryReasonDeadlock, true
+		}
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "could not serialize access"):
+		return RetryReasonSerializationFailure, true
+	case strings.Contains(msg, "deadlock detected"):
+		return RetryReasonDeadlock, true
+	case strings.Contains(msg, "database is locked"), strings.Contains(msg, "database table is locked"):
+		return RetryReasonLocked, true
+	}
+	return "", false
+}
+
+// retryBackoff returns the delay before the given retry (starting with 1): the delay grows exponentially and
+// is randomized to avoid that the colliding transactions are retried at the same time again.
+func retryBackoff(retry int) time.Duration {
+	backoff := minRetryBackoff << uint(retry-1)
+	if backoff <= 0 || backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	//nolint:gosec //no security relevance, linter complains can be ignored
+	jitter := time.Duration(rand.Int63n(int64(backoff) / 2))
+	return backoff/2 + jitter
+}
+
+// execWithRetry repeats a single statement which was executed outside a transaction if it collided with
+// a concurrent transaction
+func execWithRetry(exec func() error) error {
+	var err error
+	for retry := 0; retry <= maxRetries; retry++ {
+		if retry > 0 {
+			time.Sleep(retryBackoff(retry))
+		}
+		err = exec()
+		reason, retryable := retryReason(err)
+		if !retryable {
+			return err
+		}
+		if retry == maxRetries {
+			observeTransactionAbort(reason)
+			break
+		}
+		observeTransactionRetry(reason)
+	}
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/pkg/errors"
 	"os"
+	"time"
 
 	//add SQlite driver:
 	_ "github.com/mattn/go-sqlite3"
@@ -22,10 +23,10 @@ type sqliteConnection struct {
 	logger    *zap.SugaredLogger
 }
 
-func newSqliteConnection(db *sql.DB, encKey string, debug bool, blockQueries bool) (*sqliteConnection, error) {
+func newSqliteConnection(db *sql.DB, encKey string, previousEncKeys []string, debug bool, blockQueries bool) (*sqliteConnection, error) {
 	logger := log.NewLogger(debug)
 
-	encryptor, err := NewEncryptor(encKey)
+	encryptor, err := NewEncryptor(encKey, previousEncKeys...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +64,7 @@ func (sc *sqliteConnection) QueryRow(query string, args ...interface{}) (DataRow
 	if err := sc.validator.Validate(query); err != nil {
 		return nil, err
 	}
+	defer observeQuery(query, time.Now(), nil) //errors of single row queries are reported when the row is scanned
 	return sc.db.QueryRow(query, args...), nil
 }
 
@@ -71,7 +73,9 @@ func (sc *sqliteConnection) Query(query string, args ...interface{}) (DataRows,
 	if err := sc.validator.Validate(query); err != nil {
 		return nil, err
 	}
+	started := time.Now()
 	rows, err := sc.db.Query(query, args...)
+	observeQuery(query, started, err)
 	if err != nil {
 		sc.logger.Errorf("Sqlite3 Query() error: %s", err)
 	}
@@ -83,7 +87,14 @@ func (sc *sqliteConnection) Exec(query string, args ...interface{}) (sql.Result,
 	if err := sc.validator.Validate(query); err != nil {
 		return nil, err
 	}
-	result, err := sc.db.Exec(query, args...)
+	var result sql.Result
+	err := execWithRetry(func() error { //statements colliding with concurrent transactions are repeated
+		var execErr error
+		started := time.Now()
+		result, execErr = sc.db.Exec(query, args...)
+		observeQuery(query, started, execErr)
+		return execErr
+	})
 	if err != nil {
 		sc.logger.Errorf("Sqlite3 Exec() error: %s", err)
 	}
@@ -114,13 +125,14 @@ func (sc *sqliteConnection) DBStats() *sql.DBStats {
 }
 
 type sqliteConnectionFactory struct {
-	file          string
-	debug         bool
-	reset         bool
-	schemaFile    string
-	encryptionKey string
-	blockQueries  bool
-	logQueries    bool
+	file                   string
+	debug                  bool
+	reset                  bool
+	schemaFile             string
+	encryptionKey          string
+	previousEncryptionKeys []string
+	blockQueries           bool
+	logQueries             bool
 }
 
 func (scf *sqliteConnectionFactory) Init(_ bool) error {
@@ -164,7 +176,7 @@ func (scf *sqliteConnectionFactory) NewConnection() (Connection, error) {
 		return nil, err
 	}
 
-	return newSqliteConnection(db, scf.encryptionKey, scf.logQueries, scf.blockQueries) //connection ready to use
+	return newSqliteConnection(db, scf.encryptionKey, scf.previousEncryptionKeys, scf.logQueries, scf.blockQueries) //connection ready to use
 }
 
 func (scf *sqliteConnectionFactory) resetFile() error {
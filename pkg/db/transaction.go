@@ -3,7 +3,6 @@ package db
 import (
 	"database/sql"
 	"fmt"
-	"math/rand"
 	"strings"
 	"sync"
 	"time"
@@ -13,18 +12,22 @@ import (
 	"go.uber.org/zap"
 )
 
-const txMaxRetries = 5
-const txMaxJitter = 350
-const txMinJitter = 25
-
+// TransactionResult executes the DB operations within a transaction. Transactions which were aborted by the
+// database because they collided with concurrent transactions (serialization failures, deadlocks) are repeated
+// with an exponential backoff. Nested transactions are never repeated on their own: the outermost transaction
+// has to be repeated as a whole.
 func TransactionResult(conn Connection, dbOps func(tx *TxConnection) (interface{}, error),
 	logger *zap.SugaredLogger) (interface{}, error) {
+	if _, nested := conn.(*TxConnection); nested {
+		return execTransaction(conn, dbOps, logger)
+	}
+
 	var result interface{}
 	var err error
 	var allErr error
 
 	txCtxID := uuid.NewString()
-	for retries := 0; retries < txMaxRetries; retries++ {
+	for retries := 0; retries <= maxRetries; retries++ {
 		result, err = execTransaction(conn, dbOps, logger)
 		if err == nil {
 			if retries > 0 {
@@ -50,33 +53,28 @@ func TransactionResult(conn Connection, dbOps func(tx *TxConnection) (interface{
 			break
 		}
 
-		if isCollidingTxError(err) { // TX collided: retry
-			delay := randomJitter()
-			logger.Debugf("DB transaction (txCtxID:%s/connID:%s) collision occurred and transaction will be retried in %d msec",
-				txCtxID,
-				conn.ID(),
-				delay.Milliseconds())
-			time.Sleep(delay)
-			continue
+		reason, retryable := retryReason(err)
+		if !retryable {
+			break // anything else went wrong: give up
+		}
+		if retries == maxRetries {
+			logger.Warnf("DB transaction (txCtxID:%s/connID:%s) aborted after %d retries (reason: %s)",
+				txCtxID, conn.ID(), retries, reason)
+			observeTransactionAbort(reason)
+			break
 		}
 
-		break // anything else went wrong: give up
+		// TX collided: retry
+		delay := retryBackoff(retries + 1)
+		logger.Debugf("DB transaction (txCtxID:%s/connID:%s) collision occurred (reason: %s) and "+
+			"transaction will be retried in %d msec", txCtxID, conn.ID(), reason, delay.Milliseconds())
+		observeTransactionRetry(reason)
+		time.Sleep(delay)
 	}
 
 	return result, allErr
 }
 
-func randomJitter() time.Duration {
-	//nolint:staticcheck //no security relevance, linter complains can be ignored
-	rand.Seed(time.Now().UnixNano())
-	//nolint:gosec //no security relevance, linter complains can be ignored
-	jitter := rand.Int63n(txMaxJitter)
-	if jitter < txMinJitter {
-		jitter = jitter + txMinJitter
-	}
-	return time.Duration(jitter) * time.Millisecond
-}
-
 func execTransaction(conn Connection, dbOps func(tx *TxConnection) (interface{}, error),
 	logger *zap.SugaredLogger) (interface{}, error) {
 	log := func(msg string, args ...interface{}) {
@@ -149,16 +147,23 @@ func (t *TxConnection) Ping() error {
 }
 
 func (t *TxConnection) QueryRow(query string, args ...interface{}) (DataRow, error) {
+	defer observeQuery(query, time.Now(), nil)
 	return t.tx.QueryRow(query, args...), nil
 }
 
 func (t *TxConnection) Query(query string, args ...interface{}) (DataRows, error) {
-	return t.tx.Query(query, args...)
+	started := time.Now()
+	rows, err := t.tx.Query(query, args...)
+	observeQuery(query, started, err)
+	return rows, err
 }
 
 func (t *TxConnection) Exec(query string, args ...interface{}) (sql.Result, error) {
 	t.logger.Debugf("Transaction Exec(): %s | %v", query, args)
-	return t.tx.Exec(query, args...)
+	started := time.Now()
+	result, err := t.tx.Exec(query, args...)
+	observeQuery(query, started, err)
+	return result, err
 }
 
 func (t *TxConnection) Begin() (*TxConnection, error) {
@@ -214,10 +219,6 @@ func (t *TxConnection) DBStats() *sql.DBStats {
 	return t.conn.DBStats()
 }
 
-func isCollidingTxError(err error) bool {
-	return strings.Contains(err.Error(), "could not serialize access")
-}
-
 func isAlreadyCommitedOrRolledBackError(err error) bool {
 	return strings.Contains(err.Error(), "already been committed or rolled back")
 }
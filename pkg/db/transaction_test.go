@@ -1,16 +1,86 @@
 package db
 
 import (
-	"github.com/stretchr/testify/require"
+	"fmt"
 	"testing"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
 )
 
+type testTransactionObserver struct {
+	retries []string
+	aborts  []string
+}
+
+func (o *testTransactionObserver) ObserveTransactionRetry(reason string) {
+	o.retries = append(o.retries, reason)
+}
+
+func (o *testTransactionObserver) ObserveTransactionAbort(reason string) {
+	o.aborts = append(o.aborts, reason)
+}
+
 func TestTransaction(t *testing.T) {
 
-	t.Run("Test random jitter", func(t *testing.T) {
-		for i := 0; i < 100; i++ {
-			jitter := randomJitter().Milliseconds()
-			require.True(t, jitter >= txMinJitter && jitter <= txMaxJitter)
+	t.Run("Test retry backoff", func(t *testing.T) {
+		for retry := 1; retry <= maxRetries; retry++ {
+			for i := 0; i < 100; i++ {
+				backoff := retryBackoff(retry)
+				require.True(t, backoff >= minRetryBackoff/2 && backoff <= maxRetryBackoff,
+					"backoff %s of retry %d", backoff, retry)
+			}
 		}
+		require.True(t, retryBackoff(100) <= maxRetryBackoff)
+	})
+
+	t.Run("Test retry reason", func(t *testing.T) {
+		testCases := []struct {
+			err       error
+			reason    string
+			retryable bool
+		}{
+			{&pq.Error{Code: "40001"}, RetryReasonSerializationFailure, true},
+			{errors.Wrap(&pq.Error{Code: "40P01"}, "update failed"), RetryReasonDeadlock, true},
+			{fmt.Errorf("pq: could not serialize access due to concurrent update"), RetryReasonSerializationFailure, true},
+			{fmt.Errorf("pq: deadlock detected"), RetryReasonDeadlock, true},
+			{fmt.Errorf("database is locked"), RetryReasonLocked, true},
+			{&pq.Error{Code: "23505"}, "", false},
+			{fmt.Errorf("something else"), "", false},
+			{nil, "", false},
+		}
+		for _, testCase := range testCases {
+			reason, retryable := retryReason(testCase.err)
+			require.Equal(t, testCase.reason, reason, "%v", testCase.err)
+			require.Equal(t, testCase.retryable, retryable, "%v", testCase.err)
+		}
+	})
+
+	t.Run("Test retry of colliding statements", func(t *testing.T) {
+		observer := &testTransactionObserver{}
+		SetTransactionObserver(observer)
+		defer SetTransactionObserver(nil)
+
+		var calls int
+		err := execWithRetry(func() error {
+			calls++
+			if calls < 3 {
+				return &pq.Error{Code: "40P01"}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+		require.Equal(t, []string{RetryReasonDeadlock, RetryReasonDeadlock}, observer.retries)
+		require.Empty(t, observer.aborts)
+
+		calls = 0
+		err = execWithRetry(func() error {
+			calls++
+			return fmt.Errorf("duplicate key")
+		})
+		require.Error(t, err)
+		require.Equal(t, 1, calls)
 	})
 }
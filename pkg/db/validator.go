@@ -31,7 +31,7 @@ func (v *Validator) Validate(query string) error {
 	if err != nil {
 		return errors.Wrap(err, "Regex validation failed")
 	}
-	matchDelete, err := regexp.MatchString("DELETE FROM.*WHERE (\\(?\\w*\\s*[=<>]\\s*\\$\\d+\\)?(\\s*,\\s*)?(\\s+AND\\s+)?(\\s+OR\\s+)?)*(\\w*\\s+IN\\s+[^;]+)?$", query)
+	matchDelete, err := regexp.MatchString("DELETE FROM.*WHERE (\\(?\\w*\\s*(<>|<=|>=|[=<>])\\s*\\$\\d+\\)?(\\s*,\\s*)?(\\s+AND\\s+)?(\\s+OR\\s+)?)*(\\w*\\s+IN\\s+[^;]+)?$", query)
 	if err != nil {
 		return errors.Wrap(err, "Regex validation failed")
 	}
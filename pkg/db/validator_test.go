@@ -48,6 +48,11 @@ func TestValidator(t *testing.T) {
 		err := validator.Validate(query)
 		require.NoError(t, err)
 	})
+	t.Run("Validate valid delete query with comparisons", func(t *testing.T) {
+		query := "DELETE FROM mockTable WHERE col_1<>$1 AND col_2<=$2 AND col_3>=$3 AND col_4<$4 AND col_5 IN ($5,$6)"
+		err := validator.Validate(query)
+		require.NoError(t, err)
+	})
 	t.Run("Validate invalid delete query", func(t *testing.T) {
 		query := "DELETE FROM mockTable WHERE col_1=v1 AND col_2=v2"
 		err := validator.Validate(query)
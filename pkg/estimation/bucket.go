@@ -0,0 +1,43 @@
+package estimation
+
+// SizeBucket classifies clusters by the amount of their components: the duration of a component operation depends
+// on the load of the cluster, which grows with the amount of installed components.
+type SizeBucket string
+
+const (
+	SizeUnknown SizeBucket = "" //operations created before the cluster size was tracked
+	SizeSmall   SizeBucket = "small"
+	SizeMedium  SizeBucket = "medium"
+	SizeLarge   SizeBucket = "large"
+
+	maxSmallClusterComponents  = 15
+	maxMediumClusterComponents = 35
+)
+
+// NewSizeBucket returns the bucket of a cluster with the given amount of components
+func NewSizeBucket(components int64) SizeBucket {
+	switch {
+	case components <= 0:
+		return SizeUnknown
+	case components <= maxSmallClusterComponents:
+		return SizeSmall
+	case components <= maxMediumClusterComponents:
+		return SizeMedium
+	default:
+		return SizeLarge
+	}
+}
+
+// bounds returns the range of the component amount covered by the bucket (a max of zero means unbounded)
+func (b SizeBucket) bounds() (int64, int64) {
+	switch b {
+	case SizeSmall:
+		return 1, maxSmallClusterComponents
+	case SizeMedium:
+		return maxSmallClusterComponents + 1, maxMediumClusterComponents
+	case SizeLarge:
+		return maxMediumClusterComponents + 1, 0
+	default:
+		return 0, 0
+	}
+}
@@ -0,0 +1,207 @@
+package estimation
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+)
+
+const (
+	DefaultSamples  = 10
+	DefaultDuration = 5 * time.Minute
+
+	// minBucketSamples is the least amount of operations of a size bucket required for a bucket specific estimation:
+	// buckets with less operations fall back to the operations of clusters of all sizes
+	minBucketSamples = 3
+)
+
+// Estimate is the expected duration of a component operation
+type Estimate struct {
+	Duration   time.Duration
+	Historical bool //false if no historical operation was found and the default duration is used
+}
+
+// Distribution describes the durations of the latest successful operations of a component
+type Distribution struct {
+	Bucket  SizeBucket //SizeUnknown if the operations of clusters of all sizes were considered
+	Samples int
+	Median  time.Duration
+	P90     time.Duration
+	Max     time.Duration
+}
+
+// ETA is the expected remaining time of a queued or running operation
+type ETA struct {
+	Distribution
+	Remaining  time.Duration
+	Completion time.Time //zero for queued operations: their start depends on the queue of the worker pool
+	Overdue    bool      //operation runs longer than 90 percent of the historical operations
+}
+
+// Estimator estimates the duration of component operations by the durations of their latest successful
+// operations. Estimates are cached: an estimator is meant to be used for a single request.
+type Estimator struct {
+	repo          reconciliation.Repository
+	samples       int
+	fallback      time.Duration
+	cache         map[string]Estimate
+	distributions map[string]Distribution
+}
+
+// NewEstimator creates an estimator which considers the given amount of historical operations per component.
+// Components without historical operations are estimated with the fallback duration.
+func NewEstimator(repo reconciliation.Repository, samples int, fallback time.Duration) *Estimator {
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	if fallback <= 0 {
+		fallback = DefaultDuration
+	}
+	return &Estimator{
+		repo:          repo,
+		samples:       samples,
+		fallback:      fallback,
+		cache:         make(map[string]Estimate),
+		distributions: make(map[string]Distribution),
+	}
+}
+
+func (e *Estimator) Component(component string) (Estimate, error) {
+	if estimate, ok := e.cache[component]; ok {
+		return estimate, nil
+	}
+	durations, err := e.durations(component, SizeUnknown)
+	if err != nil {
+		return Estimate{}, err
+	}
+	estimate := Estimate{Duration: e.fallback}
+	if len(durations) > 0 {
+		estimate = Estimate{Duration: mean(durations), Historical: true}
+	}
+	e.cache[component] = estimate
+	return estimate, nil
+}
+
+// Distribution returns the duration distribution of the component operations on clusters of the given size. If
+// the bucket contains too few operations, the operations of clusters of all sizes are considered.
+func (e *Estimator) Distribution(component string, bucket SizeBucket) (Distribution, error) {
+	key := fmt.Sprintf("%s/%s", component, bucket)
+	if distribution, ok := e.distributions[key]; ok {
+		return distribution, nil
+	}
+	var durations []time.Duration
+	if bucket != SizeUnknown {
+		var err error
+		if durations, err = e.durations(component, bucket); err != nil {
+			return Distribution{}, err
+		}
+	}
+	if len(durations) < minBucketSamples {
+		bucket = SizeUnknown
+		var err error
+		if durations, err = e.durations(component, SizeUnknown); err != nil {
+			return Distribution{}, err
+		}
+	}
+	distribution := newDistribution(bucket, durations)
+	e.distributions[key] = distribution
+	return distribution, nil
+}
+
+// ETA estimates the remaining time of a queued or running operation. Nil is returned for finished operations and
+// for components without historical operations.
+func (e *Estimator) ETA(op *model.OperationEntity, now time.Time) (*ETA, error) {
+	if op.State != model.OperationStateNew && op.State != model.OperationStateInProgress {
+		return nil, nil
+	}
+	distribution, err := e.Distribution(op.Component, NewSizeBucket(op.ClusterSize))
+	if err != nil {
+		return nil, err
+	}
+	if distribution.Samples == 0 {
+		return nil, nil
+	}
+	eta := &ETA{Distribution: distribution, Remaining: distribution.Median}
+	if op.State == model.OperationStateInProgress && !op.PickedUp.IsZero() {
+		elapsed := now.Sub(op.PickedUp)
+		if eta.Remaining -= elapsed; eta.Remaining < 0 {
+			eta.Remaining = 0
+		}
+		eta.Completion = now.Add(eta.Remaining)
+		eta.Overdue = elapsed > distribution.P90
+	}
+	return eta, nil
+}
+
+// durations returns the durations of the latest successful operations of the component (on clusters of the given
+// size unless the bucket is SizeUnknown)
+func (e *Estimator) durations(component string, bucket SizeBucket) ([]time.Duration, error) {
+	filters := []operation.Filter{
+		&operation.WithComponentName{Component: component},
+		&operation.WithStates{States: []model.OperationState{model.OperationStateDone}},
+	}
+	if bucket != SizeUnknown {
+		lower, upper := bucket.bounds()
+		filters = append(filters, &operation.WithClusterSize{Min: lower, Max: upper})
+	}
+	ops, err := e.repo.GetOperations(&operation.FilterMixer{Filters: append(filters,
+		&operation.LimitByLastUpdate{Count: e.samples},
+	)})
+	if err != nil {
+		return nil, err
+	}
+	var result []time.Duration
+	for _, op := range ops {
+		if duration := processingTime(op); duration > 0 {
+			result = append(result, duration)
+		}
+	}
+	return result, nil
+}
+
+// processingTime returns the duration of an operation: the waiting time until it was picked up by a worker isn't
+// considered
+func processingTime(op *model.OperationEntity) time.Duration {
+	started := op.PickedUp
+	if started.IsZero() {
+		started = op.Created
+	}
+	return op.Updated.Sub(started)
+}
+
+func mean(durations []time.Duration) time.Duration {
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+	}
+	return (total / time.Duration(len(durations))).Truncate(time.Second)
+}
+
+func newDistribution(bucket SizeBucket, durations []time.Duration) Distribution {
+	distribution := Distribution{Bucket: bucket, Samples: len(durations)}
+	if len(durations) == 0 {
+		return distribution
+	}
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	distribution.Median = percentile(sorted, 0.5)
+	distribution.P90 = percentile(sorted, 0.9)
+	distribution.Max = sorted[len(sorted)-1]
+	return distribution
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank].Truncate(time.Second)
+}
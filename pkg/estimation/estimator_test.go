@@ -0,0 +1,122 @@
+package estimation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"github.com/stretchr/testify/require"
+)
+
+// filteringRepository applies the filters of the estimator to a static list of operations
+type filteringRepository struct {
+	reconciliation.MockRepository
+	ops []*model.OperationEntity
+}
+
+func (r *filteringRepository) GetOperations(filter operation.Filter) ([]*model.OperationEntity, error) {
+	var result []*model.OperationEntity
+	for _, op := range r.ops {
+		if filter.FilterByInstance(op) != nil {
+			result = append(result, op)
+		}
+	}
+	return result, nil
+}
+
+func doneOp(component string, clusterSize int64, duration time.Duration) *model.OperationEntity {
+	pickedUp := time.Now().Add(-time.Hour)
+	return &model.OperationEntity{
+		Component:   component,
+		State:       model.OperationStateDone,
+		ClusterSize: clusterSize,
+		PickedUp:    pickedUp,
+		Updated:     pickedUp.Add(duration),
+	}
+}
+
+func TestNewSizeBucket(t *testing.T) {
+	require.Equal(t, SizeUnknown, NewSizeBucket(0))
+	require.Equal(t, SizeSmall, NewSizeBucket(1))
+	require.Equal(t, SizeSmall, NewSizeBucket(maxSmallClusterComponents))
+	require.Equal(t, SizeMedium, NewSizeBucket(maxSmallClusterComponents+1))
+	require.Equal(t, SizeLarge, NewSizeBucket(maxMediumClusterComponents+1))
+}
+
+func TestEstimator(t *testing.T) {
+	repo := &filteringRepository{ops: []*model.OperationEntity{
+		doneOp("istio", 40, 10*time.Minute),
+		doneOp("istio", 40, 20*time.Minute),
+		doneOp("istio", 40, 12*time.Minute),
+		doneOp("istio", 5, 2*time.Minute),
+		doneOp("istio", 5, 4*time.Minute),
+		doneOp("logging", 40, time.Minute),
+	}}
+
+	t.Run("Mean duration of a component", func(t *testing.T) {
+		estimate, err := NewEstimator(repo, 0, 0).Component("istio")
+		require.NoError(t, err)
+		require.Equal(t, Estimate{Duration: 9*time.Minute + 36*time.Second, Historical: true}, estimate)
+
+		estimate, err = NewEstimator(repo, 0, time.Minute).Component("unknown")
+		require.NoError(t, err)
+		require.Equal(t, Estimate{Duration: time.Minute}, estimate)
+	})
+
+	t.Run("Distribution of a size bucket", func(t *testing.T) {
+		distribution, err := NewEstimator(repo, 0, 0).Distribution("istio", SizeLarge)
+		require.NoError(t, err)
+		require.Equal(t, Distribution{
+			Bucket:  SizeLarge,
+			Samples: 3,
+			Median:  12 * time.Minute,
+			P90:     20 * time.Minute,
+			Max:     20 * time.Minute,
+		}, distribution)
+	})
+
+	t.Run("Distribution falls back to clusters of all sizes", func(t *testing.T) {
+		distribution, err := NewEstimator(repo, 0, 0).Distribution("istio", SizeSmall)
+		require.NoError(t, err)
+		require.Equal(t, SizeUnknown, distribution.Bucket)
+		require.Equal(t, 5, distribution.Samples)
+		require.Equal(t, 10*time.Minute, distribution.Median)
+	})
+
+	t.Run("ETA of operations", func(t *testing.T) {
+		now := time.Now()
+		estimator := NewEstimator(repo, 0, 0)
+
+		eta, err := estimator.ETA(&model.OperationEntity{
+			Component: "istio", ClusterSize: 40, State: model.OperationStateNew,
+		}, now)
+		require.NoError(t, err)
+		require.Equal(t, 12*time.Minute, eta.Remaining)
+		require.True(t, eta.Completion.IsZero())
+
+		eta, err = estimator.ETA(&model.OperationEntity{
+			Component: "istio", ClusterSize: 40, State: model.OperationStateInProgress, PickedUp: now.Add(-5 * time.Minute),
+		}, now)
+		require.NoError(t, err)
+		require.Equal(t, 7*time.Minute, eta.Remaining)
+		require.Equal(t, now.Add(7*time.Minute), eta.Completion)
+		require.False(t, eta.Overdue)
+
+		eta, err = estimator.ETA(&model.OperationEntity{
+			Component: "istio", ClusterSize: 40, State: model.OperationStateInProgress, PickedUp: now.Add(-25 * time.Minute),
+		}, now)
+		require.NoError(t, err)
+		require.Zero(t, eta.Remaining)
+		require.True(t, eta.Overdue)
+
+		eta, err = estimator.ETA(&model.OperationEntity{Component: "istio", State: model.OperationStateDone}, now)
+		require.NoError(t, err)
+		require.Nil(t, eta)
+
+		eta, err = estimator.ETA(&model.OperationEntity{Component: "unknown", State: model.OperationStateNew}, now)
+		require.NoError(t, err)
+		require.Nil(t, eta)
+	})
+}
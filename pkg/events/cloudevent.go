@@ -0,0 +1,87 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+)
+
+const (
+	SpecVersion = "1.0"
+	// ContentType is used for events sent in the structured content mode of the CloudEvents HTTP binding
+	ContentType = "application/cloudevents+json"
+	// DefaultSource identifies the mothership as the producer of the events
+	DefaultSource = "/kyma-incubator/reconciler/mothership"
+
+	// typePrefixClusterStatus is completed by the new status of the cluster, e.g.
+	// 'io.kyma-project.reconciler.cluster.status.ready'
+	typePrefixClusterStatus = "io.kyma-project.reconciler.cluster.status."
+)
+
+// CloudEvent is an event in the JSON format of the CloudEvents specification 1.0
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// ClusterStatusData is the payload of events emitted for status changes of clusters
+type ClusterStatusData struct {
+	RuntimeID       string `json:"runtimeID"`
+	ClusterVersion  int64  `json:"clusterVersion"`
+	ConfigVersion   int64  `json:"configVersion"`
+	Status          string `json:"status"`
+	KymaVersion     string `json:"kymaVersion,omitempty"`
+	GlobalAccountID string `json:"globalAccountID,omitempty"`
+}
+
+// ClusterStatusType returns the event type emitted if a cluster gets the given status
+func ClusterStatusType(status string) string {
+	return typePrefixClusterStatus + status
+}
+
+// NewClusterStatusEvent creates the event for the current status of a cluster: the runtime ID is its subject
+func NewClusterStatusEvent(source string, state *cluster.State) (*CloudEvent, error) {
+	if state.Status == nil {
+		return nil, fmt.Errorf("cannot create status event without status of the cluster")
+	}
+	data := &ClusterStatusData{
+		RuntimeID:      state.Status.RuntimeID,
+		ClusterVersion: state.Status.ClusterVersion,
+		ConfigVersion:  state.Status.ConfigVersion,
+		Status:         string(state.Status.Status),
+	}
+	if state.Configuration != nil {
+		data.KymaVersion = state.Configuration.KymaVersion
+	}
+	if state.Cluster != nil {
+		data.GlobalAccountID = state.Cluster.GlobalAccountID
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTime := state.Status.Created
+	if eventTime.IsZero() {
+		eventTime = time.Now()
+	}
+	return &CloudEvent{
+		SpecVersion:     SpecVersion,
+		ID:              uuid.NewString(),
+		Source:          source,
+		Type:            ClusterStatusType(data.Status),
+		Subject:         data.RuntimeID,
+		Time:            eventTime.UTC(),
+		DataContentType: "application/json",
+		Data:            payload,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"go.uber.org/zap"
+)
+
+const defaultQueueSize = 1000
+
+// WebhookPublisher sends the status changes of clusters as CloudEvents to a webhook (e.g. a Knative broker).
+// Events are queued and sent asynchronously to avoid that a slow webhook delays the reconciliations: if the
+// queue is full, events are dropped.
+type WebhookPublisher struct {
+	URL    string
+	Source string
+	Client *http.Client //httpclient.Default is used if undefined
+	logger *zap.SugaredLogger
+	queue  chan *CloudEvent
+}
+
+func NewWebhookPublisher(url, source string, logger *zap.SugaredLogger) *WebhookPublisher {
+	if source == "" {
+		source = DefaultSource
+	}
+	return &WebhookPublisher{
+		URL:    url,
+		Source: source,
+		logger: logger,
+		queue:  make(chan *CloudEvent, defaultQueueSize),
+	}
+}
+
+// OnClusterStateUpdate queues the event for the new status of the cluster
+func (p *WebhookPublisher) OnClusterStateUpdate(state *cluster.State) error {
+	event, err := NewClusterStatusEvent(p.Source, state)
+	if err != nil {
+		return err
+	}
+	select {
+	case p.queue <- event:
+	default:
+		p.logger.Warnf("Event queue is full: dropping event '%s' of cluster '%s'", event.Type, event.Subject)
+	}
+	return nil
+}
+
+// Run sends the queued events until the context is closed
+func (p *WebhookPublisher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-p.queue:
+			if err := p.Publish(event); err != nil {
+				p.logger.Warnf("Failed to publish event '%s' of cluster '%s': %s", event.Type, event.Subject, err)
+			}
+		}
+	}
+}
+
+// Publish sends the event in the structured content mode of the CloudEvents HTTP binding
+func (p *WebhookPublisher) Publish(event *CloudEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	client := p.Client
+	if client == nil {
+		client = httpclient.Default()
+	}
+	resp, err := client.Post(p.URL, ContentType, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook '%s' responded with status code %d", p.URL, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookPublisher(t *testing.T) {
+	received := make(chan *CloudEvent, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, ContentType, r.Header.Get("Content-Type"))
+		event := &CloudEvent{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(event))
+		received <- event
+	}))
+	defer srv.Close()
+
+	publisher := NewWebhookPublisher(srv.URL, "", logger.NewLogger(true))
+	publisher.Client = srv.Client()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go publisher.Run(ctx)
+
+	created := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, publisher.OnClusterStateUpdate(&cluster.State{
+		Cluster:       &model.ClusterEntity{RuntimeID: "runtime1", GlobalAccountID: "account1"},
+		Configuration: &model.ClusterConfigurationEntity{RuntimeID: "runtime1", KymaVersion: "2.0.0"},
+		Status: &model.ClusterStatusEntity{
+			RuntimeID:      "runtime1",
+			ClusterVersion: 1,
+			ConfigVersion:  2,
+			Status:         model.ClusterStatusReady,
+			Created:        created,
+		},
+	}))
+
+	select {
+	case event := <-received:
+		require.Equal(t, SpecVersion, event.SpecVersion)
+		require.NotEmpty(t, event.ID)
+		require.Equal(t, DefaultSource, event.Source)
+		require.Equal(t, "io.kyma-project.reconciler.cluster.status.ready", event.Type)
+		require.Equal(t, "runtime1", event.Subject)
+		require.Equal(t, created, event.Time)
+		require.Equal(t, "application/json", event.DataContentType)
+
+		data := &ClusterStatusData{}
+		require.NoError(t, json.Unmarshal(event.Data, data))
+		require.Equal(t, &ClusterStatusData{
+			RuntimeID:       "runtime1",
+			ClusterVersion:  1,
+			ConfigVersion:   2,
+			Status:          "ready",
+			KymaVersion:     "2.0.0",
+			GlobalAccountID: "account1",
+		}, data)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event was not published")
+	}
+}
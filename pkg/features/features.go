@@ -12,6 +12,7 @@ const (
 	WorkerpoolOccupancyTracking
 	LogIstioOperator
 	DebugLogForSpecificOperations
+	FaultInjection
 )
 
 // define the mapping between feature name and env var name
@@ -20,6 +21,7 @@ var featureEnVarMap = map[Feature]string{
 	WorkerpoolOccupancyTracking:   "WORKERPOOL_OCCUPANCY_TRACKING_ENABLED",
 	LogIstioOperator:              "LOG_ISTIO_OPERATOR",
 	DebugLogForSpecificOperations: "DEBUG_LOGGING_FOR_SPECIFIC_OPERATIONS",
+	FaultInjection:                "FAULT_INJECTION_ENABLED",
 }
 
 func Enabled(feature Feature) bool {
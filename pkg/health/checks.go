@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+)
+
+const (
+	CheckDatabase   = "database"
+	CheckWorkspace  = "workspace"
+	CheckMothership = "mothership"
+	CheckWorkerPool = "workerpool"
+)
+
+// DatabaseCheck verifies that the database is reachable
+func DatabaseCheck(conn db.Connection) Check {
+	return func(_ context.Context) error {
+		if conn == nil {
+			return fmt.Errorf("database connection is not initialized")
+		}
+		return conn.Ping()
+	}
+}
+
+// WritableDirCheck verifies that files can be created in the given directory
+func WritableDirCheck(dir string) Check {
+	return func(_ context.Context) error {
+		file, err := os.CreateTemp(dir, ".healthcheck-*")
+		if err != nil {
+			return fmt.Errorf("directory '%s' is not writable: %s", dir, err)
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		return os.Remove(file.Name())
+	}
+}
+
+// HTTPCheck verifies that the given URL responds with a 2xx status code. If no client is given, the shared
+// HTTP client is used. The URL has to be part of the egress allowlist in any case.
+func HTTPCheck(url string, client *http.Client) Check {
+	return func(ctx context.Context) error {
+		if err := httpclient.CheckEgress(url); err != nil {
+			return err
+		}
+		httpClient := client
+		if httpClient == nil {
+			httpClient = httpclient.Default()
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("'%s' is not reachable: %s", url, err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode < http.StatusOK || resp.StatusCode > 299 {
+			return fmt.Errorf("'%s' responded with HTTP code %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,194 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+
+	StatusOK     = "ok"
+	StatusFailed = "failed"
+)
+
+// Check verifies a single dependency and returns an error if it's not healthy
+type Check func(ctx context.Context) error
+
+type Config struct {
+	Timeout        time.Duration
+	DisabledChecks []string
+}
+
+func (c *Config) validate() {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+}
+
+func (c *Config) isDisabled(name string) bool {
+	for _, disabled := range c.DisabledChecks {
+		if disabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Response is the payload returned by the liveness and readiness endpoints
+type Response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+type Checker struct {
+	config          *Config
+	logger          *zap.SugaredLogger
+	livenessChecks  []namedCheck
+	readinessChecks []namedCheck
+	mu              sync.Mutex
+}
+
+func NewChecker(config *Config, logger *zap.SugaredLogger) *Checker {
+	if config == nil {
+		config = &Config{}
+	}
+	config.validate()
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	return &Checker{
+		config: config,
+		logger: logger,
+	}
+}
+
+// WithLivenessCheck adds a check which has to pass to consider the process as alive.
+// Keep liveness checks cheap: a failing liveness probe leads to a restart of the pod.
+func (c *Checker) WithLivenessCheck(name string, check Check) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.config.isDisabled(name) {
+		c.logger.Infof("Health check '%s' is disabled and won't be used for liveness probes", name)
+		return c
+	}
+	c.livenessChecks = append(c.livenessChecks, namedCheck{name: name, check: check})
+	return c
+}
+
+// WithReadinessCheck adds a check which has to pass to consider the process as ready to receive traffic.
+func (c *Checker) WithReadinessCheck(name string, check Check) *Checker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.config.isDisabled(name) {
+		c.logger.Infof("Health check '%s' is disabled and won't be used for readiness probes", name)
+		return c
+	}
+	c.readinessChecks = append(c.readinessChecks, namedCheck{name: name, check: check})
+	return c
+}
+
+func (c *Checker) Live(ctx context.Context) *Response {
+	return c.run(ctx, c.checks(false))
+}
+
+func (c *Checker) Ready(ctx context.Context) *Response {
+	return c.run(ctx, c.checks(true))
+}
+
+func (c *Checker) LiveHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.respond(w, c.Live(r.Context()))
+	}
+}
+
+func (c *Checker) ReadyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c.respond(w, c.Ready(r.Context()))
+	}
+}
+
+func (c *Checker) checks(readiness bool) []namedCheck {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if readiness { //readiness includes liveness: a dead process is never ready
+		return append(append([]namedCheck{}, c.livenessChecks...), c.readinessChecks...)
+	}
+	return append([]namedCheck{}, c.livenessChecks...)
+}
+
+func (c *Checker) run(ctx context.Context, checks []namedCheck) *Response {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result, len(checks))
+	for _, nc := range checks {
+		go func(nc namedCheck) {
+			results <- result{name: nc.name, err: runCheck(ctx, nc.check)}
+		}(nc)
+	}
+
+	resp := &Response{Status: StatusOK, Checks: map[string]string{}}
+	for range checks {
+		res := <-results
+		if res.err == nil {
+			resp.Checks[res.name] = StatusOK
+			continue
+		}
+		c.logger.Warnf("Health check '%s' failed: %s", res.name, res.err)
+		resp.Status = StatusFailed
+		resp.Checks[res.name] = res.err.Error()
+	}
+	return resp
+}
+
+// runCheck executes the check but returns latest when the context is closed (checks could ignore the context)
+func runCheck(ctx context.Context, check Check) error {
+	errC := make(chan error, 1)
+	go func() {
+		errC <- check(ctx)
+	}()
+	select {
+	case err := <-errC:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Checker) respond(w http.ResponseWriter, resp *Response) {
+	httpCode := http.StatusOK
+	if resp.Status != StatusOK {
+		httpCode = http.StatusServiceUnavailable
+	}
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(httpCode)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		c.logger.Errorf("Failed to encode health check response: %s", err)
+	}
+}
+
+// Names returns the names of all registered checks (sorted)
+func (c *Checker) Names() []string {
+	var names []string
+	for _, nc := range c.checks(true) {
+		names = append(names, nc.name)
+	}
+	sort.Strings(names)
+	return names
+}
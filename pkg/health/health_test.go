@@ -0,0 +1,109 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	failing := func(_ context.Context) error { return errors.New("dependency down") }
+	passing := func(_ context.Context) error { return nil }
+
+	t.Run("Liveness ignores readiness checks", func(t *testing.T) {
+		checker := NewChecker(nil, nil).
+			WithLivenessCheck("alive", passing).
+			WithReadinessCheck("dependency", failing)
+
+		require.Equal(t, StatusOK, checker.Live(context.Background()).Status)
+
+		resp := checker.Ready(context.Background())
+		require.Equal(t, StatusFailed, resp.Status)
+		require.Equal(t, StatusOK, resp.Checks["alive"])
+		require.Equal(t, "dependency down", resp.Checks["dependency"])
+	})
+
+	t.Run("Disabled checks are ignored", func(t *testing.T) {
+		checker := NewChecker(&Config{DisabledChecks: []string{"dependency"}}, nil).
+			WithReadinessCheck("dependency", failing)
+		require.Equal(t, StatusOK, checker.Ready(context.Background()).Status)
+		require.Empty(t, checker.Names())
+	})
+
+	t.Run("Slow checks fail after timeout", func(t *testing.T) {
+		checker := NewChecker(&Config{Timeout: 50 * time.Millisecond}, nil).
+			WithReadinessCheck("slow", func(_ context.Context) error {
+				time.Sleep(time.Second)
+				return nil
+			})
+		resp := checker.Ready(context.Background())
+		require.Equal(t, StatusFailed, resp.Status)
+		require.Equal(t, context.DeadlineExceeded.Error(), resp.Checks["slow"])
+	})
+
+	t.Run("Handler responds HTTP 503 if not ready", func(t *testing.T) {
+		checker := NewChecker(nil, nil).WithReadinessCheck("dependency", failing)
+
+		recorder := httptest.NewRecorder()
+		checker.ReadyHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+		resp := &Response{}
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), resp))
+		require.Equal(t, StatusFailed, resp.Status)
+
+		recorder = httptest.NewRecorder()
+		checker.LiveHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
+
+func TestChecks(t *testing.T) {
+	t.Run("Writable directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, WritableDirCheck(dir)(context.Background()))
+		require.Error(t, WritableDirCheck(filepath.Join(dir, "not-existing"))(context.Background()))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("HTTP endpoint", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/healthz" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		require.NoError(t, HTTPCheck(srv.URL+"/healthz", nil)(context.Background()))
+		require.Error(t, HTTPCheck(srv.URL+"/readyz", nil)(context.Background()))
+	})
+
+	t.Run("HTTP endpoint outside of egress allowlist", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		cfg := httpclient.DefaultConfig()
+		cfg.EgressAllowlist = []string{"mothership.example.com"}
+		httpclient.SetDefaults(cfg)
+		defer httpclient.SetDefaults(httpclient.DefaultConfig())
+
+		require.True(t, httpclient.IsEgressDeniedError(HTTPCheck(srv.URL+"/healthz", nil)(context.Background())))
+		require.True(t, httpclient.IsEgressDeniedError(HTTPCheck(srv.URL+"/healthz", srv.Client())(context.Background())))
+	})
+}
@@ -0,0 +1,120 @@
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Config defines the timeouts, connection pool limits and retry behaviour of HTTP clients
+type Config struct {
+	Timeout               time.Duration //overall time a request is allowed to take (0 = unlimited)
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	IdleConnTimeout       time.Duration
+	MaxIdleConns          int
+	MaxIdleConnsPerHost   int
+	MaxConnsPerHost       int //0 = unlimited
+	MaxRetries            int
+	RetryDelay            time.Duration
+	EgressAllowlist       []string //hosts which are allowed to be contacted (empty = all hosts, see ParseEgressAllowlist)
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Timeout:               30 * time.Second,
+		DialTimeout:           10 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 20 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		MaxConnsPerHost:       0,
+		MaxRetries:            2,
+		RetryDelay:            500 * time.Millisecond,
+	}
+}
+
+// WithTimeout returns a copy of the config using the given overall request timeout (0 = unlimited)
+func (c *Config) WithTimeout(timeout time.Duration) *Config {
+	cfg := *c
+	cfg.Timeout = timeout
+	return &cfg
+}
+
+var (
+	defaultConfig = DefaultConfig()
+	defaultClient *http.Client
+	mu            sync.Mutex
+)
+
+// SetDefaults replaces the config used by Default and NewWithTimeout
+func SetDefaults(cfg *Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultConfig = cfg
+	defaultClient = nil
+}
+
+// Defaults returns a copy of the config used by Default and NewWithTimeout
+func Defaults() *Config {
+	mu.Lock()
+	defer mu.Unlock()
+	cfg := *defaultConfig
+	return &cfg
+}
+
+// Default returns the shared HTTP client which is configured with the default config
+func Default() *http.Client {
+	mu.Lock()
+	defer mu.Unlock()
+	if defaultClient == nil {
+		defaultClient = New(defaultConfig)
+	}
+	return defaultClient
+}
+
+// NewWithTimeout returns an HTTP client configured with the default config but a custom overall request timeout
+func NewWithTimeout(timeout time.Duration) *http.Client {
+	return New(Defaults().WithTimeout(timeout))
+}
+
+func New(cfg *Config) *http.Client {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   cfg.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	var roundTripper http.RoundTripper = transport
+	if cfg.MaxRetries > 0 {
+		roundTripper = &retryRoundTripper{
+			next:       transport,
+			maxRetries: cfg.MaxRetries,
+			delay:      cfg.RetryDelay,
+		}
+	}
+	if len(cfg.EgressAllowlist) > 0 {
+		allowlist, err := ParseEgressAllowlist(cfg.EgressAllowlist)
+		roundTripper = &egressRoundTripper{
+			next:      roundTripper,
+			allowlist: allowlist,
+			err:       err,
+		}
+	}
+	return &http.Client{
+		Transport: roundTripper,
+		Timeout:   cfg.Timeout,
+	}
+}
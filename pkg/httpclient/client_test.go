@@ -0,0 +1,144 @@
+package httpclient
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.RetryDelay = 10 * time.Millisecond
+	return cfg
+}
+
+func TestClient(t *testing.T) {
+	t.Run("Retry idempotent request on unavailable server", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		resp, err := New(newTestConfig()).Get(srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Give up after max retries", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer srv.Close()
+
+		resp, err := New(newTestConfig()).Get(srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+		require.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Don't retry non-idempotent request which reached the server", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		resp, err := New(newTestConfig()).Post(srv.URL, "application/json", bytes.NewBufferString("{}"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Don't retry request with idempotency key which reached the server", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewBufferString("{}"))
+		require.NoError(t, err)
+		req.Header.Set("Idempotency-Key", "123")
+		resp, err := New(newTestConfig()).Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Retry non-idempotent request if connection failed", func(t *testing.T) {
+		//reserve a port which isn't listening yet
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		var body []byte
+		srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		started := make(chan struct{})
+		go func() { //start server while client is retrying
+			defer close(started)
+			time.Sleep(15 * time.Millisecond)
+			srv.Listener, _ = net.Listen("tcp", addr)
+			srv.Start()
+		}()
+		defer func() {
+			<-started
+			srv.Close()
+		}()
+
+		cfg := newTestConfig()
+		cfg.MaxRetries = 5
+		resp, err := New(cfg).Post("http://"+addr, "application/json", bytes.NewBufferString(`{"a":1}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, `{"a":1}`, string(body))
+	})
+
+	t.Run("Overall timeout is applied", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer srv.Close()
+
+		_, err := New(newTestConfig().WithTimeout(50 * time.Millisecond)).Get(srv.URL)
+		require.Error(t, err)
+	})
+}
+
+func TestDefaults(t *testing.T) {
+	defer SetDefaults(DefaultConfig())
+
+	cfg := DefaultConfig()
+	cfg.Timeout = 1 * time.Minute
+	SetDefaults(cfg)
+
+	require.Equal(t, 1*time.Minute, Defaults().Timeout)
+	require.Equal(t, 1*time.Minute, Default().Timeout)
+	require.Same(t, Default(), Default())
+	require.Equal(t, 5*time.Second, NewWithTimeout(5*time.Second).Timeout)
+}
@@ -0,0 +1,171 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// EgressAllowlist restricts the hosts which outgoing requests are allowed to contact. It prevents that URLs
+// taken from the configuration of a cluster (e.g. chart URLs) make the control plane fetch from arbitrary endpoints.
+// An undefined or empty allowlist allows all hosts.
+type EgressAllowlist struct {
+	hosts    map[string]bool
+	domains  []string //suffixes of wildcard entries (e.g. '.example.com' for '*.example.com')
+	networks []*net.IPNet
+}
+
+// ParseEgressAllowlist parses the allowlist entries. Supported entries are host names ('charts.example.com'),
+// wildcard domains ('*.example.com' matches all subdomains), IPs ('10.0.0.1') and CIDRs ('10.0.0.0/8').
+// IP and CIDR entries only match URLs which address the host by a literal IP: host names are not resolved
+// (a DNS answer can change between the check and the connection), so they have to be allowed by name.
+func ParseEgressAllowlist(entries []string) (*EgressAllowlist, error) {
+	allowlist := &EgressAllowlist{hosts: make(map[string]bool)}
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case strings.Contains(entry, "/"):
+			_, network, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, fmt.Errorf("egress allowlist entry '%s' is not a valid CIDR: %s", entry, err)
+			}
+			allowlist.networks = append(allowlist.networks, network)
+		case net.ParseIP(entry) != nil:
+			ip := net.ParseIP(entry)
+			allowlist.networks = append(allowlist.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+		case strings.HasPrefix(entry, "*."):
+			allowlist.domains = append(allowlist.domains, entry[1:])
+		case strings.ContainsAny(entry, "*:@"):
+			return nil, fmt.Errorf("egress allowlist entry '%s' is invalid: expected a host name, "+
+				"a wildcard domain ('*.example.com'), an IP or a CIDR", entry)
+		default:
+			allowlist.hosts[entry] = true
+		}
+	}
+	return allowlist, nil
+}
+
+// Empty returns true if the allowlist doesn't restrict any host
+func (a *EgressAllowlist) Empty() bool {
+	return a == nil || (len(a.hosts) == 0 && len(a.domains) == 0 && len(a.networks) == 0)
+}
+
+// Allowed returns true if the host (without port) is allowed to be contacted
+func (a *EgressAllowlist) Allowed(host string) bool {
+	if a.Empty() {
+		return true
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if a.hosts[host] {
+		return true
+	}
+	for _, domain := range a.domains {
+		if strings.HasSuffix(host, domain) {
+			return true
+		}
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		for _, network := range a.networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Check returns an EgressDeniedError if the host of the URL isn't allowed to be contacted. Besides URLs, also
+// SCP-like git addresses ('git@github.com:org/repo.git') are supported.
+func (a *EgressAllowlist) Check(rawURL string) error {
+	if a.Empty() {
+		return nil
+	}
+	host, err := hostOf(rawURL)
+	if err != nil {
+		return err
+	}
+	if !a.Allowed(host) {
+		return &EgressDeniedError{Host: host}
+	}
+	return nil
+}
+
+// CheckEgress verifies the URL against the egress allowlist of the default config (see SetDefaults). It's used by
+// clients which don't send their requests with the HTTP clients of this package (e.g. git clients).
+func CheckEgress(rawURL string) error {
+	allowlist, err := ParseEgressAllowlist(Defaults().EgressAllowlist)
+	if err != nil {
+		return err
+	}
+	return allowlist.Check(rawURL)
+}
+
+// EgressDeniedError is returned for requests to hosts which are not part of the egress allowlist
+type EgressDeniedError struct {
+	Host string
+}
+
+func (e *EgressDeniedError) Error() string {
+	return fmt.Sprintf("egress to host '%s' is denied: host is not part of the egress allowlist", e.Host)
+}
+
+func IsEgressDeniedError(err error) bool {
+	var deniedErr *EgressDeniedError
+	return errors.As(err, &deniedErr)
+}
+
+func hostOf(rawURL string) (string, error) {
+	if !strings.Contains(rawURL, "://") {
+		//SCP-like git address: [user@]host:path
+		if idx := strings.Index(rawURL, ":"); idx > 0 {
+			host := rawURL[:idx]
+			if at := strings.LastIndex(host, "@"); at >= 0 {
+				host = host[at+1:]
+			}
+			if host != "" {
+				return host, nil
+			}
+		}
+		return "", fmt.Errorf("URL '%s' doesn't define a host", rawURL)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL '%s': %s", rawURL, err)
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("URL '%s' doesn't define a host", rawURL)
+	}
+	return u.Hostname(), nil
+}
+
+// egressRoundTripper rejects requests to hosts which are not part of the allowlist. It's checked for each request
+// and therefore also for redirects.
+type egressRoundTripper struct {
+	next      http.RoundTripper
+	allowlist *EgressAllowlist
+	err       error //invalid allowlists deny all requests
+}
+
+func (rt *egressRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.err != nil {
+		closeBody(req)
+		return nil, rt.err
+	}
+	if !rt.allowlist.Allowed(req.URL.Hostname()) {
+		closeBody(req)
+		return nil, &EgressDeniedError{Host: req.URL.Hostname()}
+	}
+	return rt.next.RoundTrip(req)
+}
+
+// closeBody fulfills the contract of http.RoundTripper which has to close the body also in case of errors
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
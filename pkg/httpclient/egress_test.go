@@ -0,0 +1,106 @@
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEgressAllowlist(t *testing.T) {
+	t.Run("Match hosts, domains, IPs and networks", func(t *testing.T) {
+		allowlist, err := ParseEgressAllowlist([]string{"charts.example.com", "*.kyma.io", "10.0.0.0/8", "192.168.1.1", "::1"})
+		require.NoError(t, err)
+
+		require.True(t, allowlist.Allowed("charts.example.com"))
+		require.True(t, allowlist.Allowed("CHARTS.example.com."))
+		require.True(t, allowlist.Allowed("eu.storage.kyma.io"))
+		require.True(t, allowlist.Allowed("10.1.2.3"))
+		require.True(t, allowlist.Allowed("192.168.1.1"))
+		require.True(t, allowlist.Allowed("::1"))
+
+		require.False(t, allowlist.Allowed("example.com"))
+		require.False(t, allowlist.Allowed("kyma.io")) //wildcards match only subdomains
+		require.False(t, allowlist.Allowed("evilkyma.io"))
+		require.False(t, allowlist.Allowed("192.168.1.2"))
+		require.False(t, allowlist.Allowed("169.254.169.254"))
+	})
+
+	t.Run("Networks match only literal IPs", func(t *testing.T) {
+		allowlist, err := ParseEgressAllowlist([]string{"127.0.0.0/8"})
+		require.NoError(t, err)
+		require.True(t, allowlist.Allowed("127.0.0.1"))
+		require.False(t, allowlist.Allowed("localhost")) //host names are not resolved
+	})
+
+	t.Run("Check URLs", func(t *testing.T) {
+		allowlist, err := ParseEgressAllowlist([]string{"github.com"})
+		require.NoError(t, err)
+
+		require.NoError(t, allowlist.Check("https://github.com/kyma-project/kyma.git"))
+		require.NoError(t, allowlist.Check("git@github.com:kyma-project/kyma.git"))
+		require.True(t, IsEgressDeniedError(allowlist.Check("https://evil.example.com/chart.tgz")))
+		require.Error(t, allowlist.Check("/local/path"))
+	})
+
+	t.Run("Empty allowlist allows all hosts", func(t *testing.T) {
+		allowlist, err := ParseEgressAllowlist(nil)
+		require.NoError(t, err)
+		require.True(t, allowlist.Empty())
+		require.True(t, allowlist.Allowed("example.com"))
+		require.NoError(t, allowlist.Check("https://example.com"))
+	})
+
+	t.Run("Reject invalid entries", func(t *testing.T) {
+		for _, entry := range []string{"10.0.0.0/33", "example.com:8080", "user@example.com", "charts.*.com"} {
+			_, err := ParseEgressAllowlist([]string{entry})
+			require.Error(t, err, entry)
+		}
+	})
+
+	t.Run("Enforce allowlist in HTTP client", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		cfg := newTestConfig()
+		cfg.EgressAllowlist = []string{"127.0.0.1"}
+		resp, err := New(cfg).Get(srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		cfg.EgressAllowlist = []string{"charts.example.com"}
+		_, err = New(cfg).Get(srv.URL) //nolint:bodyclose //request is rejected
+		require.Error(t, err)
+		require.True(t, IsEgressDeniedError(err))
+	})
+
+	t.Run("Enforce allowlist for redirects", func(t *testing.T) {
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer target.Close()
+		//redirect from 127.0.0.1 to localhost, which isn't part of the allowlist
+		redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, fmt.Sprintf("http://localhost:%d", target.Listener.Addr().(*net.TCPAddr).Port), http.StatusFound)
+		}))
+		defer redirector.Close()
+
+		cfg := newTestConfig()
+		cfg.EgressAllowlist = []string{"127.0.0.1"}
+		_, err := New(cfg).Get(redirector.URL) //nolint:bodyclose //request is rejected
+		require.True(t, IsEgressDeniedError(err))
+	})
+
+	t.Run("Invalid allowlist denies all requests", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.EgressAllowlist = []string{"10.0.0.0/33"}
+		_, err := New(cfg).Get("http://127.0.0.1:1") //nolint:bodyclose //request is rejected
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,81 @@
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// retryRoundTripper retries requests which failed for transient reasons. Requests with non-idempotent methods
+// are only retried if the connection couldn't be established, as the server never received them.
+type retryRoundTripper struct {
+	next       http.RoundTripper
+	maxRetries int
+	delay      time.Duration
+}
+
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := rt.next.RoundTrip(req)
+		if attempt >= rt.maxRetries || !rt.retryable(req, resp, err) {
+			return resp, err
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil { //body can't be sent again
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(rt.delay * time.Duration(1<<attempt)):
+		}
+	}
+}
+
+func (rt *retryRoundTripper) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		if isConnectionError(err) {
+			return true
+		}
+		return isIdempotent(req)
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isIdempotent returns true if the method of the request allows sending it twice. Requests with an idempotency
+// key aren't treated as idempotent: servers deduplicate them per replica, and a retry can reach another replica.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
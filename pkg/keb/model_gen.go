@@ -7,6 +7,94 @@ import (
 	"time"
 )
 
+// Defines values for BadgeStatus.
+const (
+	BadgeStatusGreen BadgeStatus = "green"
+
+	BadgeStatusRed BadgeStatus = "red"
+
+	BadgeStatusYellow BadgeStatus = "yellow"
+)
+
+// Defines values for ChangeType.
+const (
+	ChangeTypeAdded ChangeType = "added"
+
+	ChangeTypeModified ChangeType = "modified"
+
+	ChangeTypeRemoved ChangeType = "removed"
+)
+
+// Defines values for ComponentHealthStatus.
+const (
+	ComponentHealthStatusHealthy ComponentHealthStatus = "healthy"
+
+	ComponentHealthStatusUnhealthy ComponentHealthStatus = "unhealthy"
+
+	ComponentHealthStatusUnknown ComponentHealthStatus = "unknown"
+)
+
+// Defines values for HTTPReconciliationInfoPhase.
+const (
+	HTTPReconciliationInfoPhaseBootstrap HTTPReconciliationInfoPhase = "bootstrap"
+
+	HTTPReconciliationInfoPhaseBootstrapFailed HTTPReconciliationInfoPhase = "bootstrap_failed"
+
+	HTTPReconciliationInfoPhaseComponents HTTPReconciliationInfoPhase = "components"
+)
+
+// Defines values for Initiator.
+const (
+	InitiatorEmpty Initiator = ""
+
+	InitiatorKeb Initiator = "keb"
+
+	InitiatorScheduler Initiator = "scheduler"
+
+	InitiatorUser Initiator = "user"
+)
+
+// Defines values for OperationETASizeBucket.
+const (
+	OperationETASizeBucketEmpty OperationETASizeBucket = ""
+
+	OperationETASizeBucketLarge OperationETASizeBucket = "large"
+
+	OperationETASizeBucketMedium OperationETASizeBucket = "medium"
+
+	OperationETASizeBucketSmall OperationETASizeBucket = "small"
+)
+
+// Defines values for OperationPhasePhase.
+const (
+	OperationPhasePhaseApplied OperationPhasePhase = "applied"
+
+	OperationPhasePhaseCallbackSent OperationPhasePhase = "callbackSent"
+
+	OperationPhasePhasePickedUp OperationPhasePhase = "pickedUp"
+
+	OperationPhasePhaseQueued OperationPhasePhase = "queued"
+
+	OperationPhasePhaseReady OperationPhasePhase = "ready"
+
+	OperationPhasePhaseRendered OperationPhasePhase = "rendered"
+
+	OperationPhasePhaseWorkspaceReady OperationPhasePhase = "workspaceReady"
+)
+
+// Defines values for OperationResourceOutcome.
+const (
+	OperationResourceOutcomeCreated OperationResourceOutcome = "created"
+
+	OperationResourceOutcomeDeleted OperationResourceOutcome = "deleted"
+
+	OperationResourceOutcomeFailed OperationResourceOutcome = "failed"
+
+	OperationResourceOutcomeUnchanged OperationResourceOutcome = "unchanged"
+
+	OperationResourceOutcomeUpdated OperationResourceOutcome = "updated"
+)
+
 // Defines values for Status.
 const (
 	StatusDeleteError Status = "delete_error"
@@ -37,12 +125,18 @@ type HTTPClusterConfig KymaConfig
 
 // HTTPClusterResponse defines model for HTTPClusterResponse.
 type HTTPClusterResponse struct {
-	Cluster              string     `json:"cluster"`
-	ClusterVersion       int64      `json:"clusterVersion"`
-	ConfigurationVersion int64      `json:"configurationVersion"`
-	Failures             *[]Failure `json:"failures,omitempty"`
-	Status               Status     `json:"status"`
-	StatusURL            string     `json:"statusURL"`
+	Cluster        string `json:"cluster"`
+	ClusterVersion int64  `json:"clusterVersion"`
+
+	// Health of the components as determined by the latest status probe of the cluster (only components which define health checks are listed)
+	ComponentHealth      *[]ComponentHealth `json:"componentHealth,omitempty"`
+	ConfigurationVersion int64              `json:"configurationVersion"`
+
+	// Point in time (UTC) the soft-deleted cluster gets torn down
+	DeletionScheduled *time.Time `json:"deletionScheduled,omitempty"`
+	Failures          *[]Failure `json:"failures,omitempty"`
+	Status            Status     `json:"status"`
+	StatusURL         string     `json:"statusURL"`
 }
 
 // HTTPClusterStateResponse defines model for HTTPClusterStateResponse.
@@ -57,29 +151,201 @@ type HTTPClusterStatusResponse struct {
 	StatusChanges []StatusChange `json:"statusChanges"`
 }
 
+// HTTPConfigurationConflictResponse defines model for HTTPConfigurationConflictResponse.
+type HTTPConfigurationConflictResponse struct {
+	// Generation of the latest configuration of the cluster (also returned as ETag)
+	CurrentGeneration int64  `json:"currentGeneration"`
+	Error             string `json:"error"`
+}
+
 // HTTPErrorResponse defines model for HTTPErrorResponse.
 type HTTPErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// HTTPUpgradeRejectedResponse defines model for HTTPUpgradeRejectedResponse.
+type HTTPUpgradeRejectedResponse struct {
+	Error string `json:"error"`
+
+	// Versions which have to be installed before the requested version is accepted
+	RequiredVersions []string `json:"requiredVersions"`
+}
+
+// HTTPComponentClusters defines model for HTTPComponentClusters.
+type HTTPComponentClusters []ComponentCluster
+
+// HTTPComponentPins defines model for HTTPComponentPins.
+type HTTPComponentPins []ComponentPin
+
+// HTTPClusterChanges defines model for HTTPClusterChanges.
+type HTTPClusterChanges struct {
+	Changes   []ClusterChange `json:"changes"`
+	RuntimeID string          `json:"runtimeID"`
+}
+
+// HTTPClusterFeatureFlags defines model for HTTPClusterFeatureFlags.
+type HTTPClusterFeatureFlags struct {
+	// Names of the feature flags which are enabled for the cluster
+	FeatureFlags []string `json:"featureFlags"`
+	RuntimeID    string   `json:"runtimeID"`
+}
+
+// HTTPFreezes defines model for HTTPFreezes.
+type HTTPFreezes []Freeze
+
+// HTTPFeatureFlags defines model for HTTPFeatureFlags.
+type HTTPFeatureFlags []FeatureFlag
+
+// HTTPFleetReport defines model for HTTPFleetReport.
+type HTTPFleetReport struct {
+	Failed     int       `json:"failed"`
+	From       time.Time `json:"from"`
+	InProgress int       `json:"inProgress"`
+
+	// Mean duration of finished reconciliations in milliseconds
+	MeanTimeToReconcile int64 `json:"meanTimeToReconcile"`
+	Reconciliations     int   `json:"reconciliations"`
+	Succeeded           int   `json:"succeeded"`
+
+	// Percentage of finished reconciliations which succeeded
+	SuccessRate float64             `json:"successRate"`
+	To          time.Time           `json:"to"`
+	TopFailures []ComponentFailures `json:"topFailures"`
+}
+
+// HTTPFreshnessReport defines model for HTTPFreshnessReport.
+type HTTPFreshnessReport struct {
+	// Amount of clusters the SLO applies to
+	Clusters int `json:"clusters"`
+
+	// Percentage of compliant clusters
+	Compliance float64 `json:"compliance"`
+
+	// Amount of clusters which were reconciled successfully within the objective
+	Compliant int       `json:"compliant"`
+	Evaluated time.Time `json:"evaluated"`
+
+	// Amount of clusters whose reconciliation is disabled
+	Excluded  int                `json:"excluded"`
+	Freshness []ClusterFreshness `json:"freshness"`
+
+	// Maximal age of the latest successful reconciliation of a cluster in milliseconds
+	Objective int64 `json:"objective"`
+}
+
+// HTTPSimulationPlan defines model for HTTPSimulationPlan.
+type HTTPSimulationPlan struct {
+	Batches  []SimulatedBatch   `json:"batches"`
+	Clusters []SimulatedCluster `json:"clusters"`
+
+	// Estimated duration of the whole rollout in milliseconds
+	EstimatedDuration int64  `json:"estimatedDuration"`
+	KymaVersion       string `json:"kymaVersion"`
+	Operations        int    `json:"operations"`
+}
+
+// HTTPOperationFailureCapture defines model for HTTPOperationFailureCapture.
+type HTTPOperationFailureCapture struct {
+	CorrelationID string    `json:"correlationID"`
+	Created       time.Time `json:"created"`
+
+	// Error which made the operation fail
+	Error        string `json:"error"`
+	SchedulingID string `json:"schedulingID"`
+
+	// Payload of the failed operation (kubeconfig and sensitive values are redacted)
+	Task map[string]interface{} `json:"task"`
+
+	// Values resolved for the component chart (sensitive values are redacted)
+	Values *map[string]interface{} `json:"values,omitempty"`
+
+	// Reason why the values of the component chart couldn't be resolved
+	ValuesError *string `json:"valuesError,omitempty"`
+}
+
+// HTTPOperationPayload defines model for HTTPOperationPayload.
+type HTTPOperationPayload struct {
+	// Contract version of the run endpoint (defines the format of the payload)
+	ContractVersion int       `json:"contractVersion"`
+	CorrelationID   string    `json:"correlationID"`
+	Created         time.Time `json:"created"`
+
+	// JSON payload as it was sent to the component reconciler
+	Payload      string `json:"payload"`
+	SchedulingID string `json:"schedulingID"`
+
+	// Run endpoint of the component reconciler which received the payload
+	Url string `json:"url"`
+}
+
+// HTTPOperationResources defines model for HTTPOperationResources.
+type HTTPOperationResources struct {
+	Resources []OperationResource      `json:"resources"`
+	Summary   OperationResourceSummary `json:"summary"`
+}
+
+// HTTPOperationSmokeTests defines model for HTTPOperationSmokeTests.
+type HTTPOperationSmokeTests []SmokeTestResult
+
+// HTTPOperationTimeline defines model for HTTPOperationTimeline.
+type HTTPOperationTimeline struct {
+	Component     string           `json:"component"`
+	CorrelationID string           `json:"correlationID"`
+	Phases        []OperationPhase `json:"phases"`
+	SchedulingID  string           `json:"schedulingID"`
+	State         string           `json:"state"`
+}
+
 // HTTPReconcilerStatus defines model for HTTPReconcilerStatus.
 type HTTPReconcilerStatus []Reconciliation
 
 // HTTPReconciliationInfo defines model for HTTPReconciliationInfo.
 type HTTPReconciliationInfo struct {
-	ConfigVersion int64       `json:"configVersion"`
-	Created       time.Time   `json:"created"`
-	Finished      bool        `json:"finished"`
-	Operations    []Operation `json:"operations"`
-	RuntimeID     string      `json:"runtimeID"`
-	SchedulingID  string      `json:"schedulingID"`
-	Status        Status      `json:"status"`
-	Updated       time.Time   `json:"updated"`
+	ConfigVersion int64     `json:"configVersion"`
+	Created       time.Time `json:"created"`
+	Finished      bool      `json:"finished"`
+
+	// Initiator of a reconciliation (empty for reconciliations created before initiators were recorded)
+	Initiator  Initiator   `json:"initiator"`
+	Operations []Operation `json:"operations"`
+
+	// Bootstrap: cluster essentials are reconciled before all other components, bootstrap_failed: a cluster essential failed and blocks all other components, components: all other components are reconciled
+	Phase HTTPReconciliationInfoPhase `json:"phase"`
+
+	// Reason of the reconciliation, e.g. provisioning, versionRollout, configChange, periodic, retry, manualRetry, scheduled or deletion
+	Reason       string    `json:"reason"`
+	RuntimeID    string    `json:"runtimeID"`
+	SchedulingID string    `json:"schedulingID"`
+	Status       Status    `json:"status"`
+	Updated      time.Time `json:"updated"`
+}
+
+// Bootstrap: cluster essentials are reconciled before all other components, bootstrap_failed: a cluster essential failed and blocks all other components, components: all other components are reconciled
+type HTTPReconciliationInfoPhase string
+
+// HTTPReconcileIntervals defines model for HTTPReconcileIntervals.
+type HTTPReconcileIntervals []ReconcileInterval
+
+// HTTPScheduledReconciliations defines model for HTTPScheduledReconciliations.
+type HTTPScheduledReconciliations []ScheduledReconciliation
+
+// HTTPStatusBadge defines model for HTTPStatusBadge.
+type HTTPStatusBadge struct {
+	// Point in time of the last successful reconciliation (missing if the cluster was never reconciled successfully)
+	LastSuccessfulReconcile *time.Time  `json:"lastSuccessfulReconcile,omitempty"`
+	RuntimeID               string      `json:"runtimeID"`
+	Status                  BadgeStatus `json:"status"`
 }
 
+// HTTPTenantQuotas defines model for HTTPTenantQuotas.
+type HTTPTenantQuotas []TenantQuota
+
+// BadgeStatus defines model for badgeStatus.
+type BadgeStatus string
+
 // Cluster defines model for cluster.
 type Cluster struct {
-	// valid kubeconfig to cluster
+	// valid kubeconfig to cluster: credentials have to be embedded, only the current context is stored
 	Kubeconfig   string       `json:"kubeconfig"`
 	KymaConfig   KymaConfig   `json:"kymaConfig"`
 	Metadata     Metadata     `json:"metadata"`
@@ -87,6 +353,19 @@ type Cluster struct {
 	RuntimeInput RuntimeInput `json:"runtimeInput"`
 }
 
+// ClusterChange defines model for clusterChange.
+type ClusterChange struct {
+	// Subject of the caller which changed the configuration (empty if unknown)
+	Actor         string            `json:"actor"`
+	ConfigVersion int64             `json:"configVersion"`
+	Created       time.Time         `json:"created"`
+	Diff          ConfigurationDiff `json:"diff"`
+	Generation    int64             `json:"generation"`
+}
+
+// ChangeType defines model for changeType.
+type ChangeType string
+
 // ClusterState defines model for clusterState.
 type ClusterState struct {
 	Contract  *int64        `json:"contract,omitempty"`
@@ -131,6 +410,83 @@ type Component struct {
 	Version       string          `json:"version"`
 }
 
+// ComponentChange defines model for componentChange.
+type ComponentChange struct {
+	URL           *ValueChange          `json:"URL,omitempty"`
+	Change        ChangeType            `json:"change"`
+	Component     string                `json:"component"`
+	Configuration []ConfigurationChange `json:"configuration,omitempty"`
+	Namespace     *ValueChange          `json:"namespace,omitempty"`
+	Version       *ValueChange          `json:"version,omitempty"`
+}
+
+// ComponentCluster defines model for componentCluster.
+type ComponentCluster struct {
+	ConfigVersion int64  `json:"configVersion"`
+	KymaVersion   string `json:"kymaVersion"`
+	RuntimeID     string `json:"runtimeID"`
+
+	// Status of the latest reconciliation of the cluster
+	Status string `json:"status"`
+
+	// Version of the component which is installed on the cluster
+	Version string `json:"version"`
+}
+
+// ClusterFreshness defines model for clusterFreshness.
+type ClusterFreshness struct {
+	Cluster   string `json:"cluster"`
+	Compliant bool   `json:"compliant"`
+
+	// Point in time (UTC) of the latest successful reconciliation within the objective
+	LastSuccess *time.Time `json:"lastSuccess,omitempty"`
+
+	// Explains why the cluster violates the SLO
+	Reason *string `json:"reason,omitempty"`
+	Status Status  `json:"status"`
+}
+
+// ComponentFailures defines model for componentFailures.
+type ComponentFailures struct {
+	// Amount of clusters affected by the failures
+	Clusters  int    `json:"clusters"`
+	Component string `json:"component"`
+
+	// Amount of failed operations of the component
+	Failures     int    `json:"failures"`
+	LatestReason string `json:"latestReason"`
+}
+
+// ComponentHealth defines model for componentHealth.
+type ComponentHealth struct {
+	Component string `json:"component"`
+
+	// Describes the failed health check (unhealthy) or why the component couldn't be probed (unknown)
+	Message *string `json:"message,omitempty"`
+
+	// Point in time (UTC) the component was probed
+	Probed time.Time             `json:"probed"`
+	Status ComponentHealthStatus `json:"status"`
+}
+
+// ComponentHealthStatus defines model for ComponentHealth.Status.
+type ComponentHealthStatus string
+
+// ComponentPin defines model for componentPin.
+type ComponentPin struct {
+	Component string    `json:"component"`
+	Created   time.Time `json:"created"`
+
+	// Exact version or semver range the component is pinned to
+	Version string `json:"version"`
+}
+
+// ComponentPinUpdate defines model for componentPinUpdate.
+type ComponentPinUpdate struct {
+	// Exact version or semver range the component is pinned to
+	Version string `json:"version"`
+}
+
 // Configuration defines model for configuration.
 type Configuration struct {
 	Key    string      `json:"key"`
@@ -138,12 +494,104 @@ type Configuration struct {
 	Value  interface{} `json:"value"`
 }
 
+// ConfigurationChange defines model for configurationChange.
+type ConfigurationChange struct {
+	Change ChangeType  `json:"change"`
+	Key    string      `json:"key"`
+	New    interface{} `json:"new,omitempty"`
+	Old    interface{} `json:"old,omitempty"`
+
+	// Values of secret configurations are redacted
+	Secret bool `json:"secret"`
+}
+
+// ConfigurationDiff defines model for configurationDiff.
+type ConfigurationDiff struct {
+	Administrators *ValueChange      `json:"administrators,omitempty"`
+	Components     []ComponentChange `json:"components,omitempty"`
+	KymaProfile    *ValueChange      `json:"kymaProfile,omitempty"`
+	KymaVersion    *ValueChange      `json:"kymaVersion,omitempty"`
+}
+
+// DeletionConfirmation defines model for deletionConfirmation.
+type DeletionConfirmation struct {
+	// Point in time (UTC) the token expires
+	Expires   time.Time `json:"expires"`
+	RuntimeID string    `json:"runtimeID"`
+
+	// Token which has to be passed to the second deletion request of the cluster
+	Token string `json:"token"`
+}
+
+// DeletionProtection defines model for deletionProtection.
+type DeletionProtection struct {
+	Created   time.Time `json:"created"`
+	RuntimeID string    `json:"runtimeID"`
+}
+
+// FeatureFlag defines model for featureFlag.
+type FeatureFlag struct {
+	Created     time.Time `json:"created"`
+	Description string    `json:"description"`
+
+	// Clusters for which the feature flag is always disabled (overrules enabledClusters and the rollout percentage)
+	DisabledClusters []string `json:"disabledClusters"`
+
+	// Clusters for which the feature flag is always enabled
+	EnabledClusters []string `json:"enabledClusters"`
+	Name            string   `json:"name"`
+
+	// Percentage of the clusters for which the feature flag is enabled
+	RolloutPercentage int64 `json:"rolloutPercentage"`
+}
+
+// FeatureFlagUpdate defines model for featureFlagUpdate.
+type FeatureFlagUpdate struct {
+	Description *string `json:"description,omitempty"`
+
+	// Clusters for which the feature flag is always disabled (overrules enabledClusters and the rollout percentage)
+	DisabledClusters *[]string `json:"disabledClusters,omitempty"`
+
+	// Clusters for which the feature flag is always enabled
+	EnabledClusters *[]string `json:"enabledClusters,omitempty"`
+
+	// Percentage of the clusters for which the feature flag is enabled
+	RolloutPercentage int64 `json:"rolloutPercentage"`
+}
+
+// Freeze defines model for freeze.
+type Freeze struct {
+	// Frozen component (empty if all components are frozen)
+	Component *string   `json:"component,omitempty"`
+	Created   time.Time `json:"created"`
+
+	// User who engaged the freeze
+	EngagedBy  string     `json:"engagedBy"`
+	Id         int64      `json:"id"`
+	Reason     string     `json:"reason"`
+	Released   bool       `json:"released"`
+	ReleasedAt *time.Time `json:"releasedAt,omitempty"`
+
+	// User who released the freeze
+	ReleasedBy *string `json:"releasedBy,omitempty"`
+}
+
+// FreezeUpdate defines model for freezeUpdate.
+type FreezeUpdate struct {
+	// Component to freeze (empty to freeze all components)
+	Component *string `json:"component,omitempty"`
+	Reason    string  `json:"reason"`
+}
+
 // Failure defines model for failure.
 type Failure struct {
 	Component string `json:"component"`
 	Reason    string `json:"reason"`
 }
 
+// Initiator of a reconciliation (empty for reconciliations created before initiators were recorded)
+type Initiator string
+
 // KymaConfig defines model for kymaConfig.
 type KymaConfig struct {
 	Administrators []string    `json:"administrators"`
@@ -166,15 +614,80 @@ type Metadata struct {
 
 // Operation defines model for operation.
 type Operation struct {
+	// Component belongs to the cluster essentials which are reconciled before all other components
+	Bootstrap     bool      `json:"bootstrap"`
 	Component     string    `json:"component"`
 	CorrelationID string    `json:"correlationID"`
 	Created       time.Time `json:"created"`
-	Priority      int64     `json:"priority"`
-	Reason        string    `json:"reason"`
-	SchedulingID  string    `json:"schedulingID"`
-	State         string    `json:"state"`
-	Type          string    `json:"type"`
-	Updated       time.Time `json:"updated"`
+
+	// Estimation of a queued or running operation based on the durations of the latest successful operations of the component
+	Eta          *OperationETA `json:"eta,omitempty"`
+	Priority     int64         `json:"priority"`
+	Reason       string        `json:"reason"`
+	SchedulingID string        `json:"schedulingID"`
+	State        string        `json:"state"`
+	Type         string        `json:"type"`
+	Updated      time.Time     `json:"updated"`
+}
+
+// Estimation of a queued or running operation based on the durations of the latest successful operations of the component
+type OperationETA struct {
+	// Expected completion of a running operation (undefined for queued operations)
+	Completion *time.Time `json:"completion,omitempty"`
+
+	// Median duration of the historical operations in milliseconds
+	MedianDuration int64 `json:"medianDuration"`
+
+	// Operation runs longer than 90 percent of the historical operations
+	Overdue bool `json:"overdue"`
+
+	// 90th percentile of the durations of the historical operations in milliseconds
+	P90Duration int64 `json:"p90Duration"`
+
+	// Expected remaining duration in milliseconds
+	Remaining int64 `json:"remaining"`
+
+	// Amount of historical operations the estimation is based on
+	Samples int `json:"samples"`
+
+	// Size bucket (by amount of components) of the clusters the estimation is based on: empty if clusters of all sizes were considered
+	SizeBucket OperationETASizeBucket `json:"sizeBucket"`
+}
+
+// Size bucket (by amount of components) of the clusters the estimation is based on: empty if clusters of all sizes were considered
+type OperationETASizeBucket string
+
+// OperationPhase defines model for operationPhase.
+type OperationPhase struct {
+	// Milliseconds between the previous phase and this phase
+	Duration int64               `json:"duration"`
+	Phase    OperationPhasePhase `json:"phase"`
+	Reached  time.Time           `json:"reached"`
+}
+
+// OperationPhasePhase defines model for OperationPhase.Phase.
+type OperationPhasePhase string
+
+// OperationResource defines model for operationResource.
+type OperationResource struct {
+	ApiVersion string                   `json:"apiVersion"`
+	Error      *string                  `json:"error,omitempty"`
+	Kind       string                   `json:"kind"`
+	Name       string                   `json:"name"`
+	Namespace  string                   `json:"namespace"`
+	Outcome    OperationResourceOutcome `json:"outcome"`
+}
+
+// OperationResourceOutcome defines model for OperationResource.Outcome.
+type OperationResourceOutcome string
+
+// OperationResourceSummary defines model for operationResourceSummary.
+type OperationResourceSummary struct {
+	Created   int `json:"created"`
+	Deleted   int `json:"deleted"`
+	Failed    int `json:"failed"`
+	Unchanged int `json:"unchanged"`
+	Updated   int `json:"updated"`
 }
 
 // OperationStop defines model for operationStop.
@@ -192,9 +705,15 @@ type ReconcilerStatus struct {
 
 // Reconciliation defines model for reconciliation.
 type Reconciliation struct {
-	Created      time.Time `json:"created"`
-	Finished     bool      `json:"finished"`
-	Lock         string    `json:"lock"`
+	Created  time.Time `json:"created"`
+	Finished bool      `json:"finished"`
+
+	// Initiator of a reconciliation (empty for reconciliations created before initiators were recorded)
+	Initiator Initiator `json:"initiator"`
+	Lock      string    `json:"lock"`
+
+	// Reason of the reconciliation, e.g. provisioning, versionRollout, configChange, periodic, retry, manualRetry, scheduled or deletion
+	Reason       string    `json:"reason"`
 	RuntimeID    string    `json:"runtimeID"`
 	SchedulingID string    `json:"schedulingID"`
 	Status       Status    `json:"status"`
@@ -210,6 +729,119 @@ type RuntimeInput struct {
 // Status defines model for status.
 type Status string
 
+// ReconcileInterval defines model for reconcileInterval.
+type ReconcileInterval struct {
+	// Component the interval applies to (empty for all components)
+	Component *string   `json:"component,omitempty"`
+	Created   time.Time `json:"created"`
+
+	// Seconds between two periodic reconciliations
+	IntervalSeconds int64 `json:"intervalSeconds"`
+
+	// Cluster the interval applies to (empty for all clusters)
+	RuntimeID *string `json:"runtimeID,omitempty"`
+}
+
+// ReconcileIntervalUpdate defines model for reconcileIntervalUpdate.
+type ReconcileIntervalUpdate struct {
+	// Component the interval applies to (empty for all components)
+	Component *string `json:"component,omitempty"`
+
+	// Seconds between two periodic reconciliations
+	IntervalSeconds int64 `json:"intervalSeconds"`
+
+	// Cluster the interval applies to (empty for all clusters)
+	RuntimeID *string `json:"runtimeID,omitempty"`
+}
+
+// ScheduledReconciliation defines model for scheduledReconciliation.
+type ScheduledReconciliation struct {
+	Created time.Time `json:"created"`
+	Id      string    `json:"id"`
+
+	// Point in time (UTC) the reconciliation is triggered at the earliest
+	NotBefore time.Time `json:"notBefore"`
+	RuntimeID string    `json:"runtimeID"`
+}
+
+// ScheduledReconciliationCreate defines model for scheduledReconciliationCreate.
+type ScheduledReconciliationCreate struct {
+	// Point in time the reconciliation is triggered at the earliest
+	NotBefore time.Time `json:"notBefore"`
+	RuntimeID string    `json:"runtimeID"`
+}
+
+// SmokeTestResult defines model for smokeTestResult.
+type SmokeTestResult struct {
+	// Milliseconds the smoke test took
+	Duration int64   `json:"duration"`
+	Error    *string `json:"error,omitempty"`
+	Name     string  `json:"name"`
+	Passed   bool    `json:"passed"`
+}
+
+// SimulatedBatch defines model for simulatedBatch.
+type SimulatedBatch struct {
+	// Estimated duration of the batch in milliseconds
+	EstimatedDuration int64    `json:"estimatedDuration"`
+	Operations        int      `json:"operations"`
+	RuntimeIDs        []string `json:"runtimeIDs"`
+
+	// Offset from the begin of the rollout in milliseconds
+	Start int64 `json:"start"`
+}
+
+// SimulatedCluster defines model for simulatedCluster.
+type SimulatedCluster struct {
+	// Kyma version of the current cluster configuration
+	CurrentVersion string `json:"currentVersion"`
+
+	// Reason why the change would be rejected for this cluster
+	Error *string `json:"error,omitempty"`
+
+	// Estimated duration of the reconciliation in milliseconds
+	EstimatedDuration int64                     `json:"estimatedDuration"`
+	Groups            []SimulatedOperationGroup `json:"groups"`
+	Operations        int                       `json:"operations"`
+	RuntimeID         string                    `json:"runtimeID"`
+}
+
+// SimulatedOperation defines model for simulatedOperation.
+type SimulatedOperation struct {
+	Component      string  `json:"component"`
+	CurrentVersion *string `json:"currentVersion,omitempty"`
+
+	// Estimated duration of the operation in milliseconds
+	EstimatedDuration int64 `json:"estimatedDuration"`
+
+	// Estimation is based on historical operations (otherwise a default duration is assumed)
+	Historical bool `json:"historical"`
+
+	// Version the component would be reconciled with
+	Version string `json:"version"`
+}
+
+// SimulatedOperationGroup defines model for simulatedOperationGroup.
+type SimulatedOperationGroup struct {
+	Bootstrap bool `json:"bootstrap"`
+
+	// Estimated duration of the group in milliseconds
+	EstimatedDuration int64                `json:"estimatedDuration"`
+	Operations        []SimulatedOperation `json:"operations"`
+
+	// Groups are processed in ascending order of their priority
+	Priority int `json:"priority"`
+}
+
+// SimulationRequest defines model for simulationRequest.
+type SimulationRequest struct {
+	// Proposed Kyma version
+	KymaVersion string `json:"kymaVersion"`
+
+	// Clusters affected by the change (all clusters if empty)
+	RuntimeIDs *[]string `json:"runtimeIDs,omitempty"`
+}
+
 // StatusChange defines model for statusChange.
 type StatusChange struct {
 	Duration int64     `json:"duration"`
@@ -222,9 +854,58 @@ type StatusUpdate struct {
 	Status Status `json:"status"`
 }
 
+// StatusBadgeToken defines model for statusBadgeToken.
+type StatusBadgeToken struct {
+	RuntimeID string `json:"runtimeID"`
+
+	// Token which grants access to the status badge of the cluster (it's returned only once)
+	Token string `json:"token"`
+}
+
+// TenantQuota defines model for tenantQuota.
+type TenantQuota struct {
+	Created time.Time `json:"created"`
+
+	// Tenant (global account) the quota applies to
+	GlobalAccountID string `json:"globalAccountID"`
+
+	// Operations of the tenant assigned to workers per minute (0 means unlimited)
+	MaxOperationsPerMinute int64 `json:"maxOperationsPerMinute"`
+
+	// Operations of the tenant processed in parallel (0 means unlimited)
+	MaxParallelOperations int64 `json:"maxParallelOperations"`
+}
+
+// TenantQuotaUpdate defines model for tenantQuotaUpdate.
+type TenantQuotaUpdate struct {
+	// Tenant (global account) the quota applies to
+	GlobalAccountID string `json:"globalAccountID"`
+
+	// Operations of the tenant assigned to workers per minute (0 means unlimited)
+	MaxOperationsPerMinute int64 `json:"maxOperationsPerMinute"`
+
+	// Operations of the tenant processed in parallel (0 means unlimited)
+	MaxParallelOperations int64 `json:"maxParallelOperations"`
+}
+
+// ValueChange defines model for valueChange.
+type ValueChange struct {
+	New interface{} `json:"new"`
+	Old interface{} `json:"old"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest HTTPErrorResponse
 
+// UpgradeRejected defines model for UpgradeRejected.
+type UpgradeRejected HTTPUpgradeRejectedResponse
+
+// DeletionProtected defines model for DeletionProtected.
+type DeletionProtected HTTPErrorResponse
+
+// DeletionNotConfirmed defines model for DeletionNotConfirmed.
+type DeletionNotConfirmed HTTPErrorResponse
+
 // InternalError defines model for InternalError.
 type InternalError HTTPErrorResponse
 
@@ -234,12 +915,93 @@ type NotFoundResponse HTTPErrorResponse
 // Ok defines model for Ok.
 type Ok HTTPClusterResponse
 
+// DeletionConfirmationAcceptedResponse defines model for DeletionConfirmationAcceptedResponse.
+type DeletionConfirmationAcceptedResponse DeletionConfirmation
+
+// DeletionProtectionOKResponse defines model for DeletionProtectionOKResponse.
+type DeletionProtectionOKResponse DeletionProtection
+
+// ComponentPinOKResponse defines model for ComponentPinOKResponse.
+type ComponentPinOKResponse ComponentPin
+
+// ComponentClustersOKResponse defines model for ComponentClustersOKResponse.
+type ComponentClustersOKResponse HTTPComponentClusters
+
+// ClusterChangesOKResponse defines model for ClusterChangesOKResponse.
+type ClusterChangesOKResponse HTTPClusterChanges
+
+// ClusterFeatureFlagsOKResponse defines model for ClusterFeatureFlagsOKResponse.
+type ClusterFeatureFlagsOKResponse HTTPClusterFeatureFlags
+
+// FeatureFlagOKResponse defines model for FeatureFlagOKResponse.
+type FeatureFlagOKResponse FeatureFlag
+
+// FreezeOKResponse defines model for FreezeOKResponse.
+type FreezeOKResponse Freeze
+
+// FreezesOKResponse defines model for FreezesOKResponse.
+type FreezesOKResponse HTTPFreezes
+
+// FeatureFlagsOKResponse defines model for FeatureFlagsOKResponse.
+type FeatureFlagsOKResponse HTTPFeatureFlags
+
+// FleetReportOKResponse defines model for FleetReportOKResponse.
+type FleetReportOKResponse HTTPFleetReport
+
+// FreshnessReportOKResponse defines model for FreshnessReportOKResponse.
+type FreshnessReportOKResponse HTTPFreshnessReport
+
+// SimulationOKResponse defines model for SimulationOKResponse.
+type SimulationOKResponse HTTPSimulationPlan
+
+// ComponentPinsOKResponse defines model for ComponentPinsOKResponse.
+type ComponentPinsOKResponse HTTPComponentPins
+
+// OperationFailureCaptureOKResponse defines model for OperationFailureCaptureOKResponse.
+type OperationFailureCaptureOKResponse HTTPOperationFailureCapture
+
+// OperationPayloadOKResponse defines model for OperationPayloadOKResponse.
+type OperationPayloadOKResponse HTTPOperationPayload
+
+// OperationResourcesOKResponse defines model for OperationResourcesOKResponse.
+type OperationResourcesOKResponse HTTPOperationResources
+
+// OperationSmokeTestsOKResponse defines model for OperationSmokeTestsOKResponse.
+type OperationSmokeTestsOKResponse HTTPOperationSmokeTests
+
+// OperationTimelineOKResponse defines model for OperationTimelineOKResponse.
+type OperationTimelineOKResponse HTTPOperationTimeline
+
 // ReconcilationsOKResponse defines model for ReconcilationsOKResponse.
 type ReconcilationsOKResponse HTTPReconcilerStatus
 
 // ReconciliationInfoOKResponse defines model for ReconciliationInfoOKResponse.
 type ReconciliationInfoOKResponse HTTPReconciliationInfo
 
+// ReconcileIntervalOKResponse defines model for ReconcileIntervalOKResponse.
+type ReconcileIntervalOKResponse ReconcileInterval
+
+// ReconcileIntervalsOKResponse defines model for ReconcileIntervalsOKResponse.
+type ReconcileIntervalsOKResponse HTTPReconcileIntervals
+
+// ScheduledReconciliationOKResponse defines model for ScheduledReconciliationOKResponse.
+type ScheduledReconciliationOKResponse ScheduledReconciliation
+
+// ScheduledReconciliationsOKResponse defines model for ScheduledReconciliationsOKResponse.
+type ScheduledReconciliationsOKResponse HTTPScheduledReconciliations
+
+// StatusBadgeOKResponse defines model for StatusBadgeOKResponse.
+type StatusBadgeOKResponse HTTPStatusBadge
+
+// StatusBadgeTokenOKResponse defines model for StatusBadgeTokenOKResponse.
+type StatusBadgeTokenOKResponse StatusBadgeToken
+
+// TenantQuotaOKResponse defines model for TenantQuotaOKResponse.
+type TenantQuotaOKResponse TenantQuota
+
+// TenantQuotasOKResponse defines model for TenantQuotasOKResponse.
+type TenantQuotasOKResponse HTTPTenantQuotas
+
 // ConfigurationOkResponse defines model for configurationOkResponse.
 type ConfigurationOkResponse HTTPClusterConfig
 
@@ -256,12 +1018,78 @@ type GetClustersStateParams struct {
 	CorrelationID *string `json:"correlationID,omitempty"`
 }
 
+// GetClustersRuntimeIDBadgeParams defines parameters for GetClustersRuntimeIDBadge.
+type GetClustersRuntimeIDBadgeParams struct {
+	// Token of the status badge
+	Token string `json:"token"`
+}
+
+// GetClustersRuntimeIDChangesParams defines parameters for GetClustersRuntimeIDChanges.
+type GetClustersRuntimeIDChangesParams struct {
+	// Maximal amount of returned changes
+	Limit *int `json:"limit,omitempty"`
+}
+
+// DeleteClustersRuntimeIDParams defines parameters for DeleteClustersRuntimeID.
+type DeleteClustersRuntimeIDParams struct {
+	Token *string `json:"token,omitempty"`
+}
+
 // PutClustersRuntimeIDStatusJSONBody defines parameters for PutClustersRuntimeIDStatus.
 type PutClustersRuntimeIDStatusJSONBody StatusUpdate
 
+// PutClustersRuntimeIDPinsComponentJSONBody defines parameters for PutClustersRuntimeIDPinsComponent.
+type PutClustersRuntimeIDPinsComponentJSONBody ComponentPinUpdate
+
 // PostOperationsSchedulingIDCorrelationIDStopJSONBody defines parameters for PostOperationsSchedulingIDCorrelationIDStop.
 type PostOperationsSchedulingIDCorrelationIDStopJSONBody OperationStop
 
+// PutIntervalsJSONBody defines parameters for PutIntervals.
+type PutIntervalsJSONBody ReconcileIntervalUpdate
+
+// DeleteIntervalsParams defines parameters for DeleteIntervals.
+type DeleteIntervalsParams struct {
+	RuntimeID *string `json:"runtimeID,omitempty"`
+	Component *string `json:"component,omitempty"`
+}
+
+// GetFreezesParams defines parameters for GetFreezes.
+type GetFreezesParams struct {
+	// Include released freezes (audit trail)
+	Released *bool `json:"released,omitempty"`
+}
+
+// PutFreezesJSONBody defines parameters for PutFreezes.
+type PutFreezesJSONBody FreezeUpdate
+
+// DeleteFreezesParams defines parameters for DeleteFreezes.
+type DeleteFreezesParams struct {
+	// Component of the freeze (empty for the fleet-wide freeze)
+	Component *string `json:"component,omitempty"`
+}
+
+// PutFeatureflagsNameJSONBody defines parameters for PutFeatureflagsName.
+type PutFeatureflagsNameJSONBody FeatureFlagUpdate
+
+// PutQuotasJSONBody defines parameters for PutQuotas.
+type PutQuotasJSONBody TenantQuotaUpdate
+
+// DeleteQuotasParams defines parameters for DeleteQuotas.
+type DeleteQuotasParams struct {
+	GlobalAccountID string `json:"globalAccountID"`
+}
+
+// PostSimulationsJSONBody defines parameters for PostSimulations.
+type PostSimulationsJSONBody SimulationRequest
+
+// PostSchedulesJSONBody defines parameters for PostSchedules.
+type PostSchedulesJSONBody ScheduledReconciliationCreate
+
+// GetSchedulesParams defines parameters for GetSchedules.
+type GetSchedulesParams struct {
+	RuntimeID *string `json:"runtimeID,omitempty"`
+}
+
 // GetReconciliationsParams defines parameters for GetReconciliations.
 type GetReconciliationsParams struct {
 	RuntimeID *[]string  `json:"runtimeID,omitempty"`
@@ -280,5 +1108,26 @@ type PutClustersJSONRequestBody PutClustersJSONBody
 // PutClustersRuntimeIDStatusJSONRequestBody defines body for PutClustersRuntimeIDStatus for application/json ContentType.
 type PutClustersRuntimeIDStatusJSONRequestBody PutClustersRuntimeIDStatusJSONBody
 
+// PutClustersRuntimeIDPinsComponentJSONRequestBody defines body for PutClustersRuntimeIDPinsComponent for application/json ContentType.
+type PutClustersRuntimeIDPinsComponentJSONRequestBody PutClustersRuntimeIDPinsComponentJSONBody
+
+// PutIntervalsJSONRequestBody defines body for PutIntervals for application/json ContentType.
+type PutIntervalsJSONRequestBody PutIntervalsJSONBody
+
+// PutFreezesJSONRequestBody defines body for PutFreezes for application/json ContentType.
+type PutFreezesJSONRequestBody PutFreezesJSONBody
+
+// PutFeatureflagsNameJSONRequestBody defines body for PutFeatureflagsName for application/json ContentType.
+type PutFeatureflagsNameJSONRequestBody PutFeatureflagsNameJSONBody
+
+// PutQuotasJSONRequestBody defines body for PutQuotas for application/json ContentType.
+type PutQuotasJSONRequestBody PutQuotasJSONBody
+
+// PostSimulationsJSONRequestBody defines body for PostSimulations for application/json ContentType.
+type PostSimulationsJSONRequestBody PostSimulationsJSONBody
+
+// PostSchedulesJSONRequestBody defines body for PostSchedules for application/json ContentType.
+type PostSchedulesJSONRequestBody PostSchedulesJSONBody
+
 // PostOperationsSchedulingIDCorrelationIDStopJSONRequestBody defines body for PostOperationsSchedulingIDCorrelationIDStop for application/json ContentType.
 type PostOperationsSchedulingIDCorrelationIDStopJSONRequestBody PostOperationsSchedulingIDCorrelationIDStopJSONBody
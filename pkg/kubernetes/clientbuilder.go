@@ -13,7 +13,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 const EnvVarKubeconfig = "KUBECONFIG"
@@ -59,7 +58,7 @@ func (cb *ClientBuilder) Build(ctx context.Context, validate bool) (kubernetes.I
 		}
 		cb.kubeconfig, cb.err = cb.loadFile(kubeconfigPath)
 	}
-	config, err := clientcmd.RESTConfigFromKubeConfig(cb.kubeconfig)
+	config, err := NewRESTConfig(cb.kubeconfig)
 	if err != nil {
 		return nil, errors.Wrap(err,
 			fmt.Sprintf("failed to create Kubernetes client configuration using provided kubeconfig: %s", cb.kubeconfig))
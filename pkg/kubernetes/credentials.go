@@ -0,0 +1,389 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// EnvVarExecAllowlist contains the comma separated names of the exec plugins which kubeconfigs are allowed to use
+const EnvVarExecAllowlist = "KUBECONFIG_EXEC_ALLOWLIST"
+
+const (
+	authProviderOIDC     = "oidc"
+	tokenRefreshMargin   = 1 * time.Minute
+	execTimeout          = 30 * time.Second
+	oidcRequestTimeout   = 30 * time.Second
+	execCredentialInfo   = `{"apiVersion":"%s","kind":"ExecCredential","spec":{"interactive":false}}`
+	oidcDiscoveryPath    = "/.well-known/openid-configuration"
+	oidcGrantTypeRefresh = "refresh_token"
+)
+
+// DefaultCredentialResolver is shared by all clients of the process: clients of the same cluster reuse cached tokens
+var DefaultCredentialResolver = NewCredentialResolver(allowedExecCommands())
+
+// NewRESTConfig creates the REST configuration of a kubeconfig. Tokens of users which authenticate with an exec
+// plugin or an OIDC auth-provider are resolved by the DefaultCredentialResolver and refreshed before they expire,
+// which keeps long-running operations authenticated.
+func NewRESTConfig(kubeconfig []byte) (*rest.Config, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	authInfo := currentAuthInfo(config)
+	if authInfo == nil || (authInfo.Exec == nil && authInfo.AuthProvider == nil) {
+		return clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+	}
+
+	source, err := DefaultCredentialResolver.tokenSource(authInfo)
+	if err != nil {
+		return nil, err
+	}
+	staticAuthInfo := authInfo.DeepCopy()
+	staticAuthInfo.Exec = nil
+	staticAuthInfo.AuthProvider = nil
+	config.AuthInfos[config.Contexts[config.CurrentContext].AuthInfo] = staticAuthInfo
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &bearerTokenRoundTripper{source: source, delegate: rt}
+	})
+	return restConfig, nil
+}
+
+func currentAuthInfo(config *clientcmdapi.Config) *clientcmdapi.AuthInfo {
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil
+	}
+	return config.AuthInfos[kubeContext.AuthInfo]
+}
+
+func allowedExecCommands() []string {
+	var commands []string
+	for _, command := range strings.Split(os.Getenv(EnvVarExecAllowlist), ",") {
+		if command = strings.TrimSpace(command); command != "" {
+			commands = append(commands, command)
+		}
+	}
+	return commands
+}
+
+// CredentialResolver resolves the tokens of exec plugins and OIDC auth-providers and caches them until they expire
+type CredentialResolver struct {
+	allowedExecCommands map[string]bool
+	httpClient          *http.Client
+	mu                  sync.Mutex
+	sources             map[string]*cachingTokenSource
+}
+
+func NewCredentialResolver(allowedExecCommands []string) *CredentialResolver {
+	resolver := &CredentialResolver{
+		allowedExecCommands: make(map[string]bool, len(allowedExecCommands)),
+		httpClient:          &http.Client{Timeout: oidcRequestTimeout},
+		sources:             make(map[string]*cachingTokenSource),
+	}
+	for _, command := range allowedExecCommands {
+		resolver.allowedExecCommands[command] = true
+	}
+	return resolver
+}
+
+// IsExecAllowed returns true if the exec plugin command is part of the allowlist
+func (r *CredentialResolver) IsExecAllowed(command string) bool {
+	return r.allowedExecCommands[filepath.Base(command)]
+}
+
+// Token returns a valid token of the user
+func (r *CredentialResolver) Token(authInfo *clientcmdapi.AuthInfo) (string, error) {
+	source, err := r.tokenSource(authInfo)
+	if err != nil {
+		return "", err
+	}
+	return source.Token()
+}
+
+func (r *CredentialResolver) tokenSource(authInfo *clientcmdapi.AuthInfo) (*cachingTokenSource, error) {
+	key, err := authInfoKey(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if source, ok := r.sources[key]; ok {
+		return source, nil
+	}
+
+	var fetch func() (*token, error)
+	switch {
+	case authInfo.Exec != nil:
+		if !r.IsExecAllowed(authInfo.Exec.Command) {
+			return nil, fmt.Errorf("exec plugin '%s' is not allowed: add it to the env-var %s",
+				authInfo.Exec.Command, EnvVarExecAllowlist)
+		}
+		execConfig := authInfo.Exec.DeepCopy()
+		fetch = func() (*token, error) {
+			return execToken(execConfig)
+		}
+	case authInfo.AuthProvider != nil && authInfo.AuthProvider.Name == authProviderOIDC:
+		oidc := &oidcTokenSource{
+			httpClient: r.httpClient,
+			config:     make(map[string]string, len(authInfo.AuthProvider.Config)),
+		}
+		for k, v := range authInfo.AuthProvider.Config {
+			oidc.config[k] = v
+		}
+		fetch = oidc.token
+	case authInfo.AuthProvider != nil:
+		return nil, fmt.Errorf("auth-provider '%s' is not supported", authInfo.AuthProvider.Name)
+	default:
+		return nil, errors.New("user neither uses an exec plugin nor an auth-provider")
+	}
+
+	source := &cachingTokenSource{fetch: fetch}
+	r.sources[key] = source
+	return source, nil
+}
+
+func authInfoKey(authInfo *clientcmdapi.AuthInfo) (string, error) {
+	data, err := json.Marshal(struct {
+		Exec         *clientcmdapi.ExecConfig
+		AuthProvider *clientcmdapi.AuthProviderConfig
+	}{authInfo.Exec, authInfo.AuthProvider})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+type token struct {
+	value  string
+	expiry time.Time //zero if the token doesn't expire
+}
+
+func (t *token) valid(now time.Time) bool {
+	return t != nil && (t.expiry.IsZero() || now.Add(tokenRefreshMargin).Before(t.expiry))
+}
+
+// cachingTokenSource returns the cached token until it's close to its expiry or was rejected by the API server
+type cachingTokenSource struct {
+	fetch func() (*token, error)
+	mu    sync.Mutex
+	token *token
+}
+
+func (s *cachingTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.token.valid(time.Now()) {
+		token, err := s.fetch()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to resolve token of kubeconfig user")
+		}
+		s.token = token
+	}
+	return s.token.value, nil
+}
+
+func (s *cachingTokenSource) invalidate(value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && s.token.value == value { //ignore tokens which were already replaced
+		s.token = nil
+	}
+}
+
+type bearerTokenRoundTripper struct {
+	source   *cachingTokenSource
+	delegate http.RoundTripper
+}
+
+func (rt *bearerTokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") != "" {
+		return rt.delegate.RoundTrip(req)
+	}
+	value, err := rt.source.Token()
+	if err != nil {
+		return nil, err
+	}
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", "Bearer "+value)
+	resp, err := rt.delegate.RoundTrip(authReq)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		rt.source.invalidate(value) //token was revoked: resolve a new one for the next request
+	}
+	return resp, err
+}
+
+func (rt *bearerTokenRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.delegate
+}
+
+func execToken(config *clientcmdapi.ExecConfig) (*token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), execTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, config.Command, config.Args...) //nolint:gosec //command is part of the allowlist
+	cmd.Env = os.Environ()
+	for _, env := range config.Env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", env.Name, env.Value))
+	}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("KUBERNETES_EXEC_INFO=%s", fmt.Sprintf(execCredentialInfo, config.APIVersion)))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("exec plugin '%s' failed: %s", config.Command, stderr.String()))
+	}
+
+	var credential struct {
+		Status struct {
+			Token               string     `json:"token"`
+			ExpirationTimestamp *time.Time `json:"expirationTimestamp"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("exec plugin '%s' returned an invalid ExecCredential", config.Command))
+	}
+	if credential.Status.Token == "" {
+		return nil, fmt.Errorf("exec plugin '%s' returned no token (client certificates are not supported)",
+			config.Command)
+	}
+	result := &token{value: credential.Status.Token}
+	if credential.Status.ExpirationTimestamp != nil {
+		result.expiry = *credential.Status.ExpirationTimestamp
+	}
+	return result, nil
+}
+
+// oidcTokenSource returns the ID token of an OIDC auth-provider and refreshes it using the refresh token
+type oidcTokenSource struct {
+	httpClient *http.Client
+	config     map[string]string
+}
+
+func (s *oidcTokenSource) token() (*token, error) {
+	if idToken := s.config["id-token"]; idToken != "" {
+		if expiry, err := jwtExpiry(idToken); err == nil && (&token{value: idToken, expiry: expiry}).valid(time.Now()) {
+			return &token{value: idToken, expiry: expiry}, nil
+		}
+	}
+	if s.config["refresh-token"] == "" {
+		return nil, errors.New("OIDC ID token expired and no refresh token is configured")
+	}
+
+	tokenEndpoint, err := s.tokenEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {oidcGrantTypeRefresh},
+		"refresh_token": {s.config["refresh-token"]},
+		"client_id":     {s.config["client-id"]},
+	}
+	if secret := s.config["client-secret"]; secret != "" {
+		form.Set("client_secret", secret)
+	}
+	resp, err := s.httpClient.PostForm(tokenEndpoint, form)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to refresh OIDC token")
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to refresh OIDC token: token endpoint responded with status %d", resp.StatusCode)
+	}
+	var tokens struct {
+		IDToken      string `json:"id_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, errors.Wrap(err, "failed to decode OIDC token response")
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("OIDC token response contains no ID token")
+	}
+	expiry, err := jwtExpiry(tokens.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	//keep the rotated tokens for the next refresh
+	s.config["id-token"] = tokens.IDToken
+	if tokens.RefreshToken != "" {
+		s.config["refresh-token"] = tokens.RefreshToken
+	}
+	return &token{value: tokens.IDToken, expiry: expiry}, nil
+}
+
+func (s *oidcTokenSource) tokenEndpoint() (string, error) {
+	issuer := strings.TrimSuffix(s.config["idp-issuer-url"], "/")
+	if issuer == "" {
+		return "", errors.New("OIDC auth-provider has no 'idp-issuer-url' configured")
+	}
+	resp, err := s.httpClient.Get(issuer + oidcDiscoveryPath)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to discover OIDC provider '%s'", issuer))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to discover OIDC provider '%s': responded with status %d", issuer, resp.StatusCode)
+	}
+	var discovery struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to decode discovery document of OIDC provider '%s'", issuer))
+	}
+	if discovery.TokenEndpoint == "" {
+		return "", fmt.Errorf("OIDC provider '%s' exposes no token endpoint", issuer)
+	}
+	return discovery.TokenEndpoint, nil
+}
+
+// jwtExpiry returns the expiry of a JWT without verifying it: verification is up to the API server
+func jwtExpiry(jwt string) (time.Time, error) {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.New("OIDC ID token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to decode payload of OIDC ID token")
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to parse claims of OIDC ID token")
+	}
+	if claims.Exp == 0 {
+		return time.Time{}, errors.New("OIDC ID token has no expiry")
+	}
+	return time.Unix(claims.Exp, 0), nil
+}
@@ -0,0 +1,165 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestCredentialResolver(t *testing.T) {
+	newJWT := func(expiry time.Time) string {
+		claims, err := json.Marshal(map[string]int64{"exp": expiry.Unix()})
+		require.NoError(t, err)
+		return fmt.Sprintf("header.%s.signature", base64.RawURLEncoding.EncodeToString(claims))
+	}
+
+	t.Run("Exec plugin tokens are cached until they expire", func(t *testing.T) {
+		newExecInfo := func(counter string, expiry time.Time) *clientcmdapi.AuthInfo {
+			return &clientcmdapi.AuthInfo{
+				Exec: &clientcmdapi.ExecConfig{
+					Command:    "sh",
+					APIVersion: "client.authentication.k8s.io/v1",
+					Args: []string{"-c", fmt.Sprintf(`echo x >> %s; `+
+						`echo '{"status":{"token":"exec-token","expirationTimestamp":"%s"}}'`,
+						counter, expiry.UTC().Format(time.RFC3339))},
+				},
+			}
+		}
+		invocations := func(counter string) int {
+			data, err := os.ReadFile(counter)
+			require.NoError(t, err)
+			return strings.Count(string(data), "x")
+		}
+
+		//exec plugins have to be allowed
+		_, err := NewCredentialResolver(nil).Token(newExecInfo(filepath.Join(t.TempDir(), "counter"), time.Now()))
+		require.Error(t, err)
+
+		resolver := NewCredentialResolver([]string{"sh"})
+		for _, testCase := range []struct {
+			expiry              time.Time
+			expectedInvocations int
+		}{
+			{expiry: time.Now().Add(time.Hour), expectedInvocations: 1},
+			{expiry: time.Now().Add(30 * time.Second), expectedInvocations: 2}, //expires within the refresh margin
+		} {
+			counter := filepath.Join(t.TempDir(), "counter")
+			execInfo := newExecInfo(counter, testCase.expiry)
+			for i := 0; i < 2; i++ {
+				token, err := resolver.Token(execInfo)
+				require.NoError(t, err)
+				require.Equal(t, "exec-token", token)
+			}
+			require.Equal(t, testCase.expectedInvocations, invocations(counter))
+		}
+	})
+
+	t.Run("OIDC tokens are refreshed", func(t *testing.T) {
+		refreshedToken := newJWT(time.Now().Add(time.Hour))
+		var refreshes int32
+		var issuerURL string
+		idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case oidcDiscoveryPath:
+				_ = json.NewEncoder(w).Encode(map[string]string{"token_endpoint": issuerURL + "/token"})
+			case "/token":
+				require.NoError(t, r.ParseForm())
+				require.Equal(t, "refresh-token", r.Form.Get("refresh_token"))
+				atomic.AddInt32(&refreshes, 1)
+				_ = json.NewEncoder(w).Encode(map[string]string{"id_token": refreshedToken})
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer idp.Close()
+		issuerURL = idp.URL
+
+		resolver := NewCredentialResolver(nil)
+		oidcInfo := &clientcmdapi.AuthInfo{
+			AuthProvider: &clientcmdapi.AuthProviderConfig{
+				Name: authProviderOIDC,
+				Config: map[string]string{
+					"idp-issuer-url": idp.URL,
+					"client-id":      "reconciler",
+					"id-token":       newJWT(time.Now().Add(-time.Minute)),
+					"refresh-token":  "refresh-token",
+				},
+			},
+		}
+		for i := 0; i < 2; i++ {
+			token, err := resolver.Token(oidcInfo)
+			require.NoError(t, err)
+			require.Equal(t, refreshedToken, token)
+		}
+		require.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+
+		_, err := resolver.Token(&clientcmdapi.AuthInfo{
+			AuthProvider: &clientcmdapi.AuthProviderConfig{Name: "gcp"},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Rejected tokens are resolved again", func(t *testing.T) {
+		var fetches int32
+		source := &cachingTokenSource{fetch: func() (*token, error) {
+			return &token{value: fmt.Sprintf("token%d", atomic.AddInt32(&fetches, 1))}, nil
+		}}
+		apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token2" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}))
+		defer apiServer.Close()
+
+		client := &http.Client{Transport: &bearerTokenRoundTripper{source: source, delegate: http.DefaultTransport}}
+		for _, expectedStatus := range []int{http.StatusUnauthorized, http.StatusOK, http.StatusOK} {
+			resp, err := client.Get(apiServer.URL)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.Equal(t, expectedStatus, resp.StatusCode)
+		}
+		require.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+	})
+}
+
+func TestNewRESTConfig(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: main
+clusters:
+- name: main
+  cluster:
+    server: https://main.example.com
+contexts:
+- name: main
+  context:
+    cluster: main
+    user: main
+users:
+- name: main
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: not-allowed-plugin
+`
+	_, err := NewRESTConfig([]byte(kubeconfig))
+	require.Error(t, err)
+
+	restConfig, err := NewRESTConfig([]byte(strings.Replace(kubeconfig,
+		"    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: not-allowed-plugin\n",
+		"    token: static-token\n", 1)))
+	require.NoError(t, err)
+	require.Equal(t, "static-token", restConfig.BearerToken)
+	require.Nil(t, restConfig.WrapTransport)
+}
@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// KubeconfigError is returned if a kubeconfig can't be used by the reconciler. It lists all detected problems.
+type KubeconfigError struct {
+	Problems []string
+}
+
+func (e *KubeconfigError) Error() string {
+	return fmt.Sprintf("kubeconfig is invalid: %s", strings.Join(e.Problems, "; "))
+}
+
+func IsKubeconfigError(err error) bool {
+	var kubeconfigErr *KubeconfigError
+	return errors.As(err, &kubeconfigErr)
+}
+
+// NormalizeKubeconfig validates a kubeconfig and returns a minified copy which contains only the current context
+// and the cluster and user it references. A KubeconfigError is returned if the kubeconfig can't be parsed,
+// references local files, uses an exec plugin which is not allowed, an auth-provider other than OIDC or contains
+// an expired client certificate.
+func NormalizeKubeconfig(kubeconfig string) (string, error) {
+	config, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return "", &KubeconfigError{Problems: []string{fmt.Sprintf("failed to parse kubeconfig: %s", err)}}
+	}
+	if config.CurrentContext == "" {
+		return "", &KubeconfigError{Problems: []string{"current-context is not set"}}
+	}
+	if err := clientcmdapi.MinifyConfig(config); err != nil {
+		return "", &KubeconfigError{Problems: []string{err.Error()}}
+	}
+
+	if problems := validateKubeconfig(config, time.Now()); len(problems) > 0 {
+		return "", &KubeconfigError{Problems: problems}
+	}
+	if err := clientcmd.Validate(*config); err != nil {
+		return "", &KubeconfigError{Problems: []string{err.Error()}}
+	}
+
+	normalized, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+func validateKubeconfig(config *clientcmdapi.Config, now time.Time) []string {
+	var problems []string
+	for name, cluster := range config.Clusters {
+		if cluster.CertificateAuthority != "" {
+			problems = append(problems, fmt.Sprintf("cluster '%s' references the local file '%s': "+
+				"embed the CA using 'certificate-authority-data'", name, cluster.CertificateAuthority))
+		}
+	}
+	for name, authInfo := range config.AuthInfos {
+		if authInfo.Exec != nil && !DefaultCredentialResolver.IsExecAllowed(authInfo.Exec.Command) {
+			problems = append(problems, fmt.Sprintf("user '%s' uses the exec plugin '%s' which is not allowed: "+
+				"provide a token, a client certificate or an allowed exec plugin", name, authInfo.Exec.Command))
+		}
+		if authInfo.AuthProvider != nil && authInfo.AuthProvider.Name != authProviderOIDC {
+			problems = append(problems, fmt.Sprintf("user '%s' uses the auth-provider '%s' which is not supported: "+
+				"provide a token, a client certificate or use the '%s' auth-provider",
+				name, authInfo.AuthProvider.Name, authProviderOIDC))
+		}
+		if authInfo.ClientCertificate != "" || authInfo.ClientKey != "" || authInfo.TokenFile != "" {
+			problems = append(problems, fmt.Sprintf("user '%s' references local files: "+
+				"embed the credentials using 'client-certificate-data', 'client-key-data' or 'token'", name))
+		}
+		if len(authInfo.ClientCertificateData) > 0 {
+			if problem := validateClientCertificate(authInfo.ClientCertificateData, now); problem != "" {
+				problems = append(problems, fmt.Sprintf("user '%s': %s", name, problem))
+			}
+		}
+	}
+	return problems
+}
+
+func validateClientCertificate(certData []byte, now time.Time) string {
+	block, _ := pem.Decode(certData)
+	if block == nil {
+		return "client certificate is not PEM encoded"
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Sprintf("failed to parse client certificate: %s", err)
+	}
+	if now.After(cert.NotAfter) {
+		return fmt.Sprintf("client certificate expired at %s: renew the credentials of the cluster",
+			cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if now.Before(cert.NotBefore) {
+		return fmt.Sprintf("client certificate is not valid before %s", cert.NotBefore.UTC().Format(time.RFC3339))
+	}
+	return ""
+}
@@ -0,0 +1,111 @@
+package kubernetes
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestNormalizeKubeconfig(t *testing.T) {
+	newCert := func(notAfter time.Time) string {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "admin"},
+			NotBefore:    notAfter.Add(-48 * time.Hour),
+			NotAfter:     notAfter,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	}
+	newKubeconfig := func(user string) string {
+		return fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: main
+clusters:
+- name: main
+  cluster:
+    server: https://main.example.com
+- name: unused
+  cluster:
+    server: https://unused.example.com
+contexts:
+- name: main
+  context:
+    cluster: main
+    user: main
+- name: unused
+  context:
+    cluster: unused
+    user: unused
+users:
+- name: main
+  user:
+%s
+- name: unused
+  user:
+    token: unused-token
+`, user)
+	}
+
+	t.Run("Unused contexts are stripped", func(t *testing.T) {
+		normalized, err := NormalizeKubeconfig(newKubeconfig("    client-certificate-data: " + newCert(time.Now().Add(time.Hour)) +
+			"\n    client-key-data: a2V5"))
+		require.NoError(t, err)
+		config, err := clientcmd.Load([]byte(normalized))
+		require.NoError(t, err)
+		require.Equal(t, "main", config.CurrentContext)
+		require.Len(t, config.Contexts, 1)
+		require.Len(t, config.Clusters, 1)
+		require.Len(t, config.AuthInfos, 1)
+		require.Contains(t, config.Clusters, "main")
+	})
+
+	t.Run("Expired client certificate is rejected", func(t *testing.T) {
+		_, err := NormalizeKubeconfig(newKubeconfig("    client-certificate-data: " + newCert(time.Now().Add(-time.Hour)) +
+			"\n    client-key-data: a2V5"))
+		require.True(t, IsKubeconfigError(err))
+		require.Contains(t, err.Error(), "client certificate expired")
+
+		kubeconfig, err := os.ReadFile("kubeconfig-unreachable.yaml")
+		require.NoError(t, err)
+		_, err = NormalizeKubeconfig(string(kubeconfig))
+		require.True(t, IsKubeconfigError(err))
+	})
+
+	t.Run("Exec plugins which are not allowed and local files are rejected", func(t *testing.T) {
+		_, err := NormalizeKubeconfig(newKubeconfig(`    exec:
+      apiVersion: client.authentication.k8s.io/v1
+      command: kubelogin`))
+		require.True(t, IsKubeconfigError(err))
+		require.Contains(t, err.Error(), "exec plugin 'kubelogin'")
+
+		_, err = NormalizeKubeconfig(newKubeconfig("    tokenFile: /var/run/token"))
+		require.True(t, IsKubeconfigError(err))
+		require.Contains(t, err.Error(), "references local files")
+	})
+
+	t.Run("Unparsable kubeconfig is rejected", func(t *testing.T) {
+		_, err := NormalizeKubeconfig("not a kubeconfig")
+		require.True(t, IsKubeconfigError(err))
+
+		_, err = NormalizeKubeconfig(`apiVersion: v1
+kind: Config
+current-context: missing`)
+		require.True(t, IsKubeconfigError(err))
+	})
+}
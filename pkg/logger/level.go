@@ -0,0 +1,228 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// GlobalScope addresses the log level which is used by all loggers without a scope-specific level
+const GlobalScope = ""
+
+var levels = newLevelRegistry()
+
+// levelSnapshot is an immutable set of log levels: updates replace the whole snapshot
+type levelSnapshot struct {
+	global zapcore.Level
+	scoped map[string]zapcore.Level
+}
+
+// levelRegistry allows lock-free lookups of log levels (which happen for each log entry) by
+// storing the levels as copy-on-write snapshot. Updates are serialized by the mutex.
+type levelRegistry struct {
+	snapshot atomic.Pointer[levelSnapshot]
+	mu       sync.Mutex
+}
+
+func newLevelRegistry() *levelRegistry {
+	registry := &levelRegistry{}
+	registry.snapshot.Store(&levelSnapshot{
+		global: zapcore.InfoLevel,
+		scoped: map[string]zapcore.Level{},
+	})
+	return registry
+}
+
+func (r *levelRegistry) level(scope string) zapcore.Level {
+	snapshot := r.snapshot.Load()
+	if level, ok := snapshot.scoped[scope]; ok {
+		return level
+	}
+	return snapshot.global
+}
+
+// update applies the modification to a copy of the current snapshot and stores the copy afterwards
+func (r *levelRegistry) update(modify func(snapshot *levelSnapshot)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.snapshot.Load()
+	snapshot := &levelSnapshot{
+		global: current.global,
+		scoped: make(map[string]zapcore.Level, len(current.scoped)),
+	}
+	for scope, level := range current.scoped {
+		snapshot.scoped[scope] = level
+	}
+	modify(snapshot)
+	r.snapshot.Store(snapshot)
+}
+
+// scopedLevel is a zapcore.LevelEnabler which resolves the log level of a scope at runtime
+type scopedLevel struct {
+	scope string
+}
+
+func (l scopedLevel) Enabled(level zapcore.Level) bool {
+	return level >= levels.level(l.scope)
+}
+
+// SetLevel changes the log level of all loggers of the given scope (e.g. a component reconciler name)
+// at runtime. The GlobalScope changes the level of all loggers which have no scope-specific level.
+func SetLevel(scope, level string) error {
+	zapLevel, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	levels.update(func(snapshot *levelSnapshot) {
+		if scope == GlobalScope {
+			snapshot.global = zapLevel
+		} else {
+			snapshot.scoped[scope] = zapLevel
+		}
+	})
+	return nil
+}
+
+// ResetLevel removes a scope-specific log level: the scope falls back to the global log level
+func ResetLevel(scope string) {
+	levels.update(func(snapshot *levelSnapshot) {
+		delete(snapshot.scoped, scope)
+	})
+}
+
+// Levels returns the currently configured log levels (the global level is mapped to an empty scope)
+func Levels() map[string]string {
+	snapshot := levels.snapshot.Load()
+	result := map[string]string{GlobalScope: snapshot.global.String()}
+	for scope, level := range snapshot.scoped {
+		result[scope] = level.String()
+	}
+	return result
+}
+
+// ValidateLevel returns an error if the log level is not supported
+func ValidateLevel(level string) error {
+	_, err := parseLevel(level)
+	return err
+}
+
+func parseLevel(level string) (zapcore.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "warn", "warning":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("log level '%s' not supported - choose between 'debug', 'info', 'warn' or 'error'", level)
+	}
+}
+
+// ApplyLevelFile reads log levels from a file and applies them. Each line has the format
+// '<level>' (global level) or '<scope>=<level>' (scope-specific level). Empty lines and
+// lines starting with '#' are ignored. Scopes which are no longer listed in the file fall
+// back to the global level.
+func ApplyLevelFile(file string) error {
+	fh, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = fh.Close()
+	}()
+
+	global := zapcore.InfoLevel
+	scoped := map[string]zapcore.Level{}
+	scanner := bufio.NewScanner(fh)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		scope, level := GlobalScope, line
+		if idx := strings.Index(line, "="); idx >= 0 {
+			scope, level = strings.TrimSpace(line[:idx]), line[idx+1:]
+		}
+		zapLevel, err := parseLevel(level)
+		if err != nil {
+			return fmt.Errorf("invalid entry '%s' in log level file '%s': %s", line, file, err)
+		}
+		if scope == GlobalScope {
+			global = zapLevel
+		} else {
+			scoped[scope] = zapLevel
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+	levels.snapshot.Store(&levelSnapshot{global: global, scoped: scoped})
+	return nil
+}
+
+// WatchLevelFile applies the log levels defined in the file (see ApplyLevelFile) and re-applies them
+// whenever the file changes. The watch stops when the context gets closed.
+func WatchLevelFile(ctx context.Context, file string, interval time.Duration) error {
+	if err := ApplyLevelFile(file); err != nil {
+		return err
+	}
+	lastMod := modTime(file)
+
+	log := NewLogger(false)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mod := modTime(file)
+				if mod.Equal(lastMod) {
+					continue
+				}
+				lastMod = mod
+				if err := ApplyLevelFile(file); err != nil {
+					log.Warnf("Failed to apply log levels from file '%s': %s", file, err)
+					continue
+				}
+				log.Infof("Log levels changed: %s", levelsString())
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func modTime(file string) time.Time {
+	fileInfo, err := os.Stat(file)
+	if err != nil {
+		return time.Time{}
+	}
+	return fileInfo.ModTime()
+}
+
+func levelsString() string {
+	var result []string
+	for scope, level := range Levels() {
+		if scope == GlobalScope {
+			scope = "global"
+		}
+		result = append(result, fmt.Sprintf("%s=%s", scope, level))
+	}
+	sort.Strings(result)
+	return strings.Join(result, ", ")
+}
@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevels(t *testing.T) {
+	defer func() {
+		require.NoError(t, SetLevel(GlobalScope, "info"))
+		ResetLevel("istio")
+	}()
+
+	t.Run("Change levels at runtime", func(t *testing.T) {
+		istio := scopedLevel{scope: "istio"}
+		eventing := scopedLevel{scope: "eventing"}
+		require.False(t, istio.Enabled(zapcore.DebugLevel))
+
+		require.NoError(t, SetLevel("istio", "debug"))
+		require.True(t, istio.Enabled(zapcore.DebugLevel))
+		require.False(t, eventing.Enabled(zapcore.DebugLevel))
+
+		require.NoError(t, SetLevel(GlobalScope, "warn"))
+		require.False(t, eventing.Enabled(zapcore.InfoLevel))
+		require.True(t, istio.Enabled(zapcore.InfoLevel))
+
+		ResetLevel("istio")
+		require.False(t, istio.Enabled(zapcore.InfoLevel))
+		require.Equal(t, map[string]string{GlobalScope: "warn"}, Levels())
+	})
+
+	t.Run("Read levels while they are changed", func(t *testing.T) {
+		istio := scopedLevel{scope: "istio"}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 1000; i++ {
+				istio.Enabled(zapcore.DebugLevel)
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			require.NoError(t, SetLevel("istio", "debug"))
+			ResetLevel("istio")
+		}
+		<-done
+		require.False(t, istio.Enabled(zapcore.DebugLevel))
+	})
+
+	t.Run("Reject unsupported level", func(t *testing.T) {
+		require.Error(t, SetLevel(GlobalScope, "verbose"))
+	})
+
+	t.Run("Apply level file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "loglevels")
+		require.NoError(t, os.WriteFile(file, []byte("# comment\ndebug\nistio = warn\n"), 0600))
+		require.NoError(t, ApplyLevelFile(file))
+		require.Equal(t, map[string]string{GlobalScope: "debug", "istio": "warn"}, Levels())
+
+		require.NoError(t, os.WriteFile(file, []byte("istio=foo\n"), 0600))
+		require.Error(t, ApplyLevelFile(file))
+	})
+}
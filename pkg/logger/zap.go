@@ -17,10 +17,18 @@ var outputFormat = OutputFormatJSON
 
 type OutputFormat string
 
+// NewLogger returns a logger which follows the global log level (see SetLevel).
+// Debug loggers are always logging on debug level.
 func NewLogger(debug bool) *zap.SugaredLogger {
-	logLevel := zapcore.InfoLevel
+	return NewScopedLogger(GlobalScope, debug)
+}
+
+// NewScopedLogger returns a logger which follows the log level of the given scope (e.g. the name
+// of a component reconciler) or the global log level if no scope-specific level is defined.
+func NewScopedLogger(scope string, debug bool) *zap.SugaredLogger {
+	var logLevel zapcore.LevelEnabler = scopedLevel{scope: scope}
 	if debug {
-		logLevel = zapcore.DebugLevel
+		logLevel = zap.NewAtomicLevelAt(zapcore.DebugLevel)
 	}
 	return newLogger(logLevel).Sugar()
 }
@@ -29,7 +37,7 @@ func NewTestLogger(t *testing.T) *zap.SugaredLogger {
 	return zaptest.NewLogger(t).Sugar()
 }
 
-func newLogger(logLevel zapcore.Level) *zap.Logger {
+func newLogger(logLevel zapcore.LevelEnabler) *zap.Logger {
 	encoderConfig := zapcore.EncoderConfig{
 		MessageKey:   "message",
 		LevelKey:     "level",
@@ -52,7 +60,7 @@ func newLogger(logLevel zapcore.Level) *zap.Logger {
 		zapcore.NewCore(
 			encoder,
 			zapcore.Lock(os.Stderr),
-			zap.NewAtomicLevelAt(logLevel),
+			logLevel,
 		),
 		zap.ErrorOutput(os.Stderr))
 }
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const traceIDLabel = "trace_id"
+
+// ComponentOperationsMetric provides the following metrics:
+// - reconciler_component_operations_total{"component", "operation_type", "status"}
+// - reconciler_component_operation_duration_seconds{"component", "operation_type", "status"}
+// If a trace ID is known for an operation, it's attached as exemplar to both metrics.
+type ComponentOperationsMetric struct {
+	operations *prometheus.CounterVec
+	durations  *prometheus.HistogramVec
+	logger     *zap.SugaredLogger
+}
+
+func NewComponentOperationsMetric(logger *zap.SugaredLogger) *ComponentOperationsMetric {
+	labels := []string{"component", "operation_type", "status"}
+	return &ComponentOperationsMetric{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "component_operations_total",
+			Help:      "Number of processed operations",
+		}, labels),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "component_operation_duration_seconds",
+			Help:      "Duration of processed operations",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, labels),
+		logger: logger,
+	}
+}
+
+func (c *ComponentOperationsMetric) Describe(ch chan<- *prometheus.Desc) {
+	c.operations.Describe(ch)
+	c.durations.Describe(ch)
+}
+
+func (c *ComponentOperationsMetric) Collect(ch chan<- prometheus.Metric) {
+	c.operations.Collect(ch)
+	c.durations.Collect(ch)
+}
+
+// ExposeOperation counts a processed operation and observes its duration. The trace ID is optional.
+func (c *ComponentOperationsMetric) ExposeOperation(component string, opType model.OperationType, state model.OperationState,
+	duration time.Duration, traceID string) {
+	counter, err := c.operations.GetMetricWithLabelValues(component, string(opType), string(state))
+	if err != nil {
+		c.logger.Errorf("ComponentOperationsMetric: unable to retrieve counter with label=%s: %s", component, err)
+		return
+	}
+	histogram, err := c.durations.GetMetricWithLabelValues(component, string(opType), string(state))
+	if err != nil {
+		c.logger.Errorf("ComponentOperationsMetric: unable to retrieve histogram with label=%s: %s", component, err)
+		return
+	}
+
+	if traceID == "" {
+		counter.Inc()
+		histogram.Observe(duration.Seconds())
+		return
+	}
+	exemplar := prometheus.Labels{traceIDLabel: traceID}
+	counter.(prometheus.ExemplarAdder).AddWithExemplar(1, exemplar)
+	histogram.(prometheus.ExemplarObserver).ObserveWithExemplar(duration.Seconds(), exemplar)
+}
@@ -39,6 +39,7 @@ const (
 	dbOpenConnections    = "open_connections"
 	dbWaitCount          = "wait_count"
 	dbWaitDuration       = "wait_duration"
+	dbSaturation         = "saturation" //ratio of used connections (0-1), 0 if the pool is unlimited
 )
 
 func NewDbPoolCollector(connPool db.Connection, logger *zap.SugaredLogger) *DbPoolCollector {
@@ -60,6 +61,12 @@ func NewDbPoolCollector(connPool db.Connection, logger *zap.SugaredLogger) *DbPo
 			func(i dbMetricInput) *dbMetric { return &dbMetric{float64(i.MaxOpenConnections), dbMaxOpenConnections} },
 			func(i dbMetricInput) *dbMetric { return &dbMetric{float64(i.OpenConnections), dbOpenConnections} },
 			func(i dbMetricInput) *dbMetric { return &dbMetric{float64(i.WaitCount), dbWaitCount} },
+			func(i dbMetricInput) *dbMetric {
+				if i.MaxOpenConnections <= 0 {
+					return &dbMetric{0, dbSaturation}
+				}
+				return &dbMetric{float64(i.InUse) / float64(i.MaxOpenConnections), dbSaturation}
+			},
 			func(i dbMetricInput) *dbMetric {
 				return &dbMetric{
 					float64(i.WaitDuration.Milliseconds()),
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DbQueryCollector provides the following metrics:
+// - reconciler_db_query_duration_seconds{"statement", "table"} - latency of executed DB statements
+// - reconciler_db_query_errors_total{"statement", "table"} - number of failed DB statements
+// - reconciler_db_transaction_retries_total{"reason"} - number of retried transactions which collided with others
+// - reconciler_db_transaction_aborts_total{"reason"} - number of collided transactions which were given up
+type DbQueryCollector struct {
+	duration  *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	txRetries *prometheus.CounterVec
+	txAborts  *prometheus.CounterVec
+}
+
+func NewDbQueryCollector() *DbQueryCollector {
+	return &DbQueryCollector{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "db_query_duration_seconds",
+			Help:      "Latency of executed DB statements",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"statement", "table"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "db_query_errors_total",
+			Help:      "Number of failed DB statements",
+		}, []string{"statement", "table"}),
+		txRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "db_transaction_retries_total",
+			Help:      "Number of DB transactions which were retried because they collided with concurrent transactions",
+		}, []string{"reason"}),
+		txAborts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "db_transaction_aborts_total",
+			Help:      "Number of collided DB transactions which were given up after all retries failed",
+		}, []string{"reason"}),
+	}
+}
+
+// ObserveQuery implements the db.QueryObserver interface.
+func (c *DbQueryCollector) ObserveQuery(statement, table string, duration time.Duration, err error) {
+	c.duration.WithLabelValues(statement, table).Observe(duration.Seconds())
+	if err != nil {
+		c.errors.WithLabelValues(statement, table).Inc()
+	}
+}
+
+// ObserveTransactionRetry implements the db.TransactionObserver interface.
+func (c *DbQueryCollector) ObserveTransactionRetry(reason string) {
+	c.txRetries.WithLabelValues(reason).Inc()
+}
+
+// ObserveTransactionAbort implements the db.TransactionObserver interface.
+func (c *DbQueryCollector) ObserveTransactionAbort(reason string) {
+	c.txAborts.WithLabelValues(reason).Inc()
+}
+
+func (c *DbQueryCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.duration.Describe(ch)
+	c.errors.Describe(ch)
+	c.txRetries.Describe(ch)
+	c.txAborts.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *DbQueryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.duration.Collect(ch)
+	c.errors.Collect(ch)
+	c.txRetries.Collect(ch)
+	c.txAborts.Collect(ch)
+}
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/report"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// FreshnessSLOCollector provides the compliance of the clusters with the freshness SLO:
+// - reconciler_freshness_slo_objective_seconds - maximal age of the latest successful reconciliation of a cluster
+// - reconciler_freshness_slo_compliance - percentage of clusters which were reconciled successfully within the objective
+// - reconciler_freshness_slo_violations_total - number of clusters violating the SLO
+// - reconciler_freshness_slo_violation{"runtime_id","status"} - set to 1 for each cluster violating the SLO
+type FreshnessSLOCollector struct {
+	inventory cluster.Inventory
+	generator *report.Generator
+	objective time.Duration
+	logger    *zap.SugaredLogger
+
+	objectiveDesc  *prometheus.Desc
+	complianceDesc *prometheus.Desc
+	violationsDesc *prometheus.Desc
+	violationDesc  *prometheus.Desc
+}
+
+func NewFreshnessSLOCollector(inventory cluster.Inventory, reconRepo reconciliation.Repository, objective time.Duration, logger *zap.SugaredLogger) *FreshnessSLOCollector {
+	return &FreshnessSLOCollector{
+		inventory: inventory,
+		generator: report.NewGenerator(reconRepo),
+		objective: objective,
+		logger:    logger,
+		objectiveDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "freshness_slo_objective_seconds"),
+			"Maximal age of the latest successful reconciliation of a cluster",
+			[]string{},
+			nil),
+		complianceDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "freshness_slo_compliance"),
+			"Percentage of clusters which were reconciled successfully within the freshness objective",
+			[]string{},
+			nil),
+		violationsDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "freshness_slo_violations_total"),
+			"Number of clusters which weren't reconciled successfully within the freshness objective",
+			[]string{},
+			nil),
+		violationDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "freshness_slo_violation"),
+			"Clusters which weren't reconciled successfully within the freshness objective",
+			[]string{"runtime_id", "status"},
+			nil),
+	}
+}
+
+func (c *FreshnessSLOCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.objectiveDesc
+	ch <- c.complianceDesc
+	ch <- c.violationsDesc
+	ch <- c.violationDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *FreshnessSLOCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.inventory == nil {
+		c.logger.Error("unable to register metric: inventory is nil")
+		return
+	}
+
+	states, err := c.inventory.GetAll()
+	if err != nil {
+		c.logger.Errorf("unable to retrieve clusters for freshness SLO: %s", err)
+		return
+	}
+	freshnessReport, err := c.generator.Freshness(states, c.objective)
+	if err != nil {
+		c.logger.Errorf("unable to evaluate freshness SLO: %s", err)
+		return
+	}
+
+	violations := freshnessReport.Violations()
+	c.send(ch, c.objectiveDesc, c.objective.Seconds())
+	c.send(ch, c.complianceDesc, freshnessReport.Compliance)
+	c.send(ch, c.violationsDesc, float64(len(violations)))
+	for _, violation := range violations {
+		c.send(ch, c.violationDesc, 1, violation.RuntimeID, string(violation.Status))
+	}
+}
+
+func (c *FreshnessSLOCollector) send(ch chan<- prometheus.Metric, desc *prometheus.Desc, value float64, labels ...string) {
+	m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+	if err != nil {
+		c.logger.Errorf("unable to register metric %s", err.Error())
+		return
+	}
+	ch <- m
+}
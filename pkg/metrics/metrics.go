@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/features"
@@ -62,6 +64,67 @@ func RegisterDbPool(connPool db.Connection, logger *zap.SugaredLogger) error {
 	return nil
 }
 
+// RegisterDbQueries exposes latency and errors of all statements executed by the DB connections
+func RegisterDbQueries(logger *zap.SugaredLogger) error {
+	dbQueryCollector := NewDbQueryCollector()
+	err := prometheus.Register(dbQueryCollector)
+	switch err := err.(type) {
+	case prometheus.AlreadyRegisteredError:
+		logger.Warnf("skipping registration of DB query metrics as they were already registered, existing: %v",
+			err.ExistingCollector)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	db.SetQueryObserver(dbQueryCollector)
+	db.SetTransactionObserver(dbQueryCollector)
+	return nil
+}
+
+func RegisterOperationStates(reconciliations reconciliation.Repository, logger *zap.SugaredLogger) error {
+	err := prometheus.Register(NewOperationStateCollector(reconciliations, logger))
+	switch err := err.(type) {
+	case prometheus.AlreadyRegisteredError:
+		logger.Warnf("skipping registration of operation state metrics as they were already registered, existing: %v",
+			err.ExistingCollector)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RegisterFreshnessSLO exposes the compliance of the clusters with the freshness objective
+func RegisterFreshnessSLO(inventory cluster.Inventory, reconciliations reconciliation.Repository, objective time.Duration, logger *zap.SugaredLogger) error {
+	err := prometheus.Register(NewFreshnessSLOCollector(inventory, reconciliations, objective, logger))
+	switch err := err.(type) {
+	case prometheus.AlreadyRegisteredError:
+		logger.Warnf("skipping registration of freshness SLO metrics as they were already registered, existing: %v",
+			err.ExistingCollector)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func RegisterScheduler(schedulerCollector *SchedulerCollector, logger *zap.SugaredLogger) error {
+	err := prometheus.Register(schedulerCollector)
+	switch err := err.(type) {
+	case prometheus.AlreadyRegisteredError:
+		logger.Warnf("skipping registration of scheduler metrics as they were already registered, existing: %v",
+			err.ExistingCollector)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func RegisterOccupancy(occupancyRepo occupancy.Repository, reconcilers map[string]config.ComponentReconciler, logger *zap.SugaredLogger) error {
 	if features.Enabled(features.WorkerpoolOccupancyTracking) {
 		err := prometheus.Register(NewWorkerPoolOccupancyCollector(occupancyRepo, reconcilers, logger))
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var operationStates = []model.OperationState{
+	model.OperationStateNew,
+	model.OperationStateInProgress,
+	model.OperationStateDone,
+	model.OperationStateClientError,
+	model.OperationStateError,
+	model.OperationStateFailed,
+	model.OperationStateOrphan,
+	model.OperationStateVerificationFailed,
+}
+
+// OperationStateCollector provides the number of operations of running reconciliations per state:
+// - reconciler_scheduler_operations{"state"}
+type OperationStateCollector struct {
+	reconRepo reconciliation.Repository
+	logger    *zap.SugaredLogger
+
+	operationsDesc *prometheus.Desc
+}
+
+func NewOperationStateCollector(reconRepo reconciliation.Repository, logger *zap.SugaredLogger) *OperationStateCollector {
+	return &OperationStateCollector{
+		reconRepo: reconRepo,
+		logger:    logger,
+		operationsDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "scheduler_operations"),
+			"Number of operations of running reconciliations per state",
+			[]string{"state"},
+			nil),
+	}
+}
+
+func (c *OperationStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.operationsDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *OperationStateCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.reconRepo == nil {
+		c.logger.Error("unable to register metric: reconciliation repository is nil")
+		return
+	}
+
+	ops, err := c.reconRepo.GetReconcilingOperations()
+	if err != nil {
+		c.logger.Errorf("unable to retrieve operations of running reconciliations: %s", err)
+		return
+	}
+
+	opsByState := make(map[model.OperationState]int, len(operationStates))
+	for _, op := range ops {
+		opsByState[op.State]++
+	}
+	for _, state := range operationStates {
+		m, err := prometheus.NewConstMetric(c.operationsDesc, prometheus.GaugeValue, float64(opsByState[state]), string(state))
+		if err != nil {
+			c.logger.Errorf("unable to register metric %s", err.Error())
+			continue
+		}
+		ch <- m
+	}
+}
@@ -2,8 +2,15 @@ package metrics
 
 type ReconcilerMetricsSet struct {
 	ComponentProcessingDurationCollector *ComponentProcessingDurationMetric
+	ComponentOperationsCollector         *ComponentOperationsMetric
+	StuckWorkersCollector                *StuckWorkersMetric
 }
 
-func NewReconcilerMetricsSet(componentProcessingDurationCollector *ComponentProcessingDurationMetric) *ReconcilerMetricsSet {
-	return &ReconcilerMetricsSet{ComponentProcessingDurationCollector: componentProcessingDurationCollector}
+func NewReconcilerMetricsSet(componentProcessingDurationCollector *ComponentProcessingDurationMetric,
+	componentOperationsCollector *ComponentOperationsMetric, stuckWorkersCollector *StuckWorkersMetric) *ReconcilerMetricsSet {
+	return &ReconcilerMetricsSet{
+		ComponentProcessingDurationCollector: componentProcessingDurationCollector,
+		ComponentOperationsCollector:         componentOperationsCollector,
+		StuckWorkersCollector:                stuckWorkersCollector,
+	}
 }
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SchedulerCollector provides the following metrics:
+// - reconciler_scheduler_cluster_queue_depth - number of clusters waiting in the scheduling queue
+// - reconciler_scheduler_processable_operations - number of operations found by the last check of the worker pool
+// which are waiting for a worker
+// - reconciler_scheduler_latency_seconds{"component"} - time between the creation of an operation and its
+// first assignment to a worker
+// - reconciler_scheduler_reconciler_backoff_seconds{"reconciler"} - current backoff of an overloaded component
+// reconciler (zero if the component reconciler isn't throttled)
+type SchedulerCollector struct {
+	clusterQueueDepthDesc *prometheus.Desc
+	processableOperations prometheus.Gauge
+	schedulingLatency     *prometheus.HistogramVec
+	reconcilerBackoff     *prometheus.GaugeVec
+	clusterQueue          func() int
+	mu                    sync.Mutex
+}
+
+func NewSchedulerCollector() *SchedulerCollector {
+	return &SchedulerCollector{
+		clusterQueueDepthDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "scheduler_cluster_queue_depth"),
+			"Number of clusters waiting in the scheduling queue",
+			[]string{},
+			nil),
+		processableOperations: prometheus.NewGauge(prometheus.GaugeOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "scheduler_processable_operations",
+			Help:      "Number of operations which are waiting for a worker",
+		}),
+		schedulingLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "scheduler_latency_seconds",
+			Help:      "Time between the creation of an operation and its first assignment to a worker",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"component"}),
+		reconcilerBackoff: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "scheduler_reconciler_backoff_seconds",
+			Help:      "Current backoff of an overloaded component reconciler (zero if it isn't throttled)",
+		}, []string{"reconciler"}),
+	}
+}
+
+// OnClusterQueue registers the function which returns the current depth of the cluster scheduling queue
+func (c *SchedulerCollector) OnClusterQueue(queueDepth func() int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clusterQueue = queueDepth
+}
+
+func (c *SchedulerCollector) OnProcessableOperations(count int) {
+	c.processableOperations.Set(float64(count))
+}
+
+func (c *SchedulerCollector) OnOperationAssigned(op *model.OperationEntity) {
+	if !op.PickedUp.IsZero() { //operation was already assigned before (e.g. it's retried)
+		return
+	}
+	c.schedulingLatency.WithLabelValues(op.Component).Observe(time.Since(op.Created).Seconds())
+}
+
+func (c *SchedulerCollector) OnReconcilerBackoff(reconciler string, backoff time.Duration) {
+	c.reconcilerBackoff.WithLabelValues(reconciler).Set(backoff.Seconds())
+}
+
+func (c *SchedulerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.clusterQueueDepthDesc
+	c.processableOperations.Describe(ch)
+	c.schedulingLatency.Describe(ch)
+	c.reconcilerBackoff.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *SchedulerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	clusterQueue := c.clusterQueue
+	c.mu.Unlock()
+	if clusterQueue != nil {
+		ch <- prometheus.MustNewConstMetric(c.clusterQueueDepthDesc, prometheus.GaugeValue, float64(clusterQueue()))
+	}
+	c.processableOperations.Collect(ch)
+	c.schedulingLatency.Collect(ch)
+	c.reconcilerBackoff.Collect(ch)
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StuckWorkersMetric provides the following metrics:
+// - reconciler_stuck_workers_total{"component"} - number of workers which exceeded the stuck-worker threshold
+// - reconciler_stuck_workers{"component"} - number of stuck workers which haven't returned yet
+type StuckWorkersMetric struct {
+	stuckTotal *prometheus.CounterVec
+	stuck      *prometheus.GaugeVec
+}
+
+func NewStuckWorkersMetric() *StuckWorkersMetric {
+	return &StuckWorkersMetric{
+		stuckTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "stuck_workers_total",
+			Help:      "Number of workers which were detected as stuck",
+		}, []string{"component"}),
+		stuck: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Subsystem: prometheusSubsystem,
+			Name:      "stuck_workers",
+			Help:      "Number of stuck workers which haven't returned yet",
+		}, []string{"component"}),
+	}
+}
+
+func (c *StuckWorkersMetric) OnStuckWorker(component string) {
+	c.stuckTotal.WithLabelValues(component).Inc()
+	c.stuck.WithLabelValues(component).Inc()
+}
+
+func (c *StuckWorkersMetric) OnStuckWorkerReturned(component string) {
+	c.stuck.WithLabelValues(component).Dec()
+}
+
+func (c *StuckWorkersMetric) Describe(ch chan<- *prometheus.Desc) {
+	c.stuckTotal.Describe(ch)
+	c.stuck.Describe(ch)
+}
+
+func (c *StuckWorkersMetric) Collect(ch chan<- prometheus.Metric) {
+	c.stuckTotal.Collect(ch)
+	c.stuck.Collect(ch)
+}
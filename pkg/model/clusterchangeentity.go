@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+const tblClusterChanges string = "inventory_cluster_changes"
+
+// ClusterChangeEntity records a change of the desired configuration of a cluster: who changed it, when and what
+// was changed compared to the previous configuration.
+type ClusterChangeEntity struct {
+	ID            int64                  `db:"readOnly"`
+	RuntimeID     string                 `db:"notNull"`
+	ConfigVersion int64                  `db:"notNull"`
+	Generation    int64                  `db:"notNull"`
+	Actor         string                 `db:""`
+	Diff          *keb.ConfigurationDiff `db:"notNull,encrypt"`
+	Created       time.Time              `db:"readOnly"`
+}
+
+func (c *ClusterChangeEntity) String() string {
+	return fmt.Sprintf("ClusterChangeEntity [RuntimeID=%s,ConfigVersion=%d,Actor=%s]",
+		c.RuntimeID, c.ConfigVersion, c.Actor)
+}
+
+func (*ClusterChangeEntity) New() db.DatabaseEntity {
+	return &ClusterChangeEntity{}
+}
+
+func (c *ClusterChangeEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Diff", func(value interface{}) (interface{}, error) {
+		diff := &keb.ConfigurationDiff{}
+		err := json.Unmarshal([]byte(value.(string)), diff)
+		return diff, err
+	})
+	marshaller.AddMarshaller("Diff", convertInterfaceToJSONString)
+	return marshaller
+}
+
+func (*ClusterChangeEntity) Table() string {
+	return tblClusterChanges
+}
+
+func (c *ClusterChangeEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherChange, ok := other.(*ClusterChangeEntity)
+	if !ok {
+		return false
+	}
+	return c.RuntimeID == otherChange.RuntimeID && c.ConfigVersion == otherChange.ConfigVersion
+}
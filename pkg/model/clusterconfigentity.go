@@ -45,6 +45,7 @@ type ClusterConfigurationEntity struct {
 	Components     []*keb.Component `db:"notNull,encrypt"`
 	Administrators []string
 	Contract       int64     `db:"notNull"`
+	Generation     int64     `db:"notNull"` //incremented with each change of the cluster configuration (used as ETag)
 	Deleted        bool      `db:"notNull"`
 	Created        time.Time `db:"readOnly"`
 }
@@ -122,10 +123,35 @@ func (c *ClusterConfigurationEntity) GetComponent(component string) *keb.Compone
 
 func (c *ClusterConfigurationEntity) GetReconciliationSequence(cfg *ReconciliationSequenceConfig) *ReconciliationSequence {
 	reconSeq := newReconciliationSequence(cfg)
-	reconSeq.addComponents(c.nonMigratedComponents(cfg))
+	reconSeq.addComponents(withoutSkippedComponents(c.nonMigratedComponents(cfg), cfg.SkippedComponents))
 	return reconSeq
 }
 
+// GetSimulatedReconciliationSequence returns the reconciliation sequence without accessing the cluster: components
+// which are already managed by the operator-based reconciler are not detected and remain part of the sequence.
+func (c *ClusterConfigurationEntity) GetSimulatedReconciliationSequence(cfg *ReconciliationSequenceConfig) *ReconciliationSequence {
+	reconSeq := newReconciliationSequence(cfg)
+	reconSeq.addComponents(withoutSkippedComponents(c.Components, cfg.SkippedComponents))
+	return reconSeq
+}
+
+func withoutSkippedComponents(components []*keb.Component, skipped []string) []*keb.Component {
+	if len(skipped) == 0 {
+		return components
+	}
+	skippedComps := make(map[string]bool, len(skipped))
+	for _, comp := range skipped {
+		skippedComps[comp] = true
+	}
+	var result []*keb.Component
+	for _, comp := range components {
+		if !skippedComps[comp.Component] {
+			result = append(result, comp)
+		}
+	}
+	return result
+}
+
 func (c *ClusterConfigurationEntity) nonMigratedComponents(cfg *ReconciliationSequenceConfig) []*keb.Component {
 	logger := log.NewLogger(false)
 
@@ -208,21 +234,34 @@ func (c *ClusterConfigurationEntity) stopAndLogK8sError(logger *zap.SugaredLogge
 }
 
 type ReconciliationSequence struct {
-	Queue         [][]*keb.Component
-	preComponents [][]string
+	Queue               [][]*keb.Component
+	preComponents       [][]string
+	bootstrapComponents []string
+	bootstrapGroup      int //index of the bootstrap group in the queue (-1 if the sequence has no bootstrap group)
+}
+
+// IsBootstrapGroup returns true if the group at the given index of the queue contains the bootstrap components
+func (rs *ReconciliationSequence) IsBootstrapGroup(idx int) bool {
+	return rs.bootstrapGroup >= 0 && idx == rs.bootstrapGroup
 }
 
 type ReconciliationSequenceConfig struct {
 	PreComponents        [][]string
+	BootstrapComponents  []string //cluster essentials which have to be reconciled before any other component
 	DeleteStrategy       string
 	ComponentCRDs        map[string]config.ComponentCRD
 	ReconciliationStatus Status
 	Kubeconfig           string
+	SkippedComponents    []string //components excluded from the reconciliation (e.g. their reconcile interval didn't elapse)
+	Initiator            Initiator
+	Reason               string
 }
 
 func newReconciliationSequence(cfg *ReconciliationSequenceConfig) *ReconciliationSequence {
 	reconSeq := &ReconciliationSequence{
-		preComponents: cfg.PreComponents,
+		preComponents:       cfg.PreComponents,
+		bootstrapComponents: cfg.BootstrapComponents,
+		bootstrapGroup:      -1,
 	}
 	reconSeq.Queue = append(reconSeq.Queue, []*keb.Component{ //CRDs are always processed at the very beginning (or at the very end in deletion)
 		crdComponent,
@@ -251,6 +290,19 @@ func (rs *ReconciliationSequence) addComponents(components []*keb.Component) {
 		return result
 	}()
 
+	//add bootstrap components to queue: all other components are processed after they were successfully reconciled
+	var bootstrapComps []*keb.Component
+	for _, bootstrapComponentName := range rs.bootstrapComponents {
+		if bootstrapComp, ok := compsByNameCache[bootstrapComponentName]; ok {
+			bootstrapComps = append(bootstrapComps, bootstrapComp)
+			delete(compsByNameCache, bootstrapComp.Component) //remove bootstrap component from cache
+		}
+	}
+	if len(bootstrapComps) > 0 {
+		rs.Queue = append(rs.Queue, bootstrapComps)
+		rs.bootstrapGroup = len(rs.Queue) - 1
+	}
+
 	//add pre-components to queue
 	for _, preComponentGroup := range rs.preComponents {
 		var preComps []*keb.Component
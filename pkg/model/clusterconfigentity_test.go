@@ -197,11 +197,101 @@ func TestReconciliationSequence(t *testing.T) {
 	tests := []struct {
 		name                 string
 		preComps             [][]string
+		bootstrapComps       []string
+		skippedComps         []string
 		entity               *ClusterConfigurationEntity
 		reconciliationStatus Status
 		expected             *ReconciliationSequence
 		err                  error
 	}{
+		{
+			name:                 "Bootstrap components are processed before pre-components",
+			preComps:             [][]string{{"Pre1"}, {"Boot2"}},
+			bootstrapComps:       []string{"Boot1", "Boot2", "Boot3"},
+			reconciliationStatus: ClusterStatusReconciling,
+			entity: &ClusterConfigurationEntity{
+				Components: []*keb.Component{
+					{
+						Component: "Comp1",
+					},
+					{
+						Component: "Pre1",
+					},
+					{
+						Component: "Boot1",
+					},
+					{
+						Component: "Boot2",
+					},
+				},
+			},
+			expected: &ReconciliationSequence{
+				Queue: [][]*keb.Component{
+					{
+						crdComponent,
+					},
+					{
+						{
+							Component: "Boot1",
+						},
+						{
+							Component: "Boot2",
+						},
+					},
+					{
+						{
+							Component: "Pre1",
+						},
+					},
+					{
+						{
+							Component: "Comp1",
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name:                 "Skipped components are excluded",
+			preComps:             [][]string{{"Pre1"}, {"Pre2"}},
+			skippedComps:         []string{"Pre2", "Comp2"},
+			reconciliationStatus: ClusterStatusReconciling,
+			entity: &ClusterConfigurationEntity{
+				Components: []*keb.Component{
+					{
+						Component: "Pre1",
+					},
+					{
+						Component: "Pre2",
+					},
+					{
+						Component: "Comp1",
+					},
+					{
+						Component: "Comp2",
+					},
+				},
+			},
+			expected: &ReconciliationSequence{
+				Queue: [][]*keb.Component{
+					{
+						crdComponent,
+					},
+					{
+						{
+							Component: "Pre1",
+						},
+					},
+					{
+						{
+							Component: "Comp1",
+						},
+					},
+				},
+			},
+			err: nil,
+		},
 		{
 			name:                 "Components and single pre-components",
 			preComps:             [][]string{{"Pre1"}, {"Pre2"}},
@@ -411,12 +501,16 @@ func TestReconciliationSequence(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			result := tc.entity.GetReconciliationSequence(&ReconciliationSequenceConfig{
 				PreComponents:        tc.preComps,
+				BootstrapComponents:  tc.bootstrapComps,
 				DeleteStrategy:       "system",
 				ReconciliationStatus: tc.reconciliationStatus,
+				SkippedComponents:    tc.skippedComps,
 			})
+			require.Len(t, result.Queue, len(tc.expected.Queue))
 			for idx, expected := range tc.expected.Queue {
 				require.ElementsMatch(t, result.Queue[idx], expected)
 			}
+			require.Equal(t, len(tc.bootstrapComps) > 0, result.IsBootstrapGroup(1))
 		})
 	}
 }
@@ -13,14 +13,15 @@ import (
 const tblCluster string = "inventory_clusters"
 
 type ClusterEntity struct {
-	Version    int64             `db:"readOnly"`
-	RuntimeID  string            `db:"notNull"`
-	Runtime    *keb.RuntimeInput `db:"notNull"`
-	Metadata   *keb.Metadata     `db:"notNull"`
-	Kubeconfig string            `db:"notNull,encrypt"`
-	Contract   int64             `db:"notNull"`
-	Deleted    bool              `db:"notNull"`
-	Created    time.Time         `db:"readOnly"`
+	Version         int64             `db:"readOnly"`
+	RuntimeID       string            `db:"notNull"`
+	Runtime         *keb.RuntimeInput `db:"notNull"`
+	Metadata        *keb.Metadata     `db:"notNull"`
+	GlobalAccountID string            `db:""` //tenant of the cluster (copied from the metadata to allow filtering)
+	Kubeconfig      string            `db:"notNull,encrypt"`
+	Contract        int64             `db:"notNull"`
+	Deleted         bool              `db:"notNull"`
+	Created         time.Time         `db:"readOnly"`
 }
 
 func (c *ClusterEntity) String() string {
@@ -17,6 +17,8 @@ type ClusterStatusEntity struct {
 	ConfigVersion  int64     `db:"notNull"` // Cluster config entity primary key
 	Status         Status    `db:"notNull"`
 	Deleted        bool      `db:"notNull"`
+	Initiator      Initiator `db:"notNull"` //only defined for pending statuses
+	Reason         string    `db:"notNull"`
 	Created        time.Time `db:"readOnly"`
 }
 
@@ -38,6 +40,7 @@ func (c *ClusterStatusEntity) Marshaller() *db.EntityMarshaller {
 		}
 		return "", err
 	})
+	marshaller.AddUnmarshaller("Initiator", convertStringToInitiator)
 	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
 	return marshaller
 }
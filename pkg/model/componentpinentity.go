@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblComponentPins string = "inventory_component_pins"
+
+// ComponentPinEntity pins a component of a cluster to a version (e.g. '1.2.3') or a semver range (e.g. '~1.2')
+type ComponentPinEntity struct {
+	RuntimeID string    `db:"notNull"`
+	Component string    `db:"notNull"`
+	Version   string    `db:"notNull"`
+	Created   time.Time `db:"readOnly"`
+}
+
+func (p *ComponentPinEntity) String() string {
+	return fmt.Sprintf("ComponentPinEntity [RuntimeID=%s,Component=%s,Version=%s]",
+		p.RuntimeID, p.Component, p.Version)
+}
+
+func (*ComponentPinEntity) New() db.DatabaseEntity {
+	return &ComponentPinEntity{}
+}
+
+func (p *ComponentPinEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&p)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*ComponentPinEntity) Table() string {
+	return tblComponentPins
+}
+
+func (p *ComponentPinEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherPin, ok := other.(*ComponentPinEntity)
+	if !ok {
+		return false
+	}
+	return p.RuntimeID == otherPin.RuntimeID &&
+		p.Component == otherPin.Component &&
+		p.Version == otherPin.Version
+}
@@ -41,3 +41,11 @@ func convertStringToStatus(value interface{}) (interface{}, error) {
 	return nil, fmt.Errorf("failed to convert value '%s' (kind: %s) for field 'Status' to Status type",
 		value, reflect.TypeOf(value).Kind())
 }
+
+func convertStringToInitiator(value interface{}) (interface{}, error) {
+	if reflect.TypeOf(value).Kind() == reflect.String {
+		return Initiator(fmt.Sprintf("%v", value)), nil
+	}
+	return nil, fmt.Errorf("failed to convert value '%s' (kind: %s) for field 'Initiator' to Initiator type",
+		value, reflect.TypeOf(value).Kind())
+}
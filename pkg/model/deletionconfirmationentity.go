@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblDeletionConfirmations string = "inventory_deletion_confirmations"
+
+// DeletionConfirmationEntity is a single-use token which has to be passed to the deletion request
+// of a cluster before the Expires timestamp (UTC) is reached
+type DeletionConfirmationEntity struct {
+	Token     string    `db:"notNull"`
+	RuntimeID string    `db:"notNull"`
+	Expires   time.Time `db:"notNull"`
+	Created   time.Time `db:"readOnly"`
+}
+
+func (c *DeletionConfirmationEntity) String() string {
+	return fmt.Sprintf("DeletionConfirmationEntity [RuntimeID=%s,Expires=%s]",
+		c.RuntimeID, c.Expires.Format(time.RFC3339))
+}
+
+func (*DeletionConfirmationEntity) New() db.DatabaseEntity {
+	return &DeletionConfirmationEntity{}
+}
+
+func (c *DeletionConfirmationEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&c)
+	marshaller.AddUnmarshaller("Expires", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*DeletionConfirmationEntity) Table() string {
+	return tblDeletionConfirmations
+}
+
+func (c *DeletionConfirmationEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherConfirmation, ok := other.(*DeletionConfirmationEntity)
+	if !ok {
+		return false
+	}
+	return c.Token == otherConfirmation.Token &&
+		c.RuntimeID == otherConfirmation.RuntimeID &&
+		c.Expires.Equal(otherConfirmation.Expires)
+}
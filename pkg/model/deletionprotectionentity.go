@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblDeletionProtections string = "inventory_deletion_protections"
+
+// DeletionProtectionEntity marks a cluster as protected: deletion requests for it are rejected
+// until the protection gets removed
+type DeletionProtectionEntity struct {
+	RuntimeID string    `db:"notNull"`
+	Created   time.Time `db:"readOnly"`
+}
+
+func (p *DeletionProtectionEntity) String() string {
+	return fmt.Sprintf("DeletionProtectionEntity [RuntimeID=%s]", p.RuntimeID)
+}
+
+func (*DeletionProtectionEntity) New() db.DatabaseEntity {
+	return &DeletionProtectionEntity{}
+}
+
+func (p *DeletionProtectionEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&p)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*DeletionProtectionEntity) Table() string {
+	return tblDeletionProtections
+}
+
+func (p *DeletionProtectionEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherProtection, ok := other.(*DeletionProtectionEntity)
+	if !ok {
+		return false
+	}
+	return p.RuntimeID == otherProtection.RuntimeID
+}
@@ -0,0 +1,49 @@
+package model
+
+import "github.com/kyma-incubator/reconciler/pkg/db"
+
+// EncryptedColumns returns all columns whose values are encrypted (fields tagged with 'encrypt'): they have to be
+// re-encrypted when the encryption key gets rotated.
+func EncryptedColumns() []*db.EncryptedColumn {
+	return []*db.EncryptedColumn{
+		{
+			Entity:    &ClusterEntity{},
+			Field:     ClusterEntityFields.Kubeconfig,
+			KeyFields: []string{ClusterEntityFields.Version},
+		},
+		{
+			Entity:    &ClusterConfigurationEntity{},
+			Field:     ClusterConfigurationEntityFields.Components,
+			KeyFields: []string{ClusterConfigurationEntityFields.Version},
+		},
+		{
+			Entity:    &ClusterChangeEntity{},
+			Field:     ClusterChangeEntityFields.Diff,
+			KeyFields: []string{ClusterChangeEntityFields.ID},
+		},
+		{
+			Entity: &OperationDebugBundleEntity{},
+			Field:  OperationDebugBundleEntityFields.Bundle,
+			KeyFields: []string{
+				OperationDebugBundleEntityFields.SchedulingID,
+				OperationDebugBundleEntityFields.CorrelationID,
+			},
+		},
+		{
+			Entity: &OperationPayloadEntity{},
+			Field:  OperationPayloadEntityFields.Payload,
+			KeyFields: []string{
+				OperationPayloadEntityFields.SchedulingID,
+				OperationPayloadEntityFields.CorrelationID,
+			},
+		},
+		{
+			Entity: &OperationFailureCaptureEntity{},
+			Field:  OperationFailureCaptureEntityFields.Capture,
+			KeyFields: []string{
+				OperationFailureCaptureEntityFields.SchedulingID,
+				OperationFailureCaptureEntityFields.CorrelationID,
+			},
+		},
+	}
+}
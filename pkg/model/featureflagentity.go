@@ -0,0 +1,70 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblFeatureFlags string = "inventory_feature_flags"
+
+// FeatureFlagEntity gates a reconciler behavior: the flag is enabled for the listed clusters and for the given
+// percentage of all other clusters. Disabled clusters take precedence.
+type FeatureFlagEntity struct {
+	Name              string    `db:"notNull"`
+	Description       string    `db:"notNull"`
+	RolloutPercentage int64     `db:"notNull"`
+	EnabledClusters   []string  `db:"notNull"`
+	DisabledClusters  []string  `db:"notNull"`
+	Created           time.Time `db:"readOnly"`
+}
+
+func (f *FeatureFlagEntity) String() string {
+	return fmt.Sprintf("FeatureFlagEntity [Name=%s,RolloutPercentage=%d,EnabledClusters=%d,DisabledClusters=%d]",
+		f.Name, f.RolloutPercentage, len(f.EnabledClusters), len(f.DisabledClusters))
+}
+
+func (*FeatureFlagEntity) New() db.DatabaseEntity {
+	return &FeatureFlagEntity{}
+}
+
+func (f *FeatureFlagEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&f)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	marshaller.AddUnmarshaller("EnabledClusters", convertJSONStringToStrings)
+	marshaller.AddUnmarshaller("DisabledClusters", convertJSONStringToStrings)
+	marshaller.AddMarshaller("EnabledClusters", convertStringsToJSONString)
+	marshaller.AddMarshaller("DisabledClusters", convertStringsToJSONString)
+	return marshaller
+}
+
+func (*FeatureFlagEntity) Table() string {
+	return tblFeatureFlags
+}
+
+func (f *FeatureFlagEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherFlag, ok := other.(*FeatureFlagEntity)
+	if !ok {
+		return false
+	}
+	return f.Name == otherFlag.Name
+}
+
+func convertJSONStringToStrings(value interface{}) (interface{}, error) {
+	var result []string
+	err := json.Unmarshal([]byte(fmt.Sprintf("%s", value)), &result)
+	return result, err
+}
+
+// convertStringsToJSONString stores nil slices as empty JSON arrays
+func convertStringsToJSONString(value interface{}) (interface{}, error) {
+	if values, ok := value.([]string); ok && values == nil {
+		return "[]", nil
+	}
+	return convertInterfaceToJSONString(value)
+}
@@ -0,0 +1,3 @@
+package model
+
+//go:generate go run ../../cmd/generators/entity-fields -output fields_gen.go
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblOperationDebugBundle string = "scheduler_operation_debug_bundles"
+
+// OperationDebugBundleEntity stores the debug bundle (gzipped tarball) a component reconciler
+// captured while processing an operation which was flagged as "debug".
+type OperationDebugBundleEntity struct {
+	SchedulingID  string    `db:"notNull"`
+	CorrelationID string    `db:"notNull"`
+	Bundle        string    `db:"notNull,encrypt"` //base64 encoded
+	Created       time.Time `db:"readOnly"`
+}
+
+func NewOperationDebugBundleEntity(schedulingID, correlationID string, bundle []byte) *OperationDebugBundleEntity {
+	return &OperationDebugBundleEntity{
+		SchedulingID:  schedulingID,
+		CorrelationID: correlationID,
+		Bundle:        base64.StdEncoding.EncodeToString(bundle),
+	}
+}
+
+// Data returns the decoded bundle
+func (o *OperationDebugBundleEntity) Data() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(o.Bundle)
+}
+
+func (o *OperationDebugBundleEntity) String() string {
+	return fmt.Sprintf("OperationDebugBundleEntity [SchedulingID=%s,CorrelationID=%s]",
+		o.SchedulingID, o.CorrelationID)
+}
+
+func (*OperationDebugBundleEntity) New() db.DatabaseEntity {
+	return &OperationDebugBundleEntity{}
+}
+
+func (o *OperationDebugBundleEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&o)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*OperationDebugBundleEntity) Table() string {
+	return tblOperationDebugBundle
+}
+
+func (o *OperationDebugBundleEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherBundle, ok := other.(*OperationDebugBundleEntity)
+	if !ok {
+		return false
+	}
+	return o.SchedulingID == otherBundle.SchedulingID &&
+		o.CorrelationID == otherBundle.CorrelationID
+}
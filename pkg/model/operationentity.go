@@ -26,6 +26,8 @@ type OperationEntity struct {
 	Retries            int64          `db:""`
 	RetryID            string         `db:"notNull"`
 	Debug              bool           `db:"notNull"`
+	Bootstrap          bool           `db:"notNull"` //component belongs to the bootstrap phase of the reconciliation
+	ClusterSize        int64          `db:"notNull"` //amount of components of the cluster configuration
 }
 
 func (o *OperationEntity) String() string {
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblOperationFailureCapture string = "scheduler_operation_failure_captures"
+
+// OperationFailureCaptureEntity stores what a component reconciler captured when an operation failed: the payload
+// of the operation and the values it resolved for the component chart (kubeconfig and secrets are redacted). It
+// allows reproducing the rendering of the chart without the original caller.
+type OperationFailureCaptureEntity struct {
+	SchedulingID  string    `db:"notNull"`
+	CorrelationID string    `db:"notNull"`
+	Capture       string    `db:"notNull,encrypt"` //JSON document uploaded by the component reconciler
+	Created       time.Time `db:"readOnly"`
+}
+
+func (o *OperationFailureCaptureEntity) String() string {
+	return fmt.Sprintf("OperationFailureCaptureEntity [SchedulingID=%s,CorrelationID=%s]",
+		o.SchedulingID, o.CorrelationID)
+}
+
+func (*OperationFailureCaptureEntity) New() db.DatabaseEntity {
+	return &OperationFailureCaptureEntity{}
+}
+
+func (o *OperationFailureCaptureEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&o)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*OperationFailureCaptureEntity) Table() string {
+	return tblOperationFailureCapture
+}
+
+func (o *OperationFailureCaptureEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherCapture, ok := other.(*OperationFailureCaptureEntity)
+	if !ok {
+		return false
+	}
+	return o.SchedulingID == otherCapture.SchedulingID &&
+		o.CorrelationID == otherCapture.CorrelationID
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblOperationPayload string = "scheduler_operation_payloads"
+
+// OperationPayloadEntity stores the payload the mothership sent to a component reconciler when it invoked an
+// operation. It's used to replay the operation when a failure has to be reproduced.
+type OperationPayloadEntity struct {
+	SchedulingID    string    `db:"notNull"`
+	CorrelationID   string    `db:"notNull"`
+	URL             string    `db:"notNull"`         //run endpoint of the component reconciler which received the payload
+	ContractVersion int64     `db:"notNull"`         //contract version of the run endpoint (defines the format of the payload)
+	Payload         string    `db:"notNull,encrypt"` //JSON payload (includes the kubeconfig)
+	Created         time.Time `db:"readOnly"`
+}
+
+func (o *OperationPayloadEntity) String() string {
+	return fmt.Sprintf("OperationPayloadEntity [SchedulingID=%s,CorrelationID=%s,URL=%s,ContractVersion=%d]",
+		o.SchedulingID, o.CorrelationID, o.URL, o.ContractVersion)
+}
+
+func (*OperationPayloadEntity) New() db.DatabaseEntity {
+	return &OperationPayloadEntity{}
+}
+
+func (o *OperationPayloadEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&o)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*OperationPayloadEntity) Table() string {
+	return tblOperationPayload
+}
+
+func (o *OperationPayloadEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherPayload, ok := other.(*OperationPayloadEntity)
+	if !ok {
+		return false
+	}
+	return o.SchedulingID == otherPayload.SchedulingID &&
+		o.CorrelationID == otherPayload.CorrelationID
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"fmt"
+)
+
+// OperationPhase is a milestone an operation passes while it gets processed.
+// Phases are listed in the order they are passed.
+type OperationPhase string
+
+const (
+	OperationPhaseQueued         OperationPhase = "queued"
+	OperationPhasePickedUp       OperationPhase = "pickedUp"
+	OperationPhaseWorkspaceReady OperationPhase = "workspaceReady"
+	OperationPhaseRendered       OperationPhase = "rendered"
+	OperationPhaseApplied        OperationPhase = "applied"
+	OperationPhaseReady          OperationPhase = "ready"
+	OperationPhaseCallbackSent   OperationPhase = "callbackSent"
+)
+
+// OperationPhases returns all phases in the order an operation passes them
+func OperationPhases() []OperationPhase {
+	return []OperationPhase{
+		OperationPhaseQueued,
+		OperationPhasePickedUp,
+		OperationPhaseWorkspaceReady,
+		OperationPhaseRendered,
+		OperationPhaseApplied,
+		OperationPhaseReady,
+		OperationPhaseCallbackSent,
+	}
+}
+
+func NewOperationPhase(phase string) (OperationPhase, error) {
+	for _, operationPhase := range OperationPhases() {
+		if string(operationPhase) == phase {
+			return operationPhase, nil
+		}
+	}
+	return "", fmt.Errorf("operation phase '%s' does not exist", phase)
+}
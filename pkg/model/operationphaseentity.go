@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblOperationPhase string = "scheduler_operation_phases"
+
+// OperationPhaseEntity stores when an operation reached a particular phase
+type OperationPhaseEntity struct {
+	SchedulingID  string         `db:"notNull"`
+	CorrelationID string         `db:"notNull"`
+	Phase         OperationPhase `db:"notNull"`
+	Reached       time.Time      `db:"notNull"`
+}
+
+func (o *OperationPhaseEntity) String() string {
+	return fmt.Sprintf("OperationPhaseEntity [SchedulingID=%s,CorrelationID=%s,Phase=%s,Reached=%s]",
+		o.SchedulingID, o.CorrelationID, o.Phase, o.Reached)
+}
+
+func (*OperationPhaseEntity) New() db.DatabaseEntity {
+	return &OperationPhaseEntity{}
+}
+
+func (o *OperationPhaseEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&o)
+	marshaller.AddMarshaller("Phase", func(value interface{}) (interface{}, error) {
+		return fmt.Sprintf("%s", value), nil
+	})
+	marshaller.AddUnmarshaller("Phase", func(value interface{}) (interface{}, error) {
+		return NewOperationPhase(fmt.Sprintf("%s", value))
+	})
+	marshaller.AddUnmarshaller("Reached", convertTimestampToTime)
+	return marshaller
+}
+
+func (*OperationPhaseEntity) Table() string {
+	return tblOperationPhase
+}
+
+func (o *OperationPhaseEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherPhase, ok := other.(*OperationPhaseEntity)
+	if !ok {
+		return false
+	}
+	return o.SchedulingID == otherPhase.SchedulingID &&
+		o.CorrelationID == otherPhase.CorrelationID &&
+		o.Phase == otherPhase.Phase &&
+		o.Reached.Equal(otherPhase.Reached)
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblOperationResource string = "scheduler_operation_resources"
+
+// OperationResourceEntity stores what the reconciliation of an operation did with a resource
+type OperationResourceEntity struct {
+	SchedulingID  string `db:"notNull"`
+	CorrelationID string `db:"notNull"`
+	APIVersion    string `db:"notNull"`
+	Kind          string `db:"notNull"`
+	Namespace     string `db:""`
+	Name          string `db:"notNull"`
+	Outcome       string `db:"notNull"`
+	Error         string `db:""`
+}
+
+func (o *OperationResourceEntity) String() string {
+	return fmt.Sprintf("OperationResourceEntity [SchedulingID=%s,CorrelationID=%s,APIVersion=%s,Kind=%s,Namespace=%s,Name=%s,Outcome=%s]",
+		o.SchedulingID, o.CorrelationID, o.APIVersion, o.Kind, o.Namespace, o.Name, o.Outcome)
+}
+
+func (*OperationResourceEntity) New() db.DatabaseEntity {
+	return &OperationResourceEntity{}
+}
+
+func (o *OperationResourceEntity) Marshaller() *db.EntityMarshaller {
+	return db.NewEntityMarshaller(&o)
+}
+
+func (*OperationResourceEntity) Table() string {
+	return tblOperationResource
+}
+
+func (o *OperationResourceEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherResource, ok := other.(*OperationResourceEntity)
+	if !ok {
+		return false
+	}
+	return o.SchedulingID == otherResource.SchedulingID &&
+		o.CorrelationID == otherResource.CorrelationID &&
+		o.APIVersion == otherResource.APIVersion &&
+		o.Kind == otherResource.Kind &&
+		o.Namespace == otherResource.Namespace &&
+		o.Name == otherResource.Name &&
+		o.Outcome == otherResource.Outcome &&
+		o.Error == otherResource.Error
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblOperationSmokeTest string = "scheduler_operation_smoke_tests"
+
+// OperationSmokeTestEntity stores the result of a smoke test which verified the component of an operation
+type OperationSmokeTestEntity struct {
+	SchedulingID  string `db:"notNull"`
+	CorrelationID string `db:"notNull"`
+	Name          string `db:"notNull"`
+	Passed        bool   `db:"notNull"`
+	Error         string `db:""`
+	Duration      int64  `db:""` //not validated: fast smoke tests take 0 milliseconds
+}
+
+func (o *OperationSmokeTestEntity) String() string {
+	return fmt.Sprintf("OperationSmokeTestEntity [SchedulingID=%s,CorrelationID=%s,Name=%s,Passed=%t]",
+		o.SchedulingID, o.CorrelationID, o.Name, o.Passed)
+}
+
+func (*OperationSmokeTestEntity) New() db.DatabaseEntity {
+	return &OperationSmokeTestEntity{}
+}
+
+func (o *OperationSmokeTestEntity) Marshaller() *db.EntityMarshaller {
+	return db.NewEntityMarshaller(&o)
+}
+
+func (*OperationSmokeTestEntity) Table() string {
+	return tblOperationSmokeTest
+}
+
+func (o *OperationSmokeTestEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherSmokeTest, ok := other.(*OperationSmokeTestEntity)
+	if !ok {
+		return false
+	}
+	return o.SchedulingID == otherSmokeTest.SchedulingID &&
+		o.CorrelationID == otherSmokeTest.CorrelationID &&
+		o.Name == otherSmokeTest.Name &&
+		o.Passed == otherSmokeTest.Passed &&
+		o.Error == otherSmokeTest.Error &&
+		o.Duration == otherSmokeTest.Duration
+}
@@ -15,6 +15,8 @@ const (
 	OperationStateError       OperationState = "error"
 	OperationStateFailed      OperationState = "failed"
 	OperationStateOrphan      OperationState = "orphan"
+	//OperationStateVerificationFailed indicates that the component was applied but its smoke tests failed
+	OperationStateVerificationFailed OperationState = "verification_failed"
 )
 
 func NewOperationState(state string) (OperationState, error) {
@@ -34,6 +36,8 @@ func NewOperationState(state string) (OperationState, error) {
 		result = OperationStateFailed
 	case string(OperationStateOrphan):
 		result = OperationStateOrphan
+	case string(OperationStateVerificationFailed):
+		result = OperationStateVerificationFailed
 	default:
 		return "", fmt.Errorf("operation state '%s' does not exist", state)
 	}
@@ -41,11 +45,12 @@ func NewOperationState(state string) (OperationState, error) {
 }
 
 func (o OperationState) IsError() bool {
-	return o == OperationStateError || o == OperationStateFailed || o == OperationStateClientError
+	return o == OperationStateError || o == OperationStateFailed || o == OperationStateClientError ||
+		o == OperationStateVerificationFailed
 }
 
 func (o OperationState) IsFinal() bool {
-	return o == OperationStateError || o == OperationStateDone
+	return o == OperationStateError || o == OperationStateDone || o == OperationStateVerificationFailed
 }
 
 func (o OperationState) IsTemporary() bool {
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const (
+	tblOrphanedOperations string = "v_scheduler_orphaned_operations"
+	tblOrphanedConfigs    string = "v_inventory_orphaned_configs"
+)
+
+// OrphanedOperationEntity lists an operation whose reconciliation doesn't exist anymore
+type OrphanedOperationEntity struct {
+	SchedulingID  string    `db:"notNull"`
+	CorrelationID string    `db:"notNull"`
+	RuntimeID     string    `db:"notNull"`
+	Created       time.Time `db:"readOnly"`
+}
+
+func (ooe *OrphanedOperationEntity) String() string {
+	return fmt.Sprintf("OrphanedOperationEntity [SchedulingID=%s,CorrelationID=%s,RuntimeID=%s]",
+		ooe.SchedulingID, ooe.CorrelationID, ooe.RuntimeID)
+}
+
+func (ooe *OrphanedOperationEntity) New() db.DatabaseEntity {
+	return &OrphanedOperationEntity{}
+}
+
+func (ooe *OrphanedOperationEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&ooe)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (ooe *OrphanedOperationEntity) Table() string {
+	return tblOrphanedOperations
+}
+
+func (ooe *OrphanedOperationEntity) Equal(other db.DatabaseEntity) bool {
+	otherOrphan, ok := other.(*OrphanedOperationEntity)
+	return ok && ooe.SchedulingID == otherOrphan.SchedulingID && ooe.CorrelationID == otherOrphan.CorrelationID
+}
+
+// OrphanedConfigEntity lists an outdated cluster configuration which isn't referenced by any status,
+// reconciliation or operation
+type OrphanedConfigEntity struct {
+	ConfigID  int64     `db:"notNull"`
+	RuntimeID string    `db:"notNull"`
+	ClusterID int64     `db:"notNull"`
+	Created   time.Time `db:"readOnly"`
+}
+
+func (oce *OrphanedConfigEntity) String() string {
+	return fmt.Sprintf("OrphanedConfigEntity [ConfigID=%d,RuntimeID=%s]", oce.ConfigID, oce.RuntimeID)
+}
+
+func (oce *OrphanedConfigEntity) New() db.DatabaseEntity {
+	return &OrphanedConfigEntity{}
+}
+
+func (oce *OrphanedConfigEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&oce)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (oce *OrphanedConfigEntity) Table() string {
+	return tblOrphanedConfigs
+}
+
+func (oce *OrphanedConfigEntity) Equal(other db.DatabaseEntity) bool {
+	otherOrphan, ok := other.(*OrphanedConfigEntity)
+	return ok && oce.ConfigID == otherOrphan.ConfigID
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblTaskQueue string = "reconciler_task_queue"
+
+// QueuedTaskEntity is a pending operation of a component reconciler. The replica which processes the operation
+// holds a lease on it: the operation is picked up by another replica if the lease expires before the operation
+// was completed.
+type QueuedTaskEntity struct {
+	ID            string    `db:"notNull"`
+	Reconciler    string    `db:"notNull"`
+	Component     string    `db:"notNull"`
+	CorrelationID string    `db:"notNull"`
+	RuntimeID     string    `db:""`
+	Priority      string    `db:"notNull"`
+	Task          string    `db:"notNull,encrypt"` //JSON payload of the task (includes the kubeconfig)
+	LeaseOwner    string    `db:""`
+	LeaseExpiry   time.Time `db:"notNull"`
+	Attempts      int64     `db:""`
+	Created       time.Time `db:"readOnly"`
+}
+
+func (q *QueuedTaskEntity) String() string {
+	return fmt.Sprintf("QueuedTaskEntity [ID=%s,Reconciler=%s,Component=%s,CorrelationID=%s,LeaseOwner=%s,Attempts=%d]",
+		q.ID, q.Reconciler, q.Component, q.CorrelationID, q.LeaseOwner, q.Attempts)
+}
+
+func (*QueuedTaskEntity) New() db.DatabaseEntity {
+	return &QueuedTaskEntity{}
+}
+
+func (q *QueuedTaskEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&q)
+	marshaller.AddUnmarshaller("LeaseExpiry", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*QueuedTaskEntity) Table() string {
+	return tblTaskQueue
+}
+
+func (q *QueuedTaskEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherTask, ok := other.(*QueuedTaskEntity)
+	if !ok {
+		return false
+	}
+	return q.ID == otherTask.ID
+}
+
+// Leased returns true if a replica holds an unexpired lease on the task
+func (q *QueuedTaskEntity) Leased(now time.Time) bool {
+	return q.LeaseOwner != "" && q.LeaseExpiry.After(now)
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblReconcileIntervals string = "inventory_reconcile_intervals"
+
+// ReconcileIntervalEntity overrides the global reconcile interval. An empty RuntimeID applies the override to all
+// clusters, an empty Component applies it to the whole cluster.
+type ReconcileIntervalEntity struct {
+	RuntimeID string    `db:""`
+	Component string    `db:""`
+	Seconds   int64     `db:"notNull"`
+	Created   time.Time `db:"readOnly"`
+}
+
+func (r *ReconcileIntervalEntity) String() string {
+	return fmt.Sprintf("ReconcileIntervalEntity [RuntimeID=%s,Component=%s,Seconds=%d]",
+		r.RuntimeID, r.Component, r.Seconds)
+}
+
+func (*ReconcileIntervalEntity) New() db.DatabaseEntity {
+	return &ReconcileIntervalEntity{}
+}
+
+func (r *ReconcileIntervalEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&r)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*ReconcileIntervalEntity) Table() string {
+	return tblReconcileIntervals
+}
+
+func (r *ReconcileIntervalEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherInterval, ok := other.(*ReconcileIntervalEntity)
+	if !ok {
+		return false
+	}
+	return r.RuntimeID == otherInterval.RuntimeID &&
+		r.Component == otherInterval.Component &&
+		r.Seconds == otherInterval.Seconds
+}
+
+func (r *ReconcileIntervalEntity) Duration() time.Duration {
+	return time.Duration(r.Seconds) * time.Second
+}
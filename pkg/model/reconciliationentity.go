@@ -19,6 +19,8 @@ type ReconciliationEntity struct {
 	Created             time.Time `db:"readOnly"`
 	Updated             time.Time `db:""`
 	Status              Status    `db:"notNull"`
+	Initiator           Initiator `db:"notNull"`
+	Reason              string    `db:"notNull"`
 }
 
 func (r *ReconciliationEntity) String() string {
@@ -35,6 +37,7 @@ func (r *ReconciliationEntity) Marshaller() *db.EntityMarshaller {
 	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
 	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
 	marshaller.AddUnmarshaller("Status", convertStringToStatus)
+	marshaller.AddUnmarshaller("Initiator", convertStringToInitiator)
 	return marshaller
 }
 
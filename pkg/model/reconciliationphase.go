@@ -0,0 +1,32 @@
+package model
+
+// ReconciliationPhase indicates whether a reconciliation is still bootstrapping the cluster essentials
+type ReconciliationPhase string
+
+const (
+	//ReconciliationPhaseBootstrap: bootstrap components are processed, all other components are not scheduled yet
+	ReconciliationPhaseBootstrap ReconciliationPhase = "bootstrap"
+	//ReconciliationPhaseBootstrapFailed: a bootstrap component failed and blocks all other components
+	ReconciliationPhaseBootstrapFailed ReconciliationPhase = "bootstrap_failed"
+	//ReconciliationPhaseComponents: bootstrapping is finished (or not required) and the components are processed
+	ReconciliationPhaseComponents ReconciliationPhase = "components"
+)
+
+// NewReconciliationPhase evaluates the phase of a reconciliation by the states of its operations
+func NewReconciliationPhase(ops []*OperationEntity) ReconciliationPhase {
+	result := ReconciliationPhaseComponents
+	for _, op := range ops {
+		if !op.Bootstrap {
+			continue
+		}
+		switch op.State {
+		case OperationStateDone:
+			continue
+		case OperationStateError, OperationStateVerificationFailed:
+			return ReconciliationPhaseBootstrapFailed
+		default:
+			result = ReconciliationPhaseBootstrap
+		}
+	}
+	return result
+}
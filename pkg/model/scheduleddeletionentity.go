@@ -0,0 +1,50 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblScheduledDeletions string = "inventory_scheduled_deletions"
+
+// ScheduledDeletionEntity marks a cluster as soft-deleted: its desired state is retained and the teardown
+// is only triggered when the TeardownAfter timestamp (UTC) is reached. Until then the deletion can be reverted.
+type ScheduledDeletionEntity struct {
+	RuntimeID     string    `db:"notNull"`
+	TeardownAfter time.Time `db:"notNull"`
+	Created       time.Time `db:"readOnly"`
+}
+
+func (s *ScheduledDeletionEntity) String() string {
+	return fmt.Sprintf("ScheduledDeletionEntity [RuntimeID=%s,TeardownAfter=%s]",
+		s.RuntimeID, s.TeardownAfter.Format(time.RFC3339))
+}
+
+func (*ScheduledDeletionEntity) New() db.DatabaseEntity {
+	return &ScheduledDeletionEntity{}
+}
+
+func (s *ScheduledDeletionEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&s)
+	marshaller.AddUnmarshaller("TeardownAfter", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*ScheduledDeletionEntity) Table() string {
+	return tblScheduledDeletions
+}
+
+func (s *ScheduledDeletionEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherDeletion, ok := other.(*ScheduledDeletionEntity)
+	if !ok {
+		return false
+	}
+	return s.RuntimeID == otherDeletion.RuntimeID &&
+		s.TeardownAfter.Equal(otherDeletion.TeardownAfter)
+}
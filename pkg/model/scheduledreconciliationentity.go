@@ -0,0 +1,52 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblScheduledReconciliations string = "inventory_scheduled_reconciliations"
+
+// ScheduledReconciliationEntity is a one-off reconciliation of a cluster which is triggered as soon as
+// the NotBefore timestamp (UTC) is reached
+type ScheduledReconciliationEntity struct {
+	ID        string    `db:"notNull"`
+	RuntimeID string    `db:"notNull"`
+	NotBefore time.Time `db:"notNull"`
+	Created   time.Time `db:"readOnly"`
+}
+
+func (s *ScheduledReconciliationEntity) String() string {
+	return fmt.Sprintf("ScheduledReconciliationEntity [ID=%s,RuntimeID=%s,NotBefore=%s]",
+		s.ID, s.RuntimeID, s.NotBefore.Format(time.RFC3339))
+}
+
+func (*ScheduledReconciliationEntity) New() db.DatabaseEntity {
+	return &ScheduledReconciliationEntity{}
+}
+
+func (s *ScheduledReconciliationEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&s)
+	marshaller.AddUnmarshaller("NotBefore", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*ScheduledReconciliationEntity) Table() string {
+	return tblScheduledReconciliations
+}
+
+func (s *ScheduledReconciliationEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherSchedule, ok := other.(*ScheduledReconciliationEntity)
+	if !ok {
+		return false
+	}
+	return s.ID == otherSchedule.ID &&
+		s.RuntimeID == otherSchedule.RuntimeID &&
+		s.NotBefore.Equal(otherSchedule.NotBefore)
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblSchedulingFreezes string = "inventory_scheduling_freezes"
+
+// SchedulingFreezeEntity stops the scheduling of new operations until the freeze gets released. An empty Component
+// freezes all components (fleet-wide emergency stop). Released freezes are kept as audit trail.
+type SchedulingFreezeEntity struct {
+	ID         int64     `db:"readOnly"`
+	Component  string    `db:""`
+	Reason     string    `db:"notNull"`
+	EngagedBy  string    `db:"notNull"`
+	Released   bool      `db:"notNull"`
+	ReleasedBy string    `db:""`
+	Updated    time.Time `db:""`
+	Created    time.Time `db:"readOnly"`
+}
+
+func (f *SchedulingFreezeEntity) String() string {
+	return fmt.Sprintf("SchedulingFreezeEntity [ID=%d,Component=%s,EngagedBy=%s,Released=%t]",
+		f.ID, f.Component, f.EngagedBy, f.Released)
+}
+
+func (*SchedulingFreezeEntity) New() db.DatabaseEntity {
+	return &SchedulingFreezeEntity{}
+}
+
+func (f *SchedulingFreezeEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&f)
+	marshaller.AddUnmarshaller("Updated", convertTimestampToTime)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*SchedulingFreezeEntity) Table() string {
+	return tblSchedulingFreezes
+}
+
+func (f *SchedulingFreezeEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherFreeze, ok := other.(*SchedulingFreezeEntity)
+	if !ok {
+		return false
+	}
+	return f.Component == otherFreeze.Component &&
+		f.Reason == otherFreeze.Reason &&
+		f.EngagedBy == otherFreeze.EngagedBy &&
+		f.Released == otherFreeze.Released
+}
+
+// IsFleetWide returns true if the freeze stops the scheduling of all components
+func (f *SchedulingFreezeEntity) IsFleetWide() bool {
+	return f.Component == ""
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblStatusBadges string = "inventory_status_badges"
+
+// StatusBadgeEntity grants access to the status badge of a cluster to everyone who knows the token: only the
+// SHA-256 hash (HEX encoded) of the token is stored
+type StatusBadgeEntity struct {
+	RuntimeID string    `db:"notNull"`
+	TokenHash string    `db:"notNull"`
+	Created   time.Time `db:"readOnly"`
+}
+
+func (b *StatusBadgeEntity) String() string {
+	return fmt.Sprintf("StatusBadgeEntity [RuntimeID=%s]", b.RuntimeID)
+}
+
+func (*StatusBadgeEntity) New() db.DatabaseEntity {
+	return &StatusBadgeEntity{}
+}
+
+func (b *StatusBadgeEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&b)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*StatusBadgeEntity) Table() string {
+	return tblStatusBadges
+}
+
+func (b *StatusBadgeEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherBadge, ok := other.(*StatusBadgeEntity)
+	if !ok {
+		return false
+	}
+	return b.RuntimeID == otherBadge.RuntimeID && b.TokenHash == otherBadge.TokenHash
+}
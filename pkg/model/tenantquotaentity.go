@@ -0,0 +1,51 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+)
+
+const tblTenantQuotas string = "inventory_tenant_quotas"
+
+// TenantQuotaEntity overrides the default operation limits of a tenant (global account). A limit of zero means
+// that the tenant isn't limited.
+type TenantQuotaEntity struct {
+	GlobalAccountID        string    `db:"notNull"`
+	MaxParallelOperations  int64     `db:"notNull"`
+	MaxOperationsPerMinute int64     `db:"notNull"`
+	Created                time.Time `db:"readOnly"`
+}
+
+func (t *TenantQuotaEntity) String() string {
+	return fmt.Sprintf("TenantQuotaEntity [GlobalAccountID=%s,MaxParallelOperations=%d,MaxOperationsPerMinute=%d]",
+		t.GlobalAccountID, t.MaxParallelOperations, t.MaxOperationsPerMinute)
+}
+
+func (*TenantQuotaEntity) New() db.DatabaseEntity {
+	return &TenantQuotaEntity{}
+}
+
+func (t *TenantQuotaEntity) Marshaller() *db.EntityMarshaller {
+	marshaller := db.NewEntityMarshaller(&t)
+	marshaller.AddUnmarshaller("Created", convertTimestampToTime)
+	return marshaller
+}
+
+func (*TenantQuotaEntity) Table() string {
+	return tblTenantQuotas
+}
+
+func (t *TenantQuotaEntity) Equal(other db.DatabaseEntity) bool {
+	if other == nil {
+		return false
+	}
+	otherQuota, ok := other.(*TenantQuotaEntity)
+	if !ok {
+		return false
+	}
+	return t.GlobalAccountID == otherQuota.GlobalAccountID &&
+		t.MaxParallelOperations == otherQuota.MaxParallelOperations &&
+		t.MaxOperationsPerMinute == otherQuota.MaxOperationsPerMinute
+}
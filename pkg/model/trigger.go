@@ -0,0 +1,35 @@
+package model
+
+// Initiator of a reconciliation
+type Initiator string
+
+const (
+	InitiatorScheduler Initiator = "scheduler" //reconcile interval elapsed, retries, scheduled reconciliations and deletions
+	InitiatorKEB       Initiator = "keb"       //anonymous requests are expected to be sent by KEB
+	InitiatorUser      Initiator = "user"      //requests sent by an authenticated user
+)
+
+// Reasons of reconciliations. The reason is a free text: requests of users or KEB can define their own reasons.
+const (
+	ReasonProvisioning   = "provisioning"
+	ReasonVersionRollout = "versionRollout"
+	ReasonConfigChange   = "configChange"
+	ReasonPeriodic       = "periodic"
+	ReasonRetry          = "retry"
+	ReasonManualRetry    = "manualRetry"
+	ReasonScheduled      = "scheduled"
+	ReasonDeletion       = "deletion"
+)
+
+// Trigger returns the initiator and the reason of the reconciliation which is started for a cluster having this
+// status. Pending statuses keep the trigger of the request which set them, all others are triggered by the scheduler.
+func (c *ClusterStatusEntity) Trigger() (Initiator, string) {
+	switch c.Status {
+	case ClusterStatusReconcilePending, ClusterStatusDeletePending:
+		return c.Initiator, c.Reason
+	case ClusterStatusReconcileErrorRetryable, ClusterStatusDeleteErrorRetryable:
+		return InitiatorScheduler, ReasonRetry
+	default:
+		return InitiatorScheduler, ReasonPeriodic
+	}
+}
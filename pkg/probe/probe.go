@@ -0,0 +1,294 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	k8s "github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"go.uber.org/zap"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+type Status string
+
+const (
+	StatusHealthy   Status = "healthy"
+	StatusUnhealthy Status = "unhealthy"
+	StatusUnknown   Status = "unknown" //the cluster couldn't be probed
+)
+
+var deploymentsResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+// ComponentHealth is the result of the health checks of a component
+type ComponentHealth struct {
+	Component string
+	Status    Status
+	Message   string //describes the failed check (empty if the component is healthy)
+	Probed    time.Time
+}
+
+type Config struct {
+	Interval time.Duration
+	Timeout  time.Duration //maximal time the checks of a cluster are allowed to take
+	Workers  int           //clusters which are probed in parallel
+	Checks   map[string]config.ComponentHealthCheck
+}
+
+// ClientFactory creates the client used to probe a cluster
+type ClientFactory func(kubeconfig string, timeout time.Duration) (dynamic.Interface, error)
+
+// Prober verifies the health of the components of all clusters in an interval. It only reads the resources defined
+// by the health checks of the components, which keeps it lightweight compared to a reconciliation.
+type Prober struct {
+	inventory     cluster.Inventory
+	config        *Config
+	clientFactory ClientFactory
+	results       map[string][]*ComponentHealth //key: runtime ID
+	listeners     []Listener
+	mu            sync.RWMutex
+	logger        *zap.SugaredLogger
+}
+
+func NewProber(inventory cluster.Inventory, cfg *Config, logger *zap.SugaredLogger, listeners ...Listener) *Prober {
+	return &Prober{
+		inventory:     inventory,
+		config:        cfg,
+		clientFactory: newDynamicClient,
+		results:       make(map[string][]*ComponentHealth),
+		listeners:     listeners,
+		logger:        logger,
+	}
+}
+
+func newDynamicClient(kubeconfig string, timeout time.Duration) (dynamic.Interface, error) {
+	restConfig, err := k8s.NewRESTConfig([]byte(kubeconfig))
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = timeout
+	return dynamic.NewForConfig(restConfig)
+}
+
+func (p *Prober) Run(ctx context.Context) error {
+	if len(p.config.Checks) == 0 {
+		p.logger.Info("Status probe is not started: no component defines health checks")
+		return nil
+	}
+	p.logger.Infof("Starting status probe with an interval of %.0f secs (components with health checks: %d)",
+		p.config.Interval.Seconds(), len(p.config.Checks))
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		if err := p.ProbeAll(ctx); err != nil {
+			p.logger.Warnf("Status probe failed: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Health returns the health of the components of the cluster which were checked by the latest probe (nil if the
+// cluster wasn't probed yet)
+func (p *Prober) Health(runtimeID string) []*ComponentHealth {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.results[runtimeID]
+}
+
+// ProbeAll checks the components of all clusters which are not deleted
+func (p *Prober) ProbeAll(ctx context.Context) error {
+	states, err := p.inventory.GetAll()
+	if err != nil {
+		return err
+	}
+
+	workers := p.config.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	queue := make(chan *cluster.State)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for state := range queue {
+				health := p.probeCluster(ctx, state)
+				p.mu.Lock()
+				p.results[state.Cluster.RuntimeID] = health
+				p.mu.Unlock()
+				for _, listener := range p.listeners {
+					listener.Probed(state, health)
+				}
+			}
+		}()
+	}
+
+	probed := make(map[string]bool, len(states))
+	for _, state := range states {
+		if !probeable(state) {
+			continue
+		}
+		probed[state.Cluster.RuntimeID] = true
+		select {
+		case queue <- state:
+		case <-ctx.Done():
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	//forget clusters which got deleted
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for runtimeID := range p.results {
+		if !probed[runtimeID] {
+			delete(p.results, runtimeID)
+		}
+	}
+	return nil
+}
+
+func probeable(state *cluster.State) bool {
+	if state.Cluster == nil || state.Configuration == nil || state.Status == nil {
+		return false
+	}
+	switch state.Status.Status {
+	case model.ClusterStatusDeletePending, model.ClusterStatusDeleting, model.ClusterStatusDeleteError,
+		model.ClusterStatusDeleteErrorRetryable, model.ClusterStatusDeleted:
+		return false
+	}
+	return true
+}
+
+func (p *Prober) probeCluster(ctx context.Context, state *cluster.State) []*ComponentHealth {
+	var components []string
+	for _, component := range state.Configuration.Components {
+		if _, ok := p.config.Checks[component.Component]; ok {
+			components = append(components, component.Component)
+		}
+	}
+	if len(components) == 0 {
+		return nil
+	}
+	sort.Strings(components)
+
+	now := time.Now().UTC()
+	client, err := p.clientFactory(state.Cluster.Kubeconfig, p.config.Timeout)
+	if err != nil {
+		p.logger.Warnf("Failed to create client to probe cluster '%s': %s", state.Cluster.RuntimeID, err)
+	}
+
+	result := make([]*ComponentHealth, 0, len(components))
+	for _, component := range components {
+		health := &ComponentHealth{Component: component, Status: StatusUnknown, Probed: now}
+		if err != nil {
+			health.Message = fmt.Sprintf("failed to connect to cluster: %s", err)
+		} else {
+			health.Status, health.Message = check(ctx, client, p.config.Checks[component])
+		}
+		result = append(result, health)
+	}
+	return result
+}
+
+// check verifies all resources of the health check and reports the first one which isn't healthy
+func check(ctx context.Context, client dynamic.Interface, healthCheck config.ComponentHealthCheck) (Status, string) {
+	for _, deployment := range healthCheck.Deployments {
+		obj, err := client.Resource(deploymentsResource).Namespace(deployment.Namespace).
+			Get(ctx, deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return failureStatus(err), fmt.Sprintf("failed to get deployment '%s/%s': %s",
+				deployment.Namespace, deployment.Name, err)
+		}
+		if status, ok := condition(obj, "Available"); !ok || status != "True" {
+			return StatusUnhealthy, fmt.Sprintf("deployment '%s/%s' is not available%s",
+				deployment.Namespace, deployment.Name, conditionMessage(obj, "Available"))
+		}
+	}
+	for _, cr := range healthCheck.CustomResources {
+		gvr := schema.GroupVersionResource{Group: cr.Group, Version: cr.Version, Resource: cr.Resource}
+		obj, err := client.Resource(gvr).Namespace(cr.Namespace).Get(ctx, cr.Name, metav1.GetOptions{})
+		if err != nil {
+			return failureStatus(err), fmt.Sprintf("failed to get %s '%s': %s", cr.Resource, crName(cr), err)
+		}
+		if cr.Condition != "" {
+			status, ok := condition(obj, cr.Condition)
+			if !ok || status != "True" {
+				return StatusUnhealthy, fmt.Sprintf("condition '%s' of %s '%s' is not 'True'%s",
+					cr.Condition, cr.Resource, crName(cr), conditionMessage(obj, cr.Condition))
+			}
+			continue
+		}
+		if state, _, _ := unstructured.NestedString(obj.Object, "status", "state"); !strings.EqualFold(state, cr.State) {
+			return StatusUnhealthy, fmt.Sprintf("state of %s '%s' is '%s' but '%s' is expected",
+				cr.Resource, crName(cr), state, cr.State)
+		}
+	}
+	return StatusHealthy, ""
+}
+
+// failureStatus distinguishes missing resources (the component is unhealthy) from failed requests (the health
+// can't be determined)
+func failureStatus(err error) Status {
+	if k8serr.IsNotFound(err) {
+		return StatusUnhealthy
+	}
+	return StatusUnknown
+}
+
+func crName(cr config.CustomResourceCheck) string {
+	if cr.Namespace == "" {
+		return cr.Name
+	}
+	return fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)
+}
+
+// condition returns the status of the condition with the given type
+func condition(obj *unstructured.Unstructured, conditionType string) (string, bool) {
+	cond := findCondition(obj, conditionType)
+	if cond == nil {
+		return "", false
+	}
+	status, _, _ := unstructured.NestedString(cond, "status")
+	return status, true
+}
+
+func conditionMessage(obj *unstructured.Unstructured, conditionType string) string {
+	cond := findCondition(obj, conditionType)
+	if cond == nil {
+		return ""
+	}
+	if message, _, _ := unstructured.NestedString(cond, "message"); message != "" {
+		return fmt.Sprintf(": %s", message)
+	}
+	return ""
+}
+
+func findCondition(obj *unstructured.Unstructured, conditionType string) map[string]interface{} {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, entry := range conditions {
+		cond, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(cond, "type"); t == conditionType {
+			return cond
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+var kymaResource = schema.GroupVersionResource{Group: "operator.kyma-project.io", Version: "v1alpha1", Resource: "kymas"}
+
+func newState(runtimeID string, status model.Status, components ...string) *cluster.State {
+	state := &cluster.State{
+		Cluster:       &model.ClusterEntity{RuntimeID: runtimeID, Kubeconfig: runtimeID},
+		Configuration: &model.ClusterConfigurationEntity{RuntimeID: runtimeID},
+		Status:        &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: status},
+	}
+	for _, component := range components {
+		state.Configuration.Components = append(state.Configuration.Components, &keb.Component{Component: component})
+	}
+	return state
+}
+
+func newObject(apiVersion, kind, namespace, name string, status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"namespace": namespace, "name": name},
+		"status":     status,
+	}}
+}
+
+func conditions(condType, status, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": condType, "status": status, "message": message},
+		},
+	}
+}
+
+func TestProber(t *testing.T) {
+	scheme := runtime.NewScheme()
+	healthyClient := fake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+		map[schema.GroupVersionResource]string{kymaResource: "KymaList", deploymentsResource: "DeploymentList"},
+		newObject("apps/v1", "Deployment", "kyma-system", "istiod", conditions("Available", "True", "")),
+		newObject("operator.kyma-project.io/v1alpha1", "Kyma", "kcp-system", "kyma", map[string]interface{}{"state": "Ready"}),
+	)
+	unhealthyClient := fake.NewSimpleDynamicClientWithCustomListKinds(scheme,
+		map[schema.GroupVersionResource]string{kymaResource: "KymaList", deploymentsResource: "DeploymentList"},
+		newObject("apps/v1", "Deployment", "kyma-system", "istiod", conditions("Available", "False", "no replicas")),
+		newObject("operator.kyma-project.io/v1alpha1", "Kyma", "kcp-system", "kyma", conditions("Ready", "False", "module failed")),
+	)
+
+	inventory := &cluster.MockInventory{
+		GetAllResult: []*cluster.State{
+			newState("healthy", model.ClusterStatusReady, "istio", "kyma", "no-checks"),
+			newState("unhealthy", model.ClusterStatusReconcileError, "istio", "kyma", "missing"),
+			newState("unreachable", model.ClusterStatusReady, "istio"),
+			newState("deleted", model.ClusterStatusDeleted, "istio"),
+		},
+	}
+	prober := NewProber(inventory, &Config{
+		Interval: time.Minute,
+		Workers:  2,
+		Checks: map[string]config.ComponentHealthCheck{
+			"istio": {Deployments: []config.DeploymentCheck{{Namespace: "kyma-system", Name: "istiod"}}},
+			"kyma": {CustomResources: []config.CustomResourceCheck{{
+				Group: kymaResource.Group, Version: kymaResource.Version, Resource: kymaResource.Resource,
+				Namespace: "kcp-system", Name: "kyma", State: "ready",
+			}}},
+			"missing": {CustomResources: []config.CustomResourceCheck{{
+				Group: kymaResource.Group, Version: kymaResource.Version, Resource: kymaResource.Resource,
+				Name: "missing", Condition: "Ready",
+			}}},
+		},
+	}, logger.NewLogger(true))
+	prober.clientFactory = func(kubeconfig string, _ time.Duration) (dynamic.Interface, error) {
+		switch kubeconfig {
+		case "healthy":
+			return healthyClient, nil
+		case "unhealthy":
+			return unhealthyClient, nil
+		}
+		return nil, errors.New("cluster not reachable")
+	}
+
+	require.NoError(t, prober.ProbeAll(context.Background()))
+
+	statuses := func(runtimeID string) map[string]Status {
+		result := make(map[string]Status)
+		for _, health := range prober.Health(runtimeID) {
+			require.False(t, health.Probed.IsZero())
+			result[health.Component] = health.Status
+		}
+		return result
+	}
+	require.Equal(t, map[string]Status{"istio": StatusHealthy, "kyma": StatusHealthy}, statuses("healthy"))
+	require.Equal(t, map[string]Status{"istio": StatusUnhealthy, "kyma": StatusUnhealthy, "missing": StatusUnhealthy},
+		statuses("unhealthy"))
+	require.Equal(t, map[string]Status{"istio": StatusUnknown}, statuses("unreachable"))
+	require.Nil(t, prober.Health("deleted"))
+
+	for _, health := range prober.Health("unhealthy") {
+		if health.Component == "istio" {
+			require.Equal(t, "deployment 'kyma-system/istiod' is not available: no replicas", health.Message)
+		}
+	}
+
+	//results of clusters which disappeared from the inventory are dropped
+	inventory.GetAllResult = inventory.GetAllResult[:1]
+	require.NoError(t, prober.ProbeAll(context.Background()))
+	require.NotNil(t, prober.Health("healthy"))
+	require.Nil(t, prober.Health("unhealthy"))
+}
@@ -0,0 +1,111 @@
+package probe
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"go.uber.org/zap"
+)
+
+// Listener is notified about the results of each probed cluster
+type Listener interface {
+	Probed(state *cluster.State, health []*ComponentHealth)
+}
+
+// Watchdog triggers a repair reconciliation of clusters whose managed components became unhealthy, instead of
+// waiting until the reconcile interval of the cluster elapses. The reconciliation is scheduled for immediate
+// execution and triggered by the inventory watcher, which defers it if the cluster is reconciled meanwhile.
+type Watchdog struct {
+	inventory cluster.Inventory
+	cooldown  time.Duration        //minimal time between two repairs of a cluster
+	repaired  map[string]time.Time //key: runtime ID
+	mu        sync.Mutex
+	logger    *zap.SugaredLogger
+}
+
+func NewWatchdog(inventory cluster.Inventory, cooldown time.Duration, logger *zap.SugaredLogger) *Watchdog {
+	return &Watchdog{
+		inventory: inventory,
+		cooldown:  cooldown,
+		repaired:  make(map[string]time.Time),
+		logger:    logger,
+	}
+}
+
+func (w *Watchdog) Probed(state *cluster.State, health []*ComponentHealth) {
+	var unhealthy []string
+	for _, component := range health {
+		if component.Status == StatusUnhealthy {
+			unhealthy = append(unhealthy, component.Component)
+		}
+	}
+	if len(unhealthy) == 0 || !repairable(state.Status.Status) {
+		return
+	}
+
+	runtimeID := state.Cluster.RuntimeID
+	if !w.acquire(runtimeID) {
+		w.logger.Debugf("Watchdog skips repair of cluster '%s': cluster was repaired within the last %s",
+			runtimeID, w.cooldown)
+		return
+	}
+
+	schedules, err := w.inventory.ScheduledReconciliations(runtimeID)
+	if err != nil {
+		w.logger.Warnf("Watchdog failed to fetch scheduled reconciliations of cluster '%s': %s", runtimeID, err)
+		w.release(runtimeID)
+		return
+	}
+	now := time.Now()
+	for _, schedule := range schedules {
+		if !schedule.NotBefore.After(now) {
+			w.logger.Debugf("Watchdog skips repair of cluster '%s': reconciliation '%s' is already scheduled",
+				runtimeID, schedule.ID)
+			return
+		}
+	}
+
+	schedule, err := w.inventory.ScheduleReconciliation(runtimeID, now)
+	if err != nil {
+		w.logger.Errorf("Watchdog failed to schedule repair of cluster '%s': %s", runtimeID, err)
+		w.release(runtimeID)
+		return
+	}
+	w.logger.Infof("Watchdog scheduled repair reconciliation '%s' of cluster '%s' (unhealthy components: %s)",
+		schedule.ID, runtimeID, strings.Join(unhealthy, ", "))
+}
+
+// acquire returns true if the cooldown of the cluster elapsed and starts a new cooldown period
+func (w *Watchdog) acquire(runtimeID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	for id, repaired := range w.repaired {
+		if now.Sub(repaired) >= w.cooldown {
+			delete(w.repaired, id)
+		}
+	}
+	if _, ok := w.repaired[runtimeID]; ok {
+		return false
+	}
+	w.repaired[runtimeID] = now
+	return true
+}
+
+func (w *Watchdog) release(runtimeID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.repaired, runtimeID)
+}
+
+// repairable returns true for clusters which aren't reconciled, deleted or excluded from reconciliations
+func repairable(status model.Status) bool {
+	switch status {
+	case model.ClusterStatusReady, model.ClusterStatusReconcileError, model.ClusterStatusReconcileErrorRetryable:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package probe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+type schedulingInventory struct {
+	*cluster.MockInventory
+	scheduled []string
+}
+
+func (i *schedulingInventory) ScheduleReconciliation(runtimeID string, notBefore time.Time) (*model.ScheduledReconciliationEntity, error) {
+	i.scheduled = append(i.scheduled, runtimeID)
+	return i.MockInventory.ScheduleReconciliation(runtimeID, notBefore)
+}
+
+func TestWatchdog(t *testing.T) {
+	inventory := &schedulingInventory{MockInventory: &cluster.MockInventory{}}
+	watchdog := NewWatchdog(inventory, time.Hour, logger.NewLogger(true))
+
+	unhealthy := []*ComponentHealth{
+		{Component: "istio", Status: StatusHealthy},
+		{Component: "kyma", Status: StatusUnhealthy},
+	}
+
+	//healthy clusters and clusters which can't be probed aren't repaired
+	watchdog.Probed(newState("healthy", model.ClusterStatusReady), unhealthy[:1])
+	watchdog.Probed(newState("unknown", model.ClusterStatusReady), []*ComponentHealth{{Component: "kyma", Status: StatusUnknown}})
+	require.Empty(t, inventory.scheduled)
+
+	//clusters which are reconciled or excluded from reconciliations aren't repaired
+	watchdog.Probed(newState("reconciling", model.ClusterStatusReconciling), unhealthy)
+	watchdog.Probed(newState("disabled", model.ClusterStatusReconcileDisabled), unhealthy)
+	watchdog.Probed(newState("deleting", model.ClusterStatusDeleting), unhealthy)
+	require.Empty(t, inventory.scheduled)
+
+	//a cluster is repaired only once within the cooldown
+	watchdog.Probed(newState("ready", model.ClusterStatusReady), unhealthy)
+	watchdog.Probed(newState("ready", model.ClusterStatusReady), unhealthy)
+	require.Equal(t, []string{"ready"}, inventory.scheduled)
+
+	//clusters with a due scheduled reconciliation aren't repaired again
+	inventory.ScheduledReconciliationsResult = []*model.ScheduledReconciliationEntity{
+		{ID: "pending", RuntimeID: "error", NotBefore: time.Now().Add(-time.Minute)},
+	}
+	watchdog.Probed(newState("error", model.ClusterStatusReconcileError), unhealthy)
+	require.Equal(t, []string{"ready"}, inventory.scheduled)
+
+	//repairs are possible again after the cooldown elapsed
+	inventory.ScheduledReconciliationsResult = nil
+	watchdog.cooldown = 0
+	watchdog.Probed(newState("ready", model.ClusterStatusReady), unhealthy)
+	require.Equal(t, []string{"ready", "ready"}, inventory.scheduled)
+}
@@ -2,33 +2,41 @@ package callback
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
-	"github.com/kyma-incubator/reconciler/pkg/test"
+	"github.com/kyma-incubator/reconciler/pkg/test/callbackmock"
 	"github.com/stretchr/testify/require"
 )
 
 func TestRemoteCallbackHandler(t *testing.T) {
-	test.IntegrationTest(t)
-
 	logger := log.NewLogger(true)
 
+	mock, err := callbackmock.NewServer(callbackmock.Config{}, logger)
+	require.NoError(t, err)
+	srv := httptest.NewServer(mock.Router())
+	defer srv.Close()
+
 	t.Run("Test successful remote status update", func(t *testing.T) {
-		rcb, err := NewRemoteCallbackHandler("https://httpbin.org/status/200", logger)
+		rcb, err := NewRemoteCallbackHandler(callbackmock.URL(srv.URL, "success"), logger)
 		require.NoError(t, err)
 		require.NoError(t, rcb.Callback(&reconciler.CallbackMessage{
 			Status: reconciler.StatusRunning,
 		}))
+		require.Len(t, mock.Callbacks("success"), 1)
 	})
 
 	t.Run("Test failed remote status update", func(t *testing.T) {
-		rcb, err := NewRemoteCallbackHandler("https://httpbin.org/status/400", logger)
+		require.NoError(t, mock.SetConfig(callbackmock.Config{FailNext: 1, FailureStatusCode: http.StatusBadRequest}))
+		rcb, err := NewRemoteCallbackHandler(callbackmock.URL(srv.URL, "failure"), logger)
 		require.NoError(t, err)
 		require.Error(t, rcb.Callback(&reconciler.CallbackMessage{
 			Status: reconciler.StatusRunning,
 		}))
+		require.Empty(t, mock.Callbacks("failure"))
 	})
 }
 
@@ -0,0 +1,45 @@
+package callback
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"go.uber.org/zap"
+)
+
+// httpTransport posts callbacks to an HTTP endpoint (e.g. the callback endpoint of the mothership)
+type httpTransport struct {
+	callbackURL string
+	logger      *zap.SugaredLogger
+}
+
+func newHTTPTransport(callbackURL *url.URL, logger *zap.SugaredLogger) (Transport, error) {
+	return &httpTransport{
+		callbackURL: callbackURL.String(),
+		logger:      logger,
+	}, nil
+}
+
+func (t *httpTransport) Send(payload []byte) error {
+	resp, err := httpclient.Default().Post(t.callbackURL, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to send HTTP request: %s", err)
+	}
+	defer resp.Body.Close()
+	//dump request for debugging purposes
+	dumpResp, dumpErr := httputil.DumpResponse(resp, true)
+	if dumpErr == nil {
+		t.logger.Debugf("Remote callback handler is dumping HTTP response dump: %s", string(dumpResp))
+	} else {
+		t.logger.Debugf("Remote callback handler failed to generate HTTP response dump: %s", dumpErr)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to send request [HTTP response code: %d]: %s", resp.StatusCode, payload)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package callback
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+// Subset of the Kafka wire protocol (see https://kafka.apache.org/protocol) which is required to publish callbacks:
+// the versions were chosen to be supported by all brokers since Kafka 0.11.
+const (
+	kafkaAPIProduce          int16 = 0
+	kafkaAPIMetadata         int16 = 3
+	kafkaAPISaslHandshake    int16 = 17
+	kafkaAPISaslAuthenticate int16 = 36
+
+	kafkaProduceVersion          int16 = 3
+	kafkaMetadataVersion         int16 = 1
+	kafkaSaslHandshakeVersion    int16 = 1
+	kafkaSaslAuthenticateVersion int16 = 0
+
+	kafkaClientID    = "reconciler"
+	kafkaMaxResponse = 10 * 1024 * 1024
+)
+
+var kafkaErrors = map[int16]string{
+	3:  "UNKNOWN_TOPIC_OR_PARTITION",
+	5:  "LEADER_NOT_AVAILABLE",
+	6:  "NOT_LEADER_OR_FOLLOWER",
+	7:  "REQUEST_TIMED_OUT",
+	10: "MESSAGE_TOO_LARGE",
+	17: "INVALID_TOPIC_EXCEPTION",
+	19: "NOT_ENOUGH_REPLICAS",
+	20: "NOT_ENOUGH_REPLICAS_AFTER_APPEND",
+	29: "TOPIC_AUTHORIZATION_FAILED",
+	33: "UNSUPPORTED_SASL_MECHANISM",
+	34: "ILLEGAL_SASL_STATE",
+	58: "SASL_AUTHENTICATION_FAILED",
+}
+
+func kafkaError(code int16) error {
+	if code == 0 {
+		return nil
+	}
+	if name, ok := kafkaErrors[code]; ok {
+		return fmt.Errorf("kafka error %d (%s)", code, name)
+	}
+	return fmt.Errorf("kafka error %d", code)
+}
+
+type kafkaEncoder struct {
+	buffer bytes.Buffer
+}
+
+func (e *kafkaEncoder) int8(v int8) {
+	e.buffer.WriteByte(byte(v))
+}
+
+func (e *kafkaEncoder) int16(v int16) {
+	_ = binary.Write(&e.buffer, binary.BigEndian, v)
+}
+
+func (e *kafkaEncoder) int32(v int32) {
+	_ = binary.Write(&e.buffer, binary.BigEndian, v)
+}
+
+func (e *kafkaEncoder) int64(v int64) {
+	_ = binary.Write(&e.buffer, binary.BigEndian, v)
+}
+
+func (e *kafkaEncoder) uint32(v uint32) {
+	_ = binary.Write(&e.buffer, binary.BigEndian, v)
+}
+
+// varint uses the zig-zag encoding of the Kafka record format
+func (e *kafkaEncoder) varint(v int64) {
+	var buf [binary.MaxVarintLen64]byte
+	e.buffer.Write(buf[:binary.PutVarint(buf[:], v)])
+}
+
+func (e *kafkaEncoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.buffer.WriteString(s)
+}
+
+func (e *kafkaEncoder) nullString() {
+	e.int16(-1)
+}
+
+func (e *kafkaEncoder) bytes(b []byte) {
+	e.int32(int32(len(b)))
+	e.buffer.Write(b)
+}
+
+func (e *kafkaEncoder) raw(b []byte) {
+	e.buffer.Write(b)
+}
+
+func (e *kafkaEncoder) Bytes() []byte {
+	return e.buffer.Bytes()
+}
+
+// kafkaDecoder remembers the first error: callers check it after all fields were read
+type kafkaDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *kafkaDecoder) read(n int) []byte {
+	if d.err == nil && (n < 0 || n > len(d.data)) {
+		d.err = io.ErrUnexpectedEOF
+	}
+	if d.err != nil {
+		return make([]byte, max(n, 0))
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *kafkaDecoder) int8() int8 {
+	return int8(d.read(1)[0])
+}
+
+func (d *kafkaDecoder) int16() int16 {
+	return int16(binary.BigEndian.Uint16(d.read(2)))
+}
+
+func (d *kafkaDecoder) int32() int32 {
+	return int32(binary.BigEndian.Uint32(d.read(4)))
+}
+
+func (d *kafkaDecoder) int64() int64 {
+	return int64(binary.BigEndian.Uint64(d.read(8)))
+}
+
+func (d *kafkaDecoder) uint32() uint32 {
+	return binary.BigEndian.Uint32(d.read(4))
+}
+
+func (d *kafkaDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.data)
+	if n <= 0 {
+		d.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	d.data = d.data[n:]
+	return v
+}
+
+func (d *kafkaDecoder) string() string {
+	length := d.int16()
+	if length < 0 {
+		return ""
+	}
+	return string(d.read(int(length)))
+}
+
+func (d *kafkaDecoder) bytes() []byte {
+	length := d.int32()
+	if length < 0 {
+		return nil
+	}
+	return d.read(int(length))
+}
+
+// arrayLength returns the number of elements of an array (0 for null arrays)
+func (d *kafkaDecoder) arrayLength() int {
+	length := d.int32()
+	if length < 0 || int(length) > len(d.data) { //each element has at least one byte
+		if length > 0 {
+			d.err = io.ErrUnexpectedEOF
+		}
+		return 0
+	}
+	return int(length)
+}
+
+// kafkaRecordBatch encodes the value as a record batch (magic 2) containing a single record without key
+func kafkaRecordBatch(value []byte, timestamp time.Time) []byte {
+	record := &kafkaEncoder{}
+	record.int8(0)    //attributes
+	record.varint(0)  //timestamp delta
+	record.varint(0)  //offset delta
+	record.varint(-1) //key (null)
+	record.varint(int64(len(value)))
+	record.raw(value)
+	record.varint(0) //headers
+
+	//fields covered by the CRC
+	body := &kafkaEncoder{}
+	body.int16(0) //attributes: no compression, no transaction
+	body.int32(0) //last offset delta
+	body.int64(timestamp.UnixMilli())
+	body.int64(timestamp.UnixMilli())
+	body.int64(-1) //producer ID
+	body.int16(-1) //producer epoch
+	body.int32(-1) //base sequence
+	body.int32(1)  //number of records
+	body.varint(int64(len(record.Bytes())))
+	body.raw(record.Bytes())
+
+	batch := &kafkaEncoder{}
+	batch.int64(0)                                    //base offset (assigned by the broker)
+	batch.int32(int32(4 + 1 + 4 + len(body.Bytes()))) //batch length: leader epoch, magic, CRC and body
+	batch.int32(-1)                                   //partition leader epoch
+	batch.int8(2)                                     //magic
+	batch.uint32(crc32.Checksum(body.Bytes(), crc32.MakeTable(crc32.Castagnoli)))
+	batch.raw(body.Bytes())
+	return batch.Bytes()
+}
@@ -1,11 +1,7 @@
 package callback
 
 import (
-	"bytes"
 	"encoding/json"
-	"fmt"
-	"net/http"
-	"net/http/httputil"
 	"net/url"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
@@ -15,21 +11,30 @@ import (
 type RemoteCallbackHandler struct {
 	logger      *zap.SugaredLogger
 	callbackURL string
+	transport   Transport
 }
 
 func NewRemoteCallbackHandler(callbackURL string, logger *zap.SugaredLogger) (Handler, error) {
+	handler := &RemoteCallbackHandler{
+		logger:      logger,
+		callbackURL: callbackURL,
+	}
+	if callbackURL == "" { //empty URLs are allowed (used in some test cases)
+		return handler, nil
+	}
+
 	//validate URL
-	if callbackURL != "" { //empty URLs are allowed (used in some test cases)
-		if _, err := url.ParseRequestURI(callbackURL); err != nil {
-			return nil, err
-		}
+	u, err := url.ParseRequestURI(callbackURL)
+	if err != nil {
+		return nil, err
 	}
 
-	//return new remote callback
-	return &RemoteCallbackHandler{
-		logger:      logger,
-		callbackURL: callbackURL,
-	}, nil
+	//the scheme of the URL defines how callbacks are delivered
+	handler.transport, err = newTransport(u, logger)
+	if err != nil {
+		return nil, err
+	}
+	return handler, nil
 }
 
 func (cb *RemoteCallbackHandler) Callback(msg *reconciler.CallbackMessage) error {
@@ -38,31 +43,14 @@ func (cb *RemoteCallbackHandler) Callback(msg *reconciler.CallbackMessage) error
 		return nil
 	}
 
-	requestBody, err := json.Marshal(msg)
+	payload, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
 
-	resp, err := http.Post(cb.callbackURL, "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
-		cb.logger.Errorf("Remote callback handler failed to send HTTP request: %s", err)
+	if err := cb.transport.Send(payload); err != nil {
+		cb.logger.Errorf("Remote callback handler failed to deliver callback: %s", err)
 		return err
 	}
-	defer resp.Body.Close()
-	//dump request for debugging purposes
-	dumpResp, dumpErr := httputil.DumpResponse(resp, true)
-	if dumpErr == nil {
-		cb.logger.Debugf("Remote callback handler is dumping HTTP response dump: %s", string(dumpResp))
-	} else {
-		cb.logger.Debugf("Remote callback handler failed to generate HTTP response dump: %s", dumpErr)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		msg := fmt.Sprintf("Remote callack handler failed to send request [HTTP response code: %d]: %s",
-			resp.StatusCode, msg)
-		cb.logger.Info(msg)
-		return fmt.Errorf(msg)
-	}
-
 	return nil
 }
@@ -0,0 +1,52 @@
+package callback
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"go.uber.org/zap"
+)
+
+// Transport delivers serialized callback messages to the receiver addressed by the callback URL
+type Transport interface {
+	Send(payload []byte) error
+}
+
+// TransportFactory creates a transport for a callback URL
+type TransportFactory func(callbackURL *url.URL, logger *zap.SugaredLogger) (Transport, error)
+
+var (
+	transports = map[string]TransportFactory{
+		"http":  newHTTPTransport,
+		"https": newHTTPTransport,
+		"nats":  newNATSTransport,
+		"kafka": newKafkaTransport,
+	}
+	transportsMu sync.RWMutex
+)
+
+// RegisterTransport makes a transport available for callback URLs using the given scheme
+// (e.g. 'amqp' for callback URLs like 'amqp://broker:5672/queue').
+func RegisterTransport(scheme string, factory TransportFactory) {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	transports[strings.ToLower(scheme)] = factory
+}
+
+func newTransport(callbackURL *url.URL, logger *zap.SugaredLogger) (Transport, error) {
+	//the egress allowlist applies to all transports and not only to the ones sending their callbacks via HTTP
+	if err := httpclient.CheckEgress(callbackURL.String()); err != nil {
+		return nil, err
+	}
+	transportsMu.RLock()
+	factory, ok := transports[strings.ToLower(callbackURL.Scheme)]
+	transportsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no callback transport registered for scheme '%s' of callback URL '%s'",
+			callbackURL.Scheme, callbackURL.Redacted())
+	}
+	return factory(callbackURL, logger)
+}
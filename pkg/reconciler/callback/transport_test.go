@@ -0,0 +1,235 @@
+package callback
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeNATSServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config //TLS is required by the server if defined
+	connects  chan string
+	published chan string
+	pubError  string //error returned after a message was published
+}
+
+func newFakeNATSServer(t *testing.T, pubError string) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := &fakeNATSServer{
+		listener:  listener,
+		connects:  make(chan string, 1),
+		published: make(chan string, 1),
+		pubError:  pubError,
+	}
+	return srv
+}
+
+func (s *fakeNATSServer) start() *fakeNATSServer {
+	go s.serve()
+	return s
+}
+
+func (s *fakeNATSServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	_, _ = conn.Write([]byte(fmt.Sprintf("INFO {\"server_id\":\"test\",\"max_payload\":1048576,\"tls_required\":%t}\r\n",
+		s.tlsConfig != nil)))
+	if s.tlsConfig != nil {
+		conn = tls.Server(conn, s.tlsConfig)
+	}
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			s.connects <- strings.TrimSpace(strings.TrimPrefix(line, "CONNECT "))
+		case strings.HasPrefix(line, "PUB "):
+			var subject string
+			var size int
+			_, _ = fmt.Sscanf(line, "PUB %s %d", &subject, &size)
+			payload := make([]byte, size+2) //payload is terminated by CRLF
+			_, _ = io.ReadFull(reader, payload)
+			s.published <- fmt.Sprintf("%s:%s", subject, payload[:size])
+			if s.pubError != "" {
+				_, _ = conn.Write([]byte(s.pubError + "\r\n"))
+			}
+		case strings.HasPrefix(line, "PING"):
+			_, _ = conn.Write([]byte("PONG\r\n"))
+		}
+	}
+}
+
+func (s *fakeNATSServer) url(path string) string {
+	return fmt.Sprintf("nats://%s%s", s.listener.Addr().String(), path)
+}
+
+func TestNATSTransport(t *testing.T) {
+	logger := log.NewLogger(true)
+
+	t.Run("Publish callback to subject", func(t *testing.T) {
+		srv := newFakeNATSServer(t, "").start()
+		defer srv.listener.Close()
+
+		cbh, err := NewRemoteCallbackHandler(srv.url("/reconciler/callbacks"), logger)
+		require.NoError(t, err)
+		require.NoError(t, cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusSuccess}))
+		published := <-srv.published
+		require.True(t, strings.HasPrefix(published, "reconciler.callbacks:{"))
+		require.Contains(t, published, `"status":"success"`)
+	})
+
+	t.Run("Credentials of URL are used", func(t *testing.T) {
+		srv := newFakeNATSServer(t, "").start()
+		defer srv.listener.Close()
+
+		cbh, err := NewRemoteCallbackHandler(strings.Replace(srv.url("/callbacks"), "nats://", "nats://user:pwd@", 1), logger)
+		require.NoError(t, err)
+		require.NoError(t, cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusSuccess}))
+		connect := <-srv.connects
+		require.Contains(t, connect, `"user":"user"`)
+		require.Contains(t, connect, `"pass":"pwd"`)
+	})
+
+	t.Run("Rejected callback", func(t *testing.T) {
+		srv := newFakeNATSServer(t, "-ERR 'Permissions Violation for Publish to callbacks'").start()
+		defer srv.listener.Close()
+
+		cbh, err := NewRemoteCallbackHandler(srv.url("/callbacks"), logger)
+		require.NoError(t, err)
+		err = cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusSuccess})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Permissions Violation")
+	})
+
+	t.Run("Server requires TLS", func(t *testing.T) {
+		srv := newFakeNATSServer(t, "")
+		caFile := withTestTLS(t, srv)
+		srv.start()
+		defer srv.listener.Close()
+
+		cbh, err := NewRemoteCallbackHandler(srv.url("/callbacks")+"?ca="+caFile, logger)
+		require.NoError(t, err)
+		require.NoError(t, cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusSuccess}))
+		require.Contains(t, <-srv.published, "callbacks:")
+	})
+
+	t.Run("Enforced TLS is not downgraded", func(t *testing.T) {
+		srv := newFakeNATSServer(t, "").start()
+		defer srv.listener.Close()
+
+		cbh, err := NewRemoteCallbackHandler(srv.url("/callbacks")+"?tls=true", logger)
+		require.NoError(t, err)
+		require.Error(t, cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusSuccess}))
+	})
+
+	t.Run("Subject is required", func(t *testing.T) {
+		_, err := NewRemoteCallbackHandler("nats://localhost:4222", logger)
+		require.Error(t, err)
+	})
+}
+
+func TestTransportRegistry(t *testing.T) {
+	logger := log.NewLogger(true)
+
+	t.Run("Unsupported scheme", func(t *testing.T) {
+		_, err := NewRemoteCallbackHandler("amqp://broker:5672/callbacks", logger)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no callback transport registered for scheme 'amqp'")
+	})
+
+	t.Run("Registered transport", func(t *testing.T) {
+		transport := &testTransport{}
+		RegisterTransport("test", func(_ *url.URL, _ *zap.SugaredLogger) (Transport, error) {
+			return transport, nil
+		})
+		defer func() {
+			transportsMu.Lock()
+			delete(transports, "test")
+			transportsMu.Unlock()
+		}()
+
+		cbh, err := NewRemoteCallbackHandler("test://receiver/callbacks", logger)
+		require.NoError(t, err)
+		require.NoError(t, cbh.Callback(&reconciler.CallbackMessage{Status: reconciler.StatusRunning}))
+		require.Len(t, transport.payloads, 1)
+	})
+}
+
+func TestTransportEgress(t *testing.T) {
+	logger := log.NewLogger(true)
+	cfg := httpclient.DefaultConfig()
+	cfg.EgressAllowlist = []string{"mothership.example.com"}
+	httpclient.SetDefaults(cfg)
+	defer httpclient.SetDefaults(httpclient.DefaultConfig())
+
+	t.Run("Allowed host", func(t *testing.T) {
+		_, err := NewRemoteCallbackHandler("https://mothership.example.com/v1/operations/callback", logger)
+		require.NoError(t, err)
+	})
+
+	t.Run("Disallowed NATS host is rejected", func(t *testing.T) {
+		_, err := NewRemoteCallbackHandler("nats://nats.example.com:4222/reconciler/callbacks", logger)
+		require.True(t, httpclient.IsEgressDeniedError(err))
+	})
+
+	t.Run("Disallowed host of registered transport is rejected", func(t *testing.T) {
+		RegisterTransport("test", func(_ *url.URL, _ *zap.SugaredLogger) (Transport, error) {
+			return &testTransport{}, nil
+		})
+		defer func() {
+			transportsMu.Lock()
+			delete(transports, "test")
+			transportsMu.Unlock()
+		}()
+
+		_, err := NewRemoteCallbackHandler("test://receiver.example.com/callbacks", logger)
+		require.True(t, httpclient.IsEgressDeniedError(err))
+	})
+}
+
+// withTestTLS makes the server require TLS and returns the path of the CA file which has to be trusted by clients
+func withTestTLS(t *testing.T, srv *fakeNATSServer) string {
+	tlsSrv := httptest.NewUnstartedServer(nil)
+	tlsSrv.StartTLS()
+	t.Cleanup(tlsSrv.Close)
+	srv.tlsConfig = tlsSrv.TLS
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: tlsSrv.Certificate().Raw,
+	}), 0600))
+	return caFile
+}
+
+type testTransport struct {
+	payloads [][]byte
+}
+
+func (t *testTransport) Send(payload []byte) error {
+	t.payloads = append(t.payloads, payload)
+	return nil
+}
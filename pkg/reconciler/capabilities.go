@@ -0,0 +1,46 @@
+package reconciler
+
+type Capability string
+
+const (
+	CapabilityPayloadV2          Capability = "payloadV2" //accepts the TaskV2 payload via the v2 run API
+	CapabilityDryRun             Capability = "dryRun"
+	CapabilityPriority           Capability = "priority"
+	CapabilityTimeout            Capability = "timeout"
+	CapabilityNamespaceOverrides Capability = "namespaceOverrides"
+	CapabilityResourceFilters    Capability = "resourceFilters"
+	CapabilityCompression        Capability = "compression" //accepts gzip encoded request bodies
+)
+
+// Capabilities returns the features supported by this build of the component reconciler
+func Capabilities() []Capability {
+	return []Capability{
+		CapabilityPayloadV2,
+		CapabilityDryRun,
+		CapabilityPriority,
+		CapabilityTimeout,
+		CapabilityNamespaceOverrides,
+		CapabilityResourceFilters,
+		CapabilityCompression,
+	}
+}
+
+// HTTPCapabilitiesResponse is returned by component reconcilers to let the mothership negotiate the features
+// it can use when invoking them
+type HTTPCapabilitiesResponse struct {
+	Version      string       `json:"version"`
+	Capabilities []Capability `json:"capabilities"`
+}
+
+// Supports returns true if the component reconciler announced the capability
+func (r *HTTPCapabilitiesResponse) Supports(capability Capability) bool {
+	if r == nil {
+		return false
+	}
+	for _, c := range r.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
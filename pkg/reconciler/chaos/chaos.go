@@ -0,0 +1,249 @@
+// Package chaos injects faults into the processing of tasks (delayed applies, dropped callbacks, killed workers)
+// to validate the resilience of the scheduler, bookkeeper and retry logic in long-running test environments.
+// It's only active if the feature FAULT_INJECTION_ENABLED is enabled and must never be used in production.
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/features"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"go.uber.org/zap"
+)
+
+const (
+	EnvApplyDelayRate    = "FAULT_INJECTION_APPLY_DELAY_RATE"
+	EnvApplyDelayMax     = "FAULT_INJECTION_APPLY_DELAY_MAX"
+	EnvCallbackDropRate  = "FAULT_INJECTION_CALLBACK_DROP_RATE"
+	EnvWorkerKillRate    = "FAULT_INJECTION_WORKER_KILL_RATE"
+	EnvWorkerKillDelay   = "FAULT_INJECTION_WORKER_KILL_DELAY_MAX"
+	defaultApplyDelayMax = 30 * time.Second
+	defaultKillDelayMax  = 1 * time.Minute
+)
+
+// Config defines the rates (between 0 and 1) at which faults are injected
+type Config struct {
+	ApplyDelayRate     float64
+	ApplyDelayMax      time.Duration
+	CallbackDropRate   float64
+	WorkerKillRate     float64
+	WorkerKillDelayMax time.Duration
+}
+
+func (c *Config) validate() error {
+	for name, rate := range map[string]float64{
+		"apply delay":   c.ApplyDelayRate,
+		"callback drop": c.CallbackDropRate,
+		"worker kill":   c.WorkerKillRate,
+	} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%s rate has to be between 0 and 1 but was %v", name, rate)
+		}
+	}
+	if c.ApplyDelayMax <= 0 {
+		c.ApplyDelayMax = defaultApplyDelayMax
+	}
+	if c.WorkerKillDelayMax <= 0 {
+		c.WorkerKillDelayMax = defaultKillDelayMax
+	}
+	return nil
+}
+
+// ConfigFromEnv reads the fault injection config from the env vars. It returns nil if fault injection is disabled.
+func ConfigFromEnv() (*Config, error) {
+	if !features.Enabled(features.FaultInjection) {
+		return nil, nil
+	}
+	cfg := &Config{}
+	var err error
+	if cfg.ApplyDelayRate, err = rateFromEnv(EnvApplyDelayRate); err != nil {
+		return nil, err
+	}
+	if cfg.CallbackDropRate, err = rateFromEnv(EnvCallbackDropRate); err != nil {
+		return nil, err
+	}
+	if cfg.WorkerKillRate, err = rateFromEnv(EnvWorkerKillRate); err != nil {
+		return nil, err
+	}
+	if cfg.ApplyDelayMax, err = durationFromEnv(EnvApplyDelayMax); err != nil {
+		return nil, err
+	}
+	if cfg.WorkerKillDelayMax, err = durationFromEnv(EnvWorkerKillDelay); err != nil {
+		return nil, err
+	}
+	return cfg, cfg.validate()
+}
+
+func rateFromEnv(envVar string) (float64, error) {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return 0, nil
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("env var '%s' is not a valid rate: %s", envVar, err)
+	}
+	return rate, nil
+}
+
+func durationFromEnv(envVar string) (time.Duration, error) {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("env var '%s' is not a valid duration: %s", envVar, err)
+	}
+	return duration, nil
+}
+
+// Injector decides randomly which faults are injected
+type Injector struct {
+	config *Config
+	logger *zap.SugaredLogger
+	mu     sync.Mutex
+	rand   *rand.Rand
+}
+
+func NewInjector(config *Config, logger *zap.SugaredLogger) (*Injector, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &Injector{
+		config: config,
+		logger: logger,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec //no cryptographic use
+	}, nil
+}
+
+// NewInjectorFromEnv returns an injector configured by the env vars or nil if fault injection is disabled
+func NewInjectorFromEnv(logger *zap.SugaredLogger) (*Injector, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+	logger.Warnf("Fault injection is enabled: %+v", *cfg)
+	return NewInjector(cfg, logger)
+}
+
+// KubeClient wraps the client to delay applies randomly
+func (i *Injector) KubeClient(client kubernetes.Client) kubernetes.Client {
+	return &kubeClient{Client: client, injector: i}
+}
+
+// CallbackHandler wraps the handler to drop callbacks randomly. Dropped callbacks are not reported as error.
+func (i *Injector) CallbackHandler(handler callback.Handler) callback.Handler {
+	return &callbackHandler{Handler: handler, injector: i}
+}
+
+// KillWorker decides whether the worker processing a task gets killed. If so, the returned context is closed
+// after a random delay and the returned handler swallows all callbacks from then on, like a crashed worker would.
+func (i *Injector) KillWorker(ctx context.Context, handler callback.Handler) (context.Context, callback.Handler, context.CancelFunc) {
+	if !i.hit(i.config.WorkerKillRate) {
+		return ctx, handler, func() {}
+	}
+	delay := i.randomDuration(i.config.WorkerKillDelayMax)
+	i.logger.Warnf("Fault injection: worker will be killed in %.1f secs", delay.Seconds())
+	killedHandler := &killedCallbackHandler{Handler: handler}
+	killCtx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(delay, func() {
+		killedHandler.kill()
+		cancel()
+	})
+	return killCtx, killedHandler, func() {
+		timer.Stop()
+		cancel()
+	}
+}
+
+func (i *Injector) hit(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rand.Float64() < rate
+}
+
+func (i *Injector) randomDuration(max time.Duration) time.Duration {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return time.Duration(i.rand.Int63n(int64(max)))
+}
+
+type kubeClient struct {
+	kubernetes.Client
+	injector *Injector
+}
+
+func (c *kubeClient) Deploy(ctx context.Context, manifestTarget, namespace string, interceptors ...kubernetes.ResourceInterceptor) ([]*kubernetes.Resource, error) {
+	if err := c.delay(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.Deploy(ctx, manifestTarget, namespace, interceptors...)
+}
+
+func (c *kubeClient) DeployByCompareWithOriginal(ctx context.Context, manifestOriginal, manifestTarget, namespace string, interceptors ...kubernetes.ResourceInterceptor) ([]*kubernetes.Resource, error) {
+	if err := c.delay(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.DeployByCompareWithOriginal(ctx, manifestOriginal, manifestTarget, namespace, interceptors...)
+}
+
+func (c *kubeClient) delay(ctx context.Context) error {
+	if !c.injector.hit(c.injector.config.ApplyDelayRate) {
+		return nil
+	}
+	delay := c.injector.randomDuration(c.injector.config.ApplyDelayMax)
+	c.injector.logger.Warnf("Fault injection: delaying apply by %.1f secs", delay.Seconds())
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type callbackHandler struct {
+	callback.Handler
+	injector *Injector
+}
+
+func (h *callbackHandler) Callback(msg *reconciler.CallbackMessage) error {
+	if h.injector.hit(h.injector.config.CallbackDropRate) {
+		h.injector.logger.Warnf("Fault injection: dropping callback with status '%s'", msg.Status)
+		return nil
+	}
+	return h.Handler.Callback(msg)
+}
+
+type killedCallbackHandler struct {
+	callback.Handler
+	mu     sync.Mutex
+	killed bool
+}
+
+func (h *killedCallbackHandler) kill() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.killed = true
+}
+
+func (h *killedCallbackHandler) Callback(msg *reconciler.CallbackMessage) error {
+	h.mu.Lock()
+	killed := h.killed
+	h.mu.Unlock()
+	if killed {
+		return nil
+	}
+	return h.Handler.Callback(msg)
+}
@@ -0,0 +1,107 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/fake"
+	"github.com/stretchr/testify/require"
+)
+
+type countingHandler struct {
+	count int
+}
+
+func (h *countingHandler) Callback(_ *reconciler.CallbackMessage) error {
+	h.count++
+	return nil
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		t.Setenv(EnvCallbackDropRate, "0.5")
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		require.Nil(t, cfg)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		t.Setenv("FAULT_INJECTION_ENABLED", "true")
+		t.Setenv(EnvApplyDelayRate, "0.1")
+		t.Setenv(EnvCallbackDropRate, "0.5")
+		t.Setenv(EnvWorkerKillRate, "0.01")
+		t.Setenv(EnvApplyDelayMax, "5s")
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		require.Equal(t, &Config{
+			ApplyDelayRate:     0.1,
+			ApplyDelayMax:      5 * time.Second,
+			CallbackDropRate:   0.5,
+			WorkerKillRate:     0.01,
+			WorkerKillDelayMax: defaultKillDelayMax,
+		}, cfg)
+	})
+
+	t.Run("Invalid rate", func(t *testing.T) {
+		t.Setenv("FAULT_INJECTION_ENABLED", "true")
+		t.Setenv(EnvWorkerKillRate, "2")
+		_, err := ConfigFromEnv()
+		require.Error(t, err)
+
+		t.Setenv(EnvWorkerKillRate, "abc")
+		_, err = ConfigFromEnv()
+		require.Error(t, err)
+	})
+}
+
+func TestInjector(t *testing.T) {
+	log := logger.NewLogger(true)
+
+	t.Run("Drop callbacks", func(t *testing.T) {
+		injector, err := NewInjector(&Config{CallbackDropRate: 1}, log)
+		require.NoError(t, err)
+		handler := &countingHandler{}
+		require.NoError(t, injector.CallbackHandler(handler).Callback(&reconciler.CallbackMessage{}))
+		require.Zero(t, handler.count)
+
+		injector, err = NewInjector(&Config{}, log)
+		require.NoError(t, err)
+		require.NoError(t, injector.CallbackHandler(handler).Callback(&reconciler.CallbackMessage{}))
+		require.Equal(t, 1, handler.count)
+	})
+
+	t.Run("Delay applies", func(t *testing.T) {
+		injector, err := NewInjector(&Config{ApplyDelayRate: 1, ApplyDelayMax: time.Hour}, log)
+		require.NoError(t, err)
+		kubeClient := fake.NewClient()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = injector.KubeClient(kubeClient).Deploy(ctx, "", "test")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Empty(t, kubeClient.DeployedManifests())
+	})
+
+	t.Run("Kill worker", func(t *testing.T) {
+		injector, err := NewInjector(&Config{WorkerKillRate: 1, WorkerKillDelayMax: 10 * time.Millisecond}, log)
+		require.NoError(t, err)
+		handler := &countingHandler{}
+
+		ctx, killedHandler, cancel := injector.KillWorker(context.Background(), handler)
+		defer cancel()
+		<-ctx.Done()
+		require.NoError(t, killedHandler.Callback(&reconciler.CallbackMessage{}))
+		require.Zero(t, handler.count)
+	})
+
+	t.Run("Keep worker alive", func(t *testing.T) {
+		injector, err := NewInjector(&Config{}, log)
+		require.NoError(t, err)
+		ctx, _, cancel := injector.KillWorker(context.Background(), &countingHandler{})
+		defer cancel()
+		require.NoError(t, ctx.Err())
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/mholt/archiver/v3"
 	"github.com/otiai10/copy"
@@ -247,7 +248,8 @@ func (f *DefaultFactory) downloadArchive(URL, dstDir string, authenticator Exter
 		f.logger.Infof("Downloading archive '%s' into workspace '%s' from public repo", URL, dstDir)
 	}
 
-	client := &http.Client{}
+	//archives can be large: don't limit the overall request time but rely on connection and response header timeouts
+	client := httpclient.NewWithTimeout(0)
 	resp, err := client.Do(req) // #nosec
 	if err != nil {
 		return "", err
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io/fs"
 	"path/filepath"
-	"strings"
 
 	"github.com/imdario/mergo"
 	file "github.com/kyma-incubator/reconciler/pkg/files"
@@ -138,31 +137,40 @@ func (c *HelmClient) mergeChartConfiguration(chart *chart.Chart, component *Comp
 }
 
 func (c *HelmClient) profileConfiguration(ch *chart.Chart, profileName string, withValues bool) (map[string]interface{}, error) {
-	var profile *chart.File
-
-	profileNameLC := strings.ToLower(profileName)
-	profileNameWithPrefix := fmt.Sprintf("profile-%s.yaml", profileNameLC)
-	profileNameWithoutPrefix := fmt.Sprintf("%s.yaml", profileNameLC)
+	if profileName == "" {
+		return ch.Values, nil
+	}
 
-	for _, f := range ch.Files {
-		if (f.Name == profileNameWithPrefix) || (f.Name == profileNameWithoutPrefix) {
-			profile = f
-			break
+	//merge the values of the profile and the profiles it inherits from (parents first)
+	chain, known := profileChain(profileName)
+	var profileValues map[string]interface{}
+	for _, name := range chain {
+		profile := profileFile(ch, name)
+		if profile == nil {
+			continue //chart doesn't customize this profile
+		}
+		values, err := chartutil.ReadValues(profile.Data)
+		if err != nil {
+			return nil, err
+		}
+		if profileValues == nil {
+			profileValues = values.AsMap()
+		} else if err := mergo.Merge(&profileValues, values.AsMap(), mergo.WithOverride); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to merge values of profile '%s'", name))
 		}
 	}
 
 	//if no profile file was found, use the values from values.yaml
-	if profile == nil {
+	if profileValues == nil {
+		if !known {
+			return nil, fmt.Errorf("profile '%s' is unknown and chart '%s' has no values file for it",
+				profileName, ch.Name())
+		}
 		return ch.Values, nil
 	}
 
-	profileValues, err := chartutil.ReadValues(profile.Data)
-	if err != nil {
-		return nil, err
-	}
-
 	if withValues {
-		if err := mergo.Merge(&ch.Values, profileValues.AsMap(), mergo.WithOverride); err != nil {
+		if err := mergo.Merge(&ch.Values, profileValues, mergo.WithOverride); err != nil {
 			return nil, errors.Wrap(err, "failed to merge values.yaml with profile configuration")
 		}
 		return ch.Values, nil
@@ -171,3 +179,14 @@ func (c *HelmClient) profileConfiguration(ch *chart.Chart, profileName string, w
 	//if a profile file was found, use the values from the <profile>.yaml
 	return profileValues, nil
 }
+
+func profileFile(ch *chart.Chart, profileName string) *chart.File {
+	profileNameWithPrefix := fmt.Sprintf("profile-%s.yaml", profileName)
+	profileNameWithoutPrefix := fmt.Sprintf("%s.yaml", profileName)
+	for _, f := range ch.Files {
+		if (f.Name == profileNameWithPrefix) || (f.Name == profileNameWithoutPrefix) {
+			return f
+		}
+	}
+	return nil
+}
@@ -96,6 +96,61 @@ func TestHelm(t *testing.T) {
 		require.Equal(t, expected, got)
 	})
 
+	t.Run("Get chart configuration with inherited profile", func(t *testing.T) {
+		require.NoError(t, RegisterProfile(&Profile{Name: profileName}))
+		require.NoError(t, RegisterProfile(&Profile{Name: "child", Extends: profileName}))
+		component := NewComponentBuilder("main", componentName).
+			WithNamespace("testNamespace").
+			WithProfile("child").
+			Build()
+
+		helm, err := NewHelmClient(chartDir, logger)
+		require.NoError(t, err)
+
+		got, err := helm.profileConfiguration(loadHelmChart(t, component), "child", false)
+		require.NoError(t, err)
+
+		var expected map[string]interface{}
+		err = json.Unmarshal([]byte(`{
+			"config": {
+				"key1": "value1 from profile.yaml",
+				"key2": "value2 from profile-child.yaml"
+			},
+			"profile": true,
+			"child": true
+		}`), &expected)
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	})
+
+	t.Run("Get chart configuration with profile which isn't customized by the chart", func(t *testing.T) {
+		component := NewComponentBuilder("main", componentName).
+			WithNamespace("testNamespace").
+			WithProfile(ProfileEvaluation).
+			Build()
+
+		helm, err := NewHelmClient(chartDir, logger)
+		require.NoError(t, err)
+
+		helmChart := loadHelmChart(t, component)
+		got, err := helm.profileConfiguration(helmChart, ProfileEvaluation, false)
+		require.NoError(t, err)
+		require.Equal(t, helmChart.Values, got)
+	})
+
+	t.Run("Fail for unknown profile", func(t *testing.T) {
+		component := NewComponentBuilder("main", componentName).
+			WithNamespace("testNamespace").
+			WithProfile("doesNotExist").
+			Build()
+
+		helm, err := NewHelmClient(chartDir, logger)
+		require.NoError(t, err)
+
+		_, err = helm.profileConfiguration(loadHelmChart(t, component), "doesNotExist", false)
+		require.Error(t, err)
+	})
+
 	t.Run("Merge chart configuration with empty component configuration", func(t *testing.T) {
 		component := NewComponentBuilder("main", componentName).
 			WithNamespace("testNamespace").
@@ -0,0 +1,94 @@
+package chart
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	ProfileEvaluation = "evaluation"
+	ProfileProduction = "production"
+)
+
+// Profile is a named set of chart values which is stored in the file 'profile-<name>.yaml' (or '<name>.yaml')
+// of a chart. A profile can extend another profile: its values override the values inherited from the parent.
+type Profile struct {
+	Name    string
+	Extends string
+}
+
+var (
+	profiles = map[string]*Profile{
+		ProfileEvaluation: {Name: ProfileEvaluation},
+		ProfileProduction: {Name: ProfileProduction},
+	}
+	profilesMu sync.RWMutex
+)
+
+// RegisterProfile adds a profile (or replaces the profile with the same name). The profile it extends has to be
+// registered already.
+func RegisterProfile(profile *Profile) error {
+	if profile == nil || profile.Name == "" {
+		return fmt.Errorf("profile name is undefined")
+	}
+	profile = &Profile{Name: strings.ToLower(profile.Name), Extends: strings.ToLower(profile.Extends)}
+
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	for parent := profile.Extends; parent != ""; {
+		if parent == profile.Name {
+			return fmt.Errorf("profile '%s' cannot extend itself (directly or indirectly)", profile.Name)
+		}
+		parentProfile, ok := profiles[parent]
+		if !ok {
+			return fmt.Errorf("profile '%s' extends the unknown profile '%s'", profile.Name, parent)
+		}
+		parent = parentProfile.Extends
+	}
+	profiles[profile.Name] = profile
+	return nil
+}
+
+// Profiles returns the names of all registered profiles
+func Profiles() []string {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateProfile returns an error if the profile isn't registered. An empty profile is valid: it uses the
+// default values of the charts.
+func ValidateProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, known := profileChain(name); !known {
+		return fmt.Errorf("profile '%s' is unknown (supported profiles: %s)", name, strings.Join(Profiles(), ", "))
+	}
+	return nil
+}
+
+// profileChain returns the names of the profile and the profiles it inherits from (root first). If the profile
+// isn't registered, the chain contains only the profile itself.
+func profileChain(name string) ([]string, bool) {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	name = strings.ToLower(name)
+	profile, ok := profiles[name]
+	if !ok {
+		return []string{name}, false
+	}
+	chain := []string{profile.Name}
+	for profile.Extends != "" {
+		profile = profiles[profile.Extends]
+		chain = append([]string{profile.Name}, chain...)
+	}
+	return chain, true
+}
@@ -0,0 +1,34 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Run("Built-in profiles", func(t *testing.T) {
+		require.Subset(t, Profiles(), []string{ProfileEvaluation, ProfileProduction})
+		require.NoError(t, ValidateProfile(""))
+		require.NoError(t, ValidateProfile("Production")) //case-insensitive
+		require.Error(t, ValidateProfile("doesNotExist"))
+	})
+
+	t.Run("Register profile with inheritance", func(t *testing.T) {
+		require.NoError(t, RegisterProfile(&Profile{Name: "production-large", Extends: ProfileProduction}))
+		require.NoError(t, RegisterProfile(&Profile{Name: "production-xlarge", Extends: "production-large"}))
+		require.NoError(t, ValidateProfile("production-xlarge"))
+
+		chain, known := profileChain("production-xlarge")
+		require.True(t, known)
+		require.Equal(t, []string{ProfileProduction, "production-large", "production-xlarge"}, chain)
+	})
+
+	t.Run("Reject invalid profiles", func(t *testing.T) {
+		require.Error(t, RegisterProfile(&Profile{}))
+		require.Error(t, RegisterProfile(&Profile{Name: "orphan", Extends: "doesNotExist"}))
+		require.NoError(t, RegisterProfile(&Profile{Name: "cycle-a"}))
+		require.NoError(t, RegisterProfile(&Profile{Name: "cycle-b", Extends: "cycle-a"}))
+		require.Error(t, RegisterProfile(&Profile{Name: "cycle-a", Extends: "cycle-b"}))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	gitp "github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -45,6 +46,10 @@ func NewCloner(repoClient RepoClient, repo *reconciler.Repository, autoCheckout
 
 // Clone clones the repository from the given remote URL to the given `path` in the local filesystem.
 func (r *Cloner) Clone(path string) (*git.Repository, error) {
+	//go-git uses its own HTTP client: the egress allowlist has to be verified before cloning
+	if err := httpclient.CheckEgress(r.repo.URL); err != nil {
+		return nil, err
+	}
 	return r.repoClient.Clone(context.Background(), path, false, &git.CloneOptions{
 		Depth:             0,
 		URL:               r.repo.URL,
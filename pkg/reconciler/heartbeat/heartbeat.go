@@ -20,6 +20,19 @@ const (
 type Config struct {
 	Interval time.Duration
 	Timeout  time.Duration
+	Phases   func() []reconciler.OperationPhase //optional: returns the reached processing phases which are reported with each update
+	//optional retry metadata reported with each update:
+	Attempt   func() int                        //returns the number of the currently processed attempt
+	Resources func() reconciler.ResourceSummary //returns the amount of applied and failed resources
+	Retryable func(err error) bool              //indicates whether an error is expected to disappear by retrying
+	Version   string                            //version of the component which is reported when it was applied successfully
+	//optional: returns the results of the smoke tests which are reported with the final update
+	SmokeTests func() []reconciler.SmokeTestResult
+	//optional: returns the outcome of each handled resource which is reported with the final update
+	ResourceResults func() []reconciler.ResourceResult
+	//optional: returns warnings about the progress (e.g. resources which are not ready for a long time) which are
+	//reported with the interim updates of a running operation
+	Warnings func() []string
 }
 
 func (su *Config) validate() error {
@@ -69,6 +82,59 @@ func NewHeartbeatSender(ctx context.Context, callback cb.Handler, logger *zap.Su
 	}, nil
 }
 
+// phases returns the reached processing phases: final updates are flagged with the phase 'callbackSent'
+func (su *Sender) phases(status reconciler.Status) *[]reconciler.OperationPhase {
+	if su.config.Phases == nil {
+		return nil
+	}
+	phases := su.config.Phases()
+	if isFinalStatus(status) {
+		phases = append(phases, reconciler.OperationPhase{
+			Phase:   reconciler.OperationPhasePhaseCallbackSent,
+			Reached: time.Now().UTC(),
+		})
+	}
+	if len(phases) == 0 {
+		return nil
+	}
+	return &phases
+}
+
+// addMetadata adds the retry metadata to the callback message
+func (su *Sender) addMetadata(msg *reconciler.CallbackMessage, rootCause error) {
+	if su.config.Attempt != nil {
+		attempt := su.config.Attempt()
+		msg.Attempt = &attempt
+	}
+	if su.config.Resources != nil {
+		resources := su.config.Resources()
+		msg.Resources = &resources
+	}
+	if su.config.Retryable != nil && rootCause != nil {
+		retryable := su.config.Retryable(rootCause)
+		msg.Retryable = &retryable
+	}
+	if su.config.Version != "" && msg.Status == reconciler.StatusSuccess {
+		version := su.config.Version
+		msg.Version = &version
+	}
+	if su.config.SmokeTests != nil && (msg.Status == reconciler.StatusSuccess || msg.Status == reconciler.StatusVerificationFailed) {
+		if smokeTests := su.config.SmokeTests(); len(smokeTests) > 0 {
+			msg.SmokeTests = &smokeTests
+		}
+	}
+	if su.config.Warnings != nil && msg.Status == reconciler.StatusRunning {
+		if warnings := su.config.Warnings(); len(warnings) > 0 {
+			msg.Warnings = &warnings
+		}
+	}
+	if su.config.ResourceResults != nil && isFinalStatus(msg.Status) {
+		if resourceResults := su.config.ResourceResults(); len(resourceResults) > 0 {
+			msg.ResourceResults = &resourceResults
+		}
+	}
+}
+
 func (su *Sender) closeContext() {
 	su.m.Lock()
 	defer su.m.Unlock()
@@ -85,7 +151,7 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 	su.stopJob() //ensure previous interval-loop is stopped before starting a new loop
 
 	task := func(status reconciler.Status, rootCause error) error {
-		err := su.callback.Callback(&reconciler.CallbackMessage{
+		msg := &reconciler.CallbackMessage{
 			Status: status,
 			Error: func(err error) string {
 				if err != nil {
@@ -95,7 +161,10 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 			}(rootCause),
 			RetryID:            retryID,
 			ProcessingDuration: int(processingDuration.Milliseconds()),
-		})
+			Phases:             su.phases(status),
+		}
+		su.addMetadata(msg, rootCause)
+		err := su.callback.Callback(msg)
 		if err == nil {
 			su.logger.Debugf("Heartbeat communicated status '%s' successfully to mothership-reconciler", status)
 		} else {
@@ -210,14 +279,26 @@ func (su *Sender) Error(err error, retryID string, processingDuration time.Durat
 	return nil
 }
 
+func (su *Sender) VerificationFailed(err error, retryID string, processingDuration time.Duration) error {
+	if err := su.statusChangeAllowed(reconciler.StatusVerificationFailed); err != nil {
+		return err
+	}
+	su.sendUpdate(reconciler.StatusVerificationFailed, err, true, retryID, processingDuration) //VerificationFailed is a final status: use retry because heartbeat-requests are no longer needed
+	return nil
+}
+
 func (su *Sender) statusChangeAllowed(status reconciler.Status) error {
 	if su.isContextClosed() {
 		return &e.ContextClosedError{
 			Message: fmt.Sprintf("Cannot change status to '%s' because context of heartbeat sender is closed", status),
 		}
 	}
-	if su.status == reconciler.StatusError || su.status == reconciler.StatusSuccess {
+	if isFinalStatus(su.status) {
 		return fmt.Errorf("cannot switch in '%s' status because we are already in final status '%s'", status, su.status)
 	}
 	return nil
 }
+
+func isFinalStatus(status reconciler.Status) bool {
+	return status == reconciler.StatusSuccess || status == reconciler.StatusError || status == reconciler.StatusVerificationFailed
+}
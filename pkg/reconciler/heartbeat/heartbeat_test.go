@@ -171,3 +171,157 @@ func TestHeartbeatSender(t *testing.T) { //DO NOT RUN THIS TEST CASES IN PARALLE
 	})
 
 }
+
+func TestHeartbeatSenderPhases(t *testing.T) {
+	reached := time.Now().UTC()
+	sender, err := NewHeartbeatSender(context.Background(), newTestCallbackHandler(t), log.NewLogger(true), Config{
+		Phases: func() []reconciler.OperationPhase {
+			return []reconciler.OperationPhase{
+				{Phase: reconciler.OperationPhasePhaseRendered, Reached: reached},
+			}
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("Running update without callbackSent phase", func(t *testing.T) {
+		phases := sender.phases(reconciler.StatusRunning)
+		require.NotNil(t, phases)
+		require.Len(t, *phases, 1)
+		require.Equal(t, reconciler.OperationPhasePhaseRendered, (*phases)[0].Phase)
+	})
+
+	t.Run("Final update with callbackSent phase", func(t *testing.T) {
+		phases := sender.phases(reconciler.StatusSuccess)
+		require.NotNil(t, phases)
+		require.Len(t, *phases, 2)
+		require.Equal(t, reconciler.OperationPhasePhaseCallbackSent, (*phases)[1].Phase)
+		require.False(t, (*phases)[1].Reached.Before(reached))
+	})
+
+	t.Run("No phases without phase provider", func(t *testing.T) {
+		sender.config.Phases = nil
+		require.Nil(t, sender.phases(reconciler.StatusSuccess))
+	})
+}
+
+func TestHeartbeatSenderMetadata(t *testing.T) {
+	sender, err := NewHeartbeatSender(context.Background(), newTestCallbackHandler(t), log.NewLogger(true), Config{
+		Attempt: func() int {
+			return 2
+		},
+		Resources: func() reconciler.ResourceSummary {
+			return reconciler.ResourceSummary{Applied: 5, Failed: 1}
+		},
+		Retryable: func(err error) bool {
+			return err.Error() == "transient"
+		},
+		Version: "1.2.3",
+	})
+	require.NoError(t, err)
+
+	t.Run("Failed update with retryable error", func(t *testing.T) {
+		msg := &reconciler.CallbackMessage{Status: reconciler.StatusFailed}
+		sender.addMetadata(msg, errors.New("transient"))
+		require.Equal(t, 2, *msg.Attempt)
+		require.Equal(t, reconciler.ResourceSummary{Applied: 5, Failed: 1}, *msg.Resources)
+		require.True(t, *msg.Retryable)
+		require.Nil(t, msg.Version)
+	})
+
+	t.Run("Error update with permanent error", func(t *testing.T) {
+		msg := &reconciler.CallbackMessage{Status: reconciler.StatusError}
+		sender.addMetadata(msg, errors.New("permanent"))
+		require.False(t, *msg.Retryable)
+		require.Nil(t, msg.Version)
+	})
+
+	t.Run("Success update with applied version", func(t *testing.T) {
+		msg := &reconciler.CallbackMessage{Status: reconciler.StatusSuccess}
+		sender.addMetadata(msg, nil)
+		require.Nil(t, msg.Retryable)
+		require.Equal(t, "1.2.3", *msg.Version)
+	})
+
+	t.Run("No metadata without providers", func(t *testing.T) {
+		sender.config = Config{}
+		msg := &reconciler.CallbackMessage{Status: reconciler.StatusSuccess}
+		sender.addMetadata(msg, nil)
+		require.Nil(t, msg.Attempt)
+		require.Nil(t, msg.Resources)
+		require.Nil(t, msg.Version)
+	})
+}
+
+func TestHeartbeatSenderSmokeTests(t *testing.T) {
+	results := []reconciler.SmokeTestResult{{Name: "probe", Passed: true, Duration: 10}}
+	sender, err := NewHeartbeatSender(context.Background(), newTestCallbackHandler(t), log.NewLogger(true), Config{
+		SmokeTests: func() []reconciler.SmokeTestResult {
+			return results
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("Running update without smoke test results", func(t *testing.T) {
+		msg := &reconciler.CallbackMessage{Status: reconciler.StatusRunning}
+		sender.addMetadata(msg, nil)
+		require.Nil(t, msg.SmokeTests)
+	})
+
+	t.Run("Final updates with smoke test results", func(t *testing.T) {
+		for _, status := range []reconciler.Status{reconciler.StatusSuccess, reconciler.StatusVerificationFailed} {
+			msg := &reconciler.CallbackMessage{Status: status}
+			sender.addMetadata(msg, nil)
+			require.Equal(t, results, *msg.SmokeTests)
+		}
+	})
+
+	t.Run("VerificationFailed is a final status", func(t *testing.T) {
+		require.NoError(t, sender.VerificationFailed(errors.New("smoke test failed"), "retryID", time.Second))
+		require.Equal(t, reconciler.StatusVerificationFailed, sender.CurrentStatus())
+		require.Error(t, sender.Success("retryID", time.Second))
+	})
+}
+
+func TestHeartbeatSenderResourceResults(t *testing.T) {
+	results := []reconciler.ResourceResult{
+		{ApiVersion: "v1", Kind: "ConfigMap", Namespace: "kyma-system", Name: "cm", Outcome: reconciler.ResourceResultOutcomeCreated},
+	}
+	sender, err := NewHeartbeatSender(context.Background(), newTestCallbackHandler(t), log.NewLogger(true), Config{
+		ResourceResults: func() []reconciler.ResourceResult {
+			return results
+		},
+	})
+	require.NoError(t, err)
+
+	msg := &reconciler.CallbackMessage{Status: reconciler.StatusFailed}
+	sender.addMetadata(msg, errors.New("transient"))
+	require.Nil(t, msg.ResourceResults)
+
+	for _, status := range []reconciler.Status{reconciler.StatusSuccess, reconciler.StatusError, reconciler.StatusVerificationFailed} {
+		msg := &reconciler.CallbackMessage{Status: status}
+		sender.addMetadata(msg, nil)
+		require.Equal(t, results, *msg.ResourceResults)
+	}
+}
+
+func TestHeartbeatSenderWarnings(t *testing.T) {
+	var warnings []string
+	sender, err := NewHeartbeatSender(context.Background(), newTestCallbackHandler(t), log.NewLogger(true), Config{
+		Warnings: func() []string {
+			return warnings
+		},
+	})
+	require.NoError(t, err)
+
+	msg := &reconciler.CallbackMessage{Status: reconciler.StatusRunning}
+	sender.addMetadata(msg, nil)
+	require.Nil(t, msg.Warnings)
+
+	warnings = []string{"Deployment [namespace:kyma-system|name:foo] didn't reach state 'ready'"}
+	sender.addMetadata(msg, nil)
+	require.Equal(t, warnings, *msg.Warnings)
+
+	msg = &reconciler.CallbackMessage{Status: reconciler.StatusSuccess}
+	sender.addMetadata(msg, nil)
+	require.Nil(t, msg.Warnings)
+}
@@ -1,10 +1,18 @@
 package reconciler
 
+import "time"
+
 // HTTPErrorResponse is the model used for general error responses
 type HTTPErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// HTTPValidationErrorResponse is returned for invalid tasks: it lists each undefined or invalid field
+type HTTPValidationErrorResponse struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields"`
+}
+
 type HTTPReconciliationResponse struct {
 	//mothership reconciler expects no payload in the reconciliation response at the moment
 }
@@ -14,3 +22,20 @@ type HTTPOccupancyRequest struct {
 	RunningWorkers int    `json:"runningWorkers"`
 	PoolSize       int    `json:"poolSize"`
 }
+
+// HTTPQueueResponse lists the queued tasks of a component reconciler
+type HTTPQueueResponse struct {
+	Tasks []*HTTPQueuedTask `json:"tasks"`
+}
+
+type HTTPQueuedTask struct {
+	ID            string     `json:"id"`
+	Component     string     `json:"component"`
+	CorrelationID string     `json:"correlationID"`
+	RuntimeID     string     `json:"runtimeID"`
+	Priority      string     `json:"priority"`
+	LeaseOwner    string     `json:"leaseOwner,omitempty"` //replica which processes the task
+	LeaseExpiry   *time.Time `json:"leaseExpiry,omitempty"`
+	Attempts      int64      `json:"attempts"`
+	Created       time.Time  `json:"created"`
+}
@@ -0,0 +1,44 @@
+package base
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+)
+
+// helmReleaseCleanupAction removes orphaned Helm releases once per reconciliation: the base reconciler
+// is responsible for the CRDs which are reconciled at the beginning of each reconciliation
+type helmReleaseCleanupAction struct {
+	cleanup *service.HelmReleaseCleanupAction
+}
+
+func (a *helmReleaseCleanupAction) Run(actionCtx *service.ActionContext) error {
+	if actionCtx.Task.Component != model.CRDComponent || actionCtx.Task.Type == model.OperationTypeDelete {
+		return nil
+	}
+	return a.cleanup.Run(actionCtx)
+}
+
+// sidecarRestartAction restarts the workloads running outdated sidecars after an upgrade of istio: the base
+// reconciler is the fallback reconciler of the istio component
+type sidecarRestartAction struct {
+	restart *service.SidecarRestartAction
+}
+
+func (a *sidecarRestartAction) Run(actionCtx *service.ActionContext) error {
+	if actionCtx.Task.Component != istioComponent || actionCtx.Task.Type == model.OperationTypeDelete {
+		return nil
+	}
+	return a.restart.Run(actionCtx)
+}
+
+// postReconcileActions runs the post-reconcile actions of the base reconciler one after another
+type postReconcileActions []service.Action
+
+func (a postReconcileActions) Run(actionCtx *service.ActionContext) error {
+	for _, action := range a {
+		if err := action.Run(actionCtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,19 +1,40 @@
 package base
 
 import (
+	"os"
+	"strconv"
+
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 )
 
-const ReconcilerName = "base"
+const (
+	ReconcilerName = "base"
+
+	istioComponent = "istio"
+
+	//envHelmReleaseCleanupDryRun reports orphaned Helm releases instead of deleting them if set to 'true'
+	envHelmReleaseCleanupDryRun = "HELM_RELEASE_CLEANUP_DRY_RUN"
+)
 
 //nolint:gochecknoinits //usage of init() is intended to register reconciler-instances in centralized registry
 func init() {
 	log := logger.NewLogger(false)
 
 	log.Debugf("Initializing component reconciler '%s'", ReconcilerName)
-	_, err := service.NewComponentReconciler(ReconcilerName)
+	reconciler, err := service.NewComponentReconciler(ReconcilerName)
 	if err != nil {
 		log.Fatalf("Could not create '%s' component reconciler: %s", ReconcilerName, err)
 	}
+
+	dryRun, _ := strconv.ParseBool(os.Getenv(envHelmReleaseCleanupDryRun))
+	reconciler.WithPostReconcileAction(postReconcileActions{
+		&helmReleaseCleanupAction{
+			cleanup: service.NewHelmReleaseCleanupAction(service.HelmReleaseCleanupConfig{DryRun: dryRun}),
+		},
+		//no-op unless the istio configuration defines the expected sidecar version
+		&sidecarRestartAction{
+			restart: service.NewSidecarRestartAction(service.SidecarRestartConfig{}),
+		},
+	})
 }
@@ -2,6 +2,7 @@ package cleaner
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
@@ -15,6 +16,8 @@ import (
 
 const (
 	deleteStrategyConfigKey = "delete_strategy"
+	//forceFinalizeNamespacesConfigKey enables the removal of finalizers which keep namespaces in phase Terminating
+	forceFinalizeNamespacesConfigKey = "force_finalize_namespaces"
 )
 
 type CleanupAction struct {
@@ -44,7 +47,8 @@ func (a *CleanupAction) Run(context *service.ActionContext) error {
 	}
 
 	dropFinalizersOnlyForKymaCRs := readDeleteStrategy(context.Task.Configuration) != "all"
-	cliCleaner, err := cleanup.NewCliCleaner(context.Task.Kubeconfig, namespaces, context.Logger, dropFinalizersOnlyForKymaCRs, kymaCRDsFinder)
+	forceFinalizeNamespaces := readForceFinalizeNamespaces(context.Task.Configuration)
+	cliCleaner, err := cleanup.NewCliCleaner(context.Task.Kubeconfig, namespaces, context.Logger, dropFinalizersOnlyForKymaCRs, kymaCRDsFinder, forceFinalizeNamespaces)
 	if err != nil {
 		return err
 	}
@@ -152,3 +156,15 @@ func readDeleteStrategy(config map[string]interface{}) string {
 
 	return s
 }
+
+func readForceFinalizeNamespaces(config map[string]interface{}) bool {
+	switch v := config[forceFinalizeNamespacesConfigKey].(type) {
+	case bool:
+		return v
+	case string:
+		force, err := strconv.ParseBool(v)
+		return err == nil && force
+	default:
+		return false
+	}
+}
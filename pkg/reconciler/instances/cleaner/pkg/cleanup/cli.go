@@ -40,10 +40,11 @@ type CliCleaner struct {
 	kymaCRDsFinder               KymaCRDsFinder
 	namespaces                   []string
 	namespaceTimeout             time.Duration
+	forceFinalizeNamespaces      bool
 	logger                       *zap.SugaredLogger
 }
 
-func NewCliCleaner(kubeconfigData string, namespaces []string, logger *zap.SugaredLogger, dropFinalizersOnlyForKymaCRs bool, crdsFinder KymaCRDsFinder) (*CliCleaner, error) {
+func NewCliCleaner(kubeconfigData string, namespaces []string, logger *zap.SugaredLogger, dropFinalizersOnlyForKymaCRs bool, crdsFinder KymaCRDsFinder, forceFinalizeNamespaces bool) (*CliCleaner, error) {
 
 	kymaKube, err := NewFromConfigWithTimeout(kubeconfigData, defaultHTTPTimeout)
 	if err != nil {
@@ -55,7 +56,7 @@ func NewCliCleaner(kubeconfigData string, namespaces []string, logger *zap.Sugar
 		return nil, err
 	}
 
-	return &CliCleaner{kymaKube, apixClient, true, dropFinalizersOnlyForKymaCRs, crdsFinder, namespaces, namespaceTimeout, logger}, nil
+	return &CliCleaner{kymaKube, apixClient, true, dropFinalizersOnlyForKymaCRs, crdsFinder, namespaces, namespaceTimeout, forceFinalizeNamespaces, logger}, nil
 }
 
 // Run runs the command
@@ -72,7 +73,14 @@ func (cmd *CliCleaner) Run() error {
 	if err := cmd.deleteKymaNamespaces(); err != nil {
 		return err
 	}
-	return cmd.waitForNamespaces()
+	if err := cmd.waitForNamespaces(); err != nil {
+		//namespaces stuck in phase Terminating are reported (and force-finalized if enabled)
+		if remediationErr := cmd.remediateStuckNamespaces(); remediationErr != nil {
+			return errors.Wrap(remediationErr, err.Error())
+		}
+		return cmd.waitForNamespaces()
+	}
+	return nil
 }
 
 func (cmd *CliCleaner) removeServerlessCredentialFinalizers() error {
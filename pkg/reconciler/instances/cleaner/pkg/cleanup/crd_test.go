@@ -16,7 +16,7 @@ func TestCleanupFindAllCRDs(t *testing.T) {
 	test.IntegrationTest(t)
 	logger := log.NewLogger(true)
 	kubeconfig := test.ReadKubeconfig(t)
-	cliCleaner, err := NewCliCleaner(kubeconfig, nil, logger, false, nil)
+	cliCleaner, err := NewCliCleaner(kubeconfig, nil, logger, false, nil, false)
 	require.NoError(t, err)
 
 	// deploy crd
@@ -45,7 +45,7 @@ func TestEnsureNoneConversionWebhook(t *testing.T) {
 	test.IntegrationTest(t)
 	logger := log.NewLogger(true)
 	kubeconfig := test.ReadKubeconfig(t)
-	cliCleaner, err := NewCliCleaner(kubeconfig, nil, logger, false, nil)
+	cliCleaner, err := NewCliCleaner(kubeconfig, nil, logger, false, nil, false)
 	require.NoError(t, err)
 
 	// deploy crd
@@ -0,0 +1,252 @@
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var apiServicesGVR = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+
+// StuckNamespace is a namespace which remains in phase Terminating
+type StuckNamespace struct {
+	Name string
+	//Conditions are the reasons reported by the namespace controller (e.g. 'NamespaceFinalizersRemaining')
+	Conditions []string
+	//BlockingResources are resources in the namespace which still have finalizers
+	BlockingResources []BlockingResource
+	//UnavailableAPIServices prevent the namespace controller from discovering (and deleting) all resources
+	UnavailableAPIServices []string
+	//OrphanedAPIServices are unavailable because their service was running in the namespace
+	OrphanedAPIServices []string
+}
+
+// BlockingResource is a resource whose finalizers block the deletion of its namespace
+type BlockingResource struct {
+	GVR        schema.GroupVersionResource
+	Name       string
+	Finalizers []string
+}
+
+func (r BlockingResource) String() string {
+	return fmt.Sprintf("%s '%s' (finalizers: %s)", r.GVR.GroupResource(), r.Name, strings.Join(r.Finalizers, ","))
+}
+
+func (ns StuckNamespace) String() string {
+	var details []string
+	if len(ns.Conditions) > 0 {
+		details = append(details, fmt.Sprintf("conditions: %s", strings.Join(ns.Conditions, "; ")))
+	}
+	if len(ns.BlockingResources) > 0 {
+		var resources []string
+		for _, resource := range ns.BlockingResources {
+			resources = append(resources, resource.String())
+		}
+		details = append(details, fmt.Sprintf("blocking resources: %s", strings.Join(resources, ", ")))
+	}
+	if len(ns.UnavailableAPIServices) > 0 {
+		details = append(details, fmt.Sprintf("unavailable APIServices: %s", strings.Join(ns.UnavailableAPIServices, ", ")))
+	}
+	if len(details) == 0 {
+		return fmt.Sprintf("Namespace '%s' is stuck in phase Terminating", ns.Name)
+	}
+	return fmt.Sprintf("Namespace '%s' is stuck in phase Terminating (%s)", ns.Name, strings.Join(details, " - "))
+}
+
+// findStuckNamespaces returns the namespaces of the cleaner which are in phase Terminating
+// together with the resources and APIServices blocking their deletion
+func (cmd *CliCleaner) findStuckNamespaces() ([]StuckNamespace, error) {
+	unavailableAPIServices, err := cmd.findUnavailableAPIServices()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []StuckNamespace
+	for _, name := range cmd.namespaces {
+		namespace, err := cmd.k8s.Static().CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if namespace.Status.Phase != v1.NamespaceTerminating {
+			continue
+		}
+
+		stuckNamespace := StuckNamespace{Name: name}
+		for _, apiService := range unavailableAPIServices {
+			stuckNamespace.UnavailableAPIServices = append(stuckNamespace.UnavailableAPIServices, apiService.GetName())
+			if serviceNamespace, _, _ := unstructured.NestedString(apiService.Object, "spec", "service", "namespace"); serviceNamespace == name {
+				stuckNamespace.OrphanedAPIServices = append(stuckNamespace.OrphanedAPIServices, apiService.GetName())
+			}
+		}
+		for _, condition := range namespace.Status.Conditions {
+			if condition.Status == v1.ConditionTrue {
+				stuckNamespace.Conditions = append(stuckNamespace.Conditions,
+					fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+			}
+		}
+		if stuckNamespace.BlockingResources, err = cmd.findBlockingResources(name); err != nil {
+			return nil, err
+		}
+		result = append(result, stuckNamespace)
+	}
+	return result, nil
+}
+
+// findBlockingResources returns all resources of the namespace which have finalizers
+func (cmd *CliCleaner) findBlockingResources(namespace string) ([]BlockingResource, error) {
+	//discovery fails partially if APIServices are unavailable: the available resources are checked anyway
+	resourceLists, err := cmd.k8s.Static().Discovery().ServerPreferredNamespacedResources()
+	if err != nil && len(resourceLists) == 0 {
+		return nil, errors.Wrap(err, "failed to discover namespaced resources")
+	}
+
+	var result []BlockingResource
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, apiResource := range resourceList.APIResources {
+			if !supportsVerbs(apiResource, "list", "patch") {
+				continue
+			}
+			gvr := groupVersion.WithResource(apiResource.Name)
+			resources, err := cmd.k8s.Dynamic().Resource(gvr).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+			if err != nil {
+				cmd.logger.Debugf("Failed to list %s in namespace '%s': %s", gvr, namespace, err)
+				continue
+			}
+			for _, resource := range resources.Items {
+				if len(resource.GetFinalizers()) > 0 {
+					result = append(result, BlockingResource{
+						GVR:        gvr,
+						Name:       resource.GetName(),
+						Finalizers: resource.GetFinalizers(),
+					})
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// findUnavailableAPIServices returns all APIServices which are not available
+func (cmd *CliCleaner) findUnavailableAPIServices() ([]unstructured.Unstructured, error) {
+	apiServices, err := cmd.k8s.Dynamic().Resource(apiServicesGVR).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list APIServices")
+	}
+	var result []unstructured.Unstructured
+	for _, apiService := range apiServices.Items {
+		if !isAPIServiceAvailable(apiService) {
+			result = append(result, apiService)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetName() < result[j].GetName()
+	})
+	return result, nil
+}
+
+func isAPIServiceAvailable(apiService unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(apiService.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if conditionMap["type"] == "Available" {
+			return conditionMap["status"] == string(v1.ConditionTrue)
+		}
+	}
+	return true //no condition reported yet
+}
+
+func supportsVerbs(apiResource metav1.APIResource, verbs ...string) bool {
+	for _, verb := range verbs {
+		if !contains(apiResource.Verbs, verb) {
+			return false
+		}
+	}
+	return true
+}
+
+// forceFinalizeNamespace removes everything which blocks the deletion of a stuck namespace: orphaned APIServices
+// are deleted, the finalizers of the remaining resources and of the namespace itself are dropped
+func (cmd *CliCleaner) forceFinalizeNamespace(stuckNamespace StuckNamespace) error {
+	for _, apiService := range stuckNamespace.OrphanedAPIServices {
+		cmd.logger.Infof("Deleting unavailable APIService '%s' which blocks the deletion of namespace '%s'",
+			apiService, stuckNamespace.Name)
+		err := cmd.k8s.Dynamic().Resource(apiServicesGVR).Delete(context.Background(), apiService, metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete APIService '%s'", apiService)
+		}
+	}
+
+	for _, resource := range stuckNamespace.BlockingResources {
+		cmd.logger.Infof("Removing finalizers of %s in namespace '%s'", resource, stuckNamespace.Name)
+		_, err := cmd.k8s.Dynamic().Resource(resource.GVR).Namespace(stuckNamespace.Name).
+			Patch(context.Background(), resource.Name, types.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`), metav1.PatchOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to remove finalizers of %s", resource)
+		}
+	}
+
+	namespace, err := cmd.k8s.Static().CoreV1().Namespaces().Get(context.Background(), stuckNamespace.Name, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(namespace.Spec.Finalizers) == 0 {
+		return nil
+	}
+	cmd.logger.Infof("Finalizing namespace '%s' (dropping finalizers: %v)", namespace.Name, namespace.Spec.Finalizers)
+	namespace.Spec.Finalizers = nil
+	_, err = cmd.k8s.Static().CoreV1().Namespaces().Finalize(context.Background(), namespace, metav1.UpdateOptions{})
+	if err != nil && !apierr.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to finalize namespace '%s'", namespace.Name)
+	}
+	return nil
+}
+
+// remediateStuckNamespaces reports the namespaces stuck in phase Terminating and force-finalizes them if enabled.
+// It returns an error describing the stuck namespaces if they weren't force-finalized.
+func (cmd *CliCleaner) remediateStuckNamespaces() error {
+	stuckNamespaces, err := cmd.findStuckNamespaces()
+	if err != nil {
+		return err
+	}
+	if len(stuckNamespaces) == 0 {
+		return nil
+	}
+
+	var reports []string
+	for _, stuckNamespace := range stuckNamespaces {
+		cmd.logger.Warn(stuckNamespace.String())
+		reports = append(reports, stuckNamespace.String())
+	}
+	if !cmd.forceFinalizeNamespaces {
+		return fmt.Errorf("namespaces are stuck in phase Terminating (enable force-finalization to remove them): %s",
+			strings.Join(reports, "; "))
+	}
+	for _, stuckNamespace := range stuckNamespaces {
+		if err := cmd.forceFinalizeNamespace(stuckNamespace); err != nil {
+			return err
+		}
+	}
+	return nil
+}
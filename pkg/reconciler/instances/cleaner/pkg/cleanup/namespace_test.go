@@ -0,0 +1,100 @@
+package cleanup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+type fakeKymaKube struct {
+	static  kubernetes.Interface
+	dynamic dynamic.Interface
+}
+
+func (f *fakeKymaKube) Static() kubernetes.Interface {
+	return f.static
+}
+
+func (f *fakeKymaKube) Dynamic() dynamic.Interface {
+	return f.dynamic
+}
+
+func (f *fakeKymaKube) RestConfig() *rest.Config {
+	return &rest.Config{}
+}
+
+func TestRemediateStuckNamespaces(t *testing.T) {
+	newAPIService := func(name, serviceNamespace string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apiregistration.k8s.io/v1",
+			"kind":       "APIService",
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+			"spec": map[string]interface{}{
+				"service": map[string]interface{}{
+					"name":      "metrics",
+					"namespace": serviceNamespace,
+				},
+			},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Available", "status": "False"},
+				},
+			},
+		}}
+	}
+	newCleaner := func(force bool) *CliCleaner {
+		namespace := &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "kyma-system"},
+			Status: v1.NamespaceStatus{
+				Phase: v1.NamespaceTerminating,
+				Conditions: []v1.NamespaceCondition{
+					{Type: v1.NamespaceDeletionDiscoveryFailure, Status: v1.ConditionTrue, Message: "metrics unavailable"},
+					{Type: v1.NamespaceContentRemaining, Status: v1.ConditionFalse},
+				},
+			},
+		}
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{apiServicesGVR: "APIServiceList"},
+			newAPIService("v1beta1.metrics.kyma-project.io", "kyma-system"),
+			newAPIService("v1beta1.other.example.com", "other"))
+		return &CliCleaner{
+			k8s:                     &fakeKymaKube{static: fake.NewSimpleClientset(namespace), dynamic: dynamicClient},
+			namespaces:              []string{"kyma-system", "kyma-integration"},
+			forceFinalizeNamespaces: force,
+			logger:                  logger.NewLogger(true),
+		}
+	}
+
+	t.Run("Stuck namespaces are reported", func(t *testing.T) {
+		err := newCleaner(false).remediateStuckNamespaces()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Namespace 'kyma-system' is stuck in phase Terminating")
+		require.Contains(t, err.Error(), "NamespaceDeletionDiscoveryFailure: metrics unavailable")
+		require.Contains(t, err.Error(), "v1beta1.metrics.kyma-project.io, v1beta1.other.example.com")
+		require.NotContains(t, err.Error(), "NamespaceContentRemaining")
+	})
+
+	t.Run("Orphaned APIServices are deleted by force-finalization", func(t *testing.T) {
+		cleaner := newCleaner(true)
+		require.NoError(t, cleaner.remediateStuckNamespaces())
+
+		apiServices, err := cleaner.k8s.Dynamic().Resource(apiServicesGVR).List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, apiServices.Items, 1)
+		require.Equal(t, "v1beta1.other.example.com", apiServices.Items[0].GetName())
+	})
+}
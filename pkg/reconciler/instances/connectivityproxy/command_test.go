@@ -153,6 +153,7 @@ func TestCommands_Apply(t *testing.T) {
 		kubeClient := &mocks.Client{}
 		kubeClient.On("Deploy", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string"),
 			mock.AnythingOfType("*service.LabelsInterceptor"),
+			mock.AnythingOfType("*service.OwnershipInterceptor"),
 			mock.AnythingOfType("*service.AnnotationsInterceptor"),
 			mock.AnythingOfType("*service.ServicesInterceptor"),
 			mock.AnythingOfType("*service.PVCInterceptor"),
@@ -197,6 +198,7 @@ func TestCommands_Apply(t *testing.T) {
 		kubeClient := &mocks.Client{}
 		kubeClient.On("Deploy", ctx, emptyManifest, mock.AnythingOfType("string"),
 			mock.AnythingOfType("*service.LabelsInterceptor"),
+			mock.AnythingOfType("*service.OwnershipInterceptor"),
 			mock.AnythingOfType("*service.AnnotationsInterceptor"),
 			mock.AnythingOfType("*service.ServicesInterceptor"),
 			mock.AnythingOfType("*service.PVCInterceptor"),
@@ -239,6 +241,7 @@ func TestCommands_Apply(t *testing.T) {
 		kubeClient := &mocks.Client{}
 		kubeClient.On("Deploy", ctx, mock.AnythingOfType("string"), mock.AnythingOfType("string"),
 			mock.AnythingOfType("*service.LabelsInterceptor"),
+			mock.AnythingOfType("*service.OwnershipInterceptor"),
 			mock.AnythingOfType("*service.AnnotationsInterceptor"),
 			mock.AnythingOfType("*service.ServicesInterceptor"),
 			mock.AnythingOfType("*service.PVCInterceptor"),
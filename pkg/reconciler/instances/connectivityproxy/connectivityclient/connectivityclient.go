@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
 	"github.com/pkg/errors"
 )
 
@@ -38,10 +39,8 @@ func NewConnectivityCAClient(configs map[string]interface{}) (*ConnectivityCACli
 	}
 
 	return &ConnectivityCAClient{
-		url: fmt.Sprintf("%v%v", url, caPath),
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		url:    fmt.Sprintf("%v%v", url, caPath),
+		client: httpclient.NewWithTimeout(30 * time.Second),
 	}, nil
 }
 
@@ -1,20 +1,6 @@
-// This file is generated: manual changes will be overwritten!!!
-
+// Package instances loads the available component reconcilers into the reconciler registry.
+//
+// Each component reconciler is imported by its own loader file which can be excluded by the build tag
+// 'no_<package>' (e.g. 'go build -tags no_eventing,no_serverless') to build reconciler binaries which contain only
+// a subset of the component reconcilers.
 package instances
-
-import (
-	// import required to register component reconciler 'base' in reconciler registry
-	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/base"
-	// import required to register component reconciler 'cleaner' in reconciler registry
-	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/cleaner"
-	// import required to register component reconciler 'connectivityproxy' in reconciler registry
-	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/connectivityproxy"
-	// import required to register component reconciler 'eventing' in reconciler registry
-	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/eventing"
-	// import required to register component reconciler 'rma' in reconciler registry
-	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/rma"
-	// import required to register component reconciler 'serverless' in reconciler registry
-	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/serverless"
-	//import required to register component reconciler 'warden' in reconciler registry
-	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/warden"
-)
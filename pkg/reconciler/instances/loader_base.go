@@ -0,0 +1,8 @@
+// This file is generated: manual changes will be overwritten!!!
+
+//go:build !no_base
+
+package instances
+
+//import required to register component reconciler 'base' in reconciler registry (exclude it with build tag 'no_base')
+import _ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/base"
@@ -0,0 +1,8 @@
+// This file is generated: manual changes will be overwritten!!!
+
+//go:build !no_connectivityproxy
+
+package instances
+
+//import required to register component reconciler 'connectivityproxy' in reconciler registry (exclude it with build tag 'no_connectivityproxy')
+import _ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/connectivityproxy"
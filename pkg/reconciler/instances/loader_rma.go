@@ -0,0 +1,8 @@
+// This file is generated: manual changes will be overwritten!!!
+
+//go:build !no_rma
+
+package instances
+
+//import required to register component reconciler 'rma' in reconciler registry (exclude it with build tag 'no_rma')
+import _ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances/rma"
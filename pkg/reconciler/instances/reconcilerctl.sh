@@ -13,25 +13,21 @@ function showHelp() {
 
 function updateLoader() {
   echo "Updating component reconciler loader"
-  local import=""
+  rm -f loader_*.go
   for directory in */ ; do
       local baseName=$(basename "$directory")
-      if [ -d "$directory" -a "$baseName" != "example" ]; then
-        import="${import}
-        //import required to register component reconciler '$baseName' in reconciler registry
-        _ \"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/${baseName}\""
-      fi
-  done
-  if [ -n "$import" ]; then
-    echo "// This file is generated: manual changes will be overwritten!!!
+      if [ -d "$directory" -a "$baseName" != "example" -a "$baseName" != "utils" ]; then
+        echo "// This file is generated: manual changes will be overwritten!!!
 
-  package instances
+//go:build !no_${baseName}
 
-  import(${import}
-  )
-  " > loader.go
-  go fmt loader.go > /dev/null
-  fi
+package instances
+
+//import required to register component reconciler '${baseName}' in reconciler registry (exclude it with build tag 'no_${baseName}')
+import _ \"github.com/kyma-incubator/reconciler/pkg/reconciler/instances/${baseName}\"" > "loader_${baseName}.go"
+        go fmt "loader_${baseName}.go" > /dev/null
+      fi
+  done
 }
 
 function addReconciler {
@@ -0,0 +1,130 @@
+package rma
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/storage/driver"
+)
+
+// RmiRotateCredentialsConfig requests the rotation of the vmuser credentials of the runtime. The rotation runs in
+// two steps: before the reconciliation the release is upgraded with a new password while the previous password is
+// still accepted, after the reconciliation (when the runtime uses the new password) the previous one is removed.
+const RmiRotateCredentialsConfig = "rmi.rotateCredentials"
+
+// previousPasswordKey is the chart value of the password which is accepted in addition during a rotation
+const previousPasswordKey = "previousPassword"
+
+func isCredentialRotationRequested(config map[string]interface{}) (bool, error) {
+	rotate := false
+	err := getConfigBool(config, RmiRotateCredentialsConfig, &rotate)
+	return rotate, err
+}
+
+// CredentialRotationCleanupAction removes the previous password of a credential rotation from the rmi release
+type CredentialRotationCleanupAction struct {
+	name   string
+	client IntegrationClient
+}
+
+func NewCredentialRotationCleanupAction(name string, client IntegrationClient) *CredentialRotationCleanupAction {
+	return &CredentialRotationCleanupAction{
+		name:   name,
+		client: client,
+	}
+}
+
+func (a *CredentialRotationCleanupAction) Run(context *service.ActionContext) error {
+	if context.Task.Type != model.OperationTypeReconcile {
+		return nil
+	}
+	rotateCredentials, err := isCredentialRotationRequested(context.Task.Configuration)
+	if err != nil || !rotateCredentials {
+		return err
+	}
+	context.Logger.Debugf("Performing %s action for shoot %s", a.name, context.Task.Metadata.ShootName)
+
+	namespace := getConfigString(context.Task.Configuration, RmiNamespaceConfig)
+	if namespace == "" {
+		return errors.Errorf("missing required configuration: %s", RmiNamespaceConfig)
+	}
+	helmCfg, err := newHelmConfig(context.Task.Configuration)
+	if err != nil {
+		return err
+	}
+	releaseName := context.Task.Metadata.ShootName
+
+	cfg, err := a.client.HelmActionConfiguration(namespace)
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return errors.New("Could not get helm action configuration")
+	}
+
+	histClient := action.NewHistory(cfg)
+	histClient.Max = 1
+	releases, err := histClient.Run(releaseName)
+	if err == driver.ErrReleaseNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "while querying rmi helm history for release %s", releaseName)
+	}
+	helmRelease := findLatestRevision(releases)
+
+	values, found := withoutPreviousPassword(helmRelease.Config)
+	if !found {
+		return nil
+	}
+
+	upgradeAction := action.NewUpgrade(cfg)
+	upgradeAction.Namespace = namespace
+	upgradeAction.Timeout = helmCfg.upgradeTimeout
+	upgradeAction.Wait = helmCfg.wait
+	upgradeAction.MaxHistory = RmiHelmMaxHistory
+
+	err = helmCfg.retry(context.Context, func(attempt int) error {
+		_, err := upgradeAction.Run(releaseName, helmRelease.Chart, values)
+		if err != nil {
+			context.Logger.Warnf("helm upgrade %s-%s failed while removing previous auth password (attempt %d/%d): %s",
+				RmiChartName, releaseName, attempt, helmCfg.retries, err)
+		}
+		return err
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "removing previous auth password of %s-%s failed", RmiChartName, releaseName)
+	}
+
+	context.Logger.Infof("Rotation of auth credentials of %s-%s finished: previous password removed",
+		RmiChartName, releaseName)
+	return nil
+}
+
+// withoutPreviousPassword returns a copy of the release values without the previous password
+// and whether the values contained a previous password
+func withoutPreviousPassword(values map[string]interface{}) (map[string]interface{}, bool) {
+	auth := make(map[string]interface{})
+	switch typedAuth := values["auth"].(type) {
+	case map[string]interface{}:
+		for key, value := range typedAuth {
+			auth[key] = value
+		}
+	case map[string]string:
+		for key, value := range typedAuth {
+			auth[key] = value
+		}
+	}
+	if previousPassword, ok := auth[previousPasswordKey]; !ok || previousPassword == "" {
+		return values, false
+	}
+	delete(auth, previousPasswordKey)
+
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		result[key] = value
+	}
+	result["auth"] = auth
+	return result, true
+}
@@ -0,0 +1,84 @@
+package rma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_CredentialRotation(t *testing.T) {
+	testChart := fixChartArchive(t)
+	testClient := NewFakeClient(fake.NewSimpleClientset(&v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vmuser-rmi-test",
+			Namespace: "monitoring-system",
+		},
+		Data: map[string][]byte{
+			"username": []byte("testInstance"),
+			"password": []byte("oldPassword"),
+		},
+	}))
+	server := fixChartHTTPServer(t, testChart)
+
+	// given
+	err := NewIntegrationAction("test", testClient).Run(fixActionContext(fixChartURL(server.URL)))
+	require.NoError(t, err)
+
+	t.Run("should upgrade release with new and previous password when rotation is requested", func(t *testing.T) {
+		// given
+		context := fixActionContext(fixChartURL(server.URL))
+		context.Task.Configuration[RmiRotateCredentialsConfig] = "true"
+
+		// when
+		err := NewIntegrationAction("test", testClient).Run(context)
+
+		// then
+		require.NoError(t, err)
+		rel, err := testClient.helmStorage.Last("test")
+		require.NoError(t, err)
+		assert.Equal(t, 2, rel.Version)
+		auth := rel.Config["auth"].(map[string]string)
+		assert.Equal(t, "oldPassword", auth[previousPasswordKey])
+		assert.NotEmpty(t, auth["password"])
+		assert.NotEqual(t, "oldPassword", auth["password"])
+		assert.Equal(t, auth["password"], context.Task.Configuration["vmuser.password"])
+	})
+
+	t.Run("should remove previous password after reconciliation", func(t *testing.T) {
+		// given
+		context := fixActionContext(fixChartURL(server.URL))
+		context.Task.Configuration[RmiRotateCredentialsConfig] = true
+
+		// when
+		err := NewCredentialRotationCleanupAction("test", testClient).Run(context)
+
+		// then
+		require.NoError(t, err)
+		rel, err := testClient.helmStorage.Last("test")
+		require.NoError(t, err)
+		assert.Equal(t, 3, rel.Version)
+		auth := rel.Config["auth"].(map[string]interface{})
+		assert.NotContains(t, auth, previousPasswordKey)
+		assert.NotEmpty(t, auth["password"])
+		assert.Equal(t, "testInstance", auth["username"])
+	})
+
+	t.Run("should skip cleanup without previous password", func(t *testing.T) {
+		// given
+		context := fixActionContext(fixChartURL(server.URL))
+		context.Task.Configuration[RmiRotateCredentialsConfig] = true
+
+		// when
+		err := NewCredentialRotationCleanupAction("test", testClient).Run(context)
+
+		// then
+		require.NoError(t, err)
+		rel, err := testClient.helmStorage.Last("test")
+		require.NoError(t, err)
+		assert.Equal(t, 3, rel.Version)
+	})
+}
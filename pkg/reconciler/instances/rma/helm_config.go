@@ -0,0 +1,164 @@
+package rma
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/avast/retry-go"
+)
+
+const (
+	RmiHelmInstallTimeoutConfig = "rmi.helm.installTimeout"
+	RmiHelmUpgradeTimeoutConfig = "rmi.helm.upgradeTimeout"
+	RmiHelmDeleteTimeoutConfig  = "rmi.helm.deleteTimeout"
+	RmiHelmWaitConfig           = "rmi.helm.wait"
+	RmiHelmRetriesConfig        = "rmi.helm.retries"
+	RmiHelmRetryDelayConfig     = "rmi.helm.retryDelay"
+)
+
+const (
+	DefaultHelmInstallTimeout = 6 * time.Minute
+	DefaultHelmUpgradeTimeout = 5 * time.Minute
+	DefaultHelmDeleteTimeout  = 5 * time.Minute
+	DefaultHelmWait           = true
+	DefaultHelmRetries        = 1
+	DefaultHelmRetryDelay     = 10 * time.Second
+)
+
+// helmConfig defines how the Helm operations of the integration action are executed
+type helmConfig struct {
+	installTimeout time.Duration
+	upgradeTimeout time.Duration
+	deleteTimeout  time.Duration
+	wait           bool
+	//retries is the number of attempts of a Helm operation (1 means no retry)
+	retries    int
+	retryDelay time.Duration
+}
+
+// newHelmConfig reads the Helm settings from the task configuration and falls back to the defaults
+// for settings which are not defined. Durations are expected as Go duration (e.g. '10m') or as number of seconds.
+func newHelmConfig(config map[string]interface{}) (*helmConfig, error) {
+	result := &helmConfig{
+		installTimeout: DefaultHelmInstallTimeout,
+		upgradeTimeout: DefaultHelmUpgradeTimeout,
+		deleteTimeout:  DefaultHelmDeleteTimeout,
+		wait:           DefaultHelmWait,
+		retries:        DefaultHelmRetries,
+		retryDelay:     DefaultHelmRetryDelay,
+	}
+
+	for key, target := range map[string]*time.Duration{
+		RmiHelmInstallTimeoutConfig: &result.installTimeout,
+		RmiHelmUpgradeTimeoutConfig: &result.upgradeTimeout,
+		RmiHelmDeleteTimeoutConfig:  &result.deleteTimeout,
+		RmiHelmRetryDelayConfig:     &result.retryDelay,
+	} {
+		if err := getConfigDuration(config, key, target); err != nil {
+			return nil, err
+		}
+	}
+	if err := getConfigBool(config, RmiHelmWaitConfig, &result.wait); err != nil {
+		return nil, err
+	}
+	if err := getConfigInt(config, RmiHelmRetriesConfig, &result.retries); err != nil {
+		return nil, err
+	}
+	if result.retries < 1 {
+		return nil, fmt.Errorf("invalid configuration %s: at least 1 attempt is required but got %d",
+			RmiHelmRetriesConfig, result.retries)
+	}
+
+	return result, nil
+}
+
+// retry executes the Helm operation until it succeeds or the configured number of attempts is reached
+func (c *helmConfig) retry(ctx context.Context, operation func(attempt int) error) error {
+	attempt := 0
+	return retry.Do(func() error {
+		attempt++
+		return operation(attempt)
+	},
+		retry.Attempts(uint(c.retries)),
+		retry.Delay(c.retryDelay),
+		retry.LastErrorOnly(true),
+		retry.Context(ctx))
+}
+
+func getConfigDuration(config map[string]interface{}, key string, target *time.Duration) error {
+	val, ok := config[key]
+	if !ok {
+		return nil
+	}
+	var duration time.Duration
+	switch typedVal := val.(type) {
+	case string:
+		var err error
+		if duration, err = time.ParseDuration(typedVal); err != nil {
+			seconds, convErr := strconv.Atoi(typedVal)
+			if convErr != nil {
+				return fmt.Errorf("invalid configuration %s: '%s' is not a duration", key, typedVal)
+			}
+			duration = time.Duration(seconds) * time.Second
+		}
+	case int:
+		duration = time.Duration(typedVal) * time.Second
+	case int64:
+		duration = time.Duration(typedVal) * time.Second
+	case float64:
+		duration = time.Duration(typedVal * float64(time.Second))
+	default:
+		return fmt.Errorf("invalid configuration %s: unsupported type %T", key, val)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("invalid configuration %s: duration has to be positive but got %s", key, duration)
+	}
+	*target = duration
+	return nil
+}
+
+func getConfigBool(config map[string]interface{}, key string, target *bool) error {
+	val, ok := config[key]
+	if !ok {
+		return nil
+	}
+	switch typedVal := val.(type) {
+	case bool:
+		*target = typedVal
+	case string:
+		parsed, err := strconv.ParseBool(typedVal)
+		if err != nil {
+			return fmt.Errorf("invalid configuration %s: '%s' is not a boolean", key, typedVal)
+		}
+		*target = parsed
+	default:
+		return fmt.Errorf("invalid configuration %s: unsupported type %T", key, val)
+	}
+	return nil
+}
+
+func getConfigInt(config map[string]interface{}, key string, target *int) error {
+	val, ok := config[key]
+	if !ok {
+		return nil
+	}
+	switch typedVal := val.(type) {
+	case int:
+		*target = typedVal
+	case int64:
+		*target = int(typedVal)
+	case float64:
+		*target = int(typedVal)
+	case string:
+		parsed, err := strconv.Atoi(typedVal)
+		if err != nil {
+			return fmt.Errorf("invalid configuration %s: '%s' is not a number", key, typedVal)
+		}
+		*target = parsed
+	default:
+		return fmt.Errorf("invalid configuration %s: unsupported type %T", key, val)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package rma
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newHelmConfig(t *testing.T) {
+	t.Run("should use defaults when nothing is configured", func(t *testing.T) {
+		helmCfg, err := newHelmConfig(map[string]interface{}{})
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultHelmInstallTimeout, helmCfg.installTimeout)
+		assert.Equal(t, DefaultHelmUpgradeTimeout, helmCfg.upgradeTimeout)
+		assert.Equal(t, DefaultHelmDeleteTimeout, helmCfg.deleteTimeout)
+		assert.Equal(t, DefaultHelmWait, helmCfg.wait)
+		assert.Equal(t, DefaultHelmRetries, helmCfg.retries)
+		assert.Equal(t, DefaultHelmRetryDelay, helmCfg.retryDelay)
+	})
+
+	t.Run("should read configured values", func(t *testing.T) {
+		helmCfg, err := newHelmConfig(map[string]interface{}{
+			RmiHelmInstallTimeoutConfig: "15m",
+			RmiHelmUpgradeTimeoutConfig: 600,
+			RmiHelmDeleteTimeoutConfig:  "120",
+			RmiHelmWaitConfig:           "false",
+			RmiHelmRetriesConfig:        float64(3),
+			RmiHelmRetryDelayConfig:     "30s",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, helmCfg.installTimeout)
+		assert.Equal(t, 10*time.Minute, helmCfg.upgradeTimeout)
+		assert.Equal(t, 2*time.Minute, helmCfg.deleteTimeout)
+		assert.False(t, helmCfg.wait)
+		assert.Equal(t, 3, helmCfg.retries)
+		assert.Equal(t, 30*time.Second, helmCfg.retryDelay)
+	})
+
+	for name, config := range map[string]map[string]interface{}{
+		"invalid timeout":          {RmiHelmInstallTimeoutConfig: "soon"},
+		"negative timeout":         {RmiHelmUpgradeTimeoutConfig: "-5m"},
+		"invalid wait":             {RmiHelmWaitConfig: "maybe"},
+		"invalid retries":          {RmiHelmRetriesConfig: "often"},
+		"no attempts":              {RmiHelmRetriesConfig: 0},
+		"unsupported timeout type": {RmiHelmDeleteTimeoutConfig: []string{"5m"}},
+	} {
+		config := config
+		t.Run("should fail for "+name, func(t *testing.T) {
+			_, err := newHelmConfig(config)
+			require.Error(t, err)
+		})
+	}
+}
+
+func Test_helmConfig_retry(t *testing.T) {
+	helmCfg := &helmConfig{retries: 3, retryDelay: time.Millisecond}
+
+	t.Run("should retry until the operation succeeds", func(t *testing.T) {
+		var attempts []int
+		err := helmCfg.retry(context.Background(), func(attempt int) error {
+			attempts = append(attempts, attempt)
+			if attempt < 2 {
+				return errors.New("timed out waiting for the condition")
+			}
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2}, attempts)
+	})
+
+	t.Run("should return the last error when all attempts failed", func(t *testing.T) {
+		calls := 0
+		err := helmCfg.retry(context.Background(), func(int) error {
+			calls++
+			return errors.New("timed out waiting for the condition")
+		})
+
+		require.EqualError(t, err, "timed out waiting for the condition")
+		assert.Equal(t, 3, calls)
+	})
+}
@@ -18,6 +18,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/consistenthash"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 	"github.com/pkg/errors"
@@ -36,28 +38,41 @@ const (
 	RmiChartURLConfig   = "rmi.chartUrl"
 	RmiNamespaceConfig  = "rmi.namespace"
 	RmiVmalertGroupsNum = "rmi.vmalertGroupsNum"
+	// RmiVmalertGroupAssignment selects how instances are assigned to vmalert groups (see VMAlertGroupAssignment*)
+	RmiVmalertGroupAssignment = "rmi.vmalertGroupAssignment"
 )
 
 const DefaultVMAlertGroupsNum = 1
 
+const (
+	// VMAlertGroupAssignmentLegacy keeps the assignment of previous releases: changing the number of groups
+	// moves almost every instance to another group.
+	VMAlertGroupAssignmentLegacy = "legacy"
+	// VMAlertGroupAssignmentConsistentHash assigns instances by consistent hashing: growing from n to n+1 groups
+	// moves only about 1/(n+1) of the instances (all of them into the new group). Switching from the legacy
+	// assignment moves about (n-1)/n of the instances once, so it should be enabled together with a planned
+	// change of the number of groups.
+	VMAlertGroupAssignmentConsistentHash = "consistent-hash"
+)
+
 type IntegrationAction struct {
-	name         string
-	http         http.Client
-	client       IntegrationClient
-	mux          sync.Mutex
-	archives     map[string][]byte
-	chartVerExpr *regexp.Regexp
+	name          string
+	http          *http.Client
+	client        IntegrationClient
+	mux           sync.Mutex
+	archives      map[string][]byte
+	chartVerExpr  *regexp.Regexp
+	legacyCleanup *service.LegacyCleanupAction
 }
 
 func NewIntegrationAction(name string, client IntegrationClient) *IntegrationAction {
 	return &IntegrationAction{
-		name:   name,
-		client: client,
-		http: http.Client{
-			Timeout: 20 * time.Second,
-		},
-		archives:     make(map[string][]byte),
-		chartVerExpr: regexp.MustCompile(fmt.Sprintf("%s-([a-zA-Z0-9-.]+)\\.tgz$", RmiChartName)),
+		name:          name,
+		client:        client,
+		http:          httpclient.NewWithTimeout(20 * time.Second),
+		archives:      make(map[string][]byte),
+		chartVerExpr:  regexp.MustCompile(fmt.Sprintf("%s-([a-zA-Z0-9-.]+)\\.tgz$", RmiChartName)),
+		legacyCleanup: service.NewLegacyCleanupAction(service.LegacyCleanupConfig{Resources: legacyResources}),
 	}
 }
 
@@ -81,6 +96,16 @@ func (a *IntegrationAction) Run(context *service.ActionContext) error {
 		context.Logger.Debugf("missing configuration: %s, will use its default value: %d", RmiVmalertGroupsNum,
 			DefaultVMAlertGroupsNum)
 	}
+	helmCfg, err := newHelmConfig(context.Task.Configuration)
+	if err != nil {
+		context.Logger.Error(err)
+		return err
+	}
+	rotateCredentials, err := isCredentialRotationRequested(context.Task.Configuration)
+	if err != nil {
+		context.Logger.Error(err)
+		return err
+	}
 
 	releaseName := context.Task.Metadata.ShootName
 
@@ -102,15 +127,12 @@ func (a *IntegrationAction) Run(context *service.ActionContext) error {
 
 	switch context.Task.Type {
 	case model.OperationTypeReconcile:
-		// Ensure avs-bridge deployment is absent from the runtime
-		_, kubeErr := context.KubeClient.DeleteResource(context.Context, "deployment", "avs-bridge", "kyma-system")
-		if kubeErr != nil {
-			context.Logger.Errorf("failed to delete avs-bridge deployment from runtime: %s", kubeErr)
-		}
+		// Ensure legacy resources (e.g. the avs-bridge deployment) are absent from the runtime
+		a.legacyCleanup.Cleanup(context)
 
 		// If a release does not exist, run helm install
 		if err == driver.ErrReleaseNotFound {
-			return a.install(context, cfg, chartURL, releaseName, namespace, groupsNum)
+			return a.install(context, cfg, helmCfg, chartURL, releaseName, namespace, groupsNum)
 		}
 
 		// If the release exists, only run helm upgrade if the integration chart version is different.
@@ -129,29 +151,30 @@ func (a *IntegrationAction) Run(context *service.ActionContext) error {
 		case upgradeVersion == releaseVersion && helmRelease.Info.Status == release.StatusDeployed:
 			context.Logger.Debugf("%s-%s target version matches release version, skipping upgrade.", RmiChartName,
 				releaseName)
-			skipHelmUpgrade = true
+			skipHelmUpgrade = !rotateCredentials
 		default:
 			context.Logger.Infof("%s-%s target version: %s release version/status: %s/%s, starting upgrade.",
 				RmiChartName, releaseName, upgradeVersion, releaseVersion, helmRelease.Info.Status)
 		}
 
-		return a.upgrade(context, cfg, chartURL, releaseName, namespace, groupsNum, skipHelmUpgrade)
+		return a.upgrade(context, cfg, helmCfg, chartURL, releaseName, namespace, groupsNum, skipHelmUpgrade,
+			rotateCredentials)
 	case model.OperationTypeDelete:
 		if err == nil {
-			return a.delete(cfg, releaseName)
+			return a.delete(context, cfg, helmCfg, releaseName)
 		}
 	}
 
 	return nil
 }
 
-func (a *IntegrationAction) install(context *service.ActionContext, cfg *action.Configuration,
+func (a *IntegrationAction) install(context *service.ActionContext, cfg *action.Configuration, helmCfg *helmConfig,
 	chartURL, releaseName, namespace, groupsNum string) error {
 	installAction := action.NewInstall(cfg)
 	installAction.ReleaseName = releaseName
 	installAction.Namespace = namespace
-	installAction.Timeout = 6 * time.Minute
-	installAction.Wait = true
+	installAction.Timeout = helmCfg.installTimeout
+	installAction.Wait = helmCfg.wait
 	chart, err := a.fetchChart(context.Context, chartURL)
 	if err != nil {
 		return errors.Wrapf(err, "while fetching rmi chart from %s", chartURL)
@@ -163,7 +186,18 @@ func (a *IntegrationAction) install(context *service.ActionContext, cfg *action.
 	}
 	overrides := generateOverrideMap(context, username, password, groupsNum)
 
-	_, err = installAction.Run(chart, overrides)
+	err = helmCfg.retry(context.Context, func(attempt int) error {
+		if attempt > 1 {
+			// a failed install leaves a release behind which has to be removed before the name can be re-used
+			a.uninstallFailedRelease(context, cfg, helmCfg, releaseName)
+		}
+		_, err := installAction.Run(chart, overrides)
+		if err != nil {
+			context.Logger.Warnf("helm install %s-%s failed (attempt %d/%d): %s",
+				RmiChartName, releaseName, attempt, helmCfg.retries, err)
+		}
+		return err
+	})
 	if err != nil {
 		return errors.WithMessagef(err, "helm install %s-%s failed", RmiChartName, releaseName)
 	}
@@ -172,14 +206,25 @@ func (a *IntegrationAction) install(context *service.ActionContext, cfg *action.
 	return nil
 }
 
-func (a *IntegrationAction) upgrade(context *service.ActionContext, cfg *action.Configuration,
-	chartURL, releaseName, namespace, groupsNum string, skipHelmUpgrade bool) error {
+func (a *IntegrationAction) upgrade(context *service.ActionContext, cfg *action.Configuration, helmCfg *helmConfig,
+	chartURL, releaseName, namespace, groupsNum string, skipHelmUpgrade, rotateCredentials bool) error {
 	username := context.Task.Metadata.InstanceID
 	password, err := a.fetchPassword(context.Context, releaseName, namespace)
 	if err != nil {
 		return errors.WithMessage(err, "failed to fetch auth credentials from secret")
 	}
 
+	var previousPassword string
+	if rotateCredentials {
+		previousPassword = password
+		password, err = generatePassword(16)
+		if err != nil {
+			return errors.Wrap(err, "while generating new auth password")
+		}
+		context.Logger.Infof("Rotating auth credentials of %s-%s: previous password is accepted until the reconciliation finished",
+			RmiChartName, releaseName)
+	}
+
 	setAuthCredentialOverrides(context.Task.Configuration, username, password)
 
 	if skipHelmUpgrade {
@@ -188,8 +233,8 @@ func (a *IntegrationAction) upgrade(context *service.ActionContext, cfg *action.
 
 	upgradeAction := action.NewUpgrade(cfg)
 	upgradeAction.Namespace = namespace
-	upgradeAction.Timeout = 5 * time.Minute
-	upgradeAction.Wait = true
+	upgradeAction.Timeout = helmCfg.upgradeTimeout
+	upgradeAction.Wait = helmCfg.wait
 	upgradeAction.MaxHistory = RmiHelmMaxHistory
 	chart, err := a.fetchChart(context.Context, chartURL)
 	if err != nil {
@@ -197,8 +242,18 @@ func (a *IntegrationAction) upgrade(context *service.ActionContext, cfg *action.
 	}
 
 	overrides := generateOverrideMap(context, username, password, groupsNum)
+	if previousPassword != "" {
+		overrides["auth"].(map[string]string)[previousPasswordKey] = previousPassword
+	}
 
-	_, err = upgradeAction.Run(releaseName, chart, overrides)
+	err = helmCfg.retry(context.Context, func(attempt int) error {
+		_, err := upgradeAction.Run(releaseName, chart, overrides)
+		if err != nil {
+			context.Logger.Warnf("helm upgrade %s-%s failed (attempt %d/%d): %s",
+				RmiChartName, releaseName, attempt, helmCfg.retries, err)
+		}
+		return err
+	})
 	if err != nil {
 		return errors.WithMessagef(err, "helm upgrade %s-%s failed", RmiChartName, releaseName)
 	}
@@ -206,11 +261,23 @@ func (a *IntegrationAction) upgrade(context *service.ActionContext, cfg *action.
 	return nil
 }
 
-func (a *IntegrationAction) delete(cfg *action.Configuration, releaseName string) error {
+func (a *IntegrationAction) delete(context *service.ActionContext, cfg *action.Configuration, helmCfg *helmConfig,
+	releaseName string) error {
 	uninstallAction := action.NewUninstall(cfg)
-	uninstallAction.Timeout = 5 * time.Minute
+	uninstallAction.Timeout = helmCfg.deleteTimeout
 
-	_, err := uninstallAction.Run(releaseName)
+	err := helmCfg.retry(context.Context, func(attempt int) error {
+		_, err := uninstallAction.Run(releaseName)
+		if attempt > 1 && errors.Is(err, driver.ErrReleaseNotFound) {
+			// a previous attempt removed the release but failed afterwards
+			return nil
+		}
+		if err != nil {
+			context.Logger.Warnf("helm delete %s-%s failed (attempt %d/%d): %s",
+				RmiChartName, releaseName, attempt, helmCfg.retries, err)
+		}
+		return err
+	})
 	if err != nil {
 		return errors.WithMessagef(err, "helm delete %s-%s failed", RmiChartName, releaseName)
 	}
@@ -218,6 +285,17 @@ func (a *IntegrationAction) delete(cfg *action.Configuration, releaseName string
 	return nil
 }
 
+// uninstallFailedRelease removes the release left behind by a failed install
+func (a *IntegrationAction) uninstallFailedRelease(context *service.ActionContext, cfg *action.Configuration,
+	helmCfg *helmConfig, releaseName string) {
+	uninstallAction := action.NewUninstall(cfg)
+	uninstallAction.Timeout = helmCfg.deleteTimeout
+	_, err := uninstallAction.Run(releaseName)
+	if err != nil && !errors.Is(err, driver.ErrReleaseNotFound) {
+		context.Logger.Warnf("failed to remove release %s-%s of failed install: %s", RmiChartName, releaseName, err)
+	}
+}
+
 func (a *IntegrationAction) fetchChart(ctx context.Context, chartURL string) (*chart.Chart, error) {
 	a.mux.Lock()
 	defer a.mux.Unlock()
@@ -318,10 +396,14 @@ func generateOverrideMap(context *service.ActionContext, username, password, gro
 		"username": username,
 		"password": password,
 	}
+	assignment := getConfigString(context.Task.Configuration, RmiVmalertGroupAssignment)
 	overrideMap["vmalert"] = map[string]int{
-		"group": generateVmalertGroup(context, metadata.InstanceID, groupsNum),
+		"group": generateVmalertGroup(context, metadata.InstanceID, groupsNum, assignment),
 	}
 
+	// values of the configuration can't replace the generated runtime, auth and vmalert values
+	mergeOverrides(overrideMap, getConfigOverrides(context.Task.Configuration), false)
+
 	return overrideMap
 }
 
@@ -366,7 +448,7 @@ func findLatestRevision(releases []*release.Release) *release.Release {
 	return release
 }
 
-func generateVmalertGroup(context *service.ActionContext, id, num string) int {
+func generateVmalertGroup(context *service.ActionContext, id, num, assignment string) int {
 	groups, err := strconv.Atoi(num)
 	if err != nil {
 		context.Logger.Debugf("got error %s when converting string to int for configuration: %s, use its default value: %d",
@@ -374,10 +456,24 @@ func generateVmalertGroup(context *service.ActionContext, id, num string) int {
 		groups = DefaultVMAlertGroupsNum
 	}
 
-	csum := sha256.Sum256([]byte(id))
-	//nolint:staticcheck //no security relevance, linter complains can be ignored
-	mrand.Seed(int64(binary.LittleEndian.Uint64(csum[0:8])))
-	group := mrand.Intn(groups)
+	switch assignment {
+	case VMAlertGroupAssignmentConsistentHash:
+		return consistenthash.Bucket(id, groups)
+	case "", VMAlertGroupAssignmentLegacy:
+	default:
+		context.Logger.Debugf("unknown value '%s' of configuration: %s, use the legacy assignment",
+			assignment, RmiVmalertGroupAssignment)
+	}
+	return legacyVmalertGroup(id, groups)
+}
 
-	return group
+// legacyVmalertGroup picks the group by a random generator seeded with the hash of the instance ID. It uses its
+// own generator (instead of seeding math/rand globally) but returns the same groups as previous releases.
+func legacyVmalertGroup(id string, groups int) int {
+	if groups < 1 {
+		return 0
+	}
+	csum := sha256.Sum256([]byte(id))
+	//nolint:gosec //no security relevance, the group only has to be deterministic
+	return mrand.New(mrand.NewSource(int64(binary.LittleEndian.Uint64(csum[0:8])))).Intn(groups)
 }
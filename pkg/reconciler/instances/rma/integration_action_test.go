@@ -63,6 +63,19 @@ func Test_IntegrationAction_Run(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("should return error when rmi.helm config is invalid", func(t *testing.T) {
+		// given
+		action := NewIntegrationAction("test", testClient)
+		context := fixActionContext("fakeURL")
+		context.Task.Configuration[RmiHelmInstallTimeoutConfig] = "soon"
+
+		// when
+		err := action.Run(context)
+
+		// then
+		require.Error(t, err)
+	})
+
 	t.Run("should install rmi when release not found", func(t *testing.T) {
 		// given
 		action := NewIntegrationAction("test", testClient)
@@ -192,6 +205,7 @@ func fixActionContext(chartURL string) *service.ActionContext {
 
 	mockClient := &mocks.Client{}
 	mockClient.On("DeleteResource", mock.Anything, "deployment", "avs-bridge", "kyma-system").Return(nil, nil)
+	mockClient.On("Get", "deployment", "avs-bridge", "kyma-system").Return(nil, nil)
 	mockClient.On("getDomain").Return("testDomain", nil)
 	mockClient.On("GetHost").Return("tmphost")
 
@@ -222,6 +236,22 @@ func fixChartHTTPServer(t *testing.T, chart []byte) *httptest.Server {
 	}))
 }
 
+func Test_generateVmalertGroup(t *testing.T) {
+	context := fixActionContext("fakeURL")
+
+	t.Run("legacy assignment is used by default", func(t *testing.T) {
+		require.Equal(t, 2, generateVmalertGroup(context, "testInstance", "6", ""))
+		require.Equal(t, 2, generateVmalertGroup(context, "testInstance", "6", VMAlertGroupAssignmentLegacy))
+		require.Equal(t, 2, generateVmalertGroup(context, "testInstance", "6", "unknown"))
+		require.Equal(t, 0, generateVmalertGroup(context, "testInstance", "0", ""))
+	})
+
+	t.Run("consistent hashing is used when configured", func(t *testing.T) {
+		require.Equal(t, 3, generateVmalertGroup(context, "testInstance", "6", VMAlertGroupAssignmentConsistentHash))
+		require.Equal(t, 0, generateVmalertGroup(context, "testInstance", "invalid", VMAlertGroupAssignmentConsistentHash))
+	})
+}
+
 func assertRMIConfig(t *testing.T, context *service.ActionContext, group int, config map[string]interface{}) {
 	runtime := config["runtime"].(map[string]string)
 	auth := config["auth"].(map[string]string)
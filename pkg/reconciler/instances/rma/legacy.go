@@ -0,0 +1,10 @@
+package rma
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+)
+
+// legacyResources are deployed by former versions of the monitoring integration and have to be absent from the runtime
+var legacyResources = []service.LegacyResource{
+	{Kind: "deployment", Namespace: "kyma-system", Name: "avs-bridge"},
+}
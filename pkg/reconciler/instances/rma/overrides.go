@@ -0,0 +1,74 @@
+package rma
+
+import (
+	"sort"
+	"strings"
+)
+
+// RmiOverridesConfigPrefix is the prefix of configuration keys which define chart values of the rmi release
+// (e.g. 'rmi.overrides.vmagent.resources.limits.memory: 1Gi'). The key 'rmi.overrides' accepts a map of values.
+const RmiOverridesConfigPrefix = "rmi.overrides"
+
+// getConfigOverrides returns the chart values defined in the configuration. Keys are processed in lexical order:
+// a more specific key (e.g. 'rmi.overrides.a.b') replaces a scalar value defined by a less specific key ('rmi.overrides.a').
+func getConfigOverrides(config map[string]interface{}) map[string]interface{} {
+	var keys []string
+	for key := range config {
+		if key == RmiOverridesConfigPrefix || strings.HasPrefix(key, RmiOverridesConfigPrefix+".") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]interface{})
+	for _, key := range keys {
+		value := config[key]
+		if key == RmiOverridesConfigPrefix {
+			if values, ok := value.(map[string]interface{}); ok {
+				mergeOverrides(result, values, true)
+			}
+			continue
+		}
+		setOverride(result, strings.Split(strings.TrimPrefix(key, RmiOverridesConfigPrefix+"."), "."), value)
+	}
+	return result
+}
+
+func setOverride(values map[string]interface{}, path []string, value interface{}) {
+	for _, field := range path[:len(path)-1] {
+		child, ok := values[field].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			values[field] = child
+		}
+		values = child
+	}
+	leaf := path[len(path)-1]
+	existing, existingIsMap := values[leaf].(map[string]interface{})
+	valueMap, valueIsMap := value.(map[string]interface{})
+	if existingIsMap && valueIsMap {
+		mergeOverrides(existing, valueMap, true)
+		return
+	}
+	values[leaf] = value
+}
+
+// mergeOverrides merges the values of src into dst: nested maps are merged recursively, other values of src
+// are only taken over if dst doesn't define them or if overwrite is set
+func mergeOverrides(dst, src map[string]interface{}, overwrite bool) {
+	for key, srcVal := range src {
+		dstVal, ok := dst[key]
+		if !ok {
+			dst[key] = srcVal
+			continue
+		}
+		dstMap, dstIsMap := dstVal.(map[string]interface{})
+		srcMap, srcIsMap := srcVal.(map[string]interface{})
+		switch {
+		case dstIsMap && srcIsMap:
+			mergeOverrides(dstMap, srcMap, overwrite)
+		case overwrite:
+			dst[key] = srcVal
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package rma
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getConfigOverrides(t *testing.T) {
+	t.Run("should build nested values from configuration keys", func(t *testing.T) {
+		overrides := getConfigOverrides(map[string]interface{}{
+			RmiChartURLConfig: "http://charts/rmi-1.0.0.tgz",
+			"rmi.overrides.vmagent.resources.limits.memory": "1Gi",
+			"rmi.overrides.vmagent.replicas":                2,
+			"rmi.overrides": map[string]interface{}{
+				"vmagent": map[string]interface{}{
+					"replicas": 1,
+					"image":    "vmagent:1.0",
+				},
+			},
+			"rmi.overridesX": "ignored",
+		})
+
+		require.Equal(t, map[string]interface{}{
+			"vmagent": map[string]interface{}{
+				"replicas": 2,
+				"image":    "vmagent:1.0",
+				"resources": map[string]interface{}{
+					"limits": map[string]interface{}{
+						"memory": "1Gi",
+					},
+				},
+			},
+		}, overrides)
+	})
+
+	t.Run("should return empty values without overrides", func(t *testing.T) {
+		assert.Empty(t, getConfigOverrides(map[string]interface{}{RmiNamespaceConfig: "monitoring-system"}))
+	})
+}
+
+func Test_generateOverrideMap_withConfigOverrides(t *testing.T) {
+	context := fixActionContext("fakeURL")
+	context.Task.Configuration["rmi.overrides.vmagent.scrapeInterval"] = "30s"
+	context.Task.Configuration["rmi.overrides.runtime.region"] = "overridden"
+	context.Task.Configuration["rmi.overrides.auth"] = "overridden"
+
+	overrides := generateOverrideMap(context, context.Task.Metadata.InstanceID, "password", "6")
+
+	assert.Equal(t, map[string]interface{}{"scrapeInterval": "30s"}, overrides["vmagent"])
+	assertRMIConfig(t, context, overrides["vmalert"].(map[string]int)["group"], overrides)
+	assert.Equal(t, "password", overrides["auth"].(map[string]string)["password"])
+}
@@ -21,6 +21,8 @@ func init() {
 	reconciler.
 		// register reconciler pre-reconcile action (executed BEFORE reconciliation happens)
 		WithPreReconcileAction(NewIntegrationAction("runtime-monitoring-integration-reconcile", lazyClient)).
+		// register reconciler post-reconcile action (executed AFTER reconciliation happens)
+		WithPostReconcileAction(NewCredentialRotationCleanupAction("runtime-monitoring-integration-cleanup", lazyClient)).
 		// register reconciler post-delete action (executed AFTER deletion happens)
 		WithPostDeleteAction(NewIntegrationAction("runtime-monitoring-integration-delete", lazyClient))
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/avast/retry-go"
+	k8s "github.com/kyma-incubator/reconciler/pkg/kubernetes"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
 	"helm.sh/helm/v3/pkg/kube"
 	batchv1 "k8s.io/api/batch/v1"
@@ -15,8 +16,6 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
-	"k8s.io/client-go/tools/clientcmd"
-	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"strings"
 	"time"
@@ -67,6 +66,7 @@ func NewKubernetesClient(kubeconfig string, logger *zap.SugaredLogger, config *C
 	if err != nil {
 		return nil, err
 	}
+	config.ClientLimits.apply(restConfig)
 	mapper, err := getDiscoveryMapper(restConfig)
 	if err != nil {
 		return nil, err
@@ -184,6 +184,9 @@ func (g *kubeClientAdapter) Deploy(ctx context.Context, manifestTarget, namespac
 	if namespace == "" {
 		namespace = defaultNamespace
 	}
+	if g.config.StreamThreshold > 0 && len(manifestTarget) > g.config.StreamThreshold {
+		return g.deployStreamed(ctx, manifestTarget, namespace, interceptors)
+	}
 
 	unstructsTarget, err := g.applyInterceptors(manifestTarget, namespace, interceptors)
 	if err != nil {
@@ -222,6 +225,10 @@ func (g *kubeClientAdapter) applyInterceptors(manifestTarget string, namespace s
 	if err != nil {
 		return nil, err
 	}
+	return g.intercept(unstructsTarget, namespace, interceptors)
+}
+
+func (g *kubeClientAdapter) intercept(unstructsTarget []*unstructured.Unstructured, namespace string, interceptors []ResourceInterceptor) ([]*unstructured.Unstructured, error) {
 	//fill out the resourceListTarget map by kind
 	resourceListTarget := NewResourceList(unstructsTarget)
 
@@ -246,26 +253,78 @@ func (g *kubeClientAdapter) deployResources(ctx context.Context, infoOriginalLis
 		return nil, err
 	}
 
+	deployedResources, skipped, err := g.applyResources(ctx, pt, infoOriginalList, infoTargetList, crdGroupKinds)
+	if err != nil {
+		return nil, err
+	}
+	if skipped > 0 {
+		g.logger.Infof("Resumed deployment: %d of %d resources were already applied by a previous attempt",
+			skipped, len(infoTargetList))
+	}
+	return deployedResources, g.awaitDeployedResources(ctx, pt)
+}
+
+// applyResources applies the target resources and adds them to the progress tracker. It returns the number of
+// resources which were skipped because a previous attempt already applied them.
+func (g *kubeClientAdapter) applyResources(ctx context.Context, pt *progress.Tracker, infoOriginalList kube.ResourceList, infoTargetList kube.ResourceList, crdGroupKinds []schema.GroupKind) ([]*Resource, int, error) {
 	var deployedResources []*Resource
+	var skipped int
+	checkpoint := g.config.ApplyCheckpoint
+	outcomes := g.config.Outcomes
 	for _, infoTarget := range infoTargetList {
 		//Do intersect to make sure helmclient only do create/update but not delete resource which exists in original but not in target.
 		intersectOriginal := kube.ResourceList{infoTarget}.Intersect(infoOriginalList)
 		if len(intersectOriginal) == 0 {
-			return nil, fmt.Errorf("could not find intersect between original and target resource")
+			return nil, 0, fmt.Errorf("could not find intersect between original and target resource")
 		}
 
-		deployingResource := g.addWatchableResourceInfoToProgressTracker(infoTarget, pt)
+		deployingResource := newResourceFromInfo(infoTarget)
 		deployedResources = append(deployedResources, deployingResource)
 
-		err = g.deployResource(ctx, intersectOriginal[0], infoTarget, crdGroupKinds)
+		if err := setLastAppliedConfiguration(infoTarget); err != nil {
+			return nil, 0, errors.Wrapf(err, "failed to set last applied configuration of %s '%s' (namespace: %s)",
+				infoTarget.Object.GetObjectKind().GroupVersionKind().Kind, infoTarget.Name, infoTarget.Namespace)
+		}
+
+		targetHash := checkpoint.hash(infoTarget) //deployResource updates the object with the state of the API server
+		if checkpoint.isApplied(infoTarget, targetHash) {
+			//the previous attempt didn't confirm the readiness of the resource: it has to be tracked again
+			skipped++
+			outcomes.record(infoTarget, ResourceOutcomeUnchanged, nil)
+			g.addWatchableResourceInfoToProgressTracker(infoTarget, pt)
+			continue
+		}
+
+		outcome, err := g.deployResource(ctx, intersectOriginal[0], infoTarget, crdGroupKinds)
+		outcomes.record(infoTarget, outcome, err)
 		if err != nil {
+			checkpoint.invalidate(infoTarget)
 			g.logger.Errorf("Failed to apply Kubernetes unstructured entity: %s", err)
-			return nil, err
+			return nil, 0, err
+		}
+		checkpoint.record(infoTarget, targetHash)
+		if g.config.TrackChangedOnly && outcome == ResourceOutcomeUnchanged {
+			g.logger.Debugf("Kubernetes deployingResource '%v' is unchanged: its readiness isn't tracked", deployingResource)
+		} else {
+			g.addWatchableResourceInfoToProgressTracker(infoTarget, pt)
 		}
 		g.logger.Debugf("Kubernetes deployingResource '%v' successfully deployed", deployingResource)
 	}
+	return deployedResources, skipped, nil
+}
 
-	return deployedResources, pt.Watch(ctx, progress.ReadyState)
+// awaitDeployedResources waits until all applied resources are ready
+func (g *kubeClientAdapter) awaitDeployedResources(ctx context.Context, pt *progress.Tracker) error {
+	if g.config.OnApplied != nil {
+		g.config.OnApplied()
+	}
+	if err := pt.Watch(ctx, progress.ReadyState); err != nil {
+		return err
+	}
+	if g.config.OnReady != nil {
+		g.config.OnReady()
+	}
+	return nil
 }
 
 func (g *kubeClientAdapter) getUpdateStrategy(infoTarget *resource.Info) (UpdateStrategy, error) {
@@ -284,17 +343,19 @@ func (g *kubeClientAdapter) manifestToUnstructured(manifest string) ([]*unstruct
 	return unstructs, nil
 }
 
-func (g *kubeClientAdapter) addWatchableResourceInfoToProgressTracker(info *resource.Info, pt *progress.Tracker) *Resource {
-	res := &Resource{
+func newResourceFromInfo(info *resource.Info) *Resource {
+	return &Resource{
 		Name:      info.Name,
 		Kind:      info.Object.GetObjectKind().GroupVersionKind().Kind,
 		Namespace: info.Namespace,
 	}
-	watchable, nonWatchableErr := progress.NewWatchableResource(res.Kind)
+}
+
+func (g *kubeClientAdapter) addWatchableResourceInfoToProgressTracker(info *resource.Info, pt *progress.Tracker) {
+	watchable, nonWatchableErr := progress.NewWatchableResource(info.Object.GetObjectKind().GroupVersionKind().Kind)
 	if nonWatchableErr == nil {
-		pt.AddResourceWithInfo(watchable, res.Namespace, res.Name, info)
+		pt.AddResourceWithInfo(watchable, info.Namespace, info.Name, info)
 	}
-	return res
 }
 
 func getDiscoveryMapper(restConfig *rest.Config) (*restmapper.DeferredDiscoveryRESTMapper, error) {
@@ -309,9 +370,7 @@ func getDiscoveryMapper(restConfig *rest.Config) (*restmapper.DeferredDiscoveryR
 }
 
 func getRestConfig(kubeconfig string) (*rest.Config, error) {
-	return clientcmd.BuildConfigFromKubeconfigGetter("", func() (config *clientcmdapi.Config, e error) {
-		return clientcmd.Load([]byte(kubeconfig))
-	})
+	return k8s.NewRESTConfig([]byte(kubeconfig))
 }
 
 func (g *kubeClientAdapter) filterAndConvertToInfoList(unstructs []*unstructured.Unstructured, namespaceOverride string, ignoreNotMatchError bool) ([]*resource.Info, error) {
@@ -408,31 +467,100 @@ func (g *kubeClientAdapter) deleteResource(infoTarget *resource.Info) (*Resource
 	}, nil
 }
 
-func (g *kubeClientAdapter) deployResource(ctx context.Context, infoOriginal, infoTarget *resource.Info, crdGroupKinds []schema.GroupKind) error {
+func (g *kubeClientAdapter) deployResource(ctx context.Context, infoOriginal, infoTarget *resource.Info, crdGroupKinds []schema.GroupKind) (ResourceOutcome, error) {
 
 	strategy, err := g.getUpdateStrategy(infoTarget)
 	if err != nil {
-		return err
+		return ResourceOutcomeFailed, err
 	}
 	if strategy == SkipUpdateStrategy {
-		return nil
+		return ResourceOutcomeUnchanged, nil
 	}
 
-	infoOriginal, err = g.fetchExistingResourceAndConvertToInfo(ctx, infoOriginal, crdGroupKinds)
+	lastApplied, err := g.fetchLastAppliedAndConvertToInfo(ctx, infoTarget)
 	if err != nil {
-		return err
+		return ResourceOutcomeFailed, err
+	}
+	if lastApplied != nil {
+		infoOriginal = lastApplied
+	} else {
+		infoOriginal, err = g.fetchExistingResourceAndConvertToInfo(ctx, infoOriginal, crdGroupKinds)
+		if err != nil {
+			return ResourceOutcomeFailed, err
+		}
 	}
-	err = retry.Do(g.deployResourceFunc(infoOriginal, infoTarget, strategy),
-		retry.Attempts(uint(g.config.MaxRetries)),
-		retry.Delay(g.config.RetryDelay),
-		retry.LastErrorOnly(false),
-		retry.Context(context.Background()))
+	var previousVersion string
+	if g.config.Outcomes != nil || g.config.TrackChangedOnly { //the resource version reveals whether an update changed the resource
+		previousVersion = g.resourceVersion(ctx, infoTarget)
+	}
+	var result *kube.Result
+	description := fmt.Sprintf("%s '%s' (namespace: %s)",
+		infoTarget.Object.GetObjectKind().GroupVersionKind().Kind, infoTarget.Name, infoTarget.Namespace)
+	err = g.retryThrottled(ctx, description, func() error {
+		return retry.Do(g.deployResourceFunc(infoOriginal, infoTarget, strategy, &result),
+			retry.Attempts(uint(g.config.MaxRetries)),
+			retry.Delay(g.config.RetryDelay),
+			retry.LastErrorOnly(false),
+			retry.Context(context.Background()),
+			retry.RetryIf(func(err error) bool {
+				_, throttled := throttlingDelay(err, 0) //throttled updates are delayed by retryThrottled
+				return !throttled
+			}))
+	})
 
 	if err != nil {
-		return errors.Wrapf(err, "kubeClient failed to update %s '%s' (namespace: %s)",
+		return ResourceOutcomeFailed, errors.Wrapf(err, "kubeClient failed to update %s '%s' (namespace: %s)",
 			infoTarget.Object.GetObjectKind().GroupVersionKind().Kind, infoTarget.Name, infoTarget.Namespace)
 	}
-	return nil
+	switch {
+	case result != nil && len(result.Created) > 0:
+		return ResourceOutcomeCreated, nil
+	case previousVersion != "" && previousVersion == infoTarget.ResourceVersion:
+		return ResourceOutcomeUnchanged, nil
+	default:
+		return ResourceOutcomeUpdated, nil
+	}
+}
+
+// resourceVersion returns the version of the resource in the cluster (empty if it doesn't exist)
+func (g *kubeClientAdapter) resourceVersion(ctx context.Context, info *resource.Info) string {
+	if info.Mapping == nil {
+		return ""
+	}
+	existing, err := g.dynamicClient.Resource(info.Mapping.Resource).Namespace(info.Namespace).Get(ctx, info.Name, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	return existing.GetResourceVersion()
+}
+
+// fetchLastAppliedAndConvertToInfo returns the configuration applied by the last reconciliation as resource.Info
+// (nil if the resource doesn't exist or wasn't applied with the same API version)
+func (g *kubeClientAdapter) fetchLastAppliedAndConvertToInfo(ctx context.Context, info *resource.Info) (*resource.Info, error) {
+	if info.Mapping == nil {
+		return nil, nil
+	}
+	existingResource, err := g.dynamicClient.Resource(info.Mapping.Resource).Namespace(info.Namespace).Get(ctx, info.Name, metav1.GetOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	lastApplied, err := lastAppliedConfiguration(existingResource)
+	if err != nil {
+		g.logger.Warnf("Ignoring invalid last applied configuration of %s '%s' (namespace: %s): %s",
+			existingResource.GetKind(), info.Name, info.Namespace, err)
+		return nil, nil
+	}
+	if lastApplied == nil || lastApplied.GroupVersionKind() != info.Object.GetObjectKind().GroupVersionKind() {
+		return nil, nil
+	}
+	lastAppliedInfo, err := g.convertToInfo(lastApplied, info.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to convert last applied configuration")
+	}
+	return lastAppliedInfo, nil
 }
 
 // fetchExistingResourceAndConvertToInfo: skip non CR resources, get existing CR definitions from cluster, and convert as resource.Info
@@ -475,10 +603,11 @@ func containsGroupKind(groupKinds []schema.GroupKind, groupKind schema.GroupKind
 	return false
 }
 
-func (g *kubeClientAdapter) deployResourceFunc(infoOriginal, infoTarget *resource.Info, strategy UpdateStrategy) func() error {
+func (g *kubeClientAdapter) deployResourceFunc(infoOriginal, infoTarget *resource.Info, strategy UpdateStrategy, result **kube.Result) func() error {
 	return func() error {
 		replaceResource := strategy == ReplaceUpdateStrategy
-		_, err := g.helmClient.Update(kube.ResourceList{infoOriginal}, kube.ResourceList{infoTarget}, replaceResource)
+		var err error
+		*result, err = g.helmClient.Update(kube.ResourceList{infoOriginal}, kube.ResourceList{infoTarget}, replaceResource)
 		if err == nil {
 			g.logger.Debugf("kubeClient updated %s '%s' (namespace: %s) with stategy '%s' successfully ",
 				infoTarget.Object.GetObjectKind().GroupVersionKind().Kind, infoTarget.Name, infoTarget.Namespace, strategy)
@@ -645,6 +774,8 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifestTarget, namespac
 		g.logger.Debugf("Manifest data: %s", manifestTarget)
 		return nil, err
 	}
+	//delete dependents before the resources they depend on (e.g. custom resources before their CRDs)
+	resourceInfoTarget = sortForDeletion(resourceInfoTarget)
 	pt, err := g.newProgressTracker()
 	if err != nil {
 		return nil, err
@@ -654,9 +785,11 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifestTarget, namespac
 	for _, info := range resourceInfoTarget {
 		deletedResource, err := g.deleteResource(info)
 		if err != nil {
+			g.config.Outcomes.record(info, ResourceOutcomeFailed, err)
 			g.logger.Errorf("Failed to apply Kubernetes unstructured entity: %s", err)
 			return nil, err
 		}
+		g.config.Outcomes.record(info, ResourceOutcomeDeleted, nil)
 
 		deletedResources = append(deletedResources, deletedResource)
 
@@ -757,9 +890,18 @@ func (g *kubeClientAdapter) newProgressTracker() (*progress.Tracker, error) {
 	if err != nil {
 		return nil, err
 	}
+	var cache kubernetes.Interface
+	if g.config.InformerCache != nil {
+		cache = g.config.InformerCache.Client()
+	}
 	return progress.NewProgressTracker(clientSet, g.logger, progress.Config{
-		Interval: g.config.ProgressInterval,
-		Timeout:  g.config.ProgressTimeout,
+		Interval:       g.config.ProgressInterval,
+		Timeout:        g.config.ProgressTimeout,
+		Timeline:       g.config.ProgressTimeline,
+		ReadyThreshold: g.config.ReadyThreshold,
+		StallWarnings:  g.config.StallWarnings,
+		OnStall:        g.config.OnStall,
+		Cache:          cache,
 	})
 }
 
@@ -767,6 +909,14 @@ func (g *kubeClientAdapter) Clientset() (kubernetes.Interface, error) {
 	return kubernetes.NewForConfig(g.restConfig)
 }
 
+// readClientset returns the client of the informer cache if one is configured (used only for reads)
+func (g *kubeClientAdapter) readClientset() (kubernetes.Interface, error) {
+	if g.config.InformerCache != nil {
+		return g.config.InformerCache.Client(), nil
+	}
+	return g.Clientset()
+}
+
 func (g *kubeClientAdapter) ListGroupVersionResource(context context.Context, group string, version string, resource string, lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
 	gvr, err := g.mapper.ResourceFor(schema.GroupVersionResource{Resource: resource, Group: group, Version: version})
 	if err != nil {
@@ -788,7 +938,7 @@ func (g *kubeClientAdapter) GetDeployment(ctx context.Context, name, namespace s
 		namespace = defaultNamespace
 	}
 
-	clientset, err := g.Clientset()
+	clientset, err := g.readClientset()
 	if err != nil {
 		return nil, errors.Wrap(err, "error retrieving deployments")
 	}
@@ -809,7 +959,7 @@ func (g *kubeClientAdapter) GetStatefulSet(ctx context.Context, name, namespace
 		namespace = defaultNamespace
 	}
 
-	clientset, err := g.Clientset()
+	clientset, err := g.readClientset()
 	if err != nil {
 		return nil, errors.Wrap(err, "error retrieving statefulSet")
 	}
@@ -872,7 +1022,7 @@ func (g *kubeClientAdapter) GetPod(ctx context.Context, name, namespace string)
 		namespace = defaultNamespace
 	}
 
-	clientset, err := g.Clientset()
+	clientset, err := g.readClientset()
 	if err != nil {
 		return nil, errors.Wrap(err, "error retrieving pod")
 	}
@@ -914,7 +1064,7 @@ func (g *kubeClientAdapter) GetJob(ctx context.Context, name, namespace string)
 		namespace = defaultNamespace
 	}
 
-	clientset, err := g.Clientset()
+	clientset, err := g.readClientset()
 	if err != nil {
 		return nil, errors.Wrap(err, "error retrieving pvc")
 	}
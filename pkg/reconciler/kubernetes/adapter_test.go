@@ -2,12 +2,19 @@ package kubernetes
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	log "github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/test"
 	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -209,3 +216,270 @@ func TestKubernetesClient(t *testing.T) {
 	//TODO: test all getter methods
 
 }
+
+// fakeAPIServer serves namespaces and config maps and sets server-side fields like a real API server. Creating
+// the objects listed in failOnce fails once.
+type fakeAPIServer struct {
+	objects  map[string]map[string]interface{} //key: request path of the object
+	requests map[string]int                    //key: request path of the object
+	failOnce map[string]bool                   //key: request path of the object
+	created  []string                          //request paths of the created objects in creation order
+	version  int
+	mu       sync.Mutex
+}
+
+func newFakeAPIServer(t *testing.T, failOnce ...string) (*fakeAPIServer, string) {
+	srv := &fakeAPIServer{
+		objects:  make(map[string]map[string]interface{}),
+		requests: make(map[string]int),
+		failOnce: make(map[string]bool),
+	}
+	for _, path := range failOnce {
+		srv.failOnce[path] = true
+	}
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(httpSrv.Close)
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s
+  name: unittest
+contexts:
+- context:
+    cluster: unittest
+    user: unittest
+  name: unittest
+current-context: unittest
+users:
+- name: unittest
+  user:
+    token: unittest
+`, httpSrv.URL)
+	return srv, kubeconfig
+}
+
+func (s *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.URL.Path {
+	case "/api":
+		s.respond(w, http.StatusOK, &metav1.APIVersions{Versions: []string{"v1"}})
+		return
+	case "/apis":
+		s.respond(w, http.StatusOK, &metav1.APIGroupList{})
+		return
+	case "/api/v1":
+		s.respond(w, http.StatusOK, &metav1.APIResourceList{GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Verbs: metav1.Verbs{"get", "create", "patch"}},
+			{Name: "configmaps", Namespaced: true, Kind: "ConfigMap", Verbs: metav1.Verbs{"get", "create", "patch"}},
+		}})
+		return
+	}
+
+	path := r.URL.Path
+	if r.Method == http.MethodPost {
+		body := s.readObject(r)
+		path = fmt.Sprintf("%s/%s", path, body["metadata"].(map[string]interface{})["name"])
+		s.requests[path]++
+		if s.failOnce[path] {
+			delete(s.failOnce, path)
+			s.respondStatus(w, k8serr.NewInternalError(fmt.Errorf("unittest failure")))
+			return
+		}
+		s.objects[path] = s.withServerFields(body)
+		s.created = append(s.created, path)
+		s.respond(w, http.StatusCreated, s.objects[path])
+		return
+	}
+
+	s.requests[path]++
+	obj, ok := s.objects[path]
+	if !ok {
+		s.respondStatus(w, k8serr.NewNotFound(schema.GroupResource{}, path))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.respond(w, http.StatusOK, obj)
+	case http.MethodPatch:
+		s.objects[path] = s.withServerFields(obj)
+		s.respond(w, http.StatusOK, s.objects[path])
+	default:
+		s.respondStatus(w, k8serr.NewMethodNotSupported(schema.GroupResource{}, r.Method))
+	}
+}
+
+func (s *fakeAPIServer) readObject(r *http.Request) map[string]interface{} {
+	obj := make(map[string]interface{})
+	_ = json.NewDecoder(r.Body).Decode(&obj)
+	return obj
+}
+
+func (s *fakeAPIServer) withServerFields(obj map[string]interface{}) map[string]interface{} {
+	s.version++
+	metadata := obj["metadata"].(map[string]interface{})
+	metadata["resourceVersion"] = fmt.Sprintf("%d", s.version)
+	metadata["uid"] = fmt.Sprintf("uid-%s", metadata["name"])
+	metadata["creationTimestamp"] = "2022-01-01T00:00:00Z"
+	metadata["managedFields"] = []interface{}{map[string]interface{}{"manager": "unittest", "operation": "Update"}}
+	return obj
+}
+
+func (s *fakeAPIServer) respond(w http.ResponseWriter, code int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+func (s *fakeAPIServer) respondStatus(w http.ResponseWriter, err *k8serr.StatusError) {
+	status := err.Status()
+	status.Kind = "Status"
+	status.APIVersion = "v1"
+	s.respond(w, int(status.Code), &status)
+}
+
+func (s *fakeAPIServer) requestCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func TestDeployResumesFromCheckpoint(t *testing.T) {
+	const manifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm2
+data:
+  key: value
+`
+	const (
+		namespacePath = "/api/v1/namespaces/unittest"
+		cm1Path       = "/api/v1/namespaces/unittest/configmaps/cm1"
+		cm2Path       = "/api/v1/namespaces/unittest/configmaps/cm2"
+	)
+	srv, kubeconfig := newFakeAPIServer(t, cm2Path)
+
+	checkpoint := NewApplyCheckpoint()
+	outcomes := NewOutcomeRecorder()
+	kubeClient, err := NewKubernetesClient(kubeconfig, log.NewLogger(true), &Config{
+		MaxRetries:      1,
+		RetryDelay:      time.Millisecond,
+		ApplyCheckpoint: checkpoint,
+		Outcomes:        outcomes,
+	})
+	require.NoError(t, err)
+
+	//first attempt fails when cm2 is created
+	checkpoint.NextAttempt()
+	_, err = kubeClient.Deploy(context.Background(), manifest, "unittest")
+	require.Error(t, err)
+	require.Equal(t, 2, checkpoint.Applied()) //namespace and cm1
+	require.Equal(t, 1, checkpoint.Failed())
+
+	namespaceRequests := srv.requestCount(namespacePath)
+	cm1Requests := srv.requestCount(cm1Path)
+	cm2Requests := srv.requestCount(cm2Path)
+
+	//retry applies only cm2: already applied resources are not even fetched
+	checkpoint.NextAttempt()
+	_, err = kubeClient.Deploy(context.Background(), manifest, "unittest")
+	require.NoError(t, err)
+	require.Equal(t, namespaceRequests, srv.requestCount(namespacePath))
+	require.Equal(t, cm1Requests, srv.requestCount(cm1Path))
+	require.Greater(t, srv.requestCount(cm2Path), cm2Requests)
+	require.Equal(t, 3, checkpoint.Applied())
+	require.Zero(t, checkpoint.Failed())
+}
+
+func (s *fakeAPIServer) createdObjects() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.created...)
+}
+
+type batchRecorder struct {
+	batches []int
+}
+
+func (r *batchRecorder) Intercept(resources *ResourceCacheList, _ string) error {
+	r.batches = append(r.batches, resources.Len())
+	return nil
+}
+
+func TestDeployStreamed(t *testing.T) {
+	var manifest strings.Builder
+	var expectedCreated []string
+	for i := 1; i <= 5; i++ {
+		manifest.WriteString(fmt.Sprintf("---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm%d\ndata:\n  key: value\n", i))
+		expectedCreated = append(expectedCreated, fmt.Sprintf("/api/v1/namespaces/unittest/configmaps/cm%d", i))
+	}
+	srv, kubeconfig := newFakeAPIServer(t)
+
+	var progress []ApplyProgress
+	kubeClient, err := NewKubernetesClient(kubeconfig, log.NewLogger(true), &Config{
+		MaxRetries:      1,
+		RetryDelay:      time.Millisecond,
+		StreamThreshold: manifest.Len() - 1,
+		StreamBatchSize: 2,
+		OnApplyProgress: func(p ApplyProgress) {
+			progress = append(progress, p)
+		},
+	})
+	require.NoError(t, err)
+
+	recorder := &batchRecorder{}
+	deployed, err := kubeClient.Deploy(context.Background(), manifest.String(), "unittest", recorder)
+	require.NoError(t, err)
+	require.Len(t, deployed, 6)
+
+	//interceptors see one batch at a time, the namespace is added to the first batch
+	require.Equal(t, []int{3, 2, 1}, recorder.batches)
+
+	//namespace is created before all other resources
+	require.Equal(t, append([]string{"/api/v1/namespaces/unittest"}, expectedCreated...), srv.createdObjects())
+
+	require.Len(t, progress, 3)
+	require.Equal(t, []int{3, 5, 6}, []int{progress[0].Resources, progress[1].Resources, progress[2].Resources})
+	for i, p := range progress {
+		require.Equal(t, int64(manifest.Len()), p.TotalBytes)
+		require.LessOrEqual(t, p.Bytes, p.TotalBytes)
+		if i > 0 {
+			require.GreaterOrEqual(t, p.Bytes, progress[i-1].Bytes)
+		}
+	}
+	require.Equal(t, progress[2].TotalBytes, progress[2].Bytes)
+}
+
+func TestDeployNotStreamedByDefault(t *testing.T) {
+	const manifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+data:
+  key: value
+`
+	_, kubeconfig := newFakeAPIServer(t)
+	var streamed bool
+	kubeClient, err := NewKubernetesClient(kubeconfig, log.NewLogger(true), &Config{
+		MaxRetries: 1,
+		RetryDelay: time.Millisecond,
+		OnApplyProgress: func(_ ApplyProgress) {
+			streamed = true
+		},
+	})
+	require.NoError(t, err)
+
+	recorder := &batchRecorder{}
+	_, err = kubeClient.Deploy(context.Background(), manifest, "unittest", recorder)
+	require.NoError(t, err)
+	require.False(t, streamed)
+	require.Equal(t, []int{2}, recorder.batches)
+}
@@ -3,6 +3,9 @@ package kubernetes
 import (
 	"fmt"
 	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/informer"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
 )
 
 const (
@@ -10,6 +13,7 @@ const (
 	progressTrackerTimeout  = 2 * time.Minute
 	maxRetries              = 10
 	retryDelay              = 1 * time.Second
+	streamBatchSize         = 100
 )
 
 type Config struct {
@@ -17,6 +21,27 @@ type Config struct {
 	ProgressTimeout  time.Duration
 	MaxRetries       int
 	RetryDelay       time.Duration
+	ProgressTimeline *progress.Timeline       //optional: records the state checks of all progress trackers
+	ReadyThreshold   *progress.ReadyThreshold //optional: replicas of Deployments and DaemonSets which have to be ready
+	StallWarnings    []int                    //optional: percentages of the progress timeout after which pending resources are reported
+	OnStall          func(progress.Stall)     //optional: called for each resource which is reported as stalled
+	TrackChangedOnly bool                     //optional: await only resources which a deployment created or updated
+	ApplyCheckpoint  *ApplyCheckpoint         //optional: lets retried deployments skip already applied resources
+	OnApplied        func()                   //optional: called when all resources of a deployment were applied
+	OnReady          func()                   //optional: called when all deployed resources reached the ready state
+	Outcomes         *OutcomeRecorder         //optional: records what deployments and deletions did with each resource
+	StreamThreshold  int                      //optional: manifests larger than this number of bytes are applied streamed (0 = disabled)
+	StreamBatchSize  int                      //number of resources which are intercepted and applied together when streaming
+	OnApplyProgress  func(ApplyProgress)      //optional: called after each batch of a streamed deployment
+	InformerCache    *informer.Cache          //optional: serves the reads of progress trackers and typed getters
+	ClientLimits     ClientLimits             //optional: rate limits of the requests sent to the cluster
+}
+
+// ApplyProgress reports how far a streamed deployment got
+type ApplyProgress struct {
+	Resources  int   //applied resources
+	Bytes      int64 //processed bytes of the manifest
+	TotalBytes int64
 }
 
 func (c *Config) validate() error {
@@ -30,6 +55,13 @@ func (c *Config) validate() error {
 		return fmt.Errorf("config ProgressInterval cannot be < 0 (got %d)", c.ProgressInterval)
 	case c.ProgressTimeout < 0:
 		return fmt.Errorf("config ProgressTimeout cannot be < 0 (got %d)", c.ProgressTimeout)
+	case c.StreamThreshold < 0:
+		return fmt.Errorf("config StreamThreshold cannot be < 0 (got %d)", c.StreamThreshold)
+	case c.StreamBatchSize < 0:
+		return fmt.Errorf("config StreamBatchSize cannot be < 0 (got %d)", c.StreamBatchSize)
+	}
+	if err := c.ClientLimits.Validate(); err != nil {
+		return err
 	}
 
 	if c.MaxRetries == 0 {
@@ -44,5 +76,8 @@ func (c *Config) validate() error {
 	if c.ProgressTimeout == 0 {
 		c.ProgressTimeout = progressTrackerTimeout
 	}
+	if c.StreamBatchSize == 0 {
+		c.StreamBatchSize = streamBatchSize
+	}
 	return nil
 }
@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// deployStreamed applies a large manifest in batches of resources instead of holding all decoded resources in
+// memory (the manifest itself is still held by the caller). Interceptors are applied per batch: they see only the
+// resources of the current batch, that's why streaming has to be enabled explicitly (see Config.StreamThreshold).
+func (g *kubeClientAdapter) deployStreamed(ctx context.Context, manifestTarget, namespace string, interceptors []ResourceInterceptor) ([]*Resource, error) {
+	g.logger.Infof("Manifest has %d bytes: applying it streamed in batches of %d resources",
+		len(manifestTarget), g.config.StreamBatchSize)
+
+	crdGroupKinds, err := g.getCRDGroupKinds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pt, err := g.newProgressTracker()
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := NewManifestDecoder(strings.NewReader(manifestTarget))
+	var deployedResources []*Resource
+	var skipped, total int
+	firstBatch := true
+
+	applyBatch := func(batch []*unstructured.Unstructured) error {
+		var err error
+		if firstBatch {
+			//the namespace is created before any other resource (it's applied twice if the manifest defines it later)
+			firstBatch = false
+			if batch, err = g.addNamespaceUnstruct(batch, namespace); err != nil {
+				return err
+			}
+		}
+		unstructs, err := g.intercept(batch, namespace, interceptors)
+		if err != nil {
+			return err
+		}
+		infos, err := g.filterAndConvertToInfoList(unstructs, namespace, false)
+		if err != nil {
+			g.logger.Errorf("Failed to convert target unstructs data: %s", err)
+			return err
+		}
+		deployed, skippedInBatch, err := g.applyResources(ctx, pt, infos, infos, crdGroupKinds)
+		if err != nil {
+			return err
+		}
+		deployedResources = append(deployedResources, deployed...)
+		skipped += skippedInBatch
+		total += len(infos)
+		if g.config.OnApplyProgress != nil {
+			g.config.OnApplyProgress(ApplyProgress{
+				Resources:  total,
+				Bytes:      decoder.BytesRead(),
+				TotalBytes: int64(len(manifestTarget)),
+			})
+		}
+		return nil
+	}
+
+	batch := make([]*unstructured.Unstructured, 0, g.config.StreamBatchSize)
+	for {
+		unstruct, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			g.logger.Errorf("Failed to process manifest data to unstructured: %s", err)
+			return nil, err
+		}
+		batch = append(batch, unstruct)
+		if len(batch) < g.config.StreamBatchSize {
+			continue
+		}
+		if err := applyBatch(batch); err != nil {
+			return nil, err
+		}
+		batch = make([]*unstructured.Unstructured, 0, g.config.StreamBatchSize)
+	}
+	if len(batch) > 0 || firstBatch {
+		if err := applyBatch(batch); err != nil {
+			return nil, err
+		}
+	}
+
+	if skipped > 0 {
+		g.logger.Infof("Resumed deployment: %d of %d resources were already applied by a previous attempt",
+			skipped, total)
+	}
+	if len(deployedResources) == 0 {
+		g.logger.Warnf("Namespace '%s' was required for deploying the manifestTarget "+
+			"but no resources were finally deployed into it", namespace)
+	}
+	return deployedResources, g.awaitDeployedResources(ctx, pt)
+}
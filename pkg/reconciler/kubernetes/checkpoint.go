@@ -0,0 +1,123 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// ApplyCheckpoint remembers which resources were successfully applied by previous attempts of a deployment.
+// A retried deployment resumes with the first resource which wasn't applied yet (or failed) instead of
+// re-applying the whole manifest. Resources are only skipped if their target state didn't change since
+// they were applied.
+type ApplyCheckpoint struct {
+	attempt int
+	applied map[string]appliedResource //key: resource identifier
+	failed  map[string]struct{}        //key: resource identifier
+	mu      sync.Mutex
+}
+
+type appliedResource struct {
+	hash    string
+	attempt int
+}
+
+func NewApplyCheckpoint() *ApplyCheckpoint {
+	return &ApplyCheckpoint{
+		applied: make(map[string]appliedResource),
+		failed:  make(map[string]struct{}),
+	}
+}
+
+// NextAttempt has to be called before a deployment is retried: only resources applied by
+// previous attempts are skipped (an attempt can deploy the same resource multiple times)
+func (c *ApplyCheckpoint) NextAttempt() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempt++
+}
+
+// Applied returns the amount of resources which were successfully applied so far
+func (c *ApplyCheckpoint) Applied() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.applied)
+}
+
+// Failed returns the amount of resources which failed to be applied and weren't applied successfully afterwards
+func (c *ApplyCheckpoint) Failed() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.failed)
+}
+
+// hash returns the hash of the rendered target state of the resource (empty if no checkpoint is used). It has to be
+// computed before the resource is applied: applying updates the object with the state returned by the API server
+// (e.g. resourceVersion, uid, managedFields or status), which never matches the target state of a retry.
+func (c *ApplyCheckpoint) hash(info *resource.Info) string {
+	if c == nil {
+		return ""
+	}
+	hash, err := resourceHash(info)
+	if err != nil {
+		return ""
+	}
+	return hash
+}
+
+func (c *ApplyCheckpoint) isApplied(info *resource.Info, hash string) bool {
+	if c == nil || hash == "" {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	applied, ok := c.applied[resourceKey(info)]
+	return ok && applied.hash == hash && applied.attempt < c.attempt
+}
+
+func (c *ApplyCheckpoint) record(info *resource.Info, hash string) {
+	if c == nil || hash == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.applied[resourceKey(info)] = appliedResource{
+		hash:    hash,
+		attempt: c.attempt,
+	}
+	delete(c.failed, resourceKey(info))
+}
+
+func (c *ApplyCheckpoint) invalidate(info *resource.Info) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.applied, resourceKey(info))
+	c.failed[resourceKey(info)] = struct{}{}
+}
+
+func resourceKey(info *resource.Info) string {
+	return fmt.Sprintf("%s/%s/%s", info.Object.GetObjectKind().GroupVersionKind(), info.Namespace, info.Name)
+}
+
+func resourceHash(info *resource.Info) (string, error) {
+	data, err := json.Marshal(info.Object)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
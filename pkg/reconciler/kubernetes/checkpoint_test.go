@@ -0,0 +1,77 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestApplyCheckpoint(t *testing.T) {
+	newInfo := func(name, value string) *resource.Info {
+		return &resource.Info{
+			Name:      name,
+			Namespace: "unittest",
+			Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": name, "namespace": "unittest"},
+				"data":       map[string]interface{}{"key": value},
+			}},
+		}
+	}
+
+	record := func(checkpoint *ApplyCheckpoint, info *resource.Info) {
+		checkpoint.record(info, checkpoint.hash(info))
+	}
+	isApplied := func(checkpoint *ApplyCheckpoint, info *resource.Info) bool {
+		return checkpoint.isApplied(info, checkpoint.hash(info))
+	}
+
+	t.Run("Resources applied by previous attempts are skipped", func(t *testing.T) {
+		checkpoint := NewApplyCheckpoint()
+		checkpoint.NextAttempt()
+		record(checkpoint, newInfo("cm1", "value"))
+		require.False(t, isApplied(checkpoint, newInfo("cm1", "value"))) //same attempt has to re-apply
+
+		checkpoint.NextAttempt()
+		require.True(t, isApplied(checkpoint, newInfo("cm1", "value")))
+		require.False(t, isApplied(checkpoint, newInfo("cm2", "value")))
+		require.Equal(t, 1, checkpoint.Applied())
+	})
+
+	t.Run("Changed or failed resources are re-applied", func(t *testing.T) {
+		checkpoint := NewApplyCheckpoint()
+		record(checkpoint, newInfo("cm1", "value"))
+		record(checkpoint, newInfo("cm2", "value"))
+		checkpoint.invalidate(newInfo("cm2", "value"))
+		checkpoint.NextAttempt()
+
+		require.False(t, isApplied(checkpoint, newInfo("cm1", "changed")))
+		require.False(t, isApplied(checkpoint, newInfo("cm2", "value")))
+		require.Equal(t, 1, checkpoint.Failed())
+
+		record(checkpoint, newInfo("cm2", "value")) //failed resource was applied by retry
+		require.Zero(t, checkpoint.Failed())
+	})
+
+	t.Run("Hash ignores the state returned by the API server", func(t *testing.T) {
+		checkpoint := NewApplyCheckpoint()
+		info := newInfo("cm1", "value")
+		hash := checkpoint.hash(info)
+		info.Object.(*unstructured.Unstructured).SetResourceVersion("123") //set by applying the resource
+		checkpoint.record(info, hash)
+		checkpoint.NextAttempt()
+		require.True(t, isApplied(checkpoint, newInfo("cm1", "value")))
+	})
+
+	t.Run("Undefined checkpoint never skips resources", func(t *testing.T) {
+		var checkpoint *ApplyCheckpoint
+		checkpoint.NextAttempt()
+		record(checkpoint, newInfo("cm1", "value"))
+		require.False(t, isApplied(checkpoint, newInfo("cm1", "value")))
+		require.Zero(t, checkpoint.Applied())
+		require.Zero(t, checkpoint.Failed())
+	})
+}
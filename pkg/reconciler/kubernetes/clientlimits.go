@@ -0,0 +1,221 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	//the rate of an adaptive limiter is halved with each throttled request but doesn't drop below this rate
+	minAdaptiveQPS = 1
+	//successful requests after which the rate of an adaptive limiter is raised again
+	adaptiveRecoveryRequests = 50
+)
+
+// ClientLimits configure the client-side rate limiting of the requests sent to a cluster
+type ClientLimits struct {
+	QPS   float32 //0 = client-go default
+	Burst int     //0 = client-go default
+	//reduces the rate temporarily when the API server answers with '429 Too Many Requests'
+	Adaptive bool
+}
+
+func (l ClientLimits) Validate() error {
+	if l.QPS < 0 {
+		return fmt.Errorf("kube client QPS cannot be < 0 (got %.1f)", l.QPS)
+	}
+	if l.Burst < 0 {
+		return fmt.Errorf("kube client burst cannot be < 0 (got %d)", l.Burst)
+	}
+	return nil
+}
+
+// apply configures the rate limiter of the REST configuration: all clients which are created with the configuration
+// share its limits
+func (l ClientLimits) apply(restConfig *rest.Config) {
+	if l.QPS > 0 {
+		restConfig.QPS = l.QPS
+	}
+	if l.Burst > 0 {
+		restConfig.Burst = l.Burst
+	}
+	if !l.Adaptive {
+		return
+	}
+	qps, burst := restConfig.QPS, restConfig.Burst
+	if qps == 0 {
+		qps = rest.DefaultQPS
+	}
+	if burst == 0 {
+		burst = rest.DefaultBurst
+	}
+	limiter := newAdaptiveRateLimiter(qps, burst)
+	restConfig.RateLimiter = limiter
+	restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &throttlingRoundTripper{limiter: limiter, delegate: rt}
+	})
+}
+
+// SizeClassLimits override the client limits for clusters with at least the given amount of components
+type SizeClassLimits struct {
+	MinComponents int
+	QPS           float32
+	Burst         int
+}
+
+// ParseSizeClassLimits parses size classes in the format '<min components>:<qps>/<burst>' (e.g. '30:50/100')
+func ParseSizeClassLimits(values []string) ([]SizeClassLimits, error) {
+	var classes []SizeClassLimits
+	for _, value := range values {
+		minComponents, limits, ok := strings.Cut(strings.TrimSpace(value), ":")
+		qps, burst, ok2 := strings.Cut(limits, "/")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("kube client limits '%s' don't match the format '<min components>:<qps>/<burst>'", value)
+		}
+		class := SizeClassLimits{}
+		var err error
+		if class.MinComponents, err = strconv.Atoi(minComponents); err != nil || class.MinComponents < 0 {
+			return nil, fmt.Errorf("kube client limits '%s' define an invalid amount of components", value)
+		}
+		parsedQPS, err := strconv.ParseFloat(qps, 32)
+		if err != nil || parsedQPS <= 0 {
+			return nil, fmt.Errorf("kube client limits '%s' define an invalid QPS", value)
+		}
+		class.QPS = float32(parsedQPS)
+		if class.Burst, err = strconv.Atoi(burst); err != nil || class.Burst <= 0 {
+			return nil, fmt.Errorf("kube client limits '%s' define an invalid burst", value)
+		}
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].MinComponents < classes[j].MinComponents
+	})
+	return classes, nil
+}
+
+// LimitsForClusterSize returns the limits of the largest size class which applies to a cluster with the given amount
+// of components (the defaults if none applies)
+func LimitsForClusterSize(defaults ClientLimits, classes []SizeClassLimits, components int) ClientLimits {
+	limits := defaults
+	for _, class := range classes {
+		if components >= class.MinComponents {
+			limits.QPS = class.QPS
+			limits.Burst = class.Burst
+		}
+	}
+	return limits
+}
+
+// adaptiveRateLimiter is a token bucket whose rate is reduced when requests get throttled by the API server and
+// which recovers to the configured rate after a series of successful requests
+type adaptiveRateLimiter struct {
+	limiter   flowcontrol.RateLimiter
+	qps       float32
+	maxQPS    float32
+	burst     int
+	successes int
+	mu        sync.Mutex
+}
+
+func newAdaptiveRateLimiter(qps float32, burst int) *adaptiveRateLimiter {
+	return &adaptiveRateLimiter{
+		limiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		qps:     qps,
+		maxQPS:  qps,
+		burst:   burst,
+	}
+}
+
+func (l *adaptiveRateLimiter) current() flowcontrol.RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limiter
+}
+
+func (l *adaptiveRateLimiter) TryAccept() bool {
+	return l.current().TryAccept()
+}
+
+func (l *adaptiveRateLimiter) Accept() {
+	l.current().Accept()
+}
+
+func (l *adaptiveRateLimiter) Stop() {
+	l.current().Stop()
+}
+
+func (l *adaptiveRateLimiter) QPS() float32 {
+	return l.current().QPS()
+}
+
+func (l *adaptiveRateLimiter) Wait(ctx context.Context) error {
+	return l.current().Wait(ctx)
+}
+
+func (l *adaptiveRateLimiter) throttled() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.successes = 0
+	qps := l.qps / 2
+	if qps < minAdaptiveQPS {
+		qps = minAdaptiveQPS
+	}
+	l.setQPS(qps)
+}
+
+func (l *adaptiveRateLimiter) succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.qps >= l.maxQPS {
+		return
+	}
+	l.successes++
+	if l.successes < adaptiveRecoveryRequests {
+		return
+	}
+	l.successes = 0
+	qps := l.qps * 2
+	if qps > l.maxQPS {
+		qps = l.maxQPS
+	}
+	l.setQPS(qps)
+}
+
+func (l *adaptiveRateLimiter) setQPS(qps float32) {
+	if qps == l.qps {
+		return
+	}
+	l.qps = qps
+	l.limiter = flowcontrol.NewTokenBucketRateLimiter(qps, l.burst)
+}
+
+// throttlingRoundTripper reports the responses of the API server to the adaptive rate limiter
+type throttlingRoundTripper struct {
+	limiter  *adaptiveRateLimiter
+	delegate http.RoundTripper
+}
+
+func (rt *throttlingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.delegate.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		rt.limiter.throttled()
+	} else {
+		rt.limiter.succeeded()
+	}
+	return resp, err
+}
+
+func (rt *throttlingRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return rt.delegate
+}
@@ -0,0 +1,85 @@
+package kubernetes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func TestParseSizeClassLimits(t *testing.T) {
+	classes, err := ParseSizeClassLimits([]string{"30:50/100", " 10:20.5/40"})
+	require.NoError(t, err)
+	require.Equal(t, []SizeClassLimits{
+		{MinComponents: 10, QPS: 20.5, Burst: 40},
+		{MinComponents: 30, QPS: 50, Burst: 100},
+	}, classes)
+
+	for _, invalid := range []string{"30", "30:50", "many:50/100", "30:0/100", "30:50/-1"} {
+		_, err := ParseSizeClassLimits([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestLimitsForClusterSize(t *testing.T) {
+	defaults := ClientLimits{QPS: 10, Burst: 20, Adaptive: true}
+	classes := []SizeClassLimits{
+		{MinComponents: 10, QPS: 20, Burst: 40},
+		{MinComponents: 30, QPS: 50, Burst: 100},
+	}
+	require.Equal(t, defaults, LimitsForClusterSize(defaults, classes, 5))
+	require.Equal(t, ClientLimits{QPS: 20, Burst: 40, Adaptive: true}, LimitsForClusterSize(defaults, classes, 10))
+	require.Equal(t, ClientLimits{QPS: 50, Burst: 100, Adaptive: true}, LimitsForClusterSize(defaults, classes, 42))
+}
+
+func TestClientLimitsApply(t *testing.T) {
+	restConfig := &rest.Config{}
+	ClientLimits{QPS: 30, Burst: 60}.apply(restConfig)
+	require.Equal(t, float32(30), restConfig.QPS)
+	require.Equal(t, 60, restConfig.Burst)
+	require.Nil(t, restConfig.RateLimiter)
+
+	restConfig = &rest.Config{}
+	ClientLimits{Adaptive: true}.apply(restConfig)
+	require.Equal(t, float32(rest.DefaultQPS), restConfig.RateLimiter.QPS())
+	require.NotNil(t, restConfig.WrapTransport)
+}
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	status := http.StatusTooManyRequests
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	limiter := newAdaptiveRateLimiter(8, 16)
+	client := &http.Client{Transport: &throttlingRoundTripper{limiter: limiter, delegate: http.DefaultTransport}}
+	request := func() {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	//each throttled request halves the rate until the minimum is reached
+	request()
+	require.Equal(t, float32(4), limiter.QPS())
+	for i := 0; i < 5; i++ {
+		request()
+	}
+	require.Equal(t, float32(minAdaptiveQPS), limiter.QPS())
+
+	//successful requests restore the configured rate step by step
+	status = http.StatusOK
+	for i := 0; i < adaptiveRecoveryRequests; i++ {
+		limiter.succeeded()
+	}
+	require.Equal(t, float32(2), limiter.QPS())
+	for i := 0; i < 3*adaptiveRecoveryRequests; i++ {
+		limiter.succeeded()
+	}
+	require.Equal(t, float32(8), limiter.QPS())
+	request() //doesn't exceed the configured rate
+	require.Equal(t, float32(8), limiter.QPS())
+}
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// deletion phases: resources are deleted phase by phase, the manifest order is kept within a phase
+const (
+	deletionPhaseBlockingWebhooks = iota //webhooks pointing at services which are deleted would block all other deletions
+	deletionPhaseCustomResources         //custom resources are deleted while their controllers can still finalize them
+	deletionPhaseWorkloads
+	deletionPhaseNamespaced
+	deletionPhaseClusterScoped
+	deletionPhaseWebhooks
+	deletionPhaseCRDs
+	deletionPhaseNamespaces
+)
+
+var deletionPhasesByKind = map[string]int{
+	"Deployment":                     deletionPhaseWorkloads,
+	"StatefulSet":                    deletionPhaseWorkloads,
+	"DaemonSet":                      deletionPhaseWorkloads,
+	"ReplicaSet":                     deletionPhaseWorkloads,
+	"Job":                            deletionPhaseWorkloads,
+	"CronJob":                        deletionPhaseWorkloads,
+	"Pod":                            deletionPhaseWorkloads,
+	"HorizontalPodAutoscaler":        deletionPhaseNamespaced,
+	"PodDisruptionBudget":            deletionPhaseNamespaced,
+	"Service":                        deletionPhaseNamespaced,
+	"Endpoints":                      deletionPhaseNamespaced,
+	"Ingress":                        deletionPhaseNamespaced,
+	"NetworkPolicy":                  deletionPhaseNamespaced,
+	"ConfigMap":                      deletionPhaseNamespaced,
+	"Secret":                         deletionPhaseNamespaced,
+	"ServiceAccount":                 deletionPhaseNamespaced,
+	"PersistentVolumeClaim":          deletionPhaseNamespaced,
+	"Role":                           deletionPhaseNamespaced,
+	"RoleBinding":                    deletionPhaseNamespaced,
+	"LimitRange":                     deletionPhaseNamespaced,
+	"ResourceQuota":                  deletionPhaseNamespaced,
+	"ClusterRole":                    deletionPhaseClusterScoped,
+	"ClusterRoleBinding":             deletionPhaseClusterScoped,
+	"APIService":                     deletionPhaseClusterScoped,
+	"PriorityClass":                  deletionPhaseClusterScoped,
+	"StorageClass":                   deletionPhaseClusterScoped,
+	"PersistentVolume":               deletionPhaseClusterScoped,
+	"PodSecurityPolicy":              deletionPhaseClusterScoped,
+	"ValidatingWebhookConfiguration": deletionPhaseWebhooks,
+	"MutatingWebhookConfiguration":   deletionPhaseWebhooks,
+	"CustomResourceDefinition":       deletionPhaseCRDs,
+	"Namespace":                      deletionPhaseNamespaces,
+}
+
+// sortForDeletion orders the resources by their deletion phase: custom resources first, followed by workloads and
+// the other namespaced resources, cluster-scoped resources, webhooks, CRDs and namespaces.
+// Webhooks which point at a service of the manifest are deleted first: they would reject deletions
+// as soon as their service is gone.
+func sortForDeletion(infos []*resource.Info) []*resource.Info {
+	services := make(map[string]bool)
+	for _, info := range infos {
+		if info.Object.GetObjectKind().GroupVersionKind().Kind == "Service" {
+			services[info.Namespace+"/"+info.Name] = true
+		}
+	}
+
+	phases := make(map[*resource.Info]int, len(infos))
+	for _, info := range infos {
+		phases[info] = deletionPhaseOf(info, services)
+	}
+
+	result := make([]*resource.Info, len(infos))
+	copy(result, infos)
+	sort.SliceStable(result, func(i, j int) bool {
+		return phases[result[i]] < phases[result[j]]
+	})
+	return result
+}
+
+func deletionPhaseOf(info *resource.Info, services map[string]bool) int {
+	phase, ok := deletionPhasesByKind[info.Object.GetObjectKind().GroupVersionKind().Kind]
+	if !ok {
+		return deletionPhaseCustomResources
+	}
+	if phase == deletionPhaseWebhooks && webhookUsesServices(info, services) {
+		return deletionPhaseBlockingWebhooks
+	}
+	return phase
+}
+
+// webhookUsesServices returns true if one of the webhooks of the configuration is served by one of the services
+func webhookUsesServices(info *resource.Info, services map[string]bool) bool {
+	u, ok := info.Object.(*unstructured.Unstructured)
+	if !ok {
+		return false
+	}
+	webhooks, _, _ := unstructured.NestedSlice(u.Object, "webhooks")
+	for _, webhook := range webhooks {
+		webhookMap, ok := webhook.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(webhookMap, "clientConfig", "service", "name")
+		namespace, _, _ := unstructured.NestedString(webhookMap, "clientConfig", "service", "namespace")
+		if name != "" && services[namespace+"/"+name] {
+			return true
+		}
+	}
+	return false
+}
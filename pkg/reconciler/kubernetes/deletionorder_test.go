@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestSortForDeletion(t *testing.T) {
+	newInfo := func(kind, namespace, name string, webhookService string) *resource.Info {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name": name,
+			},
+		}}
+		if webhookService != "" {
+			u.Object["webhooks"] = []interface{}{
+				map[string]interface{}{
+					"name": "webhook",
+					"clientConfig": map[string]interface{}{
+						"service": map[string]interface{}{
+							"name":      webhookService,
+							"namespace": "kyma-system",
+						},
+					},
+				},
+			}
+		}
+		return &resource.Info{Name: name, Namespace: namespace, Object: u}
+	}
+
+	infos := []*resource.Info{
+		newInfo("Namespace", "", "kyma-system", ""),
+		newInfo("CustomResourceDefinition", "", "functions.serverless.kyma-project.io", ""),
+		newInfo("ValidatingWebhookConfiguration", "", "external-webhook", "other-service"),
+		newInfo("ClusterRole", "", "controller", ""),
+		newInfo("Service", "kyma-system", "webhook-service", ""),
+		newInfo("Deployment", "kyma-system", "controller", ""),
+		newInfo("ValidatingWebhookConfiguration", "", "blocking-webhook", "webhook-service"),
+		newInfo("Function", "kyma-system", "function", ""),
+		newInfo("ConfigMap", "kyma-system", "config", ""),
+		newInfo("Deployment", "kyma-system", "webhook", ""),
+	}
+
+	var order []string
+	for _, info := range sortForDeletion(infos) {
+		order = append(order, info.Object.GetObjectKind().GroupVersionKind().Kind+"/"+info.Name)
+	}
+	require.Equal(t, []string{
+		"ValidatingWebhookConfiguration/blocking-webhook",
+		"Function/function",
+		"Deployment/controller",
+		"Deployment/webhook",
+		"Service/webhook-service",
+		"ConfigMap/config",
+		"ClusterRole/controller",
+		"ValidatingWebhookConfiguration/external-webhook",
+		"CustomResourceDefinition/functions.serverless.kyma-project.io",
+		"Namespace/kyma-system",
+	}, order)
+}
@@ -0,0 +1,463 @@
+// Package fake provides an in-memory implementation of kubernetes.Client for tests which can't rely on a real cluster.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	v1apps "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+const (
+	defaultNamespace = "default"
+	defaultHost      = "https://fake-cluster:6443"
+	// defaultKubeconfig is parsable (tasks are validated before they are processed) but never used to connect
+	defaultKubeconfig = `apiVersion: v1
+kind: Config
+current-context: fake
+clusters:
+- name: fake
+  cluster:
+    server: ` + defaultHost + `
+contexts:
+- name: fake
+  context:
+    cluster: fake
+    user: fake
+users:
+- name: fake
+  user:
+    token: fake-token
+`
+)
+
+// clusterScopedKinds are not namespaced: the fake client has no API discovery to detect this
+var clusterScopedKinds = map[string]bool{
+	"namespace":                      true,
+	"node":                           true,
+	"persistentvolume":               true,
+	"clusterrole":                    true,
+	"clusterrolebinding":             true,
+	"customresourcedefinition":       true,
+	"priorityclass":                  true,
+	"storageclass":                   true,
+	"mutatingwebhookconfiguration":   true,
+	"validatingwebhookconfiguration": true,
+	"apiservice":                     true,
+}
+
+type resourceKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// failure is a scripted error returned when a resource is deployed
+type failure struct {
+	err       error
+	remaining int //< 1 means the resource fails always
+}
+
+var _ kubernetes.Client = &Client{}
+
+// Client stores deployed resources in memory. Resources of built-in kinds are also added to a fake clientset,
+// so they are returned by the typed getters and by Clientset().
+type Client struct {
+	mu         sync.Mutex
+	kubeconfig string
+	clientset  *k8sfake.Clientset
+	resources  map[resourceKey]*unstructured.Unstructured
+	deployed   []string
+	deleted    []string
+
+	//scripted behaviour
+	host            string
+	cannedClientset k8s.Interface
+	clientsetErr    error
+	failures        map[resourceKey]*failure
+	readinessDelays map[resourceKey]time.Duration
+}
+
+// NewClient returns a fake client whose clientset is pre-populated with the given objects
+func NewClient(objects ...runtime.Object) *Client {
+	return &Client{
+		kubeconfig:      defaultKubeconfig,
+		clientset:       k8sfake.NewSimpleClientset(objects...),
+		resources:       make(map[resourceKey]*unstructured.Unstructured),
+		host:            defaultHost,
+		failures:        make(map[resourceKey]*failure),
+		readinessDelays: make(map[resourceKey]time.Duration),
+	}
+}
+
+// WithHost sets the host returned by GetHost
+func (c *Client) WithHost(host string) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.host = host
+	return c
+}
+
+// WithClientset sets the result returned by Clientset instead of the fake clientset backing the client
+func (c *Client) WithClientset(clientset k8s.Interface, err error) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cannedClientset = clientset
+	c.clientsetErr = err
+	return c
+}
+
+// FailResource lets Deploy return the error when the resource gets applied. The resource fails the given
+// number of times and is applied afterwards: use 0 to let it fail always.
+// Resources of the manifest which are applied before the failing resource are stored as usual.
+func (c *Client) FailResource(kind, name, namespace string, times int, err error) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures[newResourceKey(kind, name, namespace)] = &failure{err: err, remaining: times}
+	return c
+}
+
+// WithReadinessDelay lets Deploy wait the given duration before it reports the resource as ready.
+// Deploy returns the error of the context if it's closed before all deployed resources are ready.
+func (c *Client) WithReadinessDelay(kind, name, namespace string, delay time.Duration) *Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readinessDelays[newResourceKey(kind, name, namespace)] = delay
+	return c
+}
+
+// DeployedManifests returns all manifests passed to Deploy in the order they were applied
+func (c *Client) DeployedManifests() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.deployed...)
+}
+
+// DeletedManifests returns all manifests passed to Delete in the order they were deleted
+func (c *Client) DeletedManifests() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.deleted...)
+}
+
+// Resources returns copies of all resources which currently exist in the fake cluster
+func (c *Client) Resources() []*unstructured.Unstructured {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var result []*unstructured.Unstructured
+	for _, u := range c.resources {
+		result = append(result, u.DeepCopy())
+	}
+	return result
+}
+
+func (c *Client) Kubeconfig() string {
+	return c.kubeconfig
+}
+
+func (c *Client) GetHost() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.host
+}
+
+func (c *Client) Clientset() (k8s.Interface, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cannedClientset != nil || c.clientsetErr != nil {
+		return c.cannedClientset, c.clientsetErr
+	}
+	return c.clientset, nil
+}
+
+func (c *Client) Deploy(ctx context.Context, manifestTarget, namespace string, interceptors ...kubernetes.ResourceInterceptor) ([]*kubernetes.Resource, error) {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	unstructs, err := kubernetes.ToUnstructured([]byte(manifestTarget), true)
+	if err != nil {
+		return nil, err
+	}
+	unstructs = append(unstructs, newNamespace(namespace))
+
+	resources := kubernetes.NewResourceList(unstructs)
+	for _, interceptor := range interceptors {
+		if interceptor == nil {
+			continue
+		}
+		if err := interceptor.Intercept(resources, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	deployed, readinessDelay, err := c.apply(manifestTarget, namespace, resources)
+	if err != nil {
+		return deployed, err
+	}
+	return deployed, waitForReadiness(ctx, readinessDelay)
+}
+
+// apply stores the resources and returns the longest readiness delay of them
+func (c *Client) apply(manifest, namespace string, resources *kubernetes.ResourceCacheList) ([]*kubernetes.Resource, time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deployed = append(c.deployed, manifest)
+	var deployed []*kubernetes.Resource
+	var readinessDelay time.Duration
+	err := resources.Visit(func(u *unstructured.Unstructured) error {
+		if u.GetNamespace() == "" && !isClusterScoped(u.GetKind()) {
+			u.SetNamespace(namespace)
+		}
+		key := newResourceKey(u.GetKind(), u.GetName(), u.GetNamespace())
+		if err := c.scriptedFailure(key); err != nil {
+			return err
+		}
+		if err := c.store(u); err != nil {
+			return err
+		}
+		if delay := c.readinessDelays[key]; delay > readinessDelay {
+			readinessDelay = delay
+		}
+		deployed = append(deployed, toResource(u))
+		return nil
+	})
+	return deployed, readinessDelay, err
+}
+
+// scriptedFailure returns the scripted error of the resource (the caller has to hold the lock)
+func (c *Client) scriptedFailure(key resourceKey) error {
+	f, ok := c.failures[key]
+	if !ok {
+		return nil
+	}
+	if f.remaining > 0 {
+		f.remaining--
+		if f.remaining == 0 {
+			delete(c.failures, key)
+		}
+	}
+	return fmt.Errorf("failed to apply %s '%s': %w", key.kind, key.name, f.err)
+}
+
+func waitForReadiness(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) DeployByCompareWithOriginal(ctx context.Context, _, manifestTarget, namespace string, interceptors ...kubernetes.ResourceInterceptor) ([]*kubernetes.Resource, error) {
+	return c.Deploy(ctx, manifestTarget, namespace, interceptors...)
+}
+
+func (c *Client) Delete(_ context.Context, manifest, namespace string) ([]*kubernetes.Resource, error) {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	unstructs, err := kubernetes.ToUnstructured([]byte(manifest), true)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deleted = append(c.deleted, manifest)
+	var deleted []*kubernetes.Resource
+	for _, u := range unstructs {
+		if u.GetNamespace() == "" && !isClusterScoped(u.GetKind()) {
+			u.SetNamespace(namespace)
+		}
+		if c.remove(u.GetKind(), u.GetName(), u.GetNamespace()) {
+			deleted = append(deleted, toResource(u))
+		}
+	}
+	return deleted, nil
+}
+
+func (c *Client) DeleteResource(_ context.Context, kind, name, namespace string) (*kubernetes.Resource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.remove(kind, name, namespace) {
+		return nil, k8serr.NewNotFound(schema.GroupResource{Resource: kind}, name)
+	}
+	return &kubernetes.Resource{Kind: kind, Name: name, Namespace: namespace}, nil
+}
+
+func (c *Client) PatchUsingStrategy(_ context.Context, kind, name, namespace string, _ []byte, _ types.PatchType) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.resources[newResourceKey(kind, name, namespace)]; !ok {
+		return k8serr.NewNotFound(schema.GroupResource{Resource: kind}, name)
+	}
+	return nil //patches aren't applied to the stored resources
+}
+
+func (c *Client) Get(kind, name, namespace string) (*unstructured.Unstructured, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	u, ok := c.resources[newResourceKey(kind, name, namespace)]
+	if !ok {
+		return nil, k8serr.NewNotFound(schema.GroupResource{Resource: kind}, name)
+	}
+	return u.DeepCopy(), nil
+}
+
+func (c *Client) GetDeployment(ctx context.Context, name, namespace string) (*v1apps.Deployment, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(defaultIfEmpty(namespace)).Get(ctx, name, metav1.GetOptions{})
+	return deployment, ignoreNotFound(err)
+}
+
+func (c *Client) GetStatefulSet(ctx context.Context, name, namespace string) (*v1apps.StatefulSet, error) {
+	statefulSet, err := c.clientset.AppsV1().StatefulSets(defaultIfEmpty(namespace)).Get(ctx, name, metav1.GetOptions{})
+	return statefulSet, ignoreNotFound(err)
+}
+
+func (c *Client) GetSecret(ctx context.Context, name, namespace string) (*v1.Secret, error) {
+	secret, err := c.clientset.CoreV1().Secrets(defaultIfEmpty(namespace)).Get(ctx, name, metav1.GetOptions{})
+	return secret, ignoreNotFound(err)
+}
+
+func (c *Client) GetService(ctx context.Context, name, namespace string) (*v1.Service, error) {
+	service, err := c.clientset.CoreV1().Services(defaultIfEmpty(namespace)).Get(ctx, name, metav1.GetOptions{})
+	return service, ignoreNotFound(err)
+}
+
+func (c *Client) GetPod(ctx context.Context, name, namespace string) (*v1.Pod, error) {
+	pod, err := c.clientset.CoreV1().Pods(defaultIfEmpty(namespace)).Get(ctx, name, metav1.GetOptions{})
+	return pod, ignoreNotFound(err)
+}
+
+func (c *Client) GetJob(ctx context.Context, name, namespace string) (*batchv1.Job, error) {
+	job, err := c.clientset.BatchV1().Jobs(defaultIfEmpty(namespace)).Get(ctx, name, metav1.GetOptions{})
+	return job, ignoreNotFound(err)
+}
+
+func (c *Client) GetPersistentVolumeClaim(ctx context.Context, name, namespace string) (*v1.PersistentVolumeClaim, error) {
+	pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(defaultIfEmpty(namespace)).Get(ctx, name, metav1.GetOptions{})
+	return pvc, ignoreNotFound(err)
+}
+
+func (c *Client) ListResource(_ context.Context, resource string, lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return c.list(func(gvr schema.GroupVersionResource) bool {
+		return gvr.Resource == strings.ToLower(resource)
+	}, lo)
+}
+
+func (c *Client) ListGroupVersionResource(_ context.Context, group string, version string, resource string, lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return c.list(func(gvr schema.GroupVersionResource) bool {
+		return gvr.Group == group && gvr.Version == version && gvr.Resource == strings.ToLower(resource)
+	}, lo)
+}
+
+func (c *Client) list(match func(gvr schema.GroupVersionResource) bool, lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	selector, err := metav1.ParseToLabelSelector(lo.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result := &unstructured.UnstructuredList{}
+	for _, u := range c.resources {
+		gvr, _ := meta.UnsafeGuessKindToResource(u.GroupVersionKind())
+		if match(gvr) && labelSelector.Matches(labels.Set(u.GetLabels())) {
+			result.Items = append(result.Items, *u.DeepCopy())
+		}
+	}
+	return result, nil
+}
+
+// store adds or replaces the resource (the caller has to hold the lock)
+func (c *Client) store(u *unstructured.Unstructured) error {
+	c.resources[newResourceKey(u.GetKind(), u.GetName(), u.GetNamespace())] = u.DeepCopy()
+
+	obj, err := scheme.Scheme.New(u.GroupVersionKind())
+	if err != nil {
+		return nil //not a built-in kind: only available through Get and List
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return fmt.Errorf("failed to convert %s '%s' to typed object: %s", u.GetKind(), u.GetName(), err)
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(u.GroupVersionKind())
+	err = c.clientset.Tracker().Create(gvr, obj, u.GetNamespace())
+	if k8serr.IsAlreadyExists(err) {
+		err = c.clientset.Tracker().Update(gvr, obj, u.GetNamespace())
+	}
+	return err
+}
+
+// remove deletes the resource and returns true if it existed (the caller has to hold the lock)
+func (c *Client) remove(kind, name, namespace string) bool {
+	key := newResourceKey(kind, name, namespace)
+	u, ok := c.resources[key]
+	if !ok {
+		return false
+	}
+	delete(c.resources, key)
+	gvr, _ := meta.UnsafeGuessKindToResource(u.GroupVersionKind())
+	_ = c.clientset.Tracker().Delete(gvr, namespace, name) //not tracked if it's not a built-in kind
+	return true
+}
+
+func newResourceKey(kind, name, namespace string) resourceKey {
+	if isClusterScoped(kind) {
+		namespace = ""
+	}
+	return resourceKey{kind: strings.ToLower(kind), namespace: namespace, name: name}
+}
+
+func newNamespace(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("Namespace")
+	u.SetName(name)
+	return u
+}
+
+func toResource(u *unstructured.Unstructured) *kubernetes.Resource {
+	return &kubernetes.Resource{Kind: u.GetKind(), Name: u.GetName(), Namespace: u.GetNamespace()}
+}
+
+func isClusterScoped(kind string) bool {
+	return clusterScopedKinds[strings.ToLower(kind)]
+}
+
+func defaultIfEmpty(namespace string) string {
+	if namespace == "" {
+		return defaultNamespace
+	}
+	return namespace
+}
+
+func ignoreNotFound(err error) error {
+	if k8serr.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,122 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const manifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  labels:
+    app: test
+spec:
+  replicas: 1
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: app-role
+`
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Deploy and delete manifest", func(t *testing.T) {
+		client := NewClient()
+
+		resources, err := client.Deploy(ctx, manifest, "test-ns")
+		require.NoError(t, err)
+		require.Len(t, resources, 3) //incl. namespace
+		require.Equal(t, []string{manifest}, client.DeployedManifests())
+
+		deployment, err := client.GetDeployment(ctx, "app", "test-ns")
+		require.NoError(t, err)
+		require.NotNil(t, deployment)
+
+		clusterRole, err := client.Get("ClusterRole", "app-role", "")
+		require.NoError(t, err)
+		require.NotNil(t, clusterRole)
+
+		list, err := client.ListResource(ctx, "deployments", metav1.ListOptions{LabelSelector: "app=test"})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+
+		deleted, err := client.Delete(ctx, manifest, "test-ns")
+		require.NoError(t, err)
+		require.Len(t, deleted, 2)
+		require.Equal(t, []string{manifest}, client.DeletedManifests())
+
+		_, err = client.Get("Deployment", "app", "test-ns")
+		require.True(t, k8serr.IsNotFound(err))
+		deployment, err = client.GetDeployment(ctx, "app", "test-ns")
+		require.NoError(t, err)
+		require.Nil(t, deployment)
+	})
+
+	t.Run("Delete missing resource", func(t *testing.T) {
+		client := NewClient()
+		_, err := client.DeleteResource(ctx, "Deployment", "missing", "test-ns")
+		require.True(t, k8serr.IsNotFound(err))
+	})
+	t.Run("Fail resource", func(t *testing.T) {
+		failure := errors.New("scripted failure")
+		client := NewClient().FailResource("ClusterRole", "app-role", "", 1, failure)
+
+		resources, err := client.Deploy(ctx, manifest, "test-ns")
+		require.ErrorIs(t, err, failure)
+		require.Len(t, resources, 1) //deployment was applied before the cluster role
+		_, err = client.Get("ClusterRole", "app-role", "")
+		require.True(t, k8serr.IsNotFound(err))
+
+		//fails only once
+		_, err = client.Deploy(ctx, manifest, "test-ns")
+		require.NoError(t, err)
+		require.Len(t, client.DeployedManifests(), 2)
+	})
+
+	t.Run("Fail resource always", func(t *testing.T) {
+		client := NewClient().FailResource("Deployment", "app", "test-ns", 0, errors.New("scripted failure"))
+		for i := 0; i < 3; i++ {
+			_, err := client.Deploy(ctx, manifest, "test-ns")
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("Slow readiness", func(t *testing.T) {
+		client := NewClient().WithReadinessDelay("Deployment", "app", "test-ns", 100*time.Millisecond)
+
+		start := time.Now()
+		_, err := client.Deploy(ctx, manifest, "test-ns")
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		client.WithReadinessDelay("Deployment", "app", "test-ns", time.Minute)
+		_, err = client.Deploy(timeoutCtx, manifest, "test-ns")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Canned host and clientset", func(t *testing.T) {
+		client := NewClient()
+		require.Equal(t, defaultHost, client.GetHost())
+		require.Equal(t, "https://my-cluster", client.WithHost("https://my-cluster").GetHost())
+
+		clientset := k8sfake.NewSimpleClientset()
+		result, err := client.WithClientset(clientset, nil).Clientset()
+		require.NoError(t, err)
+		require.Same(t, clientset, result)
+
+		_, err = client.WithClientset(nil, errors.New("unreachable")).Clientset()
+		require.Error(t, err)
+	})
+}
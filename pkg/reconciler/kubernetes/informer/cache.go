@@ -0,0 +1,111 @@
+package informer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Caches shares one informer cache per cluster between the operations which reconcile the cluster concurrently.
+// The informers of a cluster are stopped as soon as the last operation released its cache.
+type Caches struct {
+	caches map[string]*Cache
+	mu     sync.Mutex
+}
+
+func NewCaches() *Caches {
+	return &Caches{caches: make(map[string]*Cache)}
+}
+
+// Acquire returns the cache of the cluster and the function which has to be called when the operation is finished
+func (c *Caches) Acquire(kubeconfig string) (*Cache, func(), error) {
+	key := clusterKey(kubeconfig)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clusterCache, ok := c.caches[key]
+	if !ok {
+		restConfig, err := k8s.NewRESTConfig([]byte(kubeconfig))
+		if err != nil {
+			return nil, nil, err
+		}
+		client, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		clusterCache = newCache(client)
+		c.caches[key] = clusterCache
+	}
+	clusterCache.refs++
+
+	var once sync.Once
+	return clusterCache, func() {
+		once.Do(func() {
+			c.release(key, clusterCache)
+		})
+	}, nil
+}
+
+func (c *Caches) release(key string, clusterCache *Cache) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clusterCache.refs--
+	if clusterCache.refs == 0 {
+		close(clusterCache.stop)
+		delete(c.caches, key)
+	}
+}
+
+// Clusters returns the number of clusters which are currently cached
+func (c *Caches) Clusters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.caches)
+}
+
+// clusterKey identifies a cluster by its kubeconfig: operations with different credentials don't share a cache
+func clusterKey(kubeconfig string) string {
+	hash := sha256.Sum256([]byte(kubeconfig))
+	return hex.EncodeToString(hash[:])
+}
+
+// Cache watches the resources of a cluster which are read by the reconciliations. The informer of a resource type
+// is started with its first read and is used as soon as it's synced: until then, and for resources which aren't
+// cached (yet), reads are sent to the API server.
+type Cache struct {
+	client  kubernetes.Interface
+	factory informers.SharedInformerFactory
+	stop    chan struct{}
+	refs    int //guarded by the mutex of Caches
+}
+
+func newCache(client kubernetes.Interface) *Cache {
+	return &Cache{
+		client:  client,
+		factory: informers.NewSharedInformerFactory(client, 0),
+		stop:    make(chan struct{}),
+	}
+}
+
+// Client returns a client which reads Deployments, DaemonSets, StatefulSets, ReplicaSets, Pods and Jobs from the
+// cache. All other requests are sent to the API server.
+func (c *Cache) Client() kubernetes.Interface {
+	return &cachedClientset{Interface: c.client, cache: c}
+}
+
+// synced starts the informer if it isn't running yet and returns whether it's synced
+func (c *Cache) synced(informer cache.SharedIndexInformer) bool {
+	select {
+	case <-c.stop:
+		return false //cache got released
+	default:
+	}
+	c.factory.Start(c.stop) //starts only informers which aren't running yet
+	return informer.HasSynced()
+}
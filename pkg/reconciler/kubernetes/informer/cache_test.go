@@ -0,0 +1,95 @@
+package informer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: test
+contexts:
+- context:
+    cluster: test
+    user: test
+  name: test
+current-context: test
+users:
+- name: test
+  user:
+    token: abc
+`
+
+func TestCachesAcquire(t *testing.T) {
+	caches := NewCaches()
+
+	cache1, release1, err := caches.Acquire(kubeconfig)
+	require.NoError(t, err)
+	cache2, release2, err := caches.Acquire(kubeconfig)
+	require.NoError(t, err)
+	require.Same(t, cache1, cache2)
+	require.Equal(t, 1, caches.Clusters())
+
+	release1()
+	release1() //releasing twice doesn't release the cache of the other operation
+	require.Equal(t, 1, caches.Clusters())
+
+	release2()
+	require.Equal(t, 0, caches.Clusters())
+	require.False(t, cache1.synced(cache1.factory.Apps().V1().Deployments().Informer()))
+
+	_, _, err = caches.Acquire("invalid")
+	require.Error(t, err)
+}
+
+func TestCachedClient(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "kyma-system"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "kyma-system"}},
+	)
+	var gets int
+	clientset.PrependReactor("get", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+
+	cache := newCache(clientset)
+	defer close(cache.stop)
+	client := cache.Client()
+
+	//the informer is started by the first read which is answered by the API server
+	_, err := client.AppsV1().Deployments("kyma-system").Get(context.Background(), "deploy", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, gets)
+	require.Eventually(t, func() bool {
+		return cache.factory.Apps().V1().Deployments().Informer().HasSynced()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	deployment, err := client.AppsV1().Deployments("kyma-system").Get(context.Background(), "deploy", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "deploy", deployment.Name)
+	require.Equal(t, 1, gets)
+
+	//resources which aren't cached are read from the API server
+	_, err = client.AppsV1().Deployments("kyma-system").Get(context.Background(), "missing", metav1.GetOptions{})
+	require.Error(t, err)
+	require.Equal(t, 2, gets)
+
+	//reads of other resource types and writes aren't affected
+	_, err = client.CoreV1().Pods("kyma-system").Get(context.Background(), "pod", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 3, gets)
+	require.NoError(t, client.CoreV1().Pods("kyma-system").Delete(context.Background(), "pod", metav1.DeleteOptions{}))
+}
@@ -0,0 +1,182 @@
+package informer
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	appsclient "k8s.io/client-go/kubernetes/typed/apps/v1"
+	batchclient "k8s.io/client-go/kubernetes/typed/batch/v1"
+	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// cachedClientset serves reads from the cache and delegates everything else to the API server
+type cachedClientset struct {
+	kubernetes.Interface
+	cache *Cache
+}
+
+func (c *cachedClientset) AppsV1() appsclient.AppsV1Interface {
+	return &cachedAppsV1{AppsV1Interface: c.Interface.AppsV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) CoreV1() coreclient.CoreV1Interface {
+	return &cachedCoreV1{CoreV1Interface: c.Interface.CoreV1(), cache: c.cache}
+}
+
+func (c *cachedClientset) BatchV1() batchclient.BatchV1Interface {
+	return &cachedBatchV1{BatchV1Interface: c.Interface.BatchV1(), cache: c.cache}
+}
+
+// cacheable returns true if a get can be answered by the cache (reads of a specific version are not)
+func cacheable(opts metav1.GetOptions) bool {
+	return opts.ResourceVersion == ""
+}
+
+type cachedAppsV1 struct {
+	appsclient.AppsV1Interface
+	cache *Cache
+}
+
+func (c *cachedAppsV1) Deployments(namespace string) appsclient.DeploymentInterface {
+	return &cachedDeployments{DeploymentInterface: c.AppsV1Interface.Deployments(namespace), namespace: namespace, cache: c.cache}
+}
+
+func (c *cachedAppsV1) DaemonSets(namespace string) appsclient.DaemonSetInterface {
+	return &cachedDaemonSets{DaemonSetInterface: c.AppsV1Interface.DaemonSets(namespace), namespace: namespace, cache: c.cache}
+}
+
+func (c *cachedAppsV1) StatefulSets(namespace string) appsclient.StatefulSetInterface {
+	return &cachedStatefulSets{StatefulSetInterface: c.AppsV1Interface.StatefulSets(namespace), namespace: namespace, cache: c.cache}
+}
+
+func (c *cachedAppsV1) ReplicaSets(namespace string) appsclient.ReplicaSetInterface {
+	return &cachedReplicaSets{ReplicaSetInterface: c.AppsV1Interface.ReplicaSets(namespace), namespace: namespace, cache: c.cache}
+}
+
+type cachedDeployments struct {
+	appsclient.DeploymentInterface
+	namespace string
+	cache     *Cache
+}
+
+func (c *cachedDeployments) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.Deployment, error) {
+	informer := c.cache.factory.Apps().V1().Deployments()
+	if cacheable(opts) && c.cache.synced(informer.Informer()) {
+		if deployment, err := informer.Lister().Deployments(c.namespace).Get(name); err == nil {
+			return deployment.DeepCopy(), nil
+		}
+	}
+	return c.DeploymentInterface.Get(ctx, name, opts)
+}
+
+type cachedDaemonSets struct {
+	appsclient.DaemonSetInterface
+	namespace string
+	cache     *Cache
+}
+
+func (c *cachedDaemonSets) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.DaemonSet, error) {
+	informer := c.cache.factory.Apps().V1().DaemonSets()
+	if cacheable(opts) && c.cache.synced(informer.Informer()) {
+		if daemonSet, err := informer.Lister().DaemonSets(c.namespace).Get(name); err == nil {
+			return daemonSet.DeepCopy(), nil
+		}
+	}
+	return c.DaemonSetInterface.Get(ctx, name, opts)
+}
+
+type cachedStatefulSets struct {
+	appsclient.StatefulSetInterface
+	namespace string
+	cache     *Cache
+}
+
+func (c *cachedStatefulSets) Get(ctx context.Context, name string, opts metav1.GetOptions) (*appsv1.StatefulSet, error) {
+	informer := c.cache.factory.Apps().V1().StatefulSets()
+	if cacheable(opts) && c.cache.synced(informer.Informer()) {
+		if statefulSet, err := informer.Lister().StatefulSets(c.namespace).Get(name); err == nil {
+			return statefulSet.DeepCopy(), nil
+		}
+	}
+	return c.StatefulSetInterface.Get(ctx, name, opts)
+}
+
+type cachedReplicaSets struct {
+	appsclient.ReplicaSetInterface
+	namespace string
+	cache     *Cache
+}
+
+// List serves label selections from the cache (other selections are sent to the API server)
+func (c *cachedReplicaSets) List(ctx context.Context, opts metav1.ListOptions) (*appsv1.ReplicaSetList, error) {
+	informer := c.cache.factory.Apps().V1().ReplicaSets()
+	if opts.FieldSelector == "" && opts.ResourceVersion == "" && opts.Limit == 0 && c.cache.synced(informer.Informer()) {
+		selector, err := labels.Parse(opts.LabelSelector)
+		if err == nil {
+			replicaSets, err := informer.Lister().ReplicaSets(c.namespace).List(selector)
+			if err == nil {
+				list := &appsv1.ReplicaSetList{}
+				for _, replicaSet := range replicaSets {
+					list.Items = append(list.Items, *replicaSet.DeepCopy())
+				}
+				return list, nil
+			}
+		}
+	}
+	return c.ReplicaSetInterface.List(ctx, opts)
+}
+
+type cachedCoreV1 struct {
+	coreclient.CoreV1Interface
+	cache *Cache
+}
+
+func (c *cachedCoreV1) Pods(namespace string) coreclient.PodInterface {
+	return &cachedPods{PodInterface: c.CoreV1Interface.Pods(namespace), namespace: namespace, cache: c.cache}
+}
+
+type cachedPods struct {
+	coreclient.PodInterface
+	namespace string
+	cache     *Cache
+}
+
+func (c *cachedPods) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Pod, error) {
+	informer := c.cache.factory.Core().V1().Pods()
+	if cacheable(opts) && c.cache.synced(informer.Informer()) {
+		if pod, err := informer.Lister().Pods(c.namespace).Get(name); err == nil {
+			return pod.DeepCopy(), nil
+		}
+	}
+	return c.PodInterface.Get(ctx, name, opts)
+}
+
+type cachedBatchV1 struct {
+	batchclient.BatchV1Interface
+	cache *Cache
+}
+
+func (c *cachedBatchV1) Jobs(namespace string) batchclient.JobInterface {
+	return &cachedJobs{JobInterface: c.BatchV1Interface.Jobs(namespace), namespace: namespace, cache: c.cache}
+}
+
+type cachedJobs struct {
+	batchclient.JobInterface
+	namespace string
+	cache     *Cache
+}
+
+func (c *cachedJobs) Get(ctx context.Context, name string, opts metav1.GetOptions) (*batchv1.Job, error) {
+	informer := c.cache.factory.Batch().V1().Jobs()
+	if cacheable(opts) && c.cache.synced(informer.Informer()) {
+		if job, err := informer.Lister().Jobs(c.namespace).Get(name); err == nil {
+			return job.DeepCopy(), nil
+		}
+	}
+	return c.JobInterface.Get(ctx, name, opts)
+}
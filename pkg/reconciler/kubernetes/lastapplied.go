@@ -0,0 +1,61 @@
+package kubernetes
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// LastAppliedConfigAnnotation stores the configuration of a resource which was applied by the last reconciliation.
+// It is the original used for the three-way merge of the last applied, the target and the live configuration:
+// fields which were dropped from the manifest get removed, fields set by users or controllers are kept.
+const LastAppliedConfigAnnotation = "reconciler.kyma-project.io/last-applied-configuration"
+
+// maxLastAppliedConfigSize keeps the annotation below the size limit of all annotations of a resource (256 KB)
+const maxLastAppliedConfigSize = 128 * 1024
+
+// setLastAppliedConfiguration annotates the target resource with its own configuration. Resources which are too
+// large get an empty annotation to ensure an outdated configuration isn't used as original by later reconciliations.
+func setLastAppliedConfiguration(info *resource.Info) error {
+	u, ok := info.Object.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	annotations := u.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	delete(annotations, LastAppliedConfigAnnotation)
+
+	lastApplied := u.DeepCopy()
+	lastApplied.SetAnnotations(annotations)
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(lastApplied.Object, "metadata", "annotations")
+	}
+	data, err := json.Marshal(lastApplied.Object)
+	if err != nil {
+		return err
+	}
+	if len(data) > maxLastAppliedConfigSize {
+		annotations[LastAppliedConfigAnnotation] = ""
+	} else {
+		annotations[LastAppliedConfigAnnotation] = string(data)
+	}
+	u.SetAnnotations(annotations)
+	return nil
+}
+
+// lastAppliedConfiguration returns the configuration which was applied by the last reconciliation or nil if the
+// live resource doesn't provide it
+func lastAppliedConfiguration(live *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	data := live.GetAnnotations()[LastAppliedConfigAnnotation]
+	if data == "" {
+		return nil, nil
+	}
+	lastApplied := &unstructured.Unstructured{}
+	if err := lastApplied.UnmarshalJSON([]byte(data)); err != nil {
+		return nil, err
+	}
+	return lastApplied, nil
+}
@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestLastAppliedConfiguration(t *testing.T) {
+	newConfigMap := func(value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "test",
+				"namespace": "unittest",
+			},
+			"data": map[string]interface{}{
+				"key": value,
+			},
+			"replicas": int64(2),
+		}}
+	}
+
+	t.Run("Last applied configuration is stored and restored", func(t *testing.T) {
+		target := newConfigMap("value")
+		info := &resource.Info{Object: target}
+		require.NoError(t, setLastAppliedConfiguration(info))
+		annotation := target.GetAnnotations()[LastAppliedConfigAnnotation]
+		require.NotEmpty(t, annotation)
+		require.NotContains(t, annotation, LastAppliedConfigAnnotation)
+
+		//setting it again doesn't nest the previous configuration
+		require.NoError(t, setLastAppliedConfiguration(info))
+		require.Equal(t, annotation, target.GetAnnotations()[LastAppliedConfigAnnotation])
+
+		lastApplied, err := lastAppliedConfiguration(target)
+		require.NoError(t, err)
+		require.Equal(t, newConfigMap("value"), lastApplied)
+	})
+
+	t.Run("Large resources get an empty annotation", func(t *testing.T) {
+		target := newConfigMap(strings.Repeat("x", maxLastAppliedConfigSize))
+		require.NoError(t, setLastAppliedConfiguration(&resource.Info{Object: target}))
+		annotation, ok := target.GetAnnotations()[LastAppliedConfigAnnotation]
+		require.True(t, ok)
+		require.Empty(t, annotation)
+
+		lastApplied, err := lastAppliedConfiguration(target)
+		require.NoError(t, err)
+		require.Nil(t, lastApplied)
+	})
+
+	t.Run("Resources without annotation have no last applied configuration", func(t *testing.T) {
+		lastApplied, err := lastAppliedConfiguration(newConfigMap("value"))
+		require.NoError(t, err)
+		require.Nil(t, lastApplied)
+	})
+}
@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+type ResourceOutcome string
+
+const (
+	ResourceOutcomeCreated   ResourceOutcome = "created"
+	ResourceOutcomeUpdated   ResourceOutcome = "updated"
+	ResourceOutcomeUnchanged ResourceOutcome = "unchanged"
+	ResourceOutcomeDeleted   ResourceOutcome = "deleted"
+	ResourceOutcomeFailed    ResourceOutcome = "failed"
+)
+
+// ResourceResult describes what a deployment or deletion did with a resource
+type ResourceResult struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+	Outcome    ResourceOutcome
+	Error      string
+}
+
+// OutcomeRecorder tracks the outcome of each resource handled by the deployments and deletions of an operation.
+// If a resource is handled multiple times (e.g. by retries), the latest outcome wins: only an 'unchanged'
+// outcome doesn't replace a previous change of the resource.
+type OutcomeRecorder struct {
+	results map[string]*ResourceResult //key: resource identifier
+	mu      sync.Mutex
+}
+
+func NewOutcomeRecorder() *OutcomeRecorder {
+	return &OutcomeRecorder{
+		results: make(map[string]*ResourceResult),
+	}
+}
+
+// Results returns the outcomes of all recorded resources (ordered by API version, kind, namespace and name)
+func (r *OutcomeRecorder) Results() []ResourceResult {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]ResourceResult, 0, len(r.results))
+	for _, resourceResult := range r.results {
+		result = append(result, *resourceResult)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return outcomeKey(result[i]) < outcomeKey(result[j])
+	})
+	return result
+}
+
+func (r *OutcomeRecorder) record(info *resource.Info, outcome ResourceOutcome, err error) {
+	if r == nil {
+		return
+	}
+	gvk := info.Object.GetObjectKind().GroupVersionKind()
+	resourceResult := &ResourceResult{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  info.Namespace,
+		Name:       info.Name,
+		Outcome:    outcome,
+	}
+	if err != nil {
+		resourceResult.Error = err.Error()
+	}
+	r.Record(*resourceResult)
+}
+
+// Record adds the outcome of a resource which was handled outside of the deployments and deletions
+// (e.g. by a component action)
+func (r *OutcomeRecorder) Record(resourceResult ResourceResult) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := outcomeKey(resourceResult)
+	if previous, ok := r.results[key]; ok && resourceResult.Outcome == ResourceOutcomeUnchanged &&
+		previous.Outcome != ResourceOutcomeFailed {
+		return
+	}
+	r.results[key] = &resourceResult
+}
+
+func outcomeKey(result ResourceResult) string {
+	return fmt.Sprintf("%s/%s/%s/%s", result.APIVersion, result.Kind, result.Namespace, result.Name)
+}
@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestOutcomeRecorder(t *testing.T) {
+	newInfo := func(apiVersion, kind, name string) *resource.Info {
+		return &resource.Info{
+			Name:      name,
+			Namespace: "unittest",
+			Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": apiVersion,
+				"kind":       kind,
+				"metadata":   map[string]interface{}{"name": name, "namespace": "unittest"},
+			}},
+		}
+	}
+
+	t.Run("Outcomes of resources", func(t *testing.T) {
+		recorder := NewOutcomeRecorder()
+		recorder.record(newInfo("v1", "ConfigMap", "cm"), ResourceOutcomeUpdated, nil)
+		recorder.record(newInfo("apps/v1", "Deployment", "app"), ResourceOutcomeFailed, errors.New("invalid"))
+
+		require.Equal(t, []ResourceResult{
+			{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "unittest", Name: "app", Outcome: ResourceOutcomeFailed, Error: "invalid"},
+			{APIVersion: "v1", Kind: "ConfigMap", Namespace: "unittest", Name: "cm", Outcome: ResourceOutcomeUpdated},
+		}, recorder.Results())
+	})
+
+	t.Run("Retries keep the latest change", func(t *testing.T) {
+		recorder := NewOutcomeRecorder()
+		recorder.record(newInfo("v1", "ConfigMap", "cm1"), ResourceOutcomeCreated, nil)
+		recorder.record(newInfo("v1", "ConfigMap", "cm1"), ResourceOutcomeUnchanged, nil) //skipped by retry
+		recorder.record(newInfo("v1", "ConfigMap", "cm2"), ResourceOutcomeFailed, errors.New("conflict"))
+		recorder.record(newInfo("v1", "ConfigMap", "cm2"), ResourceOutcomeUnchanged, nil)
+
+		results := recorder.Results()
+		require.Len(t, results, 2)
+		require.Equal(t, ResourceOutcomeCreated, results[0].Outcome)
+		require.Equal(t, ResourceOutcomeUnchanged, results[1].Outcome)
+		require.Empty(t, results[1].Error)
+	})
+
+	t.Run("Outcomes recorded by actions", func(t *testing.T) {
+		recorder := NewOutcomeRecorder()
+		recorder.record(newInfo("apps/v1", "Deployment", "app"), ResourceOutcomeDeleted, nil)
+		recorder.Record(ResourceResult{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "unittest", Name: "app",
+			Outcome: ResourceOutcomeUnchanged})
+		recorder.Record(ResourceResult{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", Outcome: ResourceOutcomeFailed, Error: "forbidden"})
+		results := recorder.Results()
+		require.Len(t, results, 2)
+		require.Equal(t, ResourceOutcomeDeleted, results[0].Outcome)
+		require.Equal(t, "forbidden", results[1].Error)
+	})
+
+	t.Run("Undefined recorder ignores outcomes", func(t *testing.T) {
+		var recorder *OutcomeRecorder
+		recorder.record(newInfo("v1", "ConfigMap", "cm"), ResourceOutcomeCreated, nil)
+		require.Empty(t, recorder.Results())
+	})
+}
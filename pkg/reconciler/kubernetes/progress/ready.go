@@ -8,6 +8,7 @@ import (
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	appsclient "k8s.io/client-go/kubernetes/typed/apps/v1"
@@ -18,7 +19,7 @@ const expectedReadyReplicas = 1
 const expectedReadyDaemonSet = 1
 const ignorePodStateAnnotation = "reconciler.kyma-project.io/ignore-pod-state"
 
-func isDeploymentReady(ctx context.Context, client kubernetes.Interface, object *trackerResource) (bool, error) {
+func isDeploymentReady(ctx context.Context, client kubernetes.Interface, object *trackerResource, threshold *ReadyThreshold) (bool, error) {
 	deployment, err := client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
 	if err != nil {
 		return false, err
@@ -33,6 +34,18 @@ func isDeploymentReady(ctx context.Context, client kubernetes.Interface, object
 		return false, err
 	}
 
+	if threshold != nil {
+		desired := 1
+		if deployment.Spec.Replicas != nil {
+			desired = int(*deployment.Spec.Replicas)
+		}
+		var maxUnavailable *intstr.IntOrString
+		if deployment.Spec.Strategy.RollingUpdate != nil {
+			maxUnavailable = deployment.Spec.Strategy.RollingUpdate.MaxUnavailable
+		}
+		return int(replicaSet.Status.ReadyReplicas) >= threshold.requiredReplicas(desired, maxUnavailable), nil
+	}
+
 	isReady := replicaSet.Status.ReadyReplicas >= expectedReadyReplicas
 	return isReady, nil
 }
@@ -87,7 +100,7 @@ func isPodReady(ctx context.Context, client kubernetes.Interface, object *tracke
 	return pod.ObjectMeta.DeletionTimestamp == nil, nil
 }
 
-func isDaemonSetReady(ctx context.Context, client kubernetes.Interface, object *trackerResource) (bool, error) {
+func isDaemonSetReady(ctx context.Context, client kubernetes.Interface, object *trackerResource, threshold *ReadyThreshold) (bool, error) {
 	daemonSet, err := client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
 	if err != nil {
 		return false, err
@@ -97,6 +110,16 @@ func isDaemonSetReady(ctx context.Context, client kubernetes.Interface, object *
 		return true, nil
 	}
 
+	if threshold != nil {
+		//pods on broken nodes can neither be updated nor become ready
+		var maxUnavailable *intstr.IntOrString
+		if daemonSet.Spec.UpdateStrategy.RollingUpdate != nil {
+			maxUnavailable = daemonSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable
+		}
+		required := threshold.requiredReplicas(int(daemonSet.Status.DesiredNumberScheduled), maxUnavailable)
+		return int(daemonSet.Status.UpdatedNumberScheduled) >= required && int(daemonSet.Status.NumberReady) >= required, nil
+	}
+
 	if daemonSet.Status.UpdatedNumberScheduled != daemonSet.Status.DesiredNumberScheduled {
 		return false, nil
 	}
@@ -72,7 +72,7 @@ func TestIsDeploymentReady(t *testing.T) {
 
 	clientset := fake.NewSimpleClientset(objects...)
 
-	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"})
+	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"}, nil)
 
 	require.NoError(t, err)
 	require.True(t, ready)
@@ -113,7 +113,7 @@ func TestIsDeploymentNotReady(t *testing.T) {
 
 	clientset := fake.NewSimpleClientset(objects...)
 
-	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"})
+	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"}, nil)
 
 	require.NoError(t, err)
 	require.False(t, ready)
@@ -160,7 +160,7 @@ func TestIsDeploymentNotReadyWithIgnorePodStateAnnotation(t *testing.T) {
 
 	clientset := fake.NewSimpleClientset(objects...)
 
-	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"})
+	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"}, nil)
 
 	require.NoError(t, err)
 	require.True(t, ready)
@@ -217,7 +217,7 @@ func TestIsIgnoringOtherDeployments(t *testing.T) {
 
 	clientset := fake.NewSimpleClientset(objects...)
 
-	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"})
+	ready, err := isDeploymentReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"}, nil)
 
 	require.NoError(t, err)
 	require.False(t, ready)
@@ -300,7 +300,7 @@ func TestIsDaemonSetReady(t *testing.T) {
 
 			clientset := fake.NewSimpleClientset(daemonSet)
 
-			ready, err := isDaemonSetReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"})
+			ready, err := isDaemonSetReady(context.Background(), clientset, &trackerResource{name: "foo", namespace: "kyma-system"}, nil)
 
 			require.NoError(t, err)
 			require.Equal(t, tc.expected, ready)
@@ -0,0 +1,179 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Stall reports a resource which didn't reach its target state after a share of the timeout passed
+type Stall struct {
+	Resource    string
+	TargetState State
+	Reason      string //describes the current state of the resource
+	Elapsed     time.Duration
+	Timeout     time.Duration
+}
+
+func (s Stall) String() string {
+	return fmt.Sprintf("%s didn't reach state '%s' within %s (%.0f%% of the timeout): %s",
+		s.Resource, s.TargetState, s.Elapsed.Round(time.Second), 100*s.Elapsed.Seconds()/s.Timeout.Seconds(), s.Reason)
+}
+
+// stallWarnings emits a stall for each configured share of the timeout which passed since the watch was started
+type stallWarnings struct {
+	thresholds []time.Duration
+	emitted    int
+	start      time.Time
+}
+
+func newStallWarnings(percentages []int, timeout time.Duration) *stallWarnings {
+	sorted := append([]int{}, percentages...)
+	sort.Ints(sorted)
+	warnings := &stallWarnings{start: time.Now()}
+	for _, percentage := range sorted {
+		warnings.thresholds = append(warnings.thresholds, timeout*time.Duration(percentage)/100)
+	}
+	return warnings
+}
+
+// due returns true if the next threshold was passed (multiple passed thresholds are reported only once)
+func (w *stallWarnings) due() bool {
+	elapsed := time.Since(w.start)
+	due := false
+	for w.emitted < len(w.thresholds) && elapsed >= w.thresholds[w.emitted] {
+		w.emitted++
+		due = true
+	}
+	return due
+}
+
+// pendingReason describes why the resource didn't reach the target state yet
+func (pt *Tracker) pendingReason(ctx context.Context, object *trackerResource, targetState State) string {
+	if targetState == TerminatedState {
+		objMeta, err := pt.objectMeta(ctx, object)
+		switch {
+		case errors.IsNotFound(err):
+			return "resource got deleted"
+		case err != nil:
+			return fmt.Sprintf("failed to get resource: %s", err)
+		case len(objMeta.GetFinalizers()) > 0:
+			return fmt.Sprintf("resource still exists (finalizers: %s)", strings.Join(objMeta.GetFinalizers(), ", "))
+		default:
+			return "resource still exists"
+		}
+	}
+
+	var reason string
+	var err error
+	switch object.kind {
+	case Deployment:
+		reason, err = pt.deploymentReason(ctx, object)
+	case DaemonSet:
+		var daemonSet *appsv1.DaemonSet
+		daemonSet, err = pt.client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+		if err == nil {
+			reason = fmt.Sprintf("%d of %d pods are updated and %d are ready", daemonSet.Status.UpdatedNumberScheduled,
+				daemonSet.Status.DesiredNumberScheduled, daemonSet.Status.NumberReady)
+		}
+	case StatefulSet:
+		var statefulSet *appsv1.StatefulSet
+		statefulSet, err = pt.client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+		if err == nil {
+			reason = fmt.Sprintf("%d of %d replicas are updated and %d are ready", statefulSet.Status.UpdatedReplicas,
+				statefulSet.Status.Replicas, statefulSet.Status.ReadyReplicas)
+		}
+	case Pod:
+		var pod *corev1.Pod
+		pod, err = pt.client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+		if err == nil {
+			reason = podReason(pod)
+		}
+	case Job:
+		var job *batchv1.Job
+		job, err = pt.client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+		if err == nil {
+			reason = fmt.Sprintf("%d pods are active, %d succeeded and %d failed",
+				job.Status.Active, job.Status.Succeeded, job.Status.Failed)
+		}
+	case CustomResourceDefinition:
+		reason = "CRD is not established"
+	}
+	if err != nil {
+		return fmt.Sprintf("failed to get resource: %s", err)
+	}
+	return reason
+}
+
+func (pt *Tracker) deploymentReason(ctx context.Context, object *trackerResource) (string, error) {
+	deployment, err := pt.client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	reason := fmt.Sprintf("%d of %d replicas are updated and %d are ready", deployment.Status.UpdatedReplicas,
+		desired, deployment.Status.ReadyReplicas)
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Status == corev1.ConditionFalse && condition.Message != "" {
+			reason = fmt.Sprintf("%s (%s: %s)", reason, condition.Type, condition.Message)
+		}
+	}
+	return reason, nil
+}
+
+// podReason returns the phase of the pod and why its containers aren't running (e.g. 'CrashLoopBackOff')
+func podReason(pod *corev1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return "pod is terminating"
+	}
+	reasons := []string{fmt.Sprintf("pod is %s", pod.Status.Phase)}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue && condition.Reason != "" {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", condition.Type, condition.Reason))
+		}
+	}
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			reasons = append(reasons, fmt.Sprintf("container '%s' is waiting: %s", status.Name, status.State.Waiting.Reason))
+		}
+	}
+	return strings.Join(reasons, ", ")
+}
+
+func (pt *Tracker) objectMeta(ctx context.Context, object *trackerResource) (metav1.Object, error) {
+	switch object.kind {
+	case Pod:
+		return pt.client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case Deployment:
+		return pt.client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case DaemonSet:
+		return pt.client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case StatefulSet:
+		return pt.client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case Job:
+		return pt.client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case CustomResourceDefinition:
+		if object.info == nil {
+			return nil, fmt.Errorf("CRD was added without resource info")
+		}
+		if err := object.info.Get(); err != nil {
+			return nil, err
+		}
+		return meta.Accessor(object.info.Object)
+	default:
+		return nil, fmt.Errorf("resource type not supported: %s", object.kind)
+	}
+}
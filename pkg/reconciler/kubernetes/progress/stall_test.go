@@ -0,0 +1,72 @@
+package progress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStallWarningsDue(t *testing.T) {
+	warnings := newStallWarnings([]int{80, 50}, 10*time.Minute)
+	require.False(t, warnings.due())
+
+	warnings.start = time.Now().Add(-6 * time.Minute)
+	require.True(t, warnings.due())
+	require.False(t, warnings.due())
+
+	warnings.start = time.Now().Add(-9 * time.Minute)
+	require.True(t, warnings.due())
+	require.False(t, warnings.due())
+}
+
+func TestStallString(t *testing.T) {
+	stall := Stall{
+		Resource:    "Pod [namespace:kyma-system|name:foo]",
+		TargetState: ReadyState,
+		Reason:      "pod is Pending",
+		Elapsed:     5 * time.Minute,
+		Timeout:     10 * time.Minute,
+	}
+	require.Equal(t, "Pod [namespace:kyma-system|name:foo] didn't reach state 'ready' within 5m0s (50% of the timeout): pod is Pending",
+		stall.String())
+}
+
+func TestPendingReason(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deploy", Namespace: "kyma-system", Finalizers: []string{"foo/bar"}},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			UpdatedReplicas: 1,
+			ReadyReplicas:   1,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionFalse, Message: "quota exceeded"},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "kyma-system"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+			},
+		},
+	}
+	tracker := &Tracker{client: fake.NewSimpleClientset(deployment, pod)}
+
+	require.Equal(t, "1 of 2 replicas are updated and 1 are ready (ReplicaFailure: quota exceeded)",
+		tracker.pendingReason(context.Background(), &trackerResource{kind: Deployment, name: "deploy", namespace: "kyma-system"}, ReadyState))
+	require.Equal(t, "pod is Pending, container 'app' is waiting: ImagePullBackOff",
+		tracker.pendingReason(context.Background(), &trackerResource{kind: Pod, name: "pod", namespace: "kyma-system"}, ReadyState))
+	require.Equal(t, "resource still exists (finalizers: foo/bar)",
+		tracker.pendingReason(context.Background(), &trackerResource{kind: Deployment, name: "deploy", namespace: "kyma-system"}, TerminatedState))
+	require.Equal(t, "resource got deleted",
+		tracker.pendingReason(context.Background(), &trackerResource{kind: Pod, name: "missing", namespace: "kyma-system"}, TerminatedState))
+}
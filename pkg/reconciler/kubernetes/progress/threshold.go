@@ -0,0 +1,86 @@
+package progress
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const thresholdMaxUnavailable = "maxUnavailable"
+
+// ReadyThreshold defines how many replicas of Deployments and DaemonSets have to be ready. It lets large rollouts
+// succeed although a few replicas cannot start, e.g. because of a single broken node.
+// If percentage and maxUnavailable are both defined, the lower number of replicas has to be ready.
+type ReadyThreshold struct {
+	Percentage     int  //share of the desired replicas which have to be ready (1-100)
+	MaxUnavailable bool //replicas the rolling update strategy allows to be unavailable don't have to be ready
+}
+
+// ParseReadyThreshold parses a threshold like '90%', 'maxUnavailable' or '90%,maxUnavailable'
+func ParseReadyThreshold(value string) (*ReadyThreshold, error) {
+	threshold := &ReadyThreshold{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if strings.EqualFold(part, thresholdMaxUnavailable) {
+			threshold.MaxUnavailable = true
+			continue
+		}
+		percentage, err := strconv.Atoi(strings.TrimSuffix(part, "%"))
+		if err != nil {
+			return nil, fmt.Errorf("ready threshold '%s' is invalid: expected a percentage (e.g. '90%%') or '%s'",
+				value, thresholdMaxUnavailable)
+		}
+		threshold.Percentage = percentage
+	}
+	return threshold, threshold.Validate()
+}
+
+func (t *ReadyThreshold) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if t.Percentage < 0 || t.Percentage > 100 {
+		return fmt.Errorf("ready threshold percentage has to be between 1 and 100 (got %d)", t.Percentage)
+	}
+	if t.Percentage == 0 && !t.MaxUnavailable {
+		return fmt.Errorf("ready threshold requires a percentage or '%s'", thresholdMaxUnavailable)
+	}
+	return nil
+}
+
+func (t *ReadyThreshold) String() string {
+	var parts []string
+	if t.Percentage > 0 {
+		parts = append(parts, fmt.Sprintf("%d%%", t.Percentage))
+	}
+	if t.MaxUnavailable {
+		parts = append(parts, thresholdMaxUnavailable)
+	}
+	return strings.Join(parts, ",")
+}
+
+// requiredReplicas returns the number of replicas which have to be ready. At least one replica is required
+// unless no replica is desired.
+func (t *ReadyThreshold) requiredReplicas(desired int, maxUnavailable *intstr.IntOrString) int {
+	if desired <= 0 {
+		return 0
+	}
+	required := desired
+	if t.Percentage > 0 {
+		required = int(math.Ceil(float64(desired) * float64(t.Percentage) / 100))
+	}
+	if t.MaxUnavailable && maxUnavailable != nil {
+		//rounded down like the rolling update of the controllers does it
+		unavailable, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, desired, false)
+		if err == nil && (t.Percentage == 0 || desired-unavailable < required) {
+			required = desired - unavailable
+		}
+	}
+	if required < 1 {
+		return 1
+	}
+	return required
+}
@@ -0,0 +1,85 @@
+package progress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseReadyThreshold(t *testing.T) {
+	threshold, err := ParseReadyThreshold("90%")
+	require.NoError(t, err)
+	require.Equal(t, &ReadyThreshold{Percentage: 90}, threshold)
+
+	threshold, err = ParseReadyThreshold("maxunavailable, 80")
+	require.NoError(t, err)
+	require.Equal(t, &ReadyThreshold{Percentage: 80, MaxUnavailable: true}, threshold)
+	require.Equal(t, "80%,maxUnavailable", threshold.String())
+
+	for _, invalid := range []string{"", "0%", "101%", "most"} {
+		_, err := ParseReadyThreshold(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestRequiredReplicas(t *testing.T) {
+	maxUnavailable := intstr.FromString("25%")
+	tests := []struct {
+		threshold *ReadyThreshold
+		desired   int
+		expected  int
+	}{
+		{threshold: &ReadyThreshold{Percentage: 90}, desired: 100, expected: 90},
+		{threshold: &ReadyThreshold{Percentage: 90}, desired: 3, expected: 3},
+		{threshold: &ReadyThreshold{Percentage: 50}, desired: 1, expected: 1},
+		{threshold: &ReadyThreshold{Percentage: 90}, desired: 0, expected: 0},
+		{threshold: &ReadyThreshold{MaxUnavailable: true}, desired: 10, expected: 8},
+		{threshold: &ReadyThreshold{MaxUnavailable: true}, desired: 3, expected: 3},
+		{threshold: &ReadyThreshold{Percentage: 90, MaxUnavailable: true}, desired: 10, expected: 8},
+		{threshold: &ReadyThreshold{Percentage: 70, MaxUnavailable: true}, desired: 10, expected: 7},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, test.threshold.requiredReplicas(test.desired, &maxUnavailable),
+			"%s of %d replicas", test.threshold, test.desired)
+	}
+}
+
+func TestIsDaemonSetReadyWithThreshold(t *testing.T) {
+	maxUnavailable := intstr.FromInt(1)
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "kyma-system"},
+		Spec: appsv1.DaemonSetSpec{
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{ //a single node is broken
+			DesiredNumberScheduled: 20,
+			UpdatedNumberScheduled: 19,
+			NumberReady:            19,
+		},
+	}
+	clientset := fake.NewSimpleClientset(daemonSet)
+	object := &trackerResource{name: "foo", namespace: "kyma-system"}
+
+	ready, err := isDaemonSetReady(context.Background(), clientset, object, nil)
+	require.NoError(t, err)
+	require.False(t, ready)
+
+	ready, err = isDaemonSetReady(context.Background(), clientset, object, &ReadyThreshold{Percentage: 90})
+	require.NoError(t, err)
+	require.True(t, ready)
+
+	ready, err = isDaemonSetReady(context.Background(), clientset, object, &ReadyThreshold{MaxUnavailable: true})
+	require.NoError(t, err)
+	require.True(t, ready)
+
+	ready, err = isDaemonSetReady(context.Background(), clientset, object, &ReadyThreshold{Percentage: 100})
+	require.NoError(t, err)
+	require.False(t, ready)
+}
@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"sync"
+	"time"
+)
+
+// TimelineEvent is a single state check of a watched resource
+type TimelineEvent struct {
+	Time        time.Time `json:"time"`
+	Resource    string    `json:"resource"`
+	TargetState State     `json:"targetState"`
+	InState     bool      `json:"inState"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// Timeline records the state checks executed by one or more progress trackers.
+// It's used to reconstruct how resources transitioned into their target state (e.g. for debugging purposes).
+type Timeline struct {
+	events []*TimelineEvent
+	mu     sync.Mutex
+}
+
+func NewTimeline() *Timeline {
+	return &Timeline{}
+}
+
+func (t *Timeline) record(object *trackerResource, targetState State, inState bool, err error) {
+	if t == nil {
+		return
+	}
+	event := &TimelineEvent{
+		Time:        time.Now().UTC(),
+		Resource:    object.String(),
+		TargetState: targetState,
+		InState:     inState,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+// Events returns the recorded events in chronological order
+func (t *Timeline) Events() []*TimelineEvent {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*TimelineEvent{}, t.events...)
+}
@@ -0,0 +1,35 @@
+package progress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTimeline(t *testing.T) {
+	timeline := NewTimeline()
+	pt, err := NewProgressTracker(fake.NewSimpleClientset(), zap.NewNop().Sugar(), Config{
+		Interval: 1 * time.Second,
+		Timeout:  1 * time.Minute,
+		Timeline: timeline,
+	})
+	require.NoError(t, err)
+
+	pt.AddResource(Pod, "default", "unittest")
+	require.NoError(t, pt.Watch(context.Background(), TerminatedState))
+
+	events := timeline.Events()
+	require.Len(t, events, 1)
+	require.Equal(t, TerminatedState, events[0].TargetState)
+	require.True(t, events[0].InState)
+	require.Contains(t, events[0].Resource, "unittest")
+
+	//recording into an undefined timeline is ignored
+	var undefined *Timeline
+	undefined.record(&trackerResource{kind: Pod}, ReadyState, true, nil)
+	require.Empty(t, undefined.Events())
+}
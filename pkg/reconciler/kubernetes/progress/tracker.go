@@ -39,6 +39,17 @@ func (o *trackerResource) String() string {
 type Config struct {
 	Interval time.Duration
 	Timeout  time.Duration
+	Timeline *Timeline //optional: records each state check of the watched resources
+	//optional: replicas of Deployments and DaemonSets which have to be ready (default: at least one replica of a
+	//Deployment and all replicas of a DaemonSet are updated and one of them is ready)
+	ReadyThreshold *ReadyThreshold
+	//optional: percentages of the timeout after which a resource which didn't reach the target state is reported
+	//(e.g. 50 and 80 escalate twice before the timeout is reached)
+	StallWarnings []int
+	OnStall       func(Stall) //optional: called for each reported resource (stalls are logged in any case)
+	//optional: client which reads the resources from an informer cache. It's used for the recurring checks: the
+	//initial check after a deployment always reads from the API server.
+	Cache kubernetes.Interface
 }
 
 func (ptc *Config) validate() error {
@@ -54,6 +65,15 @@ func (ptc *Config) validate() error {
 	if ptc.Timeout == 0 {
 		ptc.Timeout = defaultProgressTimeout
 	}
+	if err := ptc.ReadyThreshold.Validate(); err != nil {
+		return err
+	}
+	for _, percentage := range ptc.StallWarnings {
+		if percentage <= 0 || percentage >= 100 {
+			return fmt.Errorf("progress tracker stall warnings have to be between 1 and 99 percent of the timeout "+
+				"(got %d)", percentage)
+		}
+	}
 	if ptc.Timeout <= ptc.Interval {
 		return fmt.Errorf("progress tracker will never run because configured timeout "+
 			"is <= as the check interval :%.0f secs <= %.0f secs", ptc.Timeout.Seconds(), ptc.Interval.Seconds())
@@ -62,11 +82,17 @@ func (ptc *Config) validate() error {
 }
 
 type Tracker struct {
-	objects  []*trackerResource
-	client   kubernetes.Interface
-	interval time.Duration
-	timeout  time.Duration
-	logger   *zap.SugaredLogger
+	objects       []*trackerResource
+	client        kubernetes.Interface
+	cache         kubernetes.Interface
+	interval      time.Duration
+	timeout       time.Duration
+	timeline      *Timeline
+	ready         *ReadyThreshold
+	stallWarnings []int
+	onStall       func(Stall)
+	pending       *trackerResource //first resource which wasn't in the target state during the latest check
+	logger        *zap.SugaredLogger
 }
 
 func NewProgressTracker(client kubernetes.Interface, logger *zap.SugaredLogger, config Config) (*Tracker, error) {
@@ -75,10 +101,15 @@ func NewProgressTracker(client kubernetes.Interface, logger *zap.SugaredLogger,
 	}
 
 	return &Tracker{
-		client:   client,
-		interval: config.Interval,
-		timeout:  config.Timeout,
-		logger:   logger,
+		client:        client,
+		cache:         config.Cache,
+		interval:      config.Interval,
+		timeout:       config.Timeout,
+		timeline:      config.Timeline,
+		ready:         config.ReadyThreshold,
+		stallWarnings: config.StallWarnings,
+		onStall:       config.OnStall,
+		logger:        logger,
 	}, nil
 }
 
@@ -90,7 +121,7 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 	}
 
 	//initial installation status check
-	inState, err := pt.allWatchableInState(ctx, targetState)
+	inState, err := pt.allWatchableInState(ctx, pt.client, targetState)
 	if err != nil {
 		pt.logger.Warnf("Failed to verify initial Kubernetes resource state: %v", err)
 	}
@@ -101,12 +132,17 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 	}
 
 	//start verifying the installation status in an interval
+	client := pt.client
+	if pt.cache != nil {
+		client = pt.cache
+	}
+	stallWarnings := newStallWarnings(pt.stallWarnings, pt.timeout)
 	timer := time.NewTicker(pt.interval)
 	timeout := time.After(pt.timeout)
 	for {
 		select {
 		case <-timer.C:
-			inState, err := pt.allWatchableInState(ctx, targetState)
+			inState, err := pt.allWatchableInState(ctx, client, targetState)
 			if err != nil {
 				pt.logger.Warnf("Failed to check progress of resource transition to state '%s' "+
 					"but will retry until timeout is reached: %s", targetState, err)
@@ -116,6 +152,9 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 				pt.logger.Debugf("Watchable resources reached target state '%s'", targetState)
 				return nil
 			}
+			if stallWarnings.due() && pt.pending != nil {
+				pt.reportStall(ctx, targetState, time.Since(stallWarnings.start))
+			}
 		case <-ctx.Done():
 			pt.logger.Infof("Stop checking progress of resource transition to state '%s' "+
 				"because parent context got closed", targetState)
@@ -135,6 +174,21 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 	}
 }
 
+// reportStall warns that the pending resource didn't reach the target state yet
+func (pt *Tracker) reportStall(ctx context.Context, targetState State, elapsed time.Duration) {
+	stall := Stall{
+		Resource:    pt.pending.String(),
+		TargetState: targetState,
+		Reason:      pt.pendingReason(ctx, pt.pending, targetState),
+		Elapsed:     elapsed,
+		Timeout:     pt.timeout,
+	}
+	pt.logger.Warn(stall.String())
+	if pt.onStall != nil {
+		pt.onStall(stall)
+	}
+}
+
 func (pt *Tracker) AddResource(kind WatchableResource, namespace, name string) {
 	pt.objects = append(pt.objects, &trackerResource{
 		kind:      kind,
@@ -152,33 +206,33 @@ func (pt *Tracker) AddResourceWithInfo(kind WatchableResource, namespace, name s
 	})
 }
 
-func (pt *Tracker) allWatchableInState(ctx context.Context, targetState State) (bool, error) {
+func (pt *Tracker) allWatchableInState(ctx context.Context, client kubernetes.Interface, targetState State) (bool, error) {
 	switch targetState {
 	case ReadyState:
-		return pt.isInReadyState(ctx)
+		return pt.isInReadyState(ctx, client)
 	case TerminatedState:
-		return pt.isInTerminatedState(ctx)
+		return pt.isInTerminatedState(ctx, client)
 	default:
 		return false, fmt.Errorf("state '%s' not supported", targetState)
 	}
 }
 
-func (pt *Tracker) isInReadyState(ctx context.Context) (bool, error) {
+func (pt *Tracker) isInReadyState(ctx context.Context, client kubernetes.Interface) (bool, error) {
 	for _, object := range pt.objects {
 		var err error
 		ready := true
 
 		switch object.kind {
 		case Pod:
-			ready, err = isPodReady(ctx, pt.client, object)
+			ready, err = isPodReady(ctx, client, object)
 		case Deployment:
-			ready, err = isDeploymentReady(ctx, pt.client, object)
+			ready, err = isDeploymentReady(ctx, client, object, pt.ready)
 		case DaemonSet:
-			ready, err = isDaemonSetReady(ctx, pt.client, object)
+			ready, err = isDaemonSetReady(ctx, client, object, pt.ready)
 		case StatefulSet:
-			ready, err = isStatefulSetReady(ctx, pt.client, object)
+			ready, err = isStatefulSetReady(ctx, client, object)
 		case Job:
-			ready, err = isJobReady(ctx, pt.client, object)
+			ready, err = isJobReady(ctx, client, object)
 		case CustomResourceDefinition:
 			if object.info == nil {
 				return false, fmt.Errorf("please use AddResourceWithInfo instead of AddResource for progress tracking CRD resources")
@@ -189,6 +243,10 @@ func (pt *Tracker) isInReadyState(ctx context.Context) (bool, error) {
 			}
 		}
 
+		pt.timeline.record(object, ReadyState, ready && err == nil, err)
+		if err != nil || !ready {
+			pt.pending = object
+		}
 		if err != nil {
 			pt.logger.Errorf("Failed to get resource of %v: %s", object, err)
 			return false, err
@@ -204,21 +262,21 @@ func (pt *Tracker) isInReadyState(ctx context.Context) (bool, error) {
 
 }
 
-func (pt *Tracker) isInTerminatedState(ctx context.Context) (bool, error) {
+func (pt *Tracker) isInTerminatedState(ctx context.Context, client kubernetes.Interface) (bool, error) {
 	for _, object := range pt.objects {
 		var err error
 
 		switch object.kind {
 		case Pod:
-			_, err = pt.client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+			_, err = client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
 		case Deployment:
-			_, err = pt.client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+			_, err = client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
 		case DaemonSet:
-			_, err = pt.client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+			_, err = client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
 		case StatefulSet:
-			_, err = pt.client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+			_, err = client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
 		case Job:
-			_, err = pt.client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+			_, err = client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
 		case CustomResourceDefinition:
 			if object.info == nil {
 				err = fmt.Errorf("please use AddResourceWithInfo instead of AddResource for progress tracking CRD resources")
@@ -227,6 +285,14 @@ func (pt *Tracker) isInTerminatedState(ctx context.Context) (bool, error) {
 			}
 		}
 
+		if errors.IsNotFound(err) {
+			pt.timeline.record(object, TerminatedState, true, nil)
+		} else {
+			pt.timeline.record(object, TerminatedState, false, err)
+		}
+		if err == nil || !errors.IsNotFound(err) {
+			pt.pending = object
+		}
 		if err == nil {
 			pt.logger.Debugf("Termination of %s is still ongoing", object.name)
 			return false, nil
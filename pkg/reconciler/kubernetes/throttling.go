@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// maxThrottlingDelay limits the delay a throttling API server can request for a single retry
+const maxThrottlingDelay = 1 * time.Minute
+
+// throttlingMessages identify rejections of the API priority and fairness after the error lost its type
+// (e.g. helm joins the errors of an update into a plain message)
+var throttlingMessages = []string{
+	"the server has received too many requests and has asked us to try again later",
+	"Too many requests, please try again later",
+}
+
+// throttlingDelay returns true if the API server rejected a request because of its priority and fairness limits
+// (429, or 503 with a Retry-After header) and the delay it asked for (the fallback if the delay is unknown)
+func throttlingDelay(err error, fallback time.Duration) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var retryErr retry.Error
+	if errors.As(err, &retryErr) && len(retryErr.WrappedErrors()) > 0 {
+		wrapped := retryErr.WrappedErrors()
+		err = wrapped[len(wrapped)-1]
+	}
+
+	seconds, hasDelay := k8serr.SuggestsClientDelay(err)
+	throttled := k8serr.IsTooManyRequests(err) || (k8serr.IsServiceUnavailable(err) && hasDelay)
+	if !throttled {
+		for _, msg := range throttlingMessages {
+			if strings.Contains(err.Error(), msg) {
+				throttled = true
+				break
+			}
+		}
+	}
+	if !throttled {
+		return 0, false
+	}
+
+	delay := fallback
+	if hasDelay && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	if delay > maxThrottlingDelay {
+		delay = maxThrottlingDelay
+	}
+	return delay, true
+}
+
+// retryThrottled calls the function again as long as the API server throttles its requests. Throttled calls are
+// delayed as requested by the server and don't count as failed attempts: they are only stopped by the context.
+func (g *kubeClientAdapter) retryThrottled(ctx context.Context, description string, fn func() error) error {
+	for {
+		err := fn()
+		delay, throttled := throttlingDelay(err, g.config.RetryDelay)
+		if !throttled {
+			return err
+		}
+		g.logger.Infof("API server throttled the requests to %s: retrying in %s", description, delay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestThrottlingDelay(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	tests := []struct {
+		err       error
+		throttled bool
+		delay     time.Duration
+	}{
+		{err: nil},
+		{err: fmt.Errorf("connection refused")},
+		{err: k8serr.NewServiceUnavailable("etcd is down")},
+		{err: k8serr.NewTooManyRequests("throttled", 3), throttled: true, delay: 3 * time.Second},
+		{err: k8serr.NewTooManyRequests("throttled", 0), throttled: true, delay: time.Second},
+		{err: k8serr.NewTooManyRequests("throttled", 600), throttled: true, delay: maxThrottlingDelay},
+		{err: errors.Wrap(k8serr.NewTooManyRequestsError("throttled"), "failed to update"), throttled: true, delay: time.Second},
+		{err: k8serr.NewServerTimeout(deployments, "update", 2)},
+		{err: retry.Error{fmt.Errorf("conflict"), k8serr.NewTooManyRequests("throttled", 5)}, throttled: true, delay: 5 * time.Second},
+		{err: fmt.Errorf("failed to replace object: the server has received too many requests and has asked us to try again later"), throttled: true, delay: time.Second},
+	}
+	for _, test := range tests {
+		delay, throttled := throttlingDelay(test.err, time.Second)
+		require.Equal(t, test.throttled, throttled, "%v", test.err)
+		require.Equal(t, test.delay, delay, "%v", test.err)
+	}
+}
+
+func TestRetryThrottled(t *testing.T) {
+	adapter := &kubeClientAdapter{logger: logger.NewLogger(true), config: &Config{RetryDelay: time.Millisecond}}
+
+	var calls int
+	err := adapter.retryThrottled(context.Background(), "test", func() error {
+		calls++
+		if calls < 3 {
+			return k8serr.NewTooManyRequestsError("throttled")
+		}
+		return fmt.Errorf("failed")
+	})
+	require.EqualError(t, err, "failed")
+	require.Equal(t, 3, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = adapter.retryThrottled(ctx, "test", func() error {
+		calls++
+		return k8serr.NewTooManyRequestsError("throttled")
+	})
+	require.True(t, k8serr.IsTooManyRequests(err))
+	require.Equal(t, 1, calls)
+}
@@ -103,3 +103,56 @@ func newUnstructured(b []byte) (*unstructured.Unstructured, error) {
 		Object: m,
 	}, nil
 }
+
+// ManifestDecoder decodes the resources of a manifest one by one: in contrast to ToUnstructured, the memory
+// consumption is bounded by the size of the largest resource instead of the size of the whole manifest.
+type ManifestDecoder struct {
+	counter *countingReader
+	reader  *utilyaml.YAMLReader
+}
+
+func NewManifestDecoder(r io.Reader) *ManifestDecoder {
+	counter := &countingReader{reader: r}
+	return &ManifestDecoder{
+		counter: counter,
+		reader:  utilyaml.NewYAMLReader(bufio.NewReader(counter)),
+	}
+}
+
+// Next returns the next resource of the manifest or io.EOF if all resources were decoded
+func (d *ManifestDecoder) Next() (*unstructured.Unstructured, error) {
+	for {
+		yamlData, err := d.reader.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read yaml data")
+		}
+		jsonData, err := yamlToJson.YAMLToJSON(yamlData)
+		if err != nil {
+			return nil, err
+		}
+		if string(jsonData) == "null" {
+			//YAML didn't contain any valuable JSON data (e.g. just comments)
+			continue
+		}
+		return newUnstructured(jsonData)
+	}
+}
+
+// BytesRead returns the number of bytes read from the manifest so far (including buffered data)
+func (d *ManifestDecoder) BytesRead() int64 {
+	return d.counter.count
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
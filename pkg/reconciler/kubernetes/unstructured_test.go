@@ -0,0 +1,44 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestDecoder(t *testing.T) {
+	var manifest strings.Builder
+	manifest.WriteString("---\n# just a comment\n")
+	for i := 0; i < 250; i++ {
+		manifest.WriteString(fmt.Sprintf("---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm%d\n", i))
+	}
+
+	decoder := NewManifestDecoder(strings.NewReader(manifest.String()))
+	var names []string
+	for {
+		unstruct, err := decoder.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, "ConfigMap", unstruct.GetKind())
+		names = append(names, unstruct.GetName())
+	}
+	require.Len(t, names, 250)
+	require.Equal(t, "cm0", names[0])
+	require.Equal(t, "cm249", names[249])
+	require.Equal(t, int64(manifest.Len()), decoder.BytesRead())
+
+	unstructs, err := ToUnstructured([]byte(manifest.String()), true)
+	require.NoError(t, err)
+	require.Len(t, unstructs, len(names))
+}
+
+func TestManifestDecoderInvalidYAML(t *testing.T) {
+	decoder := NewManifestDecoder(strings.NewReader("kind: ConfigMap\nmetadata: [\n"))
+	_, err := decoder.Next()
+	require.Error(t, err)
+}
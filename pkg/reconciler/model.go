@@ -3,9 +3,11 @@ package reconciler
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
 type Configuration struct {
@@ -25,6 +27,8 @@ func NewStatus(status string) (Status, error) {
 		return StatusRunning, nil
 	case string(StatusSuccess):
 		return StatusSuccess, nil
+	case strings.ToLower(string(StatusVerificationFailed)):
+		return StatusVerificationFailed, nil
 	default:
 		return "", fmt.Errorf("status '%s' not found", status)
 	}
@@ -45,15 +49,26 @@ type Task struct {
 	Profile                string                 `json:"profile"`
 	Configuration          map[string]interface{} `json:"configuration"`
 	Kubeconfig             string                 `json:"kubeconfig"`
+	RuntimeID              string                 `json:"runtimeID"` //RuntimeID is optional and used to annotate the applied resources
 	Metadata               keb.Metadata           `json:"metadata"`
 	CallbackURL            string                 `json:"callbackURL"` //CallbackURL is mandatory when component-reconciler runs in separate process
 	CorrelationID          string                 `json:"correlationID"`
 	Repository             *Repository            `json:"repository"`
 	Type                   model.OperationType    `json:"type"` // Supported task types are: reconcile, delete
 	ComponentConfiguration ComponentConfiguration `json:"componentConfiguration"`
+	FeatureFlags           []string               `json:"featureFlags,omitempty"` //FeatureFlags enabled for the cluster
+	Components             []ClusterComponent     `json:"components,omitempty"`   //Components of the desired state of the cluster
 
 	//These fields are not part of HTTP request coming from reconciler-controller:
 	CallbackFunc func(msg *CallbackMessage) error `json:"-"` //CallbackFunc is mandatory when component-reconciler runs embedded in another process
+	TraceID      string                           `json:"-"` //TraceID is only set if the request was traced
+
+	//These fields are only supported by the v2 run API:
+	Timeout            time.Duration     `json:"-"` //Timeout of the operation (0 = worker timeout)
+	DryRun             bool              `json:"-"` //DryRun renders the manifests without applying them
+	Priority           Priority          `json:"-"`
+	NamespaceOverrides map[string]string `json:"-"` //NamespaceOverrides maps namespaces of the chart to target namespaces
+	ResourceFilters    *ResourceFilters  `json:"-"` //ResourceFilters select the rendered resources which are applied
 }
 
 func (r *Task) String() string {
@@ -61,38 +76,59 @@ func (r *Task) String() string {
 		r.Component, r.Version, r.Namespace, r.Profile, r.Type)
 }
 
+// FeatureFlagEnabled returns true if the mothership enabled the feature flag for the cluster
+func (r *Task) FeatureFlagEnabled(name string) bool {
+	for _, flag := range r.FeatureFlags {
+		if flag == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns a ValidationError listing all undefined or invalid fields
 func (r *Task) Validate() error {
-	//check mandatory fields are defined
-	var errFields []string
+	validationErr := &ValidationError{}
 	r.Component = strings.TrimSpace(r.Component)
 	if r.Component == "" {
-		errFields = append(errFields, "Component")
+		validationErr.Add("component", "is undefined")
 	}
 	r.Namespace = strings.TrimSpace(r.Namespace)
 	if r.Namespace == "" {
-		errFields = append(errFields, "Namespace")
+		validationErr.Add("namespace", "is undefined")
+	}
+	if err := validateVersion(r.Version); err != nil {
+		validationErr.Add("version", "'%s' is not a valid version: %s", r.Version, err)
 	}
 	r.Kubeconfig = strings.TrimSpace(r.Kubeconfig)
 	if r.Kubeconfig == "" {
-		errFields = append(errFields, "Kubeconfig")
+		validationErr.Add("kubeconfig", "is undefined")
+	} else if _, err := clientcmd.RESTConfigFromKubeConfig([]byte(r.Kubeconfig)); err != nil {
+		validationErr.Add("kubeconfig", "cannot be parsed: %s", err)
 	}
 	r.CallbackURL = strings.TrimSpace(r.CallbackURL)
 	if r.CallbackFunc == nil && r.CallbackURL == "" {
-		errFields = append(errFields, "CallbackFunc or CallbackURL")
+		validationErr.Add("callbackURL", "is undefined (required if no callback function is set)")
 	}
 	r.CorrelationID = strings.TrimSpace(r.CorrelationID)
 	if r.CorrelationID == "" {
-		errFields = append(errFields, "CorrelationID")
+		validationErr.Add("correlationID", "is undefined")
 	}
-	if r.Type == "" {
-		errFields = append(errFields, "Type")
-	}
-	//return aggregated error msg
-	var err error
-	if len(errFields) > 0 {
-		err = fmt.Errorf("mandatory fields are undefined: %s", strings.Join(errFields, ", "))
+	switch r.Type {
+	case "":
+		validationErr.Add("type", "is undefined")
+	case model.OperationTypeReconcile, model.OperationTypeDelete:
+	default:
+		validationErr.Add("type", "'%s' is not supported (supported types: %s, %s)",
+			r.Type, model.OperationTypeReconcile, model.OperationTypeDelete)
 	}
-	return err
+	return validationErr.ErrOrNil()
+}
+
+// ClusterComponent is a component which belongs to the desired state of a cluster
+type ClusterComponent struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
 }
 
 type Repository struct {
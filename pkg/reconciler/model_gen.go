@@ -3,6 +3,36 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.8.2 DO NOT EDIT.
 package reconciler
 
+import (
+	"time"
+)
+
+// Defines values for OperationPhasePhase.
+const (
+	OperationPhasePhaseApplied OperationPhasePhase = "applied"
+
+	OperationPhasePhaseCallbackSent OperationPhasePhase = "callbackSent"
+
+	OperationPhasePhaseReady OperationPhasePhase = "ready"
+
+	OperationPhasePhaseRendered OperationPhasePhase = "rendered"
+
+	OperationPhasePhaseWorkspaceReady OperationPhasePhase = "workspaceReady"
+)
+
+// Defines values for ResourceResultOutcome.
+const (
+	ResourceResultOutcomeCreated ResourceResultOutcome = "created"
+
+	ResourceResultOutcomeDeleted ResourceResultOutcome = "deleted"
+
+	ResourceResultOutcomeFailed ResourceResultOutcome = "failed"
+
+	ResourceResultOutcomeUnchanged ResourceResultOutcome = "unchanged"
+
+	ResourceResultOutcomeUpdated ResourceResultOutcome = "updated"
+)
+
 // Defines values for Status.
 const (
 	StatusError Status = "error"
@@ -14,15 +44,81 @@ const (
 	StatusRunning Status = "running"
 
 	StatusSuccess Status = "success"
+
+	StatusVerificationFailed Status = "verificationFailed"
 )
 
 // CallbackMessage defines model for callbackMessage.
 type CallbackMessage struct {
-	Error              string  `json:"error"`
-	Manifest           *string `json:"manifest,omitempty"`
-	ProcessingDuration int     `json:"processingDuration"`
-	RetryID            string  `json:"retryID"`
-	Status             Status  `json:"status"`
+	Attempt            *int              `json:"attempt,omitempty"`
+	Error              string            `json:"error"`
+	Manifest           *string           `json:"manifest,omitempty"`
+	Phases             *[]OperationPhase `json:"phases,omitempty"`
+	ProcessingDuration int               `json:"processingDuration"`
+
+	// outcome of each resource handled by the operation
+	ResourceResults *[]ResourceResult  `json:"resourceResults,omitempty"`
+	Resources       *ResourceSummary   `json:"resources,omitempty"`
+	RetryID         string             `json:"retryID"`
+	Retryable       *bool              `json:"retryable,omitempty"`
+	SmokeTests      *[]SmokeTestResult `json:"smokeTests,omitempty"`
+	Status          Status             `json:"status"`
+	Version         *string            `json:"version,omitempty"`
+
+	// warnings about the progress of the running operation (e.g. resources which are not ready for a long time)
+	Warnings *[]string `json:"warnings,omitempty"`
+}
+
+// FailureCapture defines model for failureCapture.
+type FailureCapture struct {
+	Created time.Time `json:"created"`
+	Error   string    `json:"error"`
+
+	// payload of the failed operation (kubeconfig and sensitive values are redacted)
+	Task map[string]interface{} `json:"task"`
+
+	// values resolved for the component chart (sensitive values are redacted)
+	Values *map[string]interface{} `json:"values,omitempty"`
+
+	// reason why the values of the component chart couldn't be resolved
+	ValuesError *string `json:"valuesError,omitempty"`
+}
+
+// OperationPhase defines model for operationPhase.
+type OperationPhase struct {
+	Phase   OperationPhasePhase `json:"phase"`
+	Reached time.Time           `json:"reached"`
+}
+
+// OperationPhasePhase defines model for OperationPhase.Phase.
+type OperationPhasePhase string
+
+// ResourceSummary defines model for resourceSummary.
+type ResourceSummary struct {
+	Applied int `json:"applied"`
+	Failed  int `json:"failed"`
+}
+
+// ResourceResult defines model for resourceResult.
+type ResourceResult struct {
+	ApiVersion string                `json:"apiVersion"`
+	Error      *string               `json:"error,omitempty"`
+	Kind       string                `json:"kind"`
+	Name       string                `json:"name"`
+	Namespace  string                `json:"namespace"`
+	Outcome    ResourceResultOutcome `json:"outcome"`
+}
+
+// ResourceResultOutcome defines model for ResourceResult.Outcome.
+type ResourceResultOutcome string
+
+// SmokeTestResult defines model for smokeTestResult.
+type SmokeTestResult struct {
+	// milliseconds the smoke test took
+	Duration int64   `json:"duration"`
+	Error    *string `json:"error,omitempty"`
+	Name     string  `json:"name"`
+	Passed   bool    `json:"passed"`
 }
 
 // Status defines model for status.
@@ -33,3 +129,9 @@ type PostOperationsSchedulingIDCallbackCorrelationIDJSONBody CallbackMessage
 
 // PostOperationsSchedulingIDCallbackCorrelationIDJSONRequestBody defines body for PostOperationsSchedulingIDCallbackCorrelationID for application/json ContentType.
 type PostOperationsSchedulingIDCallbackCorrelationIDJSONRequestBody PostOperationsSchedulingIDCallbackCorrelationIDJSONBody
+
+// PutOperationsSchedulingIDCorrelationIDFailureJSONBody defines parameters for PutOperationsSchedulingIDCorrelationIDFailure.
+type PutOperationsSchedulingIDCorrelationIDFailureJSONBody FailureCapture
+
+// PutOperationsSchedulingIDCorrelationIDFailureJSONRequestBody defines body for PutOperationsSchedulingIDCorrelationIDFailure for application/json ContentType.
+type PutOperationsSchedulingIDCorrelationIDFailureJSONRequestBody PutOperationsSchedulingIDCorrelationIDFailureJSONBody
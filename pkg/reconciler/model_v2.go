@@ -0,0 +1,136 @@
+package reconciler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+type Priority string
+
+const (
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low" //low priority tasks don't occupy the workers reserved for normal tasks
+)
+
+func NewPriority(priority string) (Priority, error) {
+	switch strings.ToLower(priority) {
+	case "", string(PriorityNormal):
+		return PriorityNormal, nil
+	case string(PriorityLow):
+		return PriorityLow, nil
+	default:
+		return "", fmt.Errorf("priority '%s' not supported", priority)
+	}
+}
+
+// TaskV2 is the payload of the v2 run API. Compared to the v1 payload (Task), it accepts structured values
+// and per-operation options.
+type TaskV2 struct {
+	ComponentsReady        []string               `json:"componentsReady"`
+	Component              string                 `json:"component"`
+	Namespace              string                 `json:"namespace"`
+	Version                string                 `json:"version"`
+	URL                    string                 `json:"url"`
+	Profile                string                 `json:"profile"`
+	Values                 map[string]interface{} `json:"values"` //nested values like in a Helm values.yaml
+	Kubeconfig             string                 `json:"kubeconfig"`
+	RuntimeID              string                 `json:"runtimeID"`
+	Metadata               keb.Metadata           `json:"metadata"`
+	CallbackURL            string                 `json:"callbackURL"`
+	CorrelationID          string                 `json:"correlationID"`
+	Repository             *Repository            `json:"repository"`
+	Type                   model.OperationType    `json:"type"`
+	ComponentConfiguration ComponentConfiguration `json:"componentConfiguration"`
+	FeatureFlags           []string               `json:"featureFlags,omitempty"`
+	Components             []ClusterComponent     `json:"components,omitempty"`
+	Options                TaskOptions            `json:"options"`
+}
+
+type TaskOptions struct {
+	Timeout            string            `json:"timeout"` //duration like '10m' (limited by the worker timeout)
+	DryRun             bool              `json:"dryRun"`
+	Priority           string            `json:"priority"`
+	NamespaceOverrides map[string]string `json:"namespaceOverrides"` //key: namespace of the chart, value: target namespace
+	ResourceFilters    *ResourceFilters  `json:"resourceFilters"`
+}
+
+// Task converts the v2 payload into the task processed by the reconciler. Invalid options are reported as
+// ValidationError.
+func (t *TaskV2) Task() (*Task, error) {
+	validationErr := &ValidationError{}
+	var timeout time.Duration
+	if t.Options.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(t.Options.Timeout)
+		if err != nil {
+			validationErr.Add("options.timeout", "'%s' is not a valid duration: %s", t.Options.Timeout, err)
+		} else if timeout < 0 {
+			validationErr.Add("options.timeout", "cannot be < 0 but was '%s'", t.Options.Timeout)
+		}
+	}
+	priority, err := NewPriority(t.Options.Priority)
+	if err != nil {
+		validationErr.Add("options.priority", "%s", err)
+	}
+	for source, target := range t.Options.NamespaceOverrides {
+		if strings.TrimSpace(source) == "" || strings.TrimSpace(target) == "" {
+			validationErr.Add("options.namespaceOverrides", "cannot contain empty namespaces ('%s' => '%s')", source, target)
+		}
+	}
+	if err := t.Options.ResourceFilters.Validate(); err != nil {
+		validationErr.Add("options.resourceFilters", "%s", err)
+	}
+	if err := validationErr.ErrOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &Task{
+		ComponentsReady:        t.ComponentsReady,
+		Component:              t.Component,
+		Namespace:              t.Namespace,
+		Version:                t.Version,
+		URL:                    t.URL,
+		Profile:                t.Profile,
+		Configuration:          flattenValues(t.Values),
+		Kubeconfig:             t.Kubeconfig,
+		RuntimeID:              t.RuntimeID,
+		Metadata:               t.Metadata,
+		CallbackURL:            t.CallbackURL,
+		CorrelationID:          t.CorrelationID,
+		Repository:             t.Repository,
+		Type:                   t.Type,
+		ComponentConfiguration: t.ComponentConfiguration,
+		FeatureFlags:           t.FeatureFlags,
+		Components:             t.Components,
+		Timeout:                timeout,
+		DryRun:                 t.Options.DryRun,
+		Priority:               priority,
+		NamespaceOverrides:     t.Options.NamespaceOverrides,
+		ResourceFilters:        t.Options.ResourceFilters,
+	}, nil
+}
+
+// flattenValues converts nested values into keys with dot-notation (e.g. [a:[b:[c:value]]] becomes a.b.c=value)
+// which is the format used by the configuration of a task
+func flattenValues(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	flattenInto(result, "", values)
+	return result
+}
+
+func flattenInto(result map[string]interface{}, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenInto(result, key, nested)
+			continue
+		}
+		result[key] = value
+	}
+}
@@ -0,0 +1,73 @@
+package reconciler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskV2(t *testing.T) {
+	t.Run("Convert to task", func(t *testing.T) {
+		taskV2 := &TaskV2{
+			Component: "comp",
+			Namespace: "ns",
+			Values: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": map[string]interface{}{
+						"c": "value",
+					},
+					"d":     1,
+					"empty": map[string]interface{}{},
+				},
+				"e": true,
+			},
+			Options: TaskOptions{
+				Timeout:            "5m",
+				DryRun:             true,
+				Priority:           "LOW",
+				NamespaceOverrides: map[string]string{"ns": "other-ns"},
+				ResourceFilters: &ResourceFilters{
+					Exclude: []ResourceFilter{{Kind: "PodSecurityPolicy"}},
+				},
+			},
+		}
+		task, err := taskV2.Task()
+		require.NoError(t, err)
+		require.Equal(t, "comp", task.Component)
+		require.Equal(t, map[string]interface{}{
+			"a.b.c":   "value",
+			"a.d":     1,
+			"a.empty": map[string]interface{}{},
+			"e":       true,
+		}, task.Configuration)
+		require.Equal(t, 5*time.Minute, task.Timeout)
+		require.True(t, task.DryRun)
+		require.Equal(t, PriorityLow, task.Priority)
+		require.Equal(t, map[string]string{"ns": "other-ns"}, task.NamespaceOverrides)
+		require.True(t, task.ResourceFilters.Skip("PodSecurityPolicy", "psp", ""))
+	})
+
+	t.Run("Default options", func(t *testing.T) {
+		task, err := (&TaskV2{}).Task()
+		require.NoError(t, err)
+		require.Empty(t, task.Configuration)
+		require.Zero(t, task.Timeout)
+		require.False(t, task.DryRun)
+		require.Equal(t, PriorityNormal, task.Priority)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		for _, options := range []TaskOptions{
+			{Timeout: "abc"},
+			{Timeout: "-1m"},
+			{Priority: "urgent"},
+			{NamespaceOverrides: map[string]string{"ns": " "}},
+			{ResourceFilters: &ResourceFilters{Exclude: []ResourceFilter{{}}}},
+			{ResourceFilters: &ResourceFilters{Include: []ResourceFilter{{Name: "["}}}},
+		} {
+			_, err := (&TaskV2{Options: options}).Task()
+			require.Error(t, err)
+		}
+	})
+}
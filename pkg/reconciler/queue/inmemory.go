@@ -0,0 +1,141 @@
+package queue
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+)
+
+// InMemoryQueue is only shared by the workers of one process: queued tasks don't survive a restart
+type InMemoryQueue struct {
+	tasks map[string]*model.QueuedTaskEntity //key: ID
+	mu    sync.Mutex
+}
+
+func NewInMemoryQueue() Queue {
+	return &InMemoryQueue{
+		tasks: make(map[string]*model.QueuedTaskEntity),
+	}
+}
+
+func (q *InMemoryQueue) Enqueue(reconcilerName string, task *reconciler.Task) (*model.QueuedTaskEntity, error) {
+	entity, err := newEntity(uuid.NewString(), reconcilerName, task)
+	if err != nil {
+		return nil, err
+	}
+	entity.Created = time.Now().UTC()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tasks[entity.ID] = entity
+	copied := *entity
+	return &copied, nil
+}
+
+func (q *InMemoryQueue) Lease(reconcilerName, owner string, duration time.Duration, limit int) ([]*LeasedTask, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now().UTC()
+	entities := q.sorted(reconcilerName)
+	sort.SliceStable(entities, func(i, j int) bool {
+		return priorityRank(entities[i].Priority) < priorityRank(entities[j].Priority)
+	})
+	var result []*LeasedTask
+	for _, entity := range entities {
+		if len(result) >= limit {
+			break
+		}
+		if entity.Leased(now) {
+			continue
+		}
+		entity.LeaseOwner = owner
+		entity.LeaseExpiry = now.Add(duration)
+		entity.Attempts++
+		copied := *entity
+		leased, err := newLeasedTask(&copied)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, leased)
+	}
+	return result, nil
+}
+
+func (q *InMemoryQueue) RenewLease(id, owner string, duration time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entity, err := q.leased(id, owner)
+	if err != nil {
+		return err
+	}
+	entity.LeaseExpiry = time.Now().UTC().Add(duration)
+	return nil
+}
+
+func (q *InMemoryQueue) Release(id, owner string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entity, err := q.leased(id, owner)
+	if err != nil {
+		return err
+	}
+	entity.LeaseOwner = ""
+	entity.LeaseExpiry = time.Now().UTC()
+	entity.Attempts--
+	return nil
+}
+
+func (q *InMemoryQueue) Complete(id, owner string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, err := q.leased(id, owner); err != nil {
+		return err
+	}
+	delete(q.tasks, id)
+	return nil
+}
+
+func (q *InMemoryQueue) Tasks(reconcilerName string) ([]*model.QueuedTaskEntity, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var result []*model.QueuedTaskEntity
+	for _, entity := range q.sorted(reconcilerName) {
+		copied := *entity
+		result = append(result, &copied)
+	}
+	return result, nil
+}
+
+func (q *InMemoryQueue) WithTx(_ *db.TxConnection) (Queue, error) {
+	return q, nil
+}
+
+func (q *InMemoryQueue) sorted(reconcilerName string) []*model.QueuedTaskEntity {
+	var result []*model.QueuedTaskEntity
+	for _, entity := range q.tasks {
+		if entity.Reconciler == reconcilerName {
+			result = append(result, entity)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Created.Equal(result[j].Created) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].Created.Before(result[j].Created)
+	})
+	return result
+}
+
+func (q *InMemoryQueue) leased(id, owner string) (*model.QueuedTaskEntity, error) {
+	entity, ok := q.tasks[id]
+	if !ok || entity.LeaseOwner != owner {
+		return nil, newLeaseLostError(id, owner)
+	}
+	return entity, nil
+}
@@ -0,0 +1,217 @@
+package queue
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
+)
+
+type PersistentQueue struct {
+	*repository.Repository
+}
+
+func NewPersistentQueue(conn db.Connection, debug bool) (Queue, error) {
+	repo, err := repository.NewRepository(conn, debug)
+	if err != nil {
+		return nil, err
+	}
+	return &PersistentQueue{repo}, nil
+}
+
+func (q *PersistentQueue) WithTx(tx *db.TxConnection) (Queue, error) {
+	return NewPersistentQueue(tx, q.Debug)
+}
+
+func (q *PersistentQueue) Enqueue(reconcilerName string, task *reconciler.Task) (*model.QueuedTaskEntity, error) {
+	entity, err := newEntity(uuid.NewString(), reconcilerName, task)
+	if err != nil {
+		return nil, err
+	}
+	query, err := db.NewQuery(q.Conn, entity, q.Logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := query.Insert().Exec(); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to enqueue %s", task))
+	}
+	return entity, nil
+}
+
+// Lease uses optimistic locking: a task is only leased if its attempts counter wasn't changed by another replica
+// since it was selected
+func (q *PersistentQueue) Lease(reconcilerName, owner string, duration time.Duration, limit int) ([]*LeasedTask, error) {
+	dbOps := func(tx *db.TxConnection) (interface{}, error) {
+		columns, err := newQueueColumns(tx, q)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC()
+		var entities []db.DatabaseEntity
+		for _, priority := range leasePriorities {
+			if len(entities) >= limit {
+				break
+			}
+			query, err := db.NewQuery(tx, &model.QueuedTaskEntity{}, q.Logger)
+			if err != nil {
+				return nil, err
+			}
+			selectQ := query.Select().
+				Where(map[string]interface{}{
+					model.QueuedTaskEntityFields.Reconciler: reconcilerName,
+					model.QueuedTaskEntityFields.Priority:   string(priority),
+				})
+			priorityEntities, err := selectQ.
+				WhereRaw(fmt.Sprintf("%s='' OR %s<$%d",
+					columns.leaseOwner, columns.leaseExpiry, selectQ.NextPlaceholderCount()), now).
+				OrderBy(map[string]string{model.QueuedTaskEntityFields.Created: "ASC"}).
+				Limit(limit - len(entities)).
+				GetMany()
+			if err != nil {
+				return nil, err
+			}
+			entities = append(entities, priorityEntities...)
+		}
+
+		var result []*LeasedTask
+		for _, entity := range entities {
+			task := entity.(*model.QueuedTaskEntity)
+			updateSQL := fmt.Sprintf("UPDATE %s SET %s=$1, %s=$2, %s=$3 WHERE %s=$4 AND %s=$5",
+				task.Table(), columns.leaseOwner, columns.leaseExpiry, columns.attempts, columns.id, columns.attempts)
+			res, err := tx.Exec(updateSQL, owner, now.Add(duration), task.Attempts+1, task.ID, task.Attempts)
+			if err != nil {
+				return nil, err
+			}
+			if cnt, err := res.RowsAffected(); err != nil {
+				return nil, err
+			} else if cnt == 0 {
+				q.Logger.Debugf("Queued task '%s' was leased by another owner in between", task.ID)
+				continue
+			}
+			task.LeaseOwner = owner
+			task.LeaseExpiry = now.Add(duration)
+			task.Attempts++
+			leased, err := newLeasedTask(task)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, leased)
+		}
+		return result, nil
+	}
+	result, err := db.TransactionResult(q.Conn, dbOps, q.Logger)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to lease queued tasks of reconciler '%s'", reconcilerName))
+	}
+	return result.([]*LeasedTask), nil
+}
+
+func (q *PersistentQueue) RenewLease(id, owner string, duration time.Duration) error {
+	columns, err := newQueueColumns(q.Conn, q)
+	if err != nil {
+		return err
+	}
+	updateSQL := fmt.Sprintf("UPDATE %s SET %s=$1 WHERE %s=$2 AND %s=$3",
+		tblName(), columns.leaseExpiry, columns.id, columns.leaseOwner)
+	return q.execLeased(id, owner, updateSQL, time.Now().UTC().Add(duration), id, owner)
+}
+
+func (q *PersistentQueue) Release(id, owner string) error {
+	columns, err := newQueueColumns(q.Conn, q)
+	if err != nil {
+		return err
+	}
+	updateSQL := fmt.Sprintf("UPDATE %s SET %s='', %s=$1, %s=%s-1 WHERE %s=$2 AND %s=$3",
+		tblName(), columns.leaseOwner, columns.leaseExpiry, columns.attempts, columns.attempts,
+		columns.id, columns.leaseOwner)
+	return q.execLeased(id, owner, updateSQL, time.Now().UTC(), id, owner)
+}
+
+func (q *PersistentQueue) Complete(id, owner string) error {
+	query, err := db.NewQuery(q.Conn, &model.QueuedTaskEntity{}, q.Logger)
+	if err != nil {
+		return err
+	}
+	cnt, err := query.Delete().
+		Where(map[string]interface{}{
+			model.QueuedTaskEntityFields.ID:         id,
+			model.QueuedTaskEntityFields.LeaseOwner: owner,
+		}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return newLeaseLostError(id, owner)
+	}
+	return nil
+}
+
+func (q *PersistentQueue) Tasks(reconcilerName string) ([]*model.QueuedTaskEntity, error) {
+	query, err := db.NewQuery(q.Conn, &model.QueuedTaskEntity{}, q.Logger)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := query.Select().
+		Where(map[string]interface{}{model.QueuedTaskEntityFields.Reconciler: reconcilerName}).
+		OrderBy(map[string]string{model.QueuedTaskEntityFields.Created: "ASC"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*model.QueuedTaskEntity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, entity.(*model.QueuedTaskEntity))
+	}
+	return result, nil
+}
+
+func (q *PersistentQueue) execLeased(id, owner, stmt string, args ...interface{}) error {
+	res, err := q.Conn.Exec(stmt, args...)
+	if err != nil {
+		return err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return newLeaseLostError(id, owner)
+	}
+	return nil
+}
+
+type queueColumns struct {
+	id          string
+	leaseOwner  string
+	leaseExpiry string
+	attempts    string
+}
+
+func newQueueColumns(conn db.Connection, q *PersistentQueue) (*queueColumns, error) {
+	columnHandler, err := db.NewColumnHandler(&model.QueuedTaskEntity{}, conn, q.Logger)
+	if err != nil {
+		return nil, err
+	}
+	columns := &queueColumns{}
+	for field, column := range map[string]*string{
+		model.QueuedTaskEntityFields.ID:          &columns.id,
+		model.QueuedTaskEntityFields.LeaseOwner:  &columns.leaseOwner,
+		model.QueuedTaskEntityFields.LeaseExpiry: &columns.leaseExpiry,
+		model.QueuedTaskEntityFields.Attempts:    &columns.attempts,
+	} {
+		if *column, err = columnHandler.ColumnName(field); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+func tblName() string {
+	return (&model.QueuedTaskEntity{}).Table()
+}
@@ -0,0 +1,140 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+)
+
+// Queue stores the pending operations of component reconcilers. Replicas of the same component reconciler share
+// the queue: a replica leases the operations it processes and has to renew the lease until the operation is
+// completed. Operations of a replica which stopped (e.g. because it was restarted) are picked up by another replica
+// when their lease expired.
+type Queue interface {
+	Enqueue(reconcilerName string, task *reconciler.Task) (*model.QueuedTaskEntity, error)
+	//Lease returns up to limit unleased tasks (normal priority first, oldest first within a priority) and leases
+	//them for the given duration
+	Lease(reconcilerName, owner string, duration time.Duration, limit int) ([]*LeasedTask, error)
+	RenewLease(id, owner string, duration time.Duration) error
+	//Release returns a leased task to the queue without counting it as attempt
+	Release(id, owner string) error
+	//Complete removes a leased task from the queue
+	Complete(id, owner string) error
+	//Tasks returns the queued tasks of a reconciler (oldest first)
+	Tasks(reconcilerName string) ([]*model.QueuedTaskEntity, error)
+	WithTx(tx *db.TxConnection) (Queue, error)
+}
+
+// LeasedTask is a queued task which is processed by the lease owner
+type LeasedTask struct {
+	*model.QueuedTaskEntity
+	Task *reconciler.Task
+}
+
+// LeaseLostError is returned if the task was completed or is leased by another owner
+type LeaseLostError struct {
+	ID    string
+	Owner string
+}
+
+func newLeaseLostError(id, owner string) error {
+	return &LeaseLostError{ID: id, Owner: owner}
+}
+
+func (e *LeaseLostError) Error() string {
+	return fmt.Sprintf("lease of queued task '%s' is no longer held by '%s'", e.ID, e.Owner)
+}
+
+func IsLeaseLostError(err error) bool {
+	var leaseLostErr *LeaseLostError
+	return errors.As(err, &leaseLostErr)
+}
+
+// payload is the stored representation of a task: it includes the options which aren't part of the JSON
+// representation of a task
+type payload struct {
+	Task               *reconciler.Task            `json:"task"`
+	TraceID            string                      `json:"traceID,omitempty"`
+	Timeout            time.Duration               `json:"timeout,omitempty"`
+	DryRun             bool                        `json:"dryRun,omitempty"`
+	Priority           reconciler.Priority         `json:"priority,omitempty"`
+	NamespaceOverrides map[string]string           `json:"namespaceOverrides,omitempty"`
+	ResourceFilters    *reconciler.ResourceFilters `json:"resourceFilters,omitempty"`
+}
+
+func marshalTask(task *reconciler.Task) (string, error) {
+	data, err := json.Marshal(&payload{
+		Task:               task,
+		TraceID:            task.TraceID,
+		Timeout:            task.Timeout,
+		DryRun:             task.DryRun,
+		Priority:           task.Priority,
+		NamespaceOverrides: task.NamespaceOverrides,
+		ResourceFilters:    task.ResourceFilters,
+	})
+	return string(data), err
+}
+
+func unmarshalTask(data string) (*reconciler.Task, error) {
+	p := &payload{}
+	if err := json.Unmarshal([]byte(data), p); err != nil {
+		return nil, err
+	}
+	task := p.Task
+	if task == nil {
+		task = &reconciler.Task{}
+	}
+	task.TraceID = p.TraceID
+	task.Timeout = p.Timeout
+	task.DryRun = p.DryRun
+	task.Priority = p.Priority
+	task.NamespaceOverrides = p.NamespaceOverrides
+	task.ResourceFilters = p.ResourceFilters
+	return task, nil
+}
+
+// leasePriorities defines the order in which queued tasks are leased: normal tasks before low priority tasks
+var leasePriorities = []reconciler.Priority{reconciler.PriorityNormal, reconciler.PriorityLow}
+
+func priorityRank(priority string) int {
+	for rank, leasePriority := range leasePriorities {
+		if string(leasePriority) == priority {
+			return rank
+		}
+	}
+	return 0
+}
+
+func newEntity(id, reconcilerName string, task *reconciler.Task) (*model.QueuedTaskEntity, error) {
+	data, err := marshalTask(task)
+	if err != nil {
+		return nil, err
+	}
+	priority := task.Priority
+	if priority == "" {
+		priority = reconciler.PriorityNormal
+	}
+	return &model.QueuedTaskEntity{
+		ID:            id,
+		Reconciler:    reconcilerName,
+		Component:     task.Component,
+		CorrelationID: task.CorrelationID,
+		RuntimeID:     task.RuntimeID,
+		Priority:      string(priority),
+		Task:          data,
+		LeaseExpiry:   time.Now().UTC(),
+	}, nil
+}
+
+func newLeasedTask(entity *model.QueuedTaskEntity) (*LeasedTask, error) {
+	task, err := unmarshalTask(entity.Task)
+	if err != nil {
+		return nil, err
+	}
+	return &LeasedTask{QueuedTaskEntity: entity, Task: task}, nil
+}
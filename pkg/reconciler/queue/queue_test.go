@@ -0,0 +1,170 @@
+package queue
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/redis"
+	"github.com/kyma-incubator/reconciler/pkg/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryQueue(t *testing.T) {
+	testQueue(t, NewInMemoryQueue())
+}
+
+func TestPersistentQueue(t *testing.T) {
+	test.IntegrationTest(t)
+	queue, err := NewPersistentQueue(db.NewTestConnection(t), true)
+	require.NoError(t, err)
+	testQueue(t, queue)
+}
+
+func TestRedisQueue(t *testing.T) {
+	test.IntegrationTest(t)
+	if os.Getenv("REDIS_ADDRESS") == "" {
+		t.Skip("Skipping redis queue test: env-var 'REDIS_ADDRESS' is not defined")
+	}
+	client, err := redis.NewClient(&redis.Config{Address: os.Getenv("REDIS_ADDRESS"), KeyPrefix: "unittest"})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, client.Close())
+	}()
+	key, err := db.NewEncryptionKey()
+	require.NoError(t, err)
+	encryptor, err := db.NewEncryptor(key)
+	require.NoError(t, err)
+	queue, err := NewRedisQueue(client, encryptor)
+	require.NoError(t, err)
+	testQueue(t, queue)
+}
+
+func testQueue(t *testing.T, queue Queue) {
+	newTask := func(correlationID string) *reconciler.Task {
+		return &reconciler.Task{
+			Component:     "component",
+			Namespace:     "namespace",
+			Version:       "1.0.0",
+			Kubeconfig:    "kubeconfig",
+			CallbackURL:   "https://mothership/callback",
+			CorrelationID: correlationID,
+			Type:          model.OperationTypeReconcile,
+			Configuration: map[string]interface{}{"a.b": "c"},
+			Timeout:       5 * time.Minute,
+			Priority:      reconciler.PriorityLow,
+			NamespaceOverrides: map[string]string{
+				"namespace": "target",
+			},
+		}
+	}
+
+	t.Run("Lease tasks in order", func(t *testing.T) {
+		reconcilerName := uuid.NewString()
+		for _, correlationID := range []string{"1", "2", "3"} {
+			_, err := queue.Enqueue(reconcilerName, newTask(correlationID))
+			require.NoError(t, err)
+			time.Sleep(10 * time.Millisecond) //ensure distinct creation timestamps
+		}
+		_, err := queue.Enqueue(uuid.NewString(), newTask("other reconciler"))
+		require.NoError(t, err)
+
+		leased, err := queue.Lease(reconcilerName, "owner1", time.Minute, 2)
+		require.NoError(t, err)
+		require.Len(t, leased, 2)
+		require.Equal(t, "1", leased[0].Task.CorrelationID)
+		require.Equal(t, "2", leased[1].Task.CorrelationID)
+		require.Equal(t, int64(1), leased[0].Attempts)
+
+		//task options are restored
+		require.Equal(t, 5*time.Minute, leased[0].Task.Timeout)
+		require.Equal(t, reconciler.PriorityLow, leased[0].Task.Priority)
+		require.Equal(t, map[string]string{"namespace": "target"}, leased[0].Task.NamespaceOverrides)
+		require.Equal(t, map[string]interface{}{"a.b": "c"}, leased[0].Task.Configuration)
+
+		//leased tasks aren't leased again
+		leased2, err := queue.Lease(reconcilerName, "owner2", time.Minute, 5)
+		require.NoError(t, err)
+		require.Len(t, leased2, 1)
+		require.Equal(t, "3", leased2[0].Task.CorrelationID)
+
+		tasks, err := queue.Tasks(reconcilerName)
+		require.NoError(t, err)
+		require.Len(t, tasks, 3)
+
+		for _, task := range leased {
+			require.NoError(t, queue.Complete(task.ID, "owner1"))
+		}
+		require.True(t, IsLeaseLostError(queue.Complete(leased2[0].ID, "owner1")))
+		require.NoError(t, queue.Complete(leased2[0].ID, "owner2"))
+
+		tasks, err = queue.Tasks(reconcilerName)
+		require.NoError(t, err)
+		require.Empty(t, tasks)
+	})
+
+	t.Run("Lease normal tasks before low priority tasks", func(t *testing.T) {
+		reconcilerName := uuid.NewString()
+		_, err := queue.Enqueue(reconcilerName, newTask("low"))
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond) //ensure distinct creation timestamps
+		normalTask := newTask("normal")
+		normalTask.Priority = reconciler.PriorityNormal
+		_, err = queue.Enqueue(reconcilerName, normalTask)
+		require.NoError(t, err)
+
+		leased, err := queue.Lease(reconcilerName, "owner1", time.Minute, 1)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.Equal(t, "normal", leased[0].Task.CorrelationID)
+		require.NoError(t, queue.Complete(leased[0].ID, "owner1"))
+
+		leased, err = queue.Lease(reconcilerName, "owner1", time.Minute, 1)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.Equal(t, "low", leased[0].Task.CorrelationID)
+		require.NoError(t, queue.Complete(leased[0].ID, "owner1"))
+	})
+
+	t.Run("Expired leases are taken over", func(t *testing.T) {
+		reconcilerName := uuid.NewString()
+		_, err := queue.Enqueue(reconcilerName, newTask("1"))
+		require.NoError(t, err)
+
+		leased, err := queue.Lease(reconcilerName, "owner1", 100*time.Millisecond, 1)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+
+		time.Sleep(200 * time.Millisecond)
+		takenOver, err := queue.Lease(reconcilerName, "owner2", time.Minute, 1)
+		require.NoError(t, err)
+		require.Len(t, takenOver, 1)
+		require.Equal(t, leased[0].ID, takenOver[0].ID)
+		require.Equal(t, int64(2), takenOver[0].Attempts)
+
+		require.True(t, IsLeaseLostError(queue.RenewLease(leased[0].ID, "owner1", time.Minute)))
+		require.NoError(t, queue.RenewLease(takenOver[0].ID, "owner2", time.Minute))
+		require.NoError(t, queue.Complete(takenOver[0].ID, "owner2"))
+	})
+
+	t.Run("Released tasks are leased again", func(t *testing.T) {
+		reconcilerName := uuid.NewString()
+		_, err := queue.Enqueue(reconcilerName, newTask("1"))
+		require.NoError(t, err)
+
+		leased, err := queue.Lease(reconcilerName, "owner1", time.Minute, 1)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.NoError(t, queue.Release(leased[0].ID, "owner1"))
+
+		leased, err = queue.Lease(reconcilerName, "owner2", time.Minute, 1)
+		require.NoError(t, err)
+		require.Len(t, leased, 1)
+		require.Equal(t, int64(1), leased[0].Attempts) //releasing doesn't count as attempt
+		require.NoError(t, queue.Complete(leased[0].ID, "owner2"))
+	})
+}
@@ -0,0 +1,300 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/redis"
+	"github.com/pkg/errors"
+)
+
+// The data of a task is stored in a hash. The IDs of the pending tasks of a reconciler are stored in a sorted set
+// (scored by priority and creation time), the IDs of leased tasks in another sorted set (scored by lease expiry).
+// Lease owners and attempts are kept in hashes per reconciler. All keys of a reconciler share the reconciler name as
+// hash tag, so the Lua scripts which apply the changes atomically only access keys of one Redis Cluster slot.
+const (
+	redisEnqueueScript = `
+redis.call('HSET', KEYS[1], unpack(ARGV, 3))
+redis.call('ZADD', KEYS[2], ARGV[1], ARGV[2])
+return 1`
+	redisLeaseScript = `
+local limit = tonumber(ARGV[4])
+local result = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1], 'LIMIT', 0, limit)
+if #result < limit then
+	for _, id in ipairs(redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', '+inf', 'LIMIT', 0, limit - #result)) do
+		redis.call('ZREM', KEYS[1], id)
+		table.insert(result, id)
+	end
+end
+for _, id in ipairs(result) do
+	redis.call('ZADD', KEYS[2], ARGV[2], id)
+	redis.call('HSET', KEYS[3], id, ARGV[3])
+	redis.call('HINCRBY', KEYS[4], id, 1)
+end
+return result`
+	redisRenewScript = `
+if redis.call('HGET', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+return 1`
+	redisReleaseScript = `
+if redis.call('HGET', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+redis.call('HINCRBY', KEYS[3], ARGV[1], -1)
+return 1`
+	redisCompleteScript = `
+if redis.call('HGET', KEYS[2], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('HDEL', KEYS[2], ARGV[1])
+redis.call('HDEL', KEYS[3], ARGV[1])
+redis.call('DEL', KEYS[4])
+return 1`
+	redisGetScript = `
+local task = redis.call('HGETALL', KEYS[1])
+if #task == 0 then
+	return false
+end
+table.insert(task, 'leaseExpiry')
+table.insert(task, redis.call('ZSCORE', KEYS[2], ARGV[1]) or '')
+table.insert(task, 'leaseOwner')
+table.insert(task, redis.call('HGET', KEYS[3], ARGV[1]) or '')
+table.insert(task, 'attempts')
+table.insert(task, redis.call('HGET', KEYS[4], ARGV[1]) or '0')
+return task`
+)
+
+// redisPriorityScore separates the priorities in the sorted set of pending tasks: it is larger than any creation
+// time in milliseconds and small enough to keep the scores exact (Redis stores them as double)
+const redisPriorityScore int64 = 10_000_000_000_000
+
+// RedisQueue avoids the write load of the persistent queue on the database: the queue is shared by all replicas
+// connected to the same Redis server. Tasks are encrypted with the encryption key of the database.
+type RedisQueue struct {
+	client    *redis.Client
+	encryptor *db.Encryptor
+}
+
+func NewRedisQueue(client *redis.Client, encryptor *db.Encryptor) (Queue, error) {
+	if client == nil || encryptor == nil {
+		return nil, fmt.Errorf("redis queue requires a redis client and an encryptor")
+	}
+	return &RedisQueue{client: client, encryptor: encryptor}, nil
+}
+
+// WithTx returns the queue itself: Redis isn't part of database transactions
+func (q *RedisQueue) WithTx(_ *db.TxConnection) (Queue, error) {
+	return q, nil
+}
+
+func (q *RedisQueue) Enqueue(reconcilerName string, task *reconciler.Task) (*model.QueuedTaskEntity, error) {
+	//the reconciler name is part of the ID: all keys of the task can be derived from it
+	entity, err := newEntity(reconcilerName+"/"+uuid.NewString(), reconcilerName, task)
+	if err != nil {
+		return nil, err
+	}
+	entity.Created = time.Now().UTC()
+	entity.LeaseExpiry = entity.Created
+	encTask, err := q.encryptor.Encrypt(entity.Task)
+	if err != nil {
+		return nil, err
+	}
+	score := int64(priorityRank(entity.Priority))*redisPriorityScore + entity.Created.UnixMilli()
+	ctx, cancel := q.context()
+	defer cancel()
+	_, err = q.client.Do(ctx, "EVAL", redisEnqueueScript, 2, q.taskKey(reconcilerName, entity.ID), q.key(reconcilerName, "pending"),
+		score, entity.ID,
+		"reconciler", entity.Reconciler,
+		"component", entity.Component,
+		"correlationID", entity.CorrelationID,
+		"runtimeID", entity.RuntimeID,
+		"priority", entity.Priority,
+		"task", encTask,
+		"created", entity.Created.UnixMicro())
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to enqueue %s", task))
+	}
+	return entity, nil
+}
+
+// Lease takes over expired leases first (their tasks are waiting the longest) before pending tasks are leased
+func (q *RedisQueue) Lease(reconcilerName, owner string, duration time.Duration, limit int) ([]*LeasedTask, error) {
+	ctx, cancel := q.context()
+	defer cancel()
+	now := time.Now().UTC()
+	ids, err := redis.Strings(q.client.Do(ctx, "EVAL", redisLeaseScript, 4,
+		q.key(reconcilerName, "pending"), q.key(reconcilerName, "leased"),
+		q.key(reconcilerName, "owners"), q.key(reconcilerName, "attempts"),
+		now.UnixMilli(), now.Add(duration).UnixMilli(), owner, limit))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to lease tasks of queue '%s'", reconcilerName))
+	}
+	var result []*LeasedTask
+	for _, id := range ids {
+		entity, err := q.get(ctx, reconcilerName, id)
+		if err != nil {
+			return nil, err
+		}
+		leased, err := newLeasedTask(entity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, leased)
+	}
+	return result, nil
+}
+
+func (q *RedisQueue) RenewLease(id, owner string, duration time.Duration) error {
+	return q.eval(id, owner, redisRenewScript, func(reconcilerName string) []string {
+		return []string{q.key(reconcilerName, "leased"), q.key(reconcilerName, "owners")}
+	}, time.Now().UTC().Add(duration).UnixMilli())
+}
+
+func (q *RedisQueue) Release(id, owner string) error {
+	return q.eval(id, owner, redisReleaseScript, func(reconcilerName string) []string {
+		return []string{q.key(reconcilerName, "leased"), q.key(reconcilerName, "owners"), q.key(reconcilerName, "attempts")}
+	}, time.Now().UTC().UnixMilli())
+}
+
+func (q *RedisQueue) Complete(id, owner string) error {
+	return q.eval(id, owner, redisCompleteScript, func(reconcilerName string) []string {
+		return []string{q.key(reconcilerName, "leased"), q.key(reconcilerName, "owners"), q.key(reconcilerName, "attempts"),
+			q.taskKey(reconcilerName, id)}
+	})
+}
+
+func (q *RedisQueue) Tasks(reconcilerName string) ([]*model.QueuedTaskEntity, error) {
+	ctx, cancel := q.context()
+	defer cancel()
+	var ids []string
+	for _, set := range []string{"leased", "pending"} {
+		setIDs, err := redis.Strings(q.client.Do(ctx, "ZRANGE", q.key(reconcilerName, set), 0, -1))
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, setIDs...)
+	}
+	var result []*model.QueuedTaskEntity
+	for _, id := range ids {
+		entity, err := q.get(ctx, reconcilerName, id)
+		if err == redis.ErrNil {
+			continue //completed in the meantime
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Created.Equal(result[j].Created) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].Created.Before(result[j].Created)
+	})
+	return result, nil
+}
+
+// eval runs a script which changes a leased task: the script gets the ID and the owner as first arguments
+func (q *RedisQueue) eval(id, owner, script string, keys func(reconcilerName string) []string, args ...interface{}) error {
+	reconcilerName, ok := reconcilerOfID(id)
+	if !ok {
+		return newLeaseLostError(id, owner)
+	}
+	scriptKeys := keys(reconcilerName)
+	cmd := []interface{}{"EVAL", script, len(scriptKeys)}
+	for _, key := range scriptKeys {
+		cmd = append(cmd, key)
+	}
+	cmd = append(append(cmd, id, owner), args...)
+
+	ctx, cancel := q.context()
+	defer cancel()
+	updated, err := redis.Int64(q.client.Do(ctx, cmd...))
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return newLeaseLostError(id, owner)
+	}
+	return nil
+}
+
+func (q *RedisQueue) get(ctx context.Context, reconcilerName, id string) (*model.QueuedTaskEntity, error) {
+	fields, err := redis.StringMap(q.client.Do(ctx, "EVAL", redisGetScript, 4,
+		q.taskKey(reconcilerName, id), q.key(reconcilerName, "leased"),
+		q.key(reconcilerName, "owners"), q.key(reconcilerName, "attempts"), id))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, redis.ErrNil
+	}
+	task, err := q.encryptor.Decrypt(fields["task"])
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := strconv.ParseInt(fields["attempts"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	created, err := strconv.ParseInt(fields["created"], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	entity := &model.QueuedTaskEntity{
+		ID:            id,
+		Reconciler:    fields["reconciler"],
+		Component:     fields["component"],
+		CorrelationID: fields["correlationID"],
+		RuntimeID:     fields["runtimeID"],
+		Priority:      fields["priority"],
+		Task:          task,
+		LeaseOwner:    fields["leaseOwner"],
+		LeaseExpiry:   time.UnixMicro(created).UTC(), //pending tasks were never leased
+		Attempts:      attempts,
+		Created:       time.UnixMicro(created).UTC(),
+	}
+	if fields["leaseExpiry"] != "" {
+		leaseExpiry, err := strconv.ParseFloat(fields["leaseExpiry"], 64) //scores are returned as double
+		if err != nil {
+			return nil, err
+		}
+		entity.LeaseExpiry = time.UnixMilli(int64(leaseExpiry)).UTC()
+	}
+	return entity, nil
+}
+
+func (q *RedisQueue) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), q.client.Config().Timeout)
+}
+
+// key returns the key of a data structure of the reconciler's queue: the reconciler name is used as hash tag
+func (q *RedisQueue) key(reconcilerName string, parts ...string) string {
+	return q.client.Config().Key(append([]string{"queue", "{" + reconcilerName + "}"}, parts...)...)
+}
+
+func (q *RedisQueue) taskKey(reconcilerName, id string) string {
+	return q.key(reconcilerName, "task", id[len(reconcilerName)+1:])
+}
+
+// reconcilerOfID returns the reconciler name which prefixes the ID of a task
+func reconcilerOfID(id string) (string, bool) {
+	idx := strings.LastIndex(id, "/")
+	if idx <= 0 {
+		return "", false
+	}
+	return id[:idx], true
+}
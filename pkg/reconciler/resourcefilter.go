@@ -0,0 +1,77 @@
+package reconciler
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ResourceFilter selects rendered resources of a component: all defined criteria have to match
+type ResourceFilter struct {
+	Kind     string `json:"kind,omitempty"`     //case-insensitive kind, e.g. 'PodSecurityPolicy'
+	Name     string `json:"name,omitempty"`     //glob pattern of the resource name, e.g. 'istio-*'
+	Subchart string `json:"subchart,omitempty"` //name of the sub-chart the resource is defined in
+}
+
+func (f ResourceFilter) validate() error {
+	if f.Kind == "" && f.Name == "" && f.Subchart == "" {
+		return fmt.Errorf("resource filter requires at least a kind, name or subchart")
+	}
+	if _, err := path.Match(f.Name, ""); err != nil {
+		return fmt.Errorf("invalid name pattern '%s' of resource filter: %s", f.Name, err)
+	}
+	return nil
+}
+
+// Matches returns true if the resource fulfils all criteria of the filter. The subchart is empty for
+// resources of the main chart.
+func (f ResourceFilter) Matches(kind, name, subchart string) bool {
+	if f.Kind != "" && !strings.EqualFold(f.Kind, kind) {
+		return false
+	}
+	if f.Name != "" {
+		if match, err := path.Match(f.Name, name); err != nil || !match {
+			return false
+		}
+	}
+	return f.Subchart == "" || f.Subchart == subchart
+}
+
+// ResourceFilters define which resources of a component are applied. If include filters are defined, only the
+// resources matching one of them are applied. Resources matching an exclude filter are always skipped.
+type ResourceFilters struct {
+	Include []ResourceFilter `json:"include,omitempty"`
+	Exclude []ResourceFilter `json:"exclude,omitempty"`
+}
+
+func (f *ResourceFilters) Validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, filter := range append(append([]ResourceFilter{}, f.Include...), f.Exclude...) {
+		if err := filter.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Skip returns true if the resource is filtered out
+func (f *ResourceFilters) Skip(kind, name, subchart string) bool {
+	if f == nil {
+		return false
+	}
+	if len(f.Include) > 0 && !anyMatches(f.Include, kind, name, subchart) {
+		return true
+	}
+	return anyMatches(f.Exclude, kind, name, subchart)
+}
+
+func anyMatches(filters []ResourceFilter, kind, name, subchart string) bool {
+	for _, filter := range filters {
+		if filter.Matches(kind, name, subchart) {
+			return true
+		}
+	}
+	return false
+}
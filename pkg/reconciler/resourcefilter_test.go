@@ -0,0 +1,35 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceFilters(t *testing.T) {
+	t.Run("Nil filters skip nothing", func(t *testing.T) {
+		var filters *ResourceFilters
+		require.NoError(t, filters.Validate())
+		require.False(t, filters.Skip("Deployment", "deploy", ""))
+	})
+
+	t.Run("All criteria have to match", func(t *testing.T) {
+		filter := ResourceFilter{Kind: "Deployment", Name: "istio-*", Subchart: "pilot"}
+		require.True(t, filter.Matches("deployment", "istio-pilot", "pilot"))
+		require.False(t, filter.Matches("Service", "istio-pilot", "pilot"))
+		require.False(t, filter.Matches("Deployment", "pilot", "pilot"))
+		require.False(t, filter.Matches("Deployment", "istio-pilot", ""))
+	})
+
+	t.Run("Exclude wins over include", func(t *testing.T) {
+		filters := &ResourceFilters{
+			Include: []ResourceFilter{{Subchart: "pilot"}, {Kind: "Namespace"}},
+			Exclude: []ResourceFilter{{Kind: "PodSecurityPolicy"}},
+		}
+		require.NoError(t, filters.Validate())
+		require.False(t, filters.Skip("Deployment", "istiod", "pilot"))
+		require.False(t, filters.Skip("Namespace", "istio-system", ""))
+		require.True(t, filters.Skip("Deployment", "ingress", "gateway"))
+		require.True(t, filters.Skip("PodSecurityPolicy", "psp", "pilot"))
+	})
+}
@@ -0,0 +1,271 @@
+// Package sandbox runs external processes (CLIs, helm plugins, scripts) on behalf of component reconcilers in a
+// controlled environment: each process gets its own working directory, only allowlisted env vars of the reconciler
+// are passed to it, its runtime is limited and its captured output is bounded.
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTimeout     = 5 * time.Minute
+	defaultOutputLimit = 1024 * 1024
+	//processes get some time to terminate gracefully before their output pipes are closed
+	waitDelay = 5 * time.Second
+	//amount of stderr included into the error of a failed process
+	errorOutputBytes = 2048
+)
+
+// DefaultEnvAllowlist contains the env vars of the reconciler process which are passed to processes by default
+var DefaultEnvAllowlist = []string{"PATH", "LANG", "HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"}
+
+// Config of the executor
+type Config struct {
+	BaseDir      string        //directory in which the working directories of the processes are created (default: temp dir)
+	EnvAllowlist []string      //env vars of the reconciler process which are passed to the processes (nil = DefaultEnvAllowlist)
+	Timeout      time.Duration //maximal runtime of a process if the command doesn't define a timeout
+	OutputLimit  int           //maximal bytes of stdout and of stderr which are captured (additional output is dropped)
+}
+
+func (c *Config) validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout of sandboxed processes cannot be < 0")
+	}
+	if c.OutputLimit < 0 {
+		return fmt.Errorf("output limit of sandboxed processes cannot be < 0")
+	}
+	if c.BaseDir == "" {
+		c.BaseDir = os.TempDir()
+	}
+	if c.EnvAllowlist == nil {
+		c.EnvAllowlist = DefaultEnvAllowlist
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.OutputLimit == 0 {
+		c.OutputLimit = defaultOutputLimit
+	}
+	return nil
+}
+
+// Command describes a process which is started by the executor
+type Command struct {
+	Path    string            //name or path of the executable
+	Args    []string          //arguments passed to the executable
+	Env     map[string]string //env vars which are set in addition to the allowlisted env vars
+	Files   map[string][]byte //files written into the working directory before the process is started (relative paths)
+	Stdin   []byte            //data passed to the process via stdin
+	Timeout time.Duration     //maximal runtime of the process (0 = timeout of the executor)
+}
+
+func (c *Command) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s", c.Path, strings.Join(c.Args, " ")))
+}
+
+// Result of a finished process
+type Result struct {
+	Command   string
+	ExitCode  int //-1 if the process didn't exit regularly (e.g. it was killed)
+	Stdout    []byte
+	Stderr    []byte
+	Truncated bool //output exceeded the output limit
+	TimedOut  bool
+	Duration  time.Duration
+}
+
+// ExecError is returned if a process couldn't be started, failed or timed out
+type ExecError struct {
+	Result *Result
+	Err    error
+}
+
+func (e *ExecError) Error() string {
+	var reason string
+	switch {
+	case e.Result.TimedOut:
+		reason = fmt.Sprintf("timed out after %s", e.Result.Duration.Round(time.Millisecond))
+	case e.Result.ExitCode > 0:
+		reason = fmt.Sprintf("failed with exit code %d after %s", e.Result.ExitCode, e.Result.Duration.Round(time.Millisecond))
+	default:
+		reason = fmt.Sprintf("failed: %s", e.Err)
+	}
+	msg := fmt.Sprintf("process '%s' %s", e.Result.Command, reason)
+	if stderr := tail(e.Result.Stderr, errorOutputBytes); stderr != "" {
+		msg = fmt.Sprintf("%s: %s", msg, stderr)
+	}
+	return msg
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// Executor starts sandboxed processes
+type Executor struct {
+	config *Config
+	logger *zap.SugaredLogger
+}
+
+func NewExecutor(config *Config, logger *zap.SugaredLogger) (*Executor, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	cfg := *config
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &Executor{
+		config: &cfg,
+		logger: logger,
+	}, nil
+}
+
+// Run starts the process in a new working directory and waits until it finished. The working directory is removed
+// afterwards. The result is also returned if the process failed.
+func (e *Executor) Run(ctx context.Context, command *Command) (*Result, error) {
+	result := &Result{
+		Command:  command.String(),
+		ExitCode: -1,
+	}
+	if command.Path == "" {
+		return result, &ExecError{Result: result, Err: fmt.Errorf("no executable defined")}
+	}
+
+	workDir, err := e.createWorkDir(command)
+	if err != nil {
+		return result, &ExecError{Result: result, Err: err}
+	}
+	defer func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			e.logger.Warnf("Failed to remove working directory '%s' of process '%s': %s", workDir, result.Command, err)
+		}
+	}()
+
+	timeout := command.Timeout
+	if timeout <= 0 {
+		timeout = e.config.Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stdout := &limitedBuffer{limit: e.config.OutputLimit}
+	stderr := &limitedBuffer{limit: e.config.OutputLimit}
+	cmd := exec.CommandContext(ctx, command.Path, command.Args...) //nolint:gosec //commands are defined by the component reconcilers
+	cmd.Dir = workDir
+	cmd.Env = e.env(command, workDir)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = waitDelay
+	if command.Stdin != nil {
+		cmd.Stdin = bytes.NewReader(command.Stdin)
+	}
+
+	e.logger.Debugf("Starting sandboxed process '%s' (timeout: %s)", result.Command, timeout)
+	start := time.Now()
+	err = cmd.Run()
+	result.Duration = time.Since(start)
+	result.Stdout = stdout.Bytes()
+	result.Stderr = stderr.Bytes()
+	result.Truncated = stdout.truncated || stderr.truncated
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+	}
+	e.logger.Debugf("Sandboxed process '%s' finished with exit code %d after %s (stdout: %d bytes, stderr: %d bytes, truncated: %t)",
+		result.Command, result.ExitCode, result.Duration, len(result.Stdout), len(result.Stderr), result.Truncated)
+
+	if err != nil || result.TimedOut {
+		if err == nil {
+			err = ctx.Err()
+		}
+		return result, &ExecError{Result: result, Err: err}
+	}
+	return result, nil
+}
+
+func (e *Executor) createWorkDir(command *Command) (string, error) {
+	if err := os.MkdirAll(e.config.BaseDir, 0700); err != nil {
+		return "", errors.Wrap(err, "failed to create base directory of sandboxed processes")
+	}
+	workDir, err := os.MkdirTemp(e.config.BaseDir, "sandbox-")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create working directory")
+	}
+	for name, data := range command.Files {
+		path := filepath.Join(workDir, name)
+		if !strings.HasPrefix(path, workDir+string(filepath.Separator)) {
+			_ = os.RemoveAll(workDir)
+			return "", fmt.Errorf("file '%s' is outside of the working directory", name)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			_ = os.RemoveAll(workDir)
+			return "", errors.Wrap(err, fmt.Sprintf("failed to create directory of file '%s'", name))
+		}
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			_ = os.RemoveAll(workDir)
+			return "", errors.Wrap(err, fmt.Sprintf("failed to write file '%s'", name))
+		}
+	}
+	return workDir, nil
+}
+
+// env returns the allowlisted env vars of the reconciler process and the env vars of the command. HOME and TMPDIR
+// point to the working directory to keep caches and configs of the process (e.g. of helm plugins) isolated.
+func (e *Executor) env(command *Command, workDir string) []string {
+	env := []string{
+		fmt.Sprintf("HOME=%s", workDir),
+		fmt.Sprintf("TMPDIR=%s", workDir),
+	}
+	for _, name := range e.config.EnvAllowlist {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, fmt.Sprintf("%s=%s", name, value))
+		}
+	}
+	for name, value := range command.Env {
+		env = append(env, fmt.Sprintf("%s=%s", name, value)) //later entries override earlier ones
+	}
+	return env
+}
+
+// limitedBuffer drops all writes after the limit was reached
+type limitedBuffer struct {
+	buffer    bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := lb.limit - lb.buffer.Len(); len(p) > remaining {
+		if remaining > 0 {
+			lb.buffer.Write(p[:remaining])
+		}
+		lb.truncated = true
+		return len(p), nil
+	}
+	return lb.buffer.Write(p)
+}
+
+func (lb *limitedBuffer) Bytes() []byte {
+	return lb.buffer.Bytes()
+}
+
+func tail(output []byte, limit int) string {
+	trimmed := strings.TrimSpace(string(output))
+	if len(trimmed) > limit {
+		return "..." + trimmed[len(trimmed)-limit:]
+	}
+	return trimmed
+}
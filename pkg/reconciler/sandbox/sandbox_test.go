@@ -0,0 +1,137 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExecutor(t *testing.T, config *Config) *Executor {
+	if config.BaseDir == "" {
+		config.BaseDir = t.TempDir()
+	}
+	executor, err := NewExecutor(config, logger.NewLogger(false))
+	require.NoError(t, err)
+	return executor
+}
+
+func TestExecutor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires a POSIX shell")
+	}
+
+	t.Run("Run process in separate working directory", func(t *testing.T) {
+		baseDir := t.TempDir()
+		executor := newTestExecutor(t, &Config{BaseDir: baseDir})
+
+		result, err := executor.Run(context.Background(), &Command{
+			Path:  "sh",
+			Args:  []string{"-c", "pwd && cat config/values.yaml && cat -"},
+			Files: map[string][]byte{"config/values.yaml": []byte("replicas: 2\n")},
+			Stdin: []byte("from stdin"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, result.ExitCode)
+		lines := strings.Split(string(result.Stdout), "\n")
+		require.Equal(t, filepath.Base(baseDir), filepath.Base(filepath.Dir(lines[0])))
+		require.True(t, strings.HasPrefix(filepath.Base(lines[0]), "sandbox-"))
+		require.Contains(t, string(result.Stdout), "replicas: 2")
+		require.Contains(t, string(result.Stdout), "from stdin")
+
+		//working directory is removed after the process finished
+		entries, err := os.ReadDir(baseDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("Pass only allowlisted env vars", func(t *testing.T) {
+		t.Setenv("SANDBOX_TEST_ALLOWED", "allowed")
+		t.Setenv("SANDBOX_TEST_SECRET", "secret")
+		executor := newTestExecutor(t, &Config{EnvAllowlist: []string{"PATH", "SANDBOX_TEST_ALLOWED"}})
+
+		result, err := executor.Run(context.Background(), &Command{
+			Path: "sh",
+			Args: []string{"-c", "env"},
+			Env:  map[string]string{"SANDBOX_TEST_COMMAND": "command"},
+		})
+		require.NoError(t, err)
+		env := string(result.Stdout)
+		require.Contains(t, env, "SANDBOX_TEST_ALLOWED=allowed")
+		require.Contains(t, env, "SANDBOX_TEST_COMMAND=command")
+		require.NotContains(t, env, "SANDBOX_TEST_SECRET")
+	})
+
+	t.Run("Report failed process", func(t *testing.T) {
+		executor := newTestExecutor(t, &Config{})
+
+		result, err := executor.Run(context.Background(), &Command{
+			Path: "sh",
+			Args: []string{"-c", "echo 'chart not found' >&2; exit 3"},
+		})
+		require.Error(t, err)
+		var execErr *ExecError
+		require.True(t, errors.As(err, &execErr))
+		require.Equal(t, 3, result.ExitCode)
+		require.False(t, result.TimedOut)
+		require.Contains(t, err.Error(), "exit code 3")
+		require.Contains(t, err.Error(), "chart not found")
+	})
+
+	t.Run("Kill process after timeout", func(t *testing.T) {
+		executor := newTestExecutor(t, &Config{Timeout: 200 * time.Millisecond})
+
+		result, err := executor.Run(context.Background(), &Command{
+			Path: "sleep",
+			Args: []string{"10"},
+		})
+		require.Error(t, err)
+		require.True(t, result.TimedOut)
+		require.Less(t, result.Duration, 10*time.Second)
+		require.Contains(t, err.Error(), "timed out")
+	})
+
+	t.Run("Limit captured output", func(t *testing.T) {
+		executor := newTestExecutor(t, &Config{OutputLimit: 10})
+
+		result, err := executor.Run(context.Background(), &Command{
+			Path: "sh",
+			Args: []string{"-c", "echo 0123456789abcdef"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "0123456789", string(result.Stdout))
+		require.True(t, result.Truncated)
+	})
+
+	t.Run("Reject files outside of the working directory", func(t *testing.T) {
+		executor := newTestExecutor(t, &Config{})
+
+		_, err := executor.Run(context.Background(), &Command{
+			Path:  "true",
+			Files: map[string][]byte{"../escaped": []byte("data")},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("Report missing executable", func(t *testing.T) {
+		executor := newTestExecutor(t, &Config{})
+
+		result, err := executor.Run(context.Background(), &Command{Path: "sandbox-test-does-not-exist"})
+		require.Error(t, err)
+		require.Equal(t, -1, result.ExitCode)
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		_, err := NewExecutor(&Config{Timeout: -1}, logger.NewLogger(false))
+		require.Error(t, err)
+		_, err = NewExecutor(&Config{OutputLimit: -1}, logger.NewLogger(false))
+		require.Error(t, err)
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/sandbox"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +18,10 @@ type ActionContext struct {
 	Logger           *zap.SugaredLogger
 	Task             *reconciler.Task
 	ChartProvider    chart.Provider
+	//Outcomes reports the resources handled by the action as part of the operation result (can be nil)
+	Outcomes *kubernetes.OutcomeRecorder
+	//Executor runs external processes (CLIs, helm plugins) in a sandbox (can be nil)
+	Executor *sandbox.Executor
 }
 
 type Action interface {
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+type UpgradeStrategy string
+
+const (
+	UpgradeStrategyInPlace   UpgradeStrategy = "inPlace"
+	UpgradeStrategyBlueGreen UpgradeStrategy = "blueGreen"
+
+	//UpgradeStrategyKey is the configuration key which selects the upgrade strategy of a component
+	UpgradeStrategyKey = "reconciler.upgradeStrategy"
+)
+
+var releaseSlots = []string{"blue", "green"}
+
+func upgradeStrategyOf(task *reconciler.Task) UpgradeStrategy {
+	if strategy, ok := task.Configuration[UpgradeStrategyKey]; ok &&
+		strings.EqualFold(fmt.Sprint(strategy), string(UpgradeStrategyBlueGreen)) {
+		return UpgradeStrategyBlueGreen
+	}
+	return UpgradeStrategyInPlace
+}
+
+// release is a component version installed into a dedicated namespace
+type release struct {
+	Namespace string
+	Version   string
+}
+
+// blueGreenInstall installs a new component version into a parallel namespace. The new release replaces the
+// previous release after its resources are ready and the optional smoke check succeeded.
+// Cluster-wide resources aren't duplicated: they are shared by both releases.
+type blueGreenInstall struct {
+	install    *Install
+	smokeCheck Action
+	actionCtx  *ActionContext
+	logger     *zap.SugaredLogger
+}
+
+func newBlueGreenInstall(install *Install, smokeCheck Action, actionCtx *ActionContext) *blueGreenInstall {
+	return &blueGreenInstall{
+		install:    install,
+		smokeCheck: smokeCheck,
+		actionCtx:  actionCtx,
+		logger:     actionCtx.Logger,
+	}
+}
+
+func (b *blueGreenInstall) Invoke(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client) error {
+	active, err := b.activeRelease(ctx, task, kubeClient)
+	if err != nil {
+		return err
+	}
+	if task.Type == model.OperationTypeDelete {
+		return b.delete(ctx, chartProvider, task, kubeClient)
+	}
+	if active != nil && active.Version == task.Version {
+		//no upgrade: reconcile the active release and drop leftovers of an interrupted upgrade
+		if err := b.install.Invoke(ctx, chartProvider, releaseTask(task, active.Namespace), kubeClient); err != nil {
+			return err
+		}
+		return b.removeNamespace(ctx, kubeClient, inactiveSlot(task, active))
+	}
+	return b.upgrade(ctx, chartProvider, task, kubeClient, active)
+}
+
+func (b *blueGreenInstall) upgrade(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client, active *release) error {
+	target := &release{Namespace: inactiveSlot(task, active), Version: task.Version}
+	if errs := validation.IsDNS1123Label(target.Namespace); len(errs) > 0 {
+		return fmt.Errorf("namespace '%s' of blue/green release is invalid: %s", target.Namespace, strings.Join(errs, ", "))
+	}
+	b.logger.Infof("Blue/green upgrade of component '%s': installing version '%s' into namespace '%s'",
+		task.Component, task.Version, target.Namespace)
+
+	targetTask := releaseTask(task, target.Namespace)
+	resources, err := b.install.invoke(ctx, chartProvider, targetTask, kubeClient) //waits until resources are ready
+	if err != nil {
+		return err
+	}
+	if b.smokeCheck != nil {
+		actionCtx := *b.actionCtx
+		actionCtx.Task = targetTask
+		if err := b.smokeCheck.Run(&actionCtx); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("smoke check of component '%s' in namespace '%s' failed",
+				task.Component, target.Namespace))
+		}
+	}
+
+	if err := b.switchRelease(ctx, task, kubeClient, target); err != nil {
+		return err
+	}
+	b.logger.Infof("Blue/green upgrade of component '%s': release in namespace '%s' is active",
+		task.Component, target.Namespace)
+
+	if active == nil {
+		return b.removeInPlaceRelease(ctx, task, kubeClient, target, resources)
+	}
+	return b.removeNamespace(ctx, kubeClient, active.Namespace)
+}
+
+func (b *blueGreenInstall) delete(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client) error {
+	for _, slot := range slotNamespaces(task) {
+		if err := b.removeNamespace(ctx, kubeClient, slot); err != nil {
+			return err
+		}
+	}
+	clientset, err := kubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	err = clientset.CoreV1().ConfigMaps(task.Namespace).Delete(ctx, releaseConfigMapName(task), metav1.DeleteOptions{})
+	if err != nil && !k8serr.IsNotFound(err) {
+		return errors.Wrap(err, "failed to delete blue/green release record")
+	}
+	//removes the cluster-wide resources and the remains of an in-place installation
+	return b.install.Invoke(ctx, chartProvider, task, kubeClient)
+}
+
+// activeRelease returns the release which owns the component (nil if the component was installed in-place)
+func (b *blueGreenInstall) activeRelease(ctx context.Context, task *reconciler.Task, kubeClient kubernetes.Client) (*release, error) {
+	clientset, err := kubeClient.Clientset()
+	if err != nil {
+		return nil, err
+	}
+	cm, err := clientset.CoreV1().ConfigMaps(task.Namespace).Get(ctx, releaseConfigMapName(task), metav1.GetOptions{})
+	if k8serr.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get blue/green release record")
+	}
+	return &release{Namespace: cm.Data["namespace"], Version: cm.Data["version"]}, nil
+}
+
+// switchRelease transfers the ownership of the component to the release
+func (b *blueGreenInstall) switchRelease(ctx context.Context, task *reconciler.Task, kubeClient kubernetes.Client, target *release) error {
+	clientset, err := kubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: task.Namespace}}
+	if _, err := clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil && !k8serr.IsAlreadyExists(err) {
+		return errors.Wrap(err, fmt.Sprintf("failed to create namespace '%s' of blue/green release record", task.Namespace))
+	}
+
+	data := map[string]string{
+		"namespace": target.Namespace,
+		"version":   target.Version,
+	}
+	configMaps := clientset.CoreV1().ConfigMaps(task.Namespace)
+	cm, err := configMaps.Get(ctx, releaseConfigMapName(task), metav1.GetOptions{})
+	if k8serr.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      releaseConfigMapName(task),
+				Namespace: task.Namespace,
+				Labels: map[string]string{
+					ManagedByLabel: LabelReconcilerValue,
+				},
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+	} else if err == nil {
+		cm.Data = data
+		_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	}
+	return errors.Wrap(err, "failed to update blue/green release record")
+}
+
+// removeInPlaceRelease deletes the namespaced resources of a component which was installed in-place before
+// its first blue/green upgrade
+func (b *blueGreenInstall) removeInPlaceRelease(ctx context.Context, task *reconciler.Task, kubeClient kubernetes.Client, target *release, resources []*kubernetes.Resource) error {
+	for _, resource := range resources {
+		if resource.Namespace != target.Namespace || strings.EqualFold(resource.Kind, "Namespace") {
+			continue //cluster-wide resources are shared with the new release
+		}
+		if _, err := kubeClient.DeleteResource(ctx, resource.Kind, resource.Name, task.Namespace); err != nil && !k8serr.IsNotFound(err) {
+			return errors.Wrap(err, fmt.Sprintf("failed to delete %s '%s' of previous release in namespace '%s'",
+				resource.Kind, resource.Name, task.Namespace))
+		}
+	}
+	return nil
+}
+
+func (b *blueGreenInstall) removeNamespace(ctx context.Context, kubeClient kubernetes.Client, namespace string) error {
+	if _, err := kubeClient.DeleteResource(ctx, "Namespace", namespace, ""); err != nil && !k8serr.IsNotFound(err) {
+		return errors.Wrap(err, fmt.Sprintf("failed to delete namespace '%s' of previous release", namespace))
+	}
+	return nil
+}
+
+// releaseTask returns a copy of the task which installs the component into the namespace of the release
+func releaseTask(task *reconciler.Task, namespace string) *reconciler.Task {
+	overrides := map[string]string{}
+	for source, target := range task.NamespaceOverrides {
+		overrides[source] = target
+	}
+	overrides[task.Namespace] = namespace
+	result := *task
+	result.NamespaceOverrides = overrides
+	return &result
+}
+
+func slotNamespaces(task *reconciler.Task) []string {
+	var result []string
+	for _, slot := range releaseSlots {
+		result = append(result, fmt.Sprintf("%s-%s-%s", task.Namespace, strings.ToLower(task.Component), slot))
+	}
+	return result
+}
+
+// inactiveSlot returns the namespace of the slot which isn't used by the active release
+func inactiveSlot(task *reconciler.Task, active *release) string {
+	slots := slotNamespaces(task)
+	if active != nil && active.Namespace == slots[0] {
+		return slots[1]
+	}
+	return slots[0]
+}
+
+func releaseConfigMapName(task *reconciler.Task) string {
+	return fmt.Sprintf("%s-release", strings.ToLower(task.Component))
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/fake"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const blueGreenManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: kyma-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cfg
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: role
+`
+
+type smokeCheckAction struct {
+	err        error
+	namespaces []string
+}
+
+func (a *smokeCheckAction) Run(actionCtx *ActionContext) error {
+	a.namespaces = append(a.namespaces, actionCtx.Task.NamespaceOverrides[actionCtx.Task.Namespace])
+	return a.err
+}
+
+func TestBlueGreenInstall(t *testing.T) {
+	ctx := context.Background()
+
+	newTask := func(version string, opType model.OperationType) *reconciler.Task {
+		return &reconciler.Task{
+			Component:     "comp",
+			Namespace:     "kyma-system",
+			Version:       version,
+			Type:          opType,
+			Configuration: map[string]interface{}{UpgradeStrategyKey: "blueGreen"},
+		}
+	}
+	chartProvider := &mocks.Provider{}
+	chartProvider.On("RenderManifest", mock.Anything).Return(&chart.Manifest{Manifest: blueGreenManifest}, nil)
+
+	//install the component in-place
+	kubeClient := fake.NewClient()
+	install := NewInstall(logger.NewTestLogger(t))
+	require.NoError(t, install.Invoke(ctx, chartProvider, newTask("1.0.0", model.OperationTypeReconcile), kubeClient))
+
+	smokeCheck := &smokeCheckAction{}
+	blueGreen := newBlueGreenInstall(install, smokeCheck, &ActionContext{Logger: logger.NewTestLogger(t)})
+
+	requireActiveRelease := func(t *testing.T, namespace, version string) {
+		active, err := blueGreen.activeRelease(ctx, newTask(version, model.OperationTypeReconcile), kubeClient)
+		require.NoError(t, err)
+		require.Equal(t, &release{Namespace: namespace, Version: version}, active)
+	}
+	requireExists := func(t *testing.T, exists bool, kind, name, namespace string) {
+		_, err := kubeClient.Get(kind, name, namespace)
+		if exists {
+			require.NoError(t, err, "%s '%s' in namespace '%s' is missing", kind, name, namespace)
+		} else {
+			require.Error(t, err, "%s '%s' in namespace '%s' wasn't deleted", kind, name, namespace)
+		}
+	}
+
+	t.Run("First upgrade replaces in-place installation", func(t *testing.T) {
+		require.NoError(t, blueGreen.Invoke(ctx, chartProvider, newTask("2.0.0", model.OperationTypeReconcile), kubeClient))
+
+		requireActiveRelease(t, "kyma-system-comp-blue", "2.0.0")
+		require.Equal(t, []string{"kyma-system-comp-blue"}, smokeCheck.namespaces)
+		requireExists(t, true, "Deployment", "app", "kyma-system-comp-blue")
+		requireExists(t, true, "ConfigMap", "cfg", "kyma-system-comp-blue")
+		requireExists(t, false, "Deployment", "app", "kyma-system")
+		requireExists(t, false, "ConfigMap", "cfg", "kyma-system")
+		requireExists(t, true, "ClusterRole", "role", "") //shared by the releases
+	})
+
+	t.Run("Upgrade switches to the other slot", func(t *testing.T) {
+		require.NoError(t, blueGreen.Invoke(ctx, chartProvider, newTask("3.0.0", model.OperationTypeReconcile), kubeClient))
+
+		requireActiveRelease(t, "kyma-system-comp-green", "3.0.0")
+		requireExists(t, true, "Deployment", "app", "kyma-system-comp-green")
+		requireExists(t, false, "Namespace", "kyma-system-comp-blue", "")
+	})
+
+	t.Run("Reconciliation without upgrade keeps the active release", func(t *testing.T) {
+		smokeCheck.namespaces = nil
+		require.NoError(t, blueGreen.Invoke(ctx, chartProvider, newTask("3.0.0", model.OperationTypeReconcile), kubeClient))
+
+		requireActiveRelease(t, "kyma-system-comp-green", "3.0.0")
+		require.Empty(t, smokeCheck.namespaces)
+		requireExists(t, false, "Namespace", "kyma-system-comp-blue", "")
+	})
+
+	t.Run("Failing smoke check keeps the active release", func(t *testing.T) {
+		smokeCheck.err = errors.New("smoke check failed")
+		defer func() { smokeCheck.err = nil }()
+
+		require.Error(t, blueGreen.Invoke(ctx, chartProvider, newTask("4.0.0", model.OperationTypeReconcile), kubeClient))
+
+		requireActiveRelease(t, "kyma-system-comp-green", "3.0.0")
+		requireExists(t, true, "Deployment", "app", "kyma-system-comp-green")
+	})
+
+	t.Run("Delete removes all releases", func(t *testing.T) {
+		require.NoError(t, blueGreen.Invoke(ctx, chartProvider, newTask("3.0.0", model.OperationTypeDelete), kubeClient))
+
+		requireExists(t, false, "Namespace", "kyma-system-comp-blue", "")
+		requireExists(t, false, "Namespace", "kyma-system-comp-green", "")
+		clientset, err := kubeClient.Clientset()
+		require.NoError(t, err)
+		_, err = clientset.CoreV1().ConfigMaps("kyma-system").Get(ctx, "comp-release", metav1.GetOptions{})
+		require.Error(t, err)
+	})
+}
+
+func TestUpgradeStrategy(t *testing.T) {
+	require.Equal(t, UpgradeStrategyInPlace, upgradeStrategyOf(&reconciler.Task{}))
+	require.Equal(t, UpgradeStrategyInPlace, upgradeStrategyOf(&reconciler.Task{
+		Configuration: map[string]interface{}{UpgradeStrategyKey: "inPlace"},
+	}))
+	require.Equal(t, UpgradeStrategyBlueGreen, upgradeStrategyOf(&reconciler.Task{
+		Configuration: map[string]interface{}{UpgradeStrategyKey: "bluegreen"},
+	}))
+}
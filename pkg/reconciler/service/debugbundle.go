@@ -0,0 +1,319 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	operationURLTemplate     = "%s://%s/v1/operations/%s/%s/%s"
+	debugBundleContentType   = "application/gzip"
+	debugBundleUploadTimeout = 30 * time.Second
+	debugBundleMaxLogBytes   = 10 * 1024 * 1024
+	redactedValue            = "<redacted>"
+)
+
+// sensitiveKeyRegex matches configuration keys whose values must not be part of a debug bundle
+var sensitiveKeyRegex = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private|key|cert)`)
+
+// debugBundle captures details about the processing of a task which was flagged as "debug"
+// (rendered manifest, diffs of applied resources, helm values, progress tracker timeline and logs).
+// The bundle is uploaded to the mothership and attached to the operation record for support cases.
+type debugBundle struct {
+	task     *reconciler.Task
+	timeline *progress.Timeline
+	logs     *limitedBuffer
+	mu       sync.Mutex
+	manifest string
+	diffs    bytes.Buffer
+	result   string
+}
+
+func newDebugBundle(task *reconciler.Task) *debugBundle {
+	return &debugBundle{
+		task:     task,
+		timeline: progress.NewTimeline(),
+		logs:     &limitedBuffer{limit: debugBundleMaxLogBytes},
+	}
+}
+
+// wrapLogger returns a logger which writes all log messages (independent of the configured log level)
+// also into the bundle
+func (b *debugBundle) wrapLogger(logger *zap.SugaredLogger) *zap.SugaredLogger {
+	captureCore := zapcore.NewCore(
+		zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()),
+		zapcore.AddSync(b.logs),
+		zapcore.DebugLevel)
+	return logger.Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, captureCore)
+	})).Sugar()
+}
+
+func (b *debugBundle) progressTimeline() *progress.Timeline {
+	if b == nil {
+		return nil
+	}
+	return b.timeline
+}
+
+func (b *debugBundle) captureManifest(manifest string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.manifest = redactManifest(manifest)
+}
+
+// diffInterceptor has to be the last interceptor passed to the kube-client to capture
+// the final state of the resources which will be applied
+func (b *debugBundle) diffInterceptor(kubeClient kubernetes.Client) kubernetes.ResourceInterceptor {
+	if b == nil {
+		return nil
+	}
+	return &DebugDiffInterceptor{
+		kubeClient: kubeClient,
+		bundle:     b,
+	}
+}
+
+func (b *debugBundle) captureDiff(diff string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.diffs.WriteString(diff)
+}
+
+func (b *debugBundle) finish(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.result = string(reconciler.StatusSuccess)
+	} else {
+		b.result = err.Error()
+	}
+}
+
+// archive returns the bundle as gzipped tarball
+func (b *debugBundle) archive() ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	summary, err := json.MarshalIndent(map[string]interface{}{
+		"component":       b.task.Component,
+		"namespace":       b.task.Namespace,
+		"version":         b.task.Version,
+		"profile":         b.task.Profile,
+		"url":             b.task.URL,
+		"type":            b.task.Type,
+		"correlationID":   b.task.CorrelationID,
+		"componentsReady": b.task.ComponentsReady,
+		"result":          b.result,
+		"created":         time.Now().UTC(),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	values, err := yaml.Marshal(redactValues(b.task.Configuration))
+	if err != nil {
+		return nil, err
+	}
+	timeline, err := json.MarshalIndent(b.timeline.Events(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"task.json", summary},
+		{"values.yaml", values},
+		{"manifest.yaml", []byte(b.manifest)},
+		{"diff.patch", b.diffs.Bytes()},
+		{"timeline.json", timeline},
+		{"reconciler.log", b.logs.Bytes()},
+	}
+	for _, file := range files {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    file.name,
+			Mode:    0600,
+			Size:    int64(len(file.data)),
+			ModTime: time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tarWriter.Write(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// upload sends the bundle to the mothership (the URL is derived from the callback URL of the task)
+func (b *debugBundle) upload(logger *zap.SugaredLogger) error {
+	bundleURL, err := parseDebugBundleURL(b.task.CallbackURL)
+	if err != nil {
+		return err
+	}
+	data, err := b.archive()
+	if err != nil {
+		return errors.Wrap(err, "failed to create debug bundle archive")
+	}
+
+	req, err := http.NewRequest(http.MethodPut, bundleURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("content-type", debugBundleContentType)
+	resp, err := httpclient.NewWithTimeout(debugBundleUploadTimeout).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to upload debug bundle")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf("Failed to close response body of debug bundle upload: %s", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("upload of debug bundle to '%s' failed with HTTP code %d", bundleURL, resp.StatusCode)
+	}
+	logger.Infof("Debug bundle (%d bytes) of task with correlation ID '%s' uploaded to '%s'",
+		len(data), b.task.CorrelationID, bundleURL)
+	return nil
+}
+
+func parseDebugBundleURL(callbackURL string) (string, error) {
+	return parseOperationURL(callbackURL, "debug/bundle")
+}
+
+// parseOperationURL returns the URL of a resource of the operation. It expects a callback URL of the mothership
+// which has the format '<scheme>://<host>/v1/operations/<schedulingID>/callback/<correlationID>'
+func parseOperationURL(callbackURL, resource string) (string, error) {
+	if callbackURL == "" {
+		return "", fmt.Errorf("URL of operation resource '%s' requires a callback URL but received empty string", resource)
+	}
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse callback URL")
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 4 || segments[len(segments)-2] != "callback" || segments[len(segments)-4] != "operations" {
+		return "", fmt.Errorf("callback URL '%s' doesn't point to an operation of the mothership", callbackURL)
+	}
+	return fmt.Sprintf(operationURLTemplate, u.Scheme, u.Host,
+		segments[len(segments)-3], segments[len(segments)-1], resource), nil
+}
+
+// redactValues returns a copy of the configuration with masked values for all sensitive keys
+func redactValues(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if sensitiveKeyRegex.MatchString(key) {
+			result[key] = redactedValue
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			result[key] = redactValues(nested)
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
+
+// redactManifest masks the data of all secrets in the manifest
+func redactManifest(manifest string) string {
+	if manifest == "" {
+		return manifest
+	}
+	unstructs, err := kubernetes.ToUnstructured([]byte(manifest), true)
+	if err != nil {
+		//never fall back to the original manifest: it could contain secrets
+		return fmt.Sprintf("# manifest could not be parsed for redaction: %s\n", err)
+	}
+	var buffer bytes.Buffer
+	for _, u := range unstructs {
+		data, err := yaml.Marshal(redactSecret(u).Object)
+		if err != nil {
+			buffer.WriteString(fmt.Sprintf("# %s '%s' could not be serialized: %s\n", u.GetKind(), u.GetName(), err))
+		} else {
+			buffer.Write(data)
+		}
+		buffer.WriteString("---\n")
+	}
+	return buffer.String()
+}
+
+// redactSecret returns a copy of the resource with masked data if the resource is a secret
+func redactSecret(u *unstructured.Unstructured) *unstructured.Unstructured {
+	if u == nil || u.GetKind() != "Secret" {
+		return u
+	}
+	redacted := u.DeepCopy()
+	for _, field := range []string{"data", "stringData"} {
+		data, found, err := unstructured.NestedMap(redacted.Object, field)
+		if err != nil || !found {
+			continue
+		}
+		for key := range data {
+			data[key] = redactedValue
+		}
+		_ = unstructured.SetNestedMap(redacted.Object, data, field)
+	}
+	return redacted
+}
+
+// limitedBuffer is a thread-safe buffer which drops all writes after the limit was reached
+type limitedBuffer struct {
+	buffer    bytes.Buffer
+	limit     int
+	truncated bool
+	mu        sync.Mutex
+}
+
+func (lb *limitedBuffer) Write(p []byte) (int, error) {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	if lb.buffer.Len()+len(p) > lb.limit {
+		if !lb.truncated {
+			lb.buffer.WriteString("... log truncated: size limit of debug bundle reached\n")
+			lb.truncated = true
+		}
+		return len(p), nil
+	}
+	return lb.buffer.Write(p)
+}
+
+func (lb *limitedBuffer) Bytes() []byte {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return append([]byte{}, lb.buffer.Bytes()...)
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+const debugBundleTestManifest = `apiVersion: v1
+kind: Secret
+metadata:
+  name: credentials
+  namespace: unittest
+data:
+  password: c2VjcmV0
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: unittest
+data:
+  key: value
+`
+
+func TestDebugBundle(t *testing.T) {
+	t.Run("Parse debug bundle URL", func(t *testing.T) {
+		bundleURL, err := parseDebugBundleURL("https://mothership:8080/v1/operations/schedID/callback/corrID")
+		require.NoError(t, err)
+		require.Equal(t, "https://mothership:8080/v1/operations/schedID/corrID/debug/bundle", bundleURL)
+
+		_, err = parseDebugBundleURL("")
+		require.Error(t, err)
+		_, err = parseDebugBundleURL("https://mothership:8080/v1/occupancy/123")
+		require.Error(t, err)
+	})
+
+	t.Run("Redact values", func(t *testing.T) {
+		values := redactValues(map[string]interface{}{
+			"global.domainName":    "kyma.example.com",
+			"global.adminPassword": "secret",
+			"nested": map[string]interface{}{
+				"apiToken": "secret",
+				"replicas": 2,
+			},
+		})
+		require.Equal(t, "kyma.example.com", values["global.domainName"])
+		require.Equal(t, redactedValue, values["global.adminPassword"])
+		require.Equal(t, redactedValue, values["nested"].(map[string]interface{})["apiToken"])
+		require.Equal(t, 2, values["nested"].(map[string]interface{})["replicas"])
+	})
+
+	t.Run("Redact manifest", func(t *testing.T) {
+		manifest := redactManifest(debugBundleTestManifest)
+		require.NotContains(t, manifest, "c2VjcmV0")
+		require.Contains(t, manifest, "password: "+redactedValue)
+		require.Contains(t, manifest, "key: value") //only secrets are redacted
+	})
+
+	t.Run("Upload bundle", func(t *testing.T) {
+		var uploaded []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			require.Equal(t, "/v1/operations/schedID/corrID/debug/bundle", r.URL.Path)
+			var err error
+			uploaded, err = io.ReadAll(r.Body)
+			require.NoError(t, err)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		bundle := newDebugBundle(&reconciler.Task{
+			Component:     "unittest",
+			CorrelationID: "corrID",
+			CallbackURL:   srv.URL + "/v1/operations/schedID/callback/corrID",
+			Configuration: map[string]interface{}{"password": "secret"},
+		})
+		log := bundle.wrapLogger(logger.NewLogger(false))
+		log.Debug("captured debug message")
+		bundle.captureManifest(debugBundleTestManifest)
+		bundle.finish(errors.New("unittest failure"))
+		require.NoError(t, bundle.upload(log))
+
+		files := extractDebugBundle(t, uploaded)
+		require.Contains(t, string(files["reconciler.log"]), "captured debug message")
+		require.Contains(t, string(files["task.json"]), "unittest failure")
+		require.Contains(t, string(files["values.yaml"]), redactedValue)
+		require.NotContains(t, string(files["manifest.yaml"]), "c2VjcmV0")
+		require.Contains(t, files, "diff.patch")
+		require.Contains(t, files, "timeline.json")
+	})
+
+	t.Run("Undefined bundle is ignored", func(t *testing.T) {
+		var bundle *debugBundle
+		bundle.captureManifest(debugBundleTestManifest)
+		require.Nil(t, bundle.diffInterceptor(nil))
+		require.Nil(t, bundle.progressTimeline())
+	})
+}
+
+func extractDebugBundle(t *testing.T, data []byte) map[string][]byte {
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+	files := make(map[string][]byte)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		files[header.Name] = content
+	}
+	return files
+}
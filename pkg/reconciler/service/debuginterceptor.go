@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pmezard/go-difflib/difflib"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// DebugDiffInterceptor doesn't modify any resource: it captures the diff between the resources on the cluster
+// and the resources which will be applied in the debug bundle
+type DebugDiffInterceptor struct {
+	kubeClient kubernetes.Client
+	bundle     *debugBundle
+}
+
+func (i *DebugDiffInterceptor) Intercept(resources *kubernetes.ResourceCacheList, _ string) error {
+	return resources.Visit(func(u *unstructured.Unstructured) error {
+		//capturing a diff is best effort and never lets the deployment fail
+		i.bundle.captureDiff(i.diff(u))
+		return nil
+	})
+}
+
+func (i *DebugDiffInterceptor) diff(u *unstructured.Unstructured) string {
+	resource := fmt.Sprintf("%s/%s/%s", u.GetKind(), u.GetNamespace(), u.GetName())
+
+	var live string
+	existingResource, err := i.kubeClient.Get(u.GetKind(), u.GetName(), u.GetNamespace())
+	if err != nil && !k8serr.IsNotFound(err) {
+		return fmt.Sprintf("# %s: failed to retrieve resource from cluster: %s\n", resource, err)
+	}
+	if err == nil && existingResource != nil {
+		if live, err = toComparableYAML(existingResource); err != nil {
+			return fmt.Sprintf("# %s: failed to serialize resource of cluster: %s\n", resource, err)
+		}
+	}
+	target, err := toComparableYAML(u)
+	if err != nil {
+		return fmt.Sprintf("# %s: failed to serialize target resource: %s\n", resource, err)
+	}
+	if live == target {
+		return fmt.Sprintf("# %s: unchanged\n", resource)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(live),
+		B:        difflib.SplitLines(target),
+		FromFile: "cluster/" + resource,
+		ToFile:   "target/" + resource,
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Sprintf("# %s: failed to create diff: %s\n", resource, err)
+	}
+	return diff
+}
+
+// toComparableYAML drops all fields which are maintained by the API server and masks secret data
+func toComparableYAML(u *unstructured.Unstructured) (string, error) {
+	comparable := redactSecret(u).DeepCopy()
+	unstructured.RemoveNestedField(comparable.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink"} {
+		unstructured.RemoveNestedField(comparable.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(comparable.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	data, err := yaml.Marshal(comparable.Object)
+	return string(data), err
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDebugDiffInterceptor(t *testing.T) {
+	newConfigMap := func(name, value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "unittest",
+			},
+			"data": map[string]interface{}{
+				"key": value,
+			},
+		}}
+	}
+
+	existing := newConfigMap("existing", "old")
+	existing.SetResourceVersion("123") //fields maintained by the API server are ignored
+	unchanged := newConfigMap("unchanged", "value")
+
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Get", "ConfigMap", "existing", "unittest").Return(existing, nil)
+	kubeClient.On("Get", "ConfigMap", "unchanged", "unittest").Return(unchanged, nil)
+	kubeClient.On("Get", "ConfigMap", "created", "unittest").
+		Return(nil, k8serr.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "created"))
+
+	bundle := newDebugBundle(&reconciler.Task{})
+	resources := kubernetes.NewResourceList([]*unstructured.Unstructured{
+		newConfigMap("existing", "new"),
+		newConfigMap("unchanged", "value"),
+		newConfigMap("created", "value"),
+	})
+	require.NoError(t, bundle.diffInterceptor(kubeClient).Intercept(resources, "unittest"))
+
+	diffs := bundle.diffs.String()
+	require.Contains(t, diffs, "-  key: old\n+  key: new")
+	require.Contains(t, diffs, "# ConfigMap/unittest/unchanged: unchanged")
+	require.Contains(t, diffs, "+++ target/ConfigMap/unittest/created")
+	require.NotContains(t, diffs, "resourceVersion")
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	failureCaptureContentType   = "application/json"
+	failureCaptureUploadTimeout = 30 * time.Second
+)
+
+// newFailureCapture captures the payload of a failed task together with the values resolved for its component
+// chart. It allows support engineers to reproduce the rendering of the component without asking the original
+// caller for the payload. The kubeconfig and all sensitive values are redacted.
+func newFailureCapture(task *reconciler.Task, failure error, values map[string]interface{}, valuesErr error) (*reconciler.FailureCapture, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal task")
+	}
+	var taskData map[string]interface{}
+	if err := json.Unmarshal(data, &taskData); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal task")
+	}
+	taskData["kubeconfig"] = redactedValue
+	if configuration, ok := taskData["configuration"].(map[string]interface{}); ok {
+		taskData["configuration"] = redactValues(configuration)
+	}
+
+	capture := &reconciler.FailureCapture{
+		Created: time.Now().UTC(),
+		Error:   failure.Error(),
+		Task:    taskData,
+	}
+	if valuesErr != nil {
+		reason := valuesErr.Error()
+		capture.ValuesError = &reason
+	} else if values != nil {
+		redacted := redactValues(values)
+		capture.Values = &redacted
+	}
+	return capture, nil
+}
+
+// uploadFailureCapture sends the capture to the mothership (the URL is derived from the callback URL of the task)
+func uploadFailureCapture(capture *reconciler.FailureCapture, callbackURL string, logger *zap.SugaredLogger) error {
+	captureURL, err := parseOperationURL(callbackURL, "failure")
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(capture)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal failure capture")
+	}
+
+	req, err := http.NewRequest(http.MethodPut, captureURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("content-type", failureCaptureContentType)
+	resp, err := httpclient.NewWithTimeout(failureCaptureUploadTimeout).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to upload failure capture")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf("Failed to close response body of failure capture upload: %s", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("upload of failure capture to '%s' failed with HTTP code %d", captureURL, resp.StatusCode)
+	}
+	logger.Debugf("Failure capture (%d bytes) uploaded to '%s'", len(data), captureURL)
+	return nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureCapture(t *testing.T) {
+	task := &reconciler.Task{
+		Component:     "unittest",
+		Version:       "1.2.3",
+		Kubeconfig:    "apiVersion: v1\nkind: Config",
+		CorrelationID: "corrID",
+		Configuration: map[string]interface{}{
+			"global.domainName": "kyma.example.com",
+			"nested": map[string]interface{}{
+				"password": "secret",
+			},
+		},
+	}
+
+	t.Run("Redact task and values", func(t *testing.T) {
+		capture, err := newFailureCapture(task, errors.New("unittest failure"), map[string]interface{}{
+			"replicas": 2,
+			"auth": map[string]interface{}{
+				"clientSecret": "secret",
+			},
+		}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "unittest failure", capture.Error)
+		require.Equal(t, redactedValue, capture.Task["kubeconfig"])
+		require.Equal(t, "unittest", capture.Task["component"])
+
+		configuration := capture.Task["configuration"].(map[string]interface{})
+		require.Equal(t, "kyma.example.com", configuration["global.domainName"])
+		require.Equal(t, redactedValue, configuration["nested"].(map[string]interface{})["password"])
+
+		require.NotNil(t, capture.Values)
+		values := *capture.Values
+		require.Equal(t, 2, values["replicas"])
+		require.Equal(t, redactedValue, values["auth"].(map[string]interface{})["clientSecret"])
+		require.Nil(t, capture.ValuesError)
+
+		//the task itself is not modified
+		require.Equal(t, "apiVersion: v1\nkind: Config", task.Kubeconfig)
+		require.Equal(t, "secret", task.Configuration["nested"].(map[string]interface{})["password"])
+	})
+
+	t.Run("Values which couldn't be resolved", func(t *testing.T) {
+		capture, err := newFailureCapture(task, errors.New("unittest failure"), nil, errors.New("chart not found"))
+		require.NoError(t, err)
+		require.Nil(t, capture.Values)
+		require.NotNil(t, capture.ValuesError)
+		require.Equal(t, "chart not found", *capture.ValuesError)
+	})
+
+	t.Run("Upload capture", func(t *testing.T) {
+		var uploaded reconciler.FailureCapture
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPut, r.Method)
+			require.Equal(t, "/v1/operations/schedID/corrID/failure", r.URL.Path)
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &uploaded))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		capture, err := newFailureCapture(task, errors.New("unittest failure"), nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, uploadFailureCapture(capture, srv.URL+"/v1/operations/schedID/callback/corrID", logger.NewLogger(false)))
+		require.Equal(t, "unittest failure", uploaded.Error)
+		require.Equal(t, redactedValue, uploaded.Task["kubeconfig"])
+	})
+
+	t.Run("Upload rejected by mothership", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		capture, err := newFailureCapture(task, errors.New("unittest failure"), nil, nil)
+		require.NoError(t, err)
+		require.Error(t, uploadFailureCapture(capture, srv.URL+"/v1/operations/schedID/callback/corrID", logger.NewLogger(false)))
+		require.Error(t, uploadFailureCapture(capture, "", logger.NewLogger(false)))
+	})
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	//HelmReleaseCleanupDryRunKey is the configuration key which reports orphaned Helm releases without deleting them
+	HelmReleaseCleanupDryRunKey = "reconciler.helmReleaseCleanup.dryRun"
+
+	helmOwnerLabelSelector = "owner=helm"
+	helmReleaseNameLabel   = "name"
+)
+
+// HelmReleaseCleanupConfig configures the removal of orphaned Helm releases. Dry-run can also be enabled by
+// the configuration of a task.
+type HelmReleaseCleanupConfig struct {
+	DryRun bool //orphaned releases are only reported but not deleted
+}
+
+// HelmReleaseCleanupAction removes the storage (Secrets or ConfigMaps) of Helm releases which belong to components
+// that are no longer part of the desired state of the cluster. Orphaned release storage lets later Helm-based
+// installations of the component fail or upgrade from a wrong revision.
+//
+// Only releases in namespaces of the desired components are considered: releases in other namespaces aren't
+// managed by Kyma. The action does nothing if the task doesn't provide the components of the cluster.
+// A failed deletion doesn't fail the reconciliation: the outcome of each resource is reported as part of the
+// operation result.
+type HelmReleaseCleanupAction struct {
+	config HelmReleaseCleanupConfig
+}
+
+func NewHelmReleaseCleanupAction(config HelmReleaseCleanupConfig) *HelmReleaseCleanupAction {
+	return &HelmReleaseCleanupAction{config: config}
+}
+
+func (a *HelmReleaseCleanupAction) Run(actionCtx *ActionContext) error {
+	a.Cleanup(actionCtx)
+	return nil
+}
+
+// Cleanup removes the storage of orphaned Helm releases and returns the outcome per resource. In dry-run mode the
+// resources which would be deleted are returned with outcome 'deleted' but aren't recorded as part of the
+// operation result.
+func (a *HelmReleaseCleanupAction) Cleanup(actionCtx *ActionContext) []kubernetes.ResourceResult {
+	if actionCtx.Task == nil || len(actionCtx.Task.Components) == 0 {
+		return nil
+	}
+	dryRun := a.config.DryRun || configFlag(actionCtx.Task.Configuration, HelmReleaseCleanupDryRunKey)
+
+	desired := map[string]bool{}
+	namespaces := map[string]bool{}
+	for _, component := range actionCtx.Task.Components {
+		desired[component.Name] = true
+		namespaces[component.Namespace] = true
+	}
+
+	clientset, err := actionCtx.KubeClient.Clientset()
+	if err != nil {
+		actionCtx.Logger.Errorf("Failed to retrieve clientset to clean up orphaned Helm releases: %s", err)
+		return nil
+	}
+
+	var orphans []helmReleaseStorage
+	listOpts := metav1.ListOptions{LabelSelector: helmOwnerLabelSelector}
+	if secrets, err := clientset.CoreV1().Secrets("").List(actionCtx.Context, listOpts); err != nil {
+		actionCtx.Logger.Errorf("Failed to list storage Secrets of Helm releases: %s", err)
+	} else {
+		for _, secret := range secrets.Items {
+			orphans = appendOrphanedRelease(orphans, "Secret", secret.ObjectMeta, desired, namespaces)
+		}
+	}
+	if configMaps, err := clientset.CoreV1().ConfigMaps("").List(actionCtx.Context, listOpts); err != nil {
+		actionCtx.Logger.Errorf("Failed to list storage ConfigMaps of Helm releases: %s", err)
+	} else {
+		for _, configMap := range configMaps.Items {
+			orphans = appendOrphanedRelease(orphans, "ConfigMap", configMap.ObjectMeta, desired, namespaces)
+		}
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].String() < orphans[j].String()
+	})
+
+	results := make([]kubernetes.ResourceResult, 0, len(orphans))
+	for _, orphan := range orphans {
+		result := kubernetes.ResourceResult{
+			APIVersion: "v1",
+			Kind:       orphan.kind,
+			Namespace:  orphan.namespace,
+			Name:       orphan.name,
+			Outcome:    kubernetes.ResourceOutcomeDeleted,
+		}
+		if dryRun {
+			actionCtx.Logger.Infof("Dry-run: %s would be deleted", orphan)
+			results = append(results, result)
+			continue
+		}
+
+		var err error
+		if orphan.kind == "Secret" {
+			err = clientset.CoreV1().Secrets(orphan.namespace).Delete(actionCtx.Context, orphan.name, metav1.DeleteOptions{})
+		} else {
+			err = clientset.CoreV1().ConfigMaps(orphan.namespace).Delete(actionCtx.Context, orphan.name, metav1.DeleteOptions{})
+		}
+		switch {
+		case k8serr.IsNotFound(err):
+			result.Outcome = kubernetes.ResourceOutcomeUnchanged
+		case err != nil:
+			actionCtx.Logger.Errorf("Failed to delete %s: %s", orphan, err)
+			result.Outcome = kubernetes.ResourceOutcomeFailed
+			result.Error = err.Error()
+		default:
+			actionCtx.Logger.Infof("Deleted %s", orphan)
+		}
+		results = append(results, result)
+	}
+
+	if !dryRun {
+		for _, result := range results {
+			actionCtx.Outcomes.Record(result)
+		}
+	}
+	return results
+}
+
+type helmReleaseStorage struct {
+	kind      string
+	namespace string
+	name      string
+	release   string
+}
+
+func (s helmReleaseStorage) String() string {
+	return fmt.Sprintf("storage %s '%s' of orphaned Helm release '%s' (namespace: %s)",
+		s.kind, s.name, s.release, s.namespace)
+}
+
+// appendOrphanedRelease appends the release storage if its release doesn't belong to a desired component
+func appendOrphanedRelease(orphans []helmReleaseStorage, kind string, meta metav1.ObjectMeta,
+	desired, namespaces map[string]bool) []helmReleaseStorage {
+	release := meta.Labels[helmReleaseNameLabel]
+	if release == "" || desired[release] || !namespaces[meta.Namespace] {
+		return orphans
+	}
+	return append(orphans, helmReleaseStorage{
+		kind:      kind,
+		namespace: meta.Namespace,
+		name:      meta.Name,
+		release:   release,
+	})
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHelmReleaseCleanupAction(t *testing.T) {
+	releaseMeta := func(name, namespace, release string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"owner": "helm", "name": release},
+		}
+	}
+	newObjects := func() []runtime.Object {
+		return []runtime.Object{
+			&corev1.Secret{ObjectMeta: releaseMeta("sh.helm.release.v1.istio.v1", "istio-system", "istio")},
+			&corev1.Secret{ObjectMeta: releaseMeta("sh.helm.release.v1.monitoring.v3", "kyma-system", "monitoring")},
+			&corev1.ConfigMap{ObjectMeta: releaseMeta("tracing.v1", "kyma-system", "tracing")},
+			//releases outside of component namespaces aren't managed by Kyma
+			&corev1.Secret{ObjectMeta: releaseMeta("sh.helm.release.v1.customer.v1", "customer", "customer")},
+			//no release storage
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "kyma-system"}},
+		}
+	}
+	newActionContext := func(clientset *fake.Clientset, configuration map[string]interface{}) *ActionContext {
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Clientset").Return(clientset, nil)
+		return &ActionContext{
+			KubeClient: kubeClient,
+			Context:    context.Background(),
+			Logger:     logger.NewLogger(true),
+			Task: &reconciler.Task{
+				Configuration: configuration,
+				Components: []reconciler.ClusterComponent{
+					{Name: "istio", Namespace: "istio-system"},
+					{Name: "serverless", Namespace: "kyma-system"},
+				},
+			},
+			Outcomes: kubernetes.NewOutcomeRecorder(),
+		}
+	}
+	expected := []kubernetes.ResourceResult{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kyma-system", Name: "tracing.v1",
+			Outcome: kubernetes.ResourceOutcomeDeleted},
+		{APIVersion: "v1", Kind: "Secret", Namespace: "kyma-system", Name: "sh.helm.release.v1.monitoring.v3",
+			Outcome: kubernetes.ResourceOutcomeDeleted},
+	}
+
+	t.Run("Delete orphaned releases", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newObjects()...)
+		results := NewHelmReleaseCleanupAction(HelmReleaseCleanupConfig{}).Cleanup(newActionContext(clientset, nil))
+		require.Equal(t, expected, results)
+
+		secrets, err := clientset.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		var names []string
+		for _, secret := range secrets.Items {
+			names = append(names, secret.Name)
+		}
+		require.ElementsMatch(t, []string{"sh.helm.release.v1.istio.v1", "sh.helm.release.v1.customer.v1", "other"}, names)
+		_, err = clientset.CoreV1().ConfigMaps("kyma-system").Get(context.Background(), "tracing.v1", metav1.GetOptions{})
+		require.Error(t, err)
+	})
+
+	t.Run("Report orphaned releases in dry-run mode", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset(newObjects()...)
+		actionCtx := newActionContext(clientset, map[string]interface{}{HelmReleaseCleanupDryRunKey: "true"})
+		results := NewHelmReleaseCleanupAction(HelmReleaseCleanupConfig{}).Cleanup(actionCtx)
+		require.Equal(t, expected, results)
+
+		_, err := clientset.CoreV1().ConfigMaps("kyma-system").Get(context.Background(), "tracing.v1", metav1.GetOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("Skip tasks without components", func(t *testing.T) {
+		actionCtx := &ActionContext{Task: &reconciler.Task{}}
+		require.Empty(t, NewHelmReleaseCleanupAction(HelmReleaseCleanupConfig{}).Cleanup(actionCtx))
+	})
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IgnoreFieldsKeyPrefix is the prefix of configuration keys which define ignored fields of a kind
+// (e.g. 'reconciler.ignoreFields.Deployment: spec.replicas,spec.template.metadata.annotations')
+const IgnoreFieldsKeyPrefix = "reconciler.ignoreFields."
+
+// IgnoredFields maps a kind to the paths of the fields which are owned by controllers running in the cluster.
+// Paths use the dot-notation (e.g. 'spec.replicas'), dots which are part of a field name have to be escaped
+// (e.g. 'metadata.annotations.sidecar\.istio\.io/status').
+type IgnoredFields map[string][]string
+
+// merge returns a copy of the ignored fields which also contains the fields of other
+func (f IgnoredFields) merge(other IgnoredFields) IgnoredFields {
+	result := make(IgnoredFields, len(f)+len(other))
+	for _, fields := range []IgnoredFields{f, other} {
+		for kind, paths := range fields {
+			result[strings.ToLower(kind)] = append(result[strings.ToLower(kind)], paths...)
+		}
+	}
+	return result
+}
+
+// ignoredFieldsOf returns the ignored fields defined in the configuration of the task
+func ignoredFieldsOf(task *reconciler.Task) IgnoredFields {
+	result := IgnoredFields{}
+	for key, value := range task.Configuration {
+		if !strings.HasPrefix(key, IgnoreFieldsKeyPrefix) {
+			continue
+		}
+		kind := strings.TrimPrefix(key, IgnoreFieldsKeyPrefix)
+		var paths []string
+		switch typedValue := value.(type) {
+		case []interface{}:
+			for _, path := range typedValue {
+				paths = append(paths, fmt.Sprint(path))
+			}
+		default:
+			paths = strings.Split(fmt.Sprint(value), ",")
+		}
+		for _, path := range paths {
+			if path = strings.TrimSpace(path); path != "" {
+				result[kind] = append(result[kind], path)
+			}
+		}
+	}
+	return result
+}
+
+// IgnoreFieldsInterceptor replaces the ignored fields of a resource with the values of the resource in the cluster.
+// This avoids that a reconciliation reverts fields which are intentionally changed by controllers
+// (e.g. the replicas of a deployment which is scaled by an HPA) or defaulted by the API server.
+type IgnoreFieldsInterceptor struct {
+	kubeClient    kubernetes.Client
+	ignoredFields IgnoredFields
+	logger        *zap.SugaredLogger
+}
+
+func (i *IgnoreFieldsInterceptor) Intercept(resources *kubernetes.ResourceCacheList, namespace string) error {
+	interceptorFunc := func(u *unstructured.Unstructured) error {
+		paths := i.ignoredFields[strings.ToLower(u.GetKind())]
+		if len(paths) == 0 {
+			return nil
+		}
+
+		existingResource, err := i.kubeClient.Get(u.GetKind(), u.GetName(), kubernetes.ResolveNamespace(u, namespace))
+		if err != nil {
+			if k8serr.IsNotFound(err) { //resource will be created: use the values of the manifest
+				return nil
+			}
+			return err
+		}
+		if existingResource == nil {
+			return nil
+		}
+
+		for _, path := range paths {
+			fields := parseFieldPath(path)
+			value, found, err := unstructured.NestedFieldNoCopy(existingResource.Object, fields...)
+			if err != nil || !found {
+				continue
+			}
+			if err := unstructured.SetNestedField(u.Object, value, fields...); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to keep ignored field '%s' of %s '%s'",
+					path, u.GetKind(), u.GetName()))
+			}
+			i.logger.Debugf("Ignored field '%s' of %s '%s' (namespace: %s): value in cluster is kept",
+				path, u.GetKind(), u.GetName(), existingResource.GetNamespace())
+		}
+		return nil
+	}
+
+	return resources.Visit(interceptorFunc)
+}
+
+// parseFieldPath splits a path like 'spec.replicas' into its fields. A leading '$' or '.' is accepted to
+// support JSONPath-like notations.
+func parseFieldPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	var fields []string
+	var field strings.Builder
+	for idx := 0; idx < len(path); idx++ {
+		switch {
+		case path[idx] == '\\' && idx+1 < len(path) && path[idx+1] == '.':
+			field.WriteByte('.')
+			idx++
+		case path[idx] == '.':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(path[idx])
+		}
+	}
+	return append(fields, field.String())
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIgnoreFieldsInterceptor(t *testing.T) {
+	newDeployment := func(name string, replicas int64, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "unittest",
+			},
+			"spec": map[string]interface{}{
+				"replicas": replicas,
+			},
+		}}
+		u.SetAnnotations(annotations)
+		return u
+	}
+
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Get", "Deployment", "existing", "unittest").
+		Return(newDeployment("existing", 5, map[string]string{"sidecar.istio.io/status": "injected"}), nil)
+	kubeClient.On("Get", "Deployment", "created", "unittest").
+		Return(nil, k8serr.NewNotFound(schema.GroupResource{Resource: "deployments"}, "created"))
+
+	existing := newDeployment("existing", 1, map[string]string{"owner": "reconciler"})
+	created := newDeployment("created", 1, nil)
+	interceptor := &IgnoreFieldsInterceptor{
+		kubeClient: kubeClient,
+		ignoredFields: IgnoredFields{}.merge(IgnoredFields{
+			"Deployment": {"spec.replicas", `$.metadata.annotations.sidecar\.istio\.io/status`, "spec.paused"},
+		}),
+		logger: logger.NewLogger(true),
+	}
+	err := interceptor.Intercept(kubernetes.NewResourceList([]*unstructured.Unstructured{existing, created}), "unittest")
+	require.NoError(t, err)
+
+	replicas, _, _ := unstructured.NestedInt64(existing.Object, "spec", "replicas")
+	require.Equal(t, int64(5), replicas)
+	require.Equal(t, map[string]string{"owner": "reconciler", "sidecar.istio.io/status": "injected"},
+		existing.GetAnnotations())
+	_, found, _ := unstructured.NestedFieldNoCopy(existing.Object, "spec", "paused")
+	require.False(t, found) //fields missing in the cluster aren't touched
+
+	replicas, _, _ = unstructured.NestedInt64(created.Object, "spec", "replicas")
+	require.Equal(t, int64(1), replicas)
+}
+
+func TestIgnoredFieldsOf(t *testing.T) {
+	task := &reconciler.Task{Configuration: map[string]interface{}{
+		IgnoreFieldsKeyPrefix + "Deployment":  "spec.replicas, spec.template.metadata.annotations",
+		IgnoreFieldsKeyPrefix + "StatefulSet": []interface{}{"spec.replicas"},
+		"global.domainName":                   "example.com",
+	}}
+	require.Equal(t, IgnoredFields{
+		"Deployment":  {"spec.replicas", "spec.template.metadata.annotations"},
+		"StatefulSet": {"spec.replicas"},
+	}, ignoredFieldsOf(task))
+}
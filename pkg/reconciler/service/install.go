@@ -20,7 +20,13 @@ import (
 )
 
 type Install struct {
-	logger *zap.SugaredLogger
+	logger        *zap.SugaredLogger
+	debugBundle   *debugBundle
+	phases        *phaseRecorder
+	ignoredFields IgnoredFields
+	prerendered   *prerenderedManifest
+	memory        *memoryReservation
+	renderCache   RenderCache
 }
 
 func NewInstall(logger *zap.SugaredLogger) *Install {
@@ -33,33 +39,58 @@ type Operation interface {
 }
 
 func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client) error {
+	_, err := r.invoke(ctx, chartProvider, task, kubeClient)
+	return err
+}
+
+// invoke returns the deployed or deleted resources
+func (r *Install) invoke(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client) ([]*kubernetes.Resource, error) {
 	var err error
 	var manifest string
-	if task.Component == model.CRDComponent {
+	if prerendered, ok := r.prerendered.get(ctx, task); ok {
+		manifest = prerendered
+	} else if task.Component == model.CRDComponent {
 		manifest, err = r.renderCRDs(chartProvider, task)
 	} else if task.Component != model.CleanupComponent { // TODO add better support for components that do not have manifests
 		manifest, err = r.renderManifest(chartProvider, task)
 	}
 	if err != nil {
-		return err
+		return nil, err
+	}
+	manifest, skipped, err := filterManifest(manifest, task.ResourceFilters)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to apply resource filters to manifest of component '%s'",
+			task.Component))
 	}
+	for _, resource := range skipped {
+		r.logger.Debugf("Skipping %s: excluded by resource filters", resource)
+	}
+	r.memory.account(len(manifest))
+	r.phases.record(reconciler.OperationPhasePhaseRendered)
+	r.debugBundle.captureManifest(manifest)
 
+	var resources []*kubernetes.Resource
 	if task.Type == model.OperationTypeDelete {
-		resources, err := kubeClient.Delete(ctx, manifest, task.Namespace)
+		resources, err = kubeClient.Delete(ctx, manifest, task.Namespace)
 		if err == nil {
 			r.logger.Debugf("Deletion of manifest finished successfully: %d resources deleted", len(resources))
 		} else {
 			r.logger.Warnf("Failed to delete manifests on target cluster: %s", err)
-			return err
+			return nil, err
 		}
 	} else {
 		if task.Component == model.CleanupComponent {
-			return nil
+			return nil, nil
 		}
-		resources, err := kubeClient.Deploy(ctx, manifest, task.Namespace,
+		interceptors := []kubernetes.ResourceInterceptor{
 			&LabelsInterceptor{
 				Version: task.Version,
 			},
+			&OwnershipInterceptor{
+				kubeClient: kubeClient,
+				Component:  task.Component,
+				RuntimeID:  task.RuntimeID,
+			},
 			&AnnotationsInterceptor{},
 			&ServicesInterceptor{
 				kubeClient: kubeClient,
@@ -78,18 +109,47 @@ func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task
 					"OAuth2Client",
 				},
 			},
-		)
+		}
+		if ignoredFields := r.ignoredFields.merge(ignoredFieldsOf(task)); len(ignoredFields) > 0 {
+			interceptors = append(interceptors, &IgnoreFieldsInterceptor{
+				kubeClient:    kubeClient,
+				ignoredFields: ignoredFields,
+				logger:        r.logger,
+			})
+		}
+		namespace := task.Namespace
+		if len(task.NamespaceOverrides) > 0 {
+			nsOverrideInterceptor := &NamespaceOverrideInterceptor{Overrides: task.NamespaceOverrides}
+			namespace = nsOverrideInterceptor.Namespace(namespace)
+			//has to be the first interceptor as the other interceptors rely on the final namespaces
+			interceptors = append([]kubernetes.ResourceInterceptor{nsOverrideInterceptor}, interceptors...)
+		}
+		if r.debugBundle != nil {
+			interceptors = append(interceptors, r.debugBundle.diffInterceptor(kubeClient)) //has to be the last interceptor
+		}
+		resources, err = kubeClient.Deploy(ctx, manifest, namespace, interceptors...)
 		if err == nil {
 			r.logger.Debugf("Deployment of manifest finished successfully: %d resources deployed", len(resources))
 		} else {
 			r.logger.Warnf("Failed to deploy manifests on target cluster: %s", err)
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return resources, nil
 }
 
 func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler.Task) (string, error) {
+	var fingerprint string
+	if r.renderCache != nil && cacheable(model) {
+		fingerprint = renderFingerprint(model)
+	}
+	if fingerprint != "" {
+		if manifest, ok := r.renderCache.Get(fingerprint); ok {
+			r.logger.Debugf("Using cached manifest of component '%s' in version '%s'", model.Component, model.Version)
+			return manifest, nil
+		}
+	}
+
 	component := chart.NewComponentBuilder(model.Version, model.Component).
 		WithProfile(model.Profile).
 		WithNamespace(model.Namespace).
@@ -110,6 +170,9 @@ func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler
 		return "", errors.Wrap(err, msg)
 	}
 
+	if fingerprint != "" {
+		r.renderCache.Set(fingerprint, chartManifest.Manifest)
+	}
 	return chartManifest.Manifest, nil
 }
 
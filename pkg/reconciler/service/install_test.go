@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
@@ -8,7 +9,12 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestInstall(t *testing.T) {
@@ -40,3 +46,40 @@ func TestInstall(t *testing.T) {
 		require.ElementsMatch(t, testCase.expected, got)
 	}
 }
+
+// interceptingClient passes the resources to all interceptors like the kube-client does during a deployment
+type interceptingClient struct {
+	kubernetes.Client
+	interceptors []kubernetes.ResourceInterceptor
+}
+
+func (c *interceptingClient) Deploy(_ context.Context, _, namespace string, interceptors ...kubernetes.ResourceInterceptor) ([]*kubernetes.Resource, error) {
+	c.interceptors = interceptors
+	for _, interceptor := range interceptors {
+		if err := interceptor.Intercept(kubernetes.NewResourceList([]*unstructured.Unstructured{}), namespace); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func TestInstallWithoutDebugBundle(t *testing.T) {
+	chartProvider := &mocks.Provider{}
+	chartProvider.On("RenderManifest", mock.Anything).Return(&chart.Manifest{Manifest: ""}, nil)
+	kubeClient := &interceptingClient{}
+
+	install := NewInstall(logger.NewTestLogger(t))
+	require.NoError(t, install.Invoke(context.Background(), chartProvider, &reconciler.Task{
+		Component: "unittest",
+		Version:   "1.2.3",
+		Namespace: "unittest",
+		Type:      model.OperationTypeReconcile,
+	}, kubeClient))
+
+	require.NotEmpty(t, kubeClient.interceptors)
+	for _, interceptor := range kubeClient.interceptors {
+		require.NotNil(t, interceptor)
+		_, isDebugInterceptor := interceptor.(*DebugDiffInterceptor)
+		require.False(t, isDebugInterceptor)
+	}
+}
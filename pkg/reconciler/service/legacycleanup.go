@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	//LegacyCleanupDryRunKey is the configuration key which reports the legacy resources without deleting them
+	LegacyCleanupDryRunKey = "reconciler.legacyCleanup.dryRun"
+
+	defaultLegacyCleanupAttempts = 5
+	defaultLegacyCleanupDelay    = 2 * time.Second
+)
+
+// LegacyResource declares a resource which was deployed by a former version of a component and has to be removed.
+// The resource is either identified by its name or by a label selector.
+type LegacyResource struct {
+	Kind          string //kind or resource name accepted by the kube client (e.g. 'deployment')
+	Namespace     string //namespace of the resource (empty for cluster-scoped resources or all namespaces if a label selector is used)
+	Name          string
+	LabelSelector string //used if no name is defined (e.g. 'app=avs-bridge')
+}
+
+func (r LegacyResource) String() string {
+	if r.Name == "" {
+		return fmt.Sprintf("%s with labels '%s' (namespace: %s)", r.Kind, r.LabelSelector, r.Namespace)
+	}
+	return fmt.Sprintf("%s '%s' (namespace: %s)", r.Kind, r.Name, r.Namespace)
+}
+
+// LegacyCleanupConfig configures the removal of legacy resources. Dry-run can also be enabled by the configuration
+// of a task.
+type LegacyCleanupConfig struct {
+	Resources []LegacyResource
+	DryRun    bool          //legacy resources are only reported but not deleted
+	Attempts  uint          //attempts to delete a resource (default 5)
+	Delay     time.Duration //delay between the attempts which is doubled after each attempt (default 2s)
+}
+
+// LegacyCleanupAction deletes legacy resources and verifies that they are gone. A failed deletion doesn't fail
+// the reconciliation: the outcome of each resource is reported as part of the operation result.
+type LegacyCleanupAction struct {
+	config LegacyCleanupConfig
+}
+
+func NewLegacyCleanupAction(config LegacyCleanupConfig) *LegacyCleanupAction {
+	if config.Attempts == 0 {
+		config.Attempts = defaultLegacyCleanupAttempts
+	}
+	if config.Delay <= 0 {
+		config.Delay = defaultLegacyCleanupDelay
+	}
+	return &LegacyCleanupAction{config: config}
+}
+
+func (a *LegacyCleanupAction) Run(actionCtx *ActionContext) error {
+	a.Cleanup(actionCtx)
+	return nil
+}
+
+// Cleanup removes the legacy resources and returns the outcome per resource. In dry-run mode the resources which
+// would be deleted are returned with outcome 'deleted' but aren't recorded as part of the operation result.
+func (a *LegacyCleanupAction) Cleanup(actionCtx *ActionContext) []kubernetes.ResourceResult {
+	dryRun := a.config.DryRun || legacyCleanupDryRun(actionCtx)
+
+	var results []kubernetes.ResourceResult
+	for _, legacyResource := range a.config.Resources {
+		if legacyResource.Name != "" {
+			results = append(results, a.delete(actionCtx, legacyResource, dryRun))
+			continue
+		}
+		matches, err := a.find(actionCtx, legacyResource)
+		if err != nil {
+			actionCtx.Logger.Errorf("Failed to find legacy %s: %s", legacyResource, err)
+			results = append(results, kubernetes.ResourceResult{
+				Kind:      legacyResource.Kind,
+				Namespace: legacyResource.Namespace,
+				Outcome:   kubernetes.ResourceOutcomeFailed,
+				Error:     err.Error(),
+			})
+			continue
+		}
+		for _, match := range matches {
+			results = append(results, a.delete(actionCtx, match, dryRun))
+		}
+	}
+
+	if !dryRun {
+		for _, result := range results {
+			actionCtx.Outcomes.Record(result)
+		}
+	}
+	return results
+}
+
+// find returns the resources matching the label selector of the legacy resource
+func (a *LegacyCleanupAction) find(actionCtx *ActionContext, legacyResource LegacyResource) ([]LegacyResource, error) {
+	list, err := actionCtx.KubeClient.ListResource(actionCtx.Context, legacyResource.Kind,
+		metav1.ListOptions{LabelSelector: legacyResource.LabelSelector})
+	if err != nil {
+		return nil, err
+	}
+	var result []LegacyResource
+	for _, item := range list.Items {
+		if legacyResource.Namespace != "" && item.GetNamespace() != legacyResource.Namespace {
+			continue
+		}
+		result = append(result, LegacyResource{
+			Kind:      legacyResource.Kind,
+			Namespace: item.GetNamespace(),
+			Name:      item.GetName(),
+		})
+	}
+	return result, nil
+}
+
+// delete deletes the legacy resource until it's gone
+func (a *LegacyCleanupAction) delete(actionCtx *ActionContext, legacyResource LegacyResource, dryRun bool) kubernetes.ResourceResult {
+	result := kubernetes.ResourceResult{
+		Kind:      legacyResource.Kind,
+		Namespace: legacyResource.Namespace,
+		Name:      legacyResource.Name,
+		Outcome:   kubernetes.ResourceOutcomeUnchanged,
+	}
+
+	err := retry.Do(func() error {
+		existing, err := a.get(actionCtx, legacyResource)
+		if err != nil || existing == nil {
+			return err
+		}
+		result.APIVersion = existing.GetAPIVersion()
+		result.Kind = existing.GetKind()
+		if dryRun {
+			actionCtx.Logger.Infof("Dry-run: legacy %s would be deleted", legacyResource)
+			result.Outcome = kubernetes.ResourceOutcomeDeleted
+			return nil
+		}
+
+		if _, err := actionCtx.KubeClient.DeleteResource(actionCtx.Context, legacyResource.Kind, legacyResource.Name,
+			legacyResource.Namespace); err != nil {
+			return err
+		}
+		result.Outcome = kubernetes.ResourceOutcomeDeleted
+
+		//verify the resource is gone (e.g. finalizers can block its deletion)
+		existing, err = a.get(actionCtx, legacyResource)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("legacy %s still exists", legacyResource)
+		}
+		return nil
+	},
+		retry.Attempts(a.config.Attempts),
+		retry.Delay(a.config.Delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.Context(actionCtx.Context))
+
+	if err != nil {
+		actionCtx.Logger.Errorf("Failed to delete legacy %s: %s", legacyResource, err)
+		result.Outcome = kubernetes.ResourceOutcomeFailed
+		result.Error = err.Error()
+	} else if !dryRun {
+		actionCtx.Logger.Debugf("Legacy %s is absent (outcome: %s)", legacyResource, result.Outcome)
+	}
+	return result
+}
+
+// get returns the legacy resource or nil if it doesn't exist
+func (a *LegacyCleanupAction) get(actionCtx *ActionContext, legacyResource LegacyResource) (*unstructured.Unstructured, error) {
+	existing, err := actionCtx.KubeClient.Get(legacyResource.Kind, legacyResource.Name, legacyResource.Namespace)
+	if k8serr.IsNotFound(err) {
+		return nil, nil
+	}
+	return existing, err
+}
+
+func legacyCleanupDryRun(actionCtx *ActionContext) bool {
+	if actionCtx.Task == nil {
+		return false
+	}
+	return configFlag(actionCtx.Task.Configuration, LegacyCleanupDryRunKey)
+}
+
+// configFlag returns true if the configuration enables the flag (as boolean or string)
+func configFlag(configuration map[string]interface{}, key string) bool {
+	switch value := configuration[key].(type) {
+	case bool:
+		return value
+	case string:
+		flag, err := strconv.ParseBool(value)
+		return err == nil && flag
+	default:
+		return false
+	}
+}
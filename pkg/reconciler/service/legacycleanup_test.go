@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestLegacyCleanupAction(t *testing.T) {
+	newDeployment := func(name, namespace string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("apps/v1")
+		u.SetKind("Deployment")
+		u.SetName(name)
+		u.SetNamespace(namespace)
+		return u
+	}
+	notFound := func(name string) error {
+		return k8serr.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, name)
+	}
+	newActionContext := func(kubeClient kubernetes.Client, configuration map[string]interface{}) *ActionContext {
+		return &ActionContext{
+			KubeClient: kubeClient,
+			Context:    context.Background(),
+			Logger:     logger.NewLogger(true),
+			Task:       &reconciler.Task{Configuration: configuration},
+			Outcomes:   kubernetes.NewOutcomeRecorder(),
+		}
+	}
+	config := LegacyCleanupConfig{
+		Resources: []LegacyResource{
+			{Kind: "deployment", Namespace: "kyma-system", Name: "absent"},
+			{Kind: "deployment", Namespace: "kyma-system", Name: "legacy"},
+			{Kind: "deployment", Namespace: "kyma-system", LabelSelector: "app=legacy"},
+		},
+		Attempts: 2,
+		Delay:    time.Millisecond,
+	}
+
+	t.Run("Delete legacy resources", func(t *testing.T) {
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Get", "deployment", "absent", "kyma-system").Return(nil, notFound("absent"))
+		kubeClient.On("Get", "deployment", "legacy", "kyma-system").Return(newDeployment("legacy", "kyma-system"), nil).Once()
+		kubeClient.On("Get", "deployment", "legacy", "kyma-system").Return(nil, notFound("legacy"))
+		kubeClient.On("Get", "deployment", "blocked", "kyma-system").Return(newDeployment("blocked", "kyma-system"), nil)
+		kubeClient.On("DeleteResource", mock.Anything, "deployment", mock.Anything, "kyma-system").Return(nil, nil)
+		kubeClient.On("ListResource", mock.Anything, "deployment", metav1.ListOptions{LabelSelector: "app=legacy"}).
+			Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+				*newDeployment("blocked", "kyma-system"),
+				*newDeployment("other", "default"),
+			}}, nil)
+		actionCtx := newActionContext(kubeClient, nil)
+
+		results := NewLegacyCleanupAction(config).Cleanup(actionCtx)
+
+		require.Len(t, results, 3)
+		require.Equal(t, kubernetes.ResourceOutcomeUnchanged, results[0].Outcome)
+		require.Equal(t, kubernetes.ResourceResult{
+			APIVersion: "apps/v1", Kind: "Deployment", Namespace: "kyma-system", Name: "legacy",
+			Outcome: kubernetes.ResourceOutcomeDeleted,
+		}, results[1])
+		require.Equal(t, "blocked", results[2].Name)
+		require.Equal(t, kubernetes.ResourceOutcomeFailed, results[2].Outcome)
+		require.Contains(t, results[2].Error, "still exists")
+		require.Len(t, actionCtx.Outcomes.Results(), 3)
+		kubeClient.AssertNumberOfCalls(t, "DeleteResource", 3) //legacy once, blocked twice
+	})
+
+	t.Run("Report legacy resources in dry-run mode", func(t *testing.T) {
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Get", "deployment", "absent", "kyma-system").Return(nil, nil)
+		kubeClient.On("Get", "deployment", "legacy", "kyma-system").Return(newDeployment("legacy", "kyma-system"), nil)
+		kubeClient.On("ListResource", mock.Anything, "deployment", mock.Anything).
+			Return(nil, errors.New("forbidden"))
+		actionCtx := newActionContext(kubeClient, map[string]interface{}{LegacyCleanupDryRunKey: "true"})
+
+		results := NewLegacyCleanupAction(config).Cleanup(actionCtx)
+
+		require.Len(t, results, 3)
+		require.Equal(t, kubernetes.ResourceOutcomeUnchanged, results[0].Outcome)
+		require.Equal(t, kubernetes.ResourceOutcomeDeleted, results[1].Outcome)
+		require.Equal(t, kubernetes.ResourceOutcomeFailed, results[2].Outcome)
+		require.Empty(t, actionCtx.Outcomes.Results())
+		kubeClient.AssertNotCalled(t, "DeleteResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
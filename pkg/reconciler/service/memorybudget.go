@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"go.uber.org/zap"
+)
+
+const (
+	//operationBaseMemory is the memory an operation needs independent of its manifest (e.g. for the loaded chart)
+	operationBaseMemory int64 = 32 << 20
+	//manifestMemoryFactor approximates the memory of a manifest after it was decoded into resources
+	manifestMemoryFactor int64 = 8
+)
+
+// memoryBudget limits the approximate memory used by the operations which are processed in parallel. New
+// operations are delayed until their estimated memory fits into the budget. An operation is always admitted if no
+// other operation is in flight: otherwise operations larger than the budget would never start.
+type memoryBudget struct {
+	limit     int64
+	logger    *zap.SugaredLogger
+	mu        sync.Mutex
+	used      int64
+	inFlight  int
+	released  chan struct{}    //closed and replaced whenever memory was released
+	estimates map[string]int64 //observed memory per component version
+}
+
+func newMemoryBudget(limit int64, logger *zap.SugaredLogger) *memoryBudget {
+	if limit <= 0 {
+		return nil
+	}
+	return &memoryBudget{
+		limit:     limit,
+		logger:    logger,
+		released:  make(chan struct{}),
+		estimates: make(map[string]int64),
+	}
+}
+
+// acquire blocks until the estimated memory of the task fits into the budget
+func (b *memoryBudget) acquire(ctx context.Context, task *reconciler.Task) (*memoryReservation, error) {
+	if b == nil {
+		return nil, nil
+	}
+	key := memoryEstimateKey(task)
+	waiting := false
+	for {
+		b.mu.Lock()
+		estimate, ok := b.estimates[key]
+		if !ok {
+			estimate = operationBaseMemory
+		}
+		if b.inFlight == 0 || b.used+estimate <= b.limit {
+			b.used += estimate
+			b.inFlight++
+			b.mu.Unlock()
+			return &memoryReservation{budget: b, key: key, reserved: estimate}, nil
+		}
+		released := b.released
+		used := b.used
+		b.mu.Unlock()
+
+		if !waiting {
+			waiting = true
+			b.logger.Infof("Delaying %s: estimated memory of %d MiB exceeds the budget (%d of %d MiB in use)",
+				task, estimate>>20, used>>20, b.limit>>20)
+		}
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("memory budget exhausted: %s could not be started: %w", task, ctx.Err())
+		}
+	}
+}
+
+// Used returns the memory reserved by the operations in flight
+func (b *memoryBudget) Used() int64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+func (b *memoryBudget) notify() {
+	close(b.released)
+	b.released = make(chan struct{})
+}
+
+// memoryReservation is the share of the memory budget used by an operation
+type memoryReservation struct {
+	budget   *memoryBudget
+	key      string
+	reserved int64
+	done     bool
+}
+
+// account replaces the estimated memory of the operation by the memory derived from its rendered manifest.
+// The adjusted value is also used as estimate for later operations of the same component version.
+func (r *memoryReservation) account(manifestSize int) {
+	if r == nil {
+		return
+	}
+	usage := operationBaseMemory + int64(manifestSize)*manifestMemoryFactor
+	b := r.budget
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.estimates[r.key] = usage
+	if r.done {
+		return
+	}
+	b.used += usage - r.reserved
+	if usage < r.reserved {
+		b.notify()
+	}
+	r.reserved = usage
+}
+
+// release returns the reserved memory to the budget
+func (r *memoryReservation) release() {
+	if r == nil {
+		return
+	}
+	b := r.budget
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if r.done {
+		return
+	}
+	r.done = true
+	b.used -= r.reserved
+	b.inFlight--
+	b.notify()
+}
+
+func memoryEstimateKey(task *reconciler.Task) string {
+	return fmt.Sprintf("%s/%s/%s", task.Component, task.Version, task.URL)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	newTask := func(component string) *reconciler.Task {
+		return &reconciler.Task{Component: component, Version: "1.0.0"}
+	}
+
+	t.Run("Disabled budget admits all operations", func(t *testing.T) {
+		budget := newMemoryBudget(0, logger.NewLogger(true))
+		require.Nil(t, budget)
+		reservation, err := budget.acquire(context.Background(), newTask("a"))
+		require.NoError(t, err)
+		reservation.account(1 << 30)
+		reservation.release()
+	})
+
+	t.Run("Operation is admitted if no other operation is in flight", func(t *testing.T) {
+		budget := newMemoryBudget(1, logger.NewLogger(true))
+		reservation, err := budget.acquire(context.Background(), newTask("a"))
+		require.NoError(t, err)
+		require.Equal(t, operationBaseMemory, budget.Used())
+		reservation.release()
+		reservation.release() //releasing twice is ignored
+		require.Zero(t, budget.Used())
+	})
+
+	t.Run("Accounting adjusts the reservation and the estimate of the component", func(t *testing.T) {
+		budget := newMemoryBudget(1<<30, logger.NewLogger(true))
+		reservation, err := budget.acquire(context.Background(), newTask("a"))
+		require.NoError(t, err)
+		reservation.account(1 << 20)
+		expected := operationBaseMemory + manifestMemoryFactor<<20
+		require.Equal(t, expected, budget.Used())
+		reservation.release()
+
+		reservation, err = budget.acquire(context.Background(), newTask("a"))
+		require.NoError(t, err)
+		require.Equal(t, expected, budget.Used())
+		reservation.release()
+	})
+
+	t.Run("Operation is delayed until memory is released", func(t *testing.T) {
+		budget := newMemoryBudget(operationBaseMemory+1, logger.NewLogger(true))
+		first, err := budget.acquire(context.Background(), newTask("a"))
+		require.NoError(t, err)
+
+		acquired := make(chan *memoryReservation)
+		go func() {
+			second, err := budget.acquire(context.Background(), newTask("b"))
+			require.NoError(t, err)
+			acquired <- second
+		}()
+
+		select {
+		case <-acquired:
+			t.Fatal("operation was started although the budget is exhausted")
+		case <-time.After(100 * time.Millisecond):
+		}
+		first.release()
+
+		select {
+		case second := <-acquired:
+			require.Equal(t, operationBaseMemory, budget.Used())
+			second.release()
+		case <-time.After(5 * time.Second):
+			t.Fatal("operation wasn't started after memory was released")
+		}
+	})
+
+	t.Run("Waiting is stopped by the context", func(t *testing.T) {
+		budget := newMemoryBudget(operationBaseMemory+1, logger.NewLogger(true))
+		first, err := budget.acquire(context.Background(), newTask("a"))
+		require.NoError(t, err)
+		defer first.release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err = budget.acquire(ctx, newTask("b"))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, operationBaseMemory, budget.Used())
+	})
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// NamespaceOverrideInterceptor moves resources from the namespaces defined in the chart into the target
+// namespaces requested by the task. Namespace resources are renamed accordingly.
+type NamespaceOverrideInterceptor struct {
+	Overrides map[string]string //key: namespace of the chart, value: target namespace
+}
+
+func (i *NamespaceOverrideInterceptor) Intercept(resources *kubernetes.ResourceCacheList, _ string) error {
+	interceptorFunc := func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "Namespace" {
+			if target, ok := i.Overrides[u.GetName()]; ok {
+				u.SetName(target)
+			}
+			return nil
+		}
+		if target, ok := i.Overrides[u.GetNamespace()]; ok {
+			u.SetNamespace(target)
+		}
+		return nil
+	}
+
+	return resources.Visit(interceptorFunc)
+}
+
+// Namespace returns the target namespace of the given namespace
+func (i *NamespaceOverrideInterceptor) Namespace(namespace string) string {
+	if target, ok := i.Overrides[namespace]; ok {
+		return target
+	}
+	return namespace
+}
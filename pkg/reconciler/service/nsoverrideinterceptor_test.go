@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNamespaceOverrideInterceptor(t *testing.T) {
+	namespace := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata":   map[string]interface{}{"name": "source"},
+	}}
+	overriddenDeployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "deploy1", "namespace": "source"},
+	}}
+	unchangedDeployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "deploy2", "namespace": "other"},
+	}}
+
+	interceptor := &NamespaceOverrideInterceptor{Overrides: map[string]string{"source": "target"}}
+	resources := kubernetes.NewResourceList([]*unstructured.Unstructured{namespace, overriddenDeployment, unchangedDeployment})
+	require.NoError(t, interceptor.Intercept(resources, ""))
+
+	require.Equal(t, "target", namespace.GetName())
+	require.Equal(t, "target", overriddenDeployment.GetNamespace())
+	require.Equal(t, "other", unchangedDeployment.GetNamespace())
+	require.Equal(t, "target", interceptor.Namespace("source"))
+	require.Equal(t, "other", interceptor.Namespace("other"))
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"go.uber.org/zap"
@@ -78,7 +79,7 @@ func (t *OccupancyTracker) createOrUpdateComponentReconcilerOccupancy(reconciler
 		t.logger.Errorf("occupancy tracker failed to marshal HTTP payload to update occupancy of service '%s': %s", t.occupancyID, err)
 		return
 	}
-	resp, err := http.Post(t.occupancyCallbackURL, "application/json", bytes.NewBuffer(jsonPayload))
+	resp, err := httpclient.Default().Post(t.occupancyCallbackURL, "application/json", bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		t.logger.Error(err.Error())
 		return
@@ -106,9 +107,7 @@ func (t *OccupancyTracker) createOrUpdateComponentReconcilerOccupancy(reconciler
 }
 
 func (t *OccupancyTracker) deleteWorkerPoolOccupancy() {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
+	client := httpclient.NewWithTimeout(10 * time.Second)
 	req, err := http.NewRequest(http.MethodDelete, t.occupancyCallbackURL, nil)
 	if err != nil {
 		t.logger.Error(err.Error())
@@ -130,6 +129,9 @@ func parseOccupancyCallbackURL(callbackURL, occupancyID string) (string, error)
 	if err != nil {
 		return "", fmt.Errorf("occupancy tracker failed to parse callback URL: %s", err)
 	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("occupancy tracker requires an HTTP callback URL but got scheme '%s'", u.Scheme)
+	}
 	return fmt.Sprintf(occupancyURLTemplate, u.Scheme, u.Hostname(), u.Port(), occupancyID), nil
 }
 
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	OwnerComponentLabel         = "reconciler.kyma-project.io/owned-by-component"
+	OwnerClusterAnnotation      = "reconciler.kyma-project.io/owned-by-cluster"
+	ReconcilerVersionAnnotation = "reconciler.kyma-project.io/reconciler-version"
+)
+
+// ReconcilerVersion is the version of the reconciler which applied a resource (injected at build time)
+var ReconcilerVersion = "dev"
+
+// sharedKinds are deployed by several components and can't have a single owner
+var sharedKinds = []string{"Namespace", "CustomResourceDefinition"}
+
+// OwnershipConflict describes a resource which is already owned by another component
+type OwnershipConflict struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Owner     string
+}
+
+func (c OwnershipConflict) String() string {
+	if c.Namespace == "" {
+		return fmt.Sprintf("%s '%s' is owned by component '%s'", c.Kind, c.Name, c.Owner)
+	}
+	return fmt.Sprintf("%s '%s' (namespace: %s) is owned by component '%s'", c.Kind, c.Name, c.Namespace, c.Owner)
+}
+
+// OwnershipConflictError is returned if a component tries to apply resources which are owned by other components
+type OwnershipConflictError struct {
+	Component string
+	Conflicts []OwnershipConflict
+}
+
+func (e *OwnershipConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		conflicts = append(conflicts, conflict.String())
+	}
+	return fmt.Sprintf("ownership conflict: component '%s' cannot apply resources owned by other components: %s",
+		e.Component, strings.Join(conflicts, "; "))
+}
+
+// OwnershipInterceptor labels each resource with the component which owns it and annotates the cluster and the
+// version of the reconciler which applied it. It rejects resources which are already owned by another component
+// to avoid that two components overwrite each other's resources in each reconciliation.
+type OwnershipInterceptor struct {
+	kubeClient kubernetes.Client
+	Component  string
+	RuntimeID  string
+}
+
+func (i *OwnershipInterceptor) Intercept(resources *kubernetes.ResourceCacheList, namespace string) error {
+	var conflicts []OwnershipConflict
+	interceptorFunc := func(u *unstructured.Unstructured) error {
+		if isSharedKind(u.GetKind()) {
+			return nil
+		}
+
+		resourceNamespace := kubernetes.ResolveNamespace(u, namespace)
+		existingResource, err := i.kubeClient.Get(u.GetKind(), u.GetName(), resourceNamespace)
+		if err != nil && !k8serr.IsNotFound(err) {
+			return err
+		}
+		//resources without owner were applied by an older reconciler: they get adopted
+		if err == nil && existingResource != nil {
+			if owner := existingResource.GetLabels()[OwnerComponentLabel]; owner != "" && owner != i.Component {
+				conflicts = append(conflicts, OwnershipConflict{
+					Kind:      u.GetKind(),
+					Namespace: existingResource.GetNamespace(),
+					Name:      u.GetName(),
+					Owner:     owner,
+				})
+				return nil
+			}
+		}
+
+		labels := u.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[OwnerComponentLabel] = i.Component
+		u.SetLabels(labels)
+
+		annotations := u.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		if i.RuntimeID != "" {
+			annotations[OwnerClusterAnnotation] = i.RuntimeID
+		}
+		annotations[ReconcilerVersionAnnotation] = ReconcilerVersion
+		u.SetAnnotations(annotations)
+		return nil
+	}
+
+	if err := resources.Visit(interceptorFunc); err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		sort.Slice(conflicts, func(a, b int) bool {
+			return conflicts[a].String() < conflicts[b].String()
+		})
+		return &OwnershipConflictError{Component: i.Component, Conflicts: conflicts}
+	}
+	return nil
+}
+
+func isSharedKind(kind string) bool {
+	for _, sharedKind := range sharedKinds {
+		if strings.EqualFold(sharedKind, kind) {
+			return true
+		}
+	}
+	return false
+}
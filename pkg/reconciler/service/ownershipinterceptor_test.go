@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestOwnershipInterceptor(t *testing.T) {
+	newResource := func(kind, name, owner string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "unittest",
+			},
+		}}
+		if owner != "" {
+			u.SetLabels(map[string]string{OwnerComponentLabel: owner})
+		}
+		return u
+	}
+	notFound := k8serr.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "created")
+
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Get", "ConfigMap", "created", "unittest").Return(nil, notFound)
+	kubeClient.On("Get", "ConfigMap", "owned", "unittest").Return(newResource("ConfigMap", "owned", "comp1"), nil)
+	kubeClient.On("Get", "ConfigMap", "adopted", "unittest").Return(newResource("ConfigMap", "adopted", ""), nil)
+	kubeClient.On("Get", "ConfigMap", "foreign", "unittest").Return(newResource("ConfigMap", "foreign", "comp2"), nil)
+
+	t.Run("Resources are labeled with their owner", func(t *testing.T) {
+		unstructs := []*unstructured.Unstructured{
+			newResource("ConfigMap", "created", ""),
+			newResource("ConfigMap", "owned", ""),
+			newResource("ConfigMap", "adopted", ""),
+			newResource("Namespace", "unittest", ""), //shared kinds are not looked up
+		}
+		interceptor := &OwnershipInterceptor{kubeClient: kubeClient, Component: "comp1", RuntimeID: "runtime1"}
+		require.NoError(t, interceptor.Intercept(kubernetes.NewResourceList(unstructs), "unittest"))
+
+		for _, u := range unstructs[:3] {
+			require.Equal(t, "comp1", u.GetLabels()[OwnerComponentLabel])
+			require.Equal(t, "runtime1", u.GetAnnotations()[OwnerClusterAnnotation])
+			require.Equal(t, ReconcilerVersion, u.GetAnnotations()[ReconcilerVersionAnnotation])
+		}
+		require.Empty(t, unstructs[3].GetLabels())
+	})
+
+	t.Run("Resources owned by other components are rejected", func(t *testing.T) {
+		interceptor := &OwnershipInterceptor{kubeClient: kubeClient, Component: "comp1"}
+		err := interceptor.Intercept(kubernetes.NewResourceList([]*unstructured.Unstructured{
+			newResource("ConfigMap", "owned", ""),
+			newResource("ConfigMap", "foreign", ""),
+		}), "unittest")
+		require.Error(t, err)
+
+		ownershipErr, ok := err.(*OwnershipConflictError)
+		require.True(t, ok)
+		require.Equal(t, []OwnershipConflict{
+			{Kind: "ConfigMap", Namespace: "unittest", Name: "foreign", Owner: "comp2"},
+		}, ownershipErr.Conflicts)
+		require.Contains(t, err.Error(), "ConfigMap 'foreign' (namespace: unittest) is owned by component 'comp2'")
+	})
+}
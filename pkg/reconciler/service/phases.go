@@ -0,0 +1,75 @@
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+)
+
+// phaseRecorder remembers when the processing of a task reached a particular phase. The timestamps are
+// reported to the mothership which exposes them as operation timeline.
+// If a phase is reached multiple times (e.g. by retries), the latest timestamp wins.
+type phaseRecorder struct {
+	phases map[reconciler.OperationPhasePhase]time.Time
+	mu     sync.Mutex
+}
+
+func newPhaseRecorder() *phaseRecorder {
+	return &phaseRecorder{
+		phases: make(map[reconciler.OperationPhasePhase]time.Time),
+	}
+}
+
+func (p *phaseRecorder) record(phase reconciler.OperationPhasePhase) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.phases[phase] = time.Now().UTC()
+}
+
+// Phases returns the reached phases in chronological order
+func (p *phaseRecorder) Phases() []reconciler.OperationPhase {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	result := make([]reconciler.OperationPhase, 0, len(p.phases))
+	for phase, reached := range p.phases {
+		result = append(result, reconciler.OperationPhase{
+			Phase:   phase,
+			Reached: reached,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Reached.Before(result[j].Reached)
+	})
+	return result
+}
+
+// workspaceObserver records the phase 'workspaceReady' as soon as a workspace was successfully retrieved
+type workspaceObserver struct {
+	chart.Factory
+	phases *phaseRecorder
+}
+
+func (o *workspaceObserver) Get(version string) (*chart.KymaWorkspace, error) {
+	ws, err := o.Factory.Get(version)
+	if err == nil {
+		o.phases.record(reconciler.OperationPhasePhaseWorkspaceReady)
+	}
+	return ws, err
+}
+
+func (o *workspaceObserver) GetExternalComponent(component *chart.Component) (*chart.Workspace, error) {
+	ws, err := o.Factory.GetExternalComponent(component)
+	if err == nil {
+		o.phases.record(reconciler.OperationPhasePhaseWorkspaceReady)
+	}
+	return ws, err
+}
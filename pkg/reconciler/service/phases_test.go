@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWorkspaceFactory struct {
+	chart.Factory
+}
+
+func (f *failingWorkspaceFactory) Get(_ string) (*chart.KymaWorkspace, error) {
+	return nil, errors.New("workspace not available")
+}
+
+func TestPhaseRecorder(t *testing.T) {
+	t.Run("Phases are returned in chronological order", func(t *testing.T) {
+		phases := newPhaseRecorder()
+		phases.record(reconciler.OperationPhasePhaseRendered)
+		phases.record(reconciler.OperationPhasePhaseApplied)
+		phases.record(reconciler.OperationPhasePhaseRendered) //latest timestamp wins
+
+		result := phases.Phases()
+		require.Len(t, result, 2)
+		require.Equal(t, reconciler.OperationPhasePhaseApplied, result[0].Phase)
+		require.Equal(t, reconciler.OperationPhasePhaseRendered, result[1].Phase)
+	})
+
+	t.Run("Undefined recorder is ignored", func(t *testing.T) {
+		var phases *phaseRecorder
+		phases.record(reconciler.OperationPhasePhaseRendered)
+		require.Empty(t, phases.Phases())
+	})
+
+	t.Run("Workspace phase is only recorded for available workspaces", func(t *testing.T) {
+		phases := newPhaseRecorder()
+		observer := &workspaceObserver{
+			Factory: &failingWorkspaceFactory{},
+			phases:  phases,
+		}
+		_, err := observer.Get("1.2.3")
+		require.Error(t, err)
+		require.Empty(t, phases.Phases())
+	})
+}
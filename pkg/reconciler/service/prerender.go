@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"go.uber.org/zap"
+)
+
+// prerenderedManifest is rendered in the background while the operation is set up (e.g. while the kube client
+// is constructed): retrieving the workspace and rendering the chart overlap with the connection setup.
+// The manifest is only used if the inputs of the rendering weren't changed in between (e.g. by a pre-action
+// or a values migration).
+type prerenderedManifest struct {
+	fingerprint string
+	done        chan struct{}
+	manifest    string
+	err         error
+}
+
+// prerender starts the rendering of the manifest of the task. Nothing is rendered for components without
+// manifest.
+func prerender(install *Install, chartProvider chart.Provider, task *reconciler.Task, logger *zap.SugaredLogger) *prerenderedManifest {
+	if task.Component == model.CleanupComponent {
+		return nil
+	}
+	//render a copy: pre-actions are allowed to change the configuration of the task meanwhile
+	snapshot := *task
+	snapshot.Configuration = make(map[string]interface{}, len(task.Configuration))
+	for key, value := range task.Configuration {
+		snapshot.Configuration[key] = value
+	}
+	fingerprint := renderFingerprint(&snapshot)
+	if fingerprint == "" {
+		return nil
+	}
+	result := &prerenderedManifest{
+		fingerprint: fingerprint,
+		done:        make(chan struct{}),
+	}
+	go func() {
+		defer close(result.done)
+		if snapshot.Component == model.CRDComponent {
+			result.manifest, result.err = install.renderCRDs(chartProvider, &snapshot)
+		} else {
+			result.manifest, result.err = install.renderManifest(chartProvider, &snapshot)
+		}
+		if result.err != nil {
+			logger.Debugf("Pre-rendering of component '%s' failed (rendering is repeated by the operation): %s",
+				snapshot.Component, result.err)
+		}
+	}()
+	return result
+}
+
+// get waits for the pre-rendered manifest and returns it if it's still valid for the task
+func (p *prerenderedManifest) get(ctx context.Context, task *reconciler.Task) (string, bool) {
+	if p == nil || p.fingerprint != renderFingerprint(task) {
+		return "", false
+	}
+	select {
+	case <-p.done:
+		return p.manifest, p.err == nil
+	case <-ctx.Done():
+		return "", false
+	}
+}
+
+// renderFingerprint identifies the inputs of the rendering of a task
+func renderFingerprint(task *reconciler.Task) string {
+	inputs, err := json.Marshal([]interface{}{ //keys of maps are sorted by the encoder
+		task.Component, task.Version, task.URL, task.Profile, task.Namespace, task.Configuration,
+	})
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(inputs)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrerender(t *testing.T) {
+	log := logger.NewLogger(true)
+	install := NewInstall(log)
+	newTask := func() *reconciler.Task {
+		return &reconciler.Task{
+			Component:     "istio",
+			Version:       "2.0.0",
+			Namespace:     "istio-system",
+			Configuration: map[string]interface{}{"a.b": "value"},
+		}
+	}
+
+	t.Run("Use pre-rendered manifest", func(t *testing.T) {
+		chartProvider := &mocks.Provider{}
+		chartProvider.On("RenderManifest", mock.Anything).
+			Return(&chart.Manifest{Manifest: "kind: Deployment"}, nil).Once()
+
+		prerendered := prerender(install, chartProvider, newTask(), log)
+		manifest, ok := prerendered.get(context.Background(), newTask())
+		require.True(t, ok)
+		require.Equal(t, "kind: Deployment", manifest)
+		chartProvider.AssertNumberOfCalls(t, "RenderManifest", 1)
+	})
+
+	t.Run("Ignore pre-rendered manifest of changed task", func(t *testing.T) {
+		chartProvider := &mocks.Provider{}
+		chartProvider.On("RenderManifest", mock.Anything).
+			Return(&chart.Manifest{Manifest: "kind: Deployment"}, nil)
+
+		task := newTask()
+		prerendered := prerender(install, chartProvider, task, log)
+		task.Configuration["a.b"] = "changed" //e.g. by a pre-action
+		_, ok := prerendered.get(context.Background(), task)
+		require.False(t, ok)
+	})
+
+	t.Run("Ignore failed pre-rendering", func(t *testing.T) {
+		chartProvider := &mocks.Provider{}
+		chartProvider.On("RenderManifest", mock.Anything).Return(nil, errors.New("clone failed"))
+
+		prerendered := prerender(install, chartProvider, newTask(), log)
+		_, ok := prerendered.get(context.Background(), newTask())
+		require.False(t, ok)
+	})
+
+	t.Run("Skip components without manifest", func(t *testing.T) {
+		task := newTask()
+		task.Component = model.CleanupComponent
+		prerendered := prerender(install, &mocks.Provider{}, task, log)
+		require.Nil(t, prerendered)
+		_, ok := prerendered.get(context.Background(), task)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/queue"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultQueuePollInterval  = 2 * time.Second
+	defaultQueueLeaseDuration = 1 * time.Minute
+)
+
+type QueueDispatcherConfig struct {
+	PollInterval  time.Duration //interval to check the queue for tasks if workers are free
+	LeaseDuration time.Duration //leases are renewed while a task is processed
+	MaxAttempts   int           //tasks leased more often are dropped (0 = unlimited)
+}
+
+// QueueDispatcher assigns the tasks of a queue to the workers of the worker pool. The queue can be shared by
+// multiple replicas of the same component reconciler: each replica leases only as many tasks as it has free
+// workers.
+type QueueDispatcher struct {
+	queue          queue.Queue
+	workerPool     *WorkerPool
+	reconcilerName string
+	owner          string
+	config         QueueDispatcherConfig
+	logger         *zap.SugaredLogger
+}
+
+func NewQueueDispatcher(q queue.Queue, workerPool *WorkerPool, reconcilerName string, config QueueDispatcherConfig,
+	logger *zap.SugaredLogger) (*QueueDispatcher, error) {
+	if config.PollInterval < 0 || config.LeaseDuration < 0 || config.MaxAttempts < 0 {
+		return nil, fmt.Errorf("queue poll interval, lease duration and max attempts cannot be < 0")
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = defaultQueuePollInterval
+	}
+	if config.LeaseDuration == 0 {
+		config.LeaseDuration = defaultQueueLeaseDuration
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = reconcilerName
+	}
+	return &QueueDispatcher{
+		queue:          q,
+		workerPool:     workerPool,
+		reconcilerName: reconcilerName,
+		owner:          fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8]),
+		config:         config,
+		logger:         logger,
+	}, nil
+}
+
+// Enqueue adds the task to the queue: it's processed as soon as a worker of any replica is free
+func (d *QueueDispatcher) Enqueue(task *reconciler.Task) error {
+	entity, err := d.queue.Enqueue(d.reconcilerName, task)
+	if err != nil {
+		return err
+	}
+	d.logger.Debugf("Queued %s as task '%s'", task, entity.ID)
+	return nil
+}
+
+// Tasks returns the queued tasks (including the tasks which are processed at the moment)
+func (d *QueueDispatcher) Tasks() ([]*model.QueuedTaskEntity, error) {
+	return d.queue.Tasks(d.reconcilerName)
+}
+
+// Run dispatches queued tasks until the context is closed
+func (d *QueueDispatcher) Run(ctx context.Context) {
+	d.logger.Infof("Dispatching tasks of queue '%s' (lease owner: %s)", d.reconcilerName, d.owner)
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.dispatch(ctx); err != nil {
+			d.logger.Warnf("Failed to dispatch queued tasks: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			d.logger.Info("Stopping dispatcher of queued tasks")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *QueueDispatcher) dispatch(ctx context.Context) error {
+	if ctx.Err() != nil || d.workerPool.IsClosed() {
+		return nil
+	}
+	free := d.workerPool.FreeWorkers()
+	if free <= 0 {
+		return nil
+	}
+	tasks, err := d.queue.Lease(d.reconcilerName, d.owner, d.config.LeaseDuration, free)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task := task
+		if d.config.MaxAttempts > 0 && task.Attempts > int64(d.config.MaxAttempts) {
+			d.logger.Errorf("Dropping queued %s (task '%s'): it was started %d times without being completed",
+				task.Task, task.ID, task.Attempts-1)
+			d.complete(task)
+			continue
+		}
+		if task.Task.Priority == reconciler.PriorityLow && d.workerPool.FreeWorkers() <= d.workerPool.ReservedWorkers() {
+			d.release(task)
+			continue
+		}
+		stopRenewal := d.keepLease(ctx, task)
+		done := func() {
+			stopRenewal()
+			if ctx.Err() != nil {
+				d.release(task) //interrupted by a shutdown: another replica continues the task
+				return
+			}
+			d.complete(task)
+		}
+		if err := d.workerPool.assignWorker(ctx, task.Task, done); err != nil {
+			d.logger.Warnf("Failed to assign queued task '%s' to a worker: %s", task.ID, err)
+			stopRenewal()
+			d.release(task)
+		}
+	}
+	return nil
+}
+
+// keepLease renews the lease of the task until the returned function is called
+func (d *QueueDispatcher) keepLease(ctx context.Context, task *queue.LeasedTask) context.CancelFunc {
+	renewalCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(d.config.LeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewalCtx.Done():
+				return
+			case <-ticker.C:
+				err := d.queue.RenewLease(task.ID, d.owner, d.config.LeaseDuration)
+				if queue.IsLeaseLostError(err) {
+					d.logger.Warnf("Lost lease of queued task '%s': task is possibly processed twice", task.ID)
+					return
+				}
+				if err != nil {
+					d.logger.Warnf("Failed to renew lease of queued task '%s': %s", task.ID, err)
+				}
+			}
+		}
+	}()
+	return cancel
+}
+
+func (d *QueueDispatcher) complete(task *queue.LeasedTask) {
+	if err := d.queue.Complete(task.ID, d.owner); err != nil {
+		d.logger.Warnf("Failed to remove queued task '%s': %s", task.ID, err)
+	}
+}
+
+func (d *QueueDispatcher) release(task *queue.LeasedTask) {
+	if err := d.queue.Release(task.ID, d.owner); err != nil {
+		d.logger.Warnf("Failed to release lease of queued task '%s': %s", task.ID, err)
+	}
+}
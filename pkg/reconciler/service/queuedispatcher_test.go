@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/queue"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestQueueDispatcher(t *testing.T) {
+	newTask := func(correlationID string) *reconciler.Task {
+		return &reconciler.Task{
+			Component:     "component",
+			Namespace:     "namespace",
+			Version:       "1.0.0",
+			Kubeconfig:    "kubeconfig",
+			CallbackURL:   "https://localhost/callback",
+			CorrelationID: correlationID,
+			Type:          model.OperationTypeReconcile,
+		}
+	}
+
+	newDispatcher := func(t *testing.T, ctx context.Context, q queue.Queue, config QueueDispatcherConfig,
+		processed chan<- string) *QueueDispatcher {
+		runnerFct := func(ctx context.Context, task *reconciler.Task, _ callback.Handler, _ *zap.SugaredLogger) func() error {
+			return func() error {
+				processed <- task.CorrelationID
+				return nil
+			}
+		}
+		workerPool, err := newWorkerPoolBuilder(runnerFct).WithPoolSize(5).Build(ctx)
+		require.NoError(t, err)
+		dispatcher, err := NewQueueDispatcher(q, workerPool, "unittest", config, logger.NewLogger(true))
+		require.NoError(t, err)
+		return dispatcher
+	}
+
+	awaitEmptyQueue := func(t *testing.T, dispatcher *QueueDispatcher) {
+		require.Eventually(t, func() bool {
+			tasks, err := dispatcher.Tasks()
+			require.NoError(t, err)
+			return len(tasks) == 0
+		}, 5*time.Second, 50*time.Millisecond)
+	}
+
+	t.Run("Queued tasks are processed and removed from the queue", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		processed := make(chan string, 10)
+		dispatcher := newDispatcher(t, ctx, queue.NewInMemoryQueue(), QueueDispatcherConfig{}, processed)
+		for _, correlationID := range []string{"1", "2", "3"} {
+			require.NoError(t, dispatcher.Enqueue(newTask(correlationID)))
+		}
+		require.NoError(t, dispatcher.dispatch(ctx))
+
+		var correlationIDs []string
+		var mu sync.Mutex
+		require.Eventually(t, func() bool {
+			select {
+			case correlationID := <-processed:
+				mu.Lock()
+				correlationIDs = append(correlationIDs, correlationID)
+				mu.Unlock()
+			default:
+			}
+			return len(correlationIDs) == 3
+		}, 5*time.Second, 10*time.Millisecond)
+		require.ElementsMatch(t, []string{"1", "2", "3"}, correlationIDs)
+		awaitEmptyQueue(t, dispatcher)
+	})
+
+	t.Run("Tasks exceeding the max attempts are dropped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		q := queue.NewInMemoryQueue()
+		_, err := q.Enqueue("unittest", newTask("1"))
+		require.NoError(t, err)
+		//simulate a replica which crashed while processing the task
+		_, err = q.Lease("unittest", "crashed replica", time.Millisecond, 1)
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		processed := make(chan string, 10)
+		dispatcher := newDispatcher(t, ctx, q, QueueDispatcherConfig{MaxAttempts: 1}, processed)
+		require.NoError(t, dispatcher.dispatch(ctx))
+		awaitEmptyQueue(t, dispatcher)
+		require.Empty(t, processed)
+	})
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+	"github.com/pkg/errors"
+)
+
+// ReadyThresholdKey is the configuration key which defines how many replicas of the Deployments and DaemonSets of a
+// component have to be ready (e.g. '90%', 'maxUnavailable' or '90%,maxUnavailable')
+const ReadyThresholdKey = "reconciler.readyThreshold"
+
+// readyThresholdOf returns the ready threshold configured for the component of the task (or the fallback if the
+// component doesn't define one)
+func readyThresholdOf(task *reconciler.Task, fallback *progress.ReadyThreshold) (*progress.ReadyThreshold, error) {
+	value, ok := task.Configuration[ReadyThresholdKey]
+	if !ok || fmt.Sprint(value) == "" {
+		return fallback, nil
+	}
+	threshold, err := progress.ParseReadyThreshold(fmt.Sprint(value))
+	if err != nil {
+		return nil, errors.Wrapf(err, "configuration '%s' of component '%s' is invalid", ReadyThresholdKey, task.Component)
+	}
+	return threshold, nil
+}
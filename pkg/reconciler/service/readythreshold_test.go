@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyThresholdOf(t *testing.T) {
+	fallback := &progress.ReadyThreshold{MaxUnavailable: true}
+
+	threshold, err := readyThresholdOf(&reconciler.Task{Configuration: map[string]interface{}{}}, fallback)
+	require.NoError(t, err)
+	require.Equal(t, fallback, threshold)
+
+	threshold, err = readyThresholdOf(&reconciler.Task{Configuration: map[string]interface{}{ReadyThresholdKey: 90}}, fallback)
+	require.NoError(t, err)
+	require.Equal(t, &progress.ReadyThreshold{Percentage: 90}, threshold)
+
+	_, err = readyThresholdOf(&reconciler.Task{
+		Component:     "istio",
+		Configuration: map[string]interface{}{ReadyThresholdKey: "most"},
+	}, fallback)
+	require.ErrorContains(t, err, "component 'istio'")
+}
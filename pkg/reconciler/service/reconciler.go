@@ -11,23 +11,37 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chaos"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/informer"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/sandbox"
 	"go.uber.org/zap"
 )
 
 const (
 	defaultInterval   = 30 * time.Second
 	defaultRetryDelay = 30 * time.Second
+	defaultMaxDelay   = 5 * time.Minute
 	defaultTimeout    = 10 * time.Minute
 	defaultWorkers    = 100
 	defaultWorkspace  = "."
 )
 
+// defaultStallWarnings escalates resources which are not ready after half of the progress timeout and shortly
+// before it's reached
+var defaultStallWarnings = []int{50, 80}
+
 var (
 	wsFactory chart.Factory //singleton
 	m         sync.Mutex
 )
 
+// informerCaches are shared by all component reconcilers: operations which reconcile the same cluster concurrently
+// read its resources from the same informers
+var informerCaches = informer.NewCaches()
+
 type ComponentReconciler struct {
 	dryRun                bool
 	workspace             string
@@ -41,31 +55,58 @@ type ComponentReconciler struct {
 	preDeleteAction  Action
 	deleteAction     Action
 	postDeleteAction Action
+	//blue/green upgrades:
+	smokeCheckAction Action
+	//verification after reconciliation:
+	smokeTests []SmokeTest
+	//fields owned by controllers in the cluster:
+	ignoredFields IgnoredFields
+
+	valuesMigrations valuesMigrations
 	//retry:
-	retryDelay time.Duration
+	retryDelay    time.Duration
+	retryMaxDelay time.Duration
+	retryBudget   time.Duration
 	//worker pool:
 	timeout              time.Duration
 	workers              int
+	stuckWorkerFactor    int
+	memoryBudget         *memoryBudget
+	renderCache          RenderCache
+	informerCache        bool
+	streamThreshold      int
+	kubeClientLimits     kubernetes.ClientLimits
+	kubeClientSizes      []kubernetes.SizeClassLimits //override the limits for clusters with many components
 	logger               *zap.SugaredLogger
 	debug                bool
 	mu                   sync.Mutex
 	reconcilerMetricsSet *metrics.ReconcilerMetricsSet
+	kubeClientFactory    KubeClientFactory
+	faultInjector        *chaos.Injector
+	sandboxConfig        *sandbox.Config
 }
 
+// KubeClientFactory creates the client used to access the cluster of a task
+type KubeClientFactory func(kubeconfig string, logger *zap.SugaredLogger, config *kubernetes.Config) (kubernetes.Client, error)
+
 type heartbeatSenderConfig struct {
 	interval time.Duration
 	timeout  time.Duration
 }
 
 type progressTrackerConfig struct {
-	interval time.Duration
-	timeout  time.Duration
+	interval       time.Duration
+	timeout        time.Duration
+	readyThreshold *progress.ReadyThreshold //default of components which don't define a threshold
+	stallWarnings  []int                    //percentages of the timeout after which pending resources are reported
+	trackAll       bool                     //await also resources which a deployment didn't change
 }
 
 func NewComponentReconciler(reconcilerName string) (*ComponentReconciler, error) {
 	recon := &ComponentReconciler{
-		workspace: defaultWorkspace,
-		logger:    logger.NewLogger(false),
+		workspace:         defaultWorkspace,
+		logger:            logger.NewScopedLogger(reconcilerName, false),
+		kubeClientFactory: kubernetes.NewKubernetesClient,
 	}
 
 	RegisterReconciler(reconcilerName, recon) //add reconciler to registry
@@ -150,12 +191,37 @@ func (r *ComponentReconciler) validate() error {
 	if r.progressTrackerConfig.timeout == 0 {
 		r.progressTrackerConfig.timeout = defaultTimeout
 	}
+	if err := r.progressTrackerConfig.readyThreshold.Validate(); err != nil {
+		return err
+	}
+	if err := r.kubeClientLimits.Validate(); err != nil {
+		return err
+	}
+	if r.progressTrackerConfig.stallWarnings == nil {
+		r.progressTrackerConfig.stallWarnings = defaultStallWarnings
+	}
 	if r.retryDelay < 0 {
 		return fmt.Errorf("retry-delay cannot be < 0 (got %.1f secs", r.retryDelay.Seconds())
 	}
 	if r.retryDelay == 0 {
 		r.retryDelay = defaultRetryDelay
 	}
+	if r.retryMaxDelay < 0 {
+		return fmt.Errorf("max retry-delay cannot be < 0 (got %.1f secs)", r.retryMaxDelay.Seconds())
+	}
+	if r.retryMaxDelay == 0 {
+		r.retryMaxDelay = defaultMaxDelay
+		if r.retryMaxDelay < r.retryDelay {
+			r.retryMaxDelay = r.retryDelay
+		}
+	}
+	if r.retryMaxDelay < r.retryDelay {
+		return fmt.Errorf("max retry-delay cannot be < retry-delay (got %.1f secs < %.1f secs)",
+			r.retryMaxDelay.Seconds(), r.retryDelay.Seconds())
+	}
+	if r.retryBudget < 0 {
+		return fmt.Errorf("retry budget cannot be < 0 (got %.1f secs)", r.retryBudget.Seconds())
+	}
 	if r.workers < 0 {
 		return fmt.Errorf("workers count cannot be < 0 (got %d)", r.workers)
 	}
@@ -168,7 +234,7 @@ func (r *ComponentReconciler) validate() error {
 	if r.timeout == 0 {
 		r.timeout = defaultTimeout
 	}
-	return nil
+	return r.valuesMigrations.validate()
 }
 
 func (r *ComponentReconciler) Debug() *ComponentReconciler {
@@ -182,6 +248,18 @@ func (r *ComponentReconciler) WithReconcilerMetricsSet(reconcilerMetricsSet *met
 	return r
 }
 
+// WithKubeClientFactory replaces the factory of the clients used to access the clusters (e.g. to inject fake clients in tests)
+func (r *ComponentReconciler) WithKubeClientFactory(factory KubeClientFactory) *ComponentReconciler {
+	r.kubeClientFactory = factory
+	return r
+}
+
+// WithFaultInjector enables the injection of faults (only for resilience tests!)
+func (r *ComponentReconciler) WithFaultInjector(injector *chaos.Injector) *ComponentReconciler {
+	r.faultInjector = injector
+	return r
+}
+
 func (r *ComponentReconciler) WithWorkspace(workspace string) *ComponentReconciler {
 	r.workspace = workspace
 	return r
@@ -192,12 +270,72 @@ func (r *ComponentReconciler) WithRetryDelay(retryDelay time.Duration) *Componen
 	return r
 }
 
+// WithRetryBackoff defines the upper limit of the exponentially growing delay between retries
+func (r *ComponentReconciler) WithRetryBackoff(maxDelay time.Duration) *ComponentReconciler {
+	r.retryMaxDelay = maxDelay
+	return r
+}
+
+// WithRetryBudget defines the overall time the retries of a task are allowed to take (0 = unlimited)
+func (r *ComponentReconciler) WithRetryBudget(budget time.Duration) *ComponentReconciler {
+	r.retryBudget = budget
+	return r
+}
+
 func (r *ComponentReconciler) WithWorkers(workers int, timeout time.Duration) *ComponentReconciler {
 	r.workers = workers
 	r.timeout = timeout
 	return r
 }
 
+// WithStuckWorkerFactor treats workers as stuck if they run longer than the worker timeout × factor (0 = disabled)
+func (r *ComponentReconciler) WithStuckWorkerFactor(factor int) *ComponentReconciler {
+	r.stuckWorkerFactor = factor
+	return r
+}
+
+// WithMemoryBudget delays new operations while the approximate memory of the running operations
+// exceeds the budget in bytes (0 = disabled)
+func (r *ComponentReconciler) WithMemoryBudget(budget int64) *ComponentReconciler {
+	r.memoryBudget = newMemoryBudget(budget, r.logger)
+	return r
+}
+
+// WithRenderCache shares the rendered manifests of released versions (e.g. between replicas)
+func (r *ComponentReconciler) WithRenderCache(cache RenderCache) *ComponentReconciler {
+	r.renderCache = cache
+	return r
+}
+
+// WithInformerCache lets the progress checks and the resource getters of the kube client read Deployments,
+// DaemonSets, StatefulSets, Pods and Jobs from an informer cache of the cluster instead of the API server
+func (r *ComponentReconciler) WithInformerCache(enabled bool) *ComponentReconciler {
+	r.informerCache = enabled
+	return r
+}
+
+// WithStreamedDeployment applies manifests larger than the threshold in bytes in batches of resources (0 = disabled).
+// The interceptors see only the resources of one batch: enable it only for components whose resources don't
+// depend on each other during the interception.
+func (r *ComponentReconciler) WithStreamedDeployment(threshold int) *ComponentReconciler {
+	r.streamThreshold = threshold
+	return r
+}
+
+// WithKubeClientLimits defines the rate limits of the requests sent to the clusters. Size classes override the QPS
+// and burst for clusters with at least the given amount of components.
+func (r *ComponentReconciler) WithKubeClientLimits(limits kubernetes.ClientLimits, sizeClasses ...kubernetes.SizeClassLimits) *ComponentReconciler {
+	r.kubeClientLimits = limits
+	r.kubeClientSizes = sizeClasses
+	return r
+}
+
+// WithSandbox configures the executor which actions use to run external processes (see ActionContext.Executor)
+func (r *ComponentReconciler) WithSandbox(config *sandbox.Config) *ComponentReconciler {
+	r.sandboxConfig = config
+	return r
+}
+
 func (r *ComponentReconciler) WithPreReconcileAction(preReconcileAction Action) *ComponentReconciler {
 	r.preReconcileAction = preReconcileAction
 	return r
@@ -228,6 +366,40 @@ func (r *ComponentReconciler) WithPostDeleteAction(postDeleteAction Action) *Com
 	return r
 }
 
+// WithSmokeCheckAction verifies a release installed by a blue/green upgrade before it replaces the previous release
+func (r *ComponentReconciler) WithSmokeCheckAction(smokeCheckAction Action) *ComponentReconciler {
+	r.smokeCheckAction = smokeCheckAction
+	return r
+}
+
+// WithSmokeTests verifies the component after its resources are ready: if a smoke test fails, the operation
+// finishes with status 'verificationFailed' instead of 'success'
+func (r *ComponentReconciler) WithSmokeTests(smokeTests ...SmokeTest) *ComponentReconciler {
+	r.smokeTests = append(r.smokeTests, smokeTests...)
+	return r
+}
+
+// WithIgnoredFields keeps the values of the given fields of a kind as they are in the cluster instead of
+// reverting them to the values of the manifest (e.g. 'spec.replicas' of deployments scaled by an HPA)
+func (r *ComponentReconciler) WithIgnoredFields(kind string, paths ...string) *ComponentReconciler {
+	if r.ignoredFields == nil {
+		r.ignoredFields = IgnoredFields{}
+	}
+	r.ignoredFields[kind] = append(r.ignoredFields[kind], paths...)
+	return r
+}
+
+// WithValuesMigration transforms the configuration of the component before rendering if the component is
+// upgraded from a version < toVersion to a version >= toVersion (e.g. to apply renamed or moved keys of the chart)
+func (r *ComponentReconciler) WithValuesMigration(fromVersion, toVersion string, migration ValuesMigration) *ComponentReconciler {
+	r.valuesMigrations = append(r.valuesMigrations, &valuesMigrationStep{
+		fromVersion: fromVersion,
+		toVersion:   toVersion,
+		migration:   migration,
+	})
+	return r
+}
+
 func (r *ComponentReconciler) WithHeartbeatSenderConfig(interval, timeout time.Duration) *ComponentReconciler {
 	r.heartbeatSenderConfig.interval = interval
 	r.heartbeatSenderConfig.timeout = timeout
@@ -240,6 +412,27 @@ func (r *ComponentReconciler) WithProgressTrackerConfig(interval, timeout time.D
 	return r
 }
 
+// WithProgressStallWarnings defines the percentages of the progress timeout after which resources which didn't reach
+// their target state are reported to the mothership (no percentages disable the warnings)
+func (r *ComponentReconciler) WithProgressStallWarnings(percentages ...int) *ComponentReconciler {
+	r.progressTrackerConfig.stallWarnings = append([]int{}, percentages...)
+	return r
+}
+
+// WithProgressTrackingOfUnchangedResources lets deployments await the readiness of all resources of the manifest
+// instead of only the ones which were created or updated
+func (r *ComponentReconciler) WithProgressTrackingOfUnchangedResources(trackAll bool) *ComponentReconciler {
+	r.progressTrackerConfig.trackAll = trackAll
+	return r
+}
+
+// WithReadyThreshold relaxes the readiness check of Deployments and DaemonSets for all components which don't
+// define their own threshold
+func (r *ComponentReconciler) WithReadyThreshold(threshold *progress.ReadyThreshold) *ComponentReconciler {
+	r.progressTrackerConfig.readyThreshold = threshold
+	return r
+}
+
 func (r *ComponentReconciler) StartLocal(ctx context.Context, model *reconciler.Task, logger *zap.SugaredLogger) error {
 	//ensure model is valid
 	if err := model.Validate(); err != nil {
@@ -263,7 +456,18 @@ func (r *ComponentReconciler) StartRemote(ctx context.Context, reconcilerName st
 	if err := r.validate(); err != nil {
 		return nil, nil, err
 	}
-	workerPool, err := newWorkerPoolBuilder(r.newRunnerFunc).WithPoolSize(r.workers).WithDebug(r.debug).Build(ctx)
+	if r.faultInjector == nil {
+		injector, err := chaos.NewInjectorFromEnv(r.logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.faultInjector = injector
+	}
+	workerPool, err := newWorkerPoolBuilder(r.newRunnerFunc).
+		WithPoolSize(r.workers).
+		WithDebug(r.debug).
+		WithStuckWorkerDetection(r.timeout*time.Duration(r.stuckWorkerFactor), r.stuckWorkerObserver()).
+		Build(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -274,12 +478,38 @@ func (r *ComponentReconciler) StartRemote(ctx context.Context, reconcilerName st
 	return workerPool, tracker, nil
 }
 
+func (r *ComponentReconciler) stuckWorkerObserver() stuckWorkerObserver {
+	if r.reconcilerMetricsSet == nil || r.reconcilerMetricsSet.StuckWorkersCollector == nil {
+		return nil
+	}
+	return r.reconcilerMetricsSet.StuckWorkersCollector
+}
+
 func (r *ComponentReconciler) newRunnerFunc(ctx context.Context, model *reconciler.Task, callback callback.Handler, logger *zap.SugaredLogger) func() error {
-	r.logger.Debugf("Creating new runner closure with execution timeout of %.1f secs", r.timeout.Seconds())
+	timeout := r.timeout
+	if model.Timeout > 0 && model.Timeout < timeout { //tasks can only shorten the worker timeout
+		timeout = model.Timeout
+	}
+	r.logger.Debugf("Creating new runner closure with execution timeout of %.1f secs", timeout.Seconds())
 	return func() error {
-		timeoutCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
-		return (&runner{r, NewInstall(logger), logger}).Run(timeoutCtx, model, callback, r.reconcilerMetricsSet)
+		runCtx, cbh := timeoutCtx, callback
+		if r.faultInjector != nil {
+			var kill context.CancelFunc
+			runCtx, cbh, kill = r.faultInjector.KillWorker(timeoutCtx, r.faultInjector.CallbackHandler(callback))
+			defer kill()
+		}
+		reservation, err := r.memoryBudget.acquire(runCtx, model)
+		if err != nil {
+			return err
+		}
+		defer reservation.release()
+		install := NewInstall(logger)
+		install.ignoredFields = r.ignoredFields
+		install.memory = reservation
+		install.renderCache = r.renderCache
+		return (&runner{r, install, logger}).Run(runCtx, model, cbh, r.reconcilerMetricsSet)
 	}
 }
 
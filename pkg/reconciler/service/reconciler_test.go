@@ -40,6 +40,9 @@ func TestReconciler(t *testing.T) {
 		//verify retry config
 		recon.WithRetryDelay(222 * time.Second)
 		require.Equal(t, 222*time.Second, recon.retryDelay)
+		recon.WithRetryBackoff(333 * time.Second).WithRetryBudget(444 * time.Second)
+		require.Equal(t, 333*time.Second, recon.retryMaxDelay)
+		require.Equal(t, 444*time.Second, recon.retryBudget)
 
 		//verify pre, post and install-action
 		preAct := &DummyAction{
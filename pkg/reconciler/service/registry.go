@@ -2,6 +2,8 @@ package service
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
 var reconcilers = make(map[string]*ComponentReconciler)
@@ -28,6 +30,28 @@ func RegisteredReconcilers() []string {
 	return reconNames
 }
 
+// RestrictReconcilers removes all component reconcilers from the registry which are not listed. It fails if a listed
+// reconciler isn't registered (e.g. because it was excluded by a build tag).
+func RestrictReconcilers(reconcilerNames []string) error {
+	enabled := make(map[string]bool, len(reconcilerNames))
+	for _, reconcilerName := range reconcilerNames {
+		reconcilerName = strings.TrimSpace(reconcilerName)
+		if _, ok := reconcilers[reconcilerName]; !ok {
+			registered := RegisteredReconcilers()
+			sort.Strings(registered)
+			return fmt.Errorf("component reconciler '%s' not found in reconciler registry (available: %s)",
+				reconcilerName, strings.Join(registered, ", "))
+		}
+		enabled[reconcilerName] = true
+	}
+	for reconcilerName := range reconcilers {
+		if !enabled[reconcilerName] {
+			delete(reconcilers, reconcilerName)
+		}
+	}
+	return nil
+}
+
 func EnableReconcilerDryRun() {
 	dryRun = true
 }
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestrictReconcilers(t *testing.T) {
+	registered := reconcilers
+	defer func() {
+		reconcilers = registered
+	}()
+
+	reconcilers = map[string]*ComponentReconciler{
+		"base":    {},
+		"rma":     {},
+		"warden":  {},
+		"cleaner": {},
+	}
+
+	err := RestrictReconcilers([]string{"rma", "istio"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'istio' not found")
+	require.Len(t, RegisteredReconcilers(), 4, "registry is unchanged if a reconciler is unknown")
+
+	require.NoError(t, RestrictReconcilers([]string{"base", " rma"}))
+	require.ElementsMatch(t, []string{"base", "rma"}, RegisteredReconcilers())
+
+	_, err = GetReconciler("warden")
+	require.Error(t, err)
+}
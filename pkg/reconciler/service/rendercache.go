@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/redis"
+	"go.uber.org/zap"
+)
+
+// RenderCache stores rendered manifests by the fingerprint of their render inputs. A cache is best effort: failures
+// are logged and the manifest is rendered again.
+type RenderCache interface {
+	Get(fingerprint string) (string, bool)
+	Set(fingerprint, manifest string)
+}
+
+// cacheable returns true if the manifest of the task can be shared: charts of released versions don't change,
+// charts of branches or custom repositories can change at any time
+func cacheable(task *reconciler.Task) bool {
+	if task.URL != "" {
+		return false
+	}
+	_, err := semver.NewVersion(task.Version)
+	return err == nil
+}
+
+// redisRenderCache shares rendered manifests between all replicas connected to the same Redis server. Manifests
+// include rendered secrets and are stored encrypted.
+type redisRenderCache struct {
+	client    *redis.Client
+	encryptor *db.Encryptor
+	ttl       time.Duration
+	logger    *zap.SugaredLogger
+}
+
+func NewRedisRenderCache(client *redis.Client, encryptor *db.Encryptor, logger *zap.SugaredLogger) RenderCache {
+	return &redisRenderCache{
+		client:    client,
+		encryptor: encryptor,
+		ttl:       client.Config().RenderCacheTTL,
+		logger:    logger,
+	}
+}
+
+func (c *redisRenderCache) Get(fingerprint string) (string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.client.Config().Timeout)
+	defer cancel()
+	encManifest, err := redis.String(c.client.Do(ctx, "GET", c.key(fingerprint)))
+	if err == redis.ErrNil {
+		return "", false
+	}
+	if err != nil {
+		c.logger.Warnf("Failed to read manifest from render cache: %s", err)
+		return "", false
+	}
+	manifest, err := c.encryptor.Decrypt(encManifest)
+	if err != nil {
+		c.logger.Warnf("Failed to decrypt manifest of render cache: %s", err)
+		return "", false
+	}
+	return manifest, true
+}
+
+func (c *redisRenderCache) Set(fingerprint, manifest string) {
+	encManifest, err := c.encryptor.Encrypt(manifest)
+	if err != nil {
+		c.logger.Warnf("Failed to encrypt manifest for render cache: %s", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.client.Config().Timeout)
+	defer cancel()
+	if _, err := c.client.Do(ctx, "SET", c.key(fingerprint), encManifest, "PX", c.ttl.Milliseconds()); err != nil {
+		c.logger.Warnf("Failed to write manifest to render cache: %s", err)
+	}
+}
+
+func (c *redisRenderCache) key(fingerprint string) string {
+	return c.client.Config().Key("manifest", fingerprint)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mapRenderCache map[string]string
+
+func (c mapRenderCache) Get(fingerprint string) (string, bool) {
+	manifest, ok := c[fingerprint]
+	return manifest, ok
+}
+
+func (c mapRenderCache) Set(fingerprint, manifest string) {
+	c[fingerprint] = manifest
+}
+
+func TestRenderCache(t *testing.T) {
+	newTask := func(version string) *reconciler.Task {
+		return &reconciler.Task{
+			Component:     "istio",
+			Version:       version,
+			Namespace:     "istio-system",
+			Configuration: map[string]interface{}{"a.b": "value"},
+		}
+	}
+
+	t.Run("Manifests of released versions are rendered once", func(t *testing.T) {
+		chartProvider := &mocks.Provider{}
+		chartProvider.On("RenderManifest", mock.Anything).
+			Return(&chart.Manifest{Manifest: "kind: Deployment"}, nil)
+		install := NewInstall(logger.NewLogger(true))
+		install.renderCache = mapRenderCache{}
+
+		for i := 0; i < 3; i++ {
+			manifest, err := install.renderManifest(chartProvider, newTask("2.0.0"))
+			require.NoError(t, err)
+			require.Equal(t, "kind: Deployment", manifest)
+		}
+		chartProvider.AssertNumberOfCalls(t, "RenderManifest", 1)
+
+		task := newTask("2.0.0")
+		task.Configuration["a.b"] = "changed"
+		_, err := install.renderManifest(chartProvider, task)
+		require.NoError(t, err)
+		chartProvider.AssertNumberOfCalls(t, "RenderManifest", 2)
+	})
+
+	t.Run("Manifests of branches and custom repositories aren't cached", func(t *testing.T) {
+		require.True(t, cacheable(newTask("2.0.0")))
+		require.False(t, cacheable(newTask("main")))
+		task := newTask("2.0.0")
+		task.URL = "https://github.com/kyma-project/kyma.git"
+		require.False(t, cacheable(task))
+	})
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// sourcePattern matches the comment Helm adds to each rendered template, e.g.
+// '# Source: istio/charts/pilot/templates/deployment.yaml'
+var sourcePattern = regexp.MustCompile(`(?m)^#\s*Source:\s*(\S+)`)
+
+type manifestDocument struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+// filterManifest removes the resources skipped by the filters from the manifest and returns them.
+// Documents which don't contain a resource (e.g. just comments) are kept.
+func filterManifest(manifest string, filters *reconciler.ResourceFilters) (string, []*kubernetes.Resource, error) {
+	if filters == nil || (len(filters.Include) == 0 && len(filters.Exclude) == 0) {
+		return manifest, nil, nil
+	}
+
+	var result bytes.Buffer
+	var skipped []*kubernetes.Resource
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(manifest)))
+	for {
+		data, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to read manifest")
+		}
+
+		doc := &manifestDocument{}
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return "", nil, errors.Wrap(err, "failed to parse resource of manifest")
+		}
+		if doc.Kind != "" && filters.Skip(doc.Kind, doc.Metadata.Name, subchartOf(data)) {
+			skipped = append(skipped, &kubernetes.Resource{
+				Kind:      doc.Kind,
+				Name:      doc.Metadata.Name,
+				Namespace: doc.Metadata.Namespace,
+			})
+			continue
+		}
+		result.WriteString("---\n")
+		result.Write(bytes.TrimSpace(data))
+		result.WriteString("\n")
+	}
+	return result.String(), skipped, nil
+}
+
+// subchartOf returns the innermost sub-chart the document was rendered from (empty for the main chart)
+func subchartOf(doc []byte) string {
+	match := sourcePattern.FindSubmatch(doc)
+	if match == nil {
+		return ""
+	}
+	parts := strings.Split(string(match[1]), "/")
+	for i := len(parts) - 2; i > 0; i-- {
+		if parts[i-1] == "charts" {
+			return parts[i]
+		}
+	}
+	return ""
+}
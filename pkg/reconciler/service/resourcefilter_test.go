@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+)
+
+const filterTestManifest = `---
+# Source: istio/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istiod
+  namespace: istio-system
+---
+# Source: istio/charts/psp/templates/psp.yaml
+apiVersion: policy/v1beta1
+kind: PodSecurityPolicy
+metadata:
+  name: istio-psp
+---
+# Source: istio/charts/ingress/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+`
+
+func TestFilterManifest(t *testing.T) {
+	t.Run("Without filters", func(t *testing.T) {
+		manifest, skipped, err := filterManifest(filterTestManifest, nil)
+		require.NoError(t, err)
+		require.Equal(t, filterTestManifest, manifest)
+		require.Empty(t, skipped)
+	})
+
+	t.Run("Exclude by kind", func(t *testing.T) {
+		manifest, skipped, err := filterManifest(filterTestManifest, &reconciler.ResourceFilters{
+			Exclude: []reconciler.ResourceFilter{{Kind: "podsecuritypolicy"}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []*kubernetes.Resource{{Kind: "PodSecurityPolicy", Name: "istio-psp"}}, skipped)
+		require.NotContains(t, manifest, "istio-psp")
+		require.Contains(t, manifest, "istiod")
+		require.Contains(t, manifest, "istio-ingressgateway")
+
+		unstructs, err := kubernetes.ToUnstructured([]byte(manifest), true)
+		require.NoError(t, err)
+		require.Len(t, unstructs, 2)
+	})
+
+	t.Run("Include by name pattern and exclude by subchart", func(t *testing.T) {
+		manifest, skipped, err := filterManifest(filterTestManifest, &reconciler.ResourceFilters{
+			Include: []reconciler.ResourceFilter{{Name: "istio-*"}},
+			Exclude: []reconciler.ResourceFilter{{Subchart: "ingress"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, skipped, 2)
+		require.Contains(t, manifest, "istio-psp")
+		require.NotContains(t, manifest, "istiod")
+		require.NotContains(t, manifest, "istio-ingressgateway")
+	})
+}
+
+func TestSubchartOf(t *testing.T) {
+	require.Empty(t, subchartOf([]byte("# Source: istio/templates/a.yaml\nkind: Service")))
+	require.Equal(t, "pilot", subchartOf([]byte("# Source: istio/charts/pilot/templates/a.yaml\nkind: Service")))
+	require.Equal(t, "pilot", subchartOf([]byte("# Source: kyma/charts/istio/charts/pilot/templates/a.yaml")))
+	require.Empty(t, subchartOf([]byte("kind: Service")))
+}
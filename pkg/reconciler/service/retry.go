@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/avast/retry-go"
+	"go.uber.org/zap"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// transientRetryDelay is the initial delay used for errors which are expected to disappear within seconds
+// (e.g. API server was temporarily unavailable)
+const transientRetryDelay = 1 * time.Second
+
+type errorClass string
+
+const (
+	// errorClassPermanent errors will not disappear by retrying (e.g. invalid resources, missing permissions)
+	errorClassPermanent errorClass = "permanent"
+	// errorClassTransient errors are caused by temporary glitches and are retried quickly
+	errorClassTransient errorClass = "transient"
+	// errorClassUnknown errors are retried with the regular backoff
+	errorClassUnknown errorClass = "unknown"
+)
+
+// permanentErrorMessages are indicators for non-recoverable errors which can't be detected by their type
+var permanentErrorMessages = []string{
+	"no such host",
+	"x509: certificate is valid",
+}
+
+// transientErrorMessages are indicators for temporary errors which can't be detected by their type
+var transientErrorMessages = []string{
+	"connection refused",
+	"connection reset by peer",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"unexpected EOF",
+	"etcdserver: request timed out",
+	"the object has been modified",
+}
+
+func classifyError(err error) errorClass {
+	if err == nil {
+		return errorClassUnknown
+	}
+
+	//unauthorized errors are not permanent: they are caused by expired or rotated credentials
+	//which are refreshed in the meantime, so they are retried with the regular backoff
+	switch {
+	case k8serr.IsInvalid(err),
+		k8serr.IsBadRequest(err),
+		k8serr.IsForbidden(err),
+		k8serr.IsMethodNotSupported(err),
+		k8serr.IsNotAcceptable(err),
+		k8serr.IsUnsupportedMediaType(err):
+		return errorClassPermanent
+	case k8serr.IsServerTimeout(err),
+		k8serr.IsTimeout(err),
+		k8serr.IsTooManyRequests(err),
+		k8serr.IsServiceUnavailable(err),
+		k8serr.IsInternalError(err),
+		k8serr.IsConflict(err),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET):
+		return errorClassTransient
+	}
+	var ownershipErr *OwnershipConflictError
+	if errors.As(err, &ownershipErr) {
+		return errorClassPermanent
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return errorClassTransient
+	}
+
+	//fallback for errors which lost their type because they were converted to strings
+	msg := err.Error()
+	for _, permanentMsg := range permanentErrorMessages {
+		if strings.Contains(msg, permanentMsg) {
+			return errorClassPermanent
+		}
+	}
+	for _, transientMsg := range transientErrorMessages {
+		if strings.Contains(msg, transientMsg) {
+			return errorClassTransient
+		}
+	}
+	return errorClassUnknown
+}
+
+// retryPolicy retries failed reconciliations with an exponential backoff and jitter.
+// Retries stop when the error is permanent, the max. amount of attempts is reached or the next attempt
+// would start after the retry budget is exhausted.
+type retryPolicy struct {
+	delay    time.Duration //initial delay, doubled for each retry
+	maxDelay time.Duration
+	budget   time.Duration //overall time retries are allowed to take (0 = unlimited)
+	logger   *zap.SugaredLogger
+}
+
+// backoff returns the delay before the next attempt: the delay grows exponentially and
+// the second half of it is randomized to avoid that failed tasks are retried in lockstep
+func (p *retryPolicy) backoff(attempt uint, err error) time.Duration {
+	maxDelay := p.maxDelay
+	if maxDelay < p.delay {
+		maxDelay = p.delay
+	}
+	delay := p.delay
+	if classifyError(err) == errorClassTransient && delay > transientRetryDelay {
+		delay = transientRetryDelay
+	}
+	for i := uint(0); i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)) //nolint:gosec //jitter doesn't require crypto rand
+}
+
+// retryable checks whether the error is worth another attempt which would start after the given delay
+func (p *retryPolicy) retryable(err error, started time.Time, delay time.Duration) bool {
+	if class := classifyError(err); class == errorClassPermanent {
+		p.logger.Warnf("Stop retrying because error is %s: %s", class, err)
+		return false
+	}
+	if p.budget > 0 && time.Since(started)+delay > p.budget {
+		p.logger.Warnf("Stop retrying because next attempt in %.1f secs would exceed the retry budget of %.0f secs: %s",
+			delay.Seconds(), p.budget.Seconds(), err)
+		return false
+	}
+	return true
+}
+
+func (p *retryPolicy) options(ctx context.Context, attempts int) []retry.Option {
+	started := time.Now()
+	//retry-go evaluates RetryIf right before DelayType: the delay is calculated once in RetryIf
+	//to verify it against the retry budget and is afterwards returned by DelayType
+	var attempt uint
+	var delay time.Duration
+	return []retry.Option{
+		retry.Attempts(uint(attempts)),
+		retry.DelayType(func(n uint, err error, _ *retry.Config) time.Duration {
+			p.logger.Debugf("Retrying attempt %d in %.1f secs (error class: %s)",
+				n+1, delay.Seconds(), classifyError(err))
+			return delay
+		}),
+		retry.LastErrorOnly(false),
+		retry.RetryIf(func(err error) bool {
+			delay = p.backoff(attempt, err)
+			attempt++
+			return p.retryable(err, started, delay)
+		}),
+		retry.Context(ctx),
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	gr := schema.GroupResource{Resource: "deployments"}
+	tests := []struct {
+		err      error
+		expected errorClass
+	}{
+		{k8serr.NewForbidden(gr, "test", errors.New("forbidden")), errorClassPermanent},
+		{k8serr.NewUnauthorized("token expired"), errorClassUnknown},
+		{k8serr.NewInvalid(schema.GroupKind{Kind: "Deployment"}, "test", nil), errorClassPermanent},
+		{fmt.Errorf("dial tcp: lookup api.cluster: no such host"), errorClassPermanent},
+		{fmt.Errorf("failed to deploy: %w", &OwnershipConflictError{Component: "test"}), errorClassPermanent},
+		{k8serr.NewServiceUnavailable("unavailable"), errorClassTransient},
+		{k8serr.NewTooManyRequests("slow down", 1), errorClassTransient},
+		{fmt.Errorf("failed to apply: %w", k8serr.NewConflict(gr, "test", errors.New("modified"))), errorClassTransient},
+		{fmt.Errorf("dial tcp 10.0.0.1:443: connect: connection refused"), errorClassTransient},
+		{fmt.Errorf("something went wrong"), errorClassUnknown},
+	}
+	for _, testCase := range tests {
+		require.Equal(t, testCase.expected, classifyError(testCase.err), testCase.err.Error())
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("Backoff grows exponentially until max delay", func(t *testing.T) {
+		policy := &retryPolicy{delay: 10 * time.Second, maxDelay: 60 * time.Second, logger: logger.NewLogger(true)}
+		err := errors.New("unknown error")
+		for attempt, expected := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, 60 * time.Second, 60 * time.Second} {
+			delay := policy.backoff(uint(attempt), err)
+			require.GreaterOrEqual(t, delay, expected/2)
+			require.LessOrEqual(t, delay, expected)
+		}
+	})
+
+	t.Run("Transient errors are retried faster", func(t *testing.T) {
+		policy := &retryPolicy{delay: 30 * time.Second, maxDelay: 60 * time.Second, logger: logger.NewLogger(true)}
+		require.LessOrEqual(t, policy.backoff(0, k8serr.NewServiceUnavailable("unavailable")), transientRetryDelay)
+	})
+
+	t.Run("Permanent errors are not retried", func(t *testing.T) {
+		policy := &retryPolicy{delay: 10 * time.Millisecond, maxDelay: 10 * time.Millisecond, logger: logger.NewLogger(true)}
+		var attempts int
+		err := retry.Do(func() error {
+			attempts++
+			return k8serr.NewForbidden(schema.GroupResource{Resource: "pods"}, "test", errors.New("forbidden"))
+		}, policy.options(context.Background(), 5)...)
+		require.Error(t, err)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("Retries stop when budget is exhausted", func(t *testing.T) {
+		policy := &retryPolicy{
+			delay:    20 * time.Millisecond,
+			maxDelay: 20 * time.Millisecond,
+			budget:   50 * time.Millisecond,
+			logger:   logger.NewLogger(true),
+		}
+		var attempts int
+		err := retry.Do(func() error {
+			attempts++
+			return errors.New("unknown error")
+		}, policy.options(context.Background(), 100)...)
+		require.Error(t, err)
+		require.Less(t, attempts, 10)
+	})
+
+	t.Run("Retries stop if next attempt would exceed the budget", func(t *testing.T) {
+		policy := &retryPolicy{
+			delay:    100 * time.Millisecond,
+			maxDelay: 100 * time.Millisecond,
+			budget:   50 * time.Millisecond,
+			logger:   logger.NewLogger(true),
+		}
+		var attempts int
+		started := time.Now()
+		err := retry.Do(func() error {
+			attempts++
+			return errors.New("unknown error")
+		}, policy.options(context.Background(), 5)...)
+		require.Error(t, err)
+		require.Equal(t, 1, attempts)
+		require.Less(t, time.Since(started), 50*time.Millisecond)
+	})
+}
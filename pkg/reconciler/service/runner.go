@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
@@ -11,6 +12,7 @@ import (
 	"golang.org/x/text/language"
 
 	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/informer"
 
 	"github.com/google/uuid"
 
@@ -20,7 +22,9 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/heartbeat"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/sandbox"
 	"github.com/pkg/errors"
 )
 
@@ -31,7 +35,7 @@ type runner struct {
 }
 
 func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callback.Handler, reconcilerMetricsSet *metrics.ReconcilerMetricsSet) error {
-	if r.dryRun {
+	if r.dryRun || task.DryRun {
 		var err error
 		chartProvider, err := r.newChartProvider(nil)
 		if err != nil {
@@ -66,30 +70,112 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 		return err
 	}
 
+	var bundle *debugBundle
+	if task.ComponentConfiguration.Debug {
+		bundle = r.enableDebugBundle(task)
+	}
+
+	//track when the task reaches its processing phases
+	phases := newPhaseRecorder()
+	r.install.phases = phases
+
+	//retrieve the workspace and render the manifest while the kube client is set up
+	if _, chartProvider, err := r.observedChartProvider(); err == nil {
+		r.install.prerendered = prerender(r.install, chartProvider, task, r.logger)
+	}
+
+	//remember applied resources to resume retries with the first resource which wasn't applied yet
+	checkpoint := k8s.NewApplyCheckpoint()
+	//track what the reconciliation did with each resource
+	outcomes := k8s.NewOutcomeRecorder()
+	//report resources which don't get ready to the mothership before the progress timeout is reached
+	stalls := newStallRecorder()
+	var attempt int32
+	var smokeTestResults []reconciler.SmokeTestResult
+
 	heartbeatSender, err := heartbeat.NewHeartbeatSender(ctx, callback, r.logger, heartbeat.Config{
 		Interval: r.heartbeatSenderConfig.interval,
 		Timeout:  r.heartbeatSenderConfig.timeout,
+		Phases:   phases.Phases,
+		Attempt: func() int {
+			return int(atomic.LoadInt32(&attempt))
+		},
+		Resources: func() reconciler.ResourceSummary {
+			return reconciler.ResourceSummary{Applied: checkpoint.Applied(), Failed: checkpoint.Failed()}
+		},
+		Retryable: func(err error) bool {
+			return classifyError(err) != errorClassPermanent
+		},
+		Version: task.Version,
+		SmokeTests: func() []reconciler.SmokeTestResult {
+			return smokeTestResults
+		},
+		ResourceResults: func() []reconciler.ResourceResult {
+			return resourceResults(outcomes)
+		},
+		Warnings: stalls.Warnings,
 	})
 	if err != nil {
 		return err
 	}
-	kubeClient, err := k8s.NewKubernetesClient(task.Kubeconfig, r.logger, &k8s.Config{
+	readyThreshold, err := readyThresholdOf(task, r.progressTrackerConfig.readyThreshold)
+	if err != nil {
+		return err
+	}
+	informerCache, releaseInformerCache := r.acquireInformerCache(task)
+	defer releaseInformerCache()
+	kubeClient, err := r.kubeClientFactory(task.Kubeconfig, r.logger, &k8s.Config{
 		ProgressInterval: r.progressTrackerConfig.interval,
 		ProgressTimeout:  r.progressTrackerConfig.timeout,
+		ProgressTimeline: bundle.progressTimeline(),
+		ReadyThreshold:   readyThreshold,
+		StallWarnings:    r.progressTrackerConfig.stallWarnings,
+		OnStall:          stalls.Record,
+		TrackChangedOnly: !r.progressTrackerConfig.trackAll,
+		InformerCache:    informerCache,
+		ClientLimits:     k8s.LimitsForClusterSize(r.kubeClientLimits, r.kubeClientSizes, len(task.Components)),
+		ApplyCheckpoint:  checkpoint,
+		Outcomes:         outcomes,
+		StreamThreshold:  r.streamThreshold,
+		OnApplied: func() {
+			phases.record(reconciler.OperationPhasePhaseApplied)
+		},
+		OnReady: func() {
+			phases.record(reconciler.OperationPhasePhaseReady)
+			stalls.Reset()
+		},
+		OnApplyProgress: func(progress k8s.ApplyProgress) {
+			r.logger.Debugf("Runner: streamed deployment of component '%s' applied %d resources "+
+				"(%d of %d bytes of the manifest processed)",
+				task.Component, progress.Resources, progress.Bytes, progress.TotalBytes)
+		},
 	})
 	if err != nil {
 		return err
 	}
+	if r.faultInjector != nil {
+		kubeClient = r.faultInjector.KubeClient(kubeClient)
+	}
 	var retryID string
+	var valuesMigrated bool
 
 	retryable := func() error {
 		retryID = uuid.NewString()
+		atomic.AddInt32(&attempt, 1)
+		checkpoint.NextAttempt()
 		createOrUpdateStatusCm(ctx, task, reconciler.StatusRunning, kubeClient, r.logger)
 		if err := heartbeatSender.Running(retryID); err != nil {
 			r.logger.Warnf("Runner: failed to start status updater: %s", err)
 			return err
 		}
-		err := r.reconcile(ctx, kubeClient, task)
+		var err error
+		if !valuesMigrated {
+			err = r.migrateValues(ctx, kubeClient, task)
+			valuesMigrated = err == nil
+		}
+		if err == nil {
+			err = r.reconcile(ctx, kubeClient, task, outcomes)
+		}
 		if err != nil {
 			r.logger.Warnf("Runner: failing reconciliation of '%s' in version '%s' with profile '%s': %s",
 				task.Component, task.Version, task.Profile, err)
@@ -103,21 +189,36 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 
 	startTime := time.Now()
 	//retry the reconciliation in case of an error
-	err = retry.Do(retryable,
-		retry.Attempts(uint(task.ComponentConfiguration.MaxRetries)),
-		retry.Delay(r.retryDelay),
-		retry.LastErrorOnly(false),
-		retry.RetryIf(func(err error) bool {
-			if isIgnorableError(err.Error()) {
-				r.logger.Warnf("stop retry with ignorable error: %s", err)
-				return false
-			}
-			return true
-		}),
-		retry.Context(ctx))
+	policy := &retryPolicy{
+		delay:    r.retryDelay,
+		maxDelay: r.retryMaxDelay,
+		budget:   r.retryBudget,
+		logger:   r.logger,
+	}
+	err = retry.Do(retryable, policy.options(ctx, task.ComponentConfiguration.MaxRetries)...)
 
 	processingDuration := time.Since(startTime)
+	if bundle != nil { //upload bundle before the final status is reported to ensure it's available when operation is finished
+		bundle.finish(err)
+		if uploadErr := bundle.upload(r.logger); uploadErr != nil {
+			r.logger.Warnf("Runner: failed to upload debug bundle of component '%s': %s", task.Component, uploadErr)
+		}
+	}
+	var verificationErr error
 	if err == nil {
+		smokeTestResults, verificationErr = r.verify(ctx, kubeClient, task)
+	}
+	if err == nil && verificationErr != nil {
+		r.logger.Errorf("Runner: reconciliation of component '%s' for version '%s' finished but verification failed: %s",
+			task.Component, task.Version, verificationErr)
+		r.exposeProcessingDuration(reconcilerMetricsSet, task, model.OperationStateVerificationFailed, processingDuration)
+		r.captureFailure(task, verificationErr)
+		createOrUpdateStatusCm(ctx, task, reconciler.StatusVerificationFailed, kubeClient, r.logger)
+		if heartbeatErr := heartbeatSender.VerificationFailed(verificationErr, retryID, processingDuration); heartbeatErr != nil {
+			return errors.Wrap(verificationErr, heartbeatErr.Error())
+		}
+		return verificationErr
+	} else if err == nil {
 		r.logger.Debugf("Runner: reconciliation of component '%s' for version '%s' finished successfully",
 			task.Component, task.Version)
 		r.exposeProcessingDuration(reconcilerMetricsSet, task, model.OperationStateDone, processingDuration)
@@ -134,6 +235,7 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 		r.exposeProcessingDuration(reconcilerMetricsSet, task, model.OperationStateFailed, processingDuration)
 		r.logger.Errorf("Runner: retryable reconciliation of component '%s' for version '%s' failed consistently: giving up",
 			task.Component, task.Version)
+		r.captureFailure(task, err)
 		createOrUpdateStatusCm(ctx, task, reconciler.StatusError, kubeClient, r.logger)
 		if heartbeatErr := heartbeatSender.Error(err, retryID, processingDuration); heartbeatErr != nil {
 			return errors.Wrap(err, heartbeatErr.Error())
@@ -143,13 +245,109 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 	return err
 }
 
-// This function let reconciler can fail-fast because of certain ignorable errors
-func isIgnorableError(err string) bool {
-	if strings.Contains(err, "no such host") ||
-		strings.Contains(err, "x509: certificate is valid") {
-		return true
+// migrateValues applies the values migrations of the component to the configuration of the task if the
+// component is upgraded from its reconciled version
+func (r *runner) migrateValues(ctx context.Context, kubeClient k8s.Client, task *reconciler.Task) error {
+	if len(r.valuesMigrations) == 0 || task.Type == model.OperationTypeDelete {
+		return nil
+	}
+	installed, err := reconciledVersion(ctx, task, kubeClient)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to retrieve reconciled version of component '%s'", task.Component))
+	}
+	//migrate a copy to keep the configuration untouched if a migration fails
+	values := make(map[string]interface{}, len(task.Configuration))
+	for key, value := range task.Configuration {
+		values[key] = value
+	}
+	if err := r.valuesMigrations.apply(installed, task.Version, values, r.logger); err != nil {
+		return err
+	}
+	task.Configuration = values
+	return nil
+}
+
+// verify runs the smoke tests of the component after its resources are ready (not applied for deletions)
+func (r *runner) verify(ctx context.Context, kubeClient k8s.Client, task *reconciler.Task) ([]reconciler.SmokeTestResult, error) {
+	if len(r.smokeTests) == 0 || task.Type == model.OperationTypeDelete {
+		return nil, nil
+	}
+	executor, err := r.newExecutor()
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debugf("Runner: running %d smoke tests of component '%s'", len(r.smokeTests), task.Component)
+	return runSmokeTests(r.smokeTests, &ActionContext{
+		KubeClient: kubeClient,
+		Context:    ctx,
+		Logger:     r.logger,
+		Task:       task,
+		Executor:   executor,
+	})
+}
+
+// resourceResults converts the recorded resource outcomes into the callback model
+func resourceResults(outcomes *k8s.OutcomeRecorder) []reconciler.ResourceResult {
+	var result []reconciler.ResourceResult
+	for _, outcome := range outcomes.Results() {
+		resourceResult := reconciler.ResourceResult{
+			ApiVersion: outcome.APIVersion,
+			Kind:       outcome.Kind,
+			Namespace:  outcome.Namespace,
+			Name:       outcome.Name,
+			Outcome:    reconciler.ResourceResultOutcome(outcome.Outcome),
+		}
+		if outcome.Error != "" {
+			resourceErr := outcome.Error
+			resourceResult.Error = &resourceErr
+		}
+		result = append(result, resourceResult)
+	}
+	return result
+}
+
+// enableDebugBundle starts capturing details about the processing of the task (logs, manifests etc.)
+func (r *runner) enableDebugBundle(task *reconciler.Task) *debugBundle {
+	bundle := newDebugBundle(task)
+	r.logger = bundle.wrapLogger(r.logger)
+	r.install.logger = r.logger
+	r.install.debugBundle = bundle
+	r.logger.Infof("Runner: debug bundle will be captured for component '%s' (correlation ID: %s)",
+		task.Component, task.CorrelationID)
+	return bundle
+}
+
+// captureFailure uploads the redacted payload and the resolved values of a failed task to the mothership before
+// the final status is reported. Embedded reconcilers have no callback URL: their caller owns the payload already.
+func (r *runner) captureFailure(task *reconciler.Task, failure error) {
+	if task.CallbackURL == "" {
+		return
+	}
+	values, valuesErr := r.resolveValues(task)
+	capture, err := newFailureCapture(task, failure, values, valuesErr)
+	if err == nil {
+		err = uploadFailureCapture(capture, task.CallbackURL, r.logger)
+	}
+	if err != nil {
+		r.logger.Warnf("Runner: failed to capture failure of component '%s': %s", task.Component, err)
+	}
+}
+
+// resolveValues returns the values of the component chart merged with the profile and the configuration of the task
+func (r *runner) resolveValues(task *reconciler.Task) (map[string]interface{}, error) {
+	if task.Component == model.CRDComponent || task.Component == model.CleanupComponent {
+		return nil, nil //not rendered from a component chart
 	}
-	return false
+	chartProvider, err := r.newChartProvider(task.Repository)
+	if err != nil {
+		return nil, err
+	}
+	return chartProvider.Configuration(chart.NewComponentBuilder(task.Version, task.Component).
+		WithProfile(task.Profile).
+		WithNamespace(task.Namespace).
+		WithConfiguration(task.Configuration).
+		WithURL(task.URL).
+		Build())
 }
 
 func (r *runner) exposeProcessingDuration(reconcilerMetricsSet *metrics.ReconcilerMetricsSet, task *reconciler.Task, state model.OperationState, processingDuration time.Duration) {
@@ -162,26 +360,69 @@ func (r *runner) exposeProcessingDuration(reconcilerMetricsSet *metrics.Reconcil
 		return
 	}
 	reconcilerMetricsSet.ComponentProcessingDurationCollector.ExposeProcessingDuration(task.Component, state, processingDuration)
+	if reconcilerMetricsSet.ComponentOperationsCollector != nil {
+		reconcilerMetricsSet.ComponentOperationsCollector.ExposeOperation(task.Component, task.Type, state, processingDuration, task.TraceID)
+	}
 }
 
-func (r *runner) reconcile(ctx context.Context, kubeClient k8s.Client, task *reconciler.Task) error {
-	chartProvider, err := r.newChartProvider(nil)
+// newExecutor returns the executor of sandboxed processes passed to the actions (its logs are part of the
+// debug bundle of the task)
+func (r *runner) newExecutor() (*sandbox.Executor, error) {
+	return sandbox.NewExecutor(r.sandboxConfig, r.logger)
+}
+
+// observedChartProvider returns a chart provider whose workspace factory records when the workspace is ready
+func (r *runner) observedChartProvider() (*workspaceObserver, *chart.DefaultProvider, error) {
+	wsFactory, err := r.workspaceFactory()
+	if err != nil {
+		return nil, nil, err
+	}
+	observedWsFactory := &workspaceObserver{
+		Factory: *wsFactory,
+		phases:  r.install.phases,
+	}
+	chartProvider, err := chart.NewDefaultProvider(observedWsFactory, r.logger)
 	if err != nil {
-		return errors.Wrap(err, "Failed to create chart provider instance")
+		return nil, nil, errors.Wrap(err, "Failed to create chart provider instance")
 	}
+	return observedWsFactory, chartProvider, nil
+}
 
-	wsFactory, err := r.workspaceFactory()
+// acquireInformerCache returns the informer cache of the task's cluster (nil if caching is disabled or the cache
+// couldn't be created) and the function which releases it
+func (r *runner) acquireInformerCache(task *reconciler.Task) (*informer.Cache, func()) {
+	if !r.informerCache {
+		return nil, func() {}
+	}
+	cache, release, err := informerCaches.Acquire(task.Kubeconfig)
+	if err != nil {
+		r.logger.Warnf("Runner: failed to create informer cache for cluster of component '%s' "+
+			"(reading resources from the API server): %s", task.Component, err)
+		return nil, func() {}
+	}
+	return cache, release
+}
+
+func (r *runner) reconcile(ctx context.Context, kubeClient k8s.Client, task *reconciler.Task, outcomes *k8s.OutcomeRecorder) error {
+	observedWsFactory, chartProvider, err := r.observedChartProvider()
+	if err != nil {
+		return err
+	}
+
+	executor, err := r.newExecutor()
 	if err != nil {
 		return err
 	}
 
 	actionHelper := &ActionContext{
 		KubeClient:       kubeClient,
-		WorkspaceFactory: *wsFactory,
+		WorkspaceFactory: observedWsFactory,
 		Context:          ctx,
 		Logger:           r.logger,
 		ChartProvider:    chartProvider,
 		Task:             task,
+		Outcomes:         outcomes,
+		Executor:         executor,
 	}
 
 	// Identify the right action set to use (reconcile/delete)
@@ -199,7 +440,11 @@ func (r *runner) reconcile(ctx context.Context, kubeClient k8s.Client, task *rec
 	}
 
 	if act == nil {
-		if err := r.install.Invoke(ctx, chartProvider, task, kubeClient); err != nil {
+		var op Operation = r.install
+		if upgradeStrategyOf(task) == UpgradeStrategyBlueGreen {
+			op = newBlueGreenInstall(r.install, r.smokeCheckAction, actionHelper)
+		}
+		if err := op.Invoke(ctx, chartProvider, task, kubeClient); err != nil {
 			r.logger.Debugf("Runner: Default-%s action of '%s' with version '%s' failed: %s",
 				task.Type, task.Component, task.Version, err)
 			return err
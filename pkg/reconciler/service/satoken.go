@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	authv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	//ServiceAccountTokenRotateKey is the configuration key which enforces the rotation of the service account tokens
+	ServiceAccountTokenRotateKey = "reconciler.serviceAccountToken.rotate"
+
+	//TokenExpirationAnnotation stores when the token of a secret expires (RFC3339)
+	TokenExpirationAnnotation = "reconciler.kyma-project.io/token-expiration"
+
+	//TokenSecretKey and KubeconfigSecretKey are the keys of the secret data
+	TokenSecretKey      = "token"
+	KubeconfigSecretKey = "kubeconfig"
+
+	defaultServiceAccountTokenTTL = 24 * time.Hour
+)
+
+// ServiceAccountTokenConfig defines the service account used by a UI (e.g. a dashboard) and the secret
+// which provides its token
+type ServiceAccountTokenConfig struct {
+	Namespace      string
+	ServiceAccount string
+	ClusterRoles   []string //cluster roles bound to the service account
+	SecretName     string
+	TTL            time.Duration //validity of a token (default 24h)
+	//RotateBefore renews the token if it expires within this duration (default a third of the TTL)
+	RotateBefore time.Duration
+	//Kubeconfig stores a kubeconfig using the token in addition to the token
+	Kubeconfig bool
+}
+
+// ServiceAccountTokenAction creates a service account, binds its cluster roles and stores a bound token
+// in a secret. The token is renewed before it expires or if the rotation is enforced by the task configuration.
+type ServiceAccountTokenAction struct {
+	config ServiceAccountTokenConfig
+}
+
+func NewServiceAccountTokenAction(config ServiceAccountTokenConfig) *ServiceAccountTokenAction {
+	if config.TTL <= 0 {
+		config.TTL = defaultServiceAccountTokenTTL
+	}
+	if config.RotateBefore <= 0 || config.RotateBefore >= config.TTL {
+		config.RotateBefore = config.TTL / 3
+	}
+	if config.SecretName == "" {
+		config.SecretName = fmt.Sprintf("%s-token", config.ServiceAccount)
+	}
+	return &ServiceAccountTokenAction{config: config}
+}
+
+func (a *ServiceAccountTokenAction) Run(actionCtx *ActionContext) error {
+	clientset, err := actionCtx.KubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	if err := a.ensureServiceAccount(actionCtx.Context, clientset); err != nil {
+		return err
+	}
+	if err := a.ensureClusterRoleBindings(actionCtx.Context, clientset); err != nil {
+		return err
+	}
+
+	secret, err := clientset.CoreV1().Secrets(a.config.Namespace).Get(actionCtx.Context, a.config.SecretName, metav1.GetOptions{})
+	if err != nil && !k8serr.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to get token secret '%s'", a.config.SecretName)
+	}
+	if k8serr.IsNotFound(err) {
+		secret = nil
+	}
+	if !a.rotationRequired(actionCtx, secret, time.Now()) {
+		actionCtx.Logger.Debugf("Token of service account '%s' (namespace: %s) is valid",
+			a.config.ServiceAccount, a.config.Namespace)
+		return nil
+	}
+
+	token, expiration, err := a.createToken(actionCtx.Context, clientset)
+	if err != nil {
+		return err
+	}
+	data := map[string][]byte{TokenSecretKey: []byte(token)}
+	if a.config.Kubeconfig {
+		kubeconfig, err := a.kubeconfig(actionCtx.KubeClient.Kubeconfig(), token)
+		if err != nil {
+			return err
+		}
+		data[KubeconfigSecretKey] = kubeconfig
+	}
+	if err := a.storeToken(actionCtx.Context, clientset, secret, data, expiration); err != nil {
+		return err
+	}
+	actionCtx.Logger.Infof("Token of service account '%s' (namespace: %s) stored in secret '%s' (expires at %s)",
+		a.config.ServiceAccount, a.config.Namespace, a.config.SecretName, expiration.Format(time.RFC3339))
+	return nil
+}
+
+func (a *ServiceAccountTokenAction) ensureServiceAccount(ctx context.Context, clientset kubernetes.Interface) error {
+	_, err := clientset.CoreV1().ServiceAccounts(a.config.Namespace).Create(ctx, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: a.config.ServiceAccount, Namespace: a.config.Namespace},
+	}, metav1.CreateOptions{})
+	if err != nil && !k8serr.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "failed to create service account '%s'", a.config.ServiceAccount)
+	}
+	return nil
+}
+
+func (a *ServiceAccountTokenAction) ensureClusterRoleBindings(ctx context.Context, clientset kubernetes.Interface) error {
+	for _, clusterRole := range a.config.ClusterRoles {
+		binding := &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%s", a.config.ServiceAccount, clusterRole)},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRole},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: a.config.ServiceAccount, Namespace: a.config.Namespace},
+			},
+		}
+		_, err := clientset.RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{})
+		if err != nil && !k8serr.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to bind cluster role '%s' to service account '%s'",
+				clusterRole, a.config.ServiceAccount)
+		}
+	}
+	return nil
+}
+
+// rotationRequired returns true if the secret doesn't provide a token which is valid long enough
+func (a *ServiceAccountTokenAction) rotationRequired(actionCtx *ActionContext, secret *corev1.Secret, now time.Time) bool {
+	if secret == nil || len(secret.Data[TokenSecretKey]) == 0 {
+		return true
+	}
+	if actionCtx.Task != nil {
+		if rotate, err := strconv.ParseBool(fmt.Sprint(actionCtx.Task.Configuration[ServiceAccountTokenRotateKey])); err == nil && rotate {
+			return true
+		}
+	}
+	expiration, err := time.Parse(time.RFC3339, secret.Annotations[TokenExpirationAnnotation])
+	if err != nil {
+		return true
+	}
+	return expiration.Sub(now) < a.config.RotateBefore
+}
+
+func (a *ServiceAccountTokenAction) createToken(ctx context.Context, clientset kubernetes.Interface) (string, time.Time, error) {
+	expirationSeconds := int64(a.config.TTL.Seconds())
+	tokenRequest, err := clientset.CoreV1().ServiceAccounts(a.config.Namespace).CreateToken(ctx, a.config.ServiceAccount,
+		&authv1.TokenRequest{Spec: authv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds}}, metav1.CreateOptions{})
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(err, "failed to create token of service account '%s'", a.config.ServiceAccount)
+	}
+	if tokenRequest.Status.Token == "" {
+		return "", time.Time{}, fmt.Errorf("token of service account '%s' is empty", a.config.ServiceAccount)
+	}
+	expiration := tokenRequest.Status.ExpirationTimestamp.Time
+	if expiration.IsZero() {
+		expiration = time.Now().Add(a.config.TTL)
+	}
+	return tokenRequest.Status.Token, expiration, nil
+}
+
+// kubeconfig returns a kubeconfig for the cluster of the reconciler's kubeconfig which authenticates with the token
+func (a *ServiceAccountTokenAction) kubeconfig(reconcilerKubeconfig, token string) ([]byte, error) {
+	config, err := clientcmd.Load([]byte(reconcilerKubeconfig))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse kubeconfig")
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig doesn't define the current context '%s'", config.CurrentContext)
+	}
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig doesn't define the cluster '%s'", kubeContext.Cluster)
+	}
+
+	tokenConfig := clientcmdapi.NewConfig()
+	tokenConfig.Clusters[kubeContext.Cluster] = cluster
+	tokenConfig.AuthInfos[a.config.ServiceAccount] = &clientcmdapi.AuthInfo{Token: token}
+	tokenConfig.Contexts[a.config.ServiceAccount] = &clientcmdapi.Context{
+		Cluster:   kubeContext.Cluster,
+		AuthInfo:  a.config.ServiceAccount,
+		Namespace: a.config.Namespace,
+	}
+	tokenConfig.CurrentContext = a.config.ServiceAccount
+	return clientcmd.Write(*tokenConfig)
+}
+
+func (a *ServiceAccountTokenAction) storeToken(ctx context.Context, clientset kubernetes.Interface, secret *corev1.Secret,
+	data map[string][]byte, expiration time.Time) error {
+	exists := secret != nil
+	if !exists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: a.config.SecretName, Namespace: a.config.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+		}
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[TokenExpirationAnnotation] = expiration.UTC().Format(time.RFC3339)
+	secret.Data = data
+
+	var err error
+	if exists {
+		_, err = clientset.CoreV1().Secrets(a.config.Namespace).Update(ctx, secret, metav1.UpdateOptions{})
+	} else {
+		_, err = clientset.CoreV1().Secrets(a.config.Namespace).Create(ctx, secret, metav1.CreateOptions{})
+	}
+	return errors.Wrapf(err, "failed to store token in secret '%s'", a.config.SecretName)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const testTokenKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: shoot
+  cluster:
+    server: https://api.shoot.example.com
+    certificate-authority-data: Y2E=
+contexts:
+- name: shoot
+  context:
+    cluster: shoot
+    user: admin
+current-context: shoot
+users:
+- name: admin
+  user:
+    token: admin-token
+`
+
+func TestServiceAccountTokenAction(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	tokens := 0
+	clientset.PrependReactor("create", "serviceaccounts", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		tokens++
+		return true, &authv1.TokenRequest{Status: authv1.TokenRequestStatus{
+			Token:               fmt.Sprintf("token-%d", tokens),
+			ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+		}}, nil
+	})
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(clientset, nil)
+	kubeClient.On("Kubeconfig").Return(testTokenKubeconfig)
+
+	action := NewServiceAccountTokenAction(ServiceAccountTokenConfig{
+		Namespace:      "kyma-system",
+		ServiceAccount: "dashboard",
+		ClusterRoles:   []string{"view"},
+		TTL:            time.Hour,
+		Kubeconfig:     true,
+	})
+	newActionContext := func(configuration map[string]interface{}) *ActionContext {
+		return &ActionContext{
+			KubeClient: kubeClient,
+			Context:    context.Background(),
+			Logger:     logger.NewLogger(true),
+			Task:       &reconciler.Task{Configuration: configuration},
+		}
+	}
+	getSecret := func() map[string][]byte {
+		secret, err := clientset.CoreV1().Secrets("kyma-system").Get(context.Background(), "dashboard-token", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.NotEmpty(t, secret.Annotations[TokenExpirationAnnotation])
+		return secret.Data
+	}
+
+	t.Run("Create service account, binding and token", func(t *testing.T) {
+		require.NoError(t, action.Run(newActionContext(nil)))
+
+		_, err := clientset.CoreV1().ServiceAccounts("kyma-system").Get(context.Background(), "dashboard", metav1.GetOptions{})
+		require.NoError(t, err)
+		binding, err := clientset.RbacV1().ClusterRoleBindings().Get(context.Background(), "dashboard-view", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "view", binding.RoleRef.Name)
+
+		data := getSecret()
+		require.Equal(t, "token-1", string(data[TokenSecretKey]))
+		kubeconfig, err := clientcmd.Load(data[KubeconfigSecretKey])
+		require.NoError(t, err)
+		require.Equal(t, "token-1", kubeconfig.AuthInfos["dashboard"].Token)
+		require.Equal(t, "https://api.shoot.example.com", kubeconfig.Clusters["shoot"].Server)
+	})
+
+	t.Run("Keep valid token", func(t *testing.T) {
+		require.NoError(t, action.Run(newActionContext(nil)))
+		require.Equal(t, "token-1", string(getSecret()[TokenSecretKey]))
+	})
+
+	t.Run("Rotate token on demand", func(t *testing.T) {
+		require.NoError(t, action.Run(newActionContext(map[string]interface{}{ServiceAccountTokenRotateKey: true})))
+		require.Equal(t, "token-2", string(getSecret()[TokenSecretKey]))
+	})
+
+	t.Run("Rotate token before it expires", func(t *testing.T) {
+		expiringAction := NewServiceAccountTokenAction(ServiceAccountTokenConfig{
+			Namespace:      "kyma-system",
+			ServiceAccount: "dashboard",
+			TTL:            2 * time.Hour,
+			RotateBefore:   90 * time.Minute, //token created by the reactor expires within an hour
+		})
+		require.NoError(t, expiringAction.Run(newActionContext(nil)))
+		require.Equal(t, "token-3", string(getSecret()[TokenSecretKey]))
+	})
+}
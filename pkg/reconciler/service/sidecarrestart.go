@@ -0,0 +1,525 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	//SidecarProxyVersionKey is the configuration key of the proxy version all sidecars have to run after an upgrade
+	SidecarProxyVersionKey = "reconciler.sidecarRestart.proxyVersion"
+	//SidecarRestartBatchSizeKey is the configuration key of the amount of workloads restarted per batch
+	SidecarRestartBatchSizeKey = "reconciler.sidecarRestart.batchSize"
+	//SidecarRestartBatchIntervalKey is the configuration key of the pause between two batches (e.g. '30s')
+	SidecarRestartBatchIntervalKey = "reconciler.sidecarRestart.batchInterval"
+
+	defaultSidecarContainer          = "istio-proxy"
+	defaultSidecarRestartBatchSize   = 5
+	defaultSidecarRestartInterval    = 30 * time.Second
+	defaultSidecarRestartMaxDeferral = 10
+	defaultSidecarRolloutTimeout     = 10 * time.Minute
+	defaultSidecarRolloutInterval    = 5 * time.Second
+	restartedAtAnnotation            = "kubectl.kubernetes.io/restartedAt"
+)
+
+// SidecarRestartConfig configures the restart of workloads running outdated sidecars. Batch size and interval
+// can be overwritten by the configuration of a task.
+type SidecarRestartConfig struct {
+	ContainerName string        //name of the sidecar container (default 'istio-proxy')
+	BatchSize     int           //workloads restarted per batch (default 5)
+	BatchInterval time.Duration //pause between two batches (default 30s)
+	MaxDeferrals  int           //batches a workload is postponed while its PDB doesn't allow disruptions (default 10)
+	//RolloutTimeout is the time the restarted workloads of a batch have to finish their rollout (default 10m)
+	RolloutTimeout time.Duration
+	//RolloutInterval is the interval of the rollout checks of the restarted workloads (default 5s)
+	RolloutInterval time.Duration
+	//ProxyVersion returns the version all sidecars have to run (default: value of SidecarProxyVersionKey)
+	ProxyVersion func(actionCtx *ActionContext) (string, error)
+	//OnProgress is called after each batch with the progress of each namespace (optional)
+	OnProgress func(progress []SidecarRestartProgress)
+}
+
+// SidecarRestartProgress reports how many outdated workloads of a namespace were restarted
+type SidecarRestartProgress struct {
+	Namespace string
+	Outdated  int
+	Restarted int
+	Skipped   int //restarts which were skipped because a PDB didn't allow disruptions
+}
+
+// sidecarWorkload is a workload which runs pods with outdated sidecars
+type sidecarWorkload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	PodLabels map[string]string //labels of a pod with an outdated sidecar (used to find matching PDBs)
+	deferrals int
+}
+
+func (w *sidecarWorkload) String() string {
+	return fmt.Sprintf("%s '%s' (namespace: %s)", w.Kind, w.Name, w.Namespace)
+}
+
+// SidecarRestartAction restarts workloads whose pods run outdated sidecars (e.g. after an istio upgrade).
+// Workloads are restarted in batches: the next batch starts when the rollouts of the previous batch finished.
+// A batch restarts at most as many workloads covered by a PDB as the PDB allows disruptions, workloads protected
+// by a PDB which doesn't allow disruptions are postponed.
+type SidecarRestartAction struct {
+	config SidecarRestartConfig
+}
+
+func NewSidecarRestartAction(config SidecarRestartConfig) *SidecarRestartAction {
+	if config.ContainerName == "" {
+		config.ContainerName = defaultSidecarContainer
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultSidecarRestartBatchSize
+	}
+	if config.BatchInterval <= 0 {
+		config.BatchInterval = defaultSidecarRestartInterval
+	}
+	if config.MaxDeferrals <= 0 {
+		config.MaxDeferrals = defaultSidecarRestartMaxDeferral
+	}
+	if config.RolloutTimeout <= 0 {
+		config.RolloutTimeout = defaultSidecarRolloutTimeout
+	}
+	if config.RolloutInterval <= 0 {
+		config.RolloutInterval = defaultSidecarRolloutInterval
+	}
+	if config.ProxyVersion == nil {
+		config.ProxyVersion = func(actionCtx *ActionContext) (string, error) {
+			if version, ok := actionCtx.Task.Configuration[SidecarProxyVersionKey]; ok {
+				return fmt.Sprint(version), nil
+			}
+			return "", nil
+		}
+	}
+	return &SidecarRestartAction{config: config}
+}
+
+func (a *SidecarRestartAction) Run(actionCtx *ActionContext) error {
+	proxyVersion, err := a.config.ProxyVersion(actionCtx)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve the expected sidecar version")
+	}
+	if proxyVersion == "" {
+		actionCtx.Logger.Debugf("Sidecar restart skipped: expected sidecar version is undefined")
+		return nil
+	}
+	batchSize, batchInterval, err := a.batchConfig(actionCtx)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := actionCtx.KubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	workloads, err := a.outdatedWorkloads(actionCtx.Context, clientset, proxyVersion)
+	if err != nil {
+		return err
+	}
+	if len(workloads) == 0 {
+		actionCtx.Logger.Infof("All sidecars are running version '%s'", proxyVersion)
+		return nil
+	}
+	actionCtx.Logger.Infof("Restarting %d workloads with sidecars not running version '%s' in batches of %d",
+		len(workloads), proxyVersion, batchSize)
+
+	progress := newSidecarRestartProgress(workloads)
+	pending := workloads
+	for len(pending) > 0 {
+		//disruptions are counted per batch: the PDB status is refreshed once the rollouts of a batch finished
+		budgets := newDisruptionBudgets(clientset)
+		var batch, postponed []*sidecarWorkload
+		for _, workload := range pending {
+			if len(batch) == batchSize {
+				postponed = append(postponed, workload)
+				continue
+			}
+			reserved, blocked, err := budgets.reserve(actionCtx.Context, workload)
+			if err != nil {
+				return err
+			}
+			if reserved {
+				batch = append(batch, workload)
+				continue
+			}
+			if blocked {
+				workload.deferrals++
+				if workload.deferrals > a.config.MaxDeferrals {
+					actionCtx.Logger.Warnf("Restart of %s skipped: its PodDisruptionBudget doesn't allow disruptions", workload)
+					progress.skipped(workload)
+					continue
+				}
+			}
+			postponed = append(postponed, workload)
+		}
+
+		for _, workload := range batch {
+			if err := a.restart(actionCtx.Context, clientset, workload); err != nil {
+				return errors.Wrapf(err, "failed to restart %s", workload)
+			}
+			progress.restarted(workload)
+		}
+		if err := a.waitForRollouts(actionCtx.Context, clientset, batch); err != nil {
+			return err
+		}
+		a.report(actionCtx, progress)
+
+		pending = postponed
+		if len(pending) == 0 {
+			break
+		}
+		select {
+		case <-actionCtx.Context.Done():
+			return actionCtx.Context.Err()
+		case <-time.After(batchInterval):
+		}
+	}
+	return nil
+}
+
+func (a *SidecarRestartAction) batchConfig(actionCtx *ActionContext) (int, time.Duration, error) {
+	batchSize, batchInterval := a.config.BatchSize, a.config.BatchInterval
+	if value, ok := actionCtx.Task.Configuration[SidecarRestartBatchSizeKey]; ok {
+		size, err := strconv.Atoi(fmt.Sprint(value))
+		if err != nil || size <= 0 {
+			return 0, 0, fmt.Errorf("configuration '%s' has to be a number > 0 but was '%v'", SidecarRestartBatchSizeKey, value)
+		}
+		batchSize = size
+	}
+	if value, ok := actionCtx.Task.Configuration[SidecarRestartBatchIntervalKey]; ok {
+		interval, err := time.ParseDuration(fmt.Sprint(value))
+		if err != nil || interval < 0 {
+			return 0, 0, fmt.Errorf("configuration '%s' has to be a duration >= 0 but was '%v'", SidecarRestartBatchIntervalKey, value)
+		}
+		batchInterval = interval
+	}
+	return batchSize, batchInterval, nil
+}
+
+// outdatedWorkloads returns the workloads owning pods whose sidecar doesn't run the expected version
+func (a *SidecarRestartAction) outdatedWorkloads(ctx context.Context, clientset kubernetes.Interface, proxyVersion string) ([]*sidecarWorkload, error) {
+	pods, err := clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	workloads := make(map[string]*sidecarWorkload)
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !a.isOutdated(pod, proxyVersion) {
+			continue
+		}
+		workload, err := workloadOf(ctx, clientset, pod)
+		if err != nil {
+			return nil, err
+		}
+		if workload != nil {
+			workloads[workload.String()] = workload
+		}
+	}
+
+	result := make([]*sidecarWorkload, 0, len(workloads))
+	for _, workload := range workloads {
+		result = append(result, workload)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result, nil
+}
+
+// isOutdated returns true if the tag of the sidecar image doesn't match the proxy version (variants of the
+// version like '1.16.1-distroless' match as well). Images referenced only by digest are never outdated because
+// their version is unknown.
+func (a *SidecarRestartAction) isOutdated(pod *corev1.Pod, proxyVersion string) bool {
+	//sidecars are either regular containers or (native sidecars) init containers
+	for _, containers := range [][]corev1.Container{pod.Spec.Containers, pod.Spec.InitContainers} {
+		for _, container := range containers {
+			if container.Name != a.config.ContainerName {
+				continue
+			}
+			named, err := reference.ParseNormalizedNamed(container.Image)
+			if err != nil {
+				return false
+			}
+			tagged, ok := reference.TagNameOnly(named).(reference.Tagged) //images without tag use 'latest'
+			if !ok {
+				return false
+			}
+			tag := tagged.Tag()
+			return tag != proxyVersion && !strings.HasPrefix(tag, proxyVersion+"-")
+		}
+	}
+	return false
+}
+
+// workloadOf returns the workload controlling the pod (nil if the pod isn't controlled by a restartable workload)
+func workloadOf(ctx context.Context, clientset kubernetes.Interface, pod *corev1.Pod) (*sidecarWorkload, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, nil
+	}
+	switch owner.Kind {
+	case "ReplicaSet":
+		replicaSet, err := clientset.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		deploymentOwner := metav1.GetControllerOf(replicaSet)
+		if deploymentOwner == nil || deploymentOwner.Kind != "Deployment" {
+			return nil, nil
+		}
+		return &sidecarWorkload{Kind: "Deployment", Namespace: pod.Namespace, Name: deploymentOwner.Name,
+			PodLabels: pod.Labels}, nil
+	case "StatefulSet", "DaemonSet":
+		return &sidecarWorkload{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name,
+			PodLabels: pod.Labels}, nil
+	}
+	return nil, nil
+}
+
+// disruptionBudget tracks the disruptions a PDB allows within a batch
+type disruptionBudget struct {
+	selector  labels.Selector
+	allowed   int32 //disruptions allowed by the PDB when the batch started
+	remaining int32 //disruptions which aren't reserved by workloads of the batch
+}
+
+// disruptionBudgets reserves the disruptions of the workloads of a batch at the PDBs covering their pods
+type disruptionBudgets struct {
+	clientset  kubernetes.Interface
+	namespaces map[string][]*disruptionBudget //PDBs are listed once per namespace and batch
+}
+
+func newDisruptionBudgets(clientset kubernetes.Interface) *disruptionBudgets {
+	return &disruptionBudgets{
+		clientset:  clientset,
+		namespaces: make(map[string][]*disruptionBudget),
+	}
+}
+
+// reserve reserves a disruption at each PDB covering the pods of the workload. If a PDB has no disruption left,
+// nothing is reserved: blocked is true if the PDB didn't allow any disruption before the batch started.
+func (b *disruptionBudgets) reserve(ctx context.Context, workload *sidecarWorkload) (reserved bool, blocked bool, err error) {
+	budgets, err := b.list(ctx, workload.Namespace)
+	if err != nil {
+		return false, false, err
+	}
+	var matching []*disruptionBudget
+	for _, budget := range budgets {
+		if budget.selector.Matches(labels.Set(workload.PodLabels)) {
+			matching = append(matching, budget)
+		}
+	}
+	for _, budget := range matching {
+		if budget.remaining < 1 {
+			return false, budget.allowed < 1, nil
+		}
+	}
+	for _, budget := range matching {
+		budget.remaining--
+	}
+	return true, false, nil
+}
+
+func (b *disruptionBudgets) list(ctx context.Context, namespace string) ([]*disruptionBudget, error) {
+	if budgets, ok := b.namespaces[namespace]; ok {
+		return budgets, nil
+	}
+	pdbs, err := b.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	budgets := make([]*disruptionBudget, 0, len(pdbs.Items))
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		budgets = append(budgets, &disruptionBudget{
+			selector:  selector,
+			allowed:   pdb.Status.DisruptionsAllowed,
+			remaining: pdb.Status.DisruptionsAllowed,
+		})
+	}
+	b.namespaces[namespace] = budgets
+	return budgets, nil
+}
+
+// restart triggers a rolling restart of the workload (equivalent to 'kubectl rollout restart')
+func (a *SidecarRestartAction) restart(ctx context.Context, clientset kubernetes.Interface, workload *sidecarWorkload) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"%s":"%s"}}}}}`,
+		restartedAtAnnotation, time.Now().Format(time.RFC3339)))
+	var err error
+	switch workload.Kind {
+	case "Deployment":
+		_, err = clientset.AppsV1().Deployments(workload.Namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = clientset.AppsV1().StatefulSets(workload.Namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = clientset.AppsV1().DaemonSets(workload.Namespace).Patch(ctx, workload.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if k8serr.IsNotFound(err) { //workload was deleted in the meantime
+		return nil
+	}
+	return err
+}
+
+// waitForRollouts waits until the rollouts of the restarted workloads finished (equivalent to 'kubectl rollout status')
+func (a *SidecarRestartAction) waitForRollouts(ctx context.Context, clientset kubernetes.Interface, workloads []*sidecarWorkload) error {
+	ctx, cancel := context.WithTimeout(ctx, a.config.RolloutTimeout)
+	defer cancel()
+	pending := workloads
+	for {
+		var notReady []*sidecarWorkload
+		for _, workload := range pending {
+			ready, err := rolloutFinished(ctx, clientset, workload)
+			if err != nil {
+				return errors.Wrapf(err, "failed to check rollout of %s", workload)
+			}
+			if !ready {
+				notReady = append(notReady, workload)
+			}
+		}
+		if len(notReady) == 0 {
+			return nil
+		}
+		pending = notReady
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "rollout of %s didn't finish", pending[0])
+		case <-time.After(a.config.RolloutInterval):
+		}
+	}
+}
+
+// rolloutFinished returns true if all pods of the workload were updated and are available
+func rolloutFinished(ctx context.Context, clientset kubernetes.Interface, workload *sidecarWorkload) (bool, error) {
+	var err error
+	switch workload.Kind {
+	case "Deployment":
+		var deployment *appsv1.Deployment
+		deployment, err = clientset.AppsV1().Deployments(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			return deploymentRolledOut(deployment), nil
+		}
+	case "StatefulSet":
+		var statefulSet *appsv1.StatefulSet
+		statefulSet, err = clientset.AppsV1().StatefulSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			return statefulSetRolledOut(statefulSet), nil
+		}
+	case "DaemonSet":
+		var daemonSet *appsv1.DaemonSet
+		daemonSet, err = clientset.AppsV1().DaemonSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			return daemonSetRolledOut(daemonSet), nil
+		}
+	}
+	if k8serr.IsNotFound(err) { //workload was deleted in the meantime
+		return true, nil
+	}
+	return false, err
+}
+
+func deploymentRolledOut(deployment *appsv1.Deployment) bool {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return false
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	//old replicas have to be terminated as well
+	return deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.Replicas <= deployment.Status.UpdatedReplicas &&
+		deployment.Status.AvailableReplicas >= deployment.Status.UpdatedReplicas
+}
+
+func statefulSetRolledOut(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return false
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return false
+	}
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		return statefulSet.Status.UpdatedReplicas >= replicas-*rollingUpdate.Partition
+	}
+	return statefulSet.Status.UpdateRevision == statefulSet.Status.CurrentRevision
+}
+
+func daemonSetRolledOut(daemonSet *appsv1.DaemonSet) bool {
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return false
+	}
+	return daemonSet.Status.UpdatedNumberScheduled >= daemonSet.Status.DesiredNumberScheduled &&
+		daemonSet.Status.NumberAvailable >= daemonSet.Status.DesiredNumberScheduled
+}
+
+func (a *SidecarRestartAction) report(actionCtx *ActionContext, progress sidecarRestartProgress) {
+	result := progress.list()
+	for _, namespaceProgress := range result {
+		actionCtx.Logger.Infof("Sidecar restart in namespace '%s': %d of %d outdated workloads restarted (%d skipped)",
+			namespaceProgress.Namespace, namespaceProgress.Restarted, namespaceProgress.Outdated, namespaceProgress.Skipped)
+	}
+	if a.config.OnProgress != nil {
+		a.config.OnProgress(result)
+	}
+}
+
+type sidecarRestartProgress map[string]*SidecarRestartProgress
+
+func newSidecarRestartProgress(workloads []*sidecarWorkload) sidecarRestartProgress {
+	progress := sidecarRestartProgress{}
+	for _, workload := range workloads {
+		if _, ok := progress[workload.Namespace]; !ok {
+			progress[workload.Namespace] = &SidecarRestartProgress{Namespace: workload.Namespace}
+		}
+		progress[workload.Namespace].Outdated++
+	}
+	return progress
+}
+
+func (p sidecarRestartProgress) restarted(workload *sidecarWorkload) {
+	p[workload.Namespace].Restarted++
+}
+
+func (p sidecarRestartProgress) skipped(workload *sidecarWorkload) {
+	p[workload.Namespace].Skipped++
+}
+
+func (p sidecarRestartProgress) list() []SidecarRestartProgress {
+	result := make([]SidecarRestartProgress, 0, len(p))
+	for _, namespaceProgress := range p {
+		result = append(result, *namespaceProgress)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+	return result
+}
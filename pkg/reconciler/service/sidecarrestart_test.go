@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var isController = true
+
+func newSidecarPod(name, namespace, ownerKind, ownerName, proxyImage string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app": ownerName},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: ownerKind, Name: ownerName, Controller: &isController},
+			},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app", Image: "app:1.0.0"},
+			{Name: defaultSidecarContainer, Image: proxyImage},
+		}},
+	}
+}
+
+// newOutdatedDeployment returns a deployment, its replica set and a pod running an outdated sidecar
+func newOutdatedDeployment(deployment *appsv1.Deployment, podLabel string) []runtime.Object {
+	pod := newSidecarPod(deployment.Name+"-123-a", deployment.Namespace, "ReplicaSet", deployment.Name+"-123", "istio/proxyv2:1.15.0")
+	pod.Labels["app"] = podLabel
+	return []runtime.Object{
+		deployment,
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            deployment.Name + "-123",
+			Namespace:       deployment.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: deployment.Name, Controller: &isController}},
+		}},
+		pod,
+	}
+}
+
+func newRolledOutDeployment(name, namespace string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 1},
+		Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+func newSidecarRestartContext(clientset *fake.Clientset, batchSize string) *ActionContext {
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(clientset, nil)
+	return &ActionContext{
+		KubeClient: kubeClient,
+		Context:    context.Background(),
+		Logger:     logger.NewLogger(true),
+		Task: &reconciler.Task{Configuration: map[string]interface{}{
+			SidecarProxyVersionKey:         "1.16.1",
+			SidecarRestartBatchSizeKey:     batchSize,
+			SidecarRestartBatchIntervalKey: "0s",
+		}},
+	}
+}
+
+func isRestarted(t *testing.T, clientset *fake.Clientset, namespace, name string) bool {
+	deployment, err := clientset.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return deployment.Spec.Template.Annotations[restartedAtAnnotation] != ""
+}
+
+func TestSidecarRestartAction(t *testing.T) {
+	objects := []runtime.Object{
+		newRolledOutDeployment("outdated", "ns1"),
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "outdated-123",
+			Namespace:       "ns1",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "outdated", Controller: &isController}},
+		}},
+		newSidecarPod("outdated-123-a", "ns1", "ReplicaSet", "outdated-123", "istio/proxyv2:1.15.0"),
+		newSidecarPod("outdated-123-b", "ns1", "ReplicaSet", "outdated-123", "istio/proxyv2:1.15.0"),
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "current", Namespace: "ns1", Generation: 1},
+			Status: appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 1}},
+		newSidecarPod("current-0", "ns1", "StatefulSet", "current", "istio/proxyv2:1.16.1-distroless"),
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "protected", Namespace: "ns2"}},
+		newSidecarPod("protected-xyz", "ns2", "DaemonSet", "protected", "istio/proxyv2:1.15.0"),
+		&policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "protected", Namespace: "ns2"},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "protected"}},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+		},
+	}
+	clientset := fake.NewSimpleClientset(objects...)
+
+	var progress []SidecarRestartProgress
+	action := NewSidecarRestartAction(SidecarRestartConfig{
+		MaxDeferrals: 1,
+		OnProgress: func(p []SidecarRestartProgress) {
+			progress = p
+		},
+	})
+	err := action.Run(newSidecarRestartContext(clientset, "1"))
+	require.NoError(t, err)
+	require.True(t, isRestarted(t, clientset, "ns1", "outdated"))
+
+	statefulSet, err := clientset.AppsV1().StatefulSets("ns1").Get(context.Background(), "current", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, statefulSet.Spec.Template.Annotations)
+
+	daemonSet, err := clientset.AppsV1().DaemonSets("ns2").Get(context.Background(), "protected", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, daemonSet.Spec.Template.Annotations)
+
+	require.Equal(t, []SidecarRestartProgress{
+		{Namespace: "ns1", Outdated: 1, Restarted: 1},
+		{Namespace: "ns2", Outdated: 1, Skipped: 1},
+	}, progress)
+}
+
+func TestSidecarRestartDisruptionsPerBatch(t *testing.T) {
+	//the PDB allows one disruption: both deployments are covered by it and can't be restarted in the same batch
+	objects := append(newOutdatedDeployment(newRolledOutDeployment("first", "ns"), "shared"),
+		newOutdatedDeployment(newRolledOutDeployment("second", "ns"), "shared")...)
+	objects = append(objects, &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "ns"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "shared"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	})
+	clientset := fake.NewSimpleClientset(objects...)
+
+	var batches [][]SidecarRestartProgress
+	action := NewSidecarRestartAction(SidecarRestartConfig{
+		MaxDeferrals: 1,
+		OnProgress: func(p []SidecarRestartProgress) {
+			batches = append(batches, p)
+		},
+	})
+	require.NoError(t, action.Run(newSidecarRestartContext(clientset, "5")))
+	require.Equal(t, [][]SidecarRestartProgress{
+		{{Namespace: "ns", Outdated: 2, Restarted: 1}},
+		{{Namespace: "ns", Outdated: 2, Restarted: 2}}, //postponing the second deployment isn't counted as deferral
+	}, batches)
+	require.True(t, isRestarted(t, clientset, "ns", "first"))
+	require.True(t, isRestarted(t, clientset, "ns", "second"))
+}
+
+func TestSidecarRestartWaitsForRollout(t *testing.T) {
+	pending := newRolledOutDeployment("pending", "ns")
+	pending.Status.AvailableReplicas = 0 //new pod never becomes available
+	objects := append(newOutdatedDeployment(pending, "pending"),
+		newOutdatedDeployment(newRolledOutDeployment("waiting", "ns"), "waiting")...)
+	clientset := fake.NewSimpleClientset(objects...)
+
+	action := NewSidecarRestartAction(SidecarRestartConfig{
+		RolloutTimeout:  50 * time.Millisecond,
+		RolloutInterval: 10 * time.Millisecond,
+	})
+	err := action.Run(newSidecarRestartContext(clientset, "1"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "rollout of Deployment 'pending'")
+	require.True(t, isRestarted(t, clientset, "ns", "pending"))
+	require.False(t, isRestarted(t, clientset, "ns", "waiting"))
+}
+
+func TestSidecarIsOutdated(t *testing.T) {
+	digest := "@sha256:" + strings.Repeat("a", 64)
+	action := NewSidecarRestartAction(SidecarRestartConfig{})
+	for image, outdated := range map[string]bool{
+		"istio/proxyv2:1.16.1":                        false,
+		"istio/proxyv2:1.16.1-distroless":             false,
+		"istio/proxyv2:1.15.0":                        true,
+		"istio/proxyv2:1.16.10":                       true,
+		"registry:5000/istio/proxyv2:1.16.1":          false,
+		"registry:5000/istio/proxyv2":                 true, //untagged images run 'latest'
+		"registry:5000/istio/proxyv2:1.16.1" + digest: false,
+		"istio/proxyv2" + digest:                      false, //version is unknown
+		"Invalid Image":                               false,
+	} {
+		pod := newSidecarPod("pod", "ns", "Deployment", "deployment", image)
+		require.Equal(t, outdated, action.isOutdated(pod, "1.16.1"), image)
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultSmokeTestTimeout      = 5 * time.Minute
+	defaultSmokeTestPollInterval = 2 * time.Second
+)
+
+// SmokeTest verifies a component after its resources are ready and before the reconciliation is reported as successful
+type SmokeTest interface {
+	Name() string
+	Run(actionCtx *ActionContext) error
+}
+
+type funcSmokeTest struct {
+	name string
+	fn   func(actionCtx *ActionContext) error
+}
+
+// NewFuncSmokeTest returns a smoke test which executes the given function
+func NewFuncSmokeTest(name string, fn func(actionCtx *ActionContext) error) SmokeTest {
+	return &funcSmokeTest{name: name, fn: fn}
+}
+
+func (t *funcSmokeTest) Name() string {
+	return t.name
+}
+
+func (t *funcSmokeTest) Run(actionCtx *ActionContext) error {
+	return t.fn(actionCtx)
+}
+
+type httpProbeSmokeTest struct {
+	name           string
+	url            func(task *reconciler.Task) string
+	expectedStatus int
+}
+
+// NewHTTPProbeSmokeTest returns a smoke test which sends a GET request to the URL (e.g. an endpoint exposed
+// by the ingress of the cluster) and expects the given HTTP status code. The probe is sent with the shared
+// HTTP client and is therefore subject to the egress allowlist.
+func NewHTTPProbeSmokeTest(name string, url func(task *reconciler.Task) string, expectedStatus int) SmokeTest {
+	return &httpProbeSmokeTest{
+		name:           name,
+		url:            url,
+		expectedStatus: expectedStatus,
+	}
+}
+
+func (t *httpProbeSmokeTest) Name() string {
+	return t.name
+}
+
+func (t *httpProbeSmokeTest) Run(actionCtx *ActionContext) error {
+	url := t.url(actionCtx.Task)
+	req, err := http.NewRequestWithContext(actionCtx.Context, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to create probe request for URL '%s'", url))
+	}
+	resp, err := httpclient.Default().Do(req)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("probe of URL '%s' failed", url))
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			actionCtx.Logger.Warnf("Failed to close response body of probe '%s': %s", url, err)
+		}
+	}()
+	if resp.StatusCode != t.expectedStatus {
+		return fmt.Errorf("probe of URL '%s' returned status code %d but %d was expected",
+			url, resp.StatusCode, t.expectedStatus)
+	}
+	return nil
+}
+
+type jobSmokeTest struct {
+	name         string
+	job          func(task *reconciler.Task) *batchv1.Job
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// NewJobSmokeTest returns a smoke test which runs the job in the namespace of the component. The smoke test
+// passes if the job completes successfully within the timeout. The job is deleted afterwards.
+func NewJobSmokeTest(name string, job func(task *reconciler.Task) *batchv1.Job, timeout time.Duration) SmokeTest {
+	if timeout <= 0 {
+		timeout = defaultSmokeTestTimeout
+	}
+	return &jobSmokeTest{
+		name:         name,
+		job:          job,
+		timeout:      timeout,
+		pollInterval: defaultSmokeTestPollInterval,
+	}
+}
+
+func (t *jobSmokeTest) Name() string {
+	return t.name
+}
+
+func (t *jobSmokeTest) Run(actionCtx *ActionContext) error {
+	clientset, err := actionCtx.KubeClient.Clientset()
+	if err != nil {
+		return err
+	}
+	job := t.job(actionCtx.Task)
+	if job.Namespace == "" {
+		job.Namespace = actionCtx.Task.Namespace
+	}
+	jobs := clientset.BatchV1().Jobs(job.Namespace)
+
+	if _, err := jobs.Create(actionCtx.Context, job, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to create smoke test job '%s' in namespace '%s'", job.Name, job.Namespace))
+	}
+	defer func() {
+		propagation := metav1.DeletePropagationBackground
+		err := jobs.Delete(context.Background(), job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil && !k8serr.IsNotFound(err) {
+			actionCtx.Logger.Warnf("Failed to delete smoke test job '%s' in namespace '%s': %s", job.Name, job.Namespace, err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(actionCtx.Context, t.timeout)
+	defer cancel()
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+	for {
+		current, err := jobs.Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil && ctx.Err() == nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to get smoke test job '%s' in namespace '%s'", job.Name, job.Namespace))
+		}
+		if current != nil {
+			if finished, err := jobFinished(current); finished {
+				return err
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("smoke test job '%s' in namespace '%s' didn't finish within %.0f secs",
+				job.Name, job.Namespace, t.timeout.Seconds())
+		}
+	}
+}
+
+func jobFinished(job *batchv1.Job) (bool, error) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return true, fmt.Errorf("smoke test job '%s' failed: %s", job.Name, condition.Message)
+		}
+	}
+	return false, nil
+}
+
+// runSmokeTests executes all smoke tests and returns their results. The returned error is set if a smoke test failed.
+func runSmokeTests(smokeTests []SmokeTest, actionCtx *ActionContext) ([]reconciler.SmokeTestResult, error) {
+	var results []reconciler.SmokeTestResult
+	var failed []string
+	for _, smokeTest := range smokeTests {
+		startTime := time.Now()
+		err := smokeTest.Run(actionCtx)
+		result := reconciler.SmokeTestResult{
+			Name:     smokeTest.Name(),
+			Passed:   err == nil,
+			Duration: time.Since(startTime).Milliseconds(),
+		}
+		if err != nil {
+			actionCtx.Logger.Warnf("Smoke test '%s' of component '%s' failed: %s", smokeTest.Name(), actionCtx.Task.Component, err)
+			errMsg := err.Error()
+			result.Error = &errMsg
+			failed = append(failed, fmt.Sprintf("%s: %s", smokeTest.Name(), err))
+		}
+		results = append(results, result)
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("verification of component '%s' failed: %d of %d smoke tests failed (%v)",
+			actionCtx.Task.Component, len(failed), len(smokeTests), failed)
+	}
+	return results, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/fake"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSmokeTests(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewClient()
+	actionCtx := &ActionContext{
+		KubeClient: kubeClient,
+		Context:    ctx,
+		Logger:     logger.NewTestLogger(t),
+		Task:       &reconciler.Task{Component: "comp", Namespace: "kyma-system"},
+	}
+
+	t.Run("Results of passed and failed smoke tests", func(t *testing.T) {
+		results, err := runSmokeTests([]SmokeTest{
+			NewFuncSmokeTest("passing", func(actionCtx *ActionContext) error { return nil }),
+			NewFuncSmokeTest("failing", func(actionCtx *ActionContext) error { return errors.New("broken") }),
+		}, actionCtx)
+		require.Error(t, err)
+		require.Len(t, results, 2)
+		require.Equal(t, "passing", results[0].Name)
+		require.True(t, results[0].Passed)
+		require.Nil(t, results[0].Error)
+		require.Equal(t, "failing", results[1].Name)
+		require.False(t, results[1].Passed)
+		require.Equal(t, "broken", *results[1].Error)
+	})
+
+	t.Run("HTTP probe", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/healthz" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		probe := NewHTTPProbeSmokeTest("probe", func(task *reconciler.Task) string {
+			return server.URL + "/healthz"
+		}, http.StatusOK)
+		require.NoError(t, probe.Run(actionCtx))
+
+		probe = NewHTTPProbeSmokeTest("probe", func(task *reconciler.Task) string {
+			return server.URL + "/unavailable"
+		}, http.StatusOK)
+		require.Error(t, probe.Run(actionCtx))
+	})
+
+	t.Run("HTTP probe outside of egress allowlist", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		cfg := httpclient.DefaultConfig()
+		cfg.EgressAllowlist = []string{"ingress.example.com"}
+		httpclient.SetDefaults(cfg)
+		defer httpclient.SetDefaults(httpclient.DefaultConfig())
+
+		probe := NewHTTPProbeSmokeTest("probe", func(task *reconciler.Task) string {
+			return server.URL + "/healthz"
+		}, http.StatusOK)
+		err := probe.Run(actionCtx)
+		require.Error(t, err)
+		require.True(t, httpclient.IsEgressDeniedError(err))
+	})
+
+	t.Run("Job", func(t *testing.T) {
+		clientset, err := kubeClient.Clientset()
+		require.NoError(t, err)
+
+		runJob := func(conditionType batchv1.JobConditionType) error {
+			jobSmokeTest := NewJobSmokeTest("job", func(task *reconciler.Task) *batchv1.Job {
+				return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "comp-smoke-test"}}
+			}, time.Minute).(*jobSmokeTest)
+			jobSmokeTest.pollInterval = 10 * time.Millisecond
+
+			go func() { //simulate the job controller
+				for {
+					job, err := clientset.BatchV1().Jobs("kyma-system").Get(ctx, "comp-smoke-test", metav1.GetOptions{})
+					if err == nil {
+						job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+						_, err = clientset.BatchV1().Jobs("kyma-system").UpdateStatus(ctx, job, metav1.UpdateOptions{})
+						if err == nil {
+							return
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+				}
+			}()
+			return jobSmokeTest.Run(actionCtx)
+		}
+
+		require.NoError(t, runJob(batchv1.JobComplete))
+		require.Error(t, runJob(batchv1.JobFailed))
+
+		_, err = clientset.BatchV1().Jobs("kyma-system").Get(ctx, "comp-smoke-test", metav1.GetOptions{})
+		require.Error(t, err, "smoke test job wasn't deleted")
+	})
+}
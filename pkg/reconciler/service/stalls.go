@@ -0,0 +1,45 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+)
+
+// stallRecorder keeps the latest stall of each resource: they are reported as warnings with the heartbeats until
+// the deployed resources got ready
+type stallRecorder struct {
+	stalls    map[string]progress.Stall
+	resources []string //in order of their first stall
+	mu        sync.Mutex
+}
+
+func newStallRecorder() *stallRecorder {
+	return &stallRecorder{stalls: make(map[string]progress.Stall)}
+}
+
+func (r *stallRecorder) Record(stall progress.Stall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.stalls[stall.Resource]; !ok {
+		r.resources = append(r.resources, stall.Resource)
+	}
+	r.stalls[stall.Resource] = stall
+}
+
+func (r *stallRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stalls = make(map[string]progress.Stall)
+	r.resources = nil
+}
+
+func (r *stallRecorder) Warnings() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	warnings := make([]string, 0, len(r.resources))
+	for _, resource := range r.resources {
+		warnings = append(warnings, r.stalls[resource].String())
+	}
+	return warnings
+}
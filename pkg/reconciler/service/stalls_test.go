@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStallRecorder(t *testing.T) {
+	stall := func(resource string, elapsed time.Duration) progress.Stall {
+		return progress.Stall{
+			Resource:    resource,
+			TargetState: progress.ReadyState,
+			Reason:      "0 of 1 replicas are updated and 0 are ready",
+			Elapsed:     elapsed,
+			Timeout:     10 * time.Minute,
+		}
+	}
+
+	recorder := newStallRecorder()
+	require.Empty(t, recorder.Warnings())
+
+	recorder.Record(stall("Deployment [namespace:a|name:b]", 5*time.Minute))
+	recorder.Record(stall("Pod [namespace:a|name:c]", 5*time.Minute))
+	recorder.Record(stall("Deployment [namespace:a|name:b]", 8*time.Minute)) //escalation replaces the previous stall
+	require.Equal(t, []string{
+		"Deployment [namespace:a|name:b] didn't reach state 'ready' within 8m0s (80% of the timeout): 0 of 1 replicas are updated and 0 are ready",
+		"Pod [namespace:a|name:c] didn't reach state 'ready' within 5m0s (50% of the timeout): 0 of 1 replicas are updated and 0 are ready",
+	}, recorder.Warnings())
+
+	recorder.Reset()
+	require.Empty(t, recorder.Warnings())
+}
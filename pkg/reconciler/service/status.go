@@ -14,8 +14,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// reconciledVersionKey stores the version of the last successful reconciliation in the status ConfigMap
+const reconciledVersionKey = "reconciled-version"
+
+func statusCmName(task *reconciler.Task) string {
+	return fmt.Sprintf("%s-status", strings.ToLower(task.Component))
+}
+
 func createOrUpdateStatusCm(ctx context.Context, task *reconciler.Task, status reconciler.Status, kubeclient k8s.Client, logger *zap.SugaredLogger) {
-	configMapName := fmt.Sprintf("%s-status", strings.ToLower(task.Component))
+	configMapName := statusCmName(task)
 	if task.Namespace == "" {
 		task.Namespace = "default"
 	}
@@ -44,6 +51,9 @@ func createOrUpdateStatusCm(ctx context.Context, task *reconciler.Task, status r
 			"status":              string(status),
 			"last-reconciliation": lastReconciliation,
 		}
+		if status == reconciler.StatusSuccess {
+			configFile[reconciledVersionKey] = task.Version
+		}
 		cm := corev1.ConfigMap{
 			TypeMeta: metav1.TypeMeta{
 				Kind:       "ConfigMap",
@@ -77,6 +87,9 @@ func createOrUpdateStatusCm(ctx context.Context, task *reconciler.Task, status r
 		if status != reconciler.StatusRunning && status != reconciler.StatusNotstarted {
 			configMap.Data["last-reconciliation"] = time.Now().String()
 		}
+		if status == reconciler.StatusSuccess {
+			configMap.Data[reconciledVersionKey] = task.Version
+		}
 		_, err := clientset.CoreV1().ConfigMaps(task.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
 		if err != nil {
 			logger.Debugf("Error updating ConfigMap '%s': %s", configMapName, err)
@@ -85,3 +98,31 @@ func createOrUpdateStatusCm(ctx context.Context, task *reconciler.Task, status r
 		logger.Debugf("ConfigMap '%s' successfully updated", configMapName)
 	}
 }
+
+// reconciledVersion returns the version of the component which was successfully reconciled on the cluster
+// (empty if the component was never reconciled successfully)
+func reconciledVersion(ctx context.Context, task *reconciler.Task, kubeclient k8s.Client) (string, error) {
+	clientset, err := kubeclient.Clientset()
+	if err != nil {
+		return "", err
+	}
+	namespace := task.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	configMap, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, statusCmName(task), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if version, ok := configMap.Data[reconciledVersionKey]; ok {
+		return version, nil
+	}
+	//status ConfigMaps created before the reconciled version was tracked
+	if configMap.Data["status"] == string(reconciler.StatusSuccess) {
+		return configMap.Data["version"], nil
+	}
+	return "", nil
+}
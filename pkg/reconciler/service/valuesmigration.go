@@ -0,0 +1,110 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ValuesMigration transforms the configuration of a component (keys in dot-notation, e.g. 'global.domainName')
+// which was written for a previous chart version into the structure expected by a newer chart version
+type ValuesMigration func(values map[string]interface{}) error
+
+// RenameValues returns a migration which moves values to new keys. Renaming a key also moves all nested keys,
+// e.g. renaming 'a.b' to 'c' moves 'a.b.d' to 'c.d'. Values already defined for the new key are kept.
+func RenameValues(renames map[string]string) ValuesMigration {
+	return func(values map[string]interface{}) error {
+		keys := make([]string, 0, len(values)) //renamed keys must not be renamed again
+		for key := range values {
+			keys = append(keys, key)
+		}
+		for _, key := range keys {
+			value := values[key]
+			for oldKey, newKey := range renames {
+				var target string
+				if key == oldKey {
+					target = newKey
+				} else if strings.HasPrefix(key, oldKey+".") {
+					target = newKey + strings.TrimPrefix(key, oldKey)
+				} else {
+					continue
+				}
+				delete(values, key)
+				if _, ok := values[target]; !ok {
+					values[target] = value
+				}
+				break
+			}
+		}
+		return nil
+	}
+}
+
+type valuesMigrationStep struct {
+	fromVersion string
+	toVersion   string
+	migration   ValuesMigration
+	from, to    *semver.Version //set by validate()
+}
+
+type valuesMigrations []*valuesMigrationStep
+
+// validate parses the versions of the migration steps and sorts the steps by their target version
+func (m valuesMigrations) validate() error {
+	for _, step := range m {
+		var err error
+		if step.from, err = semver.NewVersion(step.fromVersion); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("values migration has invalid from-version '%s'", step.fromVersion))
+		}
+		if step.to, err = semver.NewVersion(step.toVersion); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("values migration has invalid to-version '%s'", step.toVersion))
+		}
+		if !step.to.GreaterThan(step.from) {
+			return fmt.Errorf("to-version '%s' of values migration has to be greater than its from-version '%s'",
+				step.toVersion, step.fromVersion)
+		}
+		if step.migration == nil {
+			return fmt.Errorf("values migration from version '%s' to '%s' is undefined",
+				step.fromVersion, step.toVersion)
+		}
+	}
+	sort.SliceStable(m, func(i, j int) bool {
+		return m[i].to.LessThan(m[j].to)
+	})
+	return nil
+}
+
+// apply runs the migration steps which are crossed by an upgrade from the installed to the target version
+// (ordered by their to-version). Nothing is migrated for new installations, downgrades or versions which
+// aren't semantic versions (e.g. 'main' or 'PR-123').
+func (m valuesMigrations) apply(installed, target string, values map[string]interface{}, logger *zap.SugaredLogger) error {
+	if len(m) == 0 || installed == "" {
+		return nil
+	}
+	installedVersion, err := semver.NewVersion(installed)
+	if err != nil {
+		logger.Debugf("Skipping values migrations: installed version '%s' is not a semantic version", installed)
+		return nil
+	}
+	targetVersion, err := semver.NewVersion(target)
+	if err != nil {
+		logger.Debugf("Skipping values migrations: target version '%s' is not a semantic version", target)
+		return nil
+	}
+	for _, step := range m {
+		if !step.to.GreaterThan(installedVersion) || step.to.GreaterThan(targetVersion) {
+			continue
+		}
+		logger.Infof("Migrating values from version '%s' to '%s' (upgrade from '%s' to '%s')",
+			step.fromVersion, step.toVersion, installed, target)
+		if err := step.migration(values); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to migrate values from version '%s' to '%s'",
+				step.fromVersion, step.toVersion))
+		}
+	}
+	return nil
+}
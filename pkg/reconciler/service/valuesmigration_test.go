@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRenameValues(t *testing.T) {
+	values := map[string]interface{}{
+		"global.domain":        "example.com",
+		"gateway.tls.enabled":  true,
+		"gateway.tls.secret":   "tls",
+		"gateway.replicas":     2,
+		"ingress.tls.secret":   "kept",
+		"unrelated.key":        "value",
+		"global.domainSuffix":  "suffix",
+		"global.domain.nested": "nested",
+	}
+	require.NoError(t, RenameValues(map[string]string{
+		"global.domain": "global.domainName",
+		"gateway.tls":   "ingress.tls",
+	})(values))
+	require.Equal(t, map[string]interface{}{
+		"global.domainName":        "example.com",
+		"global.domainName.nested": "nested",
+		"global.domainSuffix":      "suffix",
+		"ingress.tls.enabled":      true,
+		"ingress.tls.secret":       "kept",
+		"gateway.replicas":         2,
+		"unrelated.key":            "value",
+	}, values)
+}
+
+func TestValuesMigrations(t *testing.T) {
+	log := logger.NewLogger(true)
+	var applied []string
+	recorder := func(name string) ValuesMigration {
+		return func(values map[string]interface{}) error {
+			applied = append(applied, name)
+			return nil
+		}
+	}
+	migrations := valuesMigrations{
+		{fromVersion: "2.1.0", toVersion: "2.2.0", migration: recorder("2.2")},
+		{fromVersion: "2.0.0", toVersion: "2.1.0", migration: recorder("2.1")},
+		{fromVersion: "2.2.0", toVersion: "3.0.0", migration: recorder("3.0")},
+	}
+	require.NoError(t, migrations.validate())
+
+	for _, testCase := range []struct {
+		installed, target string
+		expected          []string
+	}{
+		{"2.0.0", "2.2.0", []string{"2.1", "2.2"}},
+		{"2.0.5", "3.1.0", []string{"2.1", "2.2", "3.0"}},
+		{"2.1.0", "2.1.3", nil},
+		{"2.2.0", "2.1.0", nil}, //downgrade
+		{"", "2.2.0", nil},      //new installation
+		{"main", "2.2.0", nil},
+		{"2.0.0", "PR-123", nil},
+	} {
+		applied = nil
+		require.NoError(t, migrations.apply(testCase.installed, testCase.target, map[string]interface{}{}, log))
+		require.Equal(t, testCase.expected, applied, "upgrade from '%s' to '%s'", testCase.installed, testCase.target)
+	}
+
+	t.Run("Invalid migrations", func(t *testing.T) {
+		for _, step := range []*valuesMigrationStep{
+			{fromVersion: "abc", toVersion: "1.0.0", migration: recorder("")},
+			{fromVersion: "1.0.0", toVersion: "1.0.0", migration: recorder("")},
+			{fromVersion: "1.0.0", toVersion: "2.0.0"},
+		} {
+			require.Error(t, valuesMigrations{step}.validate())
+		}
+	})
+
+	t.Run("Failing migration", func(t *testing.T) {
+		failing := valuesMigrations{{fromVersion: "1.0.0", toVersion: "2.0.0",
+			migration: func(values map[string]interface{}) error { return errors.New("broken") }}}
+		require.NoError(t, failing.validate())
+		require.Error(t, failing.apply("1.0.0", "2.0.0", map[string]interface{}{}, log))
+	})
+}
+
+func TestReconciledVersion(t *testing.T) {
+	task := &reconciler.Task{Component: "Istio", Namespace: "istio-system"}
+	newClient := func(data map[string]string) *mocks.Client {
+		clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio-status", Namespace: "istio-system"},
+			Data:       data,
+		})
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Clientset").Return(clientset, nil)
+		return kubeClient
+	}
+
+	for _, testCase := range []struct {
+		data     map[string]string
+		expected string
+	}{
+		{map[string]string{"version": "2.1.0", "status": "failed", reconciledVersionKey: "2.0.0"}, "2.0.0"},
+		{map[string]string{"version": "2.0.0", "status": "success"}, "2.0.0"},
+		{map[string]string{"version": "2.1.0", "status": "running"}, ""},
+	} {
+		version, err := reconciledVersion(context.Background(), task, newClient(testCase.data))
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, version)
+	}
+
+	t.Run("Status ConfigMap missing", func(t *testing.T) {
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Clientset").Return(fake.NewSimpleClientset(), nil)
+		version, err := reconciledVersion(context.Background(), task, kubeClient)
+		require.NoError(t, err)
+		require.Empty(t, version)
+	})
+}
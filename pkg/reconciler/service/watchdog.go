@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/panjf2000/ants/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWatchdogInterval = 30 * time.Second
+	maxStackDumpSize        = 1 << 20
+)
+
+// stuckWorkerObserver gets informed about detected stuck workers
+type stuckWorkerObserver interface {
+	OnStuckWorker(component string)
+	OnStuckWorkerReturned(component string)
+}
+
+type activeWorker struct {
+	task    *reconciler.Task
+	started time.Time
+	stuck   bool
+}
+
+// workerWatchdog detects workers which are processing a task longer than the threshold. Such workers ignored
+// the cancellation of their context and would silently shrink the capacity of the worker pool.
+// A goroutine can't be killed, so the pool capacity is increased for each stuck worker and shrunk again
+// as soon as the stuck worker returns.
+type workerWatchdog struct {
+	threshold time.Duration
+	interval  time.Duration
+	antsPool  *ants.Pool
+	observer  stuckWorkerObserver
+	logger    *zap.SugaredLogger
+	workers   map[*activeWorker]struct{}
+	mu        sync.Mutex
+}
+
+func newWorkerWatchdog(threshold time.Duration, antsPool *ants.Pool, observer stuckWorkerObserver, logger *zap.SugaredLogger) *workerWatchdog {
+	return &workerWatchdog{
+		threshold: threshold,
+		interval:  defaultWatchdogInterval,
+		antsPool:  antsPool,
+		observer:  observer,
+		logger:    logger,
+		workers:   make(map[*activeWorker]struct{}),
+	}
+}
+
+// track registers a worker processing the task and returns the function which has to be called when the worker is done
+func (w *workerWatchdog) track(task *reconciler.Task) func() {
+	if w == nil {
+		return func() {}
+	}
+	worker := &activeWorker{task: task, started: time.Now()}
+	w.mu.Lock()
+	w.workers[worker] = struct{}{}
+	w.mu.Unlock()
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.workers, worker)
+		if !worker.stuck {
+			return
+		}
+		w.antsPool.Tune(w.antsPool.Cap() - 1)
+		if w.observer != nil {
+			w.observer.OnStuckWorkerReturned(task.Component)
+		}
+		w.logger.Warnf("Stuck worker of task '%s' (correlation ID: %s) returned after %.1f secs: "+
+			"worker pool capacity reduced to %d", task, task.CorrelationID, time.Since(worker.started).Seconds(), w.antsPool.Cap())
+	}
+}
+
+func (w *workerWatchdog) run(ctx context.Context) {
+	w.logger.Infof("Starting worker watchdog: workers running longer than %.1f secs are treated as stuck",
+		w.threshold.Seconds())
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.check()
+		case <-ctx.Done():
+			w.logger.Debug("Stopping worker watchdog because parent context got closed")
+			return
+		}
+	}
+}
+
+func (w *workerWatchdog) check() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var detected bool
+	for worker := range w.workers {
+		if worker.stuck || time.Since(worker.started) < w.threshold {
+			continue
+		}
+		detected = true
+		worker.stuck = true
+		w.antsPool.Tune(w.antsPool.Cap() + 1) //compensate the capacity which is blocked by the stuck worker
+		if w.observer != nil {
+			w.observer.OnStuckWorker(worker.task.Component)
+		}
+		w.logger.Errorf("Worker of task '%s' (correlation ID: %s) is running since %.1f secs and seems to be stuck: "+
+			"worker pool capacity increased to %d", worker.task, worker.task.CorrelationID,
+			time.Since(worker.started).Seconds(), w.antsPool.Cap())
+	}
+	if detected {
+		w.logger.Errorf("Stack dump of all goroutines after detecting stuck workers:\n%s", stackDump())
+	}
+}
+
+func stackDump() string {
+	buf := make([]byte, maxStackDumpSize)
+	return string(buf[:runtime.Stack(buf, true)])
+}
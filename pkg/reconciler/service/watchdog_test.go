@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/panjf2000/ants/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type testStuckWorkerObserver struct {
+	stuck    map[string]int
+	returned map[string]int
+}
+
+func (o *testStuckWorkerObserver) OnStuckWorker(component string) {
+	o.stuck[component]++
+}
+
+func (o *testStuckWorkerObserver) OnStuckWorkerReturned(component string) {
+	o.returned[component]++
+}
+
+func TestWorkerWatchdog(t *testing.T) {
+	antsPool, err := ants.NewPool(2, ants.WithNonblocking(true))
+	require.NoError(t, err)
+	defer antsPool.Release()
+
+	observer := &testStuckWorkerObserver{stuck: map[string]int{}, returned: map[string]int{}}
+	watchdog := newWorkerWatchdog(100*time.Millisecond, antsPool, observer, logger.NewLogger(true))
+
+	doneFast := watchdog.track(&reconciler.Task{Component: "fast"})
+	doneStuck := watchdog.track(&reconciler.Task{Component: "stuck"})
+
+	t.Run("Workers within threshold are not stuck", func(t *testing.T) {
+		watchdog.check()
+		require.Empty(t, observer.stuck)
+		require.Equal(t, 2, antsPool.Cap())
+	})
+
+	t.Run("Finished workers are not tracked anymore", func(t *testing.T) {
+		doneFast()
+		time.Sleep(150 * time.Millisecond)
+		watchdog.check()
+		require.Equal(t, map[string]int{"stuck": 1}, observer.stuck)
+		require.Equal(t, 3, antsPool.Cap())
+	})
+
+	t.Run("Stuck workers are reported only once", func(t *testing.T) {
+		watchdog.check()
+		require.Equal(t, map[string]int{"stuck": 1}, observer.stuck)
+		require.Equal(t, 3, antsPool.Cap())
+	})
+
+	t.Run("Capacity is reduced when stuck worker returns", func(t *testing.T) {
+		doneStuck()
+		require.Equal(t, map[string]int{"stuck": 1}, observer.returned)
+		require.Equal(t, 2, antsPool.Cap())
+	})
+
+	t.Run("Nil watchdog is ignored", func(t *testing.T) {
+		var nilWatchdog *workerWatchdog
+		require.NotPanics(t, func() {
+			nilWatchdog.track(&reconciler.Task{})()
+		})
+	})
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
@@ -11,14 +13,17 @@ import (
 )
 
 type workPoolBuilder struct {
-	workerPool *WorkerPool
-	poolSize   int
+	workerPool     *WorkerPool
+	poolSize       int
+	stuckThreshold time.Duration
+	stuckObserver  stuckWorkerObserver
 }
 
 type WorkerPool struct {
 	debug        bool
 	logger       *zap.SugaredLogger
 	antsPool     *ants.Pool
+	watchdog     *workerWatchdog
 	newRunnerFct func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error
 }
 
@@ -41,6 +46,13 @@ func (pb *workPoolBuilder) WithDebug(debug bool) *workPoolBuilder {
 	return pb
 }
 
+// WithStuckWorkerDetection treats workers running longer than the threshold as stuck (0 = disabled)
+func (pb *workPoolBuilder) WithStuckWorkerDetection(threshold time.Duration, observer stuckWorkerObserver) *workPoolBuilder {
+	pb.stuckThreshold = threshold
+	pb.stuckObserver = observer
+	return pb
+}
+
 func (pb *workPoolBuilder) Build(ctx context.Context) (*WorkerPool, error) {
 	//add logger
 	log := logger.NewLogger(pb.workerPool.debug)
@@ -54,6 +66,11 @@ func (pb *workPoolBuilder) Build(ctx context.Context) (*WorkerPool, error) {
 	}
 	pb.workerPool.antsPool = antsPool
 
+	if pb.stuckThreshold > 0 {
+		pb.workerPool.watchdog = newWorkerWatchdog(pb.stuckThreshold, antsPool, pb.stuckObserver, log)
+		go pb.workerPool.watchdog.run(ctx)
+	}
+
 	go func(ctx context.Context, antsPool *ants.Pool) {
 		<-ctx.Done()
 		log.Info("Shutting down worker pool")
@@ -64,10 +81,15 @@ func (pb *workPoolBuilder) Build(ctx context.Context) (*WorkerPool, error) {
 }
 
 func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task) error {
+	return wa.assignWorker(ctx, model, nil)
+}
+
+// assignWorker calls the done function after the runner finished
+func (wa *WorkerPool) assignWorker(ctx context.Context, model *reconciler.Task, done func()) error {
 
 	taskDebugFlag := model.ComponentConfiguration.Debug
 	//enrich logger with correlation ID and component name
-	loggerNew := logger.NewLogger(taskDebugFlag).With(
+	loggerNew := logger.NewScopedLogger(model.Component, taskDebugFlag).With(
 		zap.Field{Key: "correlation-id", Type: zapcore.StringType, String: model.CorrelationID},
 		zap.Field{Key: "component-name", Type: zapcore.StringType, String: model.Component})
 
@@ -82,6 +104,10 @@ func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task)
 	//assign runner to worker
 	err = wa.antsPool.Submit(func() {
 		wa.logger.Debugf("Runner for model '%s' is assigned to worker", model)
+		if done != nil {
+			defer done()
+		}
+		defer wa.watchdog.track(model)()
 		runnerFunc := wa.newRunnerFct(ctx, model, remoteCbh, loggerNew)
 		if errRunner := runnerFunc(); errRunner != nil {
 			wa.logger.Warnf("Runner failed for model '%s': %v", model, errRunner)
@@ -106,6 +132,19 @@ func (wa *WorkerPool) Size() int {
 	return wa.antsPool.Cap()
 }
 
+func (wa *WorkerPool) FreeWorkers() int {
+	return wa.antsPool.Free()
+}
+
 func (wa *WorkerPool) IsFull() bool {
 	return wa.RunningWorkers() >= wa.Size()
 }
+
+// ReservedWorkers returns the number of workers which are kept free for tasks with normal priority
+func (wa *WorkerPool) ReservedWorkers() int {
+	reserved := wa.Size() / 10
+	if reserved < 1 {
+		return 1
+	}
+	return reserved
+}
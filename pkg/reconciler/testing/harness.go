@@ -0,0 +1,151 @@
+// Package testing provides a harness for authors of component reconcilers. It runs a component reconciler
+// in-process against a fake mothership and a fake cluster, so that reconcile and delete actions can be tested
+// like in an integration test but without external dependencies.
+package testing
+
+import (
+	"context"
+	gotesting "testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/fake"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultNamespace  = "kyma-system"
+	defaultVersion    = "0.0.0"
+	defaultWorkers    = 2
+	defaultMaxRetries = 1
+	defaultTimeout    = 1 * time.Minute
+	defaultInterval   = 100 * time.Millisecond
+)
+
+type Option func(*Harness)
+
+// WithKubeClient replaces the fake cluster, e.g. by a client of a real cluster or a pre-populated fake client
+func WithKubeClient(kubeClient kubernetes.Client) Option {
+	return func(h *Harness) {
+		h.KubeClient = kubeClient
+	}
+}
+
+// Harness runs a registered component reconciler in-process. Tasks are submitted to its worker pool like
+// the mothership does and the reconciler reports their progress to the fake mothership.
+type Harness struct {
+	Reconciler *service.ComponentReconciler
+	Mothership *FakeMothership
+	KubeClient kubernetes.Client
+
+	t          gotesting.TB
+	name       string
+	ctx        context.Context
+	workerPool *service.WorkerPool
+}
+
+// NewHarness starts the component reconciler registered with the given name (the package of the reconciler
+// has to be imported to register it). The reconciler is reconfigured with short intervals and a client of
+// the fake cluster. It's stopped when the test finishes.
+func NewHarness(t gotesting.TB, reconcilerName string, opts ...Option) *Harness {
+	recon, err := service.GetReconciler(reconcilerName)
+	require.NoError(t, err)
+
+	h := &Harness{
+		Reconciler: recon,
+		Mothership: NewFakeMothership(t),
+		KubeClient: fake.NewClient(),
+		t:          t,
+		name:       reconcilerName,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	recon.WithKubeClientFactory(func(_ string, _ *zap.SugaredLogger, _ *kubernetes.Config) (kubernetes.Client, error) {
+		return h.KubeClient, nil
+	}).
+		WithWorkers(defaultWorkers, defaultTimeout).
+		WithHeartbeatSenderConfig(defaultInterval, defaultTimeout).
+		WithProgressTrackerConfig(defaultInterval, defaultTimeout).
+		WithRetryDelay(defaultInterval)
+
+	var cancel context.CancelFunc
+	h.ctx, cancel = context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	var tracker *service.OccupancyTracker
+	h.workerPool, tracker, err = recon.StartRemote(h.ctx, reconcilerName)
+	require.NoError(t, err)
+	tracker.AssignCallbackURL(h.Mothership.URL())
+
+	return h
+}
+
+// Run submits the task to the reconciler. Mandatory fields which aren't set are defaulted: the callback URL
+// always points to the fake mothership.
+func (h *Harness) Run(task *reconciler.Task) *Operation {
+	if task.Component == "" {
+		task.Component = h.name
+	}
+	if task.Namespace == "" {
+		task.Namespace = defaultNamespace
+	}
+	if task.Version == "" {
+		task.Version = defaultVersion
+	}
+	if task.Type == "" {
+		task.Type = model.OperationTypeReconcile
+	}
+	if task.Kubeconfig == "" {
+		task.Kubeconfig = h.KubeClient.Kubeconfig()
+	}
+	if task.CorrelationID == "" {
+		task.CorrelationID = uuid.NewString()
+	}
+	if task.ComponentConfiguration.MaxRetries == 0 {
+		task.ComponentConfiguration.MaxRetries = defaultMaxRetries
+	}
+	if task.Configuration == nil {
+		task.Configuration = map[string]interface{}{}
+	}
+	task.CallbackURL = h.Mothership.CallbackURL(uuid.NewString(), task.CorrelationID)
+	require.NoError(h.t, task.Validate())
+
+	require.NoError(h.t, h.workerPool.AssignWorker(h.ctx, task))
+	return &Operation{
+		CorrelationID: task.CorrelationID,
+		harness:       h,
+	}
+}
+
+// Operation is a task which was submitted to the reconciler
+type Operation struct {
+	CorrelationID string
+	harness       *Harness
+}
+
+// Callbacks returns the callbacks the reconciler sent for this operation so far
+func (o *Operation) Callbacks() []*reconciler.CallbackMessage {
+	return o.harness.Mothership.Callbacks(o.CorrelationID)
+}
+
+// WaitForStatus waits until the reconciler reported the status for this operation and returns the callback
+func (o *Operation) WaitForStatus(status reconciler.Status, timeout time.Duration) *reconciler.CallbackMessage {
+	return o.harness.Mothership.WaitForCallback(o.harness.t, o.CorrelationID, timeout, func(msg *reconciler.CallbackMessage) bool {
+		return msg.Status == status
+	})
+}
+
+// Wait waits until the reconciler reported a final status (success or error) for this operation and returns the callback
+func (o *Operation) Wait(timeout time.Duration) *reconciler.CallbackMessage {
+	return o.harness.Mothership.WaitForCallback(o.harness.t, o.CorrelationID, timeout, func(msg *reconciler.CallbackMessage) bool {
+		return msg.Status == reconciler.StatusSuccess || msg.Status == reconciler.StatusError ||
+			msg.Status == reconciler.StatusVerificationFailed
+	})
+}
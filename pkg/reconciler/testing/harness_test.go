@@ -0,0 +1,68 @@
+package testing
+
+import (
+	gotesting "testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/fake"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: harness-test
+data:
+  key: value
+`
+
+type deployAction struct {
+	err error
+}
+
+func (a *deployAction) Run(context *service.ActionContext) error {
+	if a.err != nil {
+		return a.err
+	}
+	_, err := context.KubeClient.Deploy(context.Context, testManifest, context.Task.Namespace)
+	return err
+}
+
+func TestHarness(t *gotesting.T) {
+	t.Run("Reconcile successfully", func(t *gotesting.T) {
+		recon, err := service.NewComponentReconciler("harness-success")
+		require.NoError(t, err)
+		recon.WithReconcileAction(&deployAction{})
+
+		h := NewHarness(t, "harness-success")
+		op := h.Run(&reconciler.Task{})
+
+		callback := op.Wait(10 * time.Second)
+		require.Equal(t, reconciler.StatusSuccess, callback.Status)
+
+		kubeClient := h.KubeClient.(*fake.Client)
+		require.Len(t, kubeClient.DeployedManifests(), 1)
+		configMap, err := kubeClient.Get("ConfigMap", "harness-test", defaultNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, configMap)
+	})
+
+	t.Run("Reconcile with error", func(t *gotesting.T) {
+		recon, err := service.NewComponentReconciler("harness-error")
+		require.NoError(t, err)
+		recon.WithReconcileAction(&deployAction{err: errors.New("action failed")})
+
+		h := NewHarness(t, "harness-error")
+		op := h.Run(&reconciler.Task{
+			ComponentConfiguration: reconciler.ComponentConfiguration{MaxRetries: 1},
+		})
+
+		callback := op.Wait(10 * time.Second)
+		require.Equal(t, reconciler.StatusError, callback.Status)
+		require.Contains(t, callback.Error, "action failed")
+		require.NotEmpty(t, op.Callbacks())
+	})
+}
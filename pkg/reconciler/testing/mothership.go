@@ -0,0 +1,118 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	gotesting "testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+// FakeMothership receives the callbacks and occupancy reports which component reconcilers send to the mothership
+type FakeMothership struct {
+	server    *httptest.Server
+	mu        sync.Mutex
+	callbacks map[string][]*reconciler.CallbackMessage //key: correlation ID
+	occupancy map[string]*reconciler.HTTPOccupancyRequest
+	received  chan struct{}
+}
+
+// NewFakeMothership starts the fake mothership. It's stopped when the test finishes.
+func NewFakeMothership(t gotesting.TB) *FakeMothership {
+	m := &FakeMothership{
+		callbacks: make(map[string][]*reconciler.CallbackMessage),
+		occupancy: make(map[string]*reconciler.HTTPOccupancyRequest),
+		received:  make(chan struct{}, 1),
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/v1/operations/{schedulingID}/callback/{correlationID}", func(w http.ResponseWriter, r *http.Request) {
+		msg := &reconciler.CallbackMessage{}
+		if err := json.NewDecoder(r.Body).Decode(msg); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.mu.Lock()
+		correlationID := mux.Vars(r)["correlationID"]
+		m.callbacks[correlationID] = append(m.callbacks[correlationID], msg)
+		m.mu.Unlock()
+		m.notify()
+	}).Methods(http.MethodPost)
+	router.HandleFunc("/v1/occupancy/{poolID}", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		poolID := mux.Vars(r)["poolID"]
+		if r.Method == http.MethodDelete {
+			delete(m.occupancy, poolID)
+			return
+		}
+		occupancy := &reconciler.HTTPOccupancyRequest{}
+		if err := json.NewDecoder(r.Body).Decode(occupancy); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m.occupancy[poolID] = occupancy
+	}).Methods(http.MethodPost, http.MethodDelete)
+
+	m.server = httptest.NewServer(router)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *FakeMothership) URL() string {
+	return m.server.URL
+}
+
+// CallbackURL returns the URL component reconcilers have to use to report the progress of an operation
+func (m *FakeMothership) CallbackURL(schedulingID, correlationID string) string {
+	return fmt.Sprintf("%s/v1/operations/%s/callback/%s", m.server.URL, schedulingID, correlationID)
+}
+
+// Callbacks returns the callbacks received for an operation in the order they arrived
+func (m *FakeMothership) Callbacks(correlationID string) []*reconciler.CallbackMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*reconciler.CallbackMessage{}, m.callbacks[correlationID]...)
+}
+
+// Occupancy returns the latest occupancy reported by a worker pool (nil if none was reported)
+func (m *FakeMothership) Occupancy(poolID string) *reconciler.HTTPOccupancyRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.occupancy[poolID]
+}
+
+// WaitForCallback waits until a callback matching the condition was received for the operation and returns it
+func (m *FakeMothership) WaitForCallback(t gotesting.TB, correlationID string, timeout time.Duration,
+	condition func(*reconciler.CallbackMessage) bool) *reconciler.CallbackMessage {
+	deadline := time.After(timeout)
+	for {
+		for _, msg := range m.Callbacks(correlationID) {
+			if condition(msg) {
+				return msg
+			}
+		}
+		select {
+		case <-m.received:
+		case <-time.After(50 * time.Millisecond): //guard against missed notifications
+		case <-deadline:
+			require.FailNowf(t, "callback not received",
+				"no matching callback for operation '%s' received within %v (got %d callbacks)",
+				correlationID, timeout, len(m.Callbacks(correlationID)))
+			return nil
+		}
+	}
+}
+
+func (m *FakeMothership) notify() {
+	select {
+	case m.received <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,63 @@
+package reconciler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldError describes why a field of a task is invalid (the field is named like in the JSON payload)
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// ValidationError lists all invalid fields of a task: callers can fix all of them at once
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Add records an invalid field
+func (e *ValidationError) Add(field, reason string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Reason: fmt.Sprintf(reason, args...)})
+}
+
+// ErrOrNil returns the validation error if at least one field is invalid (and nil otherwise)
+func (e *ValidationError) ErrOrNil() error {
+	if e == nil || len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	reasons := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		reasons = append(reasons, fmt.Sprintf("'%s' %s", field.Field, field.Reason))
+	}
+	return fmt.Sprintf("task is invalid: %s", strings.Join(reasons, ", "))
+}
+
+// AsValidationError returns the validation error wrapped by err
+func AsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	ok := errors.As(err, &validationErr)
+	return validationErr, ok
+}
+
+// validateVersion accepts release versions and Git references (e.g. branches or commits): the version is used as
+// Git reference and as directory name of the workspace
+func validateVersion(version string) error {
+	if strings.HasPrefix(version, "-") || strings.HasPrefix(version, "/") || strings.HasSuffix(version, "/") {
+		return fmt.Errorf("cannot start with '-' or '/' or end with '/'")
+	}
+	if strings.Contains(version, "..") || strings.Contains(version, "//") {
+		return fmt.Errorf("cannot contain '..' or '//'")
+	}
+	for _, c := range version {
+		if c <= ' ' || c == 0x7f || strings.ContainsRune("~^:?*[\\", c) {
+			return fmt.Errorf("contains the invalid character %q", c)
+		}
+	}
+	return nil
+}
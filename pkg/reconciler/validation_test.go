@@ -0,0 +1,69 @@
+package reconciler
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://localhost:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: abc
+`
+
+func TestTaskValidation(t *testing.T) {
+	newTask := func() *Task {
+		return &Task{
+			Component:     "component",
+			Namespace:     "namespace",
+			Version:       "2.0.0",
+			Kubeconfig:    testKubeconfig,
+			CallbackURL:   "https://mothership/callback",
+			CorrelationID: "123",
+			Type:          model.OperationTypeReconcile,
+		}
+	}
+
+	t.Run("Valid task", func(t *testing.T) {
+		require.NoError(t, newTask().Validate())
+		for _, version := range []string{"main", "PR-123", "feature/install", "1a2b3c4"} {
+			task := newTask()
+			task.Version = version
+			require.NoError(t, task.Validate(), version)
+		}
+	})
+
+	t.Run("Each invalid field is listed", func(t *testing.T) {
+		err := (&Task{Version: "../main", Kubeconfig: "not a kubeconfig", Type: "upgrade"}).Validate()
+		validationErr, ok := AsValidationError(err)
+		require.True(t, ok)
+
+		var fields []string
+		for _, field := range validationErr.Fields {
+			fields = append(fields, field.Field)
+		}
+		require.Equal(t, []string{"component", "namespace", "version", "kubeconfig", "callbackURL", "correlationID", "type"}, fields)
+		require.Contains(t, validationErr.Fields[3].Reason, "cannot be parsed")
+		require.Contains(t, err.Error(), "'version' '../main' is not a valid version")
+	})
+
+	t.Run("Invalid versions", func(t *testing.T) {
+		for _, version := range []string{"-main", "main..x", "main branch", "main~1", "a:b", "feature/"} {
+			require.Error(t, validateVersion(version), version)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrNil is returned if a key doesn't exist
+var ErrNil = errors.New("redis: nil reply")
+
+// Error is an error reply of the Redis server: the connection stays usable
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Client sends commands to a Redis server using the RESP protocol. Connections are reused: the client is safe
+// for concurrent use.
+type Client struct {
+	config *Config
+	mu     sync.Mutex
+	idle   []*conn
+	closed bool
+}
+
+func NewClient(config *Config) (*Client, error) {
+	if !config.Enabled() {
+		return nil, fmt.Errorf("redis address is not configured")
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	client := &Client{config: config}
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+	if _, err := client.Do(ctx, "PING"); err != nil {
+		return nil, fmt.Errorf("failed to connect to redis '%s': %w", config.Address, err)
+	}
+	return client, nil
+}
+
+func (c *Client) Config() *Config {
+	return c.config
+}
+
+// Do sends a command and returns its reply: nil, string, int64, []interface{} or an Error
+func (c *Client) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	cn, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(c.config.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	reply, err := cn.do(deadline, args...)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		_ = cn.Close() //connection is in an undefined state
+		return nil, err
+	}
+	c.release(cn)
+	return reply, err
+}
+
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	var err error
+	for _, cn := range c.idle {
+		if closeErr := cn.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	c.idle = nil
+	return err
+}
+
+func (c *Client) conn(ctx context.Context) (*conn, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("redis client is closed")
+	}
+	if len(c.idle) > 0 {
+		cn := c.idle[len(c.idle)-1]
+		c.idle = c.idle[:len(c.idle)-1]
+		c.mu.Unlock()
+		return cn, nil
+	}
+	c.mu.Unlock()
+	return c.dial(ctx)
+}
+
+func (c *Client) release(cn *conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed || len(c.idle) >= c.config.PoolSize {
+		_ = cn.Close()
+		return
+	}
+	c.idle = append(c.idle, cn)
+}
+
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	dialer := &net.Dialer{Timeout: c.config.Timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.config.Address)
+	if err != nil {
+		return nil, err
+	}
+	cn := newConn(netConn)
+	deadline := time.Now().Add(c.config.Timeout)
+	if c.config.Password != "" {
+		if _, err := cn.do(deadline, "AUTH", c.config.Password); err != nil {
+			_ = cn.Close()
+			return nil, fmt.Errorf("redis authentication failed: %w", err)
+		}
+	}
+	if c.config.DB > 0 {
+		if _, err := cn.do(deadline, "SELECT", c.config.DB); err != nil {
+			_ = cn.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+func newConn(netConn net.Conn) *conn {
+	return &conn{
+		Conn:   netConn,
+		reader: bufio.NewReader(netConn),
+		writer: bufio.NewWriter(netConn),
+	}
+}
+
+func (cn *conn) do(deadline time.Time, args ...interface{}) (interface{}, error) {
+	if err := cn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if err := writeCommand(cn.writer, args...); err != nil {
+		return nil, err
+	}
+	if err := cn.writer.Flush(); err != nil {
+		return nil, err
+	}
+	return readReply(cn.reader)
+}
+
+// writeCommand encodes the command as RESP array of bulk strings
+func writeCommand(w *bufio.Writer, args ...interface{}) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		var value string
+		switch v := arg.(type) {
+		case string:
+			value = v
+		case []byte:
+			value = string(v)
+		case int:
+			value = strconv.Itoa(v)
+		case int64:
+			value = strconv.FormatInt(v, 10)
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			value = "0"
+			if v {
+				value = "1"
+			}
+		default:
+			value = fmt.Sprint(v)
+		}
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(value), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply line %q", line)
+	}
+	payload := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, Error(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil || size < 0 {
+			return nil, err //nil bulk string
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		size, err := strconv.Atoi(payload)
+		if err != nil || size < 0 {
+			return nil, err //nil array
+		}
+		result := make([]interface{}, size)
+		for i := range result {
+			if result[i], err = readReply(r); err != nil {
+				var replyErr Error
+				if !errors.As(err, &replyErr) {
+					return nil, err
+				}
+				result[i] = replyErr
+			}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", line[0])
+	}
+}
+
+// String converts a reply into a string (returns ErrNil if the key doesn't exist)
+func String(reply interface{}, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	switch v := reply.(type) {
+	case nil:
+		return "", ErrNil
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	default:
+		return "", fmt.Errorf("redis: unexpected reply of type %T for string", reply)
+	}
+}
+
+func Int64(reply interface{}, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+	switch v := reply.(type) {
+	case nil:
+		return 0, ErrNil
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("redis: unexpected reply of type %T for integer", reply)
+	}
+}
+
+// Strings converts an array reply into strings (nil elements become empty strings)
+func Strings(reply interface{}, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, nil
+	}
+	values, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected reply of type %T for array", reply)
+	}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		str, err := String(value, nil)
+		if err != nil && err != ErrNil {
+			return nil, err
+		}
+		result = append(result, str)
+	}
+	return result, nil
+}
+
+// StringMap converts the array reply of HGETALL into a map
+func StringMap(reply interface{}, err error) (map[string]string, error) {
+	values, err := Strings(reply, err)
+	if err != nil {
+		return nil, err
+	}
+	if len(values)%2 != 0 {
+		return nil, fmt.Errorf("redis: odd number of elements (%d) for map", len(values))
+	}
+	result := make(map[string]string, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		result[values[i]] = values[i+1]
+	}
+	return result, nil
+}
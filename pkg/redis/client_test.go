@@ -0,0 +1,142 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer answers commands with a minimal in-memory implementation of GET, SET, AUTH and PING
+type fakeServer struct {
+	listener net.Listener
+	password string
+	mu       sync.Mutex
+	values   map[string]string
+}
+
+func newFakeServer(t *testing.T, password string) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeServer{listener: listener, password: password, values: map[string]string{}}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			cn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(cn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeServer) serve(cn net.Conn) {
+	defer cn.Close()
+	reader, writer := bufio.NewReader(cn), bufio.NewWriter(cn)
+	authenticated := s.password == ""
+	for {
+		cmd, err := Strings(readReply(reader))
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		switch {
+		case cmd[0] == "AUTH" && cmd[1] == s.password:
+			authenticated = true
+			fmt.Fprint(writer, "+OK\r\n")
+		case cmd[0] == "AUTH":
+			fmt.Fprint(writer, "-WRONGPASS invalid password\r\n")
+		case !authenticated:
+			fmt.Fprint(writer, "-NOAUTH Authentication required\r\n")
+		case cmd[0] == "PING":
+			fmt.Fprint(writer, "+PONG\r\n")
+		case cmd[0] == "SET":
+			s.values[cmd[1]] = cmd[2]
+			fmt.Fprint(writer, "+OK\r\n")
+		case cmd[0] == "GET":
+			if value, ok := s.values[cmd[1]]; ok {
+				fmt.Fprintf(writer, "$%d\r\n%s\r\n", len(value), value)
+			} else {
+				fmt.Fprint(writer, "$-1\r\n")
+			}
+		case cmd[0] == "KEYS":
+			fmt.Fprintf(writer, "*%d\r\n", len(s.values))
+			for key := range s.values {
+				fmt.Fprintf(writer, "$%d\r\n%s\r\n", len(key), key)
+			}
+		default:
+			fmt.Fprintf(writer, "-ERR unknown command '%s'\r\n", cmd[0])
+		}
+		s.mu.Unlock()
+		if err := writer.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Send commands", func(t *testing.T) {
+		server := newFakeServer(t, "")
+		client, err := NewClient(&Config{Address: server.listener.Addr().String()})
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.Do(ctx, "SET", "key", "multi\r\nline value")
+		require.NoError(t, err)
+		value, err := String(client.Do(ctx, "GET", "key"))
+		require.NoError(t, err)
+		require.Equal(t, "multi\r\nline value", value)
+
+		_, err = String(client.Do(ctx, "GET", "missing"))
+		require.ErrorIs(t, err, ErrNil)
+
+		keys, err := Strings(client.Do(ctx, "KEYS", "*"))
+		require.NoError(t, err)
+		require.Equal(t, []string{"key"}, keys)
+
+		//error replies don't break the connection
+		_, err = client.Do(ctx, "UNKNOWN")
+		require.Error(t, err)
+		require.IsType(t, Error(""), err)
+		_, err = client.Do(ctx, "PING")
+		require.NoError(t, err)
+	})
+
+	t.Run("Authenticate", func(t *testing.T) {
+		server := newFakeServer(t, "secret")
+		_, err := NewClient(&Config{Address: server.listener.Addr().String(), Password: "wrong"})
+		require.Error(t, err)
+
+		client, err := NewClient(&Config{Address: server.listener.Addr().String(), Password: "secret"})
+		require.NoError(t, err)
+		defer client.Close()
+		_, err = client.Do(ctx, "PING")
+		require.NoError(t, err)
+	})
+
+	t.Run("Connection is unavailable", func(t *testing.T) {
+		server := newFakeServer(t, "")
+		address := server.listener.Addr().String()
+		require.NoError(t, server.listener.Close())
+		_, err := NewClient(&Config{Address: address})
+		require.Error(t, err)
+	})
+}
+
+func TestConfig(t *testing.T) {
+	config := &Config{Address: "localhost:6379"}
+	require.NoError(t, config.validate())
+	require.Equal(t, "reconciler:queue:base", config.Key("queue", "base"))
+	require.Equal(t, defaultTimeout, config.Timeout)
+
+	require.False(t, (&Config{}).Enabled())
+	require.Error(t, (&Config{Address: "localhost:6379", DB: -1}).validate())
+}
@@ -0,0 +1,93 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultKeyPrefix      = "reconciler"
+	defaultTimeout        = 5 * time.Second
+	defaultPoolSize       = 10
+	defaultRenderCacheTTL = 1 * time.Hour
+)
+
+// Config of the Redis server which replaces the database as backend of data with a high write load. Redis is
+// disabled if no address is configured. Only standalone Redis servers are supported (no Redis cluster).
+type Config struct {
+	Address   string
+	Password  string
+	DB        int
+	KeyPrefix string //prefix of all keys (allows sharing a Redis server between installations)
+	Timeout   time.Duration
+	PoolSize  int //max number of idle connections
+	//backends which use Redis instead of the database:
+	Queue          bool
+	Occupancy      bool
+	RenderCache    bool
+	RenderCacheTTL time.Duration
+}
+
+// NewConfig reads the 'redis' section of the configuration file
+func NewConfig(configFile string) (*Config, error) {
+	if configFile != "" {
+		viper.SetConfigFile(configFile)
+		if err := viper.ReadInConfig(); err != nil {
+			return nil, err
+		}
+	}
+	config := &Config{
+		Address:        viper.GetString("redis.address"),
+		Password:       viper.GetString("redis.password"),
+		DB:             viper.GetInt("redis.db"),
+		KeyPrefix:      viper.GetString("redis.keyPrefix"),
+		Timeout:        viper.GetDuration("redis.timeout"),
+		PoolSize:       viper.GetInt("redis.poolSize"),
+		Queue:          viper.GetBool("redis.queue"),
+		Occupancy:      viper.GetBool("redis.occupancy"),
+		RenderCache:    viper.GetBool("redis.renderCache"),
+		RenderCacheTTL: viper.GetDuration("redis.renderCacheTTL"),
+	}
+	//overwrite password if env-var is defined
+	if viper.IsSet("REDIS_PASSWORD") {
+		config.Password = viper.GetString("REDIS_PASSWORD")
+	}
+	return config, config.validate()
+}
+
+func (c *Config) Enabled() bool {
+	return c != nil && c.Address != ""
+}
+
+func (c *Config) validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.DB < 0 {
+		return fmt.Errorf("redis database cannot be < 0 (got %d)", c.DB)
+	}
+	if c.Timeout < 0 || c.PoolSize < 0 || c.RenderCacheTTL < 0 {
+		return fmt.Errorf("redis timeout, pool size and render cache TTL cannot be < 0")
+	}
+	if c.KeyPrefix == "" {
+		c.KeyPrefix = defaultKeyPrefix
+	}
+	if c.Timeout == 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.PoolSize == 0 {
+		c.PoolSize = defaultPoolSize
+	}
+	if c.RenderCacheTTL == 0 {
+		c.RenderCacheTTL = defaultRenderCacheTTL
+	}
+	return nil
+}
+
+// Key returns the key of the given parts prefixed by the key prefix (e.g. 'reconciler:queue:base')
+func (c *Config) Key(parts ...string) string {
+	return strings.Join(append([]string{c.KeyPrefix}, parts...), ":")
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+// WriteCSV exports a fleet report as CSV: a metric/value section followed by the failure top-list
+func WriteCSV(w io.Writer, fleetReport *keb.HTTPFleetReport) error {
+	writer := csv.NewWriter(w)
+	records := [][]string{
+		{"metric", "value"},
+		{"from", fleetReport.From.Format(time.RFC3339)},
+		{"to", fleetReport.To.Format(time.RFC3339)},
+		{"reconciliations", strconv.Itoa(fleetReport.Reconciliations)},
+		{"succeeded", strconv.Itoa(fleetReport.Succeeded)},
+		{"failed", strconv.Itoa(fleetReport.Failed)},
+		{"inProgress", strconv.Itoa(fleetReport.InProgress)},
+		{"successRate", strconv.FormatFloat(fleetReport.SuccessRate, 'f', 2, 64)},
+		{"meanTimeToReconcile", strconv.FormatInt(fleetReport.MeanTimeToReconcile, 10)},
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+
+	//encoding/csv skips empty records: the separator line has to be written directly
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+
+	records = [][]string{{"component", "failures", "clusters", "latestReason"}}
+	for _, failures := range fleetReport.TopFailures {
+		records = append(records, []string{
+			failures.Component,
+			strconv.Itoa(failures.Failures),
+			strconv.Itoa(failures.Clusters),
+			failures.LatestReason,
+		})
+	}
+	return writer.WriteAll(records)
+}
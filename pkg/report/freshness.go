@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+)
+
+// FreshnessReport evaluates the freshness SLO: each cluster has to be reconciled successfully within the objective
+type FreshnessReport struct {
+	Objective  time.Duration
+	Evaluated  time.Time
+	Clusters   int     //clusters the SLO applies to (deleted clusters and clusters with disabled reconciliation are excluded)
+	Compliant  int     //clusters which were reconciled successfully within the objective
+	Compliance float64 //percentage of compliant clusters
+	Excluded   int     //clusters whose reconciliation is disabled
+	Freshness  []*ClusterFreshness
+}
+
+// ClusterFreshness is the SLO compliance of a single cluster
+type ClusterFreshness struct {
+	RuntimeID   string
+	Status      model.Status
+	LastSuccess time.Time //zero if the cluster wasn't reconciled successfully within the objective
+	Compliant   bool
+	Reason      string //explains why the cluster violates the SLO
+}
+
+// Violations returns the clusters which violate the SLO
+func (r *FreshnessReport) Violations() []*ClusterFreshness {
+	var violations []*ClusterFreshness
+	for _, freshness := range r.Freshness {
+		if !freshness.Compliant {
+			violations = append(violations, freshness)
+		}
+	}
+	return violations
+}
+
+// Freshness evaluates the freshness SLO for the given clusters
+func (g *Generator) Freshness(states []*cluster.State, objective time.Duration) (*FreshnessReport, error) {
+	if objective <= 0 {
+		return nil, fmt.Errorf("freshness objective has to be > 0 but was %s", objective)
+	}
+	now := time.Now().UTC()
+	recons, err := g.repo.GetReconciliations(&reconciliation.FilterMixer{Filters: []reconciliation.Filter{
+		&reconciliation.WithCreationDateAfter{Time: now.Add(-objective)},
+		&reconciliation.WithStatuses{Statuses: []string{string(model.ClusterStatusReady)}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return NewFreshnessReport(now, objective, states, recons), nil
+}
+
+// NewFreshnessReport evaluates which clusters were reconciled successfully within the objective before now. The
+// latest success of a cluster is either a successful reconciliation or its current ready status.
+func NewFreshnessReport(now time.Time, objective time.Duration, states []*cluster.State, recons []*model.ReconciliationEntity) *FreshnessReport {
+	lastSuccess := make(map[string]time.Time)
+	for _, recon := range recons {
+		if !recon.Finished || recon.Status != model.ClusterStatusReady {
+			continue
+		}
+		if recon.Updated.After(lastSuccess[recon.RuntimeID]) {
+			lastSuccess[recon.RuntimeID] = recon.Updated
+		}
+	}
+
+	report := &FreshnessReport{
+		Objective: objective,
+		Evaluated: now,
+	}
+	deadline := now.Add(-objective)
+	for _, state := range states {
+		status := state.Status.Status
+		if status.IsDeleteCandidate() || status.IsDeletionInProgress() || status == model.ClusterStatusDeleted ||
+			status == model.ClusterStatusDeleteError {
+			continue
+		}
+		if status.IsDisabled() {
+			report.Excluded++
+			continue
+		}
+
+		freshness := &ClusterFreshness{
+			RuntimeID:   state.Cluster.RuntimeID,
+			Status:      status,
+			LastSuccess: lastSuccess[state.Cluster.RuntimeID],
+		}
+		if status == model.ClusterStatusReady && state.Status.Created.After(freshness.LastSuccess) {
+			freshness.LastSuccess = state.Status.Created
+		}
+		if freshness.LastSuccess.Before(deadline) {
+			freshness.LastSuccess = time.Time{}
+			freshness.Reason = violationReason(now, objective, state.Status)
+		} else {
+			freshness.Compliant = true
+			report.Compliant++
+		}
+		report.Freshness = append(report.Freshness, freshness)
+	}
+
+	report.Clusters = len(report.Freshness)
+	if report.Clusters > 0 {
+		report.Compliance = float64(report.Compliant) * 100 / float64(report.Clusters)
+	}
+	sort.Slice(report.Freshness, func(i, j int) bool {
+		return report.Freshness[i].RuntimeID < report.Freshness[j].RuntimeID
+	})
+	return report
+}
+
+// violationReason explains why a cluster wasn't reconciled successfully within the objective
+func violationReason(now time.Time, objective time.Duration, status *model.ClusterStatusEntity) string {
+	since := now.Sub(status.Created).Truncate(time.Second)
+	var cause string
+	switch status.Status {
+	case model.ClusterStatusReconcilePending:
+		cause = fmt.Sprintf("reconciliation is waiting to be processed since %s", since)
+	case model.ClusterStatusReconciling:
+		cause = fmt.Sprintf("reconciliation is running since %s", since)
+	case model.ClusterStatusReconcileError, model.ClusterStatusReconcileErrorRetryable:
+		cause = fmt.Sprintf("latest reconciliation failed %s ago (status '%s')", since, status.Status)
+	case model.ClusterStatusReady:
+		cause = fmt.Sprintf("cluster wasn't reconciled since it became ready %s ago", since)
+	default:
+		cause = fmt.Sprintf("cluster is in status '%s' since %s", status.Status, since)
+	}
+	return fmt.Sprintf("no successful reconciliation within %s: %s", objective, cause)
+}
@@ -0,0 +1,78 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+func newFreshnessState(runtimeID string, status model.Status, since time.Time) *cluster.State {
+	return &cluster.State{
+		Cluster: &model.ClusterEntity{RuntimeID: runtimeID},
+		Status:  &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: status, Created: since},
+	}
+}
+
+func TestNewFreshnessReport(t *testing.T) {
+	now := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	objective := 6 * time.Hour
+
+	states := []*cluster.State{
+		newFreshnessState("ready", model.ClusterStatusReady, now.Add(-time.Hour)),
+		newFreshnessState("reconciling", model.ClusterStatusReconciling, now.Add(-10*time.Minute)),
+		newFreshnessState("stale", model.ClusterStatusReady, now.Add(-7*time.Hour)),
+		newFreshnessState("failing", model.ClusterStatusReconcileError, now.Add(-2*time.Hour)),
+		newFreshnessState("stuck", model.ClusterStatusReconciling, now.Add(-8*time.Hour)),
+		newFreshnessState("disabled", model.ClusterStatusReconcileDisabled, now.Add(-48*time.Hour)),
+		newFreshnessState("deleted", model.ClusterStatusDeleted, now.Add(-48*time.Hour)),
+	}
+	recons := []*model.ReconciliationEntity{
+		{RuntimeID: "reconciling", Finished: true, Status: model.ClusterStatusReady, Updated: now.Add(-3 * time.Hour)},
+		{RuntimeID: "failing", Finished: true, Status: model.ClusterStatusReady, Updated: now.Add(-9 * time.Hour)},
+		{RuntimeID: "failing", Finished: true, Status: model.ClusterStatusReconcileError, Updated: now.Add(-2 * time.Hour)},
+		{RuntimeID: "stuck", Finished: false, Status: model.ClusterStatusReconciling, Updated: now.Add(-time.Hour)},
+	}
+
+	report := NewFreshnessReport(now, objective, states, recons)
+	require.Equal(t, 5, report.Clusters)
+	require.Equal(t, 2, report.Compliant)
+	require.Equal(t, 1, report.Excluded)
+	require.InDelta(t, 40.0, report.Compliance, 0.01)
+
+	require.Len(t, report.Freshness, 5)
+	require.Equal(t, &ClusterFreshness{
+		RuntimeID: "ready", Status: model.ClusterStatusReady, LastSuccess: now.Add(-time.Hour), Compliant: true,
+	}, report.Freshness[1])
+	require.Equal(t, &ClusterFreshness{
+		RuntimeID: "reconciling", Status: model.ClusterStatusReconciling, LastSuccess: now.Add(-3 * time.Hour), Compliant: true,
+	}, report.Freshness[2])
+
+	violations := report.Violations()
+	require.Len(t, violations, 3)
+	require.Equal(t, "failing", violations[0].RuntimeID)
+	require.True(t, violations[0].LastSuccess.IsZero())
+	require.Equal(t, "no successful reconciliation within 6h0m0s: latest reconciliation failed 2h0m0s ago (status 'error')",
+		violations[0].Reason)
+	require.Equal(t, "stale", violations[1].RuntimeID)
+	require.Contains(t, violations[1].Reason, "cluster wasn't reconciled since it became ready 7h0m0s ago")
+	require.Equal(t, "stuck", violations[2].RuntimeID)
+	require.Contains(t, violations[2].Reason, "reconciliation is running since 8h0m0s")
+}
+
+func TestGeneratorFreshness(t *testing.T) {
+	generator := NewGenerator(reconciliation.NewInMemoryReconciliationRepository())
+
+	_, err := generator.Freshness(nil, 0)
+	require.Error(t, err)
+
+	report, err := generator.Freshness([]*cluster.State{
+		newFreshnessState("ready", model.ClusterStatusReady, time.Now().Add(-time.Minute)),
+	}, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Compliant)
+	require.Equal(t, 100.0, report.Compliance)
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+)
+
+const DefaultTopFailures = 10
+
+// FleetReport aggregates the reconciliations and operations which were created within a time window
+type FleetReport struct {
+	From                time.Time
+	To                  time.Time
+	Reconciliations     int
+	Succeeded           int
+	Failed              int
+	InProgress          int
+	SuccessRate         float64       //percentage of finished reconciliations which succeeded
+	MeanTimeToReconcile time.Duration //mean duration of finished reconciliations
+	TopFailures         []*ComponentFailures
+}
+
+// ComponentFailures summarizes the failed operations of a component
+type ComponentFailures struct {
+	Component    string
+	Failures     int
+	Clusters     int //amount of affected clusters
+	LatestReason string
+	latest       time.Time
+}
+
+type Generator struct {
+	repo reconciliation.Repository
+}
+
+func NewGenerator(repo reconciliation.Repository) *Generator {
+	return &Generator{repo: repo}
+}
+
+// Fleet creates a report of the reconciliations created between from and to. The failure top-list contains the
+// components with most failed operations (limited to topFailures entries).
+func (g *Generator) Fleet(from, to time.Time, topFailures int) (*FleetReport, error) {
+	if !from.Before(to) {
+		return nil, fmt.Errorf("begin of report window (%s) has to be before its end (%s)",
+			from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	recons, err := g.repo.GetReconciliations(&reconciliation.FilterMixer{Filters: []reconciliation.Filter{
+		&reconciliation.WithCreationDateAfter{Time: from},
+		&reconciliation.WithCreationDateBefore{Time: to},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	ops, err := g.repo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+		&operation.WithCreationDateAfter{Time: from},
+		&operation.WithCreationDateBefore{Time: to},
+		&operation.WithStates{States: []model.OperationState{
+			model.OperationStateError,
+			model.OperationStateVerificationFailed,
+		}},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return NewFleetReport(from, to, recons, ops, topFailures), nil
+}
+
+// NewFleetReport aggregates the reconciliations and the failed operations of a time window
+func NewFleetReport(from, to time.Time, recons []*model.ReconciliationEntity, failedOps []*model.OperationEntity, topFailures int) *FleetReport {
+	report := &FleetReport{
+		From:            from,
+		To:              to,
+		Reconciliations: len(recons),
+	}
+
+	var totalDuration time.Duration
+	for _, recon := range recons {
+		if !recon.Finished {
+			report.InProgress++
+			continue
+		}
+		if recon.Status.IsFinalStable() {
+			report.Succeeded++
+		} else {
+			report.Failed++
+		}
+		totalDuration += recon.Updated.Sub(recon.Created)
+	}
+	if finished := report.Succeeded + report.Failed; finished > 0 {
+		report.SuccessRate = float64(report.Succeeded) * 100 / float64(finished)
+		report.MeanTimeToReconcile = (totalDuration / time.Duration(finished)).Truncate(time.Millisecond)
+	}
+
+	report.TopFailures = componentFailures(failedOps, topFailures)
+	return report
+}
+
+func componentFailures(failedOps []*model.OperationEntity, limit int) []*ComponentFailures {
+	byComponent := make(map[string]*ComponentFailures)
+	clusters := make(map[string]map[string]bool) //key1: component, key2: runtimeID
+	for _, op := range failedOps {
+		failures, ok := byComponent[op.Component]
+		if !ok {
+			failures = &ComponentFailures{Component: op.Component}
+			byComponent[op.Component] = failures
+			clusters[op.Component] = make(map[string]bool)
+		}
+		failures.Failures++
+		clusters[op.Component][op.RuntimeID] = true
+		if updated := lastChange(op); !updated.Before(failures.latest) {
+			failures.latest = updated
+			failures.LatestReason = op.Reason
+		}
+	}
+
+	result := make([]*ComponentFailures, 0, len(byComponent))
+	for component, failures := range byComponent {
+		failures.Clusters = len(clusters[component])
+		result = append(result, failures)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Failures != result[j].Failures {
+			return result[i].Failures > result[j].Failures
+		}
+		return result[i].Component < result[j].Component
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func lastChange(op *model.OperationEntity) time.Time {
+	if op.Updated.IsZero() {
+		return op.Created
+	}
+	return op.Updated
+}
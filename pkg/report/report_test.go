@@ -0,0 +1,97 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFleetReport(t *testing.T) {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("Aggregate reconciliations and failures", func(t *testing.T) {
+		recons := []*model.ReconciliationEntity{
+			{Finished: true, Status: model.ClusterStatusReady, Created: from, Updated: from.Add(1 * time.Minute)},
+			{Finished: true, Status: model.ClusterStatusDeleted, Created: from, Updated: from.Add(2 * time.Minute)},
+			{Finished: true, Status: model.ClusterStatusReconcileError, Created: from, Updated: from.Add(3 * time.Minute)},
+			{Finished: false, Status: model.ClusterStatusReconciling, Created: from},
+		}
+		failedOps := []*model.OperationEntity{
+			{Component: "istio", RuntimeID: "runtime1", Reason: "old reason", Created: from, Updated: from.Add(time.Minute)},
+			{Component: "istio", RuntimeID: "runtime1", Reason: "latest reason", Created: from.Add(time.Hour)},
+			{Component: "istio", RuntimeID: "runtime2", Reason: "other reason", Created: from, Updated: from.Add(2 * time.Minute)},
+			{Component: "logging", RuntimeID: "runtime1", Reason: "logging failed", Created: from},
+			{Component: "cluster-essentials", RuntimeID: "runtime3", Reason: "essentials failed", Created: from},
+		}
+
+		report := NewFleetReport(from, to, recons, failedOps, 2)
+		require.Equal(t, from, report.From)
+		require.Equal(t, to, report.To)
+		require.Equal(t, 4, report.Reconciliations)
+		require.Equal(t, 2, report.Succeeded)
+		require.Equal(t, 1, report.Failed)
+		require.Equal(t, 1, report.InProgress)
+		require.InDelta(t, 66.66, report.SuccessRate, 0.01)
+		require.Equal(t, 2*time.Minute, report.MeanTimeToReconcile)
+		require.Equal(t, []*ComponentFailures{
+			{Component: "istio", Failures: 3, Clusters: 2, LatestReason: "latest reason", latest: from.Add(time.Hour)},
+			{Component: "cluster-essentials", Failures: 1, Clusters: 1, LatestReason: "essentials failed", latest: from},
+		}, report.TopFailures)
+	})
+
+	t.Run("Empty time window", func(t *testing.T) {
+		report := NewFleetReport(from, to, nil, nil, DefaultTopFailures)
+		require.Zero(t, report.Reconciliations)
+		require.Zero(t, report.SuccessRate)
+		require.Zero(t, report.MeanTimeToReconcile)
+		require.Empty(t, report.TopFailures)
+	})
+}
+
+func TestGeneratorFleet(t *testing.T) {
+	generator := NewGenerator(reconciliation.NewInMemoryReconciliationRepository())
+
+	t.Run("Reject invalid time window", func(t *testing.T) {
+		now := time.Now()
+		_, err := generator.Fleet(now, now.Add(-time.Hour), DefaultTopFailures)
+		require.Error(t, err)
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	var buffer bytes.Buffer
+	err := WriteCSV(&buffer, &keb.HTTPFleetReport{
+		From:                from,
+		To:                  from.Add(24 * time.Hour),
+		Reconciliations:     4,
+		Succeeded:           2,
+		Failed:              1,
+		InProgress:          1,
+		SuccessRate:         66.666,
+		MeanTimeToReconcile: 120000,
+		TopFailures: []keb.ComponentFailures{
+			{Component: "istio", Failures: 3, Clusters: 2, LatestReason: "timeout, retrying"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `metric,value
+from,2022-03-01T00:00:00Z
+to,2022-03-02T00:00:00Z
+reconciliations,4
+succeeded,2
+failed,1
+inProgress,1
+successRate,66.67
+meanTimeToReconcile,120000
+
+component,failures,clusters,latestReason
+istio,3,2,"timeout, retrying"
+`, buffer.String())
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/pkg/errors"
 )
 
@@ -18,18 +19,62 @@ type ComponentReconciler struct {
 	URL string
 }
 
+// UpgradePathConfig defines the rules a Kyma upgrade of a cluster has to comply with
+type UpgradePathConfig struct {
+	AllowMajorSkip   bool     //accept upgrades which skip major versions (e.g. from 1.x to 3.x)
+	RequiredVersions []string //versions which have to be installed before a cluster can be upgraded beyond them
+}
+
 type SchedulerConfig struct {
-	PreComponents  [][]string
-	Reconcilers    map[string]ComponentReconciler
-	DeleteStrategy string
-	ComponentCRDs  map[string]ComponentCRD
+	PreComponents [][]string
+	//BootstrapComponents are the cluster essentials (e.g. CRDs or cert-manager) which have to be reconciled
+	//successfully before any other component is scheduled
+	BootstrapComponents []string
+	Reconcilers         map[string]ComponentReconciler
+	DeleteStrategy      string
+	ComponentCRDs       map[string]ComponentCRD
+	UpgradePath         UpgradePathConfig
+}
+
+// ComponentHealthCheck defines the resources the status probe verifies to determine the health of a component
+type ComponentHealthCheck struct {
+	Deployments     []DeploymentCheck     //Deployments which have to be available
+	CustomResources []CustomResourceCheck //custom resources which have to report that they are ready
+}
+
+type DeploymentCheck struct {
+	Namespace string
+	Name      string
+}
+
+// CustomResourceCheck expects a custom resource to have a condition of the given type with status 'True' or, if
+// no condition is defined, the given value in the field 'status.state' (e.g. 'Ready' of Kyma operators)
+type CustomResourceCheck struct {
+	Group     string
+	Version   string
+	Resource  string //plural name of the custom resource (e.g. 'kymas')
+	Namespace string //empty for cluster-scoped resources
+	Name      string
+	Condition string
+	State     string
 }
 
 type Config struct {
-	Scheme    string
-	Host      string
-	Port      int
-	Scheduler SchedulerConfig
+	Scheme     string
+	Host       string
+	Port       int
+	Scheduler  SchedulerConfig
+	Reloadable ReloadableConfig
+	Health     map[string]ComponentHealthCheck //health checks of components (key: component name)
+}
+
+// ReconcilerName returns the name of the component reconciler which is responsible for the component: the dedicated
+// reconciler of the component or the fallback reconciler if no dedicated reconciler is configured
+func (c *SchedulerConfig) ReconcilerName(component string) string {
+	if _, ok := c.Reconcilers[component]; ok {
+		return component
+	}
+	return FallbackComponentReconciler
 }
 
 func (c *Config) Validate() error {
@@ -45,5 +90,32 @@ func (c *Config) Validate() error {
 	if len(c.Scheduler.Reconcilers) == 0 {
 		return errors.New("reconciler mapping for mothership scheduler is not configured")
 	}
+	for _, version := range c.Scheduler.UpgradePath.RequiredVersions {
+		if _, err := semver.NewVersion(version); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("required upgrade version '%s' is not a semantic version", version))
+		}
+	}
+	for component, check := range c.Health {
+		if err := check.validate(); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("health check of component '%s' is invalid", component))
+		}
+	}
+	return c.Reloadable.Validate()
+}
+
+func (c *ComponentHealthCheck) validate() error {
+	for _, deployment := range c.Deployments {
+		if deployment.Namespace == "" || deployment.Name == "" {
+			return errors.New("namespace and name of a deployment are required")
+		}
+	}
+	for _, cr := range c.CustomResources {
+		if cr.Version == "" || cr.Resource == "" || cr.Name == "" {
+			return errors.New("version, resource and name of a custom resource are required")
+		}
+		if cr.Condition == "" && cr.State == "" {
+			return fmt.Errorf("custom resource '%s' requires either a condition or a state", cr.Name)
+		}
+	}
 	return nil
 }
@@ -19,3 +19,41 @@ func TestConfig(t *testing.T) {
 	require.NoError(t, viper.UnmarshalKey("mothership", cfg))
 	require.NotEmpty(t, cfg.Scheduler.Reconcilers[FallbackComponentReconciler])
 }
+
+func TestReconcilerName(t *testing.T) {
+	cfg := &SchedulerConfig{
+		Reconcilers: map[string]ComponentReconciler{
+			FallbackComponentReconciler: {URL: "http://base"},
+			"istio":                     {URL: "http://istio"},
+		},
+	}
+	require.Equal(t, "istio", cfg.ReconcilerName("istio"))
+	require.Equal(t, FallbackComponentReconciler, cfg.ReconcilerName("monitoring"))
+}
+
+func TestHealthCheckValidation(t *testing.T) {
+	cfg := &Config{
+		Scheme: "http",
+		Host:   "localhost",
+		Port:   8080,
+		Scheduler: SchedulerConfig{
+			Reconcilers: map[string]ComponentReconciler{FallbackComponentReconciler: {URL: "http://base"}},
+		},
+		Health: map[string]ComponentHealthCheck{
+			"istio": {Deployments: []DeploymentCheck{{Namespace: "istio-system", Name: "istiod"}}},
+			"keda": {CustomResources: []CustomResourceCheck{{
+				Group: "operator.kyma-project.io", Version: "v1alpha1", Resource: "kedas", Name: "default", State: "Ready",
+			}}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Health["istio"] = ComponentHealthCheck{Deployments: []DeploymentCheck{{Name: "istiod"}}}
+	require.Error(t, cfg.Validate())
+
+	delete(cfg.Health, "istio")
+	cfg.Health["keda"] = ComponentHealthCheck{CustomResources: []CustomResourceCheck{{
+		Version: "v1alpha1", Resource: "kedas", Name: "default",
+	}}}
+	require.Error(t, cfg.Validate())
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+// ReloadableConfig contains the settings of the mothership which are applied at runtime whenever the configuration
+// file changes. Undefined settings keep the values passed by command line flags.
+type ReloadableConfig struct {
+	LogLevel               string
+	ReconcileInterval      time.Duration
+	ReconcileJitter        *float64
+	MaxOperationsPerMinute *int
+	Workers                int
+	InvokerMaxRetries      int
+	InvokerRetryDelay      time.Duration
+}
+
+func (c *ReloadableConfig) Validate() error {
+	if c.LogLevel != "" {
+		if err := logger.ValidateLevel(c.LogLevel); err != nil {
+			return err
+		}
+	}
+	if c.ReconcileInterval < 0 {
+		return errors.New("cluster reconciliation interval cannot be < 0")
+	}
+	if c.ReconcileJitter != nil && (*c.ReconcileJitter < 0 || *c.ReconcileJitter > 1) {
+		return errors.New("cluster reconciliation jitter has to be within 0 and 1")
+	}
+	if c.MaxOperationsPerMinute != nil && *c.MaxOperationsPerMinute < 0 {
+		return errors.New("maximal operations per minute cannot be < 0")
+	}
+	if c.Workers < 0 {
+		return errors.New("amount of workers cannot be < 0")
+	}
+	if c.InvokerMaxRetries < 0 {
+		return errors.New("invoker retries cannot be < 0")
+	}
+	if c.InvokerRetryDelay < 0 {
+		return errors.New("invoker retry delay cannot be < 0")
+	}
+	return nil
+}
+
+// Changes returns the settings which differ from the previous configuration in the format '<setting>=<new value>'.
+// Settings which are no longer defined are reported with the value '<unset>'.
+func (c *ReloadableConfig) Changes(previous *ReloadableConfig) []string {
+	if previous == nil {
+		previous = &ReloadableConfig{}
+	}
+	var changes []string
+	addChange := func(setting, value, previousValue string) {
+		if value == previousValue {
+			return
+		}
+		if value == "" {
+			value = "<unset>"
+		}
+		changes = append(changes, fmt.Sprintf("%s=%s", setting, value))
+	}
+	addChange("logLevel", c.LogLevel, previous.LogLevel)
+	addChange("reconcileInterval", durationString(c.ReconcileInterval), durationString(previous.ReconcileInterval))
+	addChange("reconcileJitter", floatString(c.ReconcileJitter), floatString(previous.ReconcileJitter))
+	addChange("maxOperationsPerMinute", intString(c.MaxOperationsPerMinute), intString(previous.MaxOperationsPerMinute))
+	addChange("workers", countString(c.Workers), countString(previous.Workers))
+	addChange("invokerMaxRetries", countString(c.InvokerMaxRetries), countString(previous.InvokerMaxRetries))
+	addChange("invokerRetryDelay", durationString(c.InvokerRetryDelay), durationString(previous.InvokerRetryDelay))
+	sort.Strings(changes)
+	return changes
+}
+
+func durationString(value time.Duration) string {
+	if value == 0 {
+		return ""
+	}
+	return value.String()
+}
+
+func countString(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value)
+}
+
+func floatString(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func intString(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadableConfig(t *testing.T) {
+	jitter := 0.2
+	invalidJitter := 1.5
+	maxOps := 100
+
+	t.Run("Validate", func(t *testing.T) {
+		require.NoError(t, (&ReloadableConfig{}).Validate())
+		require.NoError(t, (&ReloadableConfig{
+			LogLevel:               "debug",
+			ReconcileInterval:      5 * time.Minute,
+			ReconcileJitter:        &jitter,
+			MaxOperationsPerMinute: &maxOps,
+			Workers:                10,
+		}).Validate())
+		require.Error(t, (&ReloadableConfig{LogLevel: "verbose"}).Validate())
+		require.Error(t, (&ReloadableConfig{ReconcileJitter: &invalidJitter}).Validate())
+		require.Error(t, (&ReloadableConfig{Workers: -1}).Validate())
+	})
+
+	t.Run("Changes", func(t *testing.T) {
+		previous := &ReloadableConfig{
+			LogLevel:          "info",
+			ReconcileInterval: 5 * time.Minute,
+			Workers:           10,
+		}
+		current := &ReloadableConfig{
+			ReconcileInterval: 5 * time.Minute,
+			ReconcileJitter:   &jitter,
+			Workers:           20,
+		}
+		require.Equal(t, []string{"logLevel=<unset>", "reconcileJitter=0.2", "workers=20"}, current.Changes(previous))
+		require.Empty(t, current.Changes(current))
+	})
+}
@@ -0,0 +1,136 @@
+package invoker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"go.uber.org/zap"
+)
+
+const (
+	capabilitiesTTL = 5 * time.Minute
+	//capabilities of unreachable reconcilers are retrieved again sooner
+	capabilitiesErrorTTL = 30 * time.Second
+	capabilitiesTimeout  = 5 * time.Second
+)
+
+// runURLPattern matches the URL of the run API of a component reconciler, e.g. 'http://istio:8080/v1/run'
+var runURLPattern = regexp.MustCompile(`^(.*)/v\d+/run/?$`)
+
+// legacyCapabilities are assumed for reconcilers which don't provide the capabilities endpoint
+var legacyCapabilities = &reconciler.HTTPCapabilitiesResponse{}
+
+type capabilitiesCacheEntry struct {
+	capabilities *reconciler.HTTPCapabilitiesResponse
+	expires      time.Time
+}
+
+// capabilityResolver retrieves the capabilities of component reconcilers and caches them per run URL.
+// The cache expires quickly because the replicas behind a URL can change their version during a rolling upgrade.
+type capabilityResolver struct {
+	client *http.Client
+	logger *zap.SugaredLogger
+	mu     sync.Mutex
+	cache  map[string]*capabilitiesCacheEntry
+}
+
+func newCapabilityResolver(logger *zap.SugaredLogger) *capabilityResolver {
+	return &capabilityResolver{
+		client: httpclient.NewWithTimeout(capabilitiesTimeout),
+		logger: logger,
+		cache:  make(map[string]*capabilitiesCacheEntry),
+	}
+}
+
+// Get returns the capabilities of the component reconciler reachable via the run URL. Reconcilers which
+// don't provide capabilities are treated as legacy reconcilers supporting only contract version v1.
+func (r *capabilityResolver) Get(runURL string) *reconciler.HTTPCapabilitiesResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.cache[runURL]; ok && time.Now().Before(entry.expires) {
+		return entry.capabilities
+	}
+
+	capabilities, err := r.fetch(runURL)
+	ttl := capabilitiesTTL
+	if err != nil {
+		r.logger.Warnf("Failed to retrieve capabilities of component reconciler '%s' "+
+			"(falling back to contract version v1): %s", runURL, err)
+		capabilities = legacyCapabilities
+		ttl = capabilitiesErrorTTL
+	}
+	r.cache[runURL] = &capabilitiesCacheEntry{
+		capabilities: capabilities,
+		expires:      time.Now().Add(ttl),
+	}
+	return capabilities
+}
+
+// Downgrade treats the component reconciler as legacy reconciler until its capabilities expire. It is used if a
+// replica rejected a request which was based on capabilities announced by another replica.
+func (r *capabilityResolver) Downgrade(runURL string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache[runURL] = &capabilitiesCacheEntry{
+		capabilities: legacyCapabilities,
+		expires:      time.Now().Add(capabilitiesErrorTTL),
+	}
+}
+
+func (r *capabilityResolver) fetch(runURL string) (*reconciler.HTTPCapabilitiesResponse, error) {
+	capabilitiesURL, ok := contractURL(runURL, "1", "capabilities")
+	if !ok {
+		r.logger.Debugf("URL '%s' is not a run URL of a component reconciler: assuming legacy reconciler", runURL)
+		return legacyCapabilities, nil
+	}
+
+	resp, err := r.client.Get(capabilitiesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.logger.Warnf("Error while closing HTTP response body: %s", err)
+		}
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+		r.logger.Infof("Component reconciler '%s' provides no capabilities: assuming legacy reconciler", runURL)
+		return legacyCapabilities, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("capabilities endpoint '%s' responded with status code %d",
+			capabilitiesURL, resp.StatusCode)
+	}
+
+	capabilities := &reconciler.HTTPCapabilitiesResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(capabilities); err != nil {
+		return nil, err
+	}
+	r.logger.Debugf("Component reconciler '%s' has version '%s' and supports: %v",
+		runURL, capabilities.Version, capabilities.Capabilities)
+	return capabilities, nil
+}
+
+// contractURL replaces the contract version and endpoint of the run URL of a component reconciler, e.g.
+// contractURL('http://istio:8080/v1/run', '2', 'run') returns 'http://istio:8080/v2/run'
+func contractURL(runURL, version, endpoint string) (string, bool) {
+	u, err := url.Parse(runURL)
+	if err != nil {
+		return "", false
+	}
+	match := runURLPattern.FindStringSubmatch(u.Path)
+	if match == nil {
+		return "", false
+	}
+	u.Path = fmt.Sprintf("%s/v%s/%s", match[1], version, endpoint)
+	return u.String(), true
+}
@@ -0,0 +1,73 @@
+package invoker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractURL(t *testing.T) {
+	testCases := []struct {
+		runURL   string
+		expected string
+		ok       bool
+	}{
+		{runURL: "http://istio:8080/v1/run", expected: "http://istio:8080/v2/run", ok: true},
+		{runURL: "https://host/prefix/v1/run/", expected: "https://host/prefix/v2/run", ok: true},
+		{runURL: "http://istio:8080/run", ok: false},
+		{runURL: "http://istio:8080/v1/loglevel", ok: false},
+	}
+	for _, tc := range testCases {
+		url, ok := contractURL(tc.runURL, "2", "run")
+		require.Equal(t, tc.ok, ok, tc.runURL)
+		require.Equal(t, tc.expected, url, tc.runURL)
+	}
+}
+
+func TestCapabilityResolver(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/new/v1/capabilities" {
+			http.NotFound(w, r)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(&reconciler.HTTPCapabilitiesResponse{
+			Version:      "2.0.0",
+			Capabilities: []reconciler.Capability{reconciler.CapabilityPayloadV2, reconciler.CapabilityDryRun},
+		}))
+	}))
+	defer srv.Close()
+
+	resolver := newCapabilityResolver(logger.NewLogger(true))
+
+	t.Run("Capabilities are cached", func(t *testing.T) {
+		requests = 0
+		for i := 0; i < 3; i++ {
+			capabilities := resolver.Get(srv.URL + "/new/v1/run")
+			require.Equal(t, "2.0.0", capabilities.Version)
+			require.True(t, capabilities.Supports(reconciler.CapabilityPayloadV2))
+		}
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("Legacy reconciler without capabilities endpoint", func(t *testing.T) {
+		capabilities := resolver.Get(srv.URL + "/old/v1/run")
+		require.False(t, capabilities.Supports(reconciler.CapabilityPayloadV2))
+	})
+
+	t.Run("Downgrade reconciler", func(t *testing.T) {
+		resolver.Downgrade(srv.URL + "/new/v1/run")
+		require.False(t, resolver.Get(srv.URL+"/new/v1/run").Supports(reconciler.CapabilityPayloadV2))
+	})
+
+	t.Run("Unreachable reconciler", func(t *testing.T) {
+		capabilities := resolver.Get("http://127.0.0.1:1/v1/run")
+		require.False(t, capabilities.Supports(reconciler.CapabilityPayloadV2))
+	})
+}
@@ -2,6 +2,8 @@ package invoker
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/avast/retry-go"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 )
@@ -14,6 +16,36 @@ func (err *NoFallbackReconcilerDefinedError) Error() string {
 		"check local component reconciler initialization", config.FallbackComponentReconciler)
 }
 
+// ReconcilerOverloadedError is returned if a component reconciler rejected an operation because it has
+// no capacity left (HTTP 429 or 503)
+type ReconcilerOverloadedError struct {
+	Reconciler string
+	StatusCode int
+	RetryAfter time.Duration //delay requested by the component reconciler (zero if undefined)
+}
+
+func (err *ReconcilerOverloadedError) Error() string {
+	return fmt.Sprintf("Component reconciler '%s' is overloaded (HTTP code: %d)", err.Reconciler, err.StatusCode)
+}
+
+func IsReconcilerOverloadedError(err error) bool {
+	return AsReconcilerOverloadedError(err) != nil
+}
+
+// AsReconcilerOverloadedError returns the ReconcilerOverloadedError wrapped by the error or nil
+func AsReconcilerOverloadedError(err error) *ReconcilerOverloadedError {
+	if rErr, isRetryErr := err.(retry.Error); isRetryErr {
+		for _, err := range rErr.WrappedErrors() {
+			if overloadedErr, ok := err.(*ReconcilerOverloadedError); ok {
+				return overloadedErr
+			}
+		}
+		return nil
+	}
+	overloadedErr, _ := err.(*ReconcilerOverloadedError)
+	return overloadedErr
+}
+
 func IsNoFallbackReconcilerDefinedError(err error) bool {
 	var ok bool
 	if rErr, isRetryErr := err.(retry.Error); isRetryErr {
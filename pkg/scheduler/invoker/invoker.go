@@ -2,7 +2,10 @@ package invoker
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"strings"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
@@ -20,9 +23,11 @@ type Params struct {
 	ClusterState         *cluster.State
 	SchedulingID         string
 	CorrelationID        string
+	OperationUpdated     time.Time //last update of the operation when it was dispatched
 	MaxOperationRetries  int
 	Type                 model.OperationType
 	Debug                bool
+	FeatureFlags         []string //names of the feature flags enabled for the cluster
 }
 
 func (p *Params) newLocalTask(callbackFunc func(msg *reconciler.CallbackMessage) error) *reconciler.Task {
@@ -31,12 +36,47 @@ func (p *Params) newLocalTask(callbackFunc func(msg *reconciler.CallbackMessage)
 	return task
 }
 
+// idempotencyKey identifies the dispatch of an operation: all requests sent for the same dispatch (e.g. retries
+// of the worker) carry the same key, so the component reconciler processes them only once. A re-dispatch of the
+// operation (e.g. after the bookkeeper marked it as orphan) updated the operation and gets a new key.
+func (p *Params) idempotencyKey() string {
+	key := sha256.Sum256([]byte(p.SchedulingID + "/" + p.CorrelationID + "/" + p.OperationUpdated.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(key[:])
+}
+
 func (p *Params) newRemoteTask(callbackURL string) *reconciler.Task {
 	task := p.newTask()
 	task.CallbackURL = callbackURL
 	return task
 }
 
+// newRemoteTaskV2 creates the payload for component reconcilers supporting contract version v2
+func (p *Params) newRemoteTaskV2(callbackURL string) *reconciler.TaskV2 {
+	task := p.newRemoteTask(callbackURL)
+	return &reconciler.TaskV2{
+		ComponentsReady:        task.ComponentsReady,
+		Component:              task.Component,
+		Namespace:              task.Namespace,
+		Version:                task.Version,
+		URL:                    task.URL,
+		Profile:                task.Profile,
+		Values:                 task.Configuration, //keys in dot-notation are kept by the reconciler
+		Kubeconfig:             task.Kubeconfig,
+		RuntimeID:              task.RuntimeID,
+		Metadata:               task.Metadata,
+		CallbackURL:            task.CallbackURL,
+		CorrelationID:          task.CorrelationID,
+		Repository:             task.Repository,
+		Type:                   task.Type,
+		ComponentConfiguration: task.ComponentConfiguration,
+		FeatureFlags:           task.FeatureFlags,
+		Components:             task.Components,
+		Options: reconciler.TaskOptions{
+			Priority: string(reconciler.PriorityNormal),
+		},
+	}
+}
+
 func (p *Params) newTask() *reconciler.Task {
 	version := p.ClusterState.Configuration.KymaVersion
 	// version := p.ComponentToReconcile.Version
@@ -56,6 +96,7 @@ func (p *Params) newTask() *reconciler.Task {
 		Profile:         p.ClusterState.Configuration.KymaProfile,
 		Configuration:   p.ComponentToReconcile.ConfigurationAsMap(),
 		Kubeconfig:      p.ClusterState.Cluster.Kubeconfig,
+		RuntimeID:       p.ClusterState.Cluster.RuntimeID,
 		Metadata:        *p.ClusterState.Cluster.Metadata,
 		CorrelationID:   p.CorrelationID,
 		Repository: &reconciler.Repository{
@@ -66,5 +107,19 @@ func (p *Params) newTask() *reconciler.Task {
 			MaxRetries: p.MaxOperationRetries,
 			Debug:      p.Debug,
 		},
+		FeatureFlags: p.FeatureFlags,
+		Components:   p.clusterComponents(),
+	}
+}
+
+// clusterComponents returns the components of the desired state of the cluster
+func (p *Params) clusterComponents() []reconciler.ClusterComponent {
+	result := make([]reconciler.ClusterComponent, 0, len(p.ClusterState.Configuration.Components))
+	for _, component := range p.ClusterState.Configuration.Components {
+		result = append(result, reconciler.ClusterComponent{
+			Name:      component.Component,
+			Namespace: component.Namespace,
+		})
 	}
+	return result
 }
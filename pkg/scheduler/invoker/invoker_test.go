@@ -5,7 +5,11 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInvoker(t *testing.T) {
@@ -30,8 +34,44 @@ func TestInvoker(t *testing.T) {
 		CorrelationID:       "",
 		MaxOperationRetries: 0,
 		Type:                model.OperationTypeDelete,
+		FeatureFlags:        []string{"flag"},
 	}
 
 	task := params.newTask()
 	assert.Equal(t, model.OperationTypeDelete, task.Type, "Task type should equal operation type")
+	assert.True(t, task.FeatureFlagEnabled("flag"))
+	assert.False(t, task.FeatureFlagEnabled("other"))
+	assert.Equal(t, []string{"flag"}, params.newRemoteTaskV2("").FeatureFlags)
+	assert.Len(t, task.Components, 5)
+	assert.Equal(t, reconciler.ClusterComponent{Name: "TestComp1"}, task.Components[0])
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	op1 := &Params{SchedulingID: "scheduling1", CorrelationID: "correlation1"}
+	assert.Equal(t, op1.idempotencyKey(), (&Params{SchedulingID: "scheduling1", CorrelationID: "correlation1"}).idempotencyKey(),
+		"Retries of an operation should use the same key")
+	assert.NotEqual(t, op1.idempotencyKey(), (&Params{SchedulingID: "scheduling1", CorrelationID: "correlation2"}).idempotencyKey())
+	assert.NotEqual(t, op1.idempotencyKey(), (&Params{SchedulingID: "scheduling2", CorrelationID: "correlation1"}).idempotencyKey())
+}
+
+func TestIdempotencyKeyOfOrphanOperation(t *testing.T) {
+	reconRepo := reconciliation.NewInMemoryReconciliationRepository()
+	reconEntity, err := reconRepo.CreateReconciliation(clusterStateMock, &model.ReconciliationSequenceConfig{})
+	require.NoError(t, err)
+	opEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: reconEntity.SchedulingID})
+	require.NoError(t, err)
+	op := opEntities[0]
+
+	paramsOf := func(op *model.OperationEntity) *Params {
+		return &Params{SchedulingID: op.SchedulingID, CorrelationID: op.CorrelationID, OperationUpdated: op.Updated}
+	}
+	dispatch := paramsOf(op)
+	assert.Equal(t, dispatch.idempotencyKey(), paramsOf(op).idempotencyKey(), "Retries of a dispatch should use the same key")
+
+	//bookkeeper marks the operation as orphan and the worker pool dispatches it again
+	require.NoError(t, reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateOrphan, false))
+	orphan, err := reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+	require.NoError(t, err)
+	assert.NotEqual(t, dispatch.idempotencyKey(), paramsOf(orphan).idempotencyKey(),
+		"Re-dispatch of an orphan operation must not replay the response of the first dispatch")
 }
@@ -76,6 +76,16 @@ func (i *LocalReconcilerInvoker) newCallbackFunc(params *Params) func(msg *recon
 			i.statusFunc(params.ComponentToReconcile.Component, msg)
 		}
 
+		if msg.Phases != nil {
+			i.updateOperationPhases(*msg.Phases, params)
+		}
+		if msg.SmokeTests != nil {
+			i.updateOperationSmokeTests(*msg.SmokeTests, params)
+		}
+		if msg.ResourceResults != nil {
+			i.updateOperationResources(*msg.ResourceResults, params)
+		}
+
 		//Mark the operation to be running or in failure state.
 		//Be aware that final states (Done, Error) for an operation will be set by worker
 		//because the worker controls retries etc. The invoker should only set interim states
@@ -89,6 +99,8 @@ func (i *LocalReconcilerInvoker) newCallbackFunc(params *Params) func(msg *recon
 			return i.updateOperationState(msg, params, model.OperationStateError)
 		case reconciler.StatusSuccess:
 			return i.updateOperationState(msg, params, model.OperationStateDone)
+		case reconciler.StatusVerificationFailed:
+			return i.updateOperationState(msg, params, model.OperationStateVerificationFailed)
 		default:
 			i.logger.Debugf("Local invoker reported operation status '%s' but will not propagate "+
 				"it as new state to operation (schedulingID:%s/correlationID:%s)",
@@ -99,6 +111,38 @@ func (i *LocalReconcilerInvoker) newCallbackFunc(params *Params) func(msg *recon
 	}
 }
 
+func (i *LocalReconcilerInvoker) updateOperationPhases(phases []reconciler.OperationPhase, params *Params) {
+	operationPhases, err := reconciliation.NewOperationPhases(phases)
+	if err == nil {
+		err = i.reconRepo.UpdateOperationPhases(params.SchedulingID, params.CorrelationID, operationPhases)
+	}
+	if err != nil {
+		//phases are only informative: don't fail the status update
+		i.logger.Warnf("Local invoker failed to update phases of operation (schedulingID:%s/correlationID:%s): %s",
+			params.SchedulingID, params.CorrelationID, err)
+	}
+}
+
+func (i *LocalReconcilerInvoker) updateOperationSmokeTests(smokeTests []reconciler.SmokeTestResult, params *Params) {
+	err := i.reconRepo.UpdateOperationSmokeTests(params.SchedulingID, params.CorrelationID,
+		reconciliation.NewOperationSmokeTests(smokeTests))
+	if err != nil {
+		//results are only informative: the status reflects whether the smoke tests passed
+		i.logger.Warnf("Local invoker failed to update smoke test results of operation (schedulingID:%s/correlationID:%s): %s",
+			params.SchedulingID, params.CorrelationID, err)
+	}
+}
+
+func (i *LocalReconcilerInvoker) updateOperationResources(resources []reconciler.ResourceResult, params *Params) {
+	err := i.reconRepo.UpdateOperationResources(params.SchedulingID, params.CorrelationID,
+		reconciliation.NewOperationResources(resources))
+	if err != nil {
+		//outcomes are only informative: don't fail the callback
+		i.logger.Warnf("Local invoker failed to update resource outcomes of operation (schedulingID:%s/correlationID:%s): %s",
+			params.SchedulingID, params.CorrelationID, err)
+	}
+}
+
 func (i *LocalReconcilerInvoker) updateOperationState(msg *reconciler.CallbackMessage, params *Params, state model.OperationState) error {
 	errMsg := "Local invoker is updating operation (schedulingID:%s/correlationID:%s) to state '%s'"
 	if msg.Error == "" {
@@ -2,34 +2,45 @@ package invoker
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/kyma-incubator/reconciler/pkg/httpclient"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/server"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
 	"strings"
+	"time"
 )
 
-const callbackURLTemplate = "%s://%s:%d/v1/operations/%s/callback/%s"
+const (
+	callbackURLTemplate = "%s://%s:%d/v1/operations/%s/callback/%s"
+	//smaller payloads are sent uncompressed: compressing them costs more than it saves
+	compressionThresholdBytes = 64 << 10
+)
 
 type RemoteReconcilerInvoker struct {
-	reconRepo reconciliation.Repository
-	config    *config.Config
-	logger    *zap.SugaredLogger
+	reconRepo    reconciliation.Repository
+	config       *config.Config
+	capabilities *capabilityResolver
+	logger       *zap.SugaredLogger
 }
 
 func NewRemoteReconcilerInvoker(reconRepo reconciliation.Repository, cfg *config.Config, logger *zap.SugaredLogger) *RemoteReconcilerInvoker {
 	return &RemoteReconcilerInvoker{
-		reconRepo: reconRepo,
-		config:    cfg,
-		logger:    logger,
+		reconRepo:    reconRepo,
+		config:       cfg,
+		capabilities: newCapabilityResolver(logger),
+		logger:       logger,
 	}
 }
 
@@ -82,7 +93,27 @@ func (i *RemoteReconcilerInvoker) Invoke(_ context.Context, params *Params) erro
 			resp.StatusCode, string(body))
 	}
 
-	return i.updateOperationState(params, model.OperationStateClientError, errorReason)
+	if err := i.updateOperationState(params, model.OperationStateClientError, errorReason); err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		//component-reconciler has no capacity left: let the caller slow down instead of retrying blindly
+		return &ReconcilerOverloadedError{
+			Reconciler: i.config.Scheduler.ReconcilerName(params.ComponentToReconcile.Component),
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+	return nil
+}
+
+// parseRetryAfter supports the delay-seconds format of the Retry-After header and returns zero for other values
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
 }
 
 func (i *RemoteReconcilerInvoker) ensureOperationNotInProgress(params *Params) error {
@@ -106,19 +137,6 @@ func (i *RemoteReconcilerInvoker) reportUnmarshalError(httpCode int, body []byte
 func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Response, error) {
 	component := params.ComponentToReconcile.Component
 
-	callbackURL := fmt.Sprintf(callbackURLTemplate,
-		i.config.Scheme,
-		i.config.Host,
-		i.config.Port,
-		params.SchedulingID,
-		params.CorrelationID)
-	payload := params.newRemoteTask(callbackURL)
-
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal HTTP payload to call reconciler of component '%s': %s", component, err)
-	}
-
 	compRecon, ok := i.config.Scheduler.Reconcilers[component]
 	if ok {
 		i.logger.Debugf("Remote invoker found dedicated reconciler for component '%s'", component)
@@ -133,33 +151,139 @@ func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Respons
 		}
 	}
 
+	callbackURL := fmt.Sprintf(callbackURLTemplate,
+		i.config.Scheme,
+		i.config.Host,
+		i.config.Port,
+		params.SchedulingID,
+		params.CorrelationID)
+
+	//the component reconciler ignores duplicates of the request (e.g. caused by a retry of the worker)
+	idempotencyKey := params.idempotencyKey()
+
+	capabilities := i.capabilities.Get(compRecon.URL)
+	if v2URL, ok := contractURL(compRecon.URL, "2", "run"); ok && capabilities.Supports(reconciler.CapabilityPayloadV2) {
+		resp, err := i.post(v2URL, params.newRemoteTaskV2(callbackURL), params, idempotencyKey,
+			capabilities.Supports(reconciler.CapabilityCompression))
+		if err != nil || !i.isContractRejected(resp) {
+			return resp, err
+		}
+		//replicas of a different version are running (e.g. during a rolling upgrade): use the v1 contract instead
+		i.logger.Infof("Remote invoker: component reconciler '%s' rejected contract version v2 "+
+			"(HTTP code: %d): retrying with contract version v1", compRecon.URL, resp.StatusCode)
+		i.capabilities.Downgrade(compRecon.URL)
+		if err := resp.Body.Close(); err != nil {
+			i.logger.Errorf("Error while closing HTTP response body: %s", err)
+		}
+		return i.post(compRecon.URL, params.newRemoteTask(callbackURL), params, idempotencyKey, false)
+	}
+
+	return i.post(compRecon.URL, params.newRemoteTask(callbackURL), params, idempotencyKey,
+		capabilities.Supports(reconciler.CapabilityCompression))
+}
+
+func (i *RemoteReconcilerInvoker) post(url string, payload interface{}, params *Params, idempotencyKey string, compress bool) (*http.Response, error) {
+	component := params.ComponentToReconcile.Component
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal HTTP payload to call reconciler of component '%s': %s", component, err)
+	}
+
+	i.storePayload(url, payload, jsonPayload, params)
+
 	i.logger.Debugf("Remote invoker is calling remote reconciler via HTTP (URL: %s) "+
 		"for component '%s' (schedulingID:%s/correlationID:%s)",
-		compRecon.URL, params.ComponentToReconcile.Component, params.SchedulingID, params.CorrelationID)
+		url, component, params.SchedulingID, params.CorrelationID)
+
+	var contentEncoding string
+	if compress && len(jsonPayload) > compressionThresholdBytes {
+		//kubeconfigs and configurations of big clusters can have several MB
+		if jsonPayload, err = gzipPayload(jsonPayload); err != nil {
+			return nil, fmt.Errorf("failed to compress HTTP payload to call reconciler of component '%s': %s", component, err)
+		}
+		contentEncoding = "gzip"
+	}
 
-	resp, err := http.Post(compRecon.URL, "application/json", bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	req.Header.Set(server.HeaderIdempotencyKey, idempotencyKey)
+	resp, err := httpclient.Default().Do(req)
 	if err == nil {
 		respDump, err := httputil.DumpResponse(resp, true)
 		if err == nil {
 			i.logger.Debugf("Remote invoker received HTTP response from reconciler of component '%s' with status '%s' [%d] "+
 				"(schedulingID:%s/correlationID:%s): %s",
-				params.ComponentToReconcile.Component, resp.Status, resp.StatusCode,
+				component, resp.Status, resp.StatusCode,
 				params.SchedulingID, params.CorrelationID, string(respDump))
 		} else {
 			i.logger.Warnf("Remote invoker failed to dump HTTP response from component reconciler: %s", err)
 		}
 	} else {
 		i.logger.Warnf("Remote invoker failed to send HTTP request to component reconciler '%s': %s",
-			compRecon.URL, err)
-		return resp, errors.Wrap(err, fmt.Sprintf("failed to call remote reconciler (URL: %s)", compRecon.URL))
+			url, err)
+		return resp, errors.Wrap(err, fmt.Sprintf("failed to call remote reconciler (URL: %s)", url))
 	}
 
 	i.logger.Debugf("Remote invoker triggered reconciliation of component '%s' on remote component reconciler '%s': %d",
-		component, compRecon.URL, resp.StatusCode)
+		component, url, resp.StatusCode)
 
 	return resp, nil
 }
 
+// storePayload keeps the payload sent to the component reconciler to be able to replay the operation. A failure
+// doesn't block the operation.
+func (i *RemoteReconcilerInvoker) storePayload(url string, payload interface{}, jsonPayload []byte, params *Params) {
+	contractVersion := int64(1)
+	if _, ok := payload.(*reconciler.TaskV2); ok {
+		contractVersion = 2
+	}
+	err := i.reconRepo.StoreOperationPayload(params.SchedulingID, params.CorrelationID, &model.OperationPayloadEntity{
+		URL:             url,
+		ContractVersion: contractVersion,
+		Payload:         string(jsonPayload),
+	})
+	if err != nil {
+		i.logger.Warnf("Remote invoker failed to store payload of operation (schedulingID:%s/correlationID:%s): %s",
+			params.SchedulingID, params.CorrelationID, err)
+	}
+}
+
+func gzipPayload(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// isContractRejected returns true if the component reconciler doesn't support the contract version of the request.
+// The response body stays readable if the request was not rejected.
+func (i *RemoteReconcilerInvoker) isContractRejected(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusNotFound {
+		return true
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		i.logger.Errorf("Error while closing HTTP response body: %s", closeErr)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && strings.Contains(string(body), "contract version")
+}
+
 func (i *RemoteReconcilerInvoker) unmarshalHTTPResponse(body []byte, respModel interface{}, params *Params) error {
 	if err := json.Unmarshal(body, respModel); err != nil {
 		i.logger.Errorf("Remote invoker failed to unmarshal HTTP response of reconciler for component '%s': %s",
@@ -170,18 +294,12 @@ func (i *RemoteReconcilerInvoker) unmarshalHTTPResponse(body []byte, respModel i
 }
 
 func (i *RemoteReconcilerInvoker) updateOperationState(params *Params, state model.OperationState, reasons ...string) error {
+	//the pickedUp timestamp is set by the repository when the operation changes to InProgress
 	err := i.reconRepo.UpdateOperationState(params.SchedulingID, params.CorrelationID, state, true, strings.Join(reasons, ", "))
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("remote invoker failed to update operation "+
 			"(schedulingID:%s/correlationID:%s) to state '%s'", params.SchedulingID, params.CorrelationID, state))
 	}
-	if state == model.OperationStateInProgress { // Only update the pickedUp timestamp if the new state is InProgress
-		err = i.reconRepo.UpdateOperationPickedUp(params.SchedulingID, params.CorrelationID)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("remote invoker failed to update pickedUp timestamp of operation "+
-				"(schedulingID:%s/correlationID:%s)", params.SchedulingID, params.CorrelationID))
-		}
-	}
 	return nil
 }
 
@@ -97,6 +97,41 @@ func TestRemoteInvoker(t *testing.T) {
 		require.NoError(t, err)
 
 		requireOperationState(t, reconRepo, opEntities[2], model.OperationStateInProgress)
+
+		//sent payload is kept to replay the operation
+		payload, err := reconRepo.GetOperationPayload(opEntities[2].SchedulingID, opEntities[2].CorrelationID)
+		require.NoError(t, err)
+		require.Equal(t, "http://127.0.0.1:5555/200", payload.URL)
+		require.Equal(t, int64(1), payload.ContractVersion)
+		task := &reconciler.Task{}
+		require.NoError(t, json.Unmarshal([]byte(payload.Payload), task))
+		require.Equal(t, opEntities[2].CorrelationID, task.CorrelationID)
+	})
+
+	t.Run("Invoke component-reconciler: fallback to contract version v1 if a replica rejects v2", func(t *testing.T) {
+		cfg := &config.Config{
+			Scheme: "https",
+			Host:   "mothership-reconciler",
+			Port:   443,
+			Scheduler: config.SchedulerConfig{
+				PreComponents: nil,
+				Reconcilers: map[string]config.ComponentReconciler{
+					"base": {
+						URL: "http://127.0.0.1:5555/mixed/v1/run",
+					},
+				},
+			},
+		}
+		err := invokeRemoteInvoker(reconRepo, opEntities[2], cfg)
+		require.NoError(t, err)
+
+		requireOperationState(t, reconRepo, opEntities[2], model.OperationStateInProgress)
+
+		//payload of the rejected v2 request is replaced by the accepted v1 payload
+		payload, err := reconRepo.GetOperationPayload(opEntities[2].SchedulingID, opEntities[2].CorrelationID)
+		require.NoError(t, err)
+		require.Equal(t, "http://127.0.0.1:5555/mixed/v1/run", payload.URL)
+		require.Equal(t, int64(1), payload.ContractVersion)
 	})
 
 	t.Run("Invoke component-reconciler: return 400 error", func(t *testing.T) {
@@ -158,6 +193,32 @@ func TestRemoteInvoker(t *testing.T) {
 
 		requireOperationState(t, reconRepo, opEntities[5], model.OperationStateClientError)
 	})
+
+	t.Run("Invoke component-reconciler: return 429 error because reconciler is overloaded", func(t *testing.T) {
+		cfg := &config.Config{
+			Scheme: "https",
+			Host:   "mothership-reconciler",
+			Port:   443,
+			Scheduler: config.SchedulerConfig{
+				PreComponents: nil,
+				Reconcilers: map[string]config.ComponentReconciler{
+					"base": {
+						URL: "http://127.0.0.1:5555/429",
+					},
+				},
+			},
+		}
+		err := invokeRemoteInvoker(reconRepo, opEntities[3], cfg)
+		require.Error(t, err)
+		require.True(t, IsReconcilerOverloadedError(err))
+		require.Equal(t, &ReconcilerOverloadedError{
+			Reconciler: "base",
+			StatusCode: http.StatusTooManyRequests,
+			RetryAfter: 20 * time.Second,
+		}, AsReconcilerOverloadedError(err))
+
+		requireOperationState(t, reconRepo, opEntities[3], model.OperationStateClientError)
+	})
 }
 
 func invokeRemoteInvoker(reconRepo reconciliation.Repository, op *model.OperationEntity, cfg *config.Config) error {
@@ -202,6 +263,36 @@ func startServer(ctx context.Context, t *testing.T) {
 			}).
 			Methods("PUT", "POST")
 
+		//replicas of different versions: capabilities are announced by a new replica but v2 is rejected by an old one
+		router.HandleFunc(
+			"/mixed/v1/capabilities",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-type", "application/json")
+				require.NoError(t, json.NewEncoder(w).Encode(&reconciler.HTTPCapabilitiesResponse{
+					Version:      "2.0.0",
+					Capabilities: []reconciler.Capability{reconciler.CapabilityPayloadV2},
+				}))
+			}).
+			Methods("GET")
+		router.HandleFunc(
+			"/mixed/v2/run",
+			func(w http.ResponseWriter, r *http.Request) {
+				server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+					Error: "contract version '2' is not supported",
+				})
+			}).
+			Methods("PUT", "POST")
+		router.HandleFunc(
+			"/mixed/v1/run",
+			func(w http.ResponseWriter, r *http.Request) {
+				task := &reconciler.Task{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(task))
+				require.Equal(t, model.CRDComponent, task.Component)
+				w.Header().Set("content-type", "application/json")
+				require.NoError(t, json.NewEncoder(w).Encode(&reconciler.HTTPReconciliationResponse{}))
+			}).
+			Methods("PUT", "POST")
+
 		router.HandleFunc(
 			"/400",
 			func(w http.ResponseWriter, r *http.Request) {
@@ -222,6 +313,17 @@ func startServer(ctx context.Context, t *testing.T) {
 			}).
 			Methods("PUT", "POST")
 
+		router.HandleFunc(
+			"/429",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-type", "application/json")
+				w.Header().Set("Retry-After", "20")
+				server.SendHTTPError(w, http.StatusTooManyRequests, &reconciler.HTTPErrorResponse{
+					Error: "worker pool has reached its capacity",
+				})
+			}).
+			Methods("PUT", "POST")
+
 		router.HandleFunc(
 			"/500bad",
 			func(w http.ResponseWriter, r *http.Request) {
@@ -58,7 +58,8 @@ func requireOperationState(t *testing.T, reconRepo reconciliation.Repository, op
 	require.Equal(t, state, opUpdated.State)
 	if opUpdated.State == model.OperationStateFailed ||
 		opUpdated.State == model.OperationStateError ||
-		opUpdated.State == model.OperationStateClientError {
+		opUpdated.State == model.OperationStateClientError ||
+		opUpdated.State == model.OperationStateVerificationFailed {
 		require.NotEmpty(t, opUpdated.Reason)
 	} else {
 		require.Empty(t, opUpdated.Reason)
@@ -1,10 +1,13 @@
 package occupancy
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/redis"
 	"github.com/kyma-incubator/reconciler/pkg/test"
 	"github.com/stretchr/testify/require"
+	"os"
 	"sync"
 	"testing"
 )
@@ -171,11 +174,16 @@ func TestOccupancyRepository(t *testing.T) {
 		},
 	}
 
-	occupancyRepo := newPersistentRepository(t)
-	for _, tc := range testCases {
-		unitTestSetup(t, occupancyRepo, occupancies)
-		t.Run(tc.name, newTestFct(tc, occupancyRepo))
-		testCleanUp(t, occupancyRepo)
+	repos := map[string]Repository{"persistent": newPersistentRepository(t)}
+	if redisRepo := newRedisRepository(t); redisRepo != nil {
+		repos["redis"] = redisRepo
+	}
+	for repoName, occupancyRepo := range repos {
+		for _, tc := range testCases {
+			unitTestSetup(t, occupancyRepo, occupancies)
+			t.Run(fmt.Sprintf("%s: %s", repoName, tc.name), newTestFct(tc, occupancyRepo))
+			testCleanUp(t, occupancyRepo)
+		}
 	}
 
 }
@@ -215,3 +223,18 @@ func newPersistentRepository(t *testing.T) Repository {
 	require.NoError(t, err)
 	return persistentOccupancyRepository
 }
+
+// newRedisRepository returns nil if no Redis server is configured by the env-var 'REDIS_ADDRESS'
+func newRedisRepository(t *testing.T) Repository {
+	if os.Getenv("REDIS_ADDRESS") == "" {
+		return nil
+	}
+	client, err := redis.NewClient(&redis.Config{Address: os.Getenv("REDIS_ADDRESS"), KeyPrefix: uuid.NewString()})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+	redisOccupancyRepository, err := NewRedisOccupancyRepository(client, true)
+	require.NoError(t, err)
+	return redisOccupancyRepository
+}
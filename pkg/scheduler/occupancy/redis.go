@@ -0,0 +1,255 @@
+package occupancy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/redis"
+	"go.uber.org/zap"
+)
+
+// RedisOccupancyRepository stores the occupancies in Redis: each worker pool reports its occupancy frequently
+// which causes a high write load on the database. The occupancy of a worker pool is stored in a hash, the IDs of all
+// worker pools are stored in a set.
+type RedisOccupancyRepository struct {
+	client *redis.Client
+	logger *zap.SugaredLogger
+}
+
+func NewRedisOccupancyRepository(client *redis.Client, debug bool) (Repository, error) {
+	if client == nil {
+		return nil, fmt.Errorf("redis occupancy repository requires a redis client")
+	}
+	return &RedisOccupancyRepository{client: client, logger: logger.NewLogger(debug)}, nil
+}
+
+// WithTx returns the repository itself: Redis isn't part of database transactions
+func (r *RedisOccupancyRepository) WithTx(_ *db.TxConnection) (Repository, error) {
+	return r, nil
+}
+
+func (r *RedisOccupancyRepository) CreateWorkerPoolOccupancy(poolID, component string, runningWorkers, poolSize int) (*model.WorkerPoolOccupancyEntity, error) {
+	occupancyEntity := &model.WorkerPoolOccupancyEntity{
+		WorkerPoolID:       poolID,
+		Component:          component,
+		RunningWorkers:     int64(runningWorkers),
+		WorkerPoolCapacity: int64(poolSize),
+		Created:            time.Now().UTC(),
+	}
+	ctx, cancel := r.context()
+	defer cancel()
+	_, err := r.client.Do(ctx, "HSET", r.poolKey(poolID),
+		"component", component,
+		"runningWorkers", runningWorkers,
+		"capacity", poolSize,
+		"created", occupancyEntity.Created.UnixMicro())
+	if err == nil {
+		_, err = r.client.Do(ctx, "SADD", r.poolsKey(), poolID)
+	}
+	if err != nil {
+		r.logger.Errorf("OccupancyRepo failed to create new worker-pool occupancy entity: %s", err)
+		return nil, err
+	}
+	r.logger.Debugf("OccupancyRepo created new worker-pool occupancy entity with poolID '%s'", poolID)
+	return occupancyEntity, nil
+}
+
+func (r *RedisOccupancyRepository) FindWorkerPoolOccupancyByID(poolID string) (*model.WorkerPoolOccupancyEntity, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+	return r.find(ctx, poolID)
+}
+
+func (r *RedisOccupancyRepository) GetComponentList() ([]string, error) {
+	occupancies, err := r.occupancies()
+	if err != nil {
+		return nil, err
+	}
+	if len(occupancies) == 0 {
+		return nil, fmt.Errorf("unable to get component list: no record was found")
+	}
+	var componentList []string
+	for _, occupancyEntity := range occupancies {
+		componentList = append(componentList, occupancyEntity.Component)
+	}
+	return componentList, nil
+}
+
+func (r *RedisOccupancyRepository) GetWorkerPoolIDs() ([]string, error) {
+	occupancies, err := r.occupancies()
+	if err != nil {
+		return nil, err
+	}
+	if len(occupancies) == 0 {
+		return nil, fmt.Errorf("unable to get component list: no record was found")
+	}
+	var poolIDs []string
+	for _, occupancyEntity := range occupancies {
+		poolIDs = append(poolIDs, occupancyEntity.WorkerPoolID)
+	}
+	return poolIDs, nil
+}
+
+func (r *RedisOccupancyRepository) GetMeanWorkerPoolOccupancyByComponent(component string) (float64, error) {
+	occupancies, err := r.occupancies()
+	if err != nil {
+		return 0, err
+	}
+	var aggregatedCapacity int64
+	var aggregatedUsage int64
+	for _, occupancyEntity := range occupancies {
+		if occupancyEntity.Component != component {
+			continue
+		}
+		aggregatedUsage += occupancyEntity.RunningWorkers
+		aggregatedCapacity += occupancyEntity.WorkerPoolCapacity
+	}
+	if aggregatedCapacity == 0 {
+		return 0, fmt.Errorf("unable to calculate worker pool capacity: no record was found for component: %s", component)
+	}
+	return 100 * float64(aggregatedUsage) / float64(aggregatedCapacity), nil
+}
+
+func (r *RedisOccupancyRepository) GetWorkerPoolOccupancies() ([]*model.WorkerPoolOccupancyEntity, error) {
+	occupancies, err := r.occupancies()
+	if err != nil {
+		return nil, err
+	}
+	if len(occupancies) == 0 {
+		return nil, fmt.Errorf("unable to get occupancies list: no record was found")
+	}
+	return occupancies, nil
+}
+
+func (r *RedisOccupancyRepository) RemoveWorkerPoolOccupancy(poolID string) error {
+	ctx, cancel := r.context()
+	defer cancel()
+	deletionCnt, err := redis.Int64(r.client.Do(ctx, "DEL", r.poolKey(poolID)))
+	if err == nil {
+		_, err = r.client.Do(ctx, "SREM", r.poolsKey(), poolID)
+	}
+	if err != nil {
+		r.logger.Errorf("OccupancyRepo failed to delete occupancy entity with poolID '%s': %s", poolID, err)
+		return err
+	}
+	r.logger.Debugf("OccupancyRepo deleted '%d' occupancy entity with poolID '%s'", deletionCnt, poolID)
+	return nil
+}
+
+func (r *RedisOccupancyRepository) RemoveWorkerPoolOccupancies(poolIDs []string) (int, error) {
+	if len(poolIDs) == 0 {
+		r.logger.Debug("OccupancyRepo received empty list of ids: nothing to remove")
+		return 0, nil
+	}
+	deletionCnt := 0
+	for _, poolID := range poolIDs {
+		if err := r.RemoveWorkerPoolOccupancy(poolID); err != nil {
+			return deletionCnt, err
+		}
+		deletionCnt++
+	}
+	return deletionCnt, nil
+}
+
+func (r *RedisOccupancyRepository) UpdateWorkerPoolOccupancy(poolID string, runningWorkers int) error {
+	ctx, cancel := r.context()
+	defer cancel()
+	occupancyEntity, err := r.find(ctx, poolID)
+	if err != nil {
+		return err
+	}
+	cvtdRunningWorkers := int64(runningWorkers)
+	if occupancyEntity.RunningWorkers == cvtdRunningWorkers {
+		r.logger.Warnf("Same number of running workers is already persisted for occupancy entity with poolID '%s'", poolID)
+		return nil
+	}
+	if cvtdRunningWorkers > occupancyEntity.WorkerPoolCapacity {
+		return fmt.Errorf("invalid number of running workers, should be less that worker pool capacity: "+
+			"(running: %d, capacity:%d)", runningWorkers, occupancyEntity.WorkerPoolCapacity)
+	}
+	if _, err := r.client.Do(ctx, "HSET", r.poolKey(poolID), "runningWorkers", runningWorkers); err != nil {
+		r.logger.Errorf("OccupancyRepo failed to update occupancy entity with poolID '%s': %s", poolID, err)
+		return err
+	}
+	r.logger.Debugf("OccupancyRepo updated workersCnt of occupancy entity with poolID '%s' to '%d'", poolID, runningWorkers)
+	return nil
+}
+
+func (r *RedisOccupancyRepository) CreateOrUpdateWorkerPoolOccupancy(poolID, component string, runningWorkers, poolSize int) (bool, error) {
+	occupancyEntity, err := r.FindWorkerPoolOccupancyByID(poolID)
+	if err != nil {
+		if _, err := r.CreateWorkerPoolOccupancy(poolID, component, runningWorkers, poolSize); err != nil {
+			return false, fmt.Errorf("could not create occupancy for component %s and poolID %s", component, poolID)
+		}
+		return true, nil
+	}
+	if component != occupancyEntity.Component || int64(poolSize) != occupancyEntity.WorkerPoolCapacity {
+		return false, fmt.Errorf("component '%s' with poolID '%s' and poolSize '%d' not found", component, poolID, poolSize)
+	}
+	if err := r.UpdateWorkerPoolOccupancy(poolID, runningWorkers); err != nil {
+		return false, fmt.Errorf("could not update occupancy for component %s and poolID %s", component, poolID)
+	}
+	return false, nil
+}
+
+func (r *RedisOccupancyRepository) occupancies() ([]*model.WorkerPoolOccupancyEntity, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+	poolIDs, err := redis.Strings(r.client.Do(ctx, "SMEMBERS", r.poolsKey()))
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.WorkerPoolOccupancyEntity
+	for _, poolID := range poolIDs {
+		occupancyEntity, err := r.find(ctx, poolID)
+		if err == redis.ErrNil {
+			continue //removed in the meantime
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, occupancyEntity)
+	}
+	return result, nil
+}
+
+// find returns redis.ErrNil if no occupancy exists for the worker pool
+func (r *RedisOccupancyRepository) find(ctx context.Context, poolID string) (*model.WorkerPoolOccupancyEntity, error) {
+	fields, err := redis.StringMap(r.client.Do(ctx, "HGETALL", r.poolKey(poolID)))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, redis.ErrNil
+	}
+	var values [3]int64
+	for i, field := range []string{"runningWorkers", "capacity", "created"} {
+		if values[i], err = strconv.ParseInt(fields[field], 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid field '%s' of occupancy of worker pool '%s': %w", field, poolID, err)
+		}
+	}
+	return &model.WorkerPoolOccupancyEntity{
+		WorkerPoolID:       poolID,
+		Component:          fields["component"],
+		RunningWorkers:     values[0],
+		WorkerPoolCapacity: values[1],
+		Created:            time.UnixMicro(values[2]).UTC(),
+	}, nil
+}
+
+func (r *RedisOccupancyRepository) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), r.client.Config().Timeout)
+}
+
+func (r *RedisOccupancyRepository) poolKey(poolID string) string {
+	return r.client.Config().Key("occupancy", poolID)
+}
+
+func (r *RedisOccupancyRepository) poolsKey() string {
+	return r.client.Config().Key("occupancies")
+}
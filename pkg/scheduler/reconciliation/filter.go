@@ -1,7 +1,6 @@
 package reconciliation
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
@@ -44,7 +43,7 @@ type Limit struct {
 
 // TODO: Limit should only apply limit without sorting.
 func (l *Limit) FilterByQuery(q *db.Select) error {
-	q.OrderBy(map[string]string{"Created": "DESC"}).Limit(l.Count)
+	q.OrderBy(map[string]string{model.ReconciliationEntityFields.Created: "DESC"}).Limit(l.Count)
 	return nil
 }
 
@@ -64,23 +63,7 @@ func (ws *WithStatuses) FilterByQuery(q *db.Select) error {
 	if len(ws.Statuses) < 1 {
 		return nil
 	}
-
-	column, err := columnName(q, "Status")
-	if err != nil {
-		return err
-	}
-
-	var whereRaw string
-	argsOffset := q.NextPlaceholderCount()
-	for i := range ws.Statuses {
-		if i > 0 {
-			whereRaw = fmt.Sprintf("%s%s", whereRaw, " OR ")
-		}
-		whereRaw = fmt.Sprintf("%s%s=$%d", whereRaw, column, argsOffset+i)
-	}
-
-	q.WhereRaw(whereRaw, toInterfaceSlice(ws.Statuses)...)
-
+	q.WhereInValues(model.ReconciliationEntityFields.Status, toInterfaceSlice(ws.Statuses)...)
 	return nil
 }
 
@@ -102,12 +85,7 @@ type WithCreationDateAfter struct {
 }
 
 func (wd *WithCreationDateAfter) FilterByQuery(q *db.Select) error {
-	column, err := columnName(q, "Created")
-	if err != nil {
-		return err
-	}
-
-	q.WhereRaw(fmt.Sprintf("%s>$%d", column, q.NextPlaceholderCount()), wd.Time.Format("2006-01-02 15:04:05.000"))
+	q.WhereCompare(model.ReconciliationEntityFields.Created, db.Greater, wd.Time)
 	return nil
 }
 
@@ -123,12 +101,7 @@ type WithCreationDateBefore struct {
 }
 
 func (wd *WithCreationDateBefore) FilterByQuery(q *db.Select) error {
-	column, err := columnName(q, "Created")
-	if err != nil {
-		return err
-	}
-
-	q.WhereRaw(fmt.Sprintf("%s<$%d", column, q.NextPlaceholderCount()), wd.Time.Format("2006-01-02 15:04:05.000"))
+	q.WhereCompare(model.ReconciliationEntityFields.Created, db.Less, wd.Time)
 	return nil
 }
 
@@ -145,7 +118,7 @@ type WithSchedulingID struct {
 
 func (ws *WithSchedulingID) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"SchedulingID": ws.SchedulingID,
+		model.ReconciliationEntityFields.SchedulingID: ws.SchedulingID,
 	})
 	return nil
 }
@@ -162,12 +135,7 @@ type WithNotSchedulingID struct {
 }
 
 func (wns *WithNotSchedulingID) FilterByQuery(q *db.Select) error {
-	column, err := columnName(q, "SchedulingID")
-	if err != nil {
-		return err
-	}
-
-	q.WhereRaw(fmt.Sprintf("%s<>$%d", column, q.NextPlaceholderCount()), wns.SchedulingID)
+	q.WhereCompare(model.ReconciliationEntityFields.SchedulingID, db.NotEqual, wns.SchedulingID)
 	return nil
 }
 
@@ -183,23 +151,10 @@ type WithRuntimeIDs struct {
 }
 
 func (wc *WithRuntimeIDs) FilterByQuery(q *db.Select) error {
-	runtimeIDsLen := len(wc.RuntimeIDs)
-	if runtimeIDsLen < 1 {
+	if len(wc.RuntimeIDs) < 1 {
 		return nil
 	}
-
-	var values string
-	argsOffset := q.NextPlaceholderCount()
-	for i := range wc.RuntimeIDs {
-		if i == runtimeIDsLen-1 {
-			values = fmt.Sprintf("%s$%d", values, i+argsOffset)
-			break
-		}
-		values = fmt.Sprintf("%s$%d,", values, i+argsOffset)
-	}
-
-	runtimeIDs := toInterfaceSlice(wc.RuntimeIDs)
-	q.WhereIn("RuntimeID", values, runtimeIDs...)
+	q.WhereInValues(model.ReconciliationEntityFields.RuntimeID, toInterfaceSlice(wc.RuntimeIDs)...)
 	return nil
 }
 
@@ -223,7 +178,7 @@ type WithRuntimeID struct {
 
 func (wc *WithRuntimeID) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"RuntimeID": wc.RuntimeID,
+		model.ReconciliationEntityFields.RuntimeID: wc.RuntimeID,
 	})
 	return nil
 }
@@ -240,7 +195,7 @@ type CurrentlyReconciling struct {
 
 func (cr *CurrentlyReconciling) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"Finished": false,
+		model.ReconciliationEntityFields.Finished: false,
 	})
 	return nil
 }
@@ -258,8 +213,8 @@ type CurrentlyReconcilingWithRuntimeID struct {
 
 func (cr *CurrentlyReconcilingWithRuntimeID) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"Finished":  false,
-		"RuntimeID": cr.RuntimeID,
+		model.ReconciliationEntityFields.Finished:  false,
+		model.ReconciliationEntityFields.RuntimeID: cr.RuntimeID,
 	})
 	return nil
 }
@@ -286,7 +241,7 @@ type WithClusterConfigStatus struct {
 
 func (wc *WithClusterConfigStatus) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"ClusterConfigStatus": wc.ClusterConfigStatus,
+		model.ReconciliationEntityFields.ClusterConfigStatus: wc.ClusterConfigStatus,
 	})
 	return nil
 }
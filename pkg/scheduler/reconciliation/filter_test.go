@@ -93,7 +93,7 @@ func (s *reconciliationTestSuite) TestFilterMixer_FilterByQuery() {
 				&WithStatuses{Statuses: []string{"test-status-1", "test-status-2"}},
 			},
 			wantErr:   false,
-			wantQuery: " WHERE runtime_id IN ($1,$2) AND (created>$3) AND (created<$4) AND (status=$5 OR status=$6)",
+			wantQuery: " WHERE runtime_id IN ($1,$2) AND created>$3 AND created<$4 AND status IN ($5,$6)",
 		},
 	}
 	for i := range tests {
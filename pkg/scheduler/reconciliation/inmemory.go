@@ -2,6 +2,7 @@ package reconciliation
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -14,9 +15,15 @@ import (
 )
 
 type InMemoryReconciliationRepository struct {
-	reconciliations map[string]*model.ReconciliationEntity       //key: clusterName
-	operations      map[string]map[string]*model.OperationEntity //key1:schedulingID, key2:correlationID
-	status          map[int64]*model.ClusterStatusEntity         //key1:schedulingID, key2:correlationID
+	reconciliations map[string]*model.ReconciliationEntity                     //key: clusterName
+	operations      map[string]map[string]*model.OperationEntity               //key1:schedulingID, key2:correlationID
+	status          map[int64]*model.ClusterStatusEntity                       //key1:schedulingID, key2:correlationID
+	debugBundles    map[string]map[string]*model.OperationDebugBundleEntity    //key1:schedulingID, key2:correlationID
+	payloads        map[string]map[string]*model.OperationPayloadEntity        //key1:schedulingID, key2:correlationID
+	failureCaptures map[string]map[string]*model.OperationFailureCaptureEntity //key1:schedulingID, key2:correlationID
+	phases          map[string]map[string]map[model.OperationPhase]time.Time   //key1:schedulingID, key2:correlationID, key3:phase
+	smokeTests      map[string]map[string][]*model.OperationSmokeTestEntity    //key1:schedulingID, key2:correlationID
+	resources       map[string]map[string][]*model.OperationResourceEntity     //key1:schedulingID, key2:correlationID
 	mu              sync.Mutex
 }
 
@@ -77,11 +84,281 @@ func (r *InMemoryReconciliationRepository) EnableDebugLogging(schedulingID strin
 	return nil
 }
 
+func (r *InMemoryReconciliationRepository) StoreDebugBundle(schedulingID, correlationID string, bundle []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	//drop bundles of operations which were removed in the meantime
+	for bundleSchedulingID := range r.debugBundles {
+		if _, ok := r.operations[bundleSchedulingID]; !ok {
+			delete(r.debugBundles, bundleSchedulingID)
+		}
+	}
+
+	if _, ok := r.debugBundles[schedulingID]; !ok {
+		r.debugBundles[schedulingID] = make(map[string]*model.OperationDebugBundleEntity)
+	}
+	bundleEntity := model.NewOperationDebugBundleEntity(schedulingID, correlationID, bundle)
+	bundleEntity.Created = time.Now().UTC()
+	r.debugBundles[schedulingID][correlationID] = bundleEntity
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) GetDebugBundle(schedulingID, correlationID string) (*model.OperationDebugBundleEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	bundleEntity, ok := r.debugBundles[schedulingID][correlationID]
+	if !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	return bundleEntity, nil
+}
+
+func (r *InMemoryReconciliationRepository) StoreOperationPayload(schedulingID, correlationID string, payload *model.OperationPayloadEntity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	//drop payloads of operations which were removed in the meantime
+	for payloadSchedulingID := range r.payloads {
+		if _, ok := r.operations[payloadSchedulingID]; !ok {
+			delete(r.payloads, payloadSchedulingID)
+		}
+	}
+
+	if _, ok := r.payloads[schedulingID]; !ok {
+		r.payloads[schedulingID] = make(map[string]*model.OperationPayloadEntity)
+	}
+	payloadEntity := *payload
+	payloadEntity.SchedulingID = schedulingID
+	payloadEntity.CorrelationID = correlationID
+	payloadEntity.Created = time.Now().UTC()
+	r.payloads[schedulingID][correlationID] = &payloadEntity
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) GetOperationPayload(schedulingID, correlationID string) (*model.OperationPayloadEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	payloadEntity, ok := r.payloads[schedulingID][correlationID]
+	if !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	return payloadEntity, nil
+}
+
+func (r *InMemoryReconciliationRepository) StoreFailureCapture(schedulingID, correlationID string, capture []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	//drop captures of operations which were removed in the meantime
+	for captureSchedulingID := range r.failureCaptures {
+		if _, ok := r.operations[captureSchedulingID]; !ok {
+			delete(r.failureCaptures, captureSchedulingID)
+		}
+	}
+
+	if _, ok := r.failureCaptures[schedulingID]; !ok {
+		r.failureCaptures[schedulingID] = make(map[string]*model.OperationFailureCaptureEntity)
+	}
+	r.failureCaptures[schedulingID][correlationID] = &model.OperationFailureCaptureEntity{
+		SchedulingID:  schedulingID,
+		CorrelationID: correlationID,
+		Capture:       string(capture),
+		Created:       time.Now().UTC(),
+	}
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) GetFailureCapture(schedulingID, correlationID string) (*model.OperationFailureCaptureEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	captureEntity, ok := r.failureCaptures[schedulingID][correlationID]
+	if !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	return captureEntity, nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationPhases(schedulingID, correlationID string, phases map[model.OperationPhase]time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	//drop phases of operations which were removed in the meantime
+	for phasesSchedulingID := range r.phases {
+		if _, ok := r.operations[phasesSchedulingID]; !ok {
+			delete(r.phases, phasesSchedulingID)
+		}
+	}
+
+	if _, ok := r.phases[schedulingID]; !ok {
+		r.phases[schedulingID] = make(map[string]map[model.OperationPhase]time.Time)
+	}
+	if _, ok := r.phases[schedulingID][correlationID]; !ok {
+		r.phases[schedulingID][correlationID] = make(map[model.OperationPhase]time.Time)
+	}
+	for phase, reached := range phases {
+		r.phases[schedulingID][correlationID][phase] = reached.UTC()
+	}
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) GetOperationPhases(schedulingID, correlationID string) ([]*model.OperationPhaseEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	var result []*model.OperationPhaseEntity
+	for phase, reached := range r.phases[schedulingID][correlationID] {
+		result = append(result, &model.OperationPhaseEntity{
+			SchedulingID:  schedulingID,
+			CorrelationID: correlationID,
+			Phase:         phase,
+			Reached:       reached,
+		})
+	}
+	return result, nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationSmokeTests(schedulingID, correlationID string, smokeTests []*model.OperationSmokeTestEntity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	//drop smoke test results of operations which were removed in the meantime
+	for smokeTestsSchedulingID := range r.smokeTests {
+		if _, ok := r.operations[smokeTestsSchedulingID]; !ok {
+			delete(r.smokeTests, smokeTestsSchedulingID)
+		}
+	}
+
+	if _, ok := r.smokeTests[schedulingID]; !ok {
+		r.smokeTests[schedulingID] = make(map[string][]*model.OperationSmokeTestEntity)
+	}
+	var result []*model.OperationSmokeTestEntity
+	for _, smokeTest := range smokeTests {
+		smokeTestCopy := *smokeTest
+		smokeTestCopy.SchedulingID = schedulingID
+		smokeTestCopy.CorrelationID = correlationID
+		result = append(result, &smokeTestCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	r.smokeTests[schedulingID][correlationID] = result
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) GetOperationSmokeTests(schedulingID, correlationID string) ([]*model.OperationSmokeTestEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	return r.smokeTests[schedulingID][correlationID], nil
+}
+
+func (r *InMemoryReconciliationRepository) UpdateOperationResources(schedulingID, correlationID string, resources []*model.OperationResourceEntity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return &repository.EntityNotFoundError{}
+	}
+
+	//drop resource outcomes of operations which were removed in the meantime
+	for resourcesSchedulingID := range r.resources {
+		if _, ok := r.operations[resourcesSchedulingID]; !ok {
+			delete(r.resources, resourcesSchedulingID)
+		}
+	}
+
+	if _, ok := r.resources[schedulingID]; !ok {
+		r.resources[schedulingID] = make(map[string][]*model.OperationResourceEntity)
+	}
+	var result []*model.OperationResourceEntity
+	for _, resource := range resources {
+		resourceCopy := *resource
+		resourceCopy.SchedulingID = schedulingID
+		resourceCopy.CorrelationID = correlationID
+		result = append(result, &resourceCopy)
+	}
+	sort.Slice(result, func(i, j int) bool { //same order as persistent repository
+		if result[i].APIVersion != result[j].APIVersion {
+			return result[i].APIVersion < result[j].APIVersion
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Namespace < result[j].Namespace
+	})
+	r.resources[schedulingID][correlationID] = result
+
+	return nil
+}
+
+func (r *InMemoryReconciliationRepository) GetOperationResources(schedulingID, correlationID string) ([]*model.OperationResourceEntity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.operations[schedulingID][correlationID]; !ok {
+		return nil, &repository.EntityNotFoundError{}
+	}
+	return r.resources[schedulingID][correlationID], nil
+}
+
 func NewInMemoryReconciliationRepository() Repository {
 	return &InMemoryReconciliationRepository{
 		reconciliations: make(map[string]*model.ReconciliationEntity),
 		operations:      make(map[string]map[string]*model.OperationEntity),
 		status:          make(map[int64]*model.ClusterStatusEntity),
+		debugBundles:    make(map[string]map[string]*model.OperationDebugBundleEntity),
+		payloads:        make(map[string]map[string]*model.OperationPayloadEntity),
+		failureCaptures: make(map[string]map[string]*model.OperationFailureCaptureEntity),
+		phases:          make(map[string]map[string]map[model.OperationPhase]time.Time),
+		smokeTests:      make(map[string]map[string][]*model.OperationSmokeTestEntity),
+		resources:       make(map[string]map[string][]*model.OperationResourceEntity),
 	}
 }
 
@@ -107,6 +384,8 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 		ClusterConfig:       state.Configuration.Version,
 		ClusterConfigStatus: state.Status.ID,
 		SchedulingID:        fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString()),
+		Initiator:           cfg.Initiator,
+		Reason:              cfg.Reason,
 		Created:             time.Now().UTC(),
 	}
 	r.reconciliations[state.Cluster.RuntimeID] = reconEntity
@@ -124,6 +403,7 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 	sequence := state.Configuration.GetReconciliationSequence(cfg)
 	for idx, components := range sequence.Queue {
 		priority := idx + 1
+		bootstrap := opType == model.OperationTypeReconcile && sequence.IsBootstrapGroup(idx)
 		for _, component := range components {
 			correlationID := fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString())
 
@@ -136,6 +416,8 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 				Component:     component.Component,
 				State:         model.OperationStateNew,
 				Type:          opType,
+				Bootstrap:     bootstrap,
+				ClusterSize:   int64(len(state.Configuration.Components)),
 				Retries:       0,
 				RetryID:       uuid.NewString(),
 				Created:       time.Now().UTC(),
@@ -351,6 +633,9 @@ func (r *InMemoryReconciliationRepository) UpdateOperationState(schedulingID, co
 	opCopy.State = state
 	opCopy.Reason = reason
 	opCopy.Updated = time.Now().UTC()
+	if state == model.OperationStateInProgress && opCopy.PickedUp.IsZero() {
+		opCopy.PickedUp = opCopy.Updated
+	}
 
 	r.operations[schedulingID][correlationID] = &opCopy
 
@@ -32,6 +32,18 @@ type MockRepository struct {
 	GetAllComponentsResult                              []string
 	GetAllComponentsResultError                         error
 	EnableDebugLoggingResult                            error
+	StoreDebugBundleResult                              error
+	GetDebugBundleResult                                *model.OperationDebugBundleEntity
+	StoreOperationPayloadResult                         error
+	GetOperationPayloadResult                           *model.OperationPayloadEntity
+	StoreFailureCaptureResult                           error
+	GetFailureCaptureResult                             *model.OperationFailureCaptureEntity
+	UpdateOperationPhasesResult                         error
+	GetOperationPhasesResult                            []*model.OperationPhaseEntity
+	UpdateOperationSmokeTestsResult                     error
+	GetOperationSmokeTestsResult                        []*model.OperationSmokeTestEntity
+	UpdateOperationResourcesResult                      error
+	GetOperationResourcesResult                         []*model.OperationResourceEntity
 	GetStatusIDsOlderThanDeadlineResult                 map[int64]bool
 }
 
@@ -39,6 +51,54 @@ func (mr *MockRepository) EnableDebugLogging(schedulingID string, correlationID
 	return mr.EnableDebugLoggingResult
 }
 
+func (mr *MockRepository) StoreDebugBundle(schedulingID, correlationID string, bundle []byte) error {
+	return mr.StoreDebugBundleResult
+}
+
+func (mr *MockRepository) GetDebugBundle(schedulingID, correlationID string) (*model.OperationDebugBundleEntity, error) {
+	return mr.GetDebugBundleResult, nil
+}
+
+func (mr *MockRepository) StoreOperationPayload(schedulingID, correlationID string, payload *model.OperationPayloadEntity) error {
+	return mr.StoreOperationPayloadResult
+}
+
+func (mr *MockRepository) GetOperationPayload(schedulingID, correlationID string) (*model.OperationPayloadEntity, error) {
+	return mr.GetOperationPayloadResult, nil
+}
+
+func (mr *MockRepository) StoreFailureCapture(schedulingID, correlationID string, capture []byte) error {
+	return mr.StoreFailureCaptureResult
+}
+
+func (mr *MockRepository) GetFailureCapture(schedulingID, correlationID string) (*model.OperationFailureCaptureEntity, error) {
+	return mr.GetFailureCaptureResult, nil
+}
+
+func (mr *MockRepository) UpdateOperationPhases(schedulingID, correlationID string, phases map[model.OperationPhase]time.Time) error {
+	return mr.UpdateOperationPhasesResult
+}
+
+func (mr *MockRepository) GetOperationPhases(schedulingID, correlationID string) ([]*model.OperationPhaseEntity, error) {
+	return mr.GetOperationPhasesResult, nil
+}
+
+func (mr *MockRepository) UpdateOperationSmokeTests(schedulingID, correlationID string, smokeTests []*model.OperationSmokeTestEntity) error {
+	return mr.UpdateOperationSmokeTestsResult
+}
+
+func (mr *MockRepository) GetOperationSmokeTests(schedulingID, correlationID string) ([]*model.OperationSmokeTestEntity, error) {
+	return mr.GetOperationSmokeTestsResult, nil
+}
+
+func (mr *MockRepository) UpdateOperationResources(schedulingID, correlationID string, resources []*model.OperationResourceEntity) error {
+	return mr.UpdateOperationResourcesResult
+}
+
+func (mr *MockRepository) GetOperationResources(schedulingID, correlationID string) ([]*model.OperationResourceEntity, error) {
+	return mr.GetOperationResourcesResult, nil
+}
+
 func (mr *MockRepository) CreateReconciliation(state *cluster.State, cfg *model.ReconciliationSequenceConfig) (*model.ReconciliationEntity, error) {
 	return mr.CreateReconciliationResult, nil
 }
@@ -1,8 +1,7 @@
 package operation
 
 import (
-	"bytes"
-	"fmt"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
@@ -43,7 +42,7 @@ type WithSchedulingID struct {
 
 func (ws *WithSchedulingID) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"SchedulingID": ws.SchedulingID,
+		model.OperationEntityFields.SchedulingID: ws.SchedulingID,
 	})
 	return nil
 }
@@ -60,19 +59,11 @@ type WithStates struct {
 }
 
 func (ws *WithStates) FilterByQuery(q *db.Select) error {
-	var args []interface{}
-	var buffer bytes.Buffer
-
-	argsOffset := q.NextPlaceholderCount()
-	for i := range ws.States {
-		args = append(args, ws.States[i])
-		if buffer.Len() > 0 {
-			buffer.WriteRune(',')
-		}
-		buffer.WriteString(fmt.Sprintf("$%d", argsOffset+i))
+	args := make([]interface{}, 0, len(ws.States))
+	for _, state := range ws.States {
+		args = append(args, state)
 	}
-
-	q.WhereIn("State", buffer.String(), args...)
+	q.WhereInValues(model.OperationEntityFields.State, args...)
 	return nil
 }
 
@@ -95,7 +86,7 @@ type WithCorrelationID struct {
 
 func (ws *WithCorrelationID) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"CorrelationID": ws.CorrelationID,
+		model.OperationEntityFields.CorrelationID: ws.CorrelationID,
 	})
 	return nil
 }
@@ -113,7 +104,7 @@ type WithComponentName struct {
 
 func (wc *WithComponentName) FilterByQuery(q *db.Select) error {
 	q.Where(map[string]interface{}{
-		"Component": wc.Component,
+		model.OperationEntityFields.Component: wc.Component,
 	})
 	return nil
 }
@@ -125,13 +116,84 @@ func (wc *WithComponentName) FilterByInstance(i *model.OperationEntity) *model.O
 	return nil
 }
 
+type WithRuntimeID struct {
+	RuntimeID string
+}
+
+func (wr *WithRuntimeID) FilterByQuery(q *db.Select) error {
+	q.Where(map[string]interface{}{
+		model.OperationEntityFields.RuntimeID: wr.RuntimeID,
+	})
+	return nil
+}
+
+func (wr *WithRuntimeID) FilterByInstance(i *model.OperationEntity) *model.OperationEntity {
+	if i.RuntimeID == wr.RuntimeID {
+		return i
+	}
+	return nil
+}
+
+type WithCreationDateAfter struct {
+	Time time.Time
+}
+
+func (wd *WithCreationDateAfter) FilterByQuery(q *db.Select) error {
+	q.WhereCompare(model.OperationEntityFields.Created, db.Greater, wd.Time)
+	return nil
+}
+
+func (wd *WithCreationDateAfter) FilterByInstance(i *model.OperationEntity) *model.OperationEntity {
+	if i.Created.After(wd.Time) {
+		return i
+	}
+	return nil
+}
+
+type WithCreationDateBefore struct {
+	Time time.Time
+}
+
+func (wd *WithCreationDateBefore) FilterByQuery(q *db.Select) error {
+	q.WhereCompare(model.OperationEntityFields.Created, db.Less, wd.Time)
+	return nil
+}
+
+func (wd *WithCreationDateBefore) FilterByInstance(i *model.OperationEntity) *model.OperationEntity {
+	if i.Created.Before(wd.Time) {
+		return i
+	}
+	return nil
+}
+
+// WithClusterSize filters operations by the amount of components of their cluster (a Max of zero means unbounded)
+type WithClusterSize struct {
+	Min int64
+	Max int64
+}
+
+func (wc *WithClusterSize) FilterByQuery(q *db.Select) error {
+	q.WhereCompare(model.OperationEntityFields.ClusterSize, db.GreaterOrEqual, wc.Min)
+	if wc.Max > 0 {
+		q.WhereCompare(model.OperationEntityFields.ClusterSize, db.LessOrEqual, wc.Max)
+	}
+	return nil
+}
+
+func (wc *WithClusterSize) FilterByInstance(i *model.OperationEntity) *model.OperationEntity {
+	if i.ClusterSize >= wc.Min && (wc.Max <= 0 || i.ClusterSize <= wc.Max) {
+		return i
+	}
+	return nil
+}
+
 type Limit struct {
 	Count       int
 	actualCount int
 }
 
 func (l *Limit) FilterByQuery(q *db.Select) error {
-	q.OrderBy(map[string]string{"Created": "DESC"}).Limit(l.Count)
+	q.OrderBy(map[string]string{model.OperationEntityFields.Created: "DESC"}).Limit(l.Count)
 	return nil
 }
 
@@ -149,7 +211,7 @@ type LimitByLastUpdate struct {
 }
 
 func (l *LimitByLastUpdate) FilterByQuery(q *db.Select) error {
-	q.OrderBy(map[string]string{"Updated": "DESC"}).Limit(l.Count)
+	q.OrderBy(map[string]string{model.OperationEntityFields.Updated: "DESC"}).Limit(l.Count)
 	return nil
 }
 
@@ -2,6 +2,7 @@ package operation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
@@ -48,6 +49,34 @@ func TestFilterMixer_FilterByQuery(t *testing.T) {
 			wantErr:   false,
 			wantQuery: " WHERE scheduling_id=$1 AND correlation_id=$2 AND state IN ($3,$4) AND component=$5 ORDER BY created DESC LIMIT 1",
 		},
+		{
+			name: "ok with runtimeID filter",
+			filters: []Filter{
+				&WithRuntimeID{RuntimeID: "test-runtime-id"},
+				&WithComponentName{Component: "component1"},
+				&LimitByLastUpdate{Count: 1},
+			},
+			wantErr:   false,
+			wantQuery: " WHERE runtime_id=$1 AND component=$2 ORDER BY updated DESC LIMIT 1",
+		},
+		{
+			name: "ok with creation date filters",
+			filters: []Filter{
+				&WithCreationDateAfter{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+				&WithCreationDateBefore{Time: time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)},
+			},
+			wantErr:   false,
+			wantQuery: " WHERE created>$1 AND created<$2",
+		},
+		{
+			name: "ok with cluster size filter",
+			filters: []Filter{
+				&WithComponentName{Component: "component1"},
+				&WithClusterSize{Min: 10, Max: 20},
+			},
+			wantErr:   false,
+			wantQuery: " WHERE component=$1 AND cluster_size>=$2 AND cluster_size<=$3",
+		},
 	}
 	for i := range tests {
 		tt := tests[i]
@@ -106,6 +106,8 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 			ClusterConfigStatus: state.Status.ID,
 			SchedulingID:        fmt.Sprintf("%s--%s", state.Cluster.RuntimeID, uuid.NewString()),
 			Status:              state.Status.Status,
+			Initiator:           cfg.Initiator,
+			Reason:              cfg.Reason,
 		}
 
 		//find existing reconciliation for this cluster
@@ -157,6 +159,8 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 
 		for idx, components := range sequence.Queue {
 			priority := idx + 1
+			//operations of the bootstrap group are reported as bootstrap phase of the reconciliation
+			bootstrap := opType == model.OperationTypeReconcile && sequence.IsBootstrapGroup(idx)
 			for _, component := range components {
 				createOpQ, err := db.NewQuery(tx, &model.OperationEntity{
 					Priority:      int64(priority),
@@ -167,6 +171,8 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 					Component:     component.Component,
 					State:         model.OperationStateNew,
 					Type:          opType,
+					Bootstrap:     bootstrap,
+					ClusterSize:   int64(len(state.Configuration.Components)),
 					RetryID:       uuid.NewString(),
 					Updated:       time.Now().UTC(),
 				}, r.Logger)
@@ -214,29 +220,19 @@ func (r *PersistentReconciliationRepository) RemoveReconciliationsBySchedulingID
 
 	dbOps := func(tx *db.TxConnection) error {
 		for _, schedulingIDsBlock := range schedulingIDsBlocks {
-			var args []interface{}
-			var buffer bytes.Buffer
-
-			for i, schedulingID := range schedulingIDsBlock {
-				if buffer.Len() > 0 {
-					buffer.WriteRune(',')
-				}
-				buffer.WriteString(fmt.Sprintf("$%d", i+1))
-				args = append(args, schedulingID)
-			}
-
 			//delete reconciliations
 			deleteQuery, err := db.NewQuery(tx, &model.ReconciliationEntity{}, r.Logger)
 			if err != nil {
 				return err
 			}
 
-			deleteQueryCount, err := deleteQuery.Delete().WhereIn("SchedulingID", buffer.String(), args...).Exec()
+			deleteQueryCount, err := deleteQuery.Delete().
+				WhereInValues(model.ReconciliationEntityFields.SchedulingID, schedulingIDsBlock...).
+				Exec()
 			if err != nil {
 				return err
 			}
-			r.Logger.Debugf("ReconRepo deleted %d reconciliations which were assigned to reconciliation with schedulingIDs '%s'", deleteQueryCount, args)
-			buffer.Reset()
+			r.Logger.Debugf("ReconRepo deleted %d reconciliations which were assigned to reconciliation with schedulingIDs '%s'", deleteQueryCount, schedulingIDsBlock)
 		}
 		return nil
 	}
@@ -250,29 +246,14 @@ func (r *PersistentReconciliationRepository) RemoveReconciliationsBeforeDeadline
 		if err != nil {
 			return err
 		}
-		columnHandler, err := db.NewColumnHandler(&model.ReconciliationEntity{}, qDelRecon.Conn, qDelRecon.Logger)
-		if err != nil {
-			return err
-		}
-
-		deleteStatement := qDelRecon.Delete()
-
-		schedulingIDColumnName, err := columnHandler.ColumnName("SchedulingID")
-		if err != nil {
-			return err
-		}
-		createdColumnName, err := columnHandler.ColumnName("Created")
-		if err != nil {
-			return err
-		}
 
 		//STEP 1: exclude latest schedulingID
 		//STEP 2: runtimeID
 		//STEP 3: deadline
-		deletedEntries, err := deleteStatement.
-			WhereRaw(fmt.Sprintf("%s<>$%d", schedulingIDColumnName, deleteStatement.NextPlaceholderCount()), latestSchedulingID).
-			Where(map[string]interface{}{"RuntimeID": runtimeID}).
-			WhereRaw(fmt.Sprintf("%s<$%d", createdColumnName, deleteStatement.NextPlaceholderCount()), deadline.Format("2006-01-02 15:04:05.000")).
+		deletedEntries, err := qDelRecon.Delete().
+			WhereCompare(model.ReconciliationEntityFields.SchedulingID, db.NotEqual, latestSchedulingID).
+			Where(map[string]interface{}{model.ReconciliationEntityFields.RuntimeID: runtimeID}).
+			WhereCompare(model.ReconciliationEntityFields.Created, db.Less, deadline).
 			Exec()
 
 		r.Logger.Debugf("Deleted %d reconciliations by filter", deletedEntries)
@@ -436,6 +417,338 @@ func (r *PersistentReconciliationRepository) GetOperation(schedulingID, correlat
 	return opEntity.(*model.OperationEntity), nil
 }
 
+func (r *PersistentReconciliationRepository) StoreDebugBundle(schedulingID, correlationID string, bundle []byte) error {
+	dbOps := func(tx *db.TxConnection) error {
+		whereCond := map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}
+
+		//a retried operation replaces the previously captured bundle
+		qDel, err := db.NewQuery(tx, &model.OperationDebugBundleEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := qDel.Delete().Where(whereCond).Exec(); err != nil {
+			return err
+		}
+
+		bundleEntity := model.NewOperationDebugBundleEntity(schedulingID, correlationID, bundle)
+		qInsert, err := db.NewQuery(tx, bundleEntity, r.Logger)
+		if err != nil {
+			return err
+		}
+		if err := qInsert.Insert().Exec(); err != nil {
+			r.Logger.Errorf("ReconRepo failed to store debug bundle of operation "+
+				"(schedulingID:%s/correlationID:%s): %s", schedulingID, correlationID, err)
+			return err
+		}
+		r.Logger.Debugf("ReconRepo stored debug bundle (%d bytes) of operation "+
+			"(schedulingID:%s/correlationID:%s)", len(bundle), schedulingID, correlationID)
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) GetDebugBundle(schedulingID, correlationID string) (*model.OperationDebugBundleEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.OperationDebugBundleEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{
+		"SchedulingID":  schedulingID,
+		"CorrelationID": correlationID,
+	}
+	bundleEntity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, r.NewNotFoundError(err, bundleEntity, whereCond)
+	}
+	return bundleEntity.(*model.OperationDebugBundleEntity), nil
+}
+
+func (r *PersistentReconciliationRepository) StoreOperationPayload(schedulingID, correlationID string, payload *model.OperationPayloadEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		whereCond := map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}
+
+		//a retried operation replaces the payload of the previous attempt
+		qDel, err := db.NewQuery(tx, &model.OperationPayloadEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := qDel.Delete().Where(whereCond).Exec(); err != nil {
+			return err
+		}
+
+		payloadEntity := *payload
+		payloadEntity.SchedulingID = schedulingID
+		payloadEntity.CorrelationID = correlationID
+		qInsert, err := db.NewQuery(tx, &payloadEntity, r.Logger)
+		if err != nil {
+			return err
+		}
+		if err := qInsert.Insert().Exec(); err != nil {
+			r.Logger.Errorf("ReconRepo failed to store payload of operation "+
+				"(schedulingID:%s/correlationID:%s): %s", schedulingID, correlationID, err)
+			return err
+		}
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) GetOperationPayload(schedulingID, correlationID string) (*model.OperationPayloadEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.OperationPayloadEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{
+		"SchedulingID":  schedulingID,
+		"CorrelationID": correlationID,
+	}
+	payloadEntity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, r.NewNotFoundError(err, payloadEntity, whereCond)
+	}
+	return payloadEntity.(*model.OperationPayloadEntity), nil
+}
+
+func (r *PersistentReconciliationRepository) StoreFailureCapture(schedulingID, correlationID string, capture []byte) error {
+	dbOps := func(tx *db.TxConnection) error {
+		whereCond := map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}
+
+		//a retried operation which failed again replaces the capture of the previous attempt
+		qDel, err := db.NewQuery(tx, &model.OperationFailureCaptureEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := qDel.Delete().Where(whereCond).Exec(); err != nil {
+			return err
+		}
+
+		captureEntity := &model.OperationFailureCaptureEntity{
+			SchedulingID:  schedulingID,
+			CorrelationID: correlationID,
+			Capture:       string(capture),
+		}
+		qInsert, err := db.NewQuery(tx, captureEntity, r.Logger)
+		if err != nil {
+			return err
+		}
+		if err := qInsert.Insert().Exec(); err != nil {
+			r.Logger.Errorf("ReconRepo failed to store failure capture of operation "+
+				"(schedulingID:%s/correlationID:%s): %s", schedulingID, correlationID, err)
+			return err
+		}
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) GetFailureCapture(schedulingID, correlationID string) (*model.OperationFailureCaptureEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.OperationFailureCaptureEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	whereCond := map[string]interface{}{
+		"SchedulingID":  schedulingID,
+		"CorrelationID": correlationID,
+	}
+	captureEntity, err := q.Select().
+		Where(whereCond).
+		GetOne()
+	if err != nil {
+		return nil, r.NewNotFoundError(err, captureEntity, whereCond)
+	}
+	return captureEntity.(*model.OperationFailureCaptureEntity), nil
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationPhases(schedulingID, correlationID string, phases map[model.OperationPhase]time.Time) error {
+	dbOps := func(tx *db.TxConnection) error {
+		for phase, reached := range phases {
+			whereCond := map[string]interface{}{
+				"SchedulingID":  schedulingID,
+				"CorrelationID": correlationID,
+				"Phase":         phase,
+			}
+
+			//a retried operation replaces the timestamps of previously reached phases
+			qDel, err := db.NewQuery(tx, &model.OperationPhaseEntity{}, r.Logger)
+			if err != nil {
+				return err
+			}
+			if _, err := qDel.Delete().Where(whereCond).Exec(); err != nil {
+				return err
+			}
+
+			phaseEntity := &model.OperationPhaseEntity{
+				SchedulingID:  schedulingID,
+				CorrelationID: correlationID,
+				Phase:         phase,
+				Reached:       reached.UTC(),
+			}
+			qInsert, err := db.NewQuery(tx, phaseEntity, r.Logger)
+			if err != nil {
+				return err
+			}
+			if err := qInsert.Insert().Exec(); err != nil {
+				r.Logger.Errorf("ReconRepo failed to store phase '%s' of operation "+
+					"(schedulingID:%s/correlationID:%s): %s", phase, schedulingID, correlationID, err)
+				return err
+			}
+		}
+		r.Logger.Debugf("ReconRepo stored %d phases of operation (schedulingID:%s/correlationID:%s)",
+			len(phases), schedulingID, correlationID)
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) GetOperationPhases(schedulingID, correlationID string) ([]*model.OperationPhaseEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.OperationPhaseEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	phaseEntities, err := q.Select().
+		Where(map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.OperationPhaseEntity
+	for _, phaseEntity := range phaseEntities {
+		result = append(result, phaseEntity.(*model.OperationPhaseEntity))
+	}
+	return result, nil
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationSmokeTests(schedulingID, correlationID string, smokeTests []*model.OperationSmokeTestEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		//a retried operation replaces the results of the previous attempt
+		qDel, err := db.NewQuery(tx, &model.OperationSmokeTestEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := qDel.Delete().Where(map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}).Exec(); err != nil {
+			return err
+		}
+
+		for _, smokeTest := range smokeTests {
+			smokeTestEntity := *smokeTest
+			smokeTestEntity.SchedulingID = schedulingID
+			smokeTestEntity.CorrelationID = correlationID
+			qInsert, err := db.NewQuery(tx, &smokeTestEntity, r.Logger)
+			if err != nil {
+				return err
+			}
+			if err := qInsert.Insert().Exec(); err != nil {
+				r.Logger.Errorf("ReconRepo failed to store result of smoke test '%s' of operation "+
+					"(schedulingID:%s/correlationID:%s): %s", smokeTest.Name, schedulingID, correlationID, err)
+				return err
+			}
+		}
+		r.Logger.Debugf("ReconRepo stored %d smoke test results of operation (schedulingID:%s/correlationID:%s)",
+			len(smokeTests), schedulingID, correlationID)
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) GetOperationSmokeTests(schedulingID, correlationID string) ([]*model.OperationSmokeTestEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.OperationSmokeTestEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	smokeTestEntities, err := q.Select().
+		Where(map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}).
+		OrderBy(map[string]string{"Name": "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.OperationSmokeTestEntity
+	for _, smokeTestEntity := range smokeTestEntities {
+		result = append(result, smokeTestEntity.(*model.OperationSmokeTestEntity))
+	}
+	return result, nil
+}
+
+func (r *PersistentReconciliationRepository) UpdateOperationResources(schedulingID, correlationID string, resources []*model.OperationResourceEntity) error {
+	dbOps := func(tx *db.TxConnection) error {
+		//a retried operation replaces the outcomes of the previous attempt
+		qDel, err := db.NewQuery(tx, &model.OperationResourceEntity{}, r.Logger)
+		if err != nil {
+			return err
+		}
+		if _, err := qDel.Delete().Where(map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}).Exec(); err != nil {
+			return err
+		}
+
+		for _, resource := range resources {
+			resourceEntity := *resource
+			resourceEntity.SchedulingID = schedulingID
+			resourceEntity.CorrelationID = correlationID
+			qInsert, err := db.NewQuery(tx, &resourceEntity, r.Logger)
+			if err != nil {
+				return err
+			}
+			if err := qInsert.Insert().Exec(); err != nil {
+				r.Logger.Errorf("ReconRepo failed to store outcome of %s '%s' (namespace: %s) of operation "+
+					"(schedulingID:%s/correlationID:%s): %s", resource.Kind, resource.Name, resource.Namespace,
+					schedulingID, correlationID, err)
+				return err
+			}
+		}
+		r.Logger.Debugf("ReconRepo stored outcomes of %d resources of operation (schedulingID:%s/correlationID:%s)",
+			len(resources), schedulingID, correlationID)
+		return nil
+	}
+	return db.Transaction(r.Conn, dbOps, r.Logger)
+}
+
+func (r *PersistentReconciliationRepository) GetOperationResources(schedulingID, correlationID string) ([]*model.OperationResourceEntity, error) {
+	q, err := db.NewQuery(r.Conn, &model.OperationResourceEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	resourceEntities, err := q.Select().
+		Where(map[string]interface{}{
+			"SchedulingID":  schedulingID,
+			"CorrelationID": correlationID,
+		}).
+		OrderBy(map[string]string{"APIVersion": "asc", "Kind": "asc", "Name": "asc", "Namespace": "asc"}).
+		GetMany()
+	if err != nil {
+		return nil, err
+	}
+	var result []*model.OperationResourceEntity
+	for _, resourceEntity := range resourceEntities {
+		result = append(result, resourceEntity.(*model.OperationResourceEntity))
+	}
+	return result, nil
+}
+
 func (r *PersistentReconciliationRepository) GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error) {
 	opEntities, err := r.GetReconcilingOperations()
 	if err != nil {
@@ -512,6 +825,9 @@ func (r *PersistentReconciliationRepository) UpdateOperationState(schedulingID,
 		}
 		op.Reason = reason
 		op.Updated = time.Now().UTC()
+		if state == model.OperationStateInProgress && op.PickedUp.IsZero() {
+			op.PickedUp = op.Updated //picked up within the same update to avoid running operations without timestamp
+		}
 
 		//prepare update query
 		q, err := db.NewQuery(tx, op, r.Logger)
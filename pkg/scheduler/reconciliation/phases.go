@@ -0,0 +1,21 @@
+package reconciliation
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+)
+
+// NewOperationPhases converts the phases reported by a component reconciler into operation phases
+func NewOperationPhases(phases []reconciler.OperationPhase) (map[model.OperationPhase]time.Time, error) {
+	result := make(map[model.OperationPhase]time.Time, len(phases))
+	for _, phase := range phases {
+		operationPhase, err := model.NewOperationPhase(string(phase.Phase))
+		if err != nil {
+			return nil, err
+		}
+		result[operationPhase] = phase.Reached
+	}
+	return result, nil
+}
@@ -44,6 +44,24 @@ type Repository interface {
 	GetMothershipOperationProcessingDuration(component string, state model.OperationState, startTime metricStartTime) (int64, error)
 	GetAllComponents() ([]string, error)
 	EnableDebugLogging(schedulingID string, correlationID ...string) error
+	//StoreDebugBundle attaches the debug bundle captured by a component reconciler to an operation (replaces an existing bundle)
+	StoreDebugBundle(schedulingID, correlationID string, bundle []byte) error
+	GetDebugBundle(schedulingID, correlationID string) (*model.OperationDebugBundleEntity, error)
+	//StoreOperationPayload stores the payload sent to a component reconciler (replaces the payload of previous attempts)
+	StoreOperationPayload(schedulingID, correlationID string, payload *model.OperationPayloadEntity) error
+	GetOperationPayload(schedulingID, correlationID string) (*model.OperationPayloadEntity, error)
+	//StoreFailureCapture attaches the capture uploaded by a component reconciler for a failed operation (replaces an existing capture)
+	StoreFailureCapture(schedulingID, correlationID string, capture []byte) error
+	GetFailureCapture(schedulingID, correlationID string) (*model.OperationFailureCaptureEntity, error)
+	//UpdateOperationPhases stores when an operation reached the given phases (replaces timestamps of already reached phases)
+	UpdateOperationPhases(schedulingID, correlationID string, phases map[model.OperationPhase]time.Time) error
+	GetOperationPhases(schedulingID, correlationID string) ([]*model.OperationPhaseEntity, error)
+	//UpdateOperationSmokeTests stores the smoke test results of an operation (replaces results of previous attempts)
+	UpdateOperationSmokeTests(schedulingID, correlationID string, smokeTests []*model.OperationSmokeTestEntity) error
+	GetOperationSmokeTests(schedulingID, correlationID string) ([]*model.OperationSmokeTestEntity, error)
+	//UpdateOperationResources stores the outcome of each resource handled by an operation (replaces outcomes of previous attempts)
+	UpdateOperationResources(schedulingID, correlationID string, resources []*model.OperationResourceEntity) error
+	GetOperationResources(schedulingID, correlationID string) ([]*model.OperationResourceEntity, error)
 }
 
 // findProcessableOperations returns all operations in all running reconciliations which are ready to be processed.
@@ -125,7 +143,7 @@ func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelO
 
 	for _, op := range ops {
 		//if one of the components is in error state, stop processing of remaining tasks
-		if op.State == model.OperationStateError {
+		if op.State == model.OperationStateError || op.State == model.OperationStateVerificationFailed {
 			return nil, false
 		}
 		//ignore component which were already successfully processed
@@ -157,7 +175,8 @@ func findProcessableOperationsInGroup(ops []*model.OperationEntity, maxParallelO
 }
 
 func concatStateReasons(state model.OperationState, reasons []string) (string, error) {
-	if (state == model.OperationStateError || state == model.OperationStateFailed) && len(reasons) == 0 {
+	if (state == model.OperationStateError || state == model.OperationStateFailed ||
+		state == model.OperationStateVerificationFailed) && len(reasons) == 0 {
 		return "", fmt.Errorf("cannot set state to '%v' without providing a reason", state)
 	}
 	return strings.Join(reasons, ", "), nil
@@ -653,6 +653,34 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 				}
 			},
 		},
+		{
+			name: "Set operation pickedUp timestamp when operation is in progress",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				op := opsEntities[0]
+				require.True(t, op.PickedUp.IsZero())
+
+				err = reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateInProgress, false)
+				require.NoError(t, err)
+				op, err = reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+				require.NoError(t, err)
+				require.False(t, op.PickedUp.IsZero())
+				pickedUp := op.PickedUp
+
+				//an operation picked up again keeps its initial timestamp
+				err = reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateInProgress, true)
+				require.NoError(t, err)
+				op, err = reconRepo.GetOperation(op.SchedulingID, op.CorrelationID)
+				require.NoError(t, err)
+				require.True(t, pickedUp.Equal(op.PickedUp))
+			},
+		},
 		{
 			name: "Update component-operation-processing-duration",
 			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
@@ -831,6 +859,165 @@ func (s *reconciliationTestSuite) TestReconciliationRepository() {
 
 			},
 		},
+		{
+			name: "Store and replace debug bundle of an operation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				operationEntity := opsEntities[0]
+
+				_, err = reconRepo.GetDebugBundle(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.True(t, repository.IsNotFoundError(err))
+
+				require.NoError(t, reconRepo.StoreDebugBundle(operationEntity.SchedulingID, operationEntity.CorrelationID, []byte("bundle1")))
+				require.NoError(t, reconRepo.StoreDebugBundle(operationEntity.SchedulingID, operationEntity.CorrelationID, []byte("bundle2")))
+
+				bundleEntity, err := reconRepo.GetDebugBundle(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.NoError(t, err)
+				data, err := bundleEntity.Data()
+				require.NoError(t, err)
+				require.Equal(t, []byte("bundle2"), data)
+
+				require.Error(t, reconRepo.StoreDebugBundle(operationEntity.SchedulingID, "unknown", []byte("bundle")))
+			},
+		},
+		{
+			name: "Store and replace payload of an operation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				operationEntity := opsEntities[0]
+
+				_, err = reconRepo.GetOperationPayload(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.True(t, repository.IsNotFoundError(err))
+
+				require.NoError(t, reconRepo.StoreOperationPayload(operationEntity.SchedulingID, operationEntity.CorrelationID,
+					&model.OperationPayloadEntity{URL: "http://reconciler/v2/run", ContractVersion: 2, Payload: `{"component":"a"}`}))
+				require.NoError(t, reconRepo.StoreOperationPayload(operationEntity.SchedulingID, operationEntity.CorrelationID,
+					&model.OperationPayloadEntity{URL: "http://reconciler/v1/run", ContractVersion: 1, Payload: `{"component":"b"}`}))
+
+				payloadEntity, err := reconRepo.GetOperationPayload(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.NoError(t, err)
+				require.Equal(t, "http://reconciler/v1/run", payloadEntity.URL)
+				require.Equal(t, int64(1), payloadEntity.ContractVersion)
+				require.Equal(t, `{"component":"b"}`, payloadEntity.Payload)
+
+				require.Error(t, reconRepo.StoreOperationPayload(operationEntity.SchedulingID, "unknown",
+					&model.OperationPayloadEntity{URL: "http://reconciler/v1/run", ContractVersion: 1, Payload: "{}"}))
+			},
+		},
+		{
+			name: "Store and replace failure capture of an operation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				operationEntity := opsEntities[0]
+
+				_, err = reconRepo.GetFailureCapture(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.True(t, repository.IsNotFoundError(err))
+
+				require.NoError(t, reconRepo.StoreFailureCapture(operationEntity.SchedulingID, operationEntity.CorrelationID, []byte(`{"error":"a"}`)))
+				require.NoError(t, reconRepo.StoreFailureCapture(operationEntity.SchedulingID, operationEntity.CorrelationID, []byte(`{"error":"b"}`)))
+
+				captureEntity, err := reconRepo.GetFailureCapture(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.NoError(t, err)
+				require.Equal(t, `{"error":"b"}`, captureEntity.Capture)
+
+				require.Error(t, reconRepo.StoreFailureCapture(operationEntity.SchedulingID, "unknown", []byte("{}")))
+			},
+		},
+		{
+			name: "Store and replace phases of an operation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				operationEntity := opsEntities[0]
+
+				phases, err := reconRepo.GetOperationPhases(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.NoError(t, err)
+				require.Empty(t, phases)
+
+				rendered := time.Now().UTC().Truncate(time.Second)
+				applied := rendered.Add(10 * time.Second)
+				require.NoError(t, reconRepo.UpdateOperationPhases(operationEntity.SchedulingID, operationEntity.CorrelationID,
+					map[model.OperationPhase]time.Time{
+						model.OperationPhaseRendered: rendered,
+						model.OperationPhaseApplied:  rendered,
+					}))
+				require.NoError(t, reconRepo.UpdateOperationPhases(operationEntity.SchedulingID, operationEntity.CorrelationID,
+					map[model.OperationPhase]time.Time{
+						model.OperationPhaseApplied: applied,
+					}))
+
+				phases, err = reconRepo.GetOperationPhases(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.NoError(t, err)
+				require.Len(t, phases, 2)
+				reached := make(map[model.OperationPhase]time.Time)
+				for _, phase := range phases {
+					reached[phase.Phase] = phase.Reached
+				}
+				require.True(t, rendered.Equal(reached[model.OperationPhaseRendered]))
+				require.True(t, applied.Equal(reached[model.OperationPhaseApplied]))
+
+				require.Error(t, reconRepo.UpdateOperationPhases(operationEntity.SchedulingID, "unknown",
+					map[model.OperationPhase]time.Time{model.OperationPhaseRendered: rendered}))
+			},
+		},
+		{
+			name: "Store and replace resource outcomes of an operation",
+			testFct: func(t *testing.T, reconRepo Repository, stateMock1, stateMock2 *cluster.State) {
+				reconEntity, err := reconRepo.CreateReconciliation(stateMock1, &model.ReconciliationSequenceConfig{})
+				require.NoError(t, err)
+
+				opsEntities, err := reconRepo.GetOperations(&operation.WithSchedulingID{
+					SchedulingID: reconEntity.SchedulingID,
+				})
+				require.NoError(t, err)
+				operationEntity := opsEntities[0]
+
+				require.NoError(t, reconRepo.UpdateOperationResources(operationEntity.SchedulingID, operationEntity.CorrelationID,
+					[]*model.OperationResourceEntity{
+						{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kyma-system", Name: "cm", Outcome: "failed", Error: "conflict"},
+					}))
+				require.NoError(t, reconRepo.UpdateOperationResources(operationEntity.SchedulingID, operationEntity.CorrelationID,
+					[]*model.OperationResourceEntity{
+						{APIVersion: "v1", Kind: "ConfigMap", Namespace: "kyma-system", Name: "cm", Outcome: "updated"},
+						{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "role", Outcome: "created"},
+					}))
+
+				resources, err := reconRepo.GetOperationResources(operationEntity.SchedulingID, operationEntity.CorrelationID)
+				require.NoError(t, err)
+				require.Len(t, resources, 2)
+				require.Equal(t, "ClusterRole", resources[0].Kind)
+				require.Equal(t, "created", resources[0].Outcome)
+				require.Equal(t, "ConfigMap", resources[1].Kind)
+				require.Equal(t, "updated", resources[1].Outcome)
+				require.Empty(t, resources[1].Error)
+
+				require.Error(t, reconRepo.UpdateOperationResources(operationEntity.SchedulingID, "unknown",
+					[]*model.OperationResourceEntity{{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", Outcome: "created"}}))
+			},
+		},
 	}
 
 	repos := map[string]Repository{
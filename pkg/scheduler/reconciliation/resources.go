@@ -0,0 +1,26 @@
+package reconciliation
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+)
+
+// NewOperationResources converts the resource outcomes reported by a component reconciler into
+// entities (the operation is assigned when they are stored)
+func NewOperationResources(resources []reconciler.ResourceResult) []*model.OperationResourceEntity {
+	result := make([]*model.OperationResourceEntity, 0, len(resources))
+	for _, resource := range resources {
+		entity := &model.OperationResourceEntity{
+			APIVersion: resource.ApiVersion,
+			Kind:       resource.Kind,
+			Namespace:  resource.Namespace,
+			Name:       resource.Name,
+			Outcome:    string(resource.Outcome),
+		}
+		if resource.Error != nil {
+			entity.Error = *resource.Error
+		}
+		result = append(result, entity)
+	}
+	return result
+}
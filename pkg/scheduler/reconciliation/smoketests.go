@@ -0,0 +1,24 @@
+package reconciliation
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+)
+
+// NewOperationSmokeTests converts the smoke test results reported by a component reconciler into
+// entities (the operation is assigned when they are stored)
+func NewOperationSmokeTests(smokeTests []reconciler.SmokeTestResult) []*model.OperationSmokeTestEntity {
+	result := make([]*model.OperationSmokeTestEntity, 0, len(smokeTests))
+	for _, smokeTest := range smokeTests {
+		entity := &model.OperationSmokeTestEntity{
+			Name:     smokeTest.Name,
+			Passed:   smokeTest.Passed,
+			Duration: smokeTest.Duration,
+		}
+		if smokeTest.Error != nil {
+			entity.Error = *smokeTest.Error
+		}
+		result = append(result, entity)
+	}
+	return result
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
+	"go.uber.org/zap"
+)
+
+// maxBackoffHistory limits the reconciliations of a cluster which are inspected to determine its backoff
+const maxBackoffHistory = 10
+
+// intervalBackoff widens the reconcile interval of healthy clusters whose latest reconciliations succeeded without
+// changing anything: each unchanged reconciliation multiplies the interval by the backoff factor until the cap is
+// reached. A changed configuration, a changed resource or a failure resets the interval.
+type intervalBackoff struct {
+	reconRepo reconciliation.Repository
+	streaks   map[string]*unchangedStreak //key: runtime ID
+	logger    *zap.SugaredLogger
+}
+
+// unchangedStreak caches the amount of unchanged reconciliations of a cluster until its status changes
+type unchangedStreak struct {
+	statusID int64
+	count    int
+}
+
+func newIntervalBackoff(reconRepo reconciliation.Repository, logger *zap.SugaredLogger) *intervalBackoff {
+	return &intervalBackoff{
+		reconRepo: reconRepo,
+		streaks:   make(map[string]*unchangedStreak),
+		logger:    logger,
+	}
+}
+
+// DueInterval returns the interval of the cluster widened by its backoff. Only ready clusters are backed off.
+func (b *intervalBackoff) DueInterval(state *cluster.State, interval time.Duration, factor float64, maxInterval time.Duration) time.Duration {
+	if factor <= 1 || maxInterval <= interval || state.Status.Status != model.ClusterStatusReady {
+		return interval
+	}
+	count := b.streak(state)
+	if count == 0 {
+		return interval
+	}
+	widened := float64(interval) * math.Pow(factor, float64(count))
+	if widened >= float64(maxInterval) {
+		return maxInterval
+	}
+	return time.Duration(widened)
+}
+
+func (b *intervalBackoff) streak(state *cluster.State) int {
+	runtimeID := state.Cluster.RuntimeID
+	if cached, ok := b.streaks[runtimeID]; ok && cached.statusID == state.Status.ID {
+		return cached.count
+	}
+	count, err := b.countUnchanged(runtimeID)
+	if err != nil {
+		b.logger.Warnf("Failed to determine reconcile interval backoff of cluster '%s': %s", runtimeID, err)
+		return 0
+	}
+	b.streaks[runtimeID] = &unchangedStreak{statusID: state.Status.ID, count: count}
+	return count
+}
+
+// countUnchanged returns the amount of consecutive unchanged reconciliations, starting with the latest one
+func (b *intervalBackoff) countUnchanged(runtimeID string) (int, error) {
+	recons, err := b.reconRepo.GetReconciliations(&reconciliation.FilterMixer{Filters: []reconciliation.Filter{
+		&reconciliation.WithRuntimeID{RuntimeID: runtimeID},
+		&reconciliation.Limit{Count: maxBackoffHistory + 1},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(recons, func(i, j int) bool {
+		return recons[i].Created.After(recons[j].Created)
+	})
+
+	var count int
+	//the oldest reconciliation is only used to detect whether its successor changed the configuration
+	for i := 0; i < len(recons)-1; i++ {
+		recon := recons[i]
+		if !recon.Finished || recon.Status != model.ClusterStatusReady || recon.ClusterConfig != recons[i+1].ClusterConfig {
+			break
+		}
+		changed, err := b.changedResources(recon.SchedulingID)
+		if err != nil {
+			return 0, err
+		}
+		if changed {
+			break
+		}
+		count++
+	}
+	return count, nil
+}
+
+// changedResources returns false only if the operations of the reconciliation reported at least one resource and
+// all of them were unchanged: reconciliations without outcomes (e.g. reported by component reconcilers which don't
+// support them) count as changed
+func (b *intervalBackoff) changedResources(schedulingID string) (bool, error) {
+	ops, err := b.reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: schedulingID})
+	if err != nil {
+		return false, err
+	}
+	var reported bool
+	for _, op := range ops {
+		resources, err := b.reconRepo.GetOperationResources(op.SchedulingID, op.CorrelationID)
+		if err != nil {
+			return false, err
+		}
+		for _, resource := range resources {
+			if resource.Outcome != string(reconciler.ResourceResultOutcomeUnchanged) {
+				return true, nil
+			}
+			reported = true
+		}
+	}
+	return !reported, nil
+}
+
+// prune drops the cached streaks of clusters which weren't found by the latest watch cycle
+func (b *intervalBackoff) prune(clusterStates []*cluster.State) {
+	found := make(map[string]bool, len(clusterStates))
+	for _, clusterState := range clusterStates {
+		if clusterState != nil {
+			found[clusterState.Cluster.RuntimeID] = true
+		}
+	}
+	for runtimeID := range b.streaks {
+		if !found[runtimeID] {
+			delete(b.streaks, runtimeID)
+		}
+	}
+}
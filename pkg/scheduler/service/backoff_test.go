@@ -0,0 +1,117 @@
+package service
+
+import (
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *serviceTestSuite) TestIntervalBackoff() {
+	t := s.T()
+	now := time.Now()
+	newRecon := func(age time.Duration, configVersion int64, status model.Status) *model.ReconciliationEntity {
+		return &model.ReconciliationEntity{
+			RuntimeID:     "runtime",
+			SchedulingID:  age.String(),
+			ClusterConfig: configVersion,
+			Finished:      status != model.ClusterStatusReconciling,
+			Status:        status,
+			Created:       now.Add(-age),
+		}
+	}
+	newState := func(statusID int64, status model.Status) *cluster.State {
+		return &cluster.State{
+			Cluster: &model.ClusterEntity{RuntimeID: "runtime"},
+			Status:  &model.ClusterStatusEntity{ID: statusID, RuntimeID: "runtime", Status: status},
+		}
+	}
+
+	reconRepo := &reconciliation.MockRepository{
+		//latest reconciliation first: three unchanged reconciliations since the configuration was changed
+		GetReconciliationsResult: []*model.ReconciliationEntity{
+			newRecon(4*time.Hour, 1, model.ClusterStatusReady),
+			newRecon(1*time.Hour, 2, model.ClusterStatusReady),
+			newRecon(2*time.Hour, 2, model.ClusterStatusReady),
+			newRecon(3*time.Hour, 2, model.ClusterStatusReady),
+		},
+		GetOperationsResult: []*model.OperationEntity{{SchedulingID: "scheduling", CorrelationID: "correlation"}},
+		GetOperationResourcesResult: []*model.OperationResourceEntity{
+			{Kind: "Deployment", Name: "istiod", Outcome: "unchanged"},
+		},
+	}
+	backoff := newIntervalBackoff(reconRepo, logger.NewLogger(true))
+
+	//the oldest reconciliation changed the configuration: two unchanged reconciliations widen the interval twice
+	require.Equal(t, 40*time.Minute, backoff.DueInterval(newState(1, model.ClusterStatusReady), 10*time.Minute, 2, time.Hour))
+	//the interval is capped
+	require.Equal(t, 30*time.Minute, backoff.DueInterval(newState(1, model.ClusterStatusReady), 10*time.Minute, 2, 30*time.Minute))
+	//disabled backoff and unhealthy clusters keep their interval
+	require.Equal(t, 10*time.Minute, backoff.DueInterval(newState(1, model.ClusterStatusReady), 10*time.Minute, 1, time.Hour))
+	require.Equal(t, 10*time.Minute, backoff.DueInterval(newState(1, model.ClusterStatusReconcileErrorRetryable), 10*time.Minute, 2, time.Hour))
+
+	//the streak is cached until the status of the cluster changes
+	reconRepo.GetOperationResourcesResult = []*model.OperationResourceEntity{
+		{Kind: "Deployment", Name: "istiod", Outcome: "updated"},
+	}
+	require.Equal(t, 40*time.Minute, backoff.DueInterval(newState(1, model.ClusterStatusReady), 10*time.Minute, 2, time.Hour))
+	//changed resources reset the interval
+	require.Equal(t, 10*time.Minute, backoff.DueInterval(newState(2, model.ClusterStatusReady), 10*time.Minute, 2, time.Hour))
+
+	//failed reconciliations reset the interval
+	reconRepo.GetOperationResourcesResult = []*model.OperationResourceEntity{
+		{Kind: "Deployment", Name: "istiod", Outcome: "unchanged"},
+	}
+	reconRepo.GetReconciliationsResult = []*model.ReconciliationEntity{
+		newRecon(1*time.Hour, 2, model.ClusterStatusReady),
+		newRecon(2*time.Hour, 2, model.ClusterStatusReconcileErrorRetryable),
+		newRecon(3*time.Hour, 2, model.ClusterStatusReady),
+	}
+	require.Equal(t, 20*time.Minute, backoff.DueInterval(newState(3, model.ClusterStatusReady), 10*time.Minute, 2, time.Hour))
+
+	//reconciliations without reported outcomes count as changed
+	reconRepo.GetOperationResourcesResult = nil
+	require.Equal(t, 10*time.Minute, backoff.DueInterval(newState(4, model.ClusterStatusReady), 10*time.Minute, 2, time.Hour))
+	reconRepo.GetOperationsResult = nil
+	require.Equal(t, 10*time.Minute, backoff.DueInterval(newState(5, model.ClusterStatusReady), 10*time.Minute, 2, time.Hour))
+
+	//pruned clusters are evaluated again
+	backoff.prune(nil)
+	require.Empty(t, backoff.streaks)
+}
+
+func (s *serviceTestSuite) TestInventoryWatch_BackoffDueClusters() {
+	t := s.T()
+	now := time.Now()
+	reconRepo := &reconciliation.MockRepository{
+		GetReconciliationsResult: []*model.ReconciliationEntity{
+			{RuntimeID: "runtime", SchedulingID: "latest", ClusterConfig: 1, Finished: true, Status: model.ClusterStatusReady, Created: now.Add(-time.Hour)},
+			{RuntimeID: "runtime", SchedulingID: "previous", ClusterConfig: 1, Finished: true, Status: model.ClusterStatusReady, Created: now.Add(-2 * time.Hour)},
+		},
+		GetOperationsResult: []*model.OperationEntity{{SchedulingID: "latest", CorrelationID: "correlation"}},
+		GetOperationResourcesResult: []*model.OperationResourceEntity{
+			{Kind: "Deployment", Name: "istiod", Outcome: "unchanged"},
+		},
+	}
+	watcher := newInventoryWatch(&cluster.MockInventory{}, logger.NewLogger(true), &SchedulerConfig{
+		ReconcileBackoffFactor:      4,
+		ReconcileBackoffMaxInterval: time.Hour,
+	})
+	watcher.backoff = newIntervalBackoff(reconRepo, logger.NewLogger(true))
+	policy := cluster.NewReconcileIntervalPolicy(10*time.Minute, 0, nil)
+
+	newState := func(status model.Status, age time.Duration) *cluster.State {
+		return &cluster.State{
+			Cluster: &model.ClusterEntity{RuntimeID: "runtime"},
+			Status:  &model.ClusterStatusEntity{RuntimeID: "runtime", Status: status, Created: now.Add(-age)},
+		}
+	}
+	//the interval of the unchanged cluster is widened to 40 minutes
+	require.Empty(t, watcher.filterDueClusters([]*cluster.State{newState(model.ClusterStatusReady, 20*time.Minute)}, policy))
+	require.Len(t, watcher.filterDueClusters([]*cluster.State{newState(model.ClusterStatusReady, 45*time.Minute)}, policy), 1)
+	//unhealthy clusters are reconciled in their regular interval
+	require.Len(t, watcher.filterDueClusters([]*cluster.State{newState(model.ClusterStatusReconcileErrorRetryable, 20*time.Minute)}, policy), 1)
+}
@@ -106,8 +106,8 @@ func (bk *bookkeeper) Run(ctx context.Context, tasks ...BookkeepingTask) error {
 				reconResult, err := bk.newReconciliationResult(recon)
 				if err == nil {
 					bk.logger.Debugf("Bookkeeper evaluated reconciliation (schedulingID:%s) for cluster '%s' "+
-						"to cluster status '%s': Done=%s / Error=%s / New=%s / Running=%s",
-						recon.SchedulingID, recon.RuntimeID, reconResult.GetResult(),
+						"to cluster status '%s' (phase: %s): Done=%s / Error=%s / New=%s / Running=%s",
+						recon.SchedulingID, recon.RuntimeID, reconResult.GetResult(), reconResult.GetPhase(),
 						bk.componentList(reconResult.done, false),
 						bk.componentList(reconResult.error, true),
 						bk.componentList(reconResult.new, false),
@@ -163,5 +163,6 @@ func (bk *bookkeeper) componentList(ops []*model.OperationEntity, withReason boo
 func (bk *bookkeeper) operationHasFailureState(op *model.OperationEntity) bool {
 	return op.State == model.OperationStateError ||
 		op.State == model.OperationStateFailed ||
-		op.State == model.OperationStateClientError
+		op.State == model.OperationStateClientError ||
+		op.State == model.OperationStateVerificationFailed
 }
@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/repository"
 	"go.uber.org/zap"
 )
 
@@ -15,6 +18,7 @@ func newInventoryWatch(inventory cluster.Inventory, logger *zap.SugaredLogger, c
 		inventory: inventory,
 		config:    config,
 		logger:    logger,
+		throttle:  newOperationThrottle(config.MaxOperationsPerMinute),
 	}
 }
 
@@ -22,6 +26,8 @@ type inventoryWatcher struct {
 	inventory cluster.Inventory
 	config    *SchedulerConfig
 	logger    *zap.SugaredLogger
+	throttle  *operationThrottle
+	backoff   *intervalBackoff //nil if the watcher can't access the reconciliations
 }
 
 func (w *inventoryWatcher) Inventory() cluster.Inventory {
@@ -32,11 +38,15 @@ func (w *inventoryWatcher) Run(ctx context.Context, queue inventoryQueue) error
 	w.logger.Infof("Starting inventory watcher with an watch-interval of %.1f secs",
 		w.config.InventoryWatchInterval.Seconds())
 
+	w.processScheduledDeletions()
+	w.processScheduledReconciliations()
 	w.processClustersToReconcile(queue) //check for clusters now, otherwise first check would be trigger by ticker
 	ticker := time.NewTicker(w.config.InventoryWatchInterval)
 	for {
 		select {
 		case <-ticker.C:
+			w.processScheduledDeletions()
+			w.processScheduledReconciliations()
 			w.processClustersToReconcile(queue)
 		case <-ctx.Done():
 			w.logger.Info("Stopping inventory watcher because parent context got closed")
@@ -47,13 +57,35 @@ func (w *inventoryWatcher) Run(ctx context.Context, queue inventoryQueue) error
 }
 
 func (w *inventoryWatcher) processClustersToReconcile(queue inventoryQueue) {
-	clusterStates, err := w.inventory.ClustersToReconcile(w.config.ClusterReconcileInterval)
+	if frozen, err := w.fleetFrozen(); frozen || err != nil {
+		if err != nil {
+			w.logger.Errorf("Inventory watcher failed to fetch freezes from inventory: %s", err)
+		} else {
+			w.logger.Info("Inventory watcher doesn't schedule reconciliations: scheduling is frozen fleet-wide")
+		}
+		return
+	}
+	overrides, err := w.inventory.ReconcileIntervals()
+	if err != nil {
+		w.logger.Errorf("Inventory watchers failed to fetch reconcile intervals from inventory: %s", err)
+		return
+	}
+	reconcileInterval, reconcileJitter := w.config.reconcileInterval()
+	intervalPolicy := cluster.NewReconcileIntervalPolicy(reconcileInterval, reconcileJitter, overrides)
+
+	//query clusters using the shortest interval: clusters with longer intervals are filtered afterwards
+	clusterStates, err := w.inventory.ClustersToReconcile(intervalPolicy.MinInterval())
 	if err != nil {
 		w.logger.Errorf("Inventory watchers failed to fetch clusters to reconcile from inventory "+
 			"(using reconcile interval of %.0f secs): %s",
-			w.config.ClusterReconcileInterval.Seconds(), err)
+			intervalPolicy.MinInterval().Seconds(), err)
 		return
 	}
+	if w.backoff != nil {
+		w.backoff.prune(clusterStates)
+	}
+	clusterStates = w.filterDueClusters(clusterStates, intervalPolicy)
+	clusterStates = w.throttlePeriodicClusters(clusterStates, intervalPolicy, time.Now())
 
 	w.logger.Debugf("Inventory watcher found %d clusters which require a reconciliation", len(clusterStates))
 	for _, clusterState := range clusterStates {
@@ -68,3 +100,200 @@ func (w *inventoryWatcher) processClustersToReconcile(queue inventoryQueue) {
 		queue <- clusterState
 	}
 }
+
+// fleetFrozen returns true if a fleet-wide freeze stops the scheduling of all clusters. Freezes of single components
+// are applied by the worker pool.
+func (w *inventoryWatcher) fleetFrozen() (bool, error) {
+	freezes, err := w.inventory.Freezes(false)
+	if err != nil {
+		return false, err
+	}
+	for _, freeze := range freezes {
+		if freeze.IsFleetWide() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// filterDueClusters drops clusters which were only found because of the shortest interval but whose own interval
+// (widened by the backoff of healthy clusters) didn't elapse yet. Clusters with a pending status are always due.
+func (w *inventoryWatcher) filterDueClusters(clusterStates []*cluster.State, policy *cluster.ReconcileIntervalPolicy) []*cluster.State {
+	now := time.Now()
+	backoffFactor, backoffMaxInterval := w.config.reconcileBackoff()
+	var result []*cluster.State
+	for _, clusterState := range clusterStates {
+		if clusterState != nil && clusterState.Status != nil && isIntervalTriggered(clusterState.Status.Status) {
+			if !policy.IsDue(clusterState, now) {
+				continue
+			}
+			if w.backoff != nil {
+				interval := policy.DueInterval(clusterState)
+				widened := w.backoff.DueInterval(clusterState, interval, backoffFactor, backoffMaxInterval)
+				if widened > interval && clusterState.Status.Created.Add(widened).After(now) {
+					w.logger.Debugf("Inventory watcher defers reconciliation of cluster '%s': its reconcile interval "+
+						"is widened to %s because the latest reconciliations didn't change anything",
+						clusterState.Cluster.RuntimeID, widened)
+					continue
+				}
+			}
+		}
+		result = append(result, clusterState)
+	}
+	return result
+}
+
+// throttlePeriodicClusters spreads periodic reconciliations over time by deferring clusters which exceed the
+// operations-per-minute budget to the next watch cycle. The most overdue clusters are scheduled first, clusters
+// with a pending status are never throttled.
+func (w *inventoryWatcher) throttlePeriodicClusters(clusterStates []*cluster.State, policy *cluster.ReconcileIntervalPolicy,
+	now time.Time) []*cluster.State {
+	maxOperationsPerMinute := w.config.maxOperationsPerMinute()
+	w.throttle.setLimit(maxOperationsPerMinute)
+	if maxOperationsPerMinute <= 0 {
+		return clusterStates
+	}
+
+	var result, periodic []*cluster.State
+	for _, clusterState := range clusterStates {
+		if clusterState != nil && clusterState.Status != nil && isIntervalTriggered(clusterState.Status.Status) {
+			periodic = append(periodic, clusterState)
+			continue
+		}
+		result = append(result, clusterState)
+	}
+
+	dueSince := func(state *cluster.State) time.Time {
+		return state.Status.Created.Add(policy.DueInterval(state))
+	}
+	sort.SliceStable(periodic, func(i, j int) bool {
+		if dueSince(periodic[i]).Equal(dueSince(periodic[j])) {
+			return periodic[i].Cluster.RuntimeID < periodic[j].Cluster.RuntimeID
+		}
+		return dueSince(periodic[i]).Before(dueSince(periodic[j]))
+	})
+
+	var deferred int
+	for _, clusterState := range periodic {
+		if !w.throttle.allow(expectedOperations(clusterState), now) {
+			deferred++
+			continue
+		}
+		result = append(result, clusterState)
+	}
+	if deferred > 0 {
+		w.logger.Infof("Inventory watcher deferred %d periodic reconciliations to the next watch cycle: "+
+			"limit of %d operations per minute reached", deferred, maxOperationsPerMinute)
+	}
+	return result
+}
+
+// expectedOperations returns the amount of operations a reconciliation of the cluster will create
+func expectedOperations(state *cluster.State) int {
+	if state.Configuration == nil || len(state.Configuration.Components) == 0 {
+		return 1
+	}
+	return len(state.Configuration.Components)
+}
+
+// isIntervalTriggered returns true for statuses which are reconciled because the reconcile interval elapsed
+func isIntervalTriggered(status model.Status) bool {
+	return status == model.ClusterStatusReady || status == model.ClusterStatusReconcileErrorRetryable ||
+		status == model.ClusterStatusDeleteErrorRetryable
+}
+
+// processScheduledReconciliations marks the clusters of due scheduled reconciliations as reconcile-pending.
+// Clusters which are currently reconciled keep their schedule until the running reconciliation is finished.
+func (w *inventoryWatcher) processScheduledReconciliations() {
+	schedules, err := w.inventory.DueScheduledReconciliations(time.Now())
+	if err != nil {
+		w.logger.Errorf("Inventory watcher failed to fetch due scheduled reconciliations from inventory: %s", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		clusterState, err := w.inventory.GetLatest(schedule.RuntimeID)
+		if err != nil && !repository.IsNotFoundError(err) {
+			w.logger.Errorf("Inventory watcher failed to fetch cluster '%s' of scheduled reconciliation '%s': %s",
+				schedule.RuntimeID, schedule.ID, err)
+			continue
+		}
+
+		switch {
+		case err != nil || !isSchedulable(clusterState.Status.Status):
+			w.logger.Warnf("Inventory watcher drops scheduled reconciliation '%s': cluster '%s' is deleted or "+
+				"its reconciliation is disabled", schedule.ID, schedule.RuntimeID)
+		case clusterState.Status.Status == model.ClusterStatusReconciling:
+			w.logger.Debugf("Inventory watcher defers scheduled reconciliation '%s' until the running reconciliation "+
+				"of cluster '%s' is finished", schedule.ID, schedule.RuntimeID)
+			continue
+		case clusterState.Status.Status != model.ClusterStatusReconcilePending:
+			//status update and removal of the schedule are atomic: a failure keeps the schedule for the next run
+			if _, err := w.inventory.TriggerScheduledReconciliation(clusterState, schedule.ID); err != nil {
+				w.logger.Errorf("Inventory watcher failed to trigger scheduled reconciliation '%s' of cluster '%s': %s",
+					schedule.ID, schedule.RuntimeID, err)
+				continue
+			}
+			w.logger.Infof("Inventory watcher triggered scheduled reconciliation '%s' of cluster '%s' (not before %s)",
+				schedule.ID, schedule.RuntimeID, schedule.NotBefore.Format(time.RFC3339))
+			continue
+		}
+
+		if err := w.inventory.CancelScheduledReconciliation(schedule.ID); err != nil && !repository.IsNotFoundError(err) {
+			w.logger.Errorf("Inventory watcher failed to remove processed scheduled reconciliation '%s': %s",
+				schedule.ID, err)
+		}
+	}
+}
+
+// processScheduledDeletions marks soft-deleted clusters whose grace period is over as delete-pending which triggers
+// their teardown. Clusters which are currently reconciled are marked after the running reconciliation is finished.
+func (w *inventoryWatcher) processScheduledDeletions() {
+	deletions, err := w.inventory.DueScheduledDeletions(time.Now())
+	if err != nil {
+		w.logger.Errorf("Inventory watcher failed to fetch due scheduled deletions from inventory: %s", err)
+		return
+	}
+
+	for _, deletion := range deletions {
+		clusterState, err := w.inventory.GetLatest(deletion.RuntimeID)
+		if err != nil && !repository.IsNotFoundError(err) {
+			w.logger.Errorf("Inventory watcher failed to fetch soft-deleted cluster '%s': %s", deletion.RuntimeID, err)
+			continue
+		}
+
+		switch {
+		case err != nil || !isDeletable(clusterState.Status.Status):
+			w.logger.Warnf("Inventory watcher drops scheduled deletion of cluster '%s': cluster is already deleted "+
+				"or its deletion is in progress", deletion.RuntimeID)
+		case clusterState.Status.Status == model.ClusterStatusReconciling:
+			w.logger.Debugf("Inventory watcher defers scheduled deletion of cluster '%s' until the running "+
+				"reconciliation is finished", deletion.RuntimeID)
+			continue
+		default:
+			if _, err := w.inventory.TriggerScheduledDeletion(clusterState); err != nil {
+				w.logger.Errorf("Inventory watcher failed to trigger scheduled deletion of cluster '%s': %s",
+					deletion.RuntimeID, err)
+				continue
+			}
+			w.logger.Infof("Inventory watcher triggered teardown of soft-deleted cluster '%s' (not before %s)",
+				deletion.RuntimeID, deletion.TeardownAfter.Format(time.RFC3339))
+			continue
+		}
+
+		if err := w.inventory.CancelScheduledDeletion(deletion.RuntimeID); err != nil && !repository.IsNotFoundError(err) {
+			w.logger.Errorf("Inventory watcher failed to remove processed scheduled deletion of cluster '%s': %s",
+				deletion.RuntimeID, err)
+		}
+	}
+}
+
+func isSchedulable(status model.Status) bool {
+	return !status.IsDisabled() && !status.IsDeleteCandidate() && !status.IsDeletionInProgress() &&
+		status != model.ClusterStatusDeleted && status != model.ClusterStatusDeleteError
+}
+
+func isDeletable(status model.Status) bool {
+	return !status.IsDeleteCandidate() && !status.IsDeletionInProgress() &&
+		status != model.ClusterStatusDeleted && status != model.ClusterStatusDeleteError
+}
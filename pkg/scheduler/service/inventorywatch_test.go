@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"time"
 
@@ -75,3 +77,214 @@ func (s *serviceTestSuite) TestInventoryWatch_ShouldStopOnCtxClose() {
 	require.NoError(t, inventoryWatch.Run(ctx, queue))
 	require.WithinDuration(t, startTime, time.Now(), 2*time.Second)
 }
+
+type scheduleInventory struct {
+	*cluster.MockInventory
+	states    map[string]*cluster.State
+	updated   map[string]model.Status
+	cancelled []string
+}
+
+func (i *scheduleInventory) GetLatest(runtimeID string) (*cluster.State, error) {
+	return i.states[runtimeID], nil
+}
+
+func (i *scheduleInventory) TriggerScheduledReconciliation(state *cluster.State, scheduleID string) (*cluster.State, error) {
+	i.updated[state.Cluster.RuntimeID] = model.ClusterStatusReconcilePending
+	i.cancelled = append(i.cancelled, scheduleID)
+	return state, nil
+}
+
+func (i *scheduleInventory) TriggerScheduledDeletion(state *cluster.State) (*cluster.State, error) {
+	i.updated[state.Cluster.RuntimeID] = model.ClusterStatusDeletePending
+	i.cancelled = append(i.cancelled, state.Cluster.RuntimeID)
+	return state, nil
+}
+
+func (i *scheduleInventory) CancelScheduledReconciliation(id string) error {
+	i.cancelled = append(i.cancelled, id)
+	return nil
+}
+
+func (i *scheduleInventory) CancelScheduledDeletion(runtimeID string) error {
+	i.cancelled = append(i.cancelled, runtimeID)
+	return nil
+}
+
+func (s *serviceTestSuite) TestInventoryWatch_ScheduledReconciliations() {
+	t := s.T()
+	newState := func(runtimeID string, status model.Status) *cluster.State {
+		return &cluster.State{
+			Cluster: &model.ClusterEntity{RuntimeID: runtimeID},
+			Status:  &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: status},
+		}
+	}
+	inventory := &scheduleInventory{
+		MockInventory: &cluster.MockInventory{
+			DueScheduledReconciliationsResult: []*model.ScheduledReconciliationEntity{
+				{ID: "ready", RuntimeID: "readyCluster"},
+				{ID: "reconciling", RuntimeID: "reconcilingCluster"},
+				{ID: "deleting", RuntimeID: "deletingCluster"},
+				{ID: "pending", RuntimeID: "pendingCluster"},
+			},
+		},
+		states: map[string]*cluster.State{
+			"readyCluster":       newState("readyCluster", model.ClusterStatusReady),
+			"reconcilingCluster": newState("reconcilingCluster", model.ClusterStatusReconciling),
+			"deletingCluster":    newState("deletingCluster", model.ClusterStatusDeleting),
+			"pendingCluster":     newState("pendingCluster", model.ClusterStatusReconcilePending),
+		},
+		updated: make(map[string]model.Status),
+	}
+
+	newInventoryWatch(inventory, logger.NewLogger(true), &SchedulerConfig{}).processScheduledReconciliations()
+
+	//only the ready cluster had to be triggered
+	require.Equal(t, map[string]model.Status{"readyCluster": model.ClusterStatusReconcilePending}, inventory.updated)
+	//schedule of the reconciling cluster is kept until its reconciliation is finished
+	require.ElementsMatch(t, []string{"ready", "deleting", "pending"}, inventory.cancelled)
+}
+
+func (s *serviceTestSuite) TestInventoryWatch_ScheduledDeletions() {
+	t := s.T()
+	newState := func(runtimeID string, status model.Status) *cluster.State {
+		return &cluster.State{
+			Cluster: &model.ClusterEntity{RuntimeID: runtimeID},
+			Status:  &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: status},
+		}
+	}
+	inventory := &scheduleInventory{
+		MockInventory: &cluster.MockInventory{
+			DueScheduledDeletionsResult: []*model.ScheduledDeletionEntity{
+				{RuntimeID: "readyCluster"},
+				{RuntimeID: "disabledCluster"},
+				{RuntimeID: "reconcilingCluster"},
+				{RuntimeID: "deletingCluster"},
+			},
+		},
+		states: map[string]*cluster.State{
+			"readyCluster":       newState("readyCluster", model.ClusterStatusReady),
+			"disabledCluster":    newState("disabledCluster", model.ClusterStatusReconcileDisabled),
+			"reconcilingCluster": newState("reconcilingCluster", model.ClusterStatusReconciling),
+			"deletingCluster":    newState("deletingCluster", model.ClusterStatusDeleting),
+		},
+		updated: make(map[string]model.Status),
+	}
+
+	newInventoryWatch(inventory, logger.NewLogger(true), &SchedulerConfig{}).processScheduledDeletions()
+
+	//teardown is triggered for all clusters whose deletion isn't already in progress
+	require.Equal(t, map[string]model.Status{
+		"readyCluster":    model.ClusterStatusDeletePending,
+		"disabledCluster": model.ClusterStatusDeletePending,
+	}, inventory.updated)
+	//scheduled deletion of the reconciling cluster is kept until its reconciliation is finished
+	require.ElementsMatch(t, []string{"readyCluster", "disabledCluster", "deletingCluster"}, inventory.cancelled)
+}
+
+func (s *serviceTestSuite) TestInventoryWatch_ReconcileIntervals() {
+	t := s.T()
+	newState := func(runtimeID string, status model.Status, age time.Duration) *cluster.State {
+		return &cluster.State{
+			Cluster: &model.ClusterEntity{RuntimeID: runtimeID},
+			Status: &model.ClusterStatusEntity{
+				RuntimeID: runtimeID,
+				Status:    status,
+				Created:   time.Now().Add(-age),
+			},
+		}
+	}
+	policy := cluster.NewReconcileIntervalPolicy(time.Minute, 0, []*model.ReconcileIntervalEntity{
+		{RuntimeID: "dailyCluster", Seconds: int64((24 * time.Hour).Seconds())},
+		{RuntimeID: "pendingCluster", Seconds: int64((24 * time.Hour).Seconds())},
+	})
+
+	dueClusters := newInventoryWatch(&cluster.MockInventory{}, logger.NewLogger(true), &SchedulerConfig{}).
+		filterDueClusters([]*cluster.State{
+			newState("globalCluster", model.ClusterStatusReady, 2*time.Minute),
+			newState("dailyCluster", model.ClusterStatusReady, 2*time.Minute),
+			newState("pendingCluster", model.ClusterStatusReconcilePending, 2*time.Minute),
+		}, policy)
+
+	//cluster with daily interval is not due yet but pending clusters are always due
+	var runtimeIDs []string
+	for _, dueCluster := range dueClusters {
+		runtimeIDs = append(runtimeIDs, dueCluster.Cluster.RuntimeID)
+	}
+	require.ElementsMatch(t, []string{"globalCluster", "pendingCluster"}, runtimeIDs)
+}
+
+func (s *serviceTestSuite) TestInventoryWatch_ThrottlePeriodicClusters() {
+	t := s.T()
+	now := time.Now()
+	newState := func(runtimeID string, status model.Status, age time.Duration, components int) *cluster.State {
+		state := &cluster.State{
+			Cluster:       &model.ClusterEntity{RuntimeID: runtimeID},
+			Configuration: &model.ClusterConfigurationEntity{RuntimeID: runtimeID},
+			Status: &model.ClusterStatusEntity{
+				RuntimeID: runtimeID,
+				Status:    status,
+				Created:   now.Add(-age),
+			},
+		}
+		for i := 0; i < components; i++ {
+			state.Configuration.Components = append(state.Configuration.Components, &keb.Component{
+				Component: fmt.Sprintf("comp%d", i),
+			})
+		}
+		return state
+	}
+	policy := cluster.NewReconcileIntervalPolicy(time.Minute, 0, nil)
+	watcher := newInventoryWatch(&cluster.MockInventory{}, logger.NewLogger(true), &SchedulerConfig{
+		MaxOperationsPerMinute: 5,
+	})
+
+	clusterStates := []*cluster.State{
+		newState("recentCluster", model.ClusterStatusReady, 2*time.Minute, 3),
+		newState("overdueCluster", model.ClusterStatusReady, 10*time.Minute, 3),
+		newState("pendingCluster", model.ClusterStatusReconcilePending, 0, 10),
+		newState("smallCluster", model.ClusterStatusReady, 5*time.Minute, 2),
+	}
+	runtimeIDs := func(states []*cluster.State) []string {
+		var result []string
+		for _, state := range states {
+			result = append(result, state.Cluster.RuntimeID)
+		}
+		return result
+	}
+
+	//most overdue clusters are scheduled first, pending clusters are not throttled
+	require.Equal(t, []string{"pendingCluster", "overdueCluster", "smallCluster"},
+		runtimeIDs(watcher.throttlePeriodicClusters(clusterStates, policy, now)))
+
+	//budget is exhausted within the same minute
+	require.Equal(t, []string{"pendingCluster"},
+		runtimeIDs(watcher.throttlePeriodicClusters(clusterStates, policy, now.Add(30*time.Second))))
+
+	//deferred cluster is scheduled after the window passed
+	require.Equal(t, []string{"pendingCluster", "overdueCluster", "smallCluster"},
+		runtimeIDs(watcher.throttlePeriodicClusters(clusterStates, policy, now.Add(throttleWindow))))
+}
+
+func (s *serviceTestSuite) TestInventoryWatch_FleetFreeze() {
+	t := s.T()
+	inventory := &cluster.MockInventory{
+		ClustersToReconcileResult: []*cluster.State{{
+			Cluster:       &model.ClusterEntity{RuntimeID: "testCluster"},
+			Configuration: &model.ClusterConfigurationEntity{RuntimeID: "testCluster"},
+			Status:        &model.ClusterStatusEntity{RuntimeID: "testCluster", Status: model.ClusterStatusReconcilePending},
+		}},
+		FreezesResult: []*model.SchedulingFreezeEntity{{Component: "istio"}, {Component: ""}},
+	}
+	watcher := newInventoryWatch(inventory, logger.NewLogger(true), &SchedulerConfig{})
+
+	//no cluster is scheduled while the fleet is frozen
+	queue := make(chan *cluster.State, 1)
+	watcher.processClustersToReconcile(queue)
+	require.Empty(t, queue)
+
+	//freezes of components are applied by the worker pool
+	inventory.FreezesResult = inventory.FreezesResult[:1]
+	watcher.processClustersToReconcile(queue)
+	require.Len(t, queue, 1)
+}
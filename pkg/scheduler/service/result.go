@@ -47,7 +47,7 @@ func (rs *ReconciliationResult) AddOperation(op *model.OperationEntity) error {
 	switch op.State {
 	case model.OperationStateDone:
 		rs.done = append(rs.done, op)
-	case model.OperationStateError:
+	case model.OperationStateError, model.OperationStateVerificationFailed:
 		rs.error = append(rs.error, op)
 	case model.OperationStateNew:
 		rs.new = append(rs.new, op)
@@ -68,6 +68,11 @@ func (rs *ReconciliationResult) GetOperations() []*model.OperationEntity {
 	return append(result, rs.error...)
 }
 
+// GetPhase returns whether the reconciliation is still bootstrapping the cluster essentials
+func (rs *ReconciliationResult) GetPhase() model.ReconciliationPhase {
+	return model.NewReconciliationPhase(rs.GetOperations())
+}
+
 func (rs *ReconciliationResult) GetResult() model.Status {
 	isDelete := true
 	for _, op := range rs.GetOperations() {
@@ -18,10 +18,17 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
 )
 
+// MetricsCollector gets informed about the internals of the scheduler and the worker pool
+type MetricsCollector interface {
+	worker.MetricsCollector
+	OnClusterQueue(queueDepth func() int)
+}
+
 type RuntimeBuilder struct {
 	reconRepo        reconciliation.Repository
 	logger           *zap.SugaredLogger
 	workerPoolConfig *worker.Config
+	metricsCollector MetricsCollector
 }
 
 func NewRuntimeBuilder(reconRepo reconciliation.Repository, logger *zap.SugaredLogger) *RuntimeBuilder {
@@ -33,7 +40,11 @@ func NewRuntimeBuilder(reconRepo reconciliation.Repository, logger *zap.SugaredL
 }
 
 func (rb *RuntimeBuilder) newWorkerPool(retriever worker.ClusterStateRetriever, invoke invoker.Invoker) (*worker.Pool, error) {
-	return worker.NewWorkerPool(retriever, rb.reconRepo, invoke, rb.workerPoolConfig, rb.logger)
+	pool, err := worker.NewWorkerPool(retriever, rb.reconRepo, invoke, rb.workerPoolConfig, rb.logger)
+	if err != nil || rb.metricsCollector == nil {
+		return pool, err
+	}
+	return pool.WithMetricsCollector(rb.metricsCollector), nil
 }
 
 func (rb *RuntimeBuilder) RunLocal(statusFunc invoker.ReconcilerStatusFunc) *RunLocal {
@@ -67,7 +78,9 @@ func (rb *RuntimeBuilder) RunRemote(conn db.Connection, inventory cluster.Invent
 }
 
 func (rb *RuntimeBuilder) newScheduler() *scheduler {
-	return newScheduler(rb.logger)
+	s := newScheduler(rb.logger)
+	s.metricsCollector = rb.metricsCollector
+	return s
 }
 
 func (rb *RuntimeBuilder) newCleaner() *cleaner {
@@ -181,6 +194,11 @@ func (r *RunRemote) WithWorkerPoolConfig(cfg *worker.Config) *RunRemote {
 	return r
 }
 
+func (r *RunRemote) WithMetricsCollector(collector MetricsCollector) *RunRemote {
+	r.runtimeBuilder.metricsCollector = collector
+	return r
+}
+
 func (r *RunRemote) WithSchedulerConfig(cfg *SchedulerConfig) *RunRemote {
 	r.schedulerConfig = cfg
 	return r
@@ -196,6 +214,45 @@ func (r *RunRemote) WithCleanerConfig(cfg *CleanerConfig) *RunRemote {
 	return r
 }
 
+func (r *RunRemote) tenantOf(runtimeID string) (string, error) {
+	state, err := r.inventory.GetLatest(runtimeID)
+	if err != nil {
+		return "", err
+	}
+	return state.Cluster.GlobalAccountID, nil
+}
+
+func (r *RunRemote) tenantQuotas() (map[string]worker.TenantQuota, error) {
+	entities, err := r.inventory.TenantQuotas()
+	if err != nil {
+		return nil, err
+	}
+	quotas := make(map[string]worker.TenantQuota, len(entities))
+	for _, entity := range entities {
+		quotas[entity.GlobalAccountID] = worker.TenantQuota{
+			MaxParallelOperations:  int(entity.MaxParallelOperations),
+			MaxOperationsPerMinute: int(entity.MaxOperationsPerMinute),
+		}
+	}
+	return quotas, nil
+}
+
+func (r *RunRemote) freeze() (worker.Freeze, error) {
+	entities, err := r.inventory.Freezes(false)
+	if err != nil {
+		return worker.Freeze{}, err
+	}
+	freeze := worker.Freeze{Components: make(map[string]bool, len(entities))}
+	for _, entity := range entities {
+		if entity.IsFleetWide() {
+			freeze.FleetWide = true
+			continue
+		}
+		freeze.Components[entity.Component] = true
+	}
+	return freeze, nil
+}
+
 func (r *RunRemote) Run(ctx context.Context) error {
 	if err := r.config.Validate(); err != nil {
 		return err
@@ -219,6 +276,14 @@ func (r *RunRemote) Run(ctx context.Context) error {
 		} else {
 			r.logger().Fatalf("Failed to create worker pool: %s", err)
 		}
+		var occupancyFunc func(reconciler string) (float64, error)
+		if features.Enabled(features.WorkerpoolOccupancyTracking) && r.occupancyRepo != nil {
+			occupancyFunc = r.occupancyRepo.GetMeanWorkerPoolOccupancyByComponent
+		}
+		workerPool.WithBackpressure(r.config.Scheduler.ReconcilerName, occupancyFunc)
+		workerPool.WithTenantQuotas(r.tenantOf, r.tenantQuotas)
+		workerPool.WithFeatureFlags(r.inventory.EnabledFeatureFlags)
+		workerPool.WithFreeze(r.freeze)
 		if features.Enabled(features.WorkerpoolOccupancyTracking) {
 			//start occupancy tracker to track worker pool
 			err = NewOccupancyTracker(workerPool, r.occupancyRepo, r.config.Scheduler.Reconcilers, r.logger()).Run(ctx)
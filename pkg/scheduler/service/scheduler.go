@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
@@ -39,14 +40,68 @@ func NewDeleteStrategy(s string) (DeleteStrategy, error) {
 
 type SchedulerConfig struct {
 	PreComponents            [][]string
+	BootstrapComponents      []string
 	InventoryWatchInterval   time.Duration
 	ClusterReconcileInterval time.Duration
-	ClusterQueueSize         int
-	DeleteStrategy           DeleteStrategy
-	ComponentCRDs            map[string]config.ComponentCRD
+	ClusterReconcileJitter   float64 //fraction of the reconcile interval which is added per cluster
+	MaxOperationsPerMinute   int     //throttle for periodic reconciliations (zero disables it)
+	//factor which widens the reconcile interval of healthy clusters after each unchanged reconciliation (values <= 1
+	//disable the backoff)
+	ReconcileBackoffFactor      float64
+	ReconcileBackoffMaxInterval time.Duration //cap of the widened reconcile interval
+	ClusterQueueSize            int
+	DeleteStrategy              DeleteStrategy
+	ComponentCRDs               map[string]config.ComponentCRD
+	//guards the settings which can be reloaded at runtime (reconcile interval, jitter and throttle)
+	mu sync.RWMutex
+}
+
+// Reload changes the settings of a running scheduler: undefined values (zero interval or nil) keep the current setting
+func (wc *SchedulerConfig) Reload(reconcileInterval time.Duration, reconcileJitter *float64, maxOperationsPerMinute *int) error {
+	if reconcileInterval < 0 {
+		return errors.New("cluster reconciliation interval cannot be < 0")
+	}
+	if reconcileJitter != nil && (*reconcileJitter < 0 || *reconcileJitter > 1) {
+		return errors.New("cluster reconciliation jitter has to be within 0 and 1")
+	}
+	if maxOperationsPerMinute != nil && *maxOperationsPerMinute < 0 {
+		return errors.New("maximal operations per minute cannot be < 0")
+	}
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	if reconcileInterval > 0 {
+		wc.ClusterReconcileInterval = reconcileInterval
+	}
+	if reconcileJitter != nil {
+		wc.ClusterReconcileJitter = *reconcileJitter
+	}
+	if maxOperationsPerMinute != nil {
+		wc.MaxOperationsPerMinute = *maxOperationsPerMinute
+	}
+	return nil
+}
+
+func (wc *SchedulerConfig) reconcileInterval() (time.Duration, float64) {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.ClusterReconcileInterval, wc.ClusterReconcileJitter
+}
+
+func (wc *SchedulerConfig) reconcileBackoff() (float64, time.Duration) {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.ReconcileBackoffFactor, wc.ReconcileBackoffMaxInterval
+}
+
+func (wc *SchedulerConfig) maxOperationsPerMinute() int {
+	wc.mu.RLock()
+	defer wc.mu.RUnlock()
+	return wc.MaxOperationsPerMinute
 }
 
 func (wc *SchedulerConfig) validate() error {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
 	if wc.InventoryWatchInterval < 0 {
 		return errors.New("inventory watch interval cannot be < 0")
 	}
@@ -59,6 +114,18 @@ func (wc *SchedulerConfig) validate() error {
 	if wc.ClusterReconcileInterval == 0 {
 		wc.ClusterReconcileInterval = defaultClusterReconcileInterval
 	}
+	if wc.ClusterReconcileJitter < 0 || wc.ClusterReconcileJitter > 1 {
+		return errors.New("cluster reconciliation jitter has to be within 0 and 1")
+	}
+	if wc.MaxOperationsPerMinute < 0 {
+		return errors.New("maximal operations per minute cannot be < 0")
+	}
+	if wc.ReconcileBackoffFactor < 0 {
+		return errors.New("reconcile interval backoff factor cannot be < 0")
+	}
+	if wc.ReconcileBackoffFactor > 1 && wc.ReconcileBackoffMaxInterval <= 0 {
+		return errors.New("reconcile interval backoff requires a maximal interval > 0")
+	}
 	if wc.ClusterQueueSize < 0 {
 		return errors.New("cluster queue cannot be < 0")
 	}
@@ -77,7 +144,8 @@ func (wc *SchedulerConfig) validate() error {
 }
 
 type scheduler struct {
-	logger *zap.SugaredLogger
+	logger           *zap.SugaredLogger
+	metricsCollector MetricsCollector
 }
 
 func newScheduler(logger *zap.SugaredLogger) *scheduler {
@@ -88,10 +156,14 @@ func newScheduler(logger *zap.SugaredLogger) *scheduler {
 
 func (s *scheduler) RunOnce(clusterState *cluster.State, reconRepo reconciliation.Repository, config *SchedulerConfig) error {
 	s.logger.Debugf("Starting local scheduler")
+	initiator, reason := clusterState.Status.Trigger()
 	reconEntity, err := reconRepo.CreateReconciliation(clusterState, &model.ReconciliationSequenceConfig{
 		PreComponents:        config.PreComponents,
+		BootstrapComponents:  config.BootstrapComponents,
 		DeleteStrategy:       string(config.DeleteStrategy),
 		ReconciliationStatus: clusterState.Status.Status,
+		Initiator:            initiator,
+		Reason:               reason,
 	})
 	if err == nil {
 		s.logger.Debugf("Scheduler created reconciliation entity: '%s", reconEntity)
@@ -105,7 +177,12 @@ func (s *scheduler) Run(ctx context.Context, transition *ClusterStatusTransition
 	}
 
 	queue := make(chan *cluster.State, config.ClusterQueueSize)
-	s.startInventoryWatcher(ctx, transition.Inventory(), config, queue)
+	if s.metricsCollector != nil {
+		s.metricsCollector.OnClusterQueue(func() int {
+			return len(queue)
+		})
+	}
+	s.startInventoryWatcher(ctx, transition, config, queue)
 
 	for {
 		select {
@@ -126,19 +203,20 @@ func (s *scheduler) Run(ctx context.Context, transition *ClusterStatusTransition
 
 }
 
-func (s *scheduler) startInventoryWatcher(ctx context.Context, inventory cluster.Inventory, config *SchedulerConfig, queue chan *cluster.State) {
+func (s *scheduler) startInventoryWatcher(ctx context.Context, transition *ClusterStatusTransition, config *SchedulerConfig, queue chan *cluster.State) {
 	s.logger.Infof("Starting inventory watcher")
 
 	go func(ctx context.Context,
-		clInv cluster.Inventory,
+		transition *ClusterStatusTransition,
 		logger *zap.SugaredLogger,
 		queue chan *cluster.State,
 		cfg *SchedulerConfig) {
 
-		watcher := newInventoryWatch(clInv, logger, cfg)
+		watcher := newInventoryWatch(transition.Inventory(), logger, cfg)
+		watcher.backoff = newIntervalBackoff(transition.ReconciliationRepository(), logger)
 		if err := watcher.Run(ctx, queue); err != nil {
 			logger.Errorf("Inventory watcher returned an error: %s", err)
 		}
 
-	}(ctx, inventory, s.logger, queue, config)
+	}(ctx, transition, s.logger, queue, config)
 }
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+const throttleWindow = 1 * time.Minute
+
+// operationThrottle limits the amount of operations which are scheduled within a sliding window of one minute
+type operationThrottle struct {
+	limit  int //zero disables the throttle
+	mu     sync.Mutex
+	grants []operationGrant
+}
+
+type operationGrant struct {
+	granted    time.Time
+	operations int
+}
+
+func newOperationThrottle(limit int) *operationThrottle {
+	return &operationThrottle{limit: limit}
+}
+
+// allow reserves the given amount of operations if they fit into the remaining budget of the current window.
+// A request which exceeds the whole budget is still granted if the window is empty: otherwise clusters with
+// many components could never be scheduled.
+func (t *operationThrottle) allow(operations int, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.limit <= 0 {
+		return true
+	}
+
+	used := 0
+	grants := t.grants[:0]
+	for _, grant := range t.grants {
+		if now.Sub(grant.granted) < throttleWindow {
+			grants = append(grants, grant)
+			used += grant.operations
+		}
+	}
+	t.grants = grants
+
+	if used > 0 && used+operations > t.limit {
+		return false
+	}
+	t.grants = append(t.grants, operationGrant{granted: now, operations: operations})
+	return true
+}
+
+// setLimit changes the limit of the throttle: operations granted within the current window stay reserved
+func (t *operationThrottle) setLimit(limit int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.limit = limit
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func (s *serviceTestSuite) TestOperationThrottle() {
+	t := s.T()
+	now := time.Now()
+
+	t.Run("Disabled throttle allows everything", func(t *testing.T) {
+		throttle := newOperationThrottle(0)
+		for i := 0; i < 100; i++ {
+			require.True(t, throttle.allow(10, now))
+		}
+	})
+
+	t.Run("Operations exceeding the budget are rejected until the window passed", func(t *testing.T) {
+		throttle := newOperationThrottle(10)
+		require.True(t, throttle.allow(6, now))
+		require.True(t, throttle.allow(4, now.Add(10*time.Second)))
+		require.False(t, throttle.allow(1, now.Add(20*time.Second)))
+		//first grant left the window
+		require.True(t, throttle.allow(5, now.Add(throttleWindow)))
+		require.False(t, throttle.allow(2, now.Add(throttleWindow)))
+	})
+
+	t.Run("Request larger than the budget is granted in an empty window", func(t *testing.T) {
+		throttle := newOperationThrottle(10)
+		require.True(t, throttle.allow(25, now))
+		require.False(t, throttle.allow(1, now))
+		require.True(t, throttle.allow(1, now.Add(throttleWindow)))
+	})
+}
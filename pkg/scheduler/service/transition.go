@@ -11,6 +11,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
 )
 
 type ClusterStatusTransition struct {
@@ -74,6 +75,15 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 			return err
 		}
 
+		// periodic reconciliations of ready clusters skip the components whose reconcile interval didn't elapse yet
+		var skippedComponents []string
+		if oldClusterState.Status.Status == model.ClusterStatusReady {
+			skippedComponents, err = componentsNotDue(inventoryTx, reconRepoTx, oldClusterState, cfg, time.Now())
+			if err != nil {
+				return errors.Wrapf(err, "failed to evaluate reconcile intervals of components of cluster '%s'", runtimeID)
+			}
+		}
+
 		// set cluster status to reconciling or deleting depending on previous state
 		var targetState model.Status
 		if oldClusterState.Status.Status.IsDeleteCandidate() {
@@ -94,13 +104,18 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 		t.logger.Debugf("Starting reconciliation for cluster '%s': set cluster status to '%s'",
 			newClusterState.Cluster.RuntimeID, newClusterState.Status.Status)
 
-		// create reconciliation entity
+		// create reconciliation entity (its trigger is defined by the status which caused the reconciliation)
+		initiator, reason := oldClusterState.Status.Trigger()
 		reconEntity, err := reconRepoTx.CreateReconciliation(newClusterState, &model.ReconciliationSequenceConfig{
 			PreComponents:        cfg.PreComponents,
+			BootstrapComponents:  cfg.BootstrapComponents,
 			DeleteStrategy:       string(cfg.DeleteStrategy),
 			ReconciliationStatus: newClusterState.Status.Status,
 			ComponentCRDs:        cfg.ComponentCRDs,
 			Kubeconfig:           newClusterState.Cluster.Kubeconfig,
+			SkippedComponents:    skippedComponents,
+			Initiator:            initiator,
+			Reason:               reason,
 		})
 		if err == nil {
 			t.logger.Debugf("Starting reconciliation for cluster '%s' succeeded: reconciliation successfully enqueued "+
@@ -122,6 +137,49 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 	return db.Transaction(t.conn, dbOp, t.logger)
 }
 
+// componentsNotDue returns the components of the cluster which were successfully reconciled within their
+// reconcile interval. These components have a longer interval than the one which triggered the reconciliation.
+func componentsNotDue(inventory cluster.Inventory, reconRepo reconciliation.Repository, state *cluster.State,
+	cfg *SchedulerConfig, now time.Time) ([]string, error) {
+	overrides, err := inventory.ReconcileIntervals()
+	if err != nil || len(overrides) == 0 {
+		return nil, err
+	}
+	reconcileInterval, reconcileJitter := cfg.reconcileInterval()
+	policy := cluster.NewReconcileIntervalPolicy(reconcileInterval, reconcileJitter, overrides)
+	dueInterval := policy.DueInterval(state)
+
+	var result []string
+	for _, component := range state.Configuration.Components {
+		interval := policy.ComponentInterval(state.Cluster.RuntimeID, component.Component)
+		if interval <= dueInterval {
+			continue
+		}
+		ops, err := reconRepo.GetOperations(&operation.FilterMixer{Filters: []operation.Filter{
+			&operation.WithRuntimeID{RuntimeID: state.Cluster.RuntimeID},
+			&operation.WithComponentName{Component: component.Component},
+			&operation.WithStates{States: []model.OperationState{model.OperationStateDone}},
+			&operation.LimitByLastUpdate{Count: 1},
+		}})
+		if err != nil {
+			return nil, err
+		}
+		var lastReconciled time.Time
+		for _, op := range ops {
+			if op.Updated.After(lastReconciled) {
+				lastReconciled = op.Updated
+			}
+		}
+		if !lastReconciled.IsZero() && now.Sub(lastReconciled) < interval {
+			result = append(result, component.Component)
+		}
+	}
+	if len(result) == len(state.Configuration.Components) {
+		return nil, nil //nothing would be reconciled: reconcile all components
+	}
+	return result, nil
+}
+
 func (t *ClusterStatusTransition) FinishReconciliation(schedulingID string, status model.Status) error {
 	dbOp := func(tx *db.TxConnection) error {
 		inventory, err := t.inventory.WithTx(tx)
@@ -8,6 +8,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
 	"github.com/kyma-incubator/reconciler/pkg/test"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -81,6 +82,8 @@ func (s *serviceTestSuite) TestTransitionStartReconciliation() {
 	require.Len(t, reconEntities, 1)
 	require.Greater(t, reconEntities[0].ClusterConfigStatus, oldClusterStateID) //verify new cluster-status ID is used
 	require.False(t, reconEntities[0].Finished)
+	require.Equal(t, model.InitiatorKEB, reconEntities[0].Initiator)
+	require.Equal(t, model.ReasonProvisioning, reconEntities[0].Reason)
 
 	//verify cluster status
 	clusterState, err := s.transition.inventory.GetLatest(clusterStates[0].Cluster.RuntimeID)
@@ -183,3 +186,55 @@ func (s *serviceTestSuite) TestCleanDeletedClusters() {
 		require.Nil(t, state)
 	}
 }
+
+func (s *serviceTestSuite) TestComponentsNotDue() {
+	t := s.T()
+
+	state := &cluster.State{
+		Cluster: &model.ClusterEntity{RuntimeID: "intervalCluster"},
+		Configuration: &model.ClusterConfigurationEntity{
+			RuntimeID: "intervalCluster",
+			Components: []*keb.Component{
+				{Component: "istio"},
+				{Component: "monitoring"},
+			},
+		},
+		Status: &model.ClusterStatusEntity{RuntimeID: "intervalCluster", Status: model.ClusterStatusReady},
+	}
+
+	//reconcile all components successfully
+	reconRepo := reconciliation.NewInMemoryReconciliationRepository()
+	recon, err := reconRepo.CreateReconciliation(state, &model.ReconciliationSequenceConfig{})
+	require.NoError(t, err)
+	ops, err := reconRepo.GetOperations(&operation.WithSchedulingID{SchedulingID: recon.SchedulingID})
+	require.NoError(t, err)
+	require.NotEmpty(t, ops)
+	for _, op := range ops {
+		require.NoError(t, reconRepo.UpdateOperationState(op.SchedulingID, op.CorrelationID, model.OperationStateDone, false))
+	}
+
+	inventory := &cluster.MockInventory{
+		ReconcileIntervalsResult: []*model.ReconcileIntervalEntity{
+			{Component: "istio", Seconds: int64((24 * time.Hour).Seconds())},
+		},
+	}
+	cfg := &SchedulerConfig{ClusterReconcileInterval: time.Hour}
+
+	t.Run("Component reconciled within its interval is skipped", func(t *testing.T) {
+		skipped, err := componentsNotDue(inventory, reconRepo, state, cfg, time.Now().Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, []string{"istio"}, skipped)
+	})
+
+	t.Run("Component is reconciled after its interval elapsed", func(t *testing.T) {
+		skipped, err := componentsNotDue(inventory, reconRepo, state, cfg, time.Now().Add(25*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, skipped)
+	})
+
+	t.Run("No component is skipped without overrides", func(t *testing.T) {
+		skipped, err := componentsNotDue(&cluster.MockInventory{}, reconRepo, state, cfg, time.Now())
+		require.NoError(t, err)
+		require.Empty(t, skipped)
+	})
+}
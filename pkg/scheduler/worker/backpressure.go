@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// backpressure slows down the assignment of operations to component reconcilers which signalled that they have no
+// capacity left. Each consecutive overload doubles the backoff (starting with the base delay and capped by the max
+// delay). The backoff is reset as soon as the component reconciler accepts an operation again.
+type backpressure struct {
+	resolve   func(component string) string
+	occupancy func(reconciler string) (float64, error) //optional: returns the occupancy in percent
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	mu        sync.Mutex
+	backoffs  map[string]*backoff
+}
+
+type backoff struct {
+	overloads int
+	until     time.Time
+}
+
+func newBackpressure(resolve func(component string) string, occupancy func(reconciler string) (float64, error),
+	baseDelay, maxDelay time.Duration) *backpressure {
+	return &backpressure{
+		resolve:   resolve,
+		occupancy: occupancy,
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		backoffs:  make(map[string]*backoff),
+	}
+}
+
+// reconciler returns the name of the component reconciler which is responsible for the component
+func (b *backpressure) reconciler(component string) string {
+	if b.resolve == nil {
+		return component
+	}
+	return b.resolve(component)
+}
+
+// overloaded registers an overload of the component reconciler and returns the resulting backoff. A delay requested
+// by the component reconciler is respected as long as it doesn't exceed the max delay.
+func (b *backpressure) overloaded(reconciler string, retryAfter time.Duration, now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.backoffs[reconciler]
+	if !ok {
+		state = &backoff{}
+		b.backoffs[reconciler] = state
+	}
+
+	delay := b.baseDelay
+	for i := 0; i < state.overloads && delay < b.maxDelay; i++ {
+		delay *= 2
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if delay > b.maxDelay {
+		delay = b.maxDelay
+	}
+
+	state.overloads++
+	state.until = now.Add(delay)
+	return delay
+}
+
+// accepted resets the backoff of the component reconciler and returns true if it was throttled before
+func (b *backpressure) accepted(reconciler string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.backoffs[reconciler]; !ok {
+		return false
+	}
+	delete(b.backoffs, reconciler)
+	return true
+}
+
+// isThrottled returns true if the backoff of the component reconciler didn't expire yet
+func (b *backpressure) isThrottled(reconciler string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.backoffs[reconciler]
+	return ok && now.Before(state.until)
+}
+
+// isFullyOccupied returns true if the component reconciler reported that all its workers are busy
+func (b *backpressure) isFullyOccupied(reconciler string) (bool, error) {
+	if b.occupancy == nil {
+		return false, nil
+	}
+	occupancy, err := b.occupancy(reconciler)
+	if err != nil {
+		return false, err
+	}
+	return occupancy >= 100, nil
+}
@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackpressure(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Backoff grows exponentially up to the max delay", func(t *testing.T) {
+		bp := newBackpressure(nil, nil, 10*time.Second, time.Minute)
+		require.Equal(t, 10*time.Second, bp.overloaded("istio", 0, now))
+		require.Equal(t, 20*time.Second, bp.overloaded("istio", 0, now))
+		require.Equal(t, 40*time.Second, bp.overloaded("istio", 0, now))
+		require.Equal(t, time.Minute, bp.overloaded("istio", 0, now))
+		require.Equal(t, time.Minute, bp.overloaded("istio", 0, now))
+	})
+
+	t.Run("Requested delay is respected within the max delay", func(t *testing.T) {
+		bp := newBackpressure(nil, nil, 10*time.Second, time.Minute)
+		require.Equal(t, 30*time.Second, bp.overloaded("istio", 30*time.Second, now))
+		require.Equal(t, time.Minute, bp.overloaded("istio", time.Hour, now))
+	})
+
+	t.Run("Reconciler is throttled until backoff expired", func(t *testing.T) {
+		bp := newBackpressure(nil, nil, 10*time.Second, time.Minute)
+		require.False(t, bp.isThrottled("istio", now))
+		bp.overloaded("istio", 0, now)
+		require.True(t, bp.isThrottled("istio", now.Add(5*time.Second)))
+		require.False(t, bp.isThrottled("istio", now.Add(10*time.Second)))
+		require.False(t, bp.isThrottled("base", now))
+	})
+
+	t.Run("Accepted operation resets the backoff", func(t *testing.T) {
+		bp := newBackpressure(nil, nil, 10*time.Second, time.Minute)
+		bp.overloaded("istio", 0, now)
+		bp.overloaded("istio", 0, now)
+		require.True(t, bp.accepted("istio"))
+		require.False(t, bp.accepted("istio"))
+		require.False(t, bp.isThrottled("istio", now))
+		require.Equal(t, 10*time.Second, bp.overloaded("istio", 0, now))
+	})
+
+	t.Run("Full occupancy is detected", func(t *testing.T) {
+		bp := newBackpressure(nil, func(reconciler string) (float64, error) {
+			switch reconciler {
+			case "istio":
+				return 100, nil
+			case "base":
+				return 60, nil
+			}
+			return 0, fmt.Errorf("no occupancy reported")
+		}, 10*time.Second, time.Minute)
+
+		fullyOccupied, err := bp.isFullyOccupied("istio")
+		require.NoError(t, err)
+		require.True(t, fullyOccupied)
+
+		fullyOccupied, err = bp.isFullyOccupied("base")
+		require.NoError(t, err)
+		require.False(t, fullyOccupied)
+
+		_, err = bp.isFullyOccupied("unknown")
+		require.Error(t, err)
+	})
+}
+
+func TestWorkerPoolBackpressure(t *testing.T) {
+	newPool := func(t *testing.T, occupancy func(reconciler string) (float64, error)) *Pool {
+		pool, err := NewWorkerPool(nil, nil, nil, &Config{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		return pool.WithBackpressure(func(component string) string {
+			if component == "istio" {
+				return component
+			}
+			return "base"
+		}, occupancy)
+	}
+	ops := []*model.OperationEntity{
+		{Component: "istio"},
+		{Component: "monitoring"},
+		{Component: "logging"},
+	}
+	components := func(ops []*model.OperationEntity) []string {
+		var result []string
+		for _, op := range ops {
+			result = append(result, op.Component)
+		}
+		return result
+	}
+
+	t.Run("Operations of overloaded reconciler are held back", func(t *testing.T) {
+		pool := newPool(t, nil)
+		pool.onReconcilerOverloaded("base", 0)
+		require.Equal(t, []string{"istio"}, components(pool.filterThrottledOps(ops)))
+
+		pool.onReconcilerAccepted("monitoring")
+		require.Equal(t, []string{"istio", "monitoring", "logging"}, components(pool.filterThrottledOps(ops)))
+	})
+
+	t.Run("Operations of fully occupied reconciler are held back", func(t *testing.T) {
+		pool := newPool(t, func(reconciler string) (float64, error) {
+			if reconciler == "istio" {
+				return 100, nil
+			}
+			return 0, fmt.Errorf("no occupancy reported")
+		})
+		require.Equal(t, []string{"monitoring", "logging"}, components(pool.filterThrottledOps(ops)))
+		require.True(t, pool.backpressure.isThrottled("istio", time.Now()))
+	})
+
+	t.Run("Pool without backpressure doesn't filter", func(t *testing.T) {
+		pool, err := NewWorkerPool(nil, nil, nil, &Config{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		require.Len(t, pool.filterThrottledOps(ops), 3)
+	})
+}
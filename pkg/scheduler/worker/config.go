@@ -2,6 +2,7 @@ package worker
 
 import (
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -12,6 +13,8 @@ const (
 	defaultInvokerMaxRetries      = 5
 	defaultInvokerRetryDelay      = 5 * time.Second
 	defaultMaxOperationRetries    = 5
+	defaultBackpressureBaseDelay  = 30 * time.Second
+	defaultBackpressureMaxDelay   = 10 * time.Minute
 )
 
 type Config struct {
@@ -21,9 +24,50 @@ type Config struct {
 	InvokerMaxRetries      int
 	InvokerRetryDelay      time.Duration
 	MaxOperationRetries    int
+	BackpressureBaseDelay  time.Duration //initial backoff of an overloaded component reconciler
+	BackpressureMaxDelay   time.Duration
+	//default limits of the operations per tenant (zero means unlimited)
+	TenantMaxParallelOperations  int
+	TenantMaxOperationsPerMinute int
+	//guards the settings which can be reloaded at runtime (pool size and invoker retries)
+	mu sync.RWMutex
+}
+
+// Reload changes the settings of a running worker pool: zero values keep the current setting
+func (c *Config) Reload(poolSize, invokerMaxRetries int, invokerRetryDelay time.Duration) error {
+	if poolSize < 0 || invokerMaxRetries < 0 || invokerRetryDelay < 0 {
+		return fmt.Errorf("pool size (%d), invoker retries (%d) and invoker retry delay (%.1f sec) cannot be < 0",
+			poolSize, invokerMaxRetries, invokerRetryDelay.Seconds())
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if poolSize > 0 {
+		c.PoolSize = poolSize
+	}
+	if invokerMaxRetries > 0 {
+		c.InvokerMaxRetries = invokerMaxRetries
+	}
+	if invokerRetryDelay > 0 {
+		c.InvokerRetryDelay = invokerRetryDelay
+	}
+	return nil
+}
+
+func (c *Config) poolSize() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PoolSize
+}
+
+func (c *Config) invokerRetries() (int, time.Duration) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.InvokerMaxRetries, c.InvokerRetryDelay
 }
 
 func (c *Config) validate() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	if c.MaxParallelOperations < 0 {
 		return fmt.Errorf("parallel operations per reconciliation cannot be < 0 (was %d)", c.MaxParallelOperations)
 	}
@@ -60,5 +104,27 @@ func (c *Config) validate() error {
 	if c.MaxOperationRetries == 0 {
 		c.MaxOperationRetries = defaultMaxOperationRetries
 	}
+	if c.BackpressureBaseDelay < 0 {
+		return fmt.Errorf("backpressure base delay cannot be < 0 (was %.1f sec)", c.BackpressureBaseDelay.Seconds())
+	}
+	if c.BackpressureBaseDelay == 0 {
+		c.BackpressureBaseDelay = defaultBackpressureBaseDelay
+	}
+	if c.BackpressureMaxDelay < 0 {
+		return fmt.Errorf("backpressure max delay cannot be < 0 (was %.1f sec)", c.BackpressureMaxDelay.Seconds())
+	}
+	if c.BackpressureMaxDelay == 0 {
+		c.BackpressureMaxDelay = defaultBackpressureMaxDelay
+	}
+	if c.BackpressureMaxDelay < c.BackpressureBaseDelay {
+		return fmt.Errorf("backpressure max delay (%.1f sec) cannot be < base delay (%.1f sec)",
+			c.BackpressureMaxDelay.Seconds(), c.BackpressureBaseDelay.Seconds())
+	}
+	if c.TenantMaxParallelOperations < 0 {
+		return fmt.Errorf("parallel operations per tenant cannot be < 0 (was %d)", c.TenantMaxParallelOperations)
+	}
+	if c.TenantMaxOperationsPerMinute < 0 {
+		return fmt.Errorf("operations per minute of a tenant cannot be < 0 (was %d)", c.TenantMaxOperationsPerMinute)
+	}
 	return nil
 }
@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+// Freeze lists the components whose operations are not assigned to workers
+type Freeze struct {
+	FleetWide  bool            //all components are frozen
+	Components map[string]bool //frozen components
+}
+
+func (f Freeze) frozen(component string) bool {
+	return f.FleetWide || f.Components[component]
+}
+
+// filterFrozenOps drops operations of frozen components: they stay untouched until the freeze gets released.
+// Operations which are already in progress aren't affected.
+func (w *Pool) filterFrozenOps(ops []*model.OperationEntity) []*model.OperationEntity {
+	if w.freeze == nil || len(ops) == 0 {
+		return ops
+	}
+
+	freeze, err := w.freeze()
+	if err != nil {
+		//an engaged freeze has to be respected: without knowing the freezes no operation is assigned
+		w.logger.Warnf("Worker pool failed to retrieve freezes and holds back all %d operations: %s", len(ops), err)
+		return nil
+	}
+
+	var filteredOps []*model.OperationEntity
+	for _, op := range ops {
+		if freeze.frozen(op.Component) {
+			continue
+		}
+		filteredOps = append(filteredOps, op)
+	}
+
+	if skipped := len(ops) - len(filteredOps); skipped > 0 {
+		w.logger.Infof("Worker pool holds back %d operations because their components are frozen", skipped)
+	}
+	return filteredOps
+}
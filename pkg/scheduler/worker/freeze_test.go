@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerPoolFreeze(t *testing.T) {
+	ops := []*model.OperationEntity{
+		{Component: "istio", CorrelationID: "op1"},
+		{Component: "monitoring", CorrelationID: "op2"},
+		{Component: "istio", CorrelationID: "op3"},
+	}
+	newPool := func(t *testing.T, freeze func() (Freeze, error)) *Pool {
+		pool, err := NewWorkerPool(nil, nil, nil, &Config{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		if freeze == nil {
+			return pool
+		}
+		return pool.WithFreeze(freeze)
+	}
+
+	t.Run("Operations of frozen components are held back", func(t *testing.T) {
+		pool := newPool(t, func() (Freeze, error) {
+			return Freeze{Components: map[string]bool{"istio": true}}, nil
+		})
+		filtered := pool.filterFrozenOps(ops)
+		require.Len(t, filtered, 1)
+		require.Equal(t, "op2", filtered[0].CorrelationID)
+	})
+
+	t.Run("Fleet-wide freeze holds back all operations", func(t *testing.T) {
+		pool := newPool(t, func() (Freeze, error) {
+			return Freeze{FleetWide: true}, nil
+		})
+		require.Empty(t, pool.filterFrozenOps(ops))
+	})
+
+	t.Run("All operations are held back if the freeze is unknown", func(t *testing.T) {
+		pool := newPool(t, func() (Freeze, error) {
+			return Freeze{}, errors.New("database not reachable")
+		})
+		require.Empty(t, pool.filterFrozenOps(ops))
+	})
+
+	t.Run("Pool without freeze doesn't filter", func(t *testing.T) {
+		require.Len(t, newPool(t, nil).filterFrozenOps(ops), 3)
+		require.Len(t, newPool(t, func() (Freeze, error) { return Freeze{}, nil }).filterFrozenOps(ops), 3)
+	})
+}
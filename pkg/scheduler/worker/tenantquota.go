@@ -0,0 +1,97 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+const tenantRateWindow = 1 * time.Minute
+
+// TenantQuota limits the operations of a tenant (global account). A limit of zero disables it.
+type TenantQuota struct {
+	MaxParallelOperations  int
+	MaxOperationsPerMinute int
+}
+
+// tenantQuotas prevents that the operations of a single tenant occupy the whole worker pool. Operations count as
+// running while they are assigned to a worker or are in progress. The rate limit is applied within a sliding window
+// of one minute.
+type tenantQuotas struct {
+	tenantOf  func(runtimeID string) (string, error)
+	overrides func() (map[string]TenantQuota, error) //optional: tenant specific quotas
+	defaults  TenantQuota
+	mu        sync.Mutex
+	assigned  map[string]string      //correlationID -> tenant of operations which are assigned to a worker
+	grants    map[string][]time.Time //assignments per tenant within the rate window
+}
+
+func newTenantQuotas(tenantOf func(runtimeID string) (string, error), overrides func() (map[string]TenantQuota, error),
+	defaults TenantQuota) *tenantQuotas {
+	return &tenantQuotas{
+		tenantOf:  tenantOf,
+		overrides: overrides,
+		defaults:  defaults,
+		assigned:  make(map[string]string),
+		grants:    make(map[string][]time.Time),
+	}
+}
+
+// quotas returns the quota of each tenant which overrides the default quota
+func (q *tenantQuotas) quotas() (map[string]TenantQuota, error) {
+	if q.overrides == nil {
+		return nil, nil
+	}
+	return q.overrides()
+}
+
+func (q *tenantQuotas) quota(tenant string, overrides map[string]TenantQuota) TenantQuota {
+	if quota, ok := overrides[tenant]; ok {
+		return quota
+	}
+	return q.defaults
+}
+
+// reserve assigns the operation to the tenant if the tenant hasn't exhausted its quota. The running operations
+// contain the tenant's operations which are in progress but not assigned to a worker anymore.
+func (q *tenantQuotas) reserve(tenant, correlationID string, running int, quota TenantQuota, now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for assignedCorrelationID, assignedTenant := range q.assigned {
+		if assignedTenant == tenant && assignedCorrelationID != correlationID {
+			running++
+		}
+	}
+	if quota.MaxParallelOperations > 0 && running >= quota.MaxParallelOperations {
+		return false
+	}
+
+	grants := q.grants[tenant][:0]
+	for _, granted := range q.grants[tenant] {
+		if now.Sub(granted) < tenantRateWindow {
+			grants = append(grants, granted)
+		}
+	}
+	if quota.MaxOperationsPerMinute > 0 && len(grants) >= quota.MaxOperationsPerMinute {
+		q.grants[tenant] = grants
+		return false
+	}
+	q.grants[tenant] = append(grants, now)
+	q.assigned[correlationID] = tenant
+	return true
+}
+
+// release is called as soon as the worker assigned to the operation is finished
+func (q *tenantQuotas) release(correlationID string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.assigned, correlationID)
+}
+
+// isAssigned returns true if the operation is assigned to a worker
+func (q *tenantQuotas) isAssigned(correlationID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.assigned[correlationID]
+	return ok
+}
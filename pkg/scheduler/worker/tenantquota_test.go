@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantQuotas(t *testing.T) {
+	now := time.Now()
+
+	t.Run("Parallel operations are limited", func(t *testing.T) {
+		quotas := newTenantQuotas(nil, nil, TenantQuota{})
+		quota := TenantQuota{MaxParallelOperations: 2}
+		require.True(t, quotas.reserve("tenant1", "op1", 0, quota, now))
+		require.True(t, quotas.reserve("tenant1", "op2", 0, quota, now))
+		require.False(t, quotas.reserve("tenant1", "op3", 0, quota, now))
+		require.True(t, quotas.reserve("tenant2", "op4", 1, quota, now))
+		require.False(t, quotas.reserve("tenant2", "op5", 1, quota, now))
+
+		quotas.release("op1")
+		require.False(t, quotas.isAssigned("op1"))
+		require.True(t, quotas.reserve("tenant1", "op3", 0, quota, now))
+	})
+
+	t.Run("Operations per minute are limited", func(t *testing.T) {
+		quotas := newTenantQuotas(nil, nil, TenantQuota{})
+		quota := TenantQuota{MaxOperationsPerMinute: 2}
+		require.True(t, quotas.reserve("tenant1", "op1", 0, quota, now))
+		require.True(t, quotas.reserve("tenant1", "op2", 0, quota, now.Add(30*time.Second)))
+		quotas.release("op1")
+		quotas.release("op2")
+		require.False(t, quotas.reserve("tenant1", "op3", 0, quota, now.Add(45*time.Second)))
+		require.True(t, quotas.reserve("tenant1", "op3", 0, quota, now.Add(time.Minute)))
+	})
+
+	t.Run("Tenant specific quota overrides the default", func(t *testing.T) {
+		quotas := newTenantQuotas(nil, func() (map[string]TenantQuota, error) {
+			return map[string]TenantQuota{"tenant1": {MaxParallelOperations: 10}}, nil
+		}, TenantQuota{MaxParallelOperations: 1})
+		overrides, err := quotas.quotas()
+		require.NoError(t, err)
+		require.Equal(t, TenantQuota{MaxParallelOperations: 10}, quotas.quota("tenant1", overrides))
+		require.Equal(t, TenantQuota{MaxParallelOperations: 1}, quotas.quota("tenant2", overrides))
+	})
+}
+
+func TestWorkerPoolTenantQuotas(t *testing.T) {
+	tenants := map[string]string{"runtime1": "tenant1", "runtime2": "tenant1", "runtime3": "tenant2"}
+	tenantOf := func(runtimeID string) (string, error) {
+		if tenant, ok := tenants[runtimeID]; ok {
+			return tenant, nil
+		}
+		return "", fmt.Errorf("cluster '%s' not found", runtimeID)
+	}
+	newPool := func(t *testing.T, reconRepo reconciliation.Repository, overrides func() (map[string]TenantQuota, error)) *Pool {
+		pool, err := NewWorkerPool(nil, reconRepo, nil, &Config{TenantMaxParallelOperations: 2}, logger.NewLogger(true))
+		require.NoError(t, err)
+		return pool.WithTenantQuotas(tenantOf, overrides)
+	}
+	ops := []*model.OperationEntity{
+		{RuntimeID: "runtime1", CorrelationID: "op1"},
+		{RuntimeID: "runtime2", CorrelationID: "op2"},
+		{RuntimeID: "runtime1", CorrelationID: "op3"},
+		{RuntimeID: "runtime3", CorrelationID: "op4"},
+		{RuntimeID: "unknown", CorrelationID: "op5"},
+	}
+	correlationIDs := func(ops []*model.OperationEntity) []string {
+		var result []string
+		for _, op := range ops {
+			result = append(result, op.CorrelationID)
+		}
+		return result
+	}
+
+	t.Run("Operations of tenants exceeding their quota are held back", func(t *testing.T) {
+		pool := newPool(t, &reconciliation.MockRepository{}, nil)
+		require.Equal(t, []string{"op1", "op2", "op4", "op5"}, correlationIDs(pool.filterTenantQuotaOps(ops)))
+
+		pool.releaseTenantQuota(ops[0])
+		require.Equal(t, []string{"op3", "op4", "op5"}, correlationIDs(pool.filterTenantQuotaOps(ops[2:])))
+	})
+
+	t.Run("Operations in progress count against the quota", func(t *testing.T) {
+		pool := newPool(t, &reconciliation.MockRepository{
+			GetOperationsResult: []*model.OperationEntity{
+				{RuntimeID: "runtime3", CorrelationID: "running1", State: model.OperationStateInProgress},
+				{RuntimeID: "runtime3", CorrelationID: "running2", State: model.OperationStateInProgress},
+			},
+		}, nil)
+		require.Equal(t, []string{"op1", "op2", "op5"}, correlationIDs(pool.filterTenantQuotaOps(ops)))
+	})
+
+	t.Run("Tenant specific quota is applied", func(t *testing.T) {
+		pool := newPool(t, &reconciliation.MockRepository{}, func() (map[string]TenantQuota, error) {
+			return map[string]TenantQuota{"tenant1": {}}, nil //unlimited
+		})
+		require.Len(t, pool.filterTenantQuotaOps(ops), 5)
+	})
+
+	t.Run("Pool without tenant quotas doesn't filter", func(t *testing.T) {
+		pool, err := NewWorkerPool(nil, nil, nil, &Config{}, logger.NewLogger(true))
+		require.NoError(t, err)
+		require.Len(t, pool.filterTenantQuotaOps(ops), 5)
+	})
+}
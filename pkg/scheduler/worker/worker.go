@@ -15,11 +15,12 @@ import (
 )
 
 type worker struct {
-	reconRepo  reconciliation.Repository
-	invoker    invoker.Invoker
-	logger     *zap.SugaredLogger
-	maxRetries int
-	retryDelay time.Duration
+	reconRepo    reconciliation.Repository
+	invoker      invoker.Invoker
+	logger       *zap.SugaredLogger
+	maxRetries   int
+	retryDelay   time.Duration
+	featureFlags []string
 }
 
 func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model.OperationEntity, maxOpRetries int) error {
@@ -49,10 +50,12 @@ func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model
 			ComponentsReady:      compsReady,
 			SchedulingID:         op.SchedulingID,
 			CorrelationID:        op.CorrelationID,
+			OperationUpdated:     op.Updated,
 			ClusterState:         clusterState,
 			MaxOperationRetries:  maxOpRetries,
 			Type:                 op.Type,
 			Debug:                op.Debug,
+			FeatureFlags:         w.featureFlags,
 		})
 	}
 
@@ -61,7 +64,11 @@ func (w *worker) run(ctx context.Context, clusterState *cluster.State, op *model
 		retry.Attempts(uint(w.maxRetries)),
 		retry.Delay(w.retryDelay),
 		retry.LastErrorOnly(false),
-		retry.Context(ctx))
+		retry.Context(ctx),
+		retry.RetryIf(func(err error) bool {
+			//overloaded component reconcilers are handled by the backpressure of the worker pool
+			return !invoker.IsReconcilerOverloadedError(err)
+		}))
 
 	if err == nil {
 		w.logger.Debugf("Worker finished processing of operation '%s' successfully", op)
@@ -92,5 +99,6 @@ func (w *worker) componentsReady(op *model.OperationEntity) ([]string, error) {
 func (w *worker) isProcessable(op *model.OperationEntity) bool {
 	return op.State != model.OperationStateDone &&
 		op.State != model.OperationStateError &&
+		op.State != model.OperationStateVerificationFailed &&
 		op.State != model.OperationStateInProgress
 }
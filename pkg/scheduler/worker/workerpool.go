@@ -11,11 +11,19 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/repository"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation/operation"
 	"github.com/panjf2000/ants/v2"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
+// MetricsCollector gets informed about the operations which are processed by the worker pool
+type MetricsCollector interface {
+	OnProcessableOperations(count int)
+	OnOperationAssigned(op *model.OperationEntity)
+	OnReconcilerBackoff(reconciler string, backoff time.Duration)
+}
+
 type Pool struct {
 	retriever         ClusterStateRetriever
 	reconRepo         reconciliation.Repository
@@ -24,6 +32,11 @@ type Pool struct {
 	logger            *zap.SugaredLogger
 	antsPool          *ants.PoolWithFunc
 	occupancyObserver occupancy.Observer
+	metricsCollector  MetricsCollector
+	backpressure      *backpressure
+	tenantQuotas      *tenantQuotas
+	featureFlags      func(runtimeID string) ([]string, error)
+	freeze            func() (Freeze, error)
 }
 
 func NewWorkerPool(retriever ClusterStateRetriever, reconRepo reconciliation.Repository, invoker invoker.Invoker, config *Config, logger *zap.SugaredLogger) (*Pool, error) {
@@ -45,6 +58,43 @@ func NewWorkerPool(retriever ClusterStateRetriever, reconRepo reconciliation.Rep
 	}, nil
 }
 
+func (w *Pool) WithMetricsCollector(collector MetricsCollector) *Pool {
+	w.metricsCollector = collector
+	return w
+}
+
+// WithBackpressure slows down the assignment of operations to component reconcilers which are overloaded. The
+// resolver maps a component to the name of its component reconciler, the optional occupancy function returns the
+// mean worker pool occupancy (in percent) reported by a component reconciler.
+func (w *Pool) WithBackpressure(resolver func(component string) string, occupancy func(reconciler string) (float64, error)) *Pool {
+	w.backpressure = newBackpressure(resolver, occupancy, w.config.BackpressureBaseDelay, w.config.BackpressureMaxDelay)
+	return w
+}
+
+// WithTenantQuotas limits the operations of each tenant (global account) by the default quota of the configuration.
+// The resolver maps a cluster to its tenant (clusters without tenant aren't limited), the optional overrides function
+// returns tenant specific quotas.
+func (w *Pool) WithTenantQuotas(tenantOf func(runtimeID string) (string, error), overrides func() (map[string]TenantQuota, error)) *Pool {
+	w.tenantQuotas = newTenantQuotas(tenantOf, overrides, TenantQuota{
+		MaxParallelOperations:  w.config.TenantMaxParallelOperations,
+		MaxOperationsPerMinute: w.config.TenantMaxOperationsPerMinute,
+	})
+	return w
+}
+
+// WithFeatureFlags passes the feature flags which are enabled for a cluster to the component reconcilers
+func (w *Pool) WithFeatureFlags(featureFlags func(runtimeID string) ([]string, error)) *Pool {
+	w.featureFlags = featureFlags
+	return w
+}
+
+// WithFreeze holds back the operations of components which are frozen (e.g. during an incident of the control
+// plane). The function returns the currently engaged freeze.
+func (w *Pool) WithFreeze(freeze func() (Freeze, error)) *Pool {
+	w.freeze = freeze
+	return w
+}
+
 func (w *Pool) RunOnce(ctx context.Context) error {
 	return w.run(ctx, true)
 }
@@ -99,15 +149,17 @@ func (w *Pool) invokeProcessableOpsOnce(ctx context.Context) error {
 }
 
 func (w *Pool) startWorkerPool(ctx context.Context) error {
-	w.logger.Infof("Starting worker pool with capacity of %d workers", w.config.PoolSize)
+	w.logger.Infof("Starting worker pool with capacity of %d workers", w.config.poolSize())
 	var err error
-	w.antsPool, err = ants.NewPoolWithFunc(w.config.PoolSize, func(op interface{}) {
+	w.antsPool, err = ants.NewPoolWithFunc(w.config.poolSize(), func(op interface{}) {
 		w.assignWorker(ctx, op.(*model.OperationEntity))
 	})
 	return err
 }
 
 func (w *Pool) assignWorker(ctx context.Context, opEntity *model.OperationEntity) {
+	defer w.releaseTenantQuota(opEntity)
+
 	clusterState, err := w.retriever.Get(opEntity)
 	if err != nil {
 		if repository.IsNotFoundError(err) { // discard the orphaned operation, it will never succeed if the cluster is gone
@@ -124,21 +176,197 @@ func (w *Pool) assignWorker(ctx context.Context, opEntity *model.OperationEntity
 		return
 	}
 
+	var featureFlags []string
+	if w.featureFlags != nil {
+		featureFlags, err = w.featureFlags(opEntity.RuntimeID)
+		if err != nil {
+			w.logger.Errorf("Worker pool is not able to assign operation '%s' to worker because feature flags "+
+				"of cluster '%s' could not be retrieved: %s", opEntity, opEntity.RuntimeID, err)
+			return
+		}
+	}
+
 	w.logger.Debugf("Worker pool is assigning operation '%s' to worker", opEntity)
 	maxOpRetries := w.config.MaxOperationRetries - int(opEntity.Retries)
+	invokerMaxRetries, invokerRetryDelay := w.config.invokerRetries()
 	err = (&worker{
-		reconRepo:  w.reconRepo,
-		invoker:    w.invoker,
-		logger:     w.logger,
-		maxRetries: w.config.InvokerMaxRetries,
-		retryDelay: w.config.InvokerRetryDelay,
+		reconRepo:    w.reconRepo,
+		invoker:      w.invoker,
+		logger:       w.logger,
+		maxRetries:   invokerMaxRetries,
+		retryDelay:   invokerRetryDelay,
+		featureFlags: featureFlags,
 	}).run(ctx, clusterState, opEntity, maxOpRetries)
+	if overloadedErr := invoker.AsReconcilerOverloadedError(err); overloadedErr != nil {
+		w.onReconcilerOverloaded(overloadedErr.Reconciler, overloadedErr.RetryAfter)
+		return
+	}
 	if err != nil {
 		w.logger.Warnf("Worker pool received an error from worker assigned to operation '%s': %s", opEntity, err)
+		return
+	}
+	w.onReconcilerAccepted(opEntity.Component)
+}
+
+func (w *Pool) onReconcilerOverloaded(reconciler string, retryAfter time.Duration) {
+	if w.backpressure == nil {
+		w.logger.Warnf("Worker pool was informed that component reconciler '%s' is overloaded", reconciler)
+		return
+	}
+	backoff := w.backpressure.overloaded(reconciler, retryAfter, time.Now())
+	w.logger.Warnf("Worker pool slows down assignment of operations to component reconciler '%s' "+
+		"because it is overloaded: backing off for %.0f secs", reconciler, backoff.Seconds())
+	if w.metricsCollector != nil {
+		w.metricsCollector.OnReconcilerBackoff(reconciler, backoff)
+	}
+}
+
+func (w *Pool) onReconcilerAccepted(component string) {
+	if w.backpressure == nil {
+		return
+	}
+	reconciler := w.backpressure.reconciler(component)
+	if !w.backpressure.accepted(reconciler) {
+		return
+	}
+	w.logger.Infof("Worker pool stops backing off from component reconciler '%s' because it accepts operations again",
+		reconciler)
+	if w.metricsCollector != nil {
+		w.metricsCollector.OnReconcilerBackoff(reconciler, 0)
+	}
+}
+
+// filterThrottledOps drops operations of component reconcilers which are backing off. Component reconcilers which
+// reported a full occupancy are throttled as well.
+func (w *Pool) filterThrottledOps(ops []*model.OperationEntity) []*model.OperationEntity {
+	if w.backpressure == nil {
+		return ops
+	}
+
+	now := time.Now()
+	throttled := make(map[string]bool)
+	var filteredOps []*model.OperationEntity
+	for _, op := range ops {
+		reconciler := w.backpressure.reconciler(op.Component)
+		isThrottled, checked := throttled[reconciler]
+		if !checked {
+			isThrottled = w.backpressure.isThrottled(reconciler, now)
+			if !isThrottled {
+				fullyOccupied, err := w.backpressure.isFullyOccupied(reconciler)
+				if err != nil { //component reconcilers without reported occupancy aren't throttled
+					w.logger.Debugf("Worker pool failed to retrieve occupancy of component reconciler '%s': %s",
+						reconciler, err)
+				}
+				if fullyOccupied {
+					w.onReconcilerOverloaded(reconciler, 0)
+					isThrottled = true
+				}
+			}
+			throttled[reconciler] = isThrottled
+		}
+		if isThrottled {
+			continue
+		}
+		filteredOps = append(filteredOps, op)
+	}
+
+	if skipped := len(ops) - len(filteredOps); skipped > 0 {
+		w.logger.Infof("Worker pool holds back %d operations because their component reconcilers are overloaded",
+			skipped)
+	}
+	return filteredOps
+}
+
+// filterTenantQuotaOps drops operations of tenants which exhausted their quota of parallel operations or
+// operations per minute.
+func (w *Pool) filterTenantQuotaOps(ops []*model.OperationEntity) []*model.OperationEntity {
+	if w.tenantQuotas == nil || len(ops) == 0 {
+		return ops
+	}
+
+	overrides, err := w.tenantQuotas.quotas()
+	if err != nil {
+		w.logger.Warnf("Worker pool failed to retrieve tenant quotas and applies the default quota to all tenants: %s",
+			err)
+	}
+	tenants := make(map[string]string) //cache of the resolved tenants per cluster
+	running, err := w.runningOpsByTenant(tenants)
+	if err != nil {
+		w.logger.Warnf("Worker pool failed to retrieve operations in progress: tenant quotas consider "+
+			"only operations assigned to workers: %s", err)
+	}
+
+	now := time.Now()
+	skipped := make(map[string]int)
+	var filteredOps []*model.OperationEntity
+	for _, op := range ops {
+		tenant := w.resolveTenant(op.RuntimeID, tenants)
+		if tenant != "" && !w.tenantQuotas.reserve(tenant, op.CorrelationID, running[tenant],
+			w.tenantQuotas.quota(tenant, overrides), now) {
+			skipped[tenant]++
+			continue
+		}
+		filteredOps = append(filteredOps, op)
+	}
+
+	for tenant, skippedCnt := range skipped {
+		w.logger.Infof("Worker pool holds back %d operations of tenant '%s' because the tenant exhausted its quota",
+			skippedCnt, tenant)
+	}
+	return filteredOps
+}
+
+func (w *Pool) runningOpsByTenant(tenants map[string]string) (map[string]int, error) {
+	ops, err := w.reconRepo.GetOperations(&operation.WithStates{
+		States: []model.OperationState{model.OperationStateInProgress},
+	})
+	if err != nil {
+		return nil, err
+	}
+	running := make(map[string]int)
+	for _, op := range ops {
+		if w.tenantQuotas.isAssigned(op.CorrelationID) { //already counted by the tenant quotas
+			continue
+		}
+		if tenant := w.resolveTenant(op.RuntimeID, tenants); tenant != "" {
+			running[tenant]++
+		}
+	}
+	return running, nil
+}
+
+func (w *Pool) resolveTenant(runtimeID string, tenants map[string]string) string {
+	tenant, ok := tenants[runtimeID]
+	if !ok {
+		var err error
+		tenant, err = w.tenantQuotas.tenantOf(runtimeID)
+		if err != nil { //operations of clusters without resolvable tenant aren't limited
+			w.logger.Debugf("Worker pool failed to resolve tenant of cluster '%s': %s", runtimeID, err)
+		}
+		tenants[runtimeID] = tenant
+	}
+	return tenant
+}
+
+func (w *Pool) releaseTenantQuota(ops ...*model.OperationEntity) {
+	if w.tenantQuotas == nil {
+		return
+	}
+	for _, op := range ops {
+		w.tenantQuotas.release(op.CorrelationID)
+	}
+}
+
+// tunePoolSize adjusts the capacity of the worker pool if its size was reloaded: running workers are not interrupted
+func (w *Pool) tunePoolSize() {
+	if size := w.config.poolSize(); size != w.antsPool.Cap() {
+		w.logger.Infof("Changing capacity of worker pool from %d to %d workers", w.antsPool.Cap(), size)
+		w.antsPool.Tune(size)
 	}
 }
 
 func (w *Pool) invokeProcessableOps() (int, error) {
+	w.tunePoolSize()
 	w.logger.Debugf("Worker pool is checking for processable operations (max parallel ops per cluster: %d)",
 		w.config.MaxParallelOperations)
 	ops, err := w.reconRepo.GetProcessableOperations(w.config.MaxParallelOperations)
@@ -148,7 +376,13 @@ func (w *Pool) invokeProcessableOps() (int, error) {
 	}
 
 	ops = w.filterProcessableOpsByMaxRetries(ops)
+	ops = w.filterFrozenOps(ops)
+	ops = w.filterThrottledOps(ops)
+	ops = w.filterTenantQuotaOps(ops)
 	opsCnt := len(ops)
+	if w.metricsCollector != nil {
+		w.metricsCollector.OnProcessableOperations(opsCnt)
+	}
 	w.logger.Debugf("Worker pool found %d processable operations: %s", opsCnt, func() string {
 		var opNames []string
 		for _, op := range ops {
@@ -165,14 +399,19 @@ func (w *Pool) invokeProcessableOps() (int, error) {
 		if w.antsPool.Free() == 0 {
 			remainingOpsCnt := opsCnt - idx
 			w.logger.Warnf("could not assign %d operations to workers because workerpool capacity reached: capacity=%d", remainingOpsCnt, w.antsPool.Cap())
+			w.releaseTenantQuota(ops[idx:]...)
 			break
 		}
 		op := ops[idx]
 		if err := w.antsPool.Invoke(op); err == nil {
+			if w.metricsCollector != nil {
+				w.metricsCollector.OnOperationAssigned(op)
+			}
 			w.logger.Debugf("Worker pool assigned worker to reconcile component '%s' on cluster '%s' (%s)",
 				op.Component, op.RuntimeID, op)
 		} else {
 			w.logger.Warnf("Worker pool failed to assign worker to operation '%s': %s", op, err)
+			w.releaseTenantQuota(ops[idx:]...)
 			return idx + 1, err
 		}
 		idx++
@@ -242,7 +481,7 @@ func (w *Pool) RunningWorkers() (int, error) {
 }
 
 func (w *Pool) Size() int {
-	return w.config.PoolSize
+	return w.config.poolSize()
 }
 
 func (w *Pool) RegisterObserver(observer occupancy.Observer) {
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	HeaderJWT        = "X-Jwt"
+	redactedValue    = "REDACTED"
+	anonymousCaller  = "anonymous"
+	maxLoggedBodyLen = 64 << 10
+)
+
+// fields whose values are never logged (matched case-insensitively as part of the field name)
+var sensitiveFields = []string{"kubeconfig", "secret", "password", "passwd", "token", "credential", "privatekey", "apikey"}
+
+// AccessLogConfig defines whether the requests processed by a webserver are logged
+type AccessLogConfig struct {
+	Enabled bool
+	Bodies  bool //request bodies are logged on debug level (kubeconfigs and secrets are redacted)
+}
+
+// Handler logs method, path, caller, latency and response code of each request after it was processed by the next
+// handler. A nil or disabled config passes the requests through.
+func (c *AccessLogConfig) Handler(next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	if c == nil || !c.Enabled {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if c.Bodies && r.Body != nil && logger.Desugar().Core().Enabled(zapcore.DebugLevel) {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			if err != nil {
+				//the size limit of the request got exceeded: the next handler gets the same error
+				r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+				body = nil
+			} else {
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w}
+		start := time.Now()
+		next.ServeHTTP(recorder, r)
+
+		keysAndValues := []interface{}{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"caller", CallerIdentity(r),
+			"remoteAddr", r.RemoteAddr,
+			"status", recorder.Status(),
+			"latency", time.Since(start).String(),
+		}
+		if len(body) > 0 {
+			logger.Debugw("HTTP request processed", append(keysAndValues, "body", RedactBody(body))...)
+			return
+		}
+		logger.Infow("HTTP request processed", keysAndValues...)
+	})
+}
+
+// CallerIdentity returns the subject of the JWT forwarded by the ingress gateway or the common name of the
+// client certificate. Callers which didn't authenticate are anonymous.
+func CallerIdentity(r *http.Request) string {
+	if jwtPayload := r.Header.Get(HeaderJWT); jwtPayload != "" {
+		//only the payload of the JWT is forwarded: its signature got already verified by the gateway
+		decoded, err := base64.RawURLEncoding.DecodeString(jwtPayload)
+		if err == nil {
+			var claims struct {
+				Sub string `json:"sub"`
+			}
+			if json.Unmarshal(decoded, &claims) == nil && claims.Sub != "" {
+				return claims.Sub
+			}
+		}
+	}
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 && r.TLS.PeerCertificates[0].Subject.CommonName != "" {
+		return r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	return anonymousCaller
+}
+
+// RedactBody returns the JSON body with redacted kubeconfigs and secrets. Non-JSON bodies are not logged at all
+// as their sensitive parts cannot be detected.
+func RedactBody(body []byte) string {
+	var payload interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return fmt.Sprintf("<non-JSON body of %d bytes>", len(body))
+	}
+	redacted, err := json.Marshal(redact(payload))
+	if err != nil {
+		return fmt.Sprintf("<body of %d bytes>", len(body))
+	}
+	if len(redacted) > maxLoggedBodyLen {
+		return fmt.Sprintf("%s...(truncated %d bytes)", redacted[:maxLoggedBodyLen], len(redacted)-maxLoggedBodyLen)
+	}
+	return string(redacted)
+}
+
+func redact(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		//configuration entries can be flagged as secret (e.g. {"key": "...", "value": "...", "secret": true})
+		secretEntry, _ := typed["secret"].(bool)
+		for field, fieldValue := range typed {
+			if _, isFlag := fieldValue.(bool); isFlag {
+				continue
+			}
+			if isSensitiveField(field) || (secretEntry && field == "value") {
+				typed[field] = redactedValue
+				continue
+			}
+			typed[field] = redact(fieldValue)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redact(item)
+		}
+	}
+	return value
+}
+
+func isSensitiveField(field string) bool {
+	field = strings.ToLower(field)
+	for _, sensitiveField := range sensitiveFields {
+		if strings.Contains(field, sensitiveField) {
+			return true
+		}
+	}
+	return false
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *statusRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+	return r.status
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
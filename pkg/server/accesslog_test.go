@@ -0,0 +1,86 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAccessLog(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	})
+	send := func(config *AccessLogConfig, level zapcore.Level, body string) (*httptest.ResponseRecorder, []observer.LoggedEntry) {
+		core, logs := observer.New(level)
+		req := httptest.NewRequest(http.MethodPost, "/v1/clusters", strings.NewReader(body))
+		req.Header.Set(HeaderJWT, base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"jane@example.com"}`)))
+		resp := httptest.NewRecorder()
+		config.Handler(echo, zap.New(core).Sugar()).ServeHTTP(resp, req)
+		return resp, logs.All()
+	}
+	const body = `{"runtimeID":"1","kubeconfig":"abc","configuration":[{"key":"a","value":"plain","secret":false},` +
+		`{"key":"b","value":"hidden","secret":true}],"credentials":{"user":"x"}}`
+
+	t.Run("Requests are logged", func(t *testing.T) {
+		resp, logs := send(&AccessLogConfig{Enabled: true}, zapcore.InfoLevel, body)
+		require.Equal(t, body, resp.Body.String())
+		require.Len(t, logs, 1)
+		fields := logs[0].ContextMap()
+		require.Equal(t, http.MethodPost, fields["method"])
+		require.Equal(t, "/v1/clusters", fields["path"])
+		require.Equal(t, "jane@example.com", fields["caller"])
+		require.Equal(t, int64(http.StatusCreated), fields["status"])
+		require.NotEmpty(t, fields["latency"])
+		require.NotContains(t, fields, "body")
+	})
+
+	t.Run("Bodies are redacted", func(t *testing.T) {
+		resp, logs := send(&AccessLogConfig{Enabled: true, Bodies: true}, zapcore.DebugLevel, body)
+		require.Equal(t, body, resp.Body.String())
+		require.Len(t, logs, 1)
+		require.Equal(t, zapcore.DebugLevel, logs[0].Level)
+		logged := logs[0].ContextMap()["body"].(string)
+		require.Contains(t, logged, `"kubeconfig":"REDACTED"`)
+		require.Contains(t, logged, `"credentials":"REDACTED"`)
+		require.Contains(t, logged, `"value":"plain"`)
+		require.NotContains(t, logged, "hidden")
+		require.Contains(t, logged, `"runtimeID":"1"`)
+	})
+
+	t.Run("Bodies require debug level", func(t *testing.T) {
+		_, logs := send(&AccessLogConfig{Enabled: true, Bodies: true}, zapcore.InfoLevel, body)
+		require.Len(t, logs, 1)
+		require.NotContains(t, logs[0].ContextMap(), "body")
+	})
+
+	t.Run("Non-JSON bodies are not logged", func(t *testing.T) {
+		require.Equal(t, "<non-JSON body of 14 bytes>", RedactBody([]byte("kubeconfig: ab")))
+	})
+
+	t.Run("Disabled access log", func(t *testing.T) {
+		_, logs := send(nil, zapcore.DebugLevel, body)
+		require.Empty(t, logs)
+		_, logs = send(&AccessLogConfig{}, zapcore.DebugLevel, body)
+		require.Empty(t, logs)
+	})
+
+	t.Run("Caller identity", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/clusters", nil)
+		require.Equal(t, "anonymous", CallerIdentity(req))
+		req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "mothership"}}}}
+		require.Equal(t, "mothership", CallerIdentity(req))
+	})
+}
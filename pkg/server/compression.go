@@ -0,0 +1,148 @@
+package server
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// DecompressRequest decodes gzip or deflate encoded request bodies before they are passed to the next handler. The
+// decoded body is limited to maxBytes (0 = unlimited) to reject small payloads which expand to huge documents.
+func DecompressRequest(next http.Handler, maxBytes int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+		if encoding == "" || encoding == "identity" || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var decoder io.ReadCloser
+		var err error
+		switch encoding {
+		case encodingGzip, "x-gzip":
+			decoder, err = gzip.NewReader(r.Body)
+		case encodingDeflate:
+			decoder, err = zlib.NewReader(r.Body)
+		default:
+			http.Error(w, fmt.Sprintf("content encoding '%s' is not supported (use '%s' or '%s')",
+				encoding, encodingGzip, encodingDeflate), http.StatusUnsupportedMediaType)
+			return
+		}
+		if err != nil {
+			if tooLarge := RequestTooLargeError(err); tooLarge != nil {
+				http.Error(w, tooLarge.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, fmt.Sprintf("request body is not %s encoded: %s", encoding, err), http.StatusBadRequest)
+			return
+		}
+
+		body := io.ReadCloser(decoder)
+		if maxBytes > 0 {
+			body = http.MaxBytesReader(w, decoder, maxBytes)
+		}
+		r.Body = body
+		r.Header.Del("Content-Encoding")
+		r.Header.Del("Content-Length")
+		r.ContentLength = -1
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CompressResponse encodes the response with gzip or deflate if the client accepts it. It's meant for endpoints
+// returning large documents, e.g. lists of clusters or reconciliations.
+func CompressResponse(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := acceptedEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next(w, r)
+			return
+		}
+
+		//HTTP defines 'deflate' as zlib format
+		var encoder io.WriteCloser = zlib.NewWriter(w)
+		if encoding == encodingGzip {
+			encoder = gzip.NewWriter(w)
+		}
+		compressed := &compressedResponseWriter{ResponseWriter: w, encoder: encoder, encoding: encoding}
+		next(compressed, r)
+		if compressed.encoded {
+			_ = encoder.Close() //flushes the remaining data
+		}
+	}
+}
+
+// acceptedEncoding returns the preferred encoding supported by the client (empty if it accepts only identity)
+func acceptedEncoding(acceptEncoding string) string {
+	var deflateAccepted bool
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		encoding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if len(fields) > 1 && strings.ReplaceAll(strings.TrimSpace(fields[1]), " ", "") == "q=0" {
+			continue
+		}
+		switch encoding {
+		case encodingGzip, "*":
+			return encodingGzip
+		case encodingDeflate:
+			deflateAccepted = true
+		}
+	}
+	if deflateAccepted {
+		return encodingDeflate
+	}
+	return ""
+}
+
+// compressedResponseWriter sets the content encoding header when the handler starts to write the response. Responses
+// without body (e.g. '204 No Content') are sent unencoded.
+type compressedResponseWriter struct {
+	http.ResponseWriter
+	encoder     io.WriteCloser
+	encoding    string
+	wroteHeader bool
+	encoded     bool
+}
+
+func (w *compressedResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if status != http.StatusNoContent && status != http.StatusNotModified {
+		w.Header().Set("Content-Encoding", w.encoding)
+		w.Header().Del("Content-Length")
+		w.encoded = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *compressedResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.encoded {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.encoder.Write(data)
+}
+
+// RequestTooLargeError returns a descriptive error if err was caused by a request body exceeding its size
+// limit (nil otherwise)
+func RequestTooLargeError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fmt.Errorf("request body exceeds the limit of %d bytes", maxBytesErr.Limit)
+	}
+	return nil
+}
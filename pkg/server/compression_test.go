@@ -0,0 +1,89 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression(t *testing.T) {
+	payload := strings.Repeat(`{"kubeconfig":"abc"}`, 100)
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if tooLarge := RequestTooLargeError(err); tooLarge != nil {
+			http.Error(w, tooLarge.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		_, _ = w.Write(body)
+	})
+	compress := func(encoding string) io.Reader {
+		var buf bytes.Buffer
+		var writer io.WriteCloser = gzip.NewWriter(&buf)
+		if encoding == "deflate" {
+			writer = zlib.NewWriter(&buf)
+		}
+		_, _ = writer.Write([]byte(payload))
+		require.NoError(t, writer.Close())
+		return &buf
+	}
+	send := func(handler http.Handler, body io.Reader, header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/run", body)
+		req.Header.Set(header, value)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Compressed requests are decoded", func(t *testing.T) {
+		handler := DecompressRequest(echo, 0)
+		for _, encoding := range []string{"gzip", "deflate"} {
+			resp := send(handler, compress(encoding), "Content-Encoding", encoding)
+			require.Equal(t, http.StatusOK, resp.Code, encoding)
+			require.Equal(t, payload, resp.Body.String(), encoding)
+		}
+		require.Equal(t, payload, send(handler, strings.NewReader(payload), "Content-Type", "application/json").Body.String())
+	})
+
+	t.Run("Decoded requests are limited", func(t *testing.T) {
+		resp := send(DecompressRequest(echo, 100), compress("gzip"), "Content-Encoding", "gzip")
+		require.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+		require.Contains(t, resp.Body.String(), "exceeds the limit of 100 bytes")
+	})
+
+	t.Run("Invalid encodings are rejected", func(t *testing.T) {
+		handler := DecompressRequest(echo, 0)
+		require.Equal(t, http.StatusUnsupportedMediaType, send(handler, compress("gzip"), "Content-Encoding", "br").Code)
+		require.Equal(t, http.StatusBadRequest, send(handler, strings.NewReader(payload), "Content-Encoding", "gzip").Code)
+	})
+
+	t.Run("Responses are compressed if accepted", func(t *testing.T) {
+		handler := CompressResponse(echo)
+
+		resp := send(handler, strings.NewReader(payload), "Accept-Encoding", "br, gzip;q=0.8")
+		require.Equal(t, "gzip", resp.Header().Get("Content-Encoding"))
+		reader, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, payload, string(body))
+
+		resp = send(handler, strings.NewReader(payload), "Accept-Encoding", "deflate")
+		require.Equal(t, "deflate", resp.Header().Get("Content-Encoding"))
+		reader2, err := zlib.NewReader(resp.Body)
+		require.NoError(t, err)
+		body, err = io.ReadAll(reader2)
+		require.NoError(t, err)
+		require.Equal(t, payload, string(body))
+
+		resp = send(handler, strings.NewReader(payload), "Accept-Encoding", "gzip;q=0")
+		require.Empty(t, resp.Header().Get("Content-Encoding"))
+		require.Equal(t, payload, resp.Body.String())
+	})
+}
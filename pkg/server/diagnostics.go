@@ -0,0 +1,21 @@
+package server
+
+import (
+	"expvar"
+	"net/http/pprof"
+
+	"github.com/gorilla/mux"
+)
+
+// NewDiagnosticsRouter returns a router exposing the runtime profiles (pprof) and the exported
+// variables (expvar) of the process. It's meant to be served on a separate admin port only.
+func NewDiagnosticsRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	router.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	router.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	router.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	router.PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index) //serves also named profiles like heap or goroutine
+	router.Handle("/debug/vars", expvar.Handler())
+	return router
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticsRouter(t *testing.T) {
+	router := NewDiagnosticsRouter()
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/goroutine?debug=1", "/debug/pprof/heap", "/debug/vars"} {
+		t.Run(path, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, http.StatusOK, resp.Code)
+			require.NotEmpty(t, resp.Body.String())
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package server
+
+import (
+	"bytes"
+	"container/heap"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	headerReplayed       = "Idempotent-Replayed"
+)
+
+// IdempotencyCache remembers the successful responses of requests carrying an Idempotency-Key header: a request
+// which is sent again with the same key (e.g. by a network retry) isn't processed twice but gets the original
+// response. Failed requests aren't remembered: sending them again processes them again.
+// Responses are kept in memory, so duplicates are only detected if they reach the same replica: clients must not
+// rely on the cache to resend requests which could be received by another replica.
+type IdempotencyCache struct {
+	retention time.Duration
+	mu        sync.Mutex
+	responses map[string]*idempotentResponse //key: path + idempotency key
+	expiry    expiryQueue                    //stored responses ordered by expiration
+}
+
+type idempotentResponse struct {
+	key     string
+	done    chan struct{} //closed when the original request was processed
+	stored  bool
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// NewIdempotencyCache returns nil if the retention is <= 0: the handler of a nil cache doesn't deduplicate requests
+func NewIdempotencyCache(retention time.Duration) *IdempotencyCache {
+	if retention <= 0 {
+		return nil
+	}
+	return &IdempotencyCache{
+		retention: retention,
+		responses: make(map[string]*idempotentResponse),
+	}
+}
+
+// Handler deduplicates the requests which are passed to the next handler. Duplicates of a request which is still
+// processed wait for its response.
+func (c *IdempotencyCache) Handler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey := r.Header.Get(HeaderIdempotencyKey)
+		if c == nil || idempotencyKey == "" {
+			next(w, r)
+			return
+		}
+		key := r.URL.Path + "#" + idempotencyKey
+		for {
+			resp, original := c.reserve(key)
+			if original {
+				c.process(key, resp, next, w, r)
+				return
+			}
+			select {
+			case <-resp.done:
+			case <-r.Context().Done():
+				return
+			}
+			if resp.stored {
+				resp.replay(w)
+				return
+			}
+			//original request failed: process the duplicate again
+		}
+	}
+}
+
+// reserve returns the response of the key and whether the caller has to process the request
+func (c *IdempotencyCache) reserve(key string) (*idempotentResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for len(c.expiry) > 0 && now.After(c.expiry[0].expires) {
+		delete(c.responses, heap.Pop(&c.expiry).(*idempotentResponse).key)
+	}
+	if resp, ok := c.responses[key]; ok {
+		return resp, false
+	}
+	resp := &idempotentResponse{key: key, done: make(chan struct{})}
+	c.responses[key] = resp
+	return resp, true
+}
+
+func (c *IdempotencyCache) process(key string, resp *idempotentResponse, next http.HandlerFunc,
+	w http.ResponseWriter, r *http.Request) {
+	recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if recorder.status >= 200 && recorder.status < 300 {
+			resp.stored = true
+			resp.status = recorder.status
+			resp.header = w.Header().Clone()
+			resp.body = recorder.body.Bytes()
+			resp.expires = time.Now().Add(c.retention)
+			heap.Push(&c.expiry, resp)
+		} else {
+			delete(c.responses, key)
+		}
+		close(resp.done)
+	}()
+	next(recorder, r)
+}
+
+func (resp *idempotentResponse) replay(w http.ResponseWriter) {
+	for name, values := range resp.header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(headerReplayed, "true")
+	w.WriteHeader(resp.status)
+	_, _ = w.Write(resp.body)
+}
+
+// expiryQueue is a min-heap of stored responses: the response which expires next is the first element
+type expiryQueue []*idempotentResponse
+
+func (q expiryQueue) Len() int {
+	return len(q)
+}
+
+func (q expiryQueue) Less(i, j int) bool {
+	return q[i].expires.Before(q[j].expires)
+}
+
+func (q expiryQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *expiryQueue) Push(x interface{}) {
+	*q = append(*q, x.(*idempotentResponse))
+}
+
+func (q *expiryQueue) Pop() interface{} {
+	old := *q
+	resp := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return resp
+}
+
+// responseRecorder captures the response while it's written to the client
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
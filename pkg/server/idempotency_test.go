@@ -0,0 +1,108 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	newHandler := func(cache *IdempotencyCache, status int, calls *int32) http.HandlerFunc {
+		return cache.Handler(func(w http.ResponseWriter, r *http.Request) {
+			call := atomic.AddInt32(calls, 1)
+			time.Sleep(20 * time.Millisecond)
+			w.WriteHeader(status)
+			_, _ = fmt.Fprintf(w, "call %d", call)
+		})
+	}
+	send := func(handler http.HandlerFunc, idempotencyKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/run", nil)
+		if idempotencyKey != "" {
+			req.Header.Set(HeaderIdempotencyKey, idempotencyKey)
+		}
+		resp := httptest.NewRecorder()
+		handler(resp, req)
+		return resp
+	}
+
+	t.Run("Duplicates get the original response", func(t *testing.T) {
+		var calls int32
+		handler := newHandler(NewIdempotencyCache(time.Minute), http.StatusOK, &calls)
+
+		original := send(handler, "key1")
+		duplicate := send(handler, "key1")
+		require.Equal(t, "call 1", duplicate.Body.String())
+		require.Equal(t, original.Code, duplicate.Code)
+		require.Equal(t, "true", duplicate.Header().Get(headerReplayed))
+
+		require.Equal(t, "call 2", send(handler, "key2").Body.String())
+		require.Equal(t, "call 3", send(handler, "").Body.String())
+		require.Equal(t, int32(3), calls)
+	})
+
+	t.Run("Concurrent duplicates wait for the original response", func(t *testing.T) {
+		var calls int32
+		handler := newHandler(NewIdempotencyCache(time.Minute), http.StatusOK, &calls)
+
+		bodies := make([]string, 5)
+		var wg sync.WaitGroup
+		for i := range bodies {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				bodies[i] = send(handler, "key").Body.String()
+			}(i)
+		}
+		wg.Wait()
+		require.Equal(t, []string{"call 1", "call 1", "call 1", "call 1", "call 1"}, bodies)
+		require.Equal(t, int32(1), calls)
+	})
+
+	t.Run("Failed requests are processed again", func(t *testing.T) {
+		var calls int32
+		handler := newHandler(NewIdempotencyCache(time.Minute), http.StatusTooManyRequests, &calls)
+
+		require.Equal(t, http.StatusTooManyRequests, send(handler, "key").Code)
+		require.Equal(t, "call 2", send(handler, "key").Body.String())
+	})
+
+	t.Run("Responses expire after the retention", func(t *testing.T) {
+		var calls int32
+		handler := newHandler(NewIdempotencyCache(10*time.Millisecond), http.StatusOK, &calls)
+
+		send(handler, "key")
+		time.Sleep(20 * time.Millisecond)
+		require.Equal(t, "call 2", send(handler, "key").Body.String())
+	})
+
+	t.Run("Expired responses are removed", func(t *testing.T) {
+		var calls int32
+		cache := NewIdempotencyCache(10 * time.Millisecond)
+		handler := newHandler(cache, http.StatusOK, &calls)
+
+		send(handler, "key1")
+		send(handler, "key2")
+		require.Len(t, cache.responses, 2)
+		require.Len(t, cache.expiry, 2)
+
+		time.Sleep(20 * time.Millisecond)
+		send(handler, "key3")
+		require.Len(t, cache.responses, 1)
+		require.Len(t, cache.expiry, 1)
+		require.Contains(t, cache.responses, "/v1/run#key3")
+	})
+
+	t.Run("Disabled cache processes all requests", func(t *testing.T) {
+		var calls int32
+		handler := newHandler(NewIdempotencyCache(0), http.StatusOK, &calls)
+
+		send(handler, "key")
+		require.Equal(t, "call 2", send(handler, "key").Body.String())
+	})
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/pkg/errors"
+)
+
+const paramLogScope = "scope"
+
+// HTTPLogLevelRequest is the payload used to change the log level of a scope at runtime
+type HTTPLogLevelRequest struct {
+	Scope string `json:"scope"` //empty scope changes the global log level
+	Level string `json:"level"`
+}
+
+// HTTPLogLevelResponse lists the currently active log levels (the global level is mapped to an empty scope)
+type HTTPLogLevelResponse struct {
+	Levels map[string]string `json:"levels"`
+}
+
+type httpErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// LogLevelHandler lists (GET), changes (PUT) or resets (DELETE, scope passed as query parameter)
+// log levels at runtime.
+func LogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		var req HTTPLogLevelRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1024)).Decode(&req); err != nil {
+			SendHTTPError(w, http.StatusBadRequest, &httpErrorResponse{
+				Error: errors.Wrap(err, "Failed to unmarshal JSON payload").Error(),
+			})
+			return
+		}
+		if err := logger.SetLevel(req.Scope, req.Level); err != nil {
+			SendHTTPError(w, http.StatusBadRequest, &httpErrorResponse{Error: err.Error()})
+			return
+		}
+		logger.NewLogger(false).Infof("Log level of scope '%s' changed to '%s'", req.Scope, req.Level)
+	case http.MethodDelete:
+		scope, err := NewParams(r).String(paramLogScope)
+		if err != nil || scope == logger.GlobalScope {
+			SendHTTPError(w, http.StatusBadRequest, &httpErrorResponse{
+				Error: "scope of the log level to reset is undefined",
+			})
+			return
+		}
+		logger.ResetLevel(scope)
+	}
+
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(&HTTPLogLevelResponse{Levels: logger.Levels()}); err != nil {
+		SendHTTPError(w, http.StatusInternalServerError, &httpErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode response payload to JSON").Error(),
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	OpenAPIPath   = "/openapi.json"
+	SwaggerUIPath = "/swagger-ui"
+
+	//routes of the versioned APIs start with this prefix whereas the paths in the specs are relative to it
+	versionPrefix = "/v{}"
+)
+
+var pathParamRegex = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>%s</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({url: "%s", dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>
+`
+
+type documentedOperation struct {
+	operation  map[string]interface{}
+	pathParams []string
+}
+
+// AddOpenAPIRoutes serves the OpenAPI spec of all routes registered at the router and a Swagger UI rendering it.
+// The spec is generated when it's requested the first time, so all routes have to be registered by then.
+func AddOpenAPIRoutes(router *mux.Router, title string, specs ...[]byte) {
+	var once sync.Once
+	var spec []byte
+	var specErr error
+	router.HandleFunc(OpenAPIPath, func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			spec, specErr = GenerateOpenAPISpec(router, title, specs...)
+		})
+		if specErr != nil {
+			SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(specErr, "failed to generate OpenAPI spec").Error(),
+			})
+			return
+		}
+		w.Header().Set("content-type", "application/json")
+		_, _ = w.Write(spec)
+	}).Methods(http.MethodGet)
+	router.HandleFunc(SwaggerUIPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "text/html")
+		_, _ = fmt.Fprintf(w, swaggerUIPage, title, OpenAPIPath)
+	}).Methods(http.MethodGet)
+}
+
+// GenerateOpenAPISpec creates an OpenAPI 3 spec (JSON) of all routes registered at the router.
+// Routes which are described by one of the given specs (YAML or JSON) are documented with the operation
+// of the spec, all other routes get a generic operation.
+func GenerateOpenAPISpec(router *mux.Router, title string, specs ...[]byte) ([]byte, error) {
+	documented := make(map[string]*documentedOperation)
+	components := make(map[string]interface{})
+	for _, spec := range specs {
+		if err := parseOpenAPISpec(spec, documented, components); err != nil {
+			return nil, err
+		}
+	}
+
+	paths := make(map[string]interface{})
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil //just a subrouter
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil || template == OpenAPIPath || template == SwaggerUIPath {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{http.MethodGet}
+		}
+
+		path := pathParamRegex.ReplaceAllString(template, "{$1}")
+		pathParams := pathParamNames(path)
+		specPath, specPathParams := normalizePath(path), pathParams
+		if strings.HasPrefix(specPath, versionPrefix) {
+			specPath = strings.TrimPrefix(specPath, versionPrefix)
+			specPathParams = pathParams[1:]
+		}
+
+		pathItem, ok := paths[path].(map[string]interface{})
+		if !ok {
+			pathItem = make(map[string]interface{})
+			paths[path] = pathItem
+		}
+		for _, method := range methods {
+			method = strings.ToLower(method)
+			operation := map[string]interface{}{
+				"summary": "Undocumented operation",
+				"responses": map[string]interface{}{
+					"default": map[string]interface{}{"description": "Response of the operation"},
+				},
+			}
+			if docOp, ok := documented[specPath+"|"+method]; ok {
+				if operation, err = renamePathParams(docOp, specPathParams); err != nil {
+					return err
+				}
+			}
+			addMissingPathParams(operation, pathParams)
+			pathItem[method] = operation
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   title,
+			"version": "1.0.0",
+		},
+		"paths":      paths,
+		"components": components,
+	})
+}
+
+func parseOpenAPISpec(spec []byte, documented map[string]*documentedOperation, components map[string]interface{}) error {
+	specJSON, err := yaml.YAMLToJSON(spec)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert OpenAPI spec to JSON")
+	}
+	var doc struct {
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components map[string]map[string]interface{} `json:"components"`
+	}
+	if err := json.Unmarshal(specJSON, &doc); err != nil {
+		return errors.Wrap(err, "failed to parse OpenAPI spec")
+	}
+
+	for path, pathItem := range doc.Paths {
+		for method, operation := range pathItem {
+			op, ok := operation.(map[string]interface{})
+			if !ok {
+				continue //e.g. parameters shared by all operations of the path
+			}
+			documented[normalizePath(path)+"|"+strings.ToLower(method)] = &documentedOperation{
+				operation:  op,
+				pathParams: pathParamNames(path),
+			}
+		}
+	}
+
+	//the specs are generated from the same models: if a component is defined multiple times, the first one wins
+	for section, entries := range doc.Components {
+		merged, ok := components[section].(map[string]interface{})
+		if !ok {
+			merged = make(map[string]interface{})
+			components[section] = merged
+		}
+		for name, entry := range entries {
+			if _, exists := merged[name]; !exists {
+				merged[name] = entry
+			}
+		}
+	}
+	return nil
+}
+
+// renamePathParams returns a copy of the documented operation which uses the path parameter names of the route
+func renamePathParams(docOp *documentedOperation, routeParams []string) (map[string]interface{}, error) {
+	data, err := json.Marshal(docOp.operation)
+	if err != nil {
+		return nil, err
+	}
+	operation := make(map[string]interface{})
+	if err := json.Unmarshal(data, &operation); err != nil {
+		return nil, err
+	}
+
+	params, _ := operation["parameters"].([]interface{})
+	for _, param := range params {
+		p, ok := param.(map[string]interface{})
+		if !ok || p["in"] != "path" {
+			continue
+		}
+		for idx, name := range docOp.pathParams {
+			if p["name"] == name && idx < len(routeParams) {
+				p["name"] = routeParams[idx]
+				break
+			}
+		}
+	}
+	return operation, nil
+}
+
+func addMissingPathParams(operation map[string]interface{}, pathParams []string) {
+	params, _ := operation["parameters"].([]interface{})
+	declared := make(map[string]bool)
+	for _, param := range params {
+		if p, ok := param.(map[string]interface{}); ok && p["in"] == "path" {
+			declared[fmt.Sprint(p["name"])] = true
+		}
+	}
+	for _, name := range pathParams {
+		if declared[name] {
+			continue
+		}
+		params = append(params, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string"},
+		})
+	}
+	if len(params) > 0 {
+		operation["parameters"] = params
+	}
+}
+
+func pathParamNames(path string) []string {
+	var names []string
+	for _, match := range pathParamRegex.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// normalizePath drops the names of the path parameters to compare paths independently of them
+func normalizePath(path string) string {
+	return pathParamRegex.ReplaceAllString(path, "{}")
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPI(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	router := mux.NewRouter()
+	router.HandleFunc("/v{version}/clusters", handler).Methods(http.MethodPost, http.MethodPut)
+	router.HandleFunc("/v{version}/clusters/{cluster}/status", handler).Methods(http.MethodGet)
+	router.HandleFunc("/v{version}/undocumented/{id:[0-9]+}", handler).Methods(http.MethodDelete)
+	router.Path("/metrics").Subrouter().Handle("", http.HandlerFunc(handler))
+	AddOpenAPIRoutes(router, "Test API", openapi.ExternalAPI, openapi.InternalAPI)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	t.Run("Spec documents registered routes", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + OpenAPIPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		spec := struct {
+			Info struct {
+				Title string `json:"title"`
+			} `json:"info"`
+			Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+			Components map[string]map[string]interface{}            `json:"components"`
+		}{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&spec))
+
+		require.Equal(t, "Test API", spec.Info.Title)
+		require.Len(t, spec.Paths, 4)
+		require.NotContains(t, spec.Paths, OpenAPIPath)
+		require.NotContains(t, spec.Paths, SwaggerUIPath)
+
+		//documented operation uses the path parameter names of the route
+		status := spec.Paths["/v{version}/clusters/{cluster}/status"]["get"]
+		require.NotEqual(t, "Undocumented operation", status["summary"])
+		require.ElementsMatch(t, []string{"cluster", "version"}, pathParams(status))
+		require.Contains(t, spec.Paths["/v{version}/clusters"], "post")
+		require.Contains(t, spec.Paths["/v{version}/clusters"], "put")
+
+		//undocumented operations are generated from the route
+		undocumented := spec.Paths["/v{version}/undocumented/{id}"]["delete"]
+		require.Equal(t, "Undocumented operation", undocumented["summary"])
+		require.ElementsMatch(t, []string{"id", "version"}, pathParams(undocumented))
+		require.Contains(t, spec.Paths["/metrics"], "get")
+
+		//components of all specs are merged
+		require.Contains(t, spec.Components["schemas"], "HTTPErrorResponse")
+		require.Contains(t, spec.Components["schemas"], "callbackMessage")
+	})
+
+	t.Run("Swagger UI", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + SwaggerUIPath)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), OpenAPIPath)
+	})
+
+	t.Run("Embedded specs are valid", func(t *testing.T) {
+		_, err := GenerateOpenAPISpec(mux.NewRouter(), "Test API", openapi.ExternalAPI, openapi.InternalAPI, openapi.ReconcilerAPI)
+		require.NoError(t, err)
+	})
+}
+
+func pathParams(operation map[string]interface{}) []string {
+	var names []string
+	for _, param := range operation["parameters"].([]interface{}) {
+		p := param.(map[string]interface{})
+		if p["in"] == "path" {
+			names = append(names, p["name"].(string))
+		}
+	}
+	return names
+}
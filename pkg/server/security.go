@@ -0,0 +1,111 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultMaxRequestBytes = 16 << 20
+
+// SecurityConfig defines the CORS policy and the limits applied to all requests of a webserver
+type SecurityConfig struct {
+	AllowedOrigins  []string //origins of browser-based clients allowed to call the API ('*' = any, empty = CORS disabled)
+	AllowedMethods  []string
+	AllowedHeaders  []string
+	CORSMaxAge      time.Duration //time browsers cache the result of a preflight request
+	MaxRequestBytes int64         //larger request bodies are rejected, also after decompression (0 = unlimited)
+}
+
+func DefaultSecurityConfig() *SecurityConfig {
+	return &SecurityConfig{
+		AllowedMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowedHeaders:  []string{"Content-Type", "Authorization", HeaderIdempotencyKey},
+		CORSMaxAge:      10 * time.Minute,
+		MaxRequestBytes: defaultMaxRequestBytes,
+	}
+}
+
+func (c *SecurityConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxRequestBytes < 0 || c.CORSMaxAge < 0 {
+		return fmt.Errorf("max request size and CORS max age cannot be < 0")
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			return fmt.Errorf("allowed origin '%s' has to be '*' or start with 'http://' or 'https://'", origin)
+		}
+	}
+	return nil
+}
+
+// Handler applies the security headers, the CORS policy and the request size limit before the request is passed
+// to the next handler. A nil config applies only the security headers.
+func (c *SecurityConfig) Handler(next http.Handler, tls bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := w.Header()
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-Frame-Options", "DENY")
+		header.Set("Referrer-Policy", "no-referrer")
+		if tls {
+			header.Set("Strict-Transport-Security", "max-age=31536000")
+		}
+		if c == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if origin := r.Header.Get("Origin"); origin != "" && len(c.AllowedOrigins) > 0 {
+			header.Add("Vary", "Origin")
+			if c.originAllowed(origin) {
+				if c.allowsAnyOrigin() {
+					header.Set("Access-Control-Allow-Origin", "*")
+				} else {
+					header.Set("Access-Control-Allow-Origin", origin)
+				}
+				if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+					header.Set("Access-Control-Allow-Methods", strings.Join(c.AllowedMethods, ", "))
+					header.Set("Access-Control-Allow-Headers", strings.Join(c.AllowedHeaders, ", "))
+					header.Set("Access-Control-Max-Age", strconv.Itoa(int(c.CORSMaxAge.Seconds())))
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+		}
+
+		if c.MaxRequestBytes > 0 && r.Body != nil {
+			if r.ContentLength > c.MaxRequestBytes {
+				http.Error(w, fmt.Sprintf("request body exceeds the limit of %d bytes", c.MaxRequestBytes),
+					http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, c.MaxRequestBytes)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *SecurityConfig) allowsAnyOrigin() bool {
+	for _, allowed := range c.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *SecurityConfig) originAllowed(origin string) bool {
+	if c.allowsAnyOrigin() {
+		return true
+	}
+	for _, allowed := range c.AllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
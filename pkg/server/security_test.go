@@ -0,0 +1,91 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityConfig(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		_, _ = w.Write(body)
+	})
+	send := func(handler http.Handler, method, origin, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/v1/clusters", strings.NewReader(body))
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("Security headers are always set", func(t *testing.T) {
+		var config *SecurityConfig
+		resp := send(config.Handler(echo, true), http.MethodGet, "", "")
+		require.Equal(t, "nosniff", resp.Header().Get("X-Content-Type-Options"))
+		require.Equal(t, "DENY", resp.Header().Get("X-Frame-Options"))
+		require.NotEmpty(t, resp.Header().Get("Strict-Transport-Security"))
+
+		resp = send(config.Handler(echo, false), http.MethodGet, "", "")
+		require.Empty(t, resp.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("CORS of allowed origins", func(t *testing.T) {
+		config := DefaultSecurityConfig()
+		config.AllowedOrigins = []string{"https://console.example.com"}
+		require.NoError(t, config.Validate())
+		handler := config.Handler(echo, false)
+
+		resp := send(handler, http.MethodOptions, "https://console.example.com", "")
+		require.Equal(t, http.StatusNoContent, resp.Code)
+		require.Equal(t, "https://console.example.com", resp.Header().Get("Access-Control-Allow-Origin"))
+		require.Contains(t, resp.Header().Get("Access-Control-Allow-Methods"), http.MethodPost)
+		require.Equal(t, "600", resp.Header().Get("Access-Control-Max-Age"))
+
+		resp = send(handler, http.MethodPost, "https://console.example.com", "payload")
+		require.Equal(t, "https://console.example.com", resp.Header().Get("Access-Control-Allow-Origin"))
+		require.Equal(t, "payload", resp.Body.String())
+
+		resp = send(handler, http.MethodOptions, "https://evil.example.com", "")
+		require.Empty(t, resp.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("CORS of any origin", func(t *testing.T) {
+		config := DefaultSecurityConfig()
+		config.AllowedOrigins = []string{"*"}
+		resp := send(config.Handler(echo, false), http.MethodGet, "https://console.example.com", "")
+		require.Equal(t, "*", resp.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("CORS is disabled by default", func(t *testing.T) {
+		resp := send(DefaultSecurityConfig().Handler(echo, false), http.MethodGet, "https://console.example.com", "")
+		require.Empty(t, resp.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Request size is limited", func(t *testing.T) {
+		config := DefaultSecurityConfig()
+		config.MaxRequestBytes = 5
+		handler := config.Handler(echo, false)
+		require.Equal(t, http.StatusRequestEntityTooLarge, send(handler, http.MethodPost, "", "too large").Code)
+		require.Equal(t, "small", send(handler, http.MethodPost, "", "small").Body.String())
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		config := DefaultSecurityConfig()
+		config.AllowedOrigins = []string{"console.example.com"}
+		require.Error(t, config.Validate())
+	})
+}
@@ -16,6 +16,8 @@ type Webserver struct {
 	SSLCrtFile string
 	SSLKeyFile string
 	Router     *mux.Router
+	Security   *SecurityConfig  //security headers are applied also if undefined
+	AccessLog  *AccessLogConfig //requests are not logged if undefined
 	server     *http.Server
 }
 
@@ -36,10 +38,15 @@ func (s *Webserver) Start(ctx context.Context) error {
 
 func (s *Webserver) startServer(router *mux.Router) {
 	//start server
-	s.server = &http.Server{Addr: fmt.Sprintf(":%d", s.Port), Handler: router, ReadHeaderTimeout: 5 * time.Second}
+	tls := s.SSLCrtFile != "" && s.SSLKeyFile != ""
+	s.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.Port),
+		Handler:           s.handler(router, tls),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
 	go func() {
 		var err error
-		if s.SSLCrtFile != "" && s.SSLKeyFile != "" {
+		if tls {
 			err = s.server.ListenAndServeTLS(s.SSLCrtFile, s.SSLKeyFile)
 		} else {
 			err = s.server.ListenAndServe()
@@ -50,6 +57,15 @@ func (s *Webserver) startServer(router *mux.Router) {
 	}()
 }
 
+// handler applies the security policy and decodes compressed requests before they get logged and routed
+func (s *Webserver) handler(router *mux.Router, tls bool) http.Handler {
+	var maxRequestBytes int64
+	if s.Security != nil {
+		maxRequestBytes = s.Security.MaxRequestBytes
+	}
+	return s.Security.Handler(DecompressRequest(s.AccessLog.Handler(router, s.logger()), maxRequestBytes), tls)
+}
+
 func (s *Webserver) stopServer() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer func() {
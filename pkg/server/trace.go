@@ -0,0 +1,44 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	headerTraceParent = "traceparent" //W3C trace context
+	headerB3TraceID   = "X-B3-TraceId"
+	headerB3Single    = "b3"
+)
+
+// TraceID returns the ID of the trace the request belongs to. The ID is only available if tracing is enabled
+// and the caller (or a service mesh proxy) propagated the trace context. An empty string is returned otherwise.
+func TraceID(r *http.Request) string {
+	//W3C format: {version}-{trace-id}-{parent-id}-{trace-flags}
+	if traceParent := strings.Split(r.Header.Get(headerTraceParent), "-"); len(traceParent) == 4 {
+		return validTraceID(traceParent[1])
+	}
+	if traceID := r.Header.Get(headerB3TraceID); traceID != "" {
+		return validTraceID(traceID)
+	}
+	//B3 single header format: {trace-id}-{span-id}-{sampling-state}-{parent-span-id}
+	if b3 := strings.Split(r.Header.Get(headerB3Single), "-"); len(b3) > 1 {
+		return validTraceID(b3[0])
+	}
+	return ""
+}
+
+func validTraceID(traceID string) string {
+	if len(traceID) != 16 && len(traceID) != 32 {
+		return ""
+	}
+	if strings.Trim(traceID, "0") == "" { //all-zero IDs are invalid
+		return ""
+	}
+	for _, c := range traceID {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return ""
+		}
+	}
+	return traceID
+}
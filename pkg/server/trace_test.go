@@ -0,0 +1,61 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceID(t *testing.T) {
+	testCases := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{
+			name: "No trace headers",
+			want: "",
+		},
+		{
+			name:    "W3C trace context",
+			headers: map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			want:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:    "W3C trace context with invalid trace ID",
+			headers: map[string]string{"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+			want:    "",
+		},
+		{
+			name:    "B3 multi header",
+			headers: map[string]string{"X-B3-TraceId": "463ac35c9f6413ad"},
+			want:    "463ac35c9f6413ad",
+		},
+		{
+			name:    "B3 single header",
+			headers: map[string]string{"b3": "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1"},
+			want:    "80f198ee56343ba864fe8b2a57d3eff7",
+		},
+		{
+			name:    "B3 single header with sampling decision only",
+			headers: map[string]string{"b3": "0"},
+			want:    "",
+		},
+		{
+			name:    "Malformed trace ID",
+			headers: map[string]string{"X-B3-TraceId": "not-a-trace-id"},
+			want:    "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, fakeURL, nil)
+			require.NoError(t, err)
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+			require.Equal(t, tc.want, TraceID(req))
+		})
+	}
+}
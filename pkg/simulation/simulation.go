@@ -0,0 +1,283 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/estimation"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+)
+
+// Change proposes a new Kyma version for a set of clusters
+type Change struct {
+	KymaVersion string
+	RuntimeIDs  []string //all clusters if empty
+}
+
+type Config struct {
+	PreComponents         [][]string
+	BootstrapComponents   []string
+	MaxParallelOperations int //parallel operations per cluster (zero means unlimited)
+	Workers               int //size of the worker pool (zero means unlimited)
+	HistorySamples        int //historical operations per component used for the estimation
+	DefaultDuration       time.Duration
+}
+
+// Plan is the result of a simulated change: clusters are reconciled in batches which fit into the worker pool
+type Plan struct {
+	KymaVersion       string
+	Clusters          []*ClusterPlan
+	Batches           []*Batch
+	Operations        int
+	EstimatedDuration time.Duration
+}
+
+// ClusterPlan contains the operations of a cluster ordered by their priority
+type ClusterPlan struct {
+	RuntimeID         string
+	CurrentVersion    string
+	Groups            []*Group
+	Operations        int
+	EstimatedDuration time.Duration
+	Error             string //reason why the change would be rejected for the cluster (e.g. a violated component pin)
+}
+
+// Group contains the operations which are processed in parallel: a group starts after all groups with a higher
+// priority are finished
+type Group struct {
+	Priority          int
+	Bootstrap         bool
+	Operations        []*Operation
+	EstimatedDuration time.Duration
+}
+
+type Operation struct {
+	Component         string
+	Version           string
+	CurrentVersion    string
+	EstimatedDuration time.Duration
+	Historical        bool //estimation is based on historical operations
+}
+
+type Batch struct {
+	RuntimeIDs        []string
+	Operations        int
+	Start             time.Duration //offset from the begin of the rollout
+	EstimatedDuration time.Duration
+}
+
+// Simulator calculates the operations caused by a change without executing anything
+type Simulator struct {
+	inventory cluster.Inventory
+	reconRepo reconciliation.Repository
+	config    *Config
+}
+
+func NewSimulator(inventory cluster.Inventory, reconRepo reconciliation.Repository, config *Config) *Simulator {
+	if config == nil {
+		config = &Config{}
+	}
+	return &Simulator{
+		inventory: inventory,
+		reconRepo: reconRepo,
+		config:    config,
+	}
+}
+
+func (s *Simulator) Simulate(change *Change) (*Plan, error) {
+	if change.KymaVersion == "" {
+		return nil, fmt.Errorf("simulation requires a Kyma version")
+	}
+	states, err := s.clusters(change.RuntimeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	estimator := estimation.NewEstimator(s.reconRepo, s.config.HistorySamples, s.config.DefaultDuration)
+	plan := &Plan{KymaVersion: change.KymaVersion}
+	for _, state := range states {
+		clusterPlan, err := s.clusterPlan(state, change.KymaVersion, estimator)
+		if err != nil {
+			return nil, err
+		}
+		plan.Clusters = append(plan.Clusters, clusterPlan)
+		plan.Operations += clusterPlan.Operations
+	}
+	plan.Batches = s.batches(plan.Clusters)
+	if len(plan.Batches) > 0 {
+		last := plan.Batches[len(plan.Batches)-1]
+		plan.EstimatedDuration = last.Start + last.EstimatedDuration
+	}
+	return plan, nil
+}
+
+func (s *Simulator) clusters(runtimeIDs []string) ([]*cluster.State, error) {
+	if len(runtimeIDs) == 0 {
+		states, err := s.inventory.GetAll()
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(states, func(i, j int) bool {
+			return states[i].Cluster.RuntimeID < states[j].Cluster.RuntimeID
+		})
+		return states, nil
+	}
+	states := make([]*cluster.State, 0, len(runtimeIDs))
+	for _, runtimeID := range runtimeIDs {
+		state, err := s.inventory.GetLatest(runtimeID)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func (s *Simulator) clusterPlan(state *cluster.State, kymaVersion string, estimator *estimation.Estimator) (*ClusterPlan, error) {
+	currentConfig := state.Configuration
+	clusterPlan := &ClusterPlan{
+		RuntimeID:      currentConfig.RuntimeID,
+		CurrentVersion: currentConfig.KymaVersion,
+	}
+
+	newConfig := *currentConfig
+	newConfig.KymaVersion = kymaVersion
+	newConfig.Components = append([]*keb.Component{}, currentConfig.Components...)
+	pins, err := s.inventory.ComponentPins(currentConfig.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := cluster.ApplyComponentPins(&newConfig, currentConfig, pins); err != nil {
+		clusterPlan.Error = err.Error()
+		return clusterPlan, nil
+	}
+
+	sequence := newConfig.GetSimulatedReconciliationSequence(&model.ReconciliationSequenceConfig{
+		PreComponents:        s.config.PreComponents,
+		BootstrapComponents:  s.config.BootstrapComponents,
+		ReconciliationStatus: state.Status.Status,
+	})
+	for idx, components := range sequence.Queue {
+		group := &Group{
+			Priority:  idx + 1,
+			Bootstrap: sequence.IsBootstrapGroup(idx),
+		}
+		for _, component := range components {
+			estimate, err := estimator.Component(component.Component)
+			if err != nil {
+				return nil, err
+			}
+			op := &Operation{
+				Component:         component.Component,
+				Version:           componentVersion(&newConfig, component.Version),
+				EstimatedDuration: estimate.Duration,
+				Historical:        estimate.Historical,
+			}
+			if currentComponent := currentConfig.GetComponent(component.Component); currentComponent != nil {
+				op.CurrentVersion = componentVersion(currentConfig, currentComponent.Version)
+			}
+			group.Operations = append(group.Operations, op)
+		}
+		sort.Slice(group.Operations, func(i, j int) bool {
+			return group.Operations[i].Component < group.Operations[j].Component
+		})
+		group.EstimatedDuration = s.groupDuration(group.Operations)
+		clusterPlan.Groups = append(clusterPlan.Groups, group)
+		clusterPlan.Operations += len(group.Operations)
+		clusterPlan.EstimatedDuration += group.EstimatedDuration
+	}
+	return clusterPlan, nil
+}
+
+// groupDuration distributes the operations (longest first) to the parallel slots of a cluster and returns the
+// duration of the slot which finishes last
+func (s *Simulator) groupDuration(ops []*Operation) time.Duration {
+	slots := len(ops)
+	if s.config.MaxParallelOperations > 0 && s.config.MaxParallelOperations < slots {
+		slots = s.config.MaxParallelOperations
+	}
+	if slots == 0 {
+		return 0
+	}
+	durations := make([]time.Duration, 0, len(ops))
+	for _, op := range ops {
+		durations = append(durations, op.EstimatedDuration)
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] > durations[j]
+	})
+
+	finished := make([]time.Duration, slots)
+	for _, duration := range durations {
+		next := 0
+		for slot := range finished {
+			if finished[slot] < finished[next] {
+				next = slot
+			}
+		}
+		finished[next] += duration
+	}
+	var result time.Duration
+	for _, slotFinished := range finished {
+		if slotFinished > result {
+			result = slotFinished
+		}
+	}
+	return result
+}
+
+// concurrency returns the maximal amount of workers occupied by the cluster at the same time
+func (s *Simulator) concurrency(clusterPlan *ClusterPlan) int {
+	var result int
+	for _, group := range clusterPlan.Groups {
+		if len(group.Operations) > result {
+			result = len(group.Operations)
+		}
+	}
+	if s.config.MaxParallelOperations > 0 && s.config.MaxParallelOperations < result {
+		result = s.config.MaxParallelOperations
+	}
+	return result
+}
+
+// batches groups the clusters which can be reconciled at the same time without exceeding the worker pool. A batch
+// starts after the previous batch is finished.
+func (s *Simulator) batches(clusterPlans []*ClusterPlan) []*Batch {
+	var batches []*Batch
+	var current *Batch
+	var occupiedWorkers int
+	for _, clusterPlan := range clusterPlans {
+		if clusterPlan.Error != "" || clusterPlan.Operations == 0 {
+			continue
+		}
+		concurrency := s.concurrency(clusterPlan)
+		if current == nil || (s.config.Workers > 0 && occupiedWorkers+concurrency > s.config.Workers) {
+			var start time.Duration
+			if current != nil {
+				start = current.Start + current.EstimatedDuration
+			}
+			current = &Batch{Start: start}
+			batches = append(batches, current)
+			occupiedWorkers = 0
+		}
+		current.RuntimeIDs = append(current.RuntimeIDs, clusterPlan.RuntimeID)
+		current.Operations += clusterPlan.Operations
+		if clusterPlan.EstimatedDuration > current.EstimatedDuration {
+			current.EstimatedDuration = clusterPlan.EstimatedDuration
+		}
+		occupiedWorkers += concurrency
+	}
+	return batches
+}
+
+// componentVersion returns the version a component gets installed with (the Kyma version if no version is defined)
+func componentVersion(config *model.ClusterConfigurationEntity, version string) string {
+	if version != "" {
+		return version
+	}
+	return config.KymaVersion
+}
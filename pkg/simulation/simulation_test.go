@@ -0,0 +1,121 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/cluster"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/stretchr/testify/require"
+)
+
+func newState(runtimeID string, components ...string) *cluster.State {
+	config := &model.ClusterConfigurationEntity{RuntimeID: runtimeID, KymaVersion: "1.0.0"}
+	for _, component := range components {
+		config.Components = append(config.Components, &keb.Component{Component: component})
+	}
+	return &cluster.State{
+		Cluster:       &model.ClusterEntity{RuntimeID: runtimeID},
+		Configuration: config,
+		Status:        &model.ClusterStatusEntity{RuntimeID: runtimeID, Status: model.ClusterStatusReady},
+	}
+}
+
+func TestSimulator(t *testing.T) {
+	now := time.Now()
+	reconRepo := &reconciliation.MockRepository{
+		GetOperationsResult: []*model.OperationEntity{ //every component operation took 2 minutes
+			{Created: now.Add(-10 * time.Minute), PickedUp: now.Add(-3 * time.Minute), Updated: now.Add(-1 * time.Minute)},
+		},
+	}
+	config := &Config{
+		PreComponents:         [][]string{{"istio"}},
+		MaxParallelOperations: 1,
+		Workers:               2,
+	}
+
+	t.Run("Operations are ordered and estimated", func(t *testing.T) {
+		inventory := &cluster.MockInventory{
+			GetAllResult: []*cluster.State{
+				newState("runtime2", "istio"),
+				newState("runtime1", "istio", "monitoring", "logging"),
+				newState("runtime3", "istio", "monitoring"),
+			},
+		}
+		plan, err := NewSimulator(inventory, reconRepo, config).Simulate(&Change{KymaVersion: "2.0.0"})
+		require.NoError(t, err)
+
+		require.Len(t, plan.Clusters, 3)
+		require.Equal(t, 9, plan.Operations)
+		clusterPlan := plan.Clusters[0]
+		require.Equal(t, "runtime1", clusterPlan.RuntimeID)
+		require.Equal(t, "1.0.0", clusterPlan.CurrentVersion)
+		require.Len(t, clusterPlan.Groups, 3)
+		require.Equal(t, model.CRDComponent, clusterPlan.Groups[0].Operations[0].Component)
+		require.Equal(t, "istio", clusterPlan.Groups[1].Operations[0].Component)
+		require.Equal(t, 3, clusterPlan.Groups[2].Priority)
+		require.Equal(t, "logging", clusterPlan.Groups[2].Operations[0].Component)
+		require.Equal(t, "monitoring", clusterPlan.Groups[2].Operations[1].Component)
+
+		op := clusterPlan.Groups[1].Operations[0]
+		require.Equal(t, "2.0.0", op.Version)
+		require.Equal(t, "1.0.0", op.CurrentVersion)
+		require.Equal(t, 2*time.Minute, op.EstimatedDuration)
+		require.True(t, op.Historical)
+		require.Equal(t, 4*time.Minute, clusterPlan.Groups[2].EstimatedDuration) //one operation at a time
+		require.Equal(t, 8*time.Minute, clusterPlan.EstimatedDuration)
+
+		//each cluster occupies one worker: the third cluster has to wait for the first batch
+		require.Len(t, plan.Batches, 2)
+		require.Equal(t, []string{"runtime1", "runtime2"}, plan.Batches[0].RuntimeIDs)
+		require.Equal(t, 8*time.Minute, plan.Batches[0].EstimatedDuration)
+		require.Equal(t, []string{"runtime3"}, plan.Batches[1].RuntimeIDs)
+		require.Equal(t, 8*time.Minute, plan.Batches[1].Start)
+		require.Equal(t, 14*time.Minute, plan.EstimatedDuration)
+	})
+
+	t.Run("Components without history are estimated with the default duration", func(t *testing.T) {
+		inventory := &cluster.MockInventory{GetLatestResult: newState("runtime1", "istio")}
+		plan, err := NewSimulator(inventory, &reconciliation.MockRepository{}, &Config{DefaultDuration: time.Minute}).
+			Simulate(&Change{KymaVersion: "2.0.0", RuntimeIDs: []string{"runtime1"}})
+		require.NoError(t, err)
+		require.Len(t, plan.Clusters, 1)
+		for _, group := range plan.Clusters[0].Groups {
+			require.Equal(t, time.Minute, group.EstimatedDuration)
+			require.False(t, group.Operations[0].Historical)
+		}
+		require.Equal(t, 2*time.Minute, plan.EstimatedDuration)
+	})
+
+	t.Run("Violated component pin is reported", func(t *testing.T) {
+		inventory := &cluster.MockInventory{
+			GetLatestResult:     newState("runtime1", "istio"),
+			ComponentPinsResult: []*model.ComponentPinEntity{{RuntimeID: "runtime1", Component: "istio", Version: "~3.0.0"}},
+		}
+		plan, err := NewSimulator(inventory, reconRepo, config).
+			Simulate(&Change{KymaVersion: "2.0.0", RuntimeIDs: []string{"runtime1"}})
+		require.NoError(t, err)
+		require.NotEmpty(t, plan.Clusters[0].Error)
+		require.Empty(t, plan.Batches)
+	})
+
+	t.Run("Kyma version is required", func(t *testing.T) {
+		_, err := NewSimulator(&cluster.MockInventory{}, reconRepo, config).Simulate(&Change{})
+		require.Error(t, err)
+	})
+}
+
+func TestGroupDuration(t *testing.T) {
+	ops := []*Operation{
+		{EstimatedDuration: 3 * time.Minute},
+		{EstimatedDuration: 2 * time.Minute},
+		{EstimatedDuration: 2 * time.Minute},
+		{EstimatedDuration: time.Minute},
+	}
+	require.Equal(t, 3*time.Minute, (&Simulator{config: &Config{}}).groupDuration(ops))
+	require.Equal(t, 4*time.Minute, (&Simulator{config: &Config{MaxParallelOperations: 2}}).groupDuration(ops))
+	require.Equal(t, 8*time.Minute, (&Simulator{config: &Config{MaxParallelOperations: 1}}).groupDuration(ops))
+	require.Zero(t, (&Simulator{config: &Config{}}).groupDuration(nil))
+}
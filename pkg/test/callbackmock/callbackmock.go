@@ -0,0 +1,304 @@
+// Package callbackmock provides a receiver for the callbacks of component reconcilers. It records the received
+// callbacks, exposes them via a REST API for assertions and can simulate latency and failures of the mothership.
+//
+// Callbacks are accepted at POST /callback/{id} (or /callback which uses the ID "default"). The recorded
+// callbacks are returned by GET /callbacks/{id} and GET /callbacks (all IDs) and deleted by DELETE /callbacks.
+// The behaviour is configured by GET/PUT /config.
+package callbackmock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultID                = "default"
+	defaultFailureStatusCode = http.StatusInternalServerError
+	paramID                  = "id"
+)
+
+// Config defines how the mock responds to callbacks
+type Config struct {
+	// Latency delays each response
+	Latency time.Duration
+	// FailureRate (between 0 and 1) of callbacks which are rejected
+	FailureRate float64
+	// FailNext rejects the next callbacks independently of the failure rate
+	FailNext int
+	// FailureStatusCode is returned for rejected callbacks (default: 500)
+	FailureStatusCode int
+}
+
+// configPayload is the representation of the config in the REST API
+type configPayload struct {
+	Latency           string  `json:"latency"`
+	FailureRate       float64 `json:"failureRate"`
+	FailNext          int     `json:"failNext"`
+	FailureStatusCode int     `json:"failureStatusCode"`
+}
+
+func (c Config) validate() error {
+	if c.FailureRate < 0 || c.FailureRate > 1 {
+		return fmt.Errorf("failure rate has to be between 0 and 1 but was %v", c.FailureRate)
+	}
+	if c.Latency < 0 || c.FailNext < 0 {
+		return fmt.Errorf("latency and number of failing callbacks cannot be negative")
+	}
+	return nil
+}
+
+// Server records the received callbacks
+type Server struct {
+	logger    *zap.SugaredLogger
+	mu        sync.Mutex
+	config    Config
+	rand      *rand.Rand
+	callbacks map[string][]*reconciler.CallbackMessage
+	notify    chan struct{}
+}
+
+func NewServer(config Config, logger *zap.SugaredLogger) (*Server, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	if logger == nil {
+		logger = zap.NewNop().Sugar()
+	}
+	return &Server{
+		logger:    logger,
+		config:    config,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec //no cryptographic use
+		callbacks: make(map[string][]*reconciler.CallbackMessage),
+		notify:    make(chan struct{}),
+	}, nil
+}
+
+// Router returns the routes of the REST API
+func (s *Server) Router() *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/callback", s.receive).Methods(http.MethodPost)
+	router.HandleFunc(fmt.Sprintf("/callback/{%s}", paramID), s.receive).Methods(http.MethodPost)
+	router.HandleFunc("/callbacks", s.listAll).Methods(http.MethodGet)
+	router.HandleFunc(fmt.Sprintf("/callbacks/{%s}", paramID), s.list).Methods(http.MethodGet)
+	router.HandleFunc("/callbacks", s.reset).Methods(http.MethodDelete)
+	router.HandleFunc("/config", s.getConfig).Methods(http.MethodGet)
+	router.HandleFunc("/config", s.updateConfig).Methods(http.MethodPut)
+	return router
+}
+
+// Start runs the server on the given port until the context gets closed
+func (s *Server) Start(ctx context.Context, port int) error {
+	return (&server.Webserver{
+		Logger: s.logger,
+		Port:   port,
+		Router: s.Router(),
+	}).Start(ctx)
+}
+
+// URL returns the callback URL for the ID of the server running at the base URL
+func URL(baseURL, id string) string {
+	return fmt.Sprintf("%s/callback/%s", baseURL, id)
+}
+
+// Callbacks returns the callbacks received for the ID
+func (s *Server) Callbacks(id string) []*reconciler.CallbackMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*reconciler.CallbackMessage{}, s.callbacks[id]...)
+}
+
+// Reset deletes all recorded callbacks
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks = make(map[string][]*reconciler.CallbackMessage)
+}
+
+// SetConfig changes the behaviour of the mock
+func (s *Server) SetConfig(config Config) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = config
+	return nil
+}
+
+// WaitForCallback blocks until a callback for the ID matches the condition and returns it
+func (s *Server) WaitForCallback(ctx context.Context, id string, condition func(msg *reconciler.CallbackMessage) bool) (*reconciler.CallbackMessage, error) {
+	for {
+		s.mu.Lock()
+		for _, msg := range s.callbacks[id] {
+			if condition(msg) {
+				s.mu.Unlock()
+				return msg, nil
+			}
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no matching callback received for ID '%s': %w", id, ctx.Err())
+		}
+	}
+}
+
+// WaitForFinalCallback blocks until a callback with status success or error was received for the ID
+func (s *Server) WaitForFinalCallback(ctx context.Context, id string) (*reconciler.CallbackMessage, error) {
+	return s.WaitForCallback(ctx, id, func(msg *reconciler.CallbackMessage) bool {
+		return msg.Status == reconciler.StatusSuccess || msg.Status == reconciler.StatusError ||
+			msg.Status == reconciler.StatusVerificationFailed
+	})
+}
+
+func (s *Server) receive(w http.ResponseWriter, r *http.Request) {
+	id, ok := mux.Vars(r)[paramID]
+	if !ok {
+		id = DefaultID
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("failed to read callback: %s", err),
+		})
+		return
+	}
+	msg := &reconciler.CallbackMessage{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("failed to unmarshal callback: %s", err),
+		})
+		return
+	}
+
+	latency, statusCode := s.respondWith()
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if statusCode != http.StatusOK {
+		s.logger.Infof("Callback mock rejects callback for ID '%s' with status code %d", id, statusCode)
+		server.SendHTTPError(w, statusCode, &keb.HTTPErrorResponse{
+			Error: "callback rejected by callback mock",
+		})
+		return
+	}
+
+	s.logger.Infof("Callback mock received callback for ID '%s': %s", id, string(body))
+	s.record(id, msg)
+	w.WriteHeader(http.StatusOK)
+}
+
+// respondWith returns the latency and status code for the next callback
+func (s *Server) respondWith() (time.Duration, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	failed := false
+	if s.config.FailNext > 0 {
+		s.config.FailNext--
+		failed = true
+	} else if s.config.FailureRate > 0 {
+		failed = s.rand.Float64() < s.config.FailureRate
+	}
+	if !failed {
+		return s.config.Latency, http.StatusOK
+	}
+	if s.config.FailureStatusCode == 0 {
+		return s.config.Latency, defaultFailureStatusCode
+	}
+	return s.config.Latency, s.config.FailureStatusCode
+}
+
+func (s *Server) record(id string, msg *reconciler.CallbackMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callbacks[id] = append(s.callbacks[id], msg)
+	close(s.notify) //wake up all waiting callers
+	s.notify = make(chan struct{})
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	s.sendJSON(w, s.Callbacks(mux.Vars(r)[paramID]))
+}
+
+func (s *Server) listAll(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	all := make(map[string][]*reconciler.CallbackMessage, len(s.callbacks))
+	for id, msgs := range s.callbacks {
+		all[id] = append([]*reconciler.CallbackMessage{}, msgs...)
+	}
+	s.mu.Unlock()
+	s.sendJSON(w, all)
+}
+
+func (s *Server) reset(w http.ResponseWriter, _ *http.Request) {
+	s.Reset()
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getConfig(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	config := s.config
+	s.mu.Unlock()
+	s.sendJSON(w, &configPayload{
+		Latency:           config.Latency.String(),
+		FailureRate:       config.FailureRate,
+		FailNext:          config.FailNext,
+		FailureStatusCode: config.FailureStatusCode,
+	})
+}
+
+func (s *Server) updateConfig(w http.ResponseWriter, r *http.Request) {
+	payload := &configPayload{}
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: fmt.Sprintf("failed to unmarshal config: %s", err),
+		})
+		return
+	}
+	config := Config{
+		FailureRate:       payload.FailureRate,
+		FailNext:          payload.FailNext,
+		FailureStatusCode: payload.FailureStatusCode,
+	}
+	if payload.Latency != "" {
+		var err error
+		if config.Latency, err = time.ParseDuration(payload.Latency); err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: fmt.Sprintf("invalid latency: %s", err),
+			})
+			return
+		}
+	}
+	if err := s.SetConfig(config); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{Error: err.Error()})
+		return
+	}
+	s.getConfig(w, r)
+}
+
+func (s *Server) sendJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.logger.Warnf("Callback mock failed to encode response: %s", err)
+	}
+}
@@ -0,0 +1,107 @@
+package callbackmock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	mock, err := NewServer(Config{}, nil)
+	require.NoError(t, err)
+	srv := httptest.NewServer(mock.Router())
+	defer srv.Close()
+
+	post := func(url string, status reconciler.Status) *http.Response {
+		payload, err := json.Marshal(&reconciler.CallbackMessage{Status: status})
+		require.NoError(t, err)
+		resp, err := http.Post(url, "application/json", bytes.NewReader(payload))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	t.Run("Record callbacks", func(t *testing.T) {
+		defer mock.Reset()
+		require.Equal(t, http.StatusOK, post(URL(srv.URL, "abc"), reconciler.StatusRunning).StatusCode)
+		require.Equal(t, http.StatusOK, post(srv.URL+"/callback", reconciler.StatusSuccess).StatusCode)
+
+		require.Len(t, mock.Callbacks("abc"), 1)
+		require.Len(t, mock.Callbacks(DefaultID), 1)
+
+		resp, err := http.Get(srv.URL + "/callbacks/abc")
+		require.NoError(t, err)
+		defer func() { require.NoError(t, resp.Body.Close()) }()
+		var callbacks []*reconciler.CallbackMessage
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&callbacks))
+		require.Len(t, callbacks, 1)
+		require.Equal(t, reconciler.StatusRunning, callbacks[0].Status)
+
+		req, err := http.NewRequest(http.MethodDelete, srv.URL+"/callbacks", nil)
+		require.NoError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Empty(t, mock.Callbacks("abc"))
+	})
+
+	t.Run("Wait for callback", func(t *testing.T) {
+		defer mock.Reset()
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			post(URL(srv.URL, "wait"), reconciler.StatusRunning)
+			post(URL(srv.URL, "wait"), reconciler.StatusError)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		msg, err := mock.WaitForFinalCallback(ctx, "wait")
+		require.NoError(t, err)
+		require.Equal(t, reconciler.StatusError, msg.Status)
+
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = mock.WaitForFinalCallback(ctx, "missing")
+		require.Error(t, err)
+	})
+
+	t.Run("Simulate failures", func(t *testing.T) {
+		defer mock.Reset()
+		require.NoError(t, mock.SetConfig(Config{FailNext: 1, FailureStatusCode: http.StatusBadGateway}))
+		require.Equal(t, http.StatusBadGateway, post(URL(srv.URL, "fail"), reconciler.StatusRunning).StatusCode)
+		require.Equal(t, http.StatusOK, post(URL(srv.URL, "fail"), reconciler.StatusRunning).StatusCode)
+		require.Len(t, mock.Callbacks("fail"), 1)
+
+		require.NoError(t, mock.SetConfig(Config{FailureRate: 1}))
+		require.Equal(t, http.StatusInternalServerError, post(URL(srv.URL, "fail"), reconciler.StatusRunning).StatusCode)
+		require.Error(t, mock.SetConfig(Config{FailureRate: 2}))
+	})
+
+	t.Run("Configure via API", func(t *testing.T) {
+		defer func() { require.NoError(t, mock.SetConfig(Config{})) }()
+		req, err := http.NewRequest(http.MethodPut, srv.URL+"/config", strings.NewReader(`{"latency": "100ms"}`))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		start := time.Now()
+		require.Equal(t, http.StatusOK, post(URL(srv.URL, "slow"), reconciler.StatusRunning).StatusCode)
+		require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+		req, err = http.NewRequest(http.MethodPut, srv.URL+"/config", strings.NewReader(`{"latency": "abc"}`))
+		require.NoError(t, err)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}